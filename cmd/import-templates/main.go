@@ -0,0 +1,259 @@
+// Package main provides a CLI tool to import questionnaire templates from a directory of JSON files.
+// Usage: go run cmd/import-templates/main.go -dir ./templates
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// importFile is a template file that passed validation
+type importFile struct {
+	path     string
+	template *models.QuestionnaireTemplate
+}
+
+func main() {
+	// Define command line flags
+	dir := flag.String("dir", "", "Directory containing template JSON files (required)")
+	envFile := flag.String("env", "", "Path to .env file (defaults to .env in current dir or backend dir)")
+	dryRun := flag.Bool("dry-run", false, "Report what would be created or updated without writing to database")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Imports questionnaire templates from *.json files as global system templates.\n")
+		fmt.Fprintf(os.Stderr, "Templates are matched by name and version: new ones are created, existing ones updated.\n\n")
+		fmt.Fprintf(os.Stderr, "Configuration is loaded from .env file and/or environment variables.\n")
+		fmt.Fprintf(os.Stderr, "Environment variables take precedence over .env file values.\n\n")
+		fmt.Fprintf(os.Stderr, "Required config (via .env or environment):\n")
+		fmt.Fprintf(os.Stderr, "  NISFIX_DATABASE_URI   MongoDB connection URI\n")
+		fmt.Fprintf(os.Stderr, "  NISFIX_DATABASE_NAME  Database name (default: nisfix)\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  %s -dir ./templates\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -dir ./templates -dry-run\n", os.Args[0])
+	}
+
+	flag.Parse()
+
+	// Load .env file
+	loadEnvFile(*envFile)
+
+	// Validate required flags
+	if *dir == "" {
+		log.Fatal("Error: -dir is required")
+	}
+
+	paths, err := filepath.Glob(filepath.Join(*dir, "*.json"))
+	if err != nil {
+		log.Fatalf("Error: invalid directory: %v", err)
+	}
+	if len(paths) == 0 {
+		log.Fatalf("Error: no *.json files found in %s", *dir)
+	}
+	sort.Strings(paths)
+
+	// Validate every file before touching the database
+	files, failed := parseTemplateFiles(paths)
+
+	// Load database configuration from environment
+	dbURI := os.Getenv("NISFIX_DATABASE_URI")
+	if dbURI == "" {
+		log.Fatal("Error: NISFIX_DATABASE_URI environment variable is required")
+	}
+	dbName := os.Getenv("NISFIX_DATABASE_NAME")
+	if dbName == "" {
+		dbName = "nisfix"
+	}
+
+	// Connect to MongoDB
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	clientOpts := options.Client().ApplyURI(dbURI)
+	client, err := mongo.Connect(ctx, clientOpts)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	defer func() {
+		if disconnectErr := client.Disconnect(ctx); disconnectErr != nil {
+			log.Printf("Error disconnecting from MongoDB: %v", disconnectErr)
+		}
+	}()
+
+	// Ping database
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatalf("Failed to ping MongoDB: %v", err)
+	}
+
+	collection := client.Database(dbName).Collection(models.QuestionnaireTemplate{}.CollectionName())
+
+	var created, updated, unchanged int
+	for _, f := range files {
+		action, err := upsertTemplate(ctx, collection, f.template, *dryRun)
+		if err != nil {
+			fmt.Printf("✗ %s: %v\n", filepath.Base(f.path), err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ %s: %s %q (version %s)\n", filepath.Base(f.path), action, f.template.Name, f.template.Version)
+		switch action {
+		case "create":
+			created++
+		case "update":
+			updated++
+		default:
+			unchanged++
+		}
+	}
+
+	fmt.Println()
+	if *dryRun {
+		fmt.Println("[DRY RUN] No changes made to database")
+	}
+	fmt.Printf("Templates: %d created, %d updated, %d unchanged, %d failed\n", created, updated, unchanged, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// parseTemplateFiles validates each file against the template import schema
+// #BUSINESS_RULE: Invalid files and repeated name+version pairs are reported and skipped; the
+// remaining files are still imported
+func parseTemplateFiles(paths []string) ([]importFile, int) {
+	var files []importFile
+	failed := 0
+	seen := make(map[string]string)
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Printf("✗ %s: %v\n", filepath.Base(path), err)
+			failed++
+			continue
+		}
+
+		template, err := services.ParseTemplateImport(content)
+		if err != nil {
+			fmt.Printf("✗ %s: %v\n", filepath.Base(path), err)
+			failed++
+			continue
+		}
+
+		// System templates are global; BeforeCreate fills in version and other defaults
+		template.IsSystem = true
+		template.Visibility = models.TemplateVisibilityGlobal
+		template.BeforeCreate()
+
+		key := template.Name + "\x00" + template.Version
+		if other, ok := seen[key]; ok {
+			fmt.Printf("✗ %s: %q version %s is already defined in %s\n", filepath.Base(path), template.Name, template.Version, filepath.Base(other))
+			failed++
+			continue
+		}
+		seen[key] = path
+
+		files = append(files, importFile{path: path, template: template})
+	}
+
+	return files, failed
+}
+
+// upsertTemplate creates the template or updates the system template with the same name and version
+// #DATA_ASSUMPTION: Topics keep their stored IDs when matched by name, so questions that refer to
+// a topic survive re-imports of files that do not pin topic IDs
+func upsertTemplate(ctx context.Context, collection *mongo.Collection, template *models.QuestionnaireTemplate, dryRun bool) (string, error) {
+	filter := bson.M{"name": template.Name, "version": template.Version, "is_system": true}
+
+	var existing models.QuestionnaireTemplate
+	err := collection.FindOne(ctx, filter).Decode(&existing)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if !dryRun {
+			if _, err := collection.InsertOne(ctx, template); err != nil {
+				return "", fmt.Errorf("failed to create template: %w", err)
+			}
+		}
+		return "create", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to look up template: %w", err)
+	}
+
+	existingTopicIDs := make(map[string]string, len(existing.Topics))
+	for _, topic := range existing.Topics {
+		existingTopicIDs[topic.Name] = topic.ID
+	}
+	for i := range template.Topics {
+		if id, ok := existingTopicIDs[template.Topics[i].Name]; ok {
+			template.Topics[i].ID = id
+		}
+	}
+
+	if sameTemplateContent(&existing, template) {
+		return "unchanged", nil
+	}
+
+	if !dryRun {
+		update := bson.M{"$set": bson.M{
+			"description":           template.Description,
+			"category":              template.Category,
+			"visibility":            template.Visibility,
+			"default_passing_score": template.DefaultPassingScore,
+			"estimated_minutes":     template.EstimatedMinutes,
+			"topics":                template.Topics,
+			"tags":                  template.Tags,
+			"updated_at":            time.Now().UTC(),
+		}}
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": existing.ID}, update); err != nil {
+			return "", fmt.Errorf("failed to update template: %w", err)
+		}
+	}
+	return "update", nil
+}
+
+// sameTemplateContent reports whether an import would leave the stored template as it is
+func sameTemplateContent(existing, template *models.QuestionnaireTemplate) bool {
+	return existing.Description == template.Description &&
+		existing.Category == template.Category &&
+		existing.Visibility == template.Visibility &&
+		existing.DefaultPassingScore == template.DefaultPassingScore &&
+		existing.EstimatedMinutes == template.EstimatedMinutes &&
+		reflect.DeepEqual(existing.Topics, template.Topics) &&
+		reflect.DeepEqual(existing.Tags, template.Tags)
+}
+
+// loadEnvFile loads environment variables from a .env file
+func loadEnvFile(path string) {
+	if path == "" {
+		// Try to find .env in current dir or backend dir
+		cwd, _ := os.Getwd()
+		if _, err := os.Stat(filepath.Join(cwd, ".env")); err == nil {
+			path = ".env"
+		} else if _, err := os.Stat(filepath.Join(cwd, "backend", ".env")); err == nil {
+			path = filepath.Join(cwd, "backend", ".env")
+		}
+	}
+
+	if path != "" {
+		if err := godotenv.Load(path); err != nil {
+			log.Printf("Error loading .env file: %v", err)
+		}
+	}
+}
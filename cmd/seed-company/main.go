@@ -62,6 +62,7 @@ func main() {
 	}
 
 	// Validate email format
+	*email = models.NormalizeEmail(*email)
 	if !isValidEmail(*email) {
 		log.Fatalf("Error: invalid email format: %s", *email)
 	}
@@ -30,6 +30,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/checkfix-tools/nisfix_backend/internal/auth"
 	"github.com/checkfix-tools/nisfix_backend/internal/config"
@@ -37,7 +38,9 @@ import (
 	"github.com/checkfix-tools/nisfix_backend/internal/handlers"
 	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
 	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+	"github.com/checkfix-tools/nisfix_backend/internal/scheduler"
 	"github.com/checkfix-tools/nisfix_backend/internal/services"
+	"github.com/checkfix-tools/nisfix_backend/internal/storage"
 
 	// Swagger docs
 	swaggerFiles "github.com/swaggo/files"
@@ -60,6 +63,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("%v", err)
+	}
 
 	// Set Gin mode based on environment
 	if cfg.IsProduction() {
@@ -76,6 +82,10 @@ func main() {
 		MaxConnIdleTime:        30 * time.Minute,
 		ConnectTimeout:         10 * time.Second,
 		ServerSelectionTimeout: 10 * time.Second,
+		ConnectAttempts:        cfg.DatabaseConnectAttempts,
+		ConnectRetryDelay:      cfg.DatabaseConnectRetryDelay,
+		ConnectRetryTimeout:    cfg.DatabaseConnectRetryTimeout,
+		HealthCheckInterval:    cfg.DatabaseHealthCheckInterval,
 	}
 
 	dbClient, err := database.NewClient(dbCfg)
@@ -100,6 +110,14 @@ func main() {
 		log.Fatalf("Failed to initialize JWT service: %v", err)
 	}
 
+	fileStorage, err := storage.NewLocalFileStorage(cfg.FileStorageDir)
+	if err != nil {
+		if closeErr := dbClient.Close(ctx); closeErr != nil {
+			log.Printf("Error closing database connection: %v", closeErr)
+		}
+		log.Fatalf("Failed to initialize file storage: %v", err)
+	}
+
 	defer func() {
 		if closeErr := dbClient.Close(ctx); closeErr != nil {
 			log.Printf("Error closing database connection: %v", closeErr)
@@ -112,6 +130,12 @@ func main() {
 		log.Printf("Warning: Failed to create indexes: %v", indexErr)
 	}
 
+	// Apply pending data migrations
+	log.Println("Running data migrations...")
+	if migrateErr := dbClient.RunMigrations(ctx); migrateErr != nil {
+		log.Printf("Warning: Failed to run migrations: %v", migrateErr)
+	}
+
 	// Seed initial data (questionnaire templates)
 	log.Println("Seeding initial data...")
 	if seedErr := dbClient.SeedData(ctx); seedErr != nil {
@@ -122,6 +146,9 @@ func main() {
 	userRepo := repository.NewUserRepository(dbClient)
 	orgRepo := repository.NewOrganizationRepository(dbClient)
 	secureLinkRepo := repository.NewSecureLinkRepository(dbClient)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(dbClient)
+	revokedTokenRepo := repository.NewRevokedTokenRepository(dbClient)
+	apiKeyRepo := repository.NewAPIKeyRepository(dbClient)
 	relationshipRepo := repository.NewRelationshipRepository(dbClient)
 	questionnaireRepo := repository.NewQuestionnaireRepository(dbClient)
 	templateRepo := repository.NewQuestionnaireTemplateRepository(dbClient)
@@ -130,20 +157,45 @@ func main() {
 	responseRepo := repository.NewResponseRepository(dbClient)
 	submissionRepo := repository.NewSubmissionRepository(dbClient)
 	verificationRepo := repository.NewVerificationRepository(dbClient)
+	auditRepo := repository.NewAuditRepository(dbClient)
+	webhookEventRepo := repository.NewWebhookEventRepository(dbClient)
+	webhookRepo := repository.NewWebhookRepository(dbClient)
+	outboxRepo := repository.NewOutboxRepository(dbClient)
+	sentEmailRepo := repository.NewSentEmailRepository(dbClient)
+	idempotencyRepo := repository.NewIdempotencyRepository(dbClient)
 
 	// Initialize mail service (always use HTTP service)
-	mailService := services.NewHTTPMailService(&cfg.Mail)
+	mailTemplates, err := services.LoadMailTemplates(services.EmbeddedMailTemplates)
+	if err != nil {
+		log.Fatalf("Failed to load mail templates: %v", err)
+	}
+	mailService := services.NewHTTPMailService(&cfg.Mail, mailTemplates, sentEmailRepo)
+
+	// Initialize email outbox for emails that must survive mail API outages
+	emailOutboxService := services.NewEmailOutboxService(outboxRepo, sentEmailRepo, mailService)
+
+	// Initialize audit service
+	auditService := services.NewAuditService(auditRepo)
+
+	// Initialize outbound webhook service
+	webhookService := services.NewWebhookService(webhookRepo)
 
 	// Initialize auth service
 	authServiceCfg := services.AuthServiceConfig{
 		MagicLinkBaseURL:    cfg.MagicLinkBaseURL,
 		RateLimitCount:      5,
 		RateLimitWindowMins: 15,
+		AccessTokenExpiry:   cfg.AccessTokenExpiry,
+		OIDCAutoProvision:   cfg.OIDC.AutoProvision,
+
+		PasswordLoginEnabled: cfg.PasswordLoginEnabled,
 	}
 	authService := services.NewAuthService(
 		userRepo,
 		orgRepo,
 		secureLinkRepo,
+		refreshTokenRepo,
+		revokedTokenRepo,
 		jwtService,
 		mailService,
 		authServiceCfg,
@@ -152,27 +204,55 @@ func main() {
 	// Initialize relationship service
 	relationshipService := services.NewRelationshipService(
 		relationshipRepo,
+		requirementRepo,
 		orgRepo,
 		userRepo,
+		emailOutboxService,
+		auditService,
+		webhookService,
+		authService,
+		cfg.MagicLinkBaseURL,
+	)
+
+	// Initialize user service
+	userService := services.NewUserService(
+		userRepo,
+		orgRepo,
+		secureLinkRepo,
+		authService,
 		mailService,
+		emailOutboxService,
 		cfg.MagicLinkBaseURL,
 	)
 
+	// Initialize API key service
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, orgRepo)
+
 	// Initialize questionnaire service
 	questionnaireService := services.NewQuestionnaireService(
 		questionnaireRepo,
 		templateRepo,
 		questionRepo,
+		submissionRepo,
+		orgRepo,
 	)
 
+	// Initialize organization service
+	organizationService := services.NewOrganizationService(orgRepo, fileStorage, auditService)
+
 	// Initialize template service
-	templateService := services.NewTemplateService(templateRepo)
+	templateService := services.NewTemplateService(templateRepo, auditService)
 
 	// Initialize requirement service
 	requirementService := services.NewRequirementService(
 		requirementRepo,
 		relationshipRepo,
 		questionnaireRepo,
+		responseRepo,
+		orgRepo,
+		userRepo,
+		auditService,
+		fileStorage,
 	)
 
 	// Initialize response service
@@ -182,15 +262,30 @@ func main() {
 		requirementRepo,
 		questionnaireRepo,
 		questionRepo,
+		orgRepo,
+		webhookService,
 	)
 
+	// Initialize dashboard service
+	dashboardService := services.NewDashboardService(relationshipRepo, requirementRepo)
+
+	// Initialize search service
+	searchService := services.NewSearchService(relationshipRepo, orgRepo, requirementRepo, questionnaireRepo)
+
 	// Initialize review service
 	reviewService := services.NewReviewService(
 		requirementRepo,
 		responseRepo,
 		submissionRepo,
+		questionRepo,
+		questionnaireRepo,
+		orgRepo,
+		webhookService,
 	)
 
+	// Initialize clarification service for per-question supplier/company threads
+	clarificationService := services.NewClarificationService(responseRepo, requirementRepo, relationshipRepo, questionRepo)
+
 	// Initialize CheckFix API client
 	// #IMPLEMENTATION_DECISION: Use mock in development, HTTP client in production
 	var checkFixAPIClient services.CheckFixAPIClient
@@ -198,7 +293,7 @@ func main() {
 		log.Println("Using mock CheckFix API client in development mode")
 		checkFixAPIClient = services.NewMockCheckFixAPIClient()
 	} else {
-		checkFixAPIClient = services.NewHTTPCheckFixAPIClient(cfg.CheckFixAPIURL, cfg.CheckFixAPIKey)
+		checkFixAPIClient = services.NewHTTPCheckFixAPIClient(cfg.CheckFixAPIURL, cfg.CheckFixAPIKey, services.DefaultCheckFixRetryConfig(), cfg.CheckFixCallTimeout)
 	}
 
 	// Initialize CheckFix service
@@ -207,42 +302,117 @@ func main() {
 		verificationRepo,
 		responseRepo,
 		requirementRepo,
+		relationshipRepo,
 		orgRepo,
+		userRepo,
+		webhookEventRepo,
+		emailOutboxService,
+		services.NewMemoryCache(),
+		cfg.CheckFixReportCacheTTL,
+		webhookService,
 	)
 
 	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(authService)
+	// Initialize optional OIDC single sign-on
+	var oidcProvider *auth.OIDCProvider
+	if cfg.OIDC.Enabled() {
+		oidcProvider, err = auth.NewOIDCProvider(ctx, auth.OIDCConfig{
+			IssuerURL:    cfg.OIDC.IssuerURL,
+			ClientID:     cfg.OIDC.ClientID,
+			ClientSecret: cfg.OIDC.ClientSecret,
+			RedirectURL:  cfg.OIDC.RedirectURL,
+		})
+		if err != nil {
+			log.Printf("Warning: OIDC login disabled: %v", err)
+			oidcProvider = nil
+		}
+	}
+
+	authRateLimiter := middleware.NewRateLimiter(cfg.AuthRateLimitPerMinute, time.Minute)
+	authHandler := handlers.NewAuthHandler(authService, oidcProvider, authRateLimiter.RateLimit(), middleware.NewMemoryRateLimitStore())
 	healthHandler := handlers.NewHealthHandler(dbClient, Version)
-	relationshipHandler := handlers.NewRelationshipHandler(relationshipService)
-	questionnaireHandler := handlers.NewQuestionnaireHandler(questionnaireService)
+	healthHandler.SetBuildInfo(handlers.BuildInfo{
+		BuildTime: BuildTime,
+		GitCommit: GitCommit,
+		GitBranch: GitBranch,
+	})
+	if !cfg.IsDevelopment() && cfg.CheckFixAPIURL != "" {
+		healthHandler.AddDependency("checkfix_api", checkFixAPIClient.Ping)
+	}
+	idempotency := middleware.Idempotency(idempotencyRepo, cfg.IdempotencyKeyTTL)
+	// Supplier login links share the magic link budget; keys are per route, so the budgets stay separate
+	relationshipHandler := handlers.NewRelationshipHandler(relationshipService, orgRepo, idempotency, authRateLimiter.RateLimit())
+	questionnaireHandler := handlers.NewQuestionnaireHandler(questionnaireService, orgRepo, idempotency)
 	templateHandler := handlers.NewTemplateHandler(templateRepo, templateService)
-	requirementHandler := handlers.NewRequirementHandler(requirementService)
-	supplierPortalHandler := handlers.NewSupplierPortalHandler(relationshipRepo, requirementRepo, responseService)
+	requirementHandler := handlers.NewRequirementHandler(requirementService, orgRepo, idempotency)
+	dashboardHandler := handlers.NewDashboardHandler(dashboardService, orgRepo)
+	searchHandler := handlers.NewSearchHandler(searchService, orgRepo)
+	supplierPortalHandler := handlers.NewSupplierPortalHandler(relationshipRepo, requirementRepo, orgRepo, userRepo, responseService, requirementService)
 	reviewHandler := handlers.NewReviewHandler(reviewService)
+	clarificationHandler := handlers.NewClarificationHandler(clarificationService)
 	checkFixHandler := handlers.NewCheckFixHandler(checkFixService)
-	organizationHandler := handlers.NewOrganizationHandler(orgRepo)
+	checkFixWebhookHandler := handlers.NewCheckFixWebhookHandler(checkFixService, cfg.CheckFixWebhookSecret)
+	organizationHandler := handlers.NewOrganizationHandler(orgRepo, organizationService)
+	userHandler := handlers.NewUserHandler(userService)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	emailOutboxHandler := handlers.NewEmailOutboxHandler(emailOutboxService)
 
 	// Create Gin router
 	router := gin.New()
 
+	// Only honour X-Forwarded-For from configured proxies
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("Invalid trusted proxies: %v", err)
+	}
+
 	// Apply global middleware
 	router.Use(middleware.Recovery())
 	router.Use(middleware.RequestID())
-	router.Use(middleware.Logger())
-	router.Use(middleware.CORS(cfg.AllowedOrigins))
+	router.Use(middleware.Metrics())
+	requestLogger := middleware.NewRequestLogger(cfg.LogFormat, os.Stdout)
+	router.Use(middleware.Logger(requestLogger))
+	router.Use(middleware.CORS(middleware.CORSConfig{
+		AllowedOrigins:   cfg.AllowedOrigins,
+		AllowedMethods:   cfg.CORSAllowedMethods,
+		AllowedHeaders:   cfg.CORSAllowedHeaders,
+		ExposedHeaders:   cfg.CORSExposedHeaders,
+		AllowCredentials: cfg.CORSAllowCredentials,
+		MaxAge:           cfg.CORSMaxAge,
+	}))
 	router.Use(middleware.SecureHeaders())
+	router.Use(middleware.MaxBodySizeByRoute(cfg.MaxBodySize, map[string]int64{
+		"/api/v1/templates/import":                    cfg.MaxImportBodySize,
+		"/api/v1/questionnaires/:id/questions/import": cfg.MaxImportBodySize,
+		// Leaves room for the multipart envelope around the file itself
+		"/api/v1/requirements/:id/attachments": services.MaxAttachmentSize + 1<<20,
+		"/api/v1/organization/logo":            services.MaxLogoSize + 1<<20,
+	}))
 
 	// Register health routes (not under /api/v1)
 	healthHandler.RegisterRoutes(router)
 
+	// Register Prometheus metrics route (not under /api/v1)
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
 	// Register Swagger documentation route
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// Create API v1 group
 	apiV1 := router.Group("/api/v1")
 
+	// Per-organization budget for authenticated requests
+	// #TECHNICAL_DEBT: In-memory counters are per instance; swap in a shared store when scaling out
+	if cfg.OrgRateLimitPerMinute > 0 {
+		apiV1.Use(middleware.OrgRateLimit(middleware.NewMemoryRateLimitStore(), jwtService, cfg.OrgRateLimitPerMinute, requestLogger))
+	}
+
 	// Create auth middleware
-	authMiddleware := middleware.AuthMiddleware(jwtService)
+	authMiddleware := middleware.AuthMiddleware(jwtService, revokedTokenRepo)
+
+	// Auth middleware that additionally accepts scoped API keys (machine-to-machine routes only)
+	apiKeyAuthMiddleware := middleware.APIKeyAuth(apiKeyService, authMiddleware)
 
 	// Register routes
 	authHandler.RegisterRoutes(apiV1, authMiddleware)
@@ -250,10 +420,23 @@ func main() {
 	questionnaireHandler.RegisterRoutes(apiV1, authMiddleware)
 	templateHandler.RegisterRoutes(apiV1, authMiddleware)
 	requirementHandler.RegisterRoutes(apiV1, authMiddleware)
+	dashboardHandler.RegisterRoutes(apiV1, authMiddleware)
+	searchHandler.RegisterRoutes(apiV1, authMiddleware)
 	supplierPortalHandler.RegisterRoutes(apiV1, authMiddleware)
 	reviewHandler.RegisterRoutes(apiV1, authMiddleware)
-	checkFixHandler.RegisterRoutes(apiV1, authMiddleware)
+	clarificationHandler.RegisterRoutes(apiV1, authMiddleware)
+	checkFixHandler.RegisterRoutes(apiV1, apiKeyAuthMiddleware)
+	if cfg.CheckFixWebhookSecret != "" {
+		checkFixWebhookHandler.RegisterRoutes(apiV1)
+	} else {
+		log.Println("CheckFix webhook disabled: no webhook secret configured")
+	}
 	organizationHandler.RegisterRoutes(apiV1, authMiddleware)
+	userHandler.RegisterRoutes(apiV1, authMiddleware)
+	apiKeyHandler.RegisterRoutes(apiV1, authMiddleware)
+	webhookHandler.RegisterRoutes(apiV1, authMiddleware)
+	emailOutboxHandler.RegisterRoutes(apiV1, authMiddleware)
+	auditHandler.RegisterRoutes(apiV1, authMiddleware)
 
 	// Create HTTP server
 	server := &http.Server{
@@ -264,6 +447,40 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Start background jobs
+	jobs := scheduler.New()
+	jobs.Every("checkfix-refresh", cfg.CheckFixRefreshInterval, func(ctx context.Context) error {
+		summary, err := checkFixService.RefreshExpiringVerifications(ctx, cfg.CheckFixRefreshDaysBeforeExpiry)
+		if err != nil {
+			return err
+		}
+		log.Printf("CheckFix refresh: checked=%d refreshed=%d invalidated=%d failed=%d notified=%d",
+			summary.Checked, summary.Refreshed, summary.Invalidated, summary.Failed, summary.Notified)
+		return nil
+	})
+	jobs.Every("email-outbox", cfg.EmailOutboxInterval, func(ctx context.Context) error {
+		summary, err := emailOutboxService.ProcessDue(ctx)
+		if err != nil {
+			return err
+		}
+		if summary.Sent+summary.Retried+summary.Failed > 0 {
+			log.Printf("Email outbox: sent=%d retried=%d failed=%d", summary.Sent, summary.Retried, summary.Failed)
+		}
+		return nil
+	})
+	jobs.Every("questionnaire-purge", cfg.QuestionnairePurgeInterval, func(ctx context.Context) error {
+		purged, err := questionnaireService.PurgeDeletedQuestionnaires(ctx, cfg.QuestionnaireRetentionDays)
+		if purged > 0 {
+			log.Printf("Questionnaire purge: purged=%d", purged)
+		}
+		return err
+	})
+	jobs.Start(ctx)
+
+	// Log MongoDB connection loss and recovery
+	monitorCtx, stopMonitor := context.WithCancel(ctx)
+	go dbClient.MonitorHealth(monitorCtx)
+
 	// Start server in goroutine
 	go func() {
 		log.Printf("Starting NisFix Backend API server v%s on port %s", Version, cfg.ServerPort)
@@ -281,6 +498,10 @@ func main() {
 
 	log.Println("Shutting down server...")
 
+	// Stop background jobs before the database connection is closed
+	jobs.Stop()
+	stopMonitor()
+
 	// Create shutdown context with timeout
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -290,5 +511,8 @@ func main() {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
 
+	// Abandon webhook retries once no request can dispatch new events
+	webhookService.Close()
+
 	log.Println("Server shutdown complete")
 }
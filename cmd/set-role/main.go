@@ -0,0 +1,170 @@
+// Package main provides a CLI tool to change the role of an existing user.
+// Usage: go run cmd/set-role/main.go -email "user@company.com" -role admin
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+func main() {
+	// Define command line flags
+	email := flag.String("email", "", "Email of the user to update (required)")
+	role := flag.String("role", "", "New role: admin or viewer (required)")
+	envFile := flag.String("env", "", "Path to .env file (defaults to .env in current dir or backend dir)")
+	dryRun := flag.Bool("dry-run", false, "Print the role change without writing to database")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Changes the role of an existing user in the NisFix database.\n")
+		fmt.Fprintf(os.Stderr, "The last active admin of an organization cannot be demoted.\n\n")
+		fmt.Fprintf(os.Stderr, "Configuration is loaded from .env file and/or environment variables.\n")
+		fmt.Fprintf(os.Stderr, "Environment variables take precedence over .env file values.\n\n")
+		fmt.Fprintf(os.Stderr, "Required config (via .env or environment):\n")
+		fmt.Fprintf(os.Stderr, "  NISFIX_DATABASE_URI   MongoDB connection URI\n")
+		fmt.Fprintf(os.Stderr, "  NISFIX_DATABASE_NAME  Database name (default: nisfix)\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  %s -email \"user@acme.com\" -role admin\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -email \"user@acme.com\" -role viewer -dry-run\n", os.Args[0])
+	}
+
+	flag.Parse()
+
+	// Load .env file
+	loadEnvFile(*envFile)
+
+	// Validate required flags
+	if *email == "" {
+		log.Fatal("Error: -email is required")
+	}
+	if *role == "" {
+		log.Fatal("Error: -role is required")
+	}
+	*email = models.NormalizeEmail(*email)
+
+	newRole := models.UserRole(strings.ToUpper(strings.TrimSpace(*role)))
+	if !newRole.IsValid() {
+		log.Fatalf("Error: invalid role '%s' (must be admin or viewer)", *role)
+	}
+
+	// Load database configuration from environment
+	dbURI := os.Getenv("NISFIX_DATABASE_URI")
+	if dbURI == "" {
+		log.Fatal("Error: NISFIX_DATABASE_URI environment variable is required")
+	}
+	dbName := os.Getenv("NISFIX_DATABASE_NAME")
+	if dbName == "" {
+		dbName = "nisfix"
+	}
+
+	// Connect to MongoDB
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	clientOpts := options.Client().ApplyURI(dbURI)
+	client, err := mongo.Connect(ctx, clientOpts)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	defer func() {
+		if disconnectErr := client.Disconnect(ctx); disconnectErr != nil {
+			log.Printf("Error disconnecting from MongoDB: %v", disconnectErr)
+		}
+	}()
+
+	// Ping database
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatalf("Failed to ping MongoDB: %v", err)
+	}
+
+	db := client.Database(dbName)
+
+	// Find user by email
+	userCollection := db.Collection(models.User{}.CollectionName())
+	var user models.User
+	err = userCollection.FindOne(ctx, bson.M{
+		"email":      *email,
+		"deleted_at": nil,
+	}).Decode(&user)
+	if err == mongo.ErrNoDocuments {
+		log.Fatalf("Error: no user found with email '%s'", *email)
+	} else if err != nil {
+		log.Fatalf("Error finding user: %v", err)
+	}
+
+	fmt.Println("=== User ===")
+	fmt.Printf("  ID:              %s\n", user.ID.Hex())
+	fmt.Printf("  Email:           %s\n", user.Email)
+	fmt.Printf("  Organization ID: %s\n", user.OrganizationID.Hex())
+	fmt.Printf("  Role:            %s -> %s\n", user.Role, newRole)
+	fmt.Println()
+
+	if user.Role == newRole {
+		fmt.Printf("User already has role %s; nothing to do\n", newRole)
+		return
+	}
+
+	// #BUSINESS_RULE: Mirrors the API guard - an organization keeps at least one active admin
+	if user.Role == models.UserRoleAdmin && user.IsActive {
+		admins, err := userCollection.CountDocuments(ctx, bson.M{
+			"organization_id": user.OrganizationID,
+			"role":            models.UserRoleAdmin,
+			"deleted_at":      nil,
+			"is_active":       true,
+		})
+		if err != nil {
+			log.Fatalf("Error counting organization admins: %v", err)
+		}
+		if admins <= 1 {
+			log.Fatalf("Error: '%s' is the last admin of organization %s and cannot be demoted", user.Email, user.OrganizationID.Hex())
+		}
+	}
+
+	if *dryRun {
+		fmt.Println("[DRY RUN] No changes made to database")
+		return
+	}
+
+	_, err = userCollection.UpdateOne(ctx,
+		bson.M{"_id": user.ID, "deleted_at": nil},
+		bson.M{"$set": bson.M{"role": newRole, "updated_at": time.Now().UTC()}},
+	)
+	if err != nil {
+		log.Fatalf("Failed to update role: %v", err)
+	}
+	fmt.Printf("✓ Changed role of %s from %s to %s\n", user.Email, user.Role, newRole)
+}
+
+// loadEnvFile loads environment variables from a .env file
+func loadEnvFile(path string) {
+	if path == "" {
+		// Try to find .env in current dir or backend dir
+		cwd, _ := os.Getwd()
+		if _, err := os.Stat(filepath.Join(cwd, ".env")); err == nil {
+			path = ".env"
+		} else if _, err := os.Stat(filepath.Join(cwd, "backend", ".env")); err == nil {
+			path = filepath.Join(cwd, "backend", ".env")
+		}
+	}
+
+	if path != "" {
+		if err := godotenv.Load(path); err != nil {
+			log.Printf("Error loading .env file: %v", err)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Package apierrors maps domain and service errors to stable API error codes and HTTP statuses.
+package apierrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// Code is a stable, machine-readable error code returned in the "error" field of error responses
+// #IMPLEMENTATION_DECISION: Codes are part of the API contract - clients switch on them, so they are
+// never renamed; messages may change freely
+type Code string
+
+// General codes, shared by every resource
+const (
+	CodeInvalidRequest  Code = "invalid_request"
+	CodeInvalidCursor   Code = "invalid_cursor"
+	CodeUnauthorized    Code = "unauthorized"
+	CodeForbidden       Code = "forbidden"
+	CodeNotFound        Code = "not_found"
+	CodeConflict        Code = "conflict"
+	CodeTooManyRequests Code = "too_many_requests"
+	CodeInternal        Code = "internal_error"
+)
+
+// Codes shared by several resources
+const (
+	CodeVersionConflict   Code = "version_conflict"
+	CodeInvalidTransition Code = "invalid_transition"
+	CodeInvalidType       Code = "invalid_type"
+)
+
+// User codes
+const (
+	CodeInvalidRole                   Code = "invalid_role"
+	CodeAlreadyExists                 Code = "already_exists"
+	CodeLastAdmin                     Code = "last_admin"
+	CodeInvalidProfile                Code = "invalid_profile"
+	CodeEmailUnchanged                Code = "email_unchanged"
+	CodeRateLimitExceeded             Code = "rate_limit_exceeded"
+	CodeInvalidToken                  Code = "invalid_token"
+	CodeInvalidNotificationPreference Code = "invalid_notification_preference"
+)
+
+// Relationship codes
+const (
+	CodeRelationshipExists    Code = "relationship_exists"
+	CodeInvalidClassification Code = "invalid_classification"
+	CodeCannotModify          Code = "cannot_modify"
+	CodeInvalidNote           Code = "invalid_note"
+)
+
+// Questionnaire codes
+const (
+	CodeTemplateNotFound  Code = "template_not_found"
+	CodeCannotPublish     Code = "cannot_publish"
+	CodeInvalidControlRef Code = "invalid_control_ref"
+	CodePointsOutOfScale  Code = "points_out_of_scale"
+)
+
+// Template codes
+const (
+	CodeNotEditable       Code = "not_editable"
+	CodeNotDeletable      Code = "not_deletable"
+	CodeAlreadyPublished  Code = "already_published"
+	CodeNotPublished      Code = "not_published"
+	CodeSuperseded        Code = "superseded"
+	CodeInUse             Code = "in_use"
+	CodeInvalidFormat     Code = "invalid_format"
+	CodeMissingFields     Code = "missing_fields"
+	CodeInvalidVisibility Code = "invalid_visibility"
+)
+
+// Requirement and response codes
+const (
+	CodeNotQuestionnaire          Code = "not_questionnaire"
+	CodeInvalidMessage            Code = "invalid_message"
+	CodeRelationshipNotActive     Code = "relationship_not_active"
+	CodeRequirementApproved       Code = "requirement_approved"
+	CodeQuestionnaireNotPublished Code = "questionnaire_not_published"
+	CodeInvalidCategoryMinimum    Code = "invalid_category_minimum"
+	CodeNotCancellable            Code = "not_cancellable"
+	CodeResponseExists            Code = "response_exists"
+	CodeAttachmentTooLarge        Code = "attachment_too_large"
+	CodeInvalidAttachment         Code = "invalid_attachment"
+	CodeTooManyAttachments        Code = "too_many_attachments"
+	CodeInvalidAssignee           Code = "invalid_assignee"
+	CodeCannotStart               Code = "cannot_start"
+	CodeAlreadySubmitted          Code = "already_submitted"
+	CodeInvalidAnswer             Code = "invalid_answer"
+)
+
+// Review codes
+const (
+	CodeNoSubmission    Code = "no_submission"
+	CodeCannotReview    Code = "cannot_review"
+	CodeInvalidDecision Code = "invalid_decision"
+	CodeReasonRequired  Code = "reason_required"
+)
+
+// Integration codes
+const (
+	CodeCheckFixTimeout     Code = "checkfix_timeout"
+	CodeCheckFixUnavailable Code = "checkfix_unavailable"
+)
+
+// Entry describes how an error is reported to clients
+type Entry struct {
+	Code   Code
+	Status int
+	// Message is shown to clients; empty means the error's own text, for errors written for clients
+	Message string
+}
+
+// Internal is reported for errors missing from the catalog
+var Internal = Entry{Code: CodeInternal, Status: http.StatusInternalServerError, Message: "An unexpected error occurred"}
+
+// mapping pairs a sentinel error with its catalog entry
+type mapping struct {
+	err   error
+	entry Entry
+}
+
+// catalog lists the errors with a client-facing meaning
+// #IMPLEMENTATION_DECISION: Checked in order with errors.Is, so specific errors come before the
+// general ones they may wrap
+var catalog = []mapping{
+	// Templates
+	{models.ErrTemplateNotFound, Entry{CodeNotFound, http.StatusNotFound, "Template not found"}},
+	{models.ErrTemplateNotOwnedByUser, Entry{CodeForbidden, http.StatusForbidden, "You can only modify templates you created"}},
+	{models.ErrTemplateNotEditable, Entry{CodeNotEditable, http.StatusBadRequest, "Template cannot be edited (already published or system template)"}},
+	{models.ErrTemplateNotDeletable, Entry{CodeNotDeletable, http.StatusBadRequest, "Template cannot be deleted"}},
+	{models.ErrTemplateAlreadyPublished, Entry{CodeAlreadyPublished, http.StatusBadRequest, "Template is already published"}},
+	{models.ErrTemplateNotPublished, Entry{CodeNotPublished, http.StatusBadRequest, "Template is not published"}},
+	{models.ErrTemplateSuperseded, Entry{CodeSuperseded, http.StatusConflict, "Template has been superseded by a newer version"}},
+	{models.ErrTemplateInUse, Entry{CodeInUse, http.StatusBadRequest, "Template is in use and cannot be modified"}},
+	{models.ErrTemplateInvalidFormat, Entry{CodeInvalidFormat, http.StatusBadRequest, ""}},
+	{models.ErrTemplateMissingFields, Entry{CodeMissingFields, http.StatusBadRequest, ""}},
+	{models.ErrTemplateInvalidVisibility, Entry{CodeInvalidVisibility, http.StatusBadRequest, "Invalid visibility value"}},
+
+	// Users
+	{services.ErrUserNotFound, Entry{CodeNotFound, http.StatusNotFound, "User not found"}},
+	{services.ErrInvalidUserRole, Entry{CodeInvalidRole, http.StatusBadRequest, "Invalid user role"}},
+	{services.ErrUserAlreadyExists, Entry{CodeAlreadyExists, http.StatusConflict, "A user with this email already exists"}},
+	{services.ErrLastAdmin, Entry{CodeLastAdmin, http.StatusConflict, "Cannot remove the last admin of the organization"}},
+	{services.ErrInvalidProfile, Entry{CodeInvalidProfile, http.StatusBadRequest, ""}},
+	{services.ErrEmailUnchanged, Entry{CodeEmailUnchanged, http.StatusBadRequest, "The new email matches the current email"}},
+	{services.ErrRateLimitExceeded, Entry{CodeRateLimitExceeded, http.StatusTooManyRequests, "Too many requests. Please try again later."}},
+	{services.ErrInvalidSecureLink, Entry{CodeInvalidToken, http.StatusUnauthorized, "Invalid or expired link"}},
+	{services.ErrInvalidNotificationPreference, Entry{CodeInvalidNotificationPreference, http.StatusBadRequest, ""}},
+
+	// Supplier relationships
+	{services.ErrRelationshipNotFound, Entry{CodeNotFound, http.StatusNotFound, "Supplier relationship not found"}},
+	{models.ErrRelationshipNotFound, Entry{CodeNotFound, http.StatusNotFound, "Supplier relationship not found"}},
+	{services.ErrRelationshipExists, Entry{CodeRelationshipExists, http.StatusConflict, "A relationship already exists with this supplier email"}},
+	{services.ErrRelationshipNotActive, Entry{CodeRelationshipNotActive, http.StatusConflict, "The supplier relationship is not active"}},
+	{services.ErrInvalidClassification, Entry{CodeInvalidClassification, http.StatusBadRequest, "Classification must be critical, important or standard"}},
+	{services.ErrCannotModifyRelationship, Entry{CodeCannotModify, http.StatusBadRequest, "Cannot modify terminated relationship"}},
+	{services.ErrInvalidNote, Entry{CodeInvalidNote, http.StatusBadRequest, ""}},
+
+	// Questionnaires
+	{services.ErrQuestionnaireNotFound, Entry{CodeNotFound, http.StatusNotFound, "Questionnaire not found"}},
+	{services.ErrQuestionNotFound, Entry{CodeNotFound, http.StatusNotFound, "Question not found in this questionnaire"}},
+	{services.ErrTemplateNotFound, Entry{CodeTemplateNotFound, http.StatusNotFound, "Template not found"}},
+	{services.ErrQuestionnaireNotEditable, Entry{CodeNotEditable, http.StatusBadRequest, "Only draft questionnaires can be edited"}},
+	{services.ErrQuestionnaireNotDeletable, Entry{CodeNotDeletable, http.StatusBadRequest, "Only draft questionnaires can be deleted"}},
+	{services.ErrQuestionnaireConflict, Entry{CodeVersionConflict, http.StatusConflict, "Questionnaire was changed by another update; reload and try again"}},
+	{services.ErrCannotPublish, Entry{CodeCannotPublish, http.StatusBadRequest, "Cannot publish: questionnaire must be in draft status and have at least one question"}},
+	{services.ErrInvalidQuestionType, Entry{CodeInvalidType, http.StatusBadRequest, "Invalid question type"}},
+	{services.ErrInvalidControlRef, Entry{CodeInvalidControlRef, http.StatusBadRequest, fmt.Sprintf("Control reference must be at most %d characters", models.MaxControlRefLength)}},
+	{models.ErrOptionPointsOutOfScale, Entry{CodePointsOutOfScale, http.StatusBadRequest, ""}},
+
+	// Requirements and attachments
+	{services.ErrRequirementNotFound, Entry{CodeNotFound, http.StatusNotFound, "Requirement not found"}},
+	{models.ErrRequirementNotFound, Entry{CodeNotFound, http.StatusNotFound, "Requirement not found"}},
+	{services.ErrInvalidRequirementType, Entry{CodeInvalidType, http.StatusBadRequest, "Invalid requirement type"}},
+	{services.ErrQuestionnaireNotPublished, Entry{CodeQuestionnaireNotPublished, http.StatusBadRequest, "Questionnaire must be published"}},
+	{services.ErrInvalidCategoryMinimum, Entry{CodeInvalidCategoryMinimum, http.StatusBadRequest, ""}},
+	{services.ErrRequirementNotEditable, Entry{CodeNotEditable, http.StatusBadRequest, "Only pending requirements can be updated"}},
+	{services.ErrRequirementNotCancellable, Entry{CodeNotCancellable, http.StatusConflict, "Only pending requirements can be cancelled"}},
+	{services.ErrRequirementHasResponse, Entry{CodeResponseExists, http.StatusConflict, "The supplier has already started a response"}},
+	{services.ErrRequirementApproved, Entry{CodeRequirementApproved, http.StatusConflict, "Requirement is approved; its response and clarifications can no longer be changed"}},
+	{services.ErrAssigneeNotInOrganization, Entry{CodeInvalidAssignee, http.StatusBadRequest, "Assignee must be an active member of your organization"}},
+	{services.ErrAttachmentNotFound, Entry{CodeNotFound, http.StatusNotFound, "Attachment not found"}},
+	{services.ErrAttachmentTooLarge, Entry{CodeAttachmentTooLarge, http.StatusRequestEntityTooLarge, fmt.Sprintf("Attachment exceeds the maximum size of %d MiB", services.MaxAttachmentSize>>20)}},
+	{services.ErrAttachmentTypeNotAllowed, Entry{CodeInvalidAttachment, http.StatusBadRequest, ""}},
+	{services.ErrAttachmentEmpty, Entry{CodeInvalidAttachment, http.StatusBadRequest, ""}},
+	{services.ErrTooManyAttachments, Entry{CodeTooManyAttachments, http.StatusConflict, ""}},
+
+	// Responses and clarifications
+	{services.ErrResponseNotFound, Entry{CodeNotFound, http.StatusNotFound, "Response not found"}},
+	{services.ErrNotQuestionnaire, Entry{CodeNotQuestionnaire, http.StatusBadRequest, "Requirement is not a questionnaire requirement"}},
+	{services.ErrCannotStartResponse, Entry{CodeCannotStart, http.StatusBadRequest, "Cannot start response for this requirement"}},
+	{services.ErrResponseAlreadyExists, Entry{CodeResponseExists, http.StatusConflict, "Response already exists"}},
+	{services.ErrResponseAlreadySubmitted, Entry{CodeAlreadySubmitted, http.StatusBadRequest, "Response has already been submitted"}},
+	{services.ErrResponseConflict, Entry{CodeVersionConflict, http.StatusConflict, "Response was changed by another save; reload and try again"}},
+	{services.ErrInvalidAnswer, Entry{CodeInvalidAnswer, http.StatusBadRequest, "Invalid question ID"}},
+	{services.ErrInvalidClarification, Entry{CodeInvalidMessage, http.StatusBadRequest, ""}},
+
+	// Reviews
+	{services.ErrNoSubmission, Entry{CodeNoSubmission, http.StatusNotFound, "No submission to review"}},
+	{services.ErrCannotReview, Entry{CodeCannotReview, http.StatusBadRequest, "Requirement is not awaiting review"}},
+	{services.ErrInvalidDecision, Entry{CodeInvalidDecision, http.StatusBadRequest, "Decision must be approve, reject or request_revision"}},
+	{services.ErrReasonRequired, Entry{CodeReasonRequired, http.StatusBadRequest, "A reason is required for this decision"}},
+	{services.ErrBulkReviewSize, Entry{CodeInvalidRequest, http.StatusBadRequest, fmt.Sprintf("Between 1 and %d approvals are required", services.MaxBulkReviewItems)}},
+
+	// Integrations
+	{services.ErrCheckFixTimeout, Entry{CodeCheckFixTimeout, http.StatusGatewayTimeout, "CheckFix did not respond in time, please try again"}},
+	{services.ErrCheckFixAPIError, Entry{CodeCheckFixUnavailable, http.StatusBadGateway, "CheckFix is currently unavailable, please try again"}},
+
+	// General
+	{services.ErrInvalidStatusTransition, Entry{CodeInvalidTransition, http.StatusBadRequest, "This status change is not allowed"}},
+	{models.ErrInvalidCursor, Entry{CodeInvalidCursor, http.StatusBadRequest, "Invalid pagination cursor"}},
+	{models.ErrInvalidInput, Entry{CodeInvalidRequest, http.StatusBadRequest, ""}},
+	{models.ErrNotFound, Entry{CodeNotFound, http.StatusNotFound, "Resource not found"}},
+	{models.ErrAlreadyExists, Entry{CodeConflict, http.StatusConflict, "Resource already exists"}},
+	{models.ErrUnauthorized, Entry{CodeUnauthorized, http.StatusUnauthorized, "Unauthorized"}},
+	{models.ErrForbidden, Entry{CodeForbidden, http.StatusForbidden, "Access denied"}},
+}
+
+// Lookup returns the catalog entry for err, or Internal and false when the error is not catalogued
+func Lookup(err error) (Entry, bool) {
+	for _, m := range catalog {
+		if errors.Is(err, m.err) {
+			return m.entry, true
+		}
+	}
+	return Internal, false
+}
+
+// MessageFor returns the client-facing message of an entry for err
+func (e Entry) MessageFor(err error) string {
+	if e.Message == "" && err != nil {
+		return err.Error()
+	}
+	return e.Message
+}
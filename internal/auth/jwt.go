@@ -4,8 +4,10 @@
 package auth
 
 import (
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -26,6 +28,7 @@ var (
 
 // Claims represents the JWT claims for access tokens
 // #INTEGRATION_POINT: Frontend uses these claims for authorization
+// #SECURITY_ASSUMPTION: RegisteredClaims.ID (jti) is the key for server-side revocation
 type Claims struct {
 	jwt.RegisteredClaims
 	UserID  string `json:"user_id"`
@@ -35,10 +38,13 @@ type Claims struct {
 }
 
 // RefreshClaims represents the JWT claims for refresh tokens
+// #SECURITY_ASSUMPTION: RegisteredClaims.ID (jti) uniquely identifies each refresh token
+// and FamilyID links all tokens rotated from the same login
 type RefreshClaims struct {
 	jwt.RegisteredClaims
 	UserID    string `json:"user_id"`
 	TokenType string `json:"type"`
+	FamilyID  string `json:"fam,omitempty"`
 }
 
 // TokenPair represents an access and refresh token pair
@@ -47,6 +53,19 @@ type TokenPair struct {
 	RefreshToken string    `json:"refresh_token"`
 	ExpiresAt    time.Time `json:"expires_at"`
 	ExpiresIn    int64     `json:"expires_in"`
+
+	// Refresh token metadata for server-side tracking (never serialized)
+	RefreshTokenID        string    `json:"-"`
+	RefreshTokenFamilyID  string    `json:"-"`
+	RefreshTokenExpiresAt time.Time `json:"-"`
+}
+
+// TokenExpiry overrides token lifetimes for a single token pair; zero fields use the service defaults
+// #SECURITY_ASSUMPTION: Overrides can only shorten lifetimes - longer values are capped at the
+// configured expiry so revocation windows sized from the global access token expiry stay valid
+type TokenExpiry struct {
+	Access  time.Duration
+	Refresh time.Duration
 }
 
 // JWTService handles JWT token generation and validation
@@ -55,6 +74,7 @@ type JWTService interface {
 	GenerateAccessToken(userID, orgID, role, orgType string) (string, time.Time, error)
 	GenerateRefreshToken(userID string) (string, error)
 	GenerateTokenPair(userID, orgID, role, orgType string) (*TokenPair, error)
+	RotateTokenPair(userID, orgID, role, orgType, familyID string, expiry TokenExpiry) (*TokenPair, error)
 	ValidateAccessToken(tokenString string) (*Claims, error)
 	ValidateRefreshToken(tokenString string) (*RefreshClaims, error)
 }
@@ -102,11 +122,22 @@ func NewJWTService(cfg JWTConfig) (JWTService, error) {
 // GenerateAccessToken creates a new access token
 // #IMPLEMENTATION_DECISION: 1-hour expiry for access tokens
 func (s *jwtService) GenerateAccessToken(userID, orgID, role, orgType string) (string, time.Time, error) {
+	return s.generateAccessToken(userID, orgID, role, orgType, s.accessTokenExpiry)
+}
+
+// generateAccessToken signs an access token valid for the given lifetime
+func (s *jwtService) generateAccessToken(userID, orgID, role, orgType string, ttl time.Duration) (string, time.Time, error) {
 	now := time.Now()
-	expiresAt := now.Add(s.accessTokenExpiry)
+	expiresAt := now.Add(ttl)
+
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate token ID: %w", err)
+	}
 
 	claims := Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			Issuer:    s.issuer,
 			Subject:   userID,
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
@@ -128,15 +159,31 @@ func (s *jwtService) GenerateAccessToken(userID, orgID, role, orgType string) (s
 	return tokenString, expiresAt, nil
 }
 
-// GenerateRefreshToken creates a new refresh token
+// GenerateRefreshToken creates a new refresh token starting a new rotation family
 // #IMPLEMENTATION_DECISION: 30-day expiry for refresh tokens
 // #SECURITY_CONCERN: Refresh tokens are single-use and should be rotated
 func (s *jwtService) GenerateRefreshToken(userID string) (string, error) {
+	tokenString, _, err := s.generateRefreshToken(userID, "", s.refreshTokenExpiry)
+	return tokenString, err
+}
+
+// generateRefreshToken signs a refresh token with a fresh jti in the given family
+// An empty familyID starts a new family keyed by the token's own jti
+func (s *jwtService) generateRefreshToken(userID, familyID string, ttl time.Duration) (string, *RefreshClaims, error) {
 	now := time.Now()
-	expiresAt := now.Add(s.refreshTokenExpiry)
+	expiresAt := now.Add(ttl)
+
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate token ID: %w", err)
+	}
+	if familyID == "" {
+		familyID = tokenID
+	}
 
 	claims := RefreshClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			Issuer:    s.issuer,
 			Subject:   userID,
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
@@ -145,37 +192,56 @@ func (s *jwtService) GenerateRefreshToken(userID string) (string, error) {
 		},
 		UserID:    userID,
 		TokenType: "refresh",
+		FamilyID:  familyID,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodRS512, claims)
 	tokenString, err := token.SignedString(s.privateKey)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign refresh token: %w", err)
+		return "", nil, fmt.Errorf("failed to sign refresh token: %w", err)
 	}
 
-	return tokenString, nil
+	return tokenString, &claims, nil
 }
 
-// GenerateTokenPair creates both access and refresh tokens
+// GenerateTokenPair creates both access and refresh tokens for a new session
 func (s *jwtService) GenerateTokenPair(userID, orgID, role, orgType string) (*TokenPair, error) {
-	accessToken, expiresAt, err := s.GenerateAccessToken(userID, orgID, role, orgType)
+	return s.RotateTokenPair(userID, orgID, role, orgType, "", TokenExpiry{})
+}
+
+// RotateTokenPair creates both access and refresh tokens, keeping the refresh token
+// in the given rotation family
+func (s *jwtService) RotateTokenPair(userID, orgID, role, orgType, familyID string, expiry TokenExpiry) (*TokenPair, error) {
+	accessTTL := effectiveExpiry(expiry.Access, s.accessTokenExpiry)
+	accessToken, expiresAt, err := s.generateAccessToken(userID, orgID, role, orgType, accessTTL)
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := s.GenerateRefreshToken(userID)
+	refreshToken, refreshClaims, err := s.generateRefreshToken(userID, familyID, effectiveExpiry(expiry.Refresh, s.refreshTokenExpiry))
 	if err != nil {
 		return nil, err
 	}
 
 	return &TokenPair{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresAt:    expiresAt,
-		ExpiresIn:    int64(s.accessTokenExpiry.Seconds()),
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		ExpiresAt:             expiresAt,
+		ExpiresIn:             int64(accessTTL.Seconds()),
+		RefreshTokenID:        refreshClaims.ID,
+		RefreshTokenFamilyID:  refreshClaims.FamilyID,
+		RefreshTokenExpiresAt: refreshClaims.ExpiresAt.Time,
 	}, nil
 }
 
+// effectiveExpiry applies an override when it is set and shorter than the configured expiry
+func effectiveExpiry(override, configured time.Duration) time.Duration {
+	if override > 0 && override < configured {
+		return override
+	}
+	return configured
+}
+
 // ValidateAccessToken validates an access token and returns the claims
 func (s *jwtService) ValidateAccessToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
@@ -221,13 +287,22 @@ func (s *jwtService) ValidateRefreshToken(tokenString string) (*RefreshClaims, e
 		return nil, ErrInvalidClaims
 	}
 
-	if claims.TokenType != "refresh" {
+	if claims.TokenType != "refresh" || claims.ID == "" {
 		return nil, ErrInvalidClaims
 	}
 
 	return claims, nil
 }
 
+// newTokenID generates a random token identifier (jti)
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 // loadPrivateKey loads an RSA private key from a PEM file
 func loadPrivateKey(path string) (*rsa.PrivateKey, error) {
 	keyData, err := os.ReadFile(path)
@@ -257,6 +257,61 @@ func TestJWTService_GenerateTokenPair(t *testing.T) {
 	}
 }
 
+func TestJWTService_RotateTokenPair_KeepsFamily(t *testing.T) {
+	svc, cleanup := createTestJWTService(t)
+	defer cleanup()
+
+	first, err := svc.GenerateTokenPair("user123", "org456", "ADMIN", "COMPANY")
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+	if first.RefreshTokenID == "" {
+		t.Fatal("TokenPair.RefreshTokenID is empty")
+	}
+	if first.RefreshTokenFamilyID != first.RefreshTokenID {
+		t.Errorf("new family ID = %v, want %v", first.RefreshTokenFamilyID, first.RefreshTokenID)
+	}
+
+	second, err := svc.RotateTokenPair("user123", "org456", "ADMIN", "COMPANY", first.RefreshTokenFamilyID, TokenExpiry{})
+	if err != nil {
+		t.Fatalf("RotateTokenPair() error = %v", err)
+	}
+	if second.RefreshTokenID == first.RefreshTokenID {
+		t.Error("rotated refresh token reused the previous jti")
+	}
+
+	claims, err := svc.ValidateRefreshToken(second.RefreshToken)
+	if err != nil {
+		t.Fatalf("ValidateRefreshToken() error = %v", err)
+	}
+	if claims.ID != second.RefreshTokenID {
+		t.Errorf("Claims.ID = %v, want %v", claims.ID, second.RefreshTokenID)
+	}
+	if claims.FamilyID != first.RefreshTokenFamilyID {
+		t.Errorf("Claims.FamilyID = %v, want %v", claims.FamilyID, first.RefreshTokenFamilyID)
+	}
+}
+
+func TestJWTService_RotateTokenPair_ExpiryOverride(t *testing.T) {
+	svc, cleanup := createTestJWTService(t)
+	defer cleanup()
+
+	// Shorter overrides apply; longer ones are capped at the configured expiry
+	pair, err := svc.RotateTokenPair("user123", "org456", "ADMIN", "COMPANY", "", TokenExpiry{Access: 15 * time.Minute, Refresh: 24 * time.Hour * 365})
+	if err != nil {
+		t.Fatalf("RotateTokenPair() error = %v", err)
+	}
+	if pair.ExpiresIn != int64((15 * time.Minute).Seconds()) {
+		t.Errorf("TokenPair.ExpiresIn = %v, want %v", pair.ExpiresIn, int64((15 * time.Minute).Seconds()))
+	}
+	if pair.ExpiresAt.After(time.Now().Add(15 * time.Minute)) {
+		t.Errorf("TokenPair.ExpiresAt = %v, want within 15 minutes", pair.ExpiresAt)
+	}
+	if pair.RefreshTokenExpiresAt.After(time.Now().Add(24 * time.Hour * 30)) {
+		t.Errorf("TokenPair.RefreshTokenExpiresAt = %v, want capped at 30 days", pair.RefreshTokenExpiresAt)
+	}
+}
+
 func TestJWTService_ValidateAccessToken_Invalid(t *testing.T) {
 	svc, cleanup := createTestJWTService(t)
 	defer cleanup()
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOIDCProvider_State(t *testing.T) {
+	p := &OIDCProvider{stateKey: []byte("client-secret")}
+
+	state := p.signState("nonce123", time.Now().Add(time.Minute))
+	if err := p.verifyState(state, "nonce123"); err != nil {
+		t.Fatalf("verifyState() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		state string
+		nonce string
+	}{
+		{"expired", p.signState("nonce123", time.Now().Add(-time.Minute)), "nonce123"},
+		{"tampered nonce", "other" + state[len("nonce123"):], "other"},
+		{"wrong key", (&OIDCProvider{stateKey: []byte("other")}).signState("nonce123", time.Now().Add(time.Minute)), "nonce123"},
+		{"malformed", "not-a-state", "nonce123"},
+		// A valid state started in another browser
+		{"other browser nonce", state, "attacker-nonce"},
+		{"missing nonce cookie", state, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := p.verifyState(tt.state, tt.nonce); !errors.Is(err, ErrOIDCInvalidState) {
+				t.Errorf("verifyState() error = %v, want ErrOIDCInvalidState", err)
+			}
+		})
+	}
+}
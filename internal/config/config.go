@@ -4,8 +4,10 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"sync"
 	"time"
 
@@ -29,6 +31,26 @@ type MailConfig struct {
 	// Supplier invitation templates
 	InviteSupplierDE string `envconfig:"TPL_INVITE_SUPPLIER_DE" default:"Nisfix_Invite_Supplier_DE"`
 	InviteSupplierEN string `envconfig:"TPL_INVITE_SUPPLIER_EN" default:"Nisfix_Invite_Supplier_EN"`
+
+	// CheckFix templates
+	CheckFixRefreshDE string `envconfig:"TPL_CHECKFIX_REFRESH_DE" default:"Nisfix_CheckFix_Refresh_DE"`
+	CheckFixRefreshEN string `envconfig:"TPL_CHECKFIX_REFRESH_EN" default:"Nisfix_CheckFix_Refresh_EN"`
+}
+
+// OIDCConfig holds OpenID Connect single sign-on configuration.
+// #INTEGRATION_POINT: Enterprise identity providers (Azure AD, Okta, Keycloak, ...)
+// #IMPLEMENTATION_DECISION: SSO is disabled when no issuer URL is configured
+type OIDCConfig struct {
+	IssuerURL     string `envconfig:"ISSUER_URL"`
+	ClientID      string `envconfig:"CLIENT_ID"`
+	ClientSecret  string `envconfig:"CLIENT_SECRET"`
+	RedirectURL   string `envconfig:"REDIRECT_URL"`
+	AutoProvision bool   `envconfig:"AUTO_PROVISION" default:"false"`
+}
+
+// Enabled returns true if OIDC login is configured
+func (c OIDCConfig) Enabled() bool {
+	return c.IssuerURL != ""
 }
 
 // Config holds all application configuration loaded from environment variables.
@@ -38,6 +60,15 @@ type Config struct {
 	DatabaseURI  string `envconfig:"DATABASE_URI" required:"true"`
 	DatabaseName string `envconfig:"DATABASE_NAME" default:"nisfix"`
 
+	// DatabaseConnectAttempts is how many times the startup ping is tried before giving up
+	DatabaseConnectAttempts int `envconfig:"DATABASE_CONNECT_ATTEMPTS" default:"10"`
+	// DatabaseConnectRetryDelay is the first retry wait; it doubles on each further retry
+	DatabaseConnectRetryDelay time.Duration `envconfig:"DATABASE_CONNECT_RETRY_DELAY" default:"1s"`
+	// DatabaseConnectRetryTimeout bounds all startup connection attempts together
+	DatabaseConnectRetryTimeout time.Duration `envconfig:"DATABASE_CONNECT_RETRY_TIMEOUT" default:"2m"`
+	// DatabaseHealthCheckInterval is how often the connection is monitored; 0 disables monitoring
+	DatabaseHealthCheckInterval time.Duration `envconfig:"DATABASE_HEALTH_CHECK_INTERVAL" default:"15s"`
+
 	// JWT configuration
 	JWTPrivateKeyPath  string        `envconfig:"JWT_PRIVATE_KEY_PATH" required:"true"`
 	JWTPublicKeyPath   string        `envconfig:"JWT_PUBLIC_KEY_PATH" required:"true"`
@@ -47,25 +78,90 @@ type Config struct {
 	// Mail service configuration
 	Mail MailConfig `envconfig:"MAIL"`
 
+	// OIDC single sign-on configuration
+	OIDC OIDCConfig `envconfig:"OIDC"`
+
 	// CheckFix API configuration
 	CheckFixAPIURL string `envconfig:"CHECKFIX_API_URL"`
 	CheckFixAPIKey string `envconfig:"CHECKFIX_API_KEY"`
 
+	// CheckFixCallTimeout bounds each CheckFix API call including retries; keep it below the
+	// server write timeout (15s) so slow calls fail cleanly. 0 disables the per-call timeout
+	CheckFixCallTimeout time.Duration `envconfig:"CHECKFIX_CALL_TIMEOUT" default:"10s"`
+
+	// CheckFixReportCacheTTL is how long successful report verifications are reused; 0 disables caching
+	CheckFixReportCacheTTL time.Duration `envconfig:"CHECKFIX_REPORT_CACHE_TTL" default:"10m"`
+
+	// CheckFixWebhookSecret signs CheckFix webhook deliveries; empty disables the webhook endpoint
+	CheckFixWebhookSecret string `envconfig:"CHECKFIX_WEBHOOK_SECRET"`
+
+	// CheckFixRefreshDaysBeforeExpiry is how many days before expiry verifications are re-verified
+	CheckFixRefreshDaysBeforeExpiry int `envconfig:"CHECKFIX_REFRESH_DAYS_BEFORE_EXPIRY" default:"7"`
+
+	// CheckFixRefreshInterval is how often expiring verifications are re-verified; 0 disables the job
+	CheckFixRefreshInterval time.Duration `envconfig:"CHECKFIX_REFRESH_INTERVAL" default:"24h"`
+
+	// IdempotencyKeyTTL is how long Idempotency-Key headers on create requests are remembered
+	IdempotencyKeyTTL time.Duration `envconfig:"IDEMPOTENCY_KEY_TTL" default:"24h"`
+
+	// EmailOutboxInterval is how often queued emails are sent and retried; 0 disables the worker
+	EmailOutboxInterval time.Duration `envconfig:"EMAIL_OUTBOX_INTERVAL" default:"1m"`
+
+	// QuestionnaireRetentionDays is how long deleted questionnaires can be restored before they are purged
+	QuestionnaireRetentionDays int `envconfig:"QUESTIONNAIRE_RETENTION_DAYS" default:"30"`
+
+	// QuestionnairePurgeInterval is how often deleted questionnaires are purged; 0 disables the job
+	QuestionnairePurgeInterval time.Duration `envconfig:"QUESTIONNAIRE_PURGE_INTERVAL" default:"24h"`
+
+	// FileStorageDir is where uploaded files (requirement attachments) are stored
+	FileStorageDir string `envconfig:"FILE_STORAGE_DIR" default:"./data/files"`
+
 	// Server configuration
 	ServerPort  string `envconfig:"SERVER_PORT" default:"8080"`
 	Environment string `envconfig:"ENVIRONMENT" default:"development"`
 
+	// LogFormat selects request log output: "pretty" for local development, "json" for log ingestion
+	LogFormat string `envconfig:"LOG_FORMAT" default:"pretty"`
+
 	// Magic link configuration
 	MagicLinkBaseURL string        `envconfig:"MAGIC_LINK_BASE_URL" required:"true"`
 	MagicLinkExpiry  time.Duration `envconfig:"MAGIC_LINK_EXPIRY" default:"15m"`
 	InvitationExpiry time.Duration `envconfig:"INVITATION_EXPIRY" default:"168h"` // 7 days
 
 	// CORS configuration
-	AllowedOrigins []string `envconfig:"ALLOWED_ORIGINS" default:"http://localhost:3000"`
+	AllowedOrigins     []string `envconfig:"ALLOWED_ORIGINS" default:"http://localhost:3000"`
+	CORSAllowedMethods []string `envconfig:"CORS_ALLOWED_METHODS" default:"GET,POST,PUT,PATCH,DELETE,OPTIONS"`
+	CORSAllowedHeaders []string `envconfig:"CORS_ALLOWED_HEADERS" default:"Origin,Content-Type,Content-Length,Accept-Encoding,Authorization,X-Request-ID,If-Match,Idempotency-Key"`
+	CORSExposedHeaders []string `envconfig:"CORS_EXPOSED_HEADERS" default:"Content-Length,Content-Disposition,ETag,X-Request-ID,Idempotent-Replayed,X-Total-Count,X-Page,X-Total-Pages,Link"`
+
+	// CORSAllowCredentials lets browsers send cookies; ALLOWED_ORIGINS must then list exact origins
+	CORSAllowCredentials bool `envconfig:"CORS_ALLOW_CREDENTIALS" default:"true"`
+
+	// CORSMaxAge is how long browsers cache preflight results; 0 disables caching
+	CORSMaxAge time.Duration `envconfig:"CORS_MAX_AGE" default:"24h"`
+
+	// MaxBodySize is the default request body limit in bytes
+	MaxBodySize int64 `envconfig:"MAX_BODY_SIZE" default:"1048576"` // 1 MiB
+	// MaxImportBodySize is the request body limit in bytes for import endpoints
+	MaxImportBodySize int64 `envconfig:"MAX_IMPORT_BODY_SIZE" default:"10485760"` // 10 MiB
 
 	// Rate limiting
 	RateLimitRequests int           `envconfig:"RATE_LIMIT_REQUESTS" default:"100"`
 	RateLimitWindow   time.Duration `envconfig:"RATE_LIMIT_WINDOW" default:"1m"`
+
+	// AuthRateLimitPerMinute is the per-IP budget for the public magic-link and login endpoints
+	AuthRateLimitPerMinute int `envconfig:"AUTH_RATE_LIMIT_PER_MINUTE" default:"10"`
+
+	// PasswordLoginEnabled allows password login as a fallback to magic links for organizations
+	// that also enable it in their settings
+	PasswordLoginEnabled bool `envconfig:"PASSWORD_LOGIN_ENABLED" default:"false"`
+
+	// OrgRateLimitPerMinute is the per-organization budget for authenticated API requests; 0 disables it
+	OrgRateLimitPerMinute int `envconfig:"ORG_RATE_LIMIT_PER_MINUTE" default:"600"`
+
+	// TrustedProxies lists proxy IPs/CIDRs whose X-Forwarded-For header is honoured
+	// #SECURITY_ASSUMPTION: Empty means the client IP is always the socket address
+	TrustedProxies []string `envconfig:"TRUSTED_PROXIES"`
 }
 
 var (
@@ -80,22 +176,107 @@ func Load() (*Config, error) {
 	once.Do(func() {
 		instance = &Config{}
 		errInit = envconfig.Process("NISFIX", instance)
-		if errInit != nil {
-			return
+	})
+
+	return instance, errInit
+}
+
+// namedDuration pairs a duration setting with its environment variable name for error messages
+type namedDuration struct {
+	name  string
+	value time.Duration
+}
+
+// Validate checks the loaded configuration and reports every problem at once
+// #IMPLEMENTATION_DECISION: Problems are collected rather than returned one by one, so a
+// misconfigured deployment can be fixed in a single pass
+func (c *Config) Validate() error {
+	var errs []error
+
+	// JWT keys must be readable, not just present
+	for _, key := range []struct{ name, path string }{
+		{"JWT_PRIVATE_KEY_PATH", c.JWTPrivateKeyPath},
+		{"JWT_PUBLIC_KEY_PATH", c.JWTPublicKeyPath},
+	} {
+		f, err := os.Open(key.path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: key file is not readable: %w", key.name, err))
+			continue
 		}
+		_ = f.Close()
+	}
 
-		// Validate required file paths exist
-		if _, err := os.Stat(instance.JWTPrivateKeyPath); os.IsNotExist(err) {
-			errInit = fmt.Errorf("JWT private key file not found: %s", instance.JWTPrivateKeyPath)
-			return
+	// Token and link lifetimes must be positive
+	for _, setting := range []namedDuration{
+		{"ACCESS_TOKEN_EXPIRY", c.AccessTokenExpiry},
+		{"REFRESH_TOKEN_EXPIRY", c.RefreshTokenExpiry},
+		{"MAGIC_LINK_EXPIRY", c.MagicLinkExpiry},
+		{"INVITATION_EXPIRY", c.InvitationExpiry},
+		{"RATE_LIMIT_WINDOW", c.RateLimitWindow},
+	} {
+		if setting.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %s", setting.name, setting.value))
 		}
-		if _, err := os.Stat(instance.JWTPublicKeyPath); os.IsNotExist(err) {
-			errInit = fmt.Errorf("JWT public key file not found: %s", instance.JWTPublicKeyPath)
-			return
+	}
+	if c.AccessTokenExpiry > 0 && c.RefreshTokenExpiry > 0 && c.RefreshTokenExpiry <= c.AccessTokenExpiry {
+		errs = append(errs, fmt.Errorf("REFRESH_TOKEN_EXPIRY (%s) must be longer than ACCESS_TOKEN_EXPIRY (%s)", c.RefreshTokenExpiry, c.AccessTokenExpiry))
+	}
+
+	// Job intervals and cache lifetimes use 0 to disable, so only negatives are wrong
+	for _, setting := range []namedDuration{
+		{"DATABASE_HEALTH_CHECK_INTERVAL", c.DatabaseHealthCheckInterval},
+		{"CHECKFIX_CALL_TIMEOUT", c.CheckFixCallTimeout},
+		{"CHECKFIX_REPORT_CACHE_TTL", c.CheckFixReportCacheTTL},
+		{"CHECKFIX_REFRESH_INTERVAL", c.CheckFixRefreshInterval},
+		{"EMAIL_OUTBOX_INTERVAL", c.EmailOutboxInterval},
+		{"QUESTIONNAIRE_PURGE_INTERVAL", c.QuestionnairePurgeInterval},
+		{"CORS_MAX_AGE", c.CORSMaxAge},
+	} {
+		if setting.value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative, got %s", setting.name, setting.value))
 		}
-	})
+	}
 
-	return instance, errInit
+	if c.QuestionnaireRetentionDays < 1 {
+		errs = append(errs, fmt.Errorf("QUESTIONNAIRE_RETENTION_DAYS must be at least 1, got %d", c.QuestionnaireRetentionDays))
+	}
+	if c.RateLimitRequests < 1 {
+		errs = append(errs, fmt.Errorf("RATE_LIMIT_REQUESTS must be at least 1, got %d", c.RateLimitRequests))
+	}
+	if c.OrgRateLimitPerMinute < 0 {
+		errs = append(errs, fmt.Errorf("ORG_RATE_LIMIT_PER_MINUTE must not be negative, got %d", c.OrgRateLimitPerMinute))
+	}
+	if c.MaxBodySize < 1 || c.MaxImportBodySize < 1 {
+		errs = append(errs, fmt.Errorf("MAX_BODY_SIZE and MAX_IMPORT_BODY_SIZE must be positive"))
+	}
+	if c.LogFormat != "pretty" && c.LogFormat != "json" {
+		errs = append(errs, fmt.Errorf("LOG_FORMAT must be pretty or json, got %q", c.LogFormat))
+	}
+
+	// Browsers reject a wildcard origin on credentialed requests
+	if c.CORSAllowCredentials && slices.Contains(c.AllowedOrigins, "*") {
+		errs = append(errs, fmt.Errorf("ALLOWED_ORIGINS cannot contain * when CORS_ALLOW_CREDENTIALS is enabled"))
+	}
+
+	// Validate OIDC settings are complete when SSO is enabled
+	if c.OIDC.Enabled() && (c.OIDC.ClientID == "" || c.OIDC.ClientSecret == "" || c.OIDC.RedirectURL == "") {
+		errs = append(errs, fmt.Errorf("OIDC issuer configured but client ID, client secret or redirect URL is missing"))
+	}
+
+	// #SECURITY_ASSUMPTION: Production never falls back to the mock CheckFix client or open CORS
+	if c.IsProduction() {
+		if c.CheckFixAPIURL == "" || c.CheckFixAPIKey == "" {
+			errs = append(errs, fmt.Errorf("CHECKFIX_API_URL and CHECKFIX_API_KEY are required in production"))
+		}
+		if slices.Contains(c.AllowedOrigins, "*") {
+			errs = append(errs, fmt.Errorf("ALLOWED_ORIGINS cannot contain * in production"))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
 }
 
 // GetConfig returns the loaded configuration.
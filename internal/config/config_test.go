@@ -0,0 +1,84 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// validConfig returns a configuration that passes Validate, with key files in a temp dir
+func validConfig(t *testing.T) *Config {
+	t.Helper()
+	dir := t.TempDir()
+	privateKey := filepath.Join(dir, "private.pem")
+	publicKey := filepath.Join(dir, "public.pem")
+	for _, path := range []string{privateKey, publicKey} {
+		if err := os.WriteFile(path, []byte("key"), 0o600); err != nil {
+			t.Fatalf("Failed to write key file: %v", err)
+		}
+	}
+
+	return &Config{
+		JWTPrivateKeyPath:          privateKey,
+		JWTPublicKeyPath:           publicKey,
+		AccessTokenExpiry:          time.Hour,
+		RefreshTokenExpiry:         720 * time.Hour,
+		MagicLinkExpiry:            15 * time.Minute,
+		InvitationExpiry:           168 * time.Hour,
+		QuestionnaireRetentionDays: 30,
+		Environment:                "development",
+		LogFormat:                  "pretty",
+		AllowedOrigins:             []string{"http://localhost:3000"},
+		MaxBodySize:                1 << 20,
+		MaxImportBodySize:          10 << 20,
+		RateLimitRequests:          100,
+		RateLimitWindow:            time.Minute,
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(c *Config)
+		wantErrs []string
+	}{
+		{"valid", func(c *Config) {}, nil},
+		{"missing key file", func(c *Config) { c.JWTPublicKeyPath = "/nonexistent/public.pem" }, []string{"JWT_PUBLIC_KEY_PATH"}},
+		{"non-positive expiry", func(c *Config) { c.MagicLinkExpiry = 0 }, []string{"MAGIC_LINK_EXPIRY"}},
+		{"refresh shorter than access", func(c *Config) { c.RefreshTokenExpiry = time.Minute }, []string{"REFRESH_TOKEN_EXPIRY"}},
+		{"negative interval", func(c *Config) { c.EmailOutboxInterval = -time.Second }, []string{"EMAIL_OUTBOX_INTERVAL"}},
+		{"production needs checkfix and exact origins", func(c *Config) {
+			c.Environment = "production"
+			c.AllowedOrigins = []string{"*"}
+		}, []string{"CHECKFIX_API_URL", "ALLOWED_ORIGINS cannot contain * in production"}},
+		{"every problem is reported", func(c *Config) {
+			c.JWTPrivateKeyPath = ""
+			c.AccessTokenExpiry = -time.Hour
+			c.LogFormat = "xml"
+		}, []string{"JWT_PRIVATE_KEY_PATH", "ACCESS_TOKEN_EXPIRY", "LOG_FORMAT"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig(t)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Validate() error = nil, want error")
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() error = %v, want it to mention %s", err, want)
+				}
+			}
+		})
+	}
+}
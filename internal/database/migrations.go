@@ -0,0 +1,132 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CollectionSchemaMigrations records which data migrations have been applied
+const CollectionSchemaMigrations = "schema_migrations"
+
+// indexNotFoundCode is the MongoDB error code for dropping an index that does not exist
+const indexNotFoundCode = 27
+
+// migration is a one-off data migration identified by a stable ID
+type migration struct {
+	id  string
+	run func(ctx context.Context, db *mongo.Database) error
+}
+
+// migrations lists data migrations in the order they must be applied
+// #MIGRATION_DECISION: Append only - never rename or reorder applied migrations
+var migrations = []migration{
+	{id: "0001_backfill_organization_domains", run: backfillOrganizationDomains},
+	{id: "0002_backfill_submission_companies", run: backfillSubmissionCompanies},
+	{id: "0003_default_questions_scored", run: defaultQuestionsScored},
+	{id: "0004_drop_verification_supplier_unique", run: dropVerificationSupplierUnique},
+}
+
+// RunMigrations applies data migrations that have not been applied yet
+// #MIGRATION_DECISION: Migrations run at startup after index creation and must be idempotent,
+// since two replicas starting together may both run a pending migration
+func (c *Client) RunMigrations(ctx context.Context) error {
+	applied := c.Collection(CollectionSchemaMigrations)
+
+	for _, m := range migrations {
+		count, err := applied.CountDocuments(ctx, bson.M{"_id": m.id})
+		if err != nil {
+			return fmt.Errorf("failed to check migration %s: %w", m.id, err)
+		}
+		if count > 0 {
+			continue
+		}
+
+		log.Printf("Applying migration %s...", m.id)
+		if err := m.run(ctx, c.database); err != nil {
+			return fmt.Errorf("migration %s failed: %w", m.id, err)
+		}
+
+		_, err = applied.InsertOne(ctx, bson.M{"_id": m.id, "applied_at": time.Now().UTC()})
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to record migration %s: %w", m.id, err)
+		}
+	}
+
+	return nil
+}
+
+// backfillOrganizationDomains copies the primary domain into the verified domains list
+func backfillOrganizationDomains(ctx context.Context, db *mongo.Database) error {
+	filter := bson.M{
+		"domain":  bson.M{"$nin": bson.A{nil, ""}},
+		"domains": bson.M{"$exists": false},
+	}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"domains": bson.A{"$domain"}}}},
+	}
+
+	result, err := db.Collection(CollectionOrganizations).UpdateMany(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	log.Printf("Backfilled domains for %d organizations", result.ModifiedCount)
+	return nil
+}
+
+// backfillSubmissionCompanies copies the company and requirement of each submission's response onto it
+// #IMPLEMENTATION_DECISION: Resolved server-side with $lookup and $merge; submissions whose response or
+// requirement is gone are left without a company and stay out of company exports
+func backfillSubmissionCompanies(ctx context.Context, db *mongo.Database) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"company_id": bson.M{"$exists": false}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": CollectionSupplierResponses, "localField": "response_id", "foreignField": "_id", "as": "response",
+		}}},
+		{{Key: "$unwind", Value: "$response"}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": CollectionRequirements, "localField": "response.requirement_id", "foreignField": "_id", "as": "requirement",
+		}}},
+		{{Key: "$unwind", Value: "$requirement"}},
+		{{Key: "$project", Value: bson.M{"company_id": "$requirement.company_id", "requirement_id": "$requirement._id"}}},
+		{{Key: "$merge", Value: bson.M{
+			"into": CollectionQuestionnaireSubmissions, "on": "_id", "whenMatched": "merge", "whenNotMatched": "discard",
+		}}},
+	}
+
+	cursor, err := db.Collection(CollectionQuestionnaireSubmissions).Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	return cursor.Close(ctx)
+}
+
+// defaultQuestionsScored marks questions created before informational questions existed as scored
+func defaultQuestionsScored(ctx context.Context, db *mongo.Database) error {
+	result, err := db.Collection(CollectionQuestions).UpdateMany(ctx,
+		bson.M{"scored": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"scored": true}},
+	)
+	if err != nil {
+		return err
+	}
+	log.Printf("Marked %d existing questions as scored", result.ModifiedCount)
+	return nil
+}
+
+// dropVerificationSupplierUnique drops the unique supplier_id index that allowed only one verification per supplier
+// #MIGRATION_DECISION: Suppliers now keep a verification history, so the old index would reject every
+// second verification; databases created after the change never had it, which counts as done
+func dropVerificationSupplierUnique(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(CollectionCheckFixVerifications).Indexes().DropOne(ctx, "supplier_id_1")
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == indexNotFoundCode {
+		return nil
+	}
+	return err
+}
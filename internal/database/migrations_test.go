@@ -0,0 +1,45 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestDropVerificationSupplierUnique(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name     string
+		response bson.D
+		wantErr  bool
+	}{
+		{"drops the index", mtest.CreateSuccessResponse(), false},
+		{"missing index is already done", mtest.CreateCommandErrorResponse(mtest.CommandError{Code: indexNotFoundCode, Name: "IndexNotFound", Message: "index not found with name [supplier_id_1]"}), false},
+		{"other errors fail the migration", mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 13, Name: "Unauthorized", Message: "not authorized"}), true},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			mt.AddMockResponses(tt.response)
+
+			err := dropVerificationSupplierUnique(context.Background(), mt.DB)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+
+			started := mt.GetStartedEvent()
+			if started == nil || started.CommandName != "dropIndexes" {
+				t.Fatalf("Expected a dropIndexes command, got %+v", started)
+			}
+			if coll := started.Command.Lookup("dropIndexes").StringValue(); coll != CollectionCheckFixVerifications {
+				t.Errorf("Expected collection %s, got %s", CollectionCheckFixVerifications, coll)
+			}
+			if index := started.Command.Lookup("index").StringValue(); index != "supplier_id_1" {
+				t.Errorf("Expected index supplier_id_1, got %s", index)
+			}
+		})
+	}
+}
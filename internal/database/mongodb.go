@@ -5,12 +5,15 @@ package database
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
 )
 
 // Collection names as constants
@@ -19,6 +22,9 @@ const (
 	CollectionOrganizations                = "organizations"
 	CollectionUsers                        = "users"
 	CollectionSecureLinks                  = "secure_links"
+	CollectionRefreshTokens                = "refresh_tokens"
+	CollectionRevokedTokens                = "revoked_tokens"
+	CollectionAPIKeys                      = "api_keys"
 	CollectionQuestionnaireTemplates       = "questionnaire_templates"
 	CollectionQuestionnaires               = "questionnaires"
 	CollectionQuestions                    = "questions"
@@ -28,6 +34,11 @@ const (
 	CollectionQuestionnaireSubmissions     = "questionnaire_submissions"
 	CollectionCheckFixVerifications        = "checkfix_verifications"
 	CollectionAuditLogs                    = "audit_logs"
+	CollectionWebhookEvents                = "webhook_events"
+	CollectionWebhooks                     = "webhooks"
+	CollectionEmailOutbox                  = "email_outbox"
+	CollectionSentEmails                   = "sent_emails"
+	CollectionIdempotencyKeys              = "idempotency_keys"
 )
 
 // Config holds MongoDB connection configuration
@@ -40,8 +51,20 @@ type Config struct {
 	MaxConnIdleTime        time.Duration
 	ConnectTimeout         time.Duration
 	ServerSelectionTimeout time.Duration
+
+	// ConnectAttempts is the number of startup pings before giving up (minimum 1)
+	ConnectAttempts int
+	// ConnectRetryDelay is the wait before the first retry; it doubles up to maxConnectRetryDelay
+	ConnectRetryDelay time.Duration
+	// ConnectRetryTimeout bounds the whole startup retry loop; 0 means attempts alone limit it
+	ConnectRetryTimeout time.Duration
+	// HealthCheckInterval is how often the health monitor pings; 0 disables it
+	HealthCheckInterval time.Duration
 }
 
+// maxConnectRetryDelay caps the startup retry backoff
+const maxConnectRetryDelay = 30 * time.Second
+
 // DefaultConfig returns default MongoDB configuration
 func DefaultConfig() Config {
 	return Config{
@@ -52,6 +75,10 @@ func DefaultConfig() Config {
 		MaxConnIdleTime:        30 * time.Minute,
 		ConnectTimeout:         10 * time.Second,
 		ServerSelectionTimeout: 10 * time.Second,
+		ConnectAttempts:        10,
+		ConnectRetryDelay:      time.Second,
+		ConnectRetryTimeout:    2 * time.Minute,
+		HealthCheckInterval:    15 * time.Second,
 	}
 }
 
@@ -63,6 +90,8 @@ type Client struct {
 }
 
 // NewClient creates a new MongoDB client
+// #IMPLEMENTATION_DECISION: The startup ping is retried with backoff so the server survives
+// MongoDB starting after it (e.g. container start order) instead of crash-looping
 func NewClient(cfg Config) (*Client, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
 	defer cancel()
@@ -82,8 +111,21 @@ func NewClient(cfg Config) (*Client, error) {
 		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
 	}
 
-	// Verify connection with ping
-	if err := client.Ping(ctx, readpref.Primary()); err != nil {
+	// Verify connection with ping, retrying while MongoDB comes up
+	retryCtx := context.Background()
+	if cfg.ConnectRetryTimeout > 0 {
+		var retryCancel context.CancelFunc
+		retryCtx, retryCancel = context.WithTimeout(retryCtx, cfg.ConnectRetryTimeout)
+		defer retryCancel()
+	}
+	ping := func(ctx context.Context) error {
+		pingCtx, pingCancel := context.WithTimeout(ctx, cfg.ConnectTimeout)
+		defer pingCancel()
+		return client.Ping(pingCtx, readpref.Primary())
+	}
+	if err := pingWithRetry(retryCtx, ping, cfg.ConnectAttempts, cfg.ConnectRetryDelay); err != nil {
+		//nolint:errcheck // Best-effort cleanup of a client that never connected
+		client.Disconnect(context.Background())
 		return nil, fmt.Errorf("failed to ping MongoDB: %w", err)
 	}
 
@@ -94,6 +136,73 @@ func NewClient(cfg Config) (*Client, error) {
 	}, nil
 }
 
+// pingWithRetry calls ping until it succeeds, attempts are exhausted or ctx expires
+func pingWithRetry(ctx context.Context, ping func(ctx context.Context) error, attempts int, delay time.Duration) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = ping(ctx); err == nil {
+			return nil
+		}
+		if attempt == attempts {
+			break
+		}
+
+		log.Printf("MongoDB not reachable (attempt %d/%d), retrying in %s: %v", attempt, attempts, delay, err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("gave up after %d attempts: %w", attempt, err)
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if delay > maxConnectRetryDelay {
+			delay = maxConnectRetryDelay
+		}
+	}
+	return fmt.Errorf("gave up after %d attempts: %w", attempts, err)
+}
+
+// MonitorHealth pings MongoDB at the configured interval and logs when the connection
+// is lost or restored, until ctx is cancelled
+// #IMPLEMENTATION_DECISION: Log-only - the driver reconnects by itself and the readiness
+// endpoint reports not-ready meanwhile, so the process keeps running through a blip
+func (c *Client) MonitorHealth(ctx context.Context) {
+	if c.config.HealthCheckInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(c.config.HealthCheckInterval)
+	defer ticker.Stop()
+
+	healthy := true
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := c.HealthCheck(ctx)
+		switch {
+		case err != nil && healthy:
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("MongoDB connection lost: %v", err)
+			healthy = false
+		case err == nil && !healthy:
+			log.Println("MongoDB connection restored")
+			healthy = true
+		}
+	}
+}
+
 // Database returns the MongoDB database
 func (c *Client) Database() *mongo.Database {
 	return c.database
@@ -194,13 +303,27 @@ func (c *Client) EnsureIndexes(ctx context.Context) error {
 					Keys:    bson.D{{Key: "domain", Value: 1}},
 					Options: options.Index().SetUnique(true).SetSparse(true),
 				},
+				{
+					// A verified domain belongs to at most one organization
+					Keys:    bson.D{{Key: "domains", Value: 1}},
+					Options: options.Index().SetUnique(true).SetSparse(true),
+				},
 				{
 					Keys: bson.D{{Key: "type", Value: 1}},
 				},
+				{
+					// Supplier name search
+					Keys:    bson.D{{Key: "name", Value: "text"}},
+					Options: options.Index().SetName("idx_name_text"),
+				},
 			},
 		},
 		{
 			collection: CollectionUsers,
+			// #INDEX_STRATEGY: The unique email index compares bytes; it is case-insensitive in effect
+			// because every write and lookup goes through models.NormalizeEmail
+			// #MIGRATION_DECISION: Users stored with mixed-case emails must be lowercased before they can
+			// be found again; duplicates differing only in case have to be merged by hand
 			models: []mongo.IndexModel{
 				{
 					Keys:    bson.D{{Key: "email", Value: 1}},
@@ -227,6 +350,62 @@ func (c *Client) EnsureIndexes(ctx context.Context) error {
 				},
 			},
 		},
+		{
+			collection: CollectionRefreshTokens,
+			models: []mongo.IndexModel{
+				{
+					Keys:    bson.D{{Key: "token_id", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys:    bson.D{{Key: "expires_at", Value: 1}},
+					Options: options.Index().SetExpireAfterSeconds(0), // TTL index
+				},
+				{
+					// Reuse detection revokes a whole token family
+					Keys: bson.D{{Key: "family_id", Value: 1}},
+				},
+				{
+					Keys: bson.D{{Key: "user_id", Value: 1}},
+				},
+			},
+		},
+		{
+			collection: CollectionRevokedTokens,
+			models: []mongo.IndexModel{
+				{
+					// User-wide revocations carry no token_id
+					Keys:    bson.D{{Key: "token_id", Value: 1}},
+					Options: options.Index().SetUnique(true).SetSparse(true),
+				},
+				{
+					Keys:    bson.D{{Key: "expires_at", Value: 1}},
+					Options: options.Index().SetExpireAfterSeconds(0), // TTL index
+				},
+				{
+					Keys: bson.D{
+						{Key: "user_id", Value: 1},
+						{Key: "created_at", Value: -1},
+					},
+				},
+			},
+		},
+		{
+			collection: CollectionAPIKeys,
+			models: []mongo.IndexModel{
+				{
+					// API key authentication looks keys up by hash
+					Keys:    bson.D{{Key: "key_hash", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys: bson.D{
+						{Key: "organization_id", Value: 1},
+						{Key: "created_at", Value: -1},
+					},
+				},
+			},
+		},
 		{
 			collection: CollectionQuestionnaireTemplates,
 			models: []mongo.IndexModel{
@@ -236,6 +415,29 @@ func (c *Client) EnsureIndexes(ctx context.Context) error {
 						{Key: "is_system", Value: 1},
 					},
 				},
+				{
+					Keys:    bson.D{{Key: "root_template_id", Value: 1}},
+					Options: options.Index().SetSparse(true),
+				},
+				{
+					// Multikey index for tag filtering (any/all)
+					Keys: bson.D{{Key: "tags", Value: 1}},
+				},
+				{
+					// Template search ranks name matches above tag and description matches
+					Keys: bson.D{
+						{Key: "name", Value: "text"},
+						{Key: "description", Value: "text"},
+						{Key: "tags", Value: "text"},
+					},
+					Options: options.Index().
+						SetName("idx_text_search").
+						SetWeights(bson.D{
+							{Key: "name", Value: 10},
+							{Key: "tags", Value: 5},
+							{Key: "description", Value: 1},
+						}),
+				},
 			},
 		},
 		{
@@ -247,6 +449,25 @@ func (c *Client) EnsureIndexes(ctx context.Context) error {
 						{Key: "status", Value: 1},
 					},
 				},
+				{
+					Keys: bson.D{
+						{Key: "company_id", Value: 1},
+						{Key: "applies_to", Value: 1},
+						{Key: "status", Value: 1},
+					},
+				},
+				{
+					// Global search; $text queries must match company_id exactly
+					Keys: bson.D{
+						{Key: "company_id", Value: 1},
+						{Key: "name", Value: "text"},
+					},
+					Options: options.Index().SetName("idx_company_name_text"),
+				},
+				{
+					Keys:    bson.D{{Key: "deleted_at", Value: 1}},
+					Options: options.Index().SetSparse(true),
+				},
 			},
 		},
 		{
@@ -258,6 +479,13 @@ func (c *Client) EnsureIndexes(ctx context.Context) error {
 						{Key: "order", Value: 1},
 					},
 				},
+				{
+					// Control reference search within a company's questionnaires
+					Keys: bson.D{
+						{Key: "questionnaire_id", Value: 1},
+						{Key: "control_ref", Value: 1},
+					},
+				},
 			},
 		},
 		{
@@ -273,6 +501,24 @@ func (c *Client) EnsureIndexes(ctx context.Context) error {
 				{
 					Keys: bson.D{{Key: "invited_email", Value: 1}},
 				},
+				{
+					// One open invitation or relationship per company and email
+					Keys: bson.D{
+						{Key: "company_id", Value: 1},
+						{Key: "invited_email", Value: 1},
+					},
+					Options: options.Index().
+						SetUnique(true).
+						SetName("idx_company_invited_email_open_unique").
+						SetPartialFilterExpression(openRelationshipFilter()),
+				},
+				{
+					// Keyset pagination of a company's suppliers
+					Keys: bson.D{
+						{Key: "company_id", Value: 1},
+						{Key: "_id", Value: -1},
+					},
+				},
 				{
 					Keys: bson.D{{Key: "status", Value: 1}},
 				},
@@ -288,12 +534,42 @@ func (c *Client) EnsureIndexes(ctx context.Context) error {
 						{Key: "due_date", Value: 1},
 					},
 				},
+				{
+					// Keyset pagination of a company's requirements
+					Keys: bson.D{
+						{Key: "company_id", Value: 1},
+						{Key: "_id", Value: -1},
+					},
+				},
 				{
 					Keys: bson.D{
 						{Key: "supplier_id", Value: 1},
 						{Key: "status", Value: 1},
 					},
 				},
+				{
+					// Supplier team members listing the requirements assigned to them
+					Keys: bson.D{
+						{Key: "supplier_id", Value: 1},
+						{Key: "assigned_user_id", Value: 1},
+						{Key: "due_date", Value: 1},
+					},
+				},
+				{
+					// Per-relationship requirement counts in supplier exports
+					Keys: bson.D{
+						{Key: "relationship_id", Value: 1},
+						{Key: "status", Value: 1},
+					},
+				},
+				{
+					// Global search; $text queries must match company_id exactly
+					Keys: bson.D{
+						{Key: "company_id", Value: 1},
+						{Key: "title", Value: "text"},
+					},
+					Options: options.Index().SetName("idx_company_title_text"),
+				},
 			},
 		},
 		{
@@ -318,15 +594,25 @@ func (c *Client) EnsureIndexes(ctx context.Context) error {
 				{
 					Keys: bson.D{{Key: "questionnaire_id", Value: 1}},
 				},
+				{
+					Keys: bson.D{{Key: "company_id", Value: 1}, {Key: "submitted_at", Value: -1}},
+				},
 			},
 		},
 		{
 			collection: CollectionCheckFixVerifications,
+			// #INDEX_STRATEGY: One verification per response; a supplier accumulates a history that is
+			// read newest first, so supplier_id is paired with verified_at instead of being unique
+			// #MIGRATION_DECISION: The former unique supplier_id_1 index is dropped by the
+			// 0004_drop_verification_supplier_unique migration
 			models: []mongo.IndexModel{
 				{
-					Keys:    bson.D{{Key: "supplier_id", Value: 1}},
+					Keys:    bson.D{{Key: "response_id", Value: 1}},
 					Options: options.Index().SetUnique(true),
 				},
+				{
+					Keys: bson.D{{Key: "supplier_id", Value: 1}, {Key: "verified_at", Value: -1}},
+				},
 				{
 					Keys: bson.D{{Key: "expires_at", Value: 1}},
 				},
@@ -344,11 +630,123 @@ func (c *Client) EnsureIndexes(ctx context.Context) error {
 						{Key: "resource_id", Value: 1},
 					},
 				},
+				{
+					// Organization audit log listings, optionally bounded by a time range
+					Keys: bson.D{
+						{Key: "actor_org_id", Value: 1},
+						{Key: "created_at", Value: -1},
+					},
+				},
 				{
 					Keys: bson.D{{Key: "created_at", Value: -1}},
 				},
 			},
 		},
+		{
+			collection: CollectionWebhookEvents,
+			models: []mongo.IndexModel{
+				{
+					// Claims rely on this index to deduplicate deliveries
+					Keys: bson.D{
+						{Key: "source", Value: 1},
+						{Key: "event_id", Value: 1},
+					},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys:    bson.D{{Key: "expires_at", Value: 1}},
+					Options: options.Index().SetExpireAfterSeconds(0),
+				},
+			},
+		},
+		{
+			collection: CollectionWebhooks,
+			models: []mongo.IndexModel{
+				{
+					// Dispatch looks up an organization's subscribers for one event
+					Keys: bson.D{
+						{Key: "organization_id", Value: 1},
+						{Key: "events", Value: 1},
+					},
+				},
+				{
+					Keys: bson.D{
+						{Key: "organization_id", Value: 1},
+						{Key: "created_at", Value: -1},
+					},
+				},
+			},
+		},
+		{
+			collection: CollectionEmailOutbox,
+			models: []mongo.IndexModel{
+				{
+					// The outbox worker claims the oldest due pending email
+					Keys: bson.D{
+						{Key: "status", Value: 1},
+						{Key: "next_attempt_at", Value: 1},
+					},
+				},
+				{
+					Keys: bson.D{
+						{Key: "organization_id", Value: 1},
+						{Key: "status", Value: 1},
+						{Key: "failed_at", Value: -1},
+					},
+				},
+				{
+					// Only sent emails carry sent_at, so pending and failed ones never expire
+					Keys:    bson.D{{Key: "sent_at", Value: 1}},
+					Options: options.Index().SetExpireAfterSeconds(int32(models.OutboxEmailSentRetention.Seconds())),
+				},
+			},
+		},
+		{
+			collection: CollectionSentEmails,
+			models: []mongo.IndexModel{
+				{
+					// Admins look up what was sent to one recipient
+					Keys: bson.D{
+						{Key: "organization_id", Value: 1},
+						{Key: "recipient", Value: 1},
+						{Key: "created_at", Value: -1},
+					},
+				},
+				{
+					Keys: bson.D{
+						{Key: "organization_id", Value: 1},
+						{Key: "created_at", Value: -1},
+					},
+				},
+				{
+					Keys:    bson.D{{Key: "provider_message_id", Value: 1}},
+					Options: options.Index().SetSparse(true),
+				},
+				{
+					Keys:    bson.D{{Key: "created_at", Value: 1}},
+					Options: options.Index().SetExpireAfterSeconds(int32(models.SentEmailRetention.Seconds())),
+				},
+			},
+		},
+		{
+			collection: CollectionIdempotencyKeys,
+			models: []mongo.IndexModel{
+				{
+					// Keys are scoped per organization and route
+					Keys: bson.D{
+						{Key: "organization_id", Value: 1},
+						{Key: "route", Value: 1},
+						{Key: "key", Value: 1},
+					},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					// TTL index - removes keys once expires_at has passed
+					Keys:    bson.D{{Key: "expires_at", Value: 1}},
+					Options: options.Index().SetExpireAfterSeconds(0),
+				},
+			},
+		},
 	}
 
 	for _, idx := range indexes {
@@ -362,6 +760,22 @@ func (c *Client) EnsureIndexes(ctx context.Context) error {
 	return nil
 }
 
+// openRelationshipFilter matches relationships that still block a new invitation to the same email
+// #INDEX_STRATEGY: Partial filters cannot express $ne, so non-terminated statuses are listed with $in
+// (MongoDB 6.0+). Relationships are never soft-deleted - terminating one frees the email for re-invites
+// #MIGRATION_DECISION: Index creation fails at startup if duplicate open invitations already exist;
+// terminate the duplicates before deploying
+func openRelationshipFilter() bson.M {
+	return bson.M{
+		"status": bson.M{"$in": bson.A{
+			models.RelationshipStatusPending,
+			models.RelationshipStatusActive,
+			models.RelationshipStatusRejected,
+			models.RelationshipStatusSuspended,
+		}},
+	}
+}
+
 // SeedData seeds initial data including questionnaire templates
 // #IMPLEMENTATION_DECISION: Only seeds if data doesn't exist (idempotent)
 func (c *Client) SeedData(ctx context.Context) error {
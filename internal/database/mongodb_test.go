@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPingWithRetry(t *testing.T) {
+	errDown := errors.New("connection refused")
+
+	tests := []struct {
+		name      string
+		failures  int
+		attempts  int
+		wantCalls int
+		wantErr   bool
+	}{
+		{"succeeds first time", 0, 3, 1, false},
+		{"recovers after retries", 2, 3, 3, false},
+		{"gives up after attempts", 5, 3, 3, true},
+		{"zero attempts still pings once", 5, 0, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			ping := func(context.Context) error {
+				calls++
+				if calls <= tt.failures {
+					return errDown
+				}
+				return nil
+			}
+
+			err := pingWithRetry(context.Background(), ping, tt.attempts, time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, errDown) {
+				t.Errorf("Expected wrapped ping error, got %v", err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("Expected %d pings, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+// Package handlers provides HTTP handlers for API endpoints.
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// APIKeyHandler handles organization API key endpoints
+// #INTEGRATION_POINT: CI pipelines authenticate with keys minted here
+type APIKeyHandler struct {
+	apiKeyService services.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(apiKeyService services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// CreateAPIKeyRequest represents the create API key request body
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []string   `json:"scopes" binding:"required"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKeyResponse represents an API key in API responses
+type APIKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse includes the plaintext key, which is only returned once
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// CreateAPIKey handles POST /api/v1/organization/api-keys
+// @Summary Create an API key
+// @Description Mints an API key for machine-to-machine access. The plaintext key is only returned in this response.
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateAPIKeyRequest true "API key request"
+// @Success 201 {object} CreateAPIKeyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	role, _ := middleware.GetRole(c)
+
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Name and scopes are required",
+		})
+		return
+	}
+
+	scopes := make([]models.APIKeyScope, len(req.Scopes))
+	for i, s := range req.Scopes {
+		scopes[i] = models.APIKeyScope(strings.ToLower(s))
+	}
+
+	key, plaintext, err := h.apiKeyService.CreateAPIKey(c.Request.Context(), orgID, userID, role, services.CreateAPIKeyRequest{
+		Name:      req.Name,
+		Scopes:    scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAPIKeyScope) {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_scope",
+				Message: "At least one valid scope is required",
+			})
+			return
+		}
+		if errors.Is(err, services.ErrInvalidAPIKeyTTL) {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_expiry",
+				Message: "expires_at must be in the future",
+			})
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to create API key",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreateAPIKeyResponse{
+		APIKeyResponse: toAPIKeyResponse(key),
+		Key:            plaintext,
+	})
+}
+
+// ListAPIKeys handles GET /api/v1/organization/api-keys
+// @Summary List API keys
+// @Description Lists API keys of the current organization, including revoked keys
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} APIKeyResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	keys, err := h.apiKeyService.ListAPIKeys(c.Request.Context(), orgID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to list API keys",
+		})
+		return
+	}
+
+	items := make([]APIKeyResponse, len(keys))
+	for i := range keys {
+		items[i] = toAPIKeyResponse(&keys[i])
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// RevokeAPIKey handles DELETE /api/v1/organization/api-keys/:id
+// @Summary Revoke an API key
+// @Description Revokes an API key so it can no longer authenticate
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "API key ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /organization/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	keyID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid API key ID",
+		})
+		return
+	}
+
+	if err := h.apiKeyService.RevokeAPIKey(c.Request.Context(), orgID, keyID); err != nil {
+		if errors.Is(err, services.ErrAPIKeyNotFound) {
+			writeError(c, http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "API key not found",
+			})
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to revoke API key",
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RegisterRoutes registers API key handler routes
+// #SECURITY_ASSUMPTION: Key management is restricted to organization admins with an interactive session
+func (h *APIKeyHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	keys := rg.Group("/organization/api-keys")
+	keys.Use(authMiddleware)
+	keys.Use(middleware.RequireAdmin())
+	keys.POST("", h.CreateAPIKey)
+	keys.GET("", h.ListAPIKeys)
+	keys.DELETE("/:id", h.RevokeAPIKey)
+}
+
+// toAPIKeyResponse converts an API key model to response
+func toAPIKeyResponse(k *models.APIKey) APIKeyResponse {
+	scopes := make([]string, len(k.Scopes))
+	for i, s := range k.Scopes {
+		scopes[i] = string(s)
+	}
+
+	return APIKeyResponse{
+		ID:         k.ID.Hex(),
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
@@ -0,0 +1,237 @@
+// Package handlers provides HTTP handlers for API endpoints.
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// AuditHandler handles organization audit log endpoints
+// #INTEGRATION_POINT: Compliance view of who changed what within an organization
+type AuditHandler struct {
+	auditService services.AuditService
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(auditService services.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// AuditLogResponse represents an audit log entry in API responses
+type AuditLogResponse struct {
+	ID           string                 `json:"id"`
+	ActorUserID  *string                `json:"actor_user_id,omitempty"`
+	ActorEmail   string                 `json:"actor_email,omitempty"`
+	Action       string                 `json:"action"`
+	ResourceType string                 `json:"resource_type"`
+	ResourceID   string                 `json:"resource_id"`
+	Description  string                 `json:"description"`
+	Changes      map[string]interface{} `json:"changes,omitempty"`
+	IPAddress    string                 `json:"ip_address,omitempty"`
+	RequestID    string                 `json:"request_id,omitempty"`
+	CreatedAt    time.Time              `json:"created_at"`
+}
+
+// PaginatedAuditLogsResponse represents paginated audit log entries
+type PaginatedAuditLogsResponse struct {
+	Items      []AuditLogResponse `json:"items"`
+	TotalCount int64              `json:"total_count"`
+	Page       int                `json:"page"`
+	Limit      int                `json:"limit"`
+	TotalPages int                `json:"total_pages"`
+}
+
+// ListAuditLogs handles GET /api/v1/organization/audit-logs
+// @Summary List audit logs
+// @Description Lists audit log entries recorded for the current organization, newest first
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param action query string false "Filter by action (e.g. create, update, suspend)"
+// @Param resource_type query string false "Filter by resource type (e.g. relationship, requirement, template)"
+// @Param actor query string false "Filter by acting user ID"
+// @Param from query string false "Only entries at or after this time (RFC 3339)"
+// @Param to query string false "Only entries at or before this time (RFC 3339)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} PaginatedAuditLogsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/audit-logs [get]
+func (h *AuditHandler) ListAuditLogs(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	var filter repository.AuditLogFilter
+
+	if actionStr := c.Query("action"); actionStr != "" {
+		action := models.AuditAction(strings.ToUpper(actionStr))
+		if !action.IsValid() {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_action",
+				Message: "Invalid audit action",
+			})
+			return
+		}
+		filter.Action = &action
+	}
+
+	filter.ResourceType = strings.ToLower(c.Query("resource_type"))
+
+	if actor := c.Query("actor"); actor != "" {
+		actorID, err := primitive.ObjectIDFromHex(actor)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_actor",
+				Message: "Invalid actor user ID",
+			})
+			return
+		}
+		filter.ActorUserID = &actorID
+	}
+
+	from, to, ok := parseTimeRange(c)
+	if !ok {
+		return
+	}
+	filter.From, filter.To = from, to
+
+	opts := repository.DefaultPaginationOptions()
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		opts.Page = page
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 && limit <= 100 {
+		opts.Limit = limit
+	}
+
+	result, err := h.auditService.ListByOrganization(c.Request.Context(), orgID, filter, opts)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to list audit logs",
+		})
+		return
+	}
+
+	items := make([]AuditLogResponse, len(result.Items))
+	for i := range result.Items {
+		items[i] = toAuditLogResponse(&result.Items[i])
+	}
+
+	setPaginationHeaders(c, result)
+	c.JSON(http.StatusOK, PaginatedAuditLogsResponse{
+		Items:      items,
+		TotalCount: result.TotalCount,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		TotalPages: result.TotalPages,
+	})
+}
+
+// RegisterRoutes registers audit handler routes
+// #SECURITY_CONCERN: Audit logs include IP addresses and are restricted to organization admins
+func (h *AuditHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	auditLogs := rg.Group("/organization/audit-logs")
+	auditLogs.Use(authMiddleware)
+	auditLogs.Use(middleware.RequireAdmin())
+	auditLogs.GET("", h.ListAuditLogs)
+}
+
+// auditRequestInfo attaches the authenticated actor and request metadata to the
+// request context so services can attribute the audit entries they emit.
+// Must run after the auth middleware.
+func auditRequestInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		info := services.AuditRequestInfo{
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			RequestID: middleware.GetRequestID(c),
+		}
+		if userID, ok := middleware.GetUserID(c); ok {
+			info.ActorUserID = &userID
+		}
+		if orgID, ok := middleware.GetOrgID(c); ok {
+			info.ActorOrgID = &orgID
+		}
+
+		c.Request = c.Request.WithContext(services.WithAuditRequestInfo(c.Request.Context(), info))
+		c.Next()
+	}
+}
+
+// toAuditLogResponse converts an audit log model to response
+func toAuditLogResponse(a *models.AuditLog) AuditLogResponse {
+	resp := AuditLogResponse{
+		ID:           a.ID.Hex(),
+		ActorEmail:   a.ActorEmail,
+		Action:       strings.ToLower(string(a.Action)),
+		ResourceType: a.ResourceType,
+		ResourceID:   a.ResourceID.Hex(),
+		Description:  a.Description,
+		Changes:      a.Changes,
+		IPAddress:    a.IPAddress,
+		RequestID:    a.RequestID,
+		CreatedAt:    a.CreatedAt,
+	}
+	if a.ActorUserID != nil {
+		actorID := a.ActorUserID.Hex()
+		resp.ActorUserID = &actorID
+	}
+	return resp
+}
+
+// parseTimeRange reads the optional RFC 3339 "from" and "to" query parameters,
+// writing the error response when one is invalid or the range is inverted
+func parseTimeRange(c *gin.Context) (from, to *time.Time, ok bool) {
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+	}{
+		{"from", &from},
+		{"to", &to},
+	} {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_date",
+				Message: "Dates must be in RFC 3339 format",
+			})
+			return nil, nil, false
+		}
+		*param.target = &t
+	}
+
+	if from != nil && to != nil && to.Before(*from) {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_date",
+			Message: "'to' must not be before 'from'",
+		})
+		return nil, nil, false
+	}
+
+	return from, to, true
+}
@@ -4,10 +4,15 @@ package handlers
 
 import (
 	"errors"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/checkfix-tools/nisfix_backend/internal/auth"
 	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
 	"github.com/checkfix-tools/nisfix_backend/internal/models"
 	"github.com/checkfix-tools/nisfix_backend/internal/services"
@@ -16,13 +21,39 @@ import (
 // AuthHandler handles authentication endpoints
 // #INTEGRATION_POINT: Frontend auth flow uses these endpoints
 type AuthHandler struct {
-	authService services.AuthService
+	authService  services.AuthService
+	oidcProvider *auth.OIDCProvider
+	rateLimit    gin.HandlerFunc
+	loginLimit   middleware.RateLimitStore
 }
 
+// Password login attempts allowed per email address within loginAttemptWindow
+const (
+	loginAttemptsPerEmail = 5
+	loginAttemptWindow    = 15 * time.Minute
+)
+
+// oidcNonceCookie binds an SSO login to the browser that started it
+// #SECURITY_ASSUMPTION: SameSite=Lax still sends the cookie on the identity provider's top-level
+// redirect back to the callback, but not on cross-site subrequests
+const (
+	oidcNonceCookie     = "nisfix_oidc_nonce"
+	oidcNonceCookiePath = "/api/v1/auth/oidc"
+)
+
 // NewAuthHandler creates a new auth handler
-func NewAuthHandler(authService services.AuthService) *AuthHandler {
+// #IMPLEMENTATION_DECISION: oidcProvider is nil when SSO is not configured; OIDC routes are then not registered
+// rateLimit guards the public magic-link and login endpoints per IP; nil disables it
+// loginLimit counts password login attempts per email; nil disables it
+func NewAuthHandler(authService services.AuthService, oidcProvider *auth.OIDCProvider, rateLimit gin.HandlerFunc, loginLimit middleware.RateLimitStore) *AuthHandler {
+	if rateLimit == nil {
+		rateLimit = func(c *gin.Context) { c.Next() }
+	}
 	return &AuthHandler{
-		authService: authService,
+		authService:  authService,
+		oidcProvider: oidcProvider,
+		rateLimit:    rateLimit,
+		loginLimit:   loginLimit,
 	}
 }
 
@@ -50,7 +81,7 @@ type RequestMagicLinkResponse struct {
 func (h *AuthHandler) RequestMagicLink(c *gin.Context) {
 	var req RequestMagicLinkRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Invalid email address",
 		})
@@ -60,7 +91,7 @@ func (h *AuthHandler) RequestMagicLink(c *gin.Context) {
 	err := h.authService.RequestMagicLink(c.Request.Context(), req.Email)
 	if err != nil {
 		if errors.Is(err, services.ErrRateLimitExceeded) {
-			c.JSON(http.StatusTooManyRequests, ErrorResponse{
+			writeError(c, http.StatusTooManyRequests, ErrorResponse{
 				Error:   "rate_limit_exceeded",
 				Message: "Too many magic link requests. Please try again later.",
 			})
@@ -102,11 +133,12 @@ type VerifyMagicLinkResponse struct {
 // @Success 200 {object} VerifyMagicLinkResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
 // @Router /auth/verify [post]
 func (h *AuthHandler) VerifyMagicLink(c *gin.Context) {
 	var req VerifyMagicLinkRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Token is required",
 		})
@@ -125,7 +157,7 @@ func (h *AuthHandler) VerifyMagicLink(c *gin.Context) {
 			message = "Account is not available"
 		}
 
-		c.JSON(statusCode, ErrorResponse{
+		writeError(c, statusCode, ErrorResponse{
 			Error:   "authentication_failed",
 			Message: message,
 		})
@@ -142,6 +174,270 @@ func (h *AuthHandler) VerifyMagicLink(c *gin.Context) {
 	})
 }
 
+// LoginRequest represents the password login request body
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// Login handles POST /api/v1/auth/login
+// @Summary Log in with a password
+// @Description Signs in with email and password, a fallback for users who cannot receive magic links.
+// @Description Requires password login to be enabled for the deployment and the user's organization.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body LoginRequest true "Login request"
+// @Success 200 {object} VerifyMagicLinkResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Email and password are required",
+		})
+		return
+	}
+
+	// #SECURITY_CONCERN: The per-IP limit alone lets a botnet guess one account's password;
+	// attempts are also counted per email
+	if h.loginLimit != nil {
+		key := "login|" + models.NormalizeEmail(req.Email)
+		allowed, retryAfter, err := h.loginLimit.Allow(c.Request.Context(), key, loginAttemptsPerEmail, loginAttemptWindow)
+		if err == nil && !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			writeError(c, http.StatusTooManyRequests, ErrorResponse{
+				Error:   "rate_limit_exceeded",
+				Message: "Too many login attempts. Please try again later.",
+			})
+			return
+		}
+	}
+
+	tokenPair, user, org, err := h.authService.LoginWithPassword(c.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPasswordLoginDisabled):
+			writeError(c, http.StatusForbidden, ErrorResponse{
+				Error:   "password_login_disabled",
+				Message: "Password login is not enabled",
+			})
+		case errors.Is(err, services.ErrInvalidCredentials):
+			writeError(c, http.StatusUnauthorized, ErrorResponse{
+				Error:   "authentication_failed",
+				Message: "Invalid email or password",
+			})
+		default:
+			writeError(c, http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to log in",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, VerifyMagicLinkResponse{
+		AccessToken:  tokenPair.AccessToken,
+		RefreshToken: tokenPair.RefreshToken,
+		ExpiresAt:    tokenPair.ExpiresAt.Unix(),
+		ExpiresIn:    tokenPair.ExpiresIn,
+		User:         user,
+		Organization: org,
+	})
+}
+
+// SetPasswordRequest represents the set password request body
+type SetPasswordRequest struct {
+	// CurrentPassword is required when the user already has a password
+	CurrentPassword string `json:"current_password"`
+	Password        string `json:"password" binding:"required"`
+}
+
+// SetPasswordResponse represents the set password response with the tokens replacing the current session's
+type SetPasswordResponse struct {
+	Message      string `json:"message"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresAt    int64  `json:"expires_at"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// SetPassword handles POST /api/v1/auth/set-password
+// @Summary Set a password
+// @Description Sets or replaces the current user's password for password login, typically right after
+// @Description signing in with a magic link. Replacing an existing password requires the current one.
+// @Description Signs out the user's other sessions and returns new tokens for this one. Requires
+// @Description password login to be enabled for the deployment and the user's organization.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body SetPasswordRequest true "New password"
+// @Success 200 {object} SetPasswordResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /auth/set-password [post]
+func (h *AuthHandler) SetPassword(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	var req SetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Password is required",
+		})
+		return
+	}
+
+	tokenPair, err := h.authService.SetPassword(c.Request.Context(), userID, req.CurrentPassword, req.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidPassword):
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_password",
+				Message: err.Error(),
+			})
+		case errors.Is(err, services.ErrWrongCurrentPassword):
+			writeError(c, http.StatusForbidden, ErrorResponse{
+				Error:   "invalid_current_password",
+				Message: "Current password is incorrect",
+			})
+		case errors.Is(err, services.ErrPasswordLoginDisabled):
+			writeError(c, http.StatusForbidden, ErrorResponse{
+				Error:   "password_login_disabled",
+				Message: "Password login is not enabled for your organization",
+			})
+		case errors.Is(err, services.ErrUserNotFound), errors.Is(err, services.ErrOrganizationNotFound):
+			writeError(c, http.StatusUnauthorized, ErrorResponse{
+				Error:   "unauthorized",
+				Message: "User not found",
+			})
+		default:
+			writeError(c, http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to set password",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, SetPasswordResponse{
+		Message:      "Password set",
+		AccessToken:  tokenPair.AccessToken,
+		RefreshToken: tokenPair.RefreshToken,
+		ExpiresAt:    tokenPair.ExpiresAt.Unix(),
+		ExpiresIn:    tokenPair.ExpiresIn,
+	})
+}
+
+// OIDCLogin handles GET /api/v1/auth/oidc/login
+// @Summary Start SSO login
+// @Description Redirects to the configured OpenID Connect identity provider
+// @Tags Auth
+// @Success 302 "Redirect to identity provider"
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/oidc/login [get]
+func (h *AuthHandler) OIDCLogin(c *gin.Context) {
+	url, nonce, err := h.oidcProvider.AuthCodeURL()
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to start SSO login",
+		})
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcNonceCookie, nonce, int(auth.OIDCStateExpiry.Seconds()), oidcNonceCookiePath, "", true, true)
+	c.Redirect(http.StatusFound, url)
+}
+
+// OIDCCallback handles GET /api/v1/auth/oidc/callback
+// @Summary Complete SSO login
+// @Description Exchanges the identity provider authorization code and returns access/refresh tokens.
+// @Description The email must belong to an existing user unless auto-provisioning is enabled.
+// @Description Must be reached from the browser that started the login, which holds its nonce cookie.
+// @Tags Auth
+// @Produce json
+// @Param code query string true "Authorization code"
+// @Param state query string true "Signed state from the login redirect"
+// @Success 200 {object} VerifyMagicLinkResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /auth/oidc/callback [get]
+func (h *AuthHandler) OIDCCallback(c *gin.Context) {
+	// #SECURITY_ASSUMPTION: error and error_description are attacker-controllable query parameters,
+	// so they are only logged; the client gets a fixed code and message
+	if idpErr := c.Query("error"); idpErr != "" {
+		log.Printf("OIDC callback: identity provider returned error %q (%q), request %s",
+			idpErr, c.Query("error_description"), middleware.GetRequestID(c))
+		message := "Identity provider could not complete the sign-in"
+		if idpErr == "access_denied" {
+			message = "Sign-in was cancelled or denied at the identity provider"
+		}
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "authentication_failed",
+			Message: message,
+		})
+		return
+	}
+
+	code := c.Query("code")
+	state := c.Query("state")
+	if code == "" || state == "" {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "code and state are required",
+		})
+		return
+	}
+
+	// The nonce is single-use; clear it whatever the outcome
+	nonce, _ := c.Cookie(oidcNonceCookie)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcNonceCookie, "", -1, oidcNonceCookiePath, "", true, true)
+
+	identity, err := h.oidcProvider.Exchange(c.Request.Context(), code, state, nonce)
+	if err != nil {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "authentication_failed",
+			Message: "SSO login could not be verified",
+		})
+		return
+	}
+
+	tokenPair, user, org, err := h.authService.LoginWithOIDC(c.Request.Context(), identity.Email, identity.Name)
+	if err != nil {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "authentication_failed",
+			Message: "Account is not available",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, VerifyMagicLinkResponse{
+		AccessToken:  tokenPair.AccessToken,
+		RefreshToken: tokenPair.RefreshToken,
+		ExpiresAt:    tokenPair.ExpiresAt.Unix(),
+		ExpiresIn:    tokenPair.ExpiresIn,
+		User:         user,
+		Organization: org,
+	})
+}
+
 // RefreshTokenRequest represents the refresh token request body
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
@@ -157,7 +453,8 @@ type RefreshTokenResponse struct {
 
 // RefreshToken handles POST /api/v1/auth/refresh
 // @Summary Refresh access token
-// @Description Uses refresh token to generate new access/refresh token pair
+// @Description Exchanges a single-use refresh token for a new access/refresh token pair.
+// @Description Reusing an already-exchanged refresh token revokes the whole token chain.
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -169,7 +466,7 @@ type RefreshTokenResponse struct {
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req RefreshTokenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Refresh token is required",
 		})
@@ -178,7 +475,14 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 	tokenPair, err := h.authService.RefreshAccessToken(c.Request.Context(), req.RefreshToken)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		if errors.Is(err, services.ErrRefreshTokenReused) {
+			writeError(c, http.StatusUnauthorized, ErrorResponse{
+				Error:   "refresh_token_reused",
+				Message: "Refresh token has already been used; please sign in again",
+			})
+			return
+		}
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "invalid_refresh_token",
 			Message: "Invalid or expired refresh token",
 		})
@@ -193,6 +497,11 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	})
 }
 
+// LogoutRequest represents the optional logout request body
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
 // LogoutResponse represents the logout response
 type LogoutResponse struct {
 	Message string `json:"message"`
@@ -200,29 +509,39 @@ type LogoutResponse struct {
 
 // Logout handles POST /api/v1/auth/logout
 // @Summary Logout user
-// @Description Invalidates the user's session (client should discard tokens)
+// @Description Revokes the current access token server-side. When a refresh token is
+// @Description supplied, its whole rotation chain is revoked as well.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param request body LogoutRequest false "Optional refresh token to revoke"
 // @Success 200 {object} LogoutResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
 // @Router /auth/logout [post]
 func (h *AuthHandler) Logout(c *gin.Context) {
-	userID, ok := middleware.GetUserID(c)
+	claims, ok := middleware.GetClaims(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
 		return
 	}
 
-	// Invalidate refresh token (server-side cleanup if implemented)
-	// Error is intentionally ignored as logout should succeed regardless
-	if err := h.authService.InvalidateRefreshToken(c.Request.Context(), userID); err != nil {
-		// Log error but don't fail logout
-		_ = err
+	// Body is optional - a missing or malformed body only skips refresh token revocation
+	var req LogoutRequest
+	if c.Request.ContentLength > 0 {
+		_ = c.ShouldBindJSON(&req)
+	}
+
+	if err := h.authService.Logout(c.Request.Context(), claims, req.RefreshToken); err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to revoke session",
+		})
+		return
 	}
 
 	c.JSON(http.StatusOK, LogoutResponse{
@@ -249,7 +568,7 @@ type GetMeResponse struct {
 func (h *AuthHandler) GetMe(c *gin.Context) {
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -258,7 +577,7 @@ func (h *AuthHandler) GetMe(c *gin.Context) {
 
 	user, org, err := h.authService.GetUserContext(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "User not found",
 		})
@@ -276,17 +595,27 @@ func (h *AuthHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.Han
 	auth := rg.Group("/auth")
 
 	// Public endpoints
-	auth.POST("/magic-link", h.RequestMagicLink)
-	auth.POST("/verify", h.VerifyMagicLink)
+	// #SECURITY_CONCERN: Per-IP limit prevents email enumeration and link brute forcing
+	auth.POST("/magic-link", h.rateLimit, h.RequestMagicLink)
+	auth.POST("/verify", h.rateLimit, h.VerifyMagicLink)
+	auth.POST("/login", h.rateLimit, h.Login)
 	auth.POST("/refresh", h.RefreshToken)
 
+	// SSO endpoints (only when an identity provider is configured)
+	if h.oidcProvider != nil {
+		auth.GET("/oidc/login", h.OIDCLogin)
+		auth.GET("/oidc/callback", h.OIDCCallback)
+	}
+
 	// Protected endpoints
 	auth.POST("/logout", authMiddleware, h.Logout)
 	auth.GET("/me", authMiddleware, h.GetMe)
+	auth.POST("/set-password", authMiddleware, h.SetPassword)
 }
 
 // ErrorResponse represents an API error response
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
+	Error     string `json:"error"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
 }
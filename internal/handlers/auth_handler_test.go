@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAuthHandler_OIDCCallback_IdentityProviderError(t *testing.T) {
+	handler := &AuthHandler{}
+	router := gin.New()
+	router.GET("/auth/oidc/callback", handler.OIDCCallback)
+
+	tests := []struct {
+		idpErr      string
+		wantMessage string
+	}{
+		{"access_denied", "Sign-in was cancelled or denied at the identity provider"},
+		{"<script>alert(1)</script>", "Identity provider could not complete the sign-in"},
+	}
+
+	for _, tt := range tests {
+		query := url.Values{"error": {tt.idpErr}, "error_description": {"Visit evil.example to fix your account"}}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/auth/oidc/callback?"+query.Encode(), http.NoBody))
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%q: expected status %d, got %d", tt.idpErr, http.StatusUnauthorized, w.Code)
+		}
+		var response ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if response.Error != "authentication_failed" || response.Message != tt.wantMessage {
+			t.Errorf("%q: expected a fixed error, got %+v", tt.idpErr, response)
+		}
+		// Nothing the identity provider sent is echoed back
+		if body := w.Body.String(); strings.Contains(body, "evil.example") || strings.Contains(body, "script") {
+			t.Errorf("%q: expected the IdP values not to be echoed, got %s", tt.idpErr, body)
+		}
+	}
+}
@@ -4,6 +4,7 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
 	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
 	"github.com/checkfix-tools/nisfix_backend/internal/services"
 )
 
@@ -32,6 +34,7 @@ type CheckFixStatusResponse struct {
 	IsLinked         bool                          `json:"is_linked"`
 	AccountID        string                        `json:"account_id,omitempty"`
 	Domain           string                        `json:"domain,omitempty"`
+	Domains          []string                      `json:"domains,omitempty"`
 	LinkedAt         *time.Time                    `json:"linked_at,omitempty"`
 	LatestGrade      *string                       `json:"latest_grade,omitempty"`
 	LatestVerifiedAt *time.Time                    `json:"latest_verified_at,omitempty"`
@@ -45,6 +48,7 @@ type CheckFixVerificationResponse struct {
 	Domain           string                  `json:"domain"`
 	VerifiedDomain   string                  `json:"verified_domain"`
 	DomainMatch      bool                    `json:"domain_match"`
+	MatchedDomain    string                  `json:"matched_domain,omitempty"`
 	ReportHash       string                  `json:"report_hash"`
 	ReportDate       time.Time               `json:"report_date"`
 	OverallGrade     string                  `json:"overall_grade"`
@@ -60,6 +64,29 @@ type CheckFixVerificationResponse struct {
 	DaysUntilExpiry  int                     `json:"days_until_expiry"`
 }
 
+// PaginatedVerificationsResponse represents a page of a supplier's verification history
+type PaginatedVerificationsResponse struct {
+	Items      []CheckFixVerificationResponse `json:"items"`
+	TotalCount int64                          `json:"total_count"`
+	Page       int                            `json:"page"`
+	Limit      int                            `json:"limit"`
+	TotalPages int                            `json:"total_pages"`
+}
+
+// GradeTrendPointResponse represents one verification in a grade trend
+type GradeTrendPointResponse struct {
+	VerifiedAt   time.Time `json:"verified_at"`
+	Grade        string    `json:"grade"`
+	OverallScore int       `json:"overall_score"`
+}
+
+// GradeTrendResponse represents a supplier's CheckFix grade trend
+type GradeTrendResponse struct {
+	SupplierID *string                   `json:"supplier_id,omitempty"`
+	Points     []GradeTrendPointResponse `json:"points"`
+	Trend      string                    `json:"trend"`
+}
+
 // CategoryGradeResponse represents a category grade
 type CategoryGradeResponse struct {
 	Category string `json:"category"`
@@ -72,6 +99,17 @@ type LinkAccountRequest struct {
 	AccountID string `json:"account_id" binding:"required"`
 }
 
+// DomainRequest represents a request to add a verified domain
+type DomainRequest struct {
+	Domain string `json:"domain" binding:"required"`
+}
+
+// CheckFixDomainsResponse lists a supplier's verified domains
+type CheckFixDomainsResponse struct {
+	Domain  string   `json:"domain"`
+	Domains []string `json:"domains"`
+}
+
 // VerifyReportRequest represents the verify report request
 type VerifyReportRequest struct {
 	ReportHash string `json:"report_hash" binding:"required"`
@@ -90,7 +128,7 @@ type CheckFixSubmissionResponse struct {
 	Verification *CheckFixVerificationResponse `json:"verification"`
 }
 
-// GetStatus handles GET /api/v1/supplier/checkfix/status
+// GetStatus handles GET /api/v1/supplier/checkfix and GET /api/v1/supplier/checkfix/status
 // @Summary Get CheckFix status
 // @Description Gets the current CheckFix integration status for the supplier
 // @Tags CheckFix
@@ -100,11 +138,12 @@ type CheckFixSubmissionResponse struct {
 // @Success 200 {object} CheckFixStatusResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
+// @Router /supplier/checkfix [get]
 // @Router /supplier/checkfix/status [get]
 func (h *CheckFixHandler) GetStatus(c *gin.Context) {
 	supplierID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -113,7 +152,7 @@ func (h *CheckFixHandler) GetStatus(c *gin.Context) {
 
 	status, err := h.checkFixService.GetLinkStatus(c.Request.Context(), supplierID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to get CheckFix status",
 		})
@@ -124,6 +163,7 @@ func (h *CheckFixHandler) GetStatus(c *gin.Context) {
 		IsLinked:  status.IsLinked,
 		AccountID: status.AccountID,
 		Domain:    status.Domain,
+		Domains:   status.Domains,
 		LinkedAt:  status.LinkedAt,
 	}
 
@@ -141,6 +181,61 @@ func (h *CheckFixHandler) GetStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// ListVerifications handles GET /api/v1/supplier/checkfix/verifications
+// @Summary List CheckFix verification history
+// @Description Lists the supplier's own CheckFix verifications, newest first
+// @Tags CheckFix
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} PaginatedVerificationsResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /supplier/checkfix/verifications [get]
+func (h *CheckFixHandler) ListVerifications(c *gin.Context) {
+	supplierID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	opts := repository.DefaultPaginationOptions()
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		opts.Page = page
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 && limit <= 100 {
+		opts.Limit = limit
+	}
+
+	result, err := h.checkFixService.ListVerifications(c.Request.Context(), supplierID, opts)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to list verifications",
+		})
+		return
+	}
+
+	items := make([]CheckFixVerificationResponse, len(result.Items))
+	for i := range result.Items {
+		items[i] = *toCheckFixVerificationResponse(&result.Items[i])
+	}
+
+	setPaginationHeaders(c, result)
+	c.JSON(http.StatusOK, PaginatedVerificationsResponse{
+		Items:      items,
+		TotalCount: result.TotalCount,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		TotalPages: result.TotalPages,
+	})
+}
+
 // LinkAccount handles POST /api/v1/supplier/checkfix/link
 // @Summary Link CheckFix account
 // @Description Links the supplier's CheckFix account
@@ -153,11 +248,12 @@ func (h *CheckFixHandler) GetStatus(c *gin.Context) {
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
+// @Failure 504 {object} ErrorResponse
 // @Router /supplier/checkfix/link [post]
 func (h *CheckFixHandler) LinkAccount(c *gin.Context) {
 	supplierID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -166,7 +262,7 @@ func (h *CheckFixHandler) LinkAccount(c *gin.Context) {
 
 	var req LinkAccountRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Account ID is required",
 		})
@@ -174,7 +270,19 @@ func (h *CheckFixHandler) LinkAccount(c *gin.Context) {
 	}
 
 	if err := h.checkFixService.LinkAccount(c.Request.Context(), supplierID, req.AccountID); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		// Covers ErrCheckFixTimeout, which wraps ErrCheckFixAPIError
+		if errors.Is(err, services.ErrCheckFixAPIError) {
+			respondError(c, err)
+			return
+		}
+		if errors.Is(err, services.ErrDomainAlreadyClaimed) {
+			writeError(c, http.StatusConflict, ErrorResponse{
+				Error:   "domain_claimed",
+				Message: "The account's domain is already verified by another organization",
+			})
+			return
+		}
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "link_failed",
 			Message: err.Error(),
 		})
@@ -184,7 +292,7 @@ func (h *CheckFixHandler) LinkAccount(c *gin.Context) {
 	// Return updated status
 	status, err := h.checkFixService.GetLinkStatus(c.Request.Context(), supplierID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to get link status",
 		})
@@ -194,6 +302,7 @@ func (h *CheckFixHandler) LinkAccount(c *gin.Context) {
 		IsLinked:  status.IsLinked,
 		AccountID: status.AccountID,
 		Domain:    status.Domain,
+		Domains:   status.Domains,
 		LinkedAt:  status.LinkedAt,
 	}
 
@@ -214,7 +323,7 @@ func (h *CheckFixHandler) LinkAccount(c *gin.Context) {
 func (h *CheckFixHandler) UnlinkAccount(c *gin.Context) {
 	supplierID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -222,7 +331,7 @@ func (h *CheckFixHandler) UnlinkAccount(c *gin.Context) {
 	}
 
 	if err := h.checkFixService.UnlinkAccount(c.Request.Context(), supplierID); err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to unlink account",
 		})
@@ -232,6 +341,127 @@ func (h *CheckFixHandler) UnlinkAccount(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Account unlinked successfully"})
 }
 
+// AddDomain handles POST /api/v1/supplier/checkfix/domains
+// @Summary Add a verified domain
+// @Description Adds a domain verified for the linked CheckFix account, so reports for it are accepted
+// @Tags CheckFix
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body DomainRequest true "Domain to add"
+// @Success 200 {object} CheckFixDomainsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 504 {object} ErrorResponse
+// @Router /supplier/checkfix/domains [post]
+func (h *CheckFixHandler) AddDomain(c *gin.Context) {
+	supplierID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	var req DomainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Domain is required",
+		})
+		return
+	}
+
+	org, err := h.checkFixService.AddDomain(c.Request.Context(), supplierID, req.Domain)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrCheckFixTimeout):
+			respondError(c, err)
+		case errors.Is(err, services.ErrCheckFixNotLinked):
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "not_linked",
+				Message: "CheckFix account is not linked",
+			})
+		case errors.Is(err, services.ErrDomainNotInAccount):
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "domain_not_verified",
+				Message: "Domain is not verified for the linked CheckFix account",
+			})
+		case errors.Is(err, services.ErrDomainAlreadyClaimed):
+			writeError(c, http.StatusConflict, ErrorResponse{
+				Error:   "domain_claimed",
+				Message: "Domain is already verified by another organization",
+			})
+		default:
+			writeError(c, http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to add domain",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, CheckFixDomainsResponse{
+		Domain:  org.Domain,
+		Domains: org.VerifiedDomains(),
+	})
+}
+
+// RemoveDomain handles DELETE /api/v1/supplier/checkfix/domains/:domain
+// @Summary Remove a verified domain
+// @Description Removes a secondary verified domain (the primary account domain cannot be removed)
+// @Tags CheckFix
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param domain path string true "Domain to remove"
+// @Success 200 {object} CheckFixDomainsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /supplier/checkfix/domains/{domain} [delete]
+func (h *CheckFixHandler) RemoveDomain(c *gin.Context) {
+	supplierID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	org, err := h.checkFixService.RemoveDomain(c.Request.Context(), supplierID, c.Param("domain"))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrDomainNotFound):
+			writeError(c, http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Domain not found",
+			})
+		case errors.Is(err, services.ErrPrimaryDomain):
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "primary_domain",
+				Message: "The primary CheckFix account domain cannot be removed",
+			})
+		default:
+			writeError(c, http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to remove domain",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, CheckFixDomainsResponse{
+		Domain:  org.Domain,
+		Domains: org.VerifiedDomains(),
+	})
+}
+
 // VerifyReport handles POST /api/v1/supplier/checkfix/verify
 // @Summary Verify a CheckFix report
 // @Description Verifies a CheckFix report and stores the verification
@@ -244,11 +474,12 @@ func (h *CheckFixHandler) UnlinkAccount(c *gin.Context) {
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
+// @Failure 504 {object} ErrorResponse
 // @Router /supplier/checkfix/verify [post]
 func (h *CheckFixHandler) VerifyReport(c *gin.Context) {
 	supplierID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -257,7 +488,7 @@ func (h *CheckFixHandler) VerifyReport(c *gin.Context) {
 
 	var req VerifyReportRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Report hash is required",
 		})
@@ -267,22 +498,26 @@ func (h *CheckFixHandler) VerifyReport(c *gin.Context) {
 	// Use a zero ObjectID for standalone verification (not tied to a response)
 	verification, err := h.checkFixService.VerifyReport(c.Request.Context(), supplierID, primitive.NilObjectID, req.ReportHash)
 	if err != nil {
+		if errors.Is(err, services.ErrCheckFixTimeout) {
+			respondError(c, err)
+			return
+		}
 		if errors.Is(err, services.ErrCheckFixNotLinked) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
+			writeError(c, http.StatusBadRequest, ErrorResponse{
 				Error:   "not_linked",
 				Message: "CheckFix account is not linked",
 			})
 			return
 		}
 		if errors.Is(err, services.ErrCheckFixReportNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
+			writeError(c, http.StatusNotFound, ErrorResponse{
 				Error:   "report_not_found",
 				Message: "CheckFix report not found",
 			})
 			return
 		}
 
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "verification_failed",
 			Message: "Failed to verify report",
 		})
@@ -306,11 +541,12 @@ func (h *CheckFixHandler) VerifyReport(c *gin.Context) {
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 504 {object} ErrorResponse
 // @Router /supplier/requirements/{id}/checkfix [post]
 func (h *CheckFixHandler) SubmitCheckFix(c *gin.Context) {
 	supplierID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -319,7 +555,7 @@ func (h *CheckFixHandler) SubmitCheckFix(c *gin.Context) {
 
 	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid requirement ID",
 		})
@@ -328,7 +564,7 @@ func (h *CheckFixHandler) SubmitCheckFix(c *gin.Context) {
 
 	var req SubmitCheckFixRequest
 	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Report hash is required",
 		})
@@ -337,29 +573,33 @@ func (h *CheckFixHandler) SubmitCheckFix(c *gin.Context) {
 
 	result, err := h.checkFixService.SubmitCheckFixResponse(c.Request.Context(), requirementID, supplierID, req.ReportHash)
 	if err != nil {
+		if errors.Is(err, services.ErrCheckFixTimeout) {
+			respondError(c, err)
+			return
+		}
 		if errors.Is(err, services.ErrRequirementNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
+			writeError(c, http.StatusNotFound, ErrorResponse{
 				Error:   "not_found",
 				Message: "Requirement not found",
 			})
 			return
 		}
 		if errors.Is(err, services.ErrCheckFixNotLinked) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
+			writeError(c, http.StatusBadRequest, ErrorResponse{
 				Error:   "not_linked",
 				Message: "CheckFix account is not linked",
 			})
 			return
 		}
 		if errors.Is(err, services.ErrCheckFixReportNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
+			writeError(c, http.StatusNotFound, ErrorResponse{
 				Error:   "report_not_found",
 				Message: "CheckFix report not found",
 			})
 			return
 		}
 
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "submission_failed",
 			Message: "Failed to submit CheckFix verification",
 		})
@@ -374,6 +614,172 @@ func (h *CheckFixHandler) SubmitCheckFix(c *gin.Context) {
 	})
 }
 
+// SatisfyFromCheckFix handles POST /api/v1/supplier/requirements/:id/satisfy-from-checkfix
+// @Summary Satisfy a requirement with the latest CheckFix verification
+// @Description Submits the supplier's latest valid CheckFix verification as the response to a CheckFix
+// @Description requirement, without re-entering the report hash. When it does not meet the requirement's
+// @Description minimum grade, report age or category minimums nothing is submitted and the 422 message says why.
+// @Tags CheckFix
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Requirement ID"
+// @Success 200 {object} CheckFixSubmissionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /supplier/requirements/{id}/satisfy-from-checkfix [post]
+func (h *CheckFixHandler) SatisfyFromCheckFix(c *gin.Context) {
+	supplierID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid requirement ID",
+		})
+		return
+	}
+
+	result, err := h.checkFixService.SatisfyFromLatest(c.Request.Context(), requirementID, supplierID)
+	if err != nil {
+		if errors.Is(err, services.ErrRequirementNotFound) {
+			writeError(c, http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Requirement not found",
+			})
+			return
+		}
+		if errors.Is(err, services.ErrInvalidStatusTransition) {
+			writeError(c, http.StatusConflict, ErrorResponse{
+				Error:   "invalid_transition",
+				Message: "Requirement is already closed",
+			})
+			return
+		}
+		if errors.Is(err, services.ErrNoValidVerification) {
+			writeError(c, http.StatusUnprocessableEntity, ErrorResponse{
+				Error:   "no_valid_verification",
+				Message: "No valid CheckFix verification found; submit a report hash instead",
+			})
+			return
+		}
+		var shortfall *services.CheckFixShortfallError
+		if errors.As(err, &shortfall) {
+			writeError(c, http.StatusUnprocessableEntity, ErrorResponse{
+				Error:   "requirement_not_met",
+				Message: "Latest CheckFix verification does not meet the requirement: " + shortfall.Reason,
+			})
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "submission_failed",
+			Message: "Failed to submit CheckFix verification",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, CheckFixSubmissionResponse{
+		Passed:       result.Passed,
+		Grade:        string(result.Grade),
+		Message:      result.Message,
+		Verification: toCheckFixVerificationResponse(result.Verification),
+	})
+}
+
+// GetSupplierGradeTrend handles GET /api/v1/suppliers/:id/checkfix-trend
+// @Summary Get a supplier's CheckFix grade trend
+// @Description Returns the supplier's recent CheckFix grades, oldest first, and whether they are improving, stable or declining
+// @Tags CheckFix
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Relationship ID"
+// @Param points query int false "Number of most recent verifications to consider" default(5)
+// @Success 200 {object} GradeTrendResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /suppliers/{id}/checkfix-trend [get]
+func (h *CheckFixHandler) GetSupplierGradeTrend(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	relationshipID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid relationship ID",
+		})
+		return
+	}
+
+	points := services.DefaultGradeTrendPoints
+	if raw := c.Query("points"); raw != "" {
+		points, err = strconv.Atoi(raw)
+		if err != nil || points < 2 || points > services.MaxGradeTrendPoints {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_points",
+				Message: "points must be between 2 and 50",
+			})
+			return
+		}
+	}
+
+	trend, err := h.checkFixService.GetGradeTrend(c.Request.Context(), companyID, relationshipID, points)
+	if err != nil {
+		if errors.Is(err, services.ErrRelationshipNotFound) {
+			writeError(c, http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Supplier relationship not found",
+			})
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to get grade trend",
+		})
+		return
+	}
+
+	resp := GradeTrendResponse{
+		Points: make([]GradeTrendPointResponse, len(trend.Points)),
+		Trend:  trend.Trend,
+	}
+	if trend.SupplierID != nil {
+		supplierID := trend.SupplierID.Hex()
+		resp.SupplierID = &supplierID
+	}
+	for i, p := range trend.Points {
+		resp.Points[i] = GradeTrendPointResponse{
+			VerifiedAt:   p.VerifiedAt,
+			Grade:        string(p.Grade),
+			OverallScore: p.OverallScore,
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
 // GetRequirementVerification handles GET /api/v1/requirements/:id/checkfix
 // @Summary Get CheckFix verification for a requirement
 // @Description Gets the CheckFix verification details for a requirement (company view)
@@ -390,7 +796,7 @@ func (h *CheckFixHandler) SubmitCheckFix(c *gin.Context) {
 func (h *CheckFixHandler) GetRequirementVerification(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -401,7 +807,7 @@ func (h *CheckFixHandler) GetRequirementVerification(c *gin.Context) {
 	// For now, just return not found as this needs requirement lookup first
 	_ = companyID
 
-	c.JSON(http.StatusNotFound, ErrorResponse{
+	writeError(c, http.StatusNotFound, ErrorResponse{
 		Error:   "not_found",
 		Message: "No CheckFix verification found",
 	})
@@ -409,26 +815,39 @@ func (h *CheckFixHandler) GetRequirementVerification(c *gin.Context) {
 
 // RegisterRoutes registers CheckFix handler routes
 // #INTEGRATION_POINT: Supplier routes for CheckFix management
+// #SECURITY_ASSUMPTION: authMiddleware also accepts API keys here (see middleware.APIKeyAuth),
+// so every route declares the scope it needs or requires an interactive session
 func (h *CheckFixHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
 	// Supplier CheckFix routes
 	supplier := rg.Group("/supplier")
 	supplier.Use(authMiddleware)
 	supplier.Use(middleware.RequireSupplier())
+	supplier.Use(middleware.RequireWriteAccess())
 
 	checkfix := supplier.Group("/checkfix")
-	checkfix.GET("/status", h.GetStatus)
-	checkfix.POST("/link", h.LinkAccount)
-	checkfix.DELETE("/link", h.UnlinkAccount)
-	checkfix.POST("/verify", h.VerifyReport)
+	checkfix.GET("", middleware.RequireScope(models.APIKeyScopeCheckFixRead), h.GetStatus)
+	checkfix.GET("/status", middleware.RequireScope(models.APIKeyScopeCheckFixRead), h.GetStatus)
+	checkfix.GET("/verifications", middleware.RequireScope(models.APIKeyScopeCheckFixRead), h.ListVerifications)
+	checkfix.POST("/link", middleware.RequireUserSession(), h.LinkAccount)
+	checkfix.DELETE("/link", middleware.RequireUserSession(), h.UnlinkAccount)
+	checkfix.POST("/domains", middleware.RequireUserSession(), h.AddDomain)
+	checkfix.DELETE("/domains/:domain", middleware.RequireUserSession(), h.RemoveDomain)
+	checkfix.POST("/verify", middleware.RequireScope(models.APIKeyScopeCheckFixSubmit), h.VerifyReport)
 
 	// Submit CheckFix for requirement
-	supplier.POST("/requirements/:id/checkfix", h.SubmitCheckFix)
+	supplier.POST("/requirements/:id/checkfix", middleware.RequireScope(models.APIKeyScopeCheckFixSubmit), h.SubmitCheckFix)
+	supplier.POST("/requirements/:id/satisfy-from-checkfix", middleware.RequireScope(models.APIKeyScopeCheckFixSubmit), h.SatisfyFromCheckFix)
 
 	// Company routes for viewing verifications
+	suppliers := rg.Group("/suppliers")
+	suppliers.Use(authMiddleware)
+	suppliers.Use(middleware.RequireCompany())
+	suppliers.GET("/:id/checkfix-trend", middleware.RequireScope(models.APIKeyScopeCheckFixRead), h.GetSupplierGradeTrend)
+
 	requirements := rg.Group("/requirements")
 	requirements.Use(authMiddleware)
 	requirements.Use(middleware.RequireCompany())
-	requirements.GET("/:id/checkfix", h.GetRequirementVerification)
+	requirements.GET("/:id/checkfix", middleware.RequireScope(models.APIKeyScopeCheckFixRead), h.GetRequirementVerification)
 }
 
 // toCheckFixVerificationResponse converts a verification to API response
@@ -451,6 +870,7 @@ func toCheckFixVerificationResponse(v *models.CheckFixVerification) *CheckFixVer
 		Domain:           v.Domain,
 		VerifiedDomain:   v.VerifiedDomain,
 		DomainMatch:      v.DomainMatch,
+		MatchedDomain:    v.MatchedDomain,
 		ReportHash:       v.ReportHash,
 		ReportDate:       v.ReportDate,
 		OverallGrade:     string(v.OverallGrade),
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// stubHistoryCheckFixService serves verifications per supplier; other methods are not used
+type stubHistoryCheckFixService struct {
+	services.CheckFixService
+	verifications map[primitive.ObjectID][]models.CheckFixVerification
+}
+
+func (s *stubHistoryCheckFixService) ListVerifications(_ context.Context, supplierID primitive.ObjectID, opts repository.PaginationOptions) (*repository.PaginatedResult[models.CheckFixVerification], error) {
+	items := s.verifications[supplierID]
+	return &repository.PaginatedResult[models.CheckFixVerification]{
+		Items:      items,
+		TotalCount: int64(len(items)),
+		Page:       opts.Page,
+		Limit:      opts.Limit,
+		TotalPages: 1,
+	}, nil
+}
+
+func TestCheckFixHandler_ListVerifications(t *testing.T) {
+	supplierID := primitive.NewObjectID()
+	service := &stubHistoryCheckFixService{verifications: map[primitive.ObjectID][]models.CheckFixVerification{
+		supplierID: {{ID: primitive.NewObjectID(), OverallGrade: models.CheckFixGradeA}},
+	}}
+
+	tests := []struct {
+		name       string
+		orgID      primitive.ObjectID
+		orgType    models.OrganizationType
+		wantStatus int
+		wantItems  int
+	}{
+		{"own history", supplierID, models.OrganizationTypeSupplier, http.StatusOK, 1},
+		{"other supplier sees nothing", primitive.NewObjectID(), models.OrganizationTypeSupplier, http.StatusOK, 0},
+		{"company is rejected", supplierID, models.OrganizationTypeCompany, http.StatusForbidden, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := func(c *gin.Context) {
+				c.Set(middleware.ContextKeyOrgID, tt.orgID.Hex())
+				c.Set(middleware.ContextKeyOrgType, string(tt.orgType))
+				c.Next()
+			}
+			router := gin.New()
+			NewCheckFixHandler(service).RegisterRoutes(router.Group("/api/v1"), auth)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/supplier/checkfix/verifications", nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp PaginatedVerificationsResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(resp.Items) != tt.wantItems {
+				t.Errorf("Expected %d verifications, got %d", tt.wantItems, len(resp.Items))
+			}
+		})
+	}
+}
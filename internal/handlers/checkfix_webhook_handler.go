@@ -0,0 +1,132 @@
+// Package handlers provides HTTP handlers for API endpoints.
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+const (
+	// CheckFixSignatureHeader carries the hex HMAC-SHA256 of the request body, prefixed with "sha256="
+	CheckFixSignatureHeader = "X-CheckFix-Signature"
+
+	// maxWebhookBodyBytes bounds webhook payloads read before signature verification
+	maxWebhookBodyBytes = 1 << 20
+)
+
+// CheckFixWebhookHandler receives CheckFix webhook deliveries
+// #INTEGRATION_POINT: CheckFix pushes report updates so stored verifications do not go stale
+type CheckFixWebhookHandler struct {
+	checkFixService services.CheckFixService
+	secret          []byte
+}
+
+// NewCheckFixWebhookHandler creates a new CheckFix webhook handler
+func NewCheckFixWebhookHandler(checkFixService services.CheckFixService, secret string) *CheckFixWebhookHandler {
+	return &CheckFixWebhookHandler{
+		checkFixService: checkFixService,
+		secret:          []byte(secret),
+	}
+}
+
+// WebhookAckResponse acknowledges a webhook delivery
+type WebhookAckResponse struct {
+	Status         string `json:"status"`
+	OutcomeChanged bool   `json:"outcome_changed,omitempty"`
+}
+
+// HandleWebhook handles POST /api/v1/checkfix/webhook
+// @Summary Receive CheckFix webhook
+// @Description Receives CheckFix report events signed with the shared webhook secret (X-CheckFix-Signature: sha256=<hex HMAC of body>)
+// @Tags CheckFix
+// @Accept json
+// @Produce json
+// @Param X-CheckFix-Signature header string true "HMAC-SHA256 signature of the body"
+// @Param request body services.CheckFixWebhookEvent true "Webhook event"
+// @Success 200 {object} WebhookAckResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /checkfix/webhook [post]
+func (h *CheckFixWebhookHandler) HandleWebhook(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookBodyBytes))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to read request body",
+		})
+		return
+	}
+
+	if !h.validSignature(body, c.GetHeader(CheckFixSignatureHeader)) {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "invalid_signature",
+			Message: "Invalid webhook signature",
+		})
+		return
+	}
+
+	var event services.CheckFixWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil || event.EventID == "" {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid webhook event",
+		})
+		return
+	}
+
+	result, err := h.checkFixService.HandleWebhookEvent(c.Request.Context(), &event)
+	if err != nil {
+		// A non-2xx status makes CheckFix redeliver the event
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to process webhook event",
+		})
+		return
+	}
+
+	status := "processed"
+	switch {
+	case result.Duplicate:
+		status = "duplicate"
+	case result.Ignored:
+		status = "ignored"
+	}
+
+	c.JSON(http.StatusOK, WebhookAckResponse{
+		Status:         status,
+		OutcomeChanged: result.OutcomeChanged,
+	})
+}
+
+// validSignature checks the body's HMAC-SHA256 against the signature header
+// #SECURITY_ASSUMPTION: Replays of a signed body are harmless because events are deduplicated by ID
+func (h *CheckFixWebhookHandler) validSignature(body []byte, header string) bool {
+	if len(h.secret) == 0 {
+		return false
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(header, "sha256="))
+	if err != nil || len(signature) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(body)
+	return hmac.Equal(signature, mac.Sum(nil))
+}
+
+// RegisterRoutes registers CheckFix webhook routes
+// #SECURITY_ASSUMPTION: No session auth - requests are authenticated by the body signature
+func (h *CheckFixWebhookHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/checkfix/webhook", h.HandleWebhook)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// stubWebhookCheckFixService records webhook events; other methods are not used
+type stubWebhookCheckFixService struct {
+	services.CheckFixService
+	events []*services.CheckFixWebhookEvent
+}
+
+func (s *stubWebhookCheckFixService) HandleWebhookEvent(_ context.Context, event *services.CheckFixWebhookEvent) (*services.CheckFixWebhookResult, error) {
+	s.events = append(s.events, event)
+	return &services.CheckFixWebhookResult{}, nil
+}
+
+func TestCheckFixWebhookHandler_Signature(t *testing.T) {
+	const secret = "webhook-secret"
+	body := []byte(`{"event_id":"evt_1","type":"report.updated","domain":"example.com"}`)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	validSignature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name       string
+		signature  string
+		wantStatus int
+		wantEvents int
+	}{
+		{"missing signature", "", http.StatusUnauthorized, 0},
+		{"wrong signature", "sha256=" + hex.EncodeToString(make([]byte, sha256.Size)), http.StatusUnauthorized, 0},
+		{"valid signature", validSignature, http.StatusOK, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &stubWebhookCheckFixService{}
+			router := gin.New()
+			NewCheckFixWebhookHandler(service, secret).RegisterRoutes(router.Group("/api/v1"))
+
+			req := httptest.NewRequest("POST", "/api/v1/checkfix/webhook", bytes.NewReader(body))
+			if tt.signature != "" {
+				req.Header.Set(CheckFixSignatureHeader, tt.signature)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if len(service.events) != tt.wantEvents {
+				t.Errorf("Expected %d processed events, got %d", tt.wantEvents, len(service.events))
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Package handlers provides HTTP handlers for API endpoints.
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// ClarificationHandler handles the per-question clarification threads
+// #INTEGRATION_POINT: Suppliers ask about a question from the response editor; companies answer
+// from the requirement view
+type ClarificationHandler struct {
+	clarificationService services.ClarificationService
+}
+
+// NewClarificationHandler creates a new clarification handler
+func NewClarificationHandler(clarificationService services.ClarificationService) *ClarificationHandler {
+	return &ClarificationHandler{
+		clarificationService: clarificationService,
+	}
+}
+
+// ClarificationRequest represents a clarification message
+type ClarificationRequest struct {
+	Message string `json:"message" binding:"required"`
+}
+
+// ClarificationResponse represents a clarification message in API responses
+type ClarificationResponse struct {
+	QuestionID   string    `json:"question_id"`
+	FromSupplier bool      `json:"from_supplier"`
+	Message      string    `json:"message"`
+	AuthorID     string    `json:"author_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AskClarification handles POST /api/v1/supplier/responses/:id/questions/:questionID/query
+// @Summary Ask about a question
+// @Description Adds a supplier question about a questionnaire question to the response's clarification thread.
+// @Description Threads take new messages while the relationship is active and the requirement is not approved.
+// @Tags Supplier Portal
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Response ID"
+// @Param questionID path string true "Question ID"
+// @Param request body ClarificationRequest true "Message"
+// @Success 201 {object} ClarificationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /supplier/responses/{id}/questions/{questionID}/query [post]
+func (h *ClarificationHandler) AskClarification(c *gin.Context) {
+	supplierID, userID, responseID, ok := clarificationParams(c, "Invalid response ID")
+	if !ok {
+		return
+	}
+
+	var req ClarificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Message is required",
+		})
+		return
+	}
+
+	clarification, err := h.clarificationService.Ask(c.Request.Context(), responseID, supplierID, userID, c.Param("questionID"), req.Message)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toClarificationResponse(clarification))
+}
+
+// ListSupplierClarifications handles GET /api/v1/supplier/responses/:id/questions/:questionID/clarifications
+// @Summary List clarifications of a question
+// @Description Returns the clarification thread about a question of one of the supplier's responses, oldest first
+// @Tags Supplier Portal
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Response ID"
+// @Param questionID path string true "Question ID"
+// @Success 200 {object} []ClarificationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /supplier/responses/{id}/questions/{questionID}/clarifications [get]
+func (h *ClarificationHandler) ListSupplierClarifications(c *gin.Context) {
+	supplierID, _, responseID, ok := clarificationParams(c, "Invalid response ID")
+	if !ok {
+		return
+	}
+
+	thread, err := h.clarificationService.ListForSupplier(c.Request.Context(), responseID, supplierID, c.Param("questionID"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toClarificationResponses(thread))
+}
+
+// RespondClarification handles POST /api/v1/requirements/:id/questions/:questionID/respond
+// @Summary Answer a clarification
+// @Description Adds a company answer to the clarification thread about a question of the requirement's response
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Requirement ID"
+// @Param questionID path string true "Question ID"
+// @Param request body ClarificationRequest true "Message"
+// @Success 201 {object} ClarificationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /requirements/{id}/questions/{questionID}/respond [post]
+func (h *ClarificationHandler) RespondClarification(c *gin.Context) {
+	companyID, userID, requirementID, ok := clarificationParams(c, "Invalid requirement ID")
+	if !ok {
+		return
+	}
+
+	var req ClarificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Message is required",
+		})
+		return
+	}
+
+	clarification, err := h.clarificationService.Respond(c.Request.Context(), requirementID, companyID, userID, c.Param("questionID"), req.Message)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toClarificationResponse(clarification))
+}
+
+// ListCompanyClarifications handles GET /api/v1/requirements/:id/questions/:questionID/clarifications
+// @Summary List clarifications of a question
+// @Description Returns the clarification thread about a question of the requirement's response, oldest first
+// @Tags Review
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Requirement ID"
+// @Param questionID path string true "Question ID"
+// @Success 200 {object} []ClarificationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /requirements/{id}/questions/{questionID}/clarifications [get]
+func (h *ClarificationHandler) ListCompanyClarifications(c *gin.Context) {
+	companyID, _, requirementID, ok := clarificationParams(c, "Invalid requirement ID")
+	if !ok {
+		return
+	}
+
+	thread, err := h.clarificationService.ListForCompany(c.Request.Context(), requirementID, companyID, c.Param("questionID"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toClarificationResponses(thread))
+}
+
+// RegisterRoutes registers clarification handler routes
+// #SECURITY_ASSUMPTION: Suppliers reach threads through their own responses, companies through
+// their own requirements; the service also checks the relationship belongs to both
+func (h *ClarificationHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	supplier := rg.Group("/supplier/responses")
+	supplier.Use(authMiddleware)
+	supplier.Use(middleware.RequireSupplier())
+	supplier.Use(middleware.RequireWriteAccess())
+	supplier.GET("/:id/questions/:questionID/clarifications", h.ListSupplierClarifications)
+	supplier.POST("/:id/questions/:questionID/query", h.AskClarification)
+
+	company := rg.Group("/requirements")
+	company.Use(authMiddleware)
+	company.Use(middleware.RequireCompany())
+	company.GET("/:id/questions/:questionID/clarifications", h.ListCompanyClarifications)
+	company.POST("/:id/questions/:questionID/respond", middleware.RequireAdmin(), h.RespondClarification)
+}
+
+// clarificationParams reads the organization, user and path ID of a clarification request,
+// writing the error response when one is missing
+func clarificationParams(c *gin.Context, invalidIDMessage string) (orgID, userID, id primitive.ObjectID, ok bool) {
+	orgID, orgOK := middleware.GetOrgID(c)
+	userID, userOK := middleware.GetUserID(c)
+	if !orgOK || !userOK {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return orgID, userID, id, false
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: invalidIDMessage,
+		})
+		return orgID, userID, id, false
+	}
+
+	return orgID, userID, id, true
+}
+
+// toClarificationResponses converts a clarification thread to responses
+func toClarificationResponses(thread []models.Clarification) []ClarificationResponse {
+	items := make([]ClarificationResponse, len(thread))
+	for i := range thread {
+		items[i] = toClarificationResponse(&thread[i])
+	}
+	return items
+}
+
+// toClarificationResponse converts a clarification model to response
+func toClarificationResponse(c *models.Clarification) ClarificationResponse {
+	return ClarificationResponse{
+		QuestionID:   c.QuestionID.Hex(),
+		FromSupplier: c.FromSupplier,
+		Message:      c.Message,
+		AuthorID:     c.AuthorID.Hex(),
+		CreatedAt:    c.CreatedAt,
+	}
+}
@@ -0,0 +1,147 @@
+// Package handlers provides HTTP handlers for API endpoints.
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// DashboardHandler handles the company dashboard endpoint
+// #INTEGRATION_POINT: Company portal landing page
+type DashboardHandler struct {
+	dashboardService services.DashboardService
+	orgRepo          repository.OrganizationRepository
+}
+
+// NewDashboardHandler creates a new dashboard handler
+func NewDashboardHandler(dashboardService services.DashboardService, orgRepo repository.OrganizationRepository) *DashboardHandler {
+	return &DashboardHandler{
+		dashboardService: dashboardService,
+		orgRepo:          orgRepo,
+	}
+}
+
+// CompanyDashboardResponse represents the company dashboard
+type CompanyDashboardResponse struct {
+	Suppliers               SupplierBreakdownResponse     `json:"suppliers"`
+	Requirements            RequirementBreakdownResponse  `json:"requirements"`
+	AwaitingReview          []RequirementResponse         `json:"awaiting_review"`
+	TopOutstandingSuppliers []OutstandingSupplierResponse `json:"top_outstanding_suppliers"`
+}
+
+// SupplierBreakdownResponse counts supplier relationships by status and classification
+type SupplierBreakdownResponse struct {
+	Total            int64            `json:"total"`
+	ByStatus         map[string]int64 `json:"by_status"`
+	ByClassification map[string]int64 `json:"by_classification"`
+}
+
+// RequirementBreakdownResponse counts requirements by status
+type RequirementBreakdownResponse struct {
+	Total    int64            `json:"total"`
+	ByStatus map[string]int64 `json:"by_status"`
+	Overdue  int64            `json:"overdue"`
+}
+
+// OutstandingSupplierResponse is a supplier with requirements awaiting its action
+type OutstandingSupplierResponse struct {
+	SupplierID   string `json:"supplier_id"`
+	SupplierName string `json:"supplier_name,omitempty"`
+	Outstanding  int64  `json:"outstanding"`
+	Overdue      int64  `json:"overdue"`
+}
+
+// GetCompanyDashboard handles GET /api/v1/dashboard
+// @Summary Get company dashboard
+// @Description Gets supplier counts by status and classification, requirement counts by status, the overdue count, the submissions waiting longest for review and the suppliers with the most outstanding requirements
+// @Tags Dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} CompanyDashboardResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /dashboard [get]
+func (h *DashboardHandler) GetCompanyDashboard(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	dashboard, err := h.dashboardService.GetCompanyDashboard(ctx, companyID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to get dashboard",
+		})
+		return
+	}
+
+	resp := CompanyDashboardResponse{
+		Suppliers: SupplierBreakdownResponse{
+			Total:            dashboard.Suppliers.Total,
+			ByStatus:         make(map[string]int64, len(dashboard.Suppliers.ByStatus)),
+			ByClassification: make(map[string]int64, len(dashboard.Suppliers.ByClassification)),
+		},
+		Requirements: RequirementBreakdownResponse{
+			Total:    dashboard.Requirements.Total,
+			ByStatus: make(map[string]int64, len(dashboard.Requirements.ByStatus)),
+			Overdue:  dashboard.Requirements.Overdue,
+		},
+		AwaitingReview:          make([]RequirementResponse, len(dashboard.AwaitingReview)),
+		TopOutstandingSuppliers: make([]OutstandingSupplierResponse, len(dashboard.TopOutstanding)),
+	}
+	for status, count := range dashboard.Suppliers.ByStatus {
+		resp.Suppliers.ByStatus[string(status)] = count
+	}
+	for classification, count := range dashboard.Suppliers.ByClassification {
+		resp.Suppliers.ByClassification[string(classification)] = count
+	}
+	for status, count := range dashboard.Requirements.ByStatus {
+		resp.Requirements.ByStatus[string(status)] = count
+	}
+
+	// Resolve supplier names of both lists in one lookup
+	resolver := newOrgNameResolver()
+	for i := range dashboard.AwaitingReview {
+		resolver.add(&dashboard.AwaitingReview[i].SupplierID)
+	}
+	for i := range dashboard.TopOutstanding {
+		resolver.add(&dashboard.TopOutstanding[i].SupplierID)
+	}
+	names := resolver.resolve(ctx, h.orgRepo)
+
+	for i := range dashboard.AwaitingReview {
+		resp.AwaitingReview[i] = toRequirementResponse(&dashboard.AwaitingReview[i])
+		resp.AwaitingReview[i].SupplierName = names[dashboard.AwaitingReview[i].SupplierID]
+	}
+	for i, s := range dashboard.TopOutstanding {
+		resp.TopOutstandingSuppliers[i] = OutstandingSupplierResponse{
+			SupplierID:   s.SupplierID.Hex(),
+			SupplierName: names[s.SupplierID],
+			Outstanding:  s.Outstanding,
+			Overdue:      s.Overdue,
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RegisterRoutes registers dashboard handler routes
+// #INTEGRATION_POINT: Routes require authentication and company organization type
+func (h *DashboardHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	dashboard := rg.Group("/dashboard")
+	dashboard.Use(authMiddleware)
+	dashboard.Use(middleware.RequireCompany())
+	dashboard.GET("", h.GetCompanyDashboard)
+}
@@ -0,0 +1,227 @@
+// Package handlers provides HTTP handlers for API endpoints.
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// EmailOutboxHandler handles the failed and sent email views of the email outbox
+// #INTEGRATION_POINT: Lets admins see invitations and notices that could not be delivered, and
+// troubleshoot emails a recipient reports missing
+type EmailOutboxHandler struct {
+	outboxService services.EmailOutboxService
+}
+
+// NewEmailOutboxHandler creates a new email outbox handler
+func NewEmailOutboxHandler(outboxService services.EmailOutboxService) *EmailOutboxHandler {
+	return &EmailOutboxHandler{
+		outboxService: outboxService,
+	}
+}
+
+// FailedEmailResponse represents a permanently failed email in API responses
+type FailedEmailResponse struct {
+	ID        string     `json:"id"`
+	Kind      string     `json:"kind"`
+	Recipient string     `json:"recipient"`
+	Attempts  int        `json:"attempts"`
+	LastError string     `json:"last_error,omitempty"`
+	FailedAt  *time.Time `json:"failed_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// PaginatedFailedEmailsResponse represents paginated failed emails
+type PaginatedFailedEmailsResponse struct {
+	Items      []FailedEmailResponse `json:"items"`
+	TotalCount int64                 `json:"total_count"`
+	Page       int                   `json:"page"`
+	Limit      int                   `json:"limit"`
+	TotalPages int                   `json:"total_pages"`
+}
+
+// SentEmailResponse represents one attempt to hand an email to the mail provider
+type SentEmailResponse struct {
+	ID                string    `json:"id"`
+	Kind              string    `json:"kind"`
+	Recipient         string    `json:"recipient"`
+	Status            string    `json:"status"`
+	ProviderMessageID string    `json:"provider_message_id,omitempty"`
+	Error             string    `json:"error,omitempty"`
+	OutboxEmailID     string    `json:"outbox_email_id,omitempty"`
+	Attempt           int       `json:"attempt,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// PaginatedSentEmailsResponse represents paginated sent emails
+type PaginatedSentEmailsResponse struct {
+	Items      []SentEmailResponse `json:"items"`
+	TotalCount int64               `json:"total_count"`
+	Page       int                 `json:"page"`
+	Limit      int                 `json:"limit"`
+	TotalPages int                 `json:"total_pages"`
+}
+
+// ListFailedEmails handles GET /api/v1/organization/failed-emails
+// @Summary List failed emails
+// @Description Lists emails of the current organization that could not be delivered after all retries, most recent failure first
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} PaginatedFailedEmailsResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/failed-emails [get]
+func (h *EmailOutboxHandler) ListFailedEmails(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	opts := repository.DefaultPaginationOptions()
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		opts.Page = page
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 && limit <= 100 {
+		opts.Limit = limit
+	}
+
+	result, err := h.outboxService.ListFailed(c.Request.Context(), orgID, opts)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to list failed emails",
+		})
+		return
+	}
+
+	items := make([]FailedEmailResponse, len(result.Items))
+	for i := range result.Items {
+		items[i] = toFailedEmailResponse(&result.Items[i])
+	}
+
+	setPaginationHeaders(c, result)
+	c.JSON(http.StatusOK, PaginatedFailedEmailsResponse{
+		Items:      items,
+		TotalCount: result.TotalCount,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		TotalPages: result.TotalPages,
+	})
+}
+
+// ListSentEmails handles GET /api/v1/organization/sent-emails
+// @Summary List sent emails
+// @Description Lists the attempts to hand the organization's emails to the mail provider, newest first. Accepted attempts carry the provider message ID; retries of a queued email share its outbox_email_id. Records are kept for 30 days.
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param recipient query string false "Only list emails sent to this address"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} PaginatedSentEmailsResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/sent-emails [get]
+func (h *EmailOutboxHandler) ListSentEmails(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	opts := repository.DefaultPaginationOptions()
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		opts.Page = page
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 && limit <= 100 {
+		opts.Limit = limit
+	}
+
+	result, err := h.outboxService.ListSent(c.Request.Context(), orgID, c.Query("recipient"), opts)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to list sent emails",
+		})
+		return
+	}
+
+	items := make([]SentEmailResponse, len(result.Items))
+	for i := range result.Items {
+		items[i] = toSentEmailResponse(&result.Items[i])
+	}
+
+	setPaginationHeaders(c, result)
+	c.JSON(http.StatusOK, PaginatedSentEmailsResponse{
+		Items:      items,
+		TotalCount: result.TotalCount,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		TotalPages: result.TotalPages,
+	})
+}
+
+// RegisterRoutes registers email outbox handler routes
+// #SECURITY_ASSUMPTION: Failed and sent emails expose recipient addresses and are restricted to organization admins
+func (h *EmailOutboxHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	failedEmails := rg.Group("/organization/failed-emails")
+	failedEmails.Use(authMiddleware)
+	failedEmails.Use(middleware.RequireAdmin())
+	failedEmails.GET("", h.ListFailedEmails)
+
+	sentEmails := rg.Group("/organization/sent-emails")
+	sentEmails.Use(authMiddleware)
+	sentEmails.Use(middleware.RequireAdmin())
+	sentEmails.GET("", h.ListSentEmails)
+}
+
+// toFailedEmailResponse converts an outbox email model to response
+func toFailedEmailResponse(e *models.OutboxEmail) FailedEmailResponse {
+	return FailedEmailResponse{
+		ID:        e.ID.Hex(),
+		Kind:      string(e.Kind),
+		Recipient: e.Recipient,
+		Attempts:  e.Attempts,
+		LastError: e.LastError,
+		FailedAt:  e.FailedAt,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// toSentEmailResponse converts a sent email model to response
+func toSentEmailResponse(e *models.SentEmail) SentEmailResponse {
+	resp := SentEmailResponse{
+		ID:                e.ID.Hex(),
+		Kind:              e.Kind,
+		Recipient:         e.Recipient,
+		Status:            string(e.Status),
+		ProviderMessageID: e.ProviderMessageID,
+		Error:             e.Error,
+		Attempt:           e.Attempt,
+		CreatedAt:         e.CreatedAt,
+	}
+	if e.OutboxEmailID != nil {
+		resp.OutboxEmailID = e.OutboxEmailID.Hex()
+	}
+	return resp
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/apierrors"
+	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// writeError writes an error response stamped with the request ID
+// #IMPLEMENTATION_DECISION: Every handler error goes through here, so clients can quote the
+// request ID from any error body when reporting a problem
+func writeError(c *gin.Context, status int, resp ErrorResponse) {
+	resp.RequestID = middleware.GetRequestID(c)
+	c.JSON(status, resp)
+}
+
+// respondError writes the catalog response for a service error
+// #IMPLEMENTATION_DECISION: Errors missing from the catalog become a generic internal error, so
+// internal error text never reaches clients
+func respondError(c *gin.Context, err error) {
+	entry, _ := apierrors.Lookup(err)
+	writeError(c, entry.Status, ErrorResponse{
+		Error:   string(entry.Code),
+		Message: entry.MessageFor(err),
+	})
+}
+
+// respondSessionError writes the response for a failed self-service request on the caller's own account
+// #IMPLEMENTATION_DECISION: A missing or inactive caller means the session no longer identifies
+// anyone, so it is unauthorized rather than not found; other errors use the catalog
+func respondSessionError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrUserNotFound) || errors.Is(err, services.ErrUserInactive) ||
+		errors.Is(err, services.ErrOrganizationNotFound) {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   string(apierrors.CodeUnauthorized),
+			Message: "User not found",
+		})
+		return
+	}
+	respondError(c, err)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+func TestRespondError(t *testing.T) {
+	router := gin.New()
+	router.Use(middleware.RequestID())
+	router.GET("/template", func(c *gin.Context) {
+		respondError(c, fmt.Errorf("failed to get template: %w", models.ErrTemplateNotFound))
+	})
+	router.GET("/broken", func(c *gin.Context) {
+		respondError(c, errors.New("connection refused by mongo-0"))
+	})
+	router.GET("/me", func(c *gin.Context) {
+		respondSessionError(c, services.ErrUserInactive)
+	})
+	router.GET("/me/email", func(c *gin.Context) {
+		respondSessionError(c, services.ErrEmailUnchanged)
+	})
+
+	tests := []struct {
+		path        string
+		wantStatus  int
+		wantCode    string
+		wantMessage string
+	}{
+		{"/template", http.StatusNotFound, "not_found", "Template not found"},
+		// Uncatalogued errors must not leak their text
+		{"/broken", http.StatusInternalServerError, "internal_error", "An unexpected error occurred"},
+		// A caller whose own account is gone is unauthorized; other self-service errors use the catalog
+		{"/me", http.StatusUnauthorized, "unauthorized", "User not found"},
+		{"/me/email", http.StatusBadRequest, "email_unchanged", "The new email matches the current email"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+		req.Header.Set("X-Request-ID", "req-42")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var body ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: invalid body %q: %v", tt.path, w.Body.String(), err)
+		}
+		if w.Code != tt.wantStatus || body.Error != tt.wantCode || body.Message != tt.wantMessage || body.RequestID != "req-42" {
+			t.Errorf("%s: got %d %+v, want %d %s %q with request ID", tt.path, w.Code, body, tt.wantStatus, tt.wantCode, tt.wantMessage)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportWriter writes export rows in one output format
+type exportWriter[T any] interface {
+	contentType() string
+	begin() error
+	write(rows []T) error
+	end() error
+}
+
+// newExportWriter returns the writer for a format, or false for an unknown format
+func newExportWriter[T any](format string, w io.Writer, header []string, record func(*T) []string) (exportWriter[T], bool) {
+	switch format {
+	case "csv":
+		return &csvExportWriter[T]{w: csv.NewWriter(w), header: header, record: record}, true
+	case "json":
+		return &jsonExportWriter[T]{w: w, first: true}, true
+	default:
+		return nil, false
+	}
+}
+
+// csvExportWriter writes an export as CSV with a header row
+type csvExportWriter[T any] struct {
+	w      *csv.Writer
+	header []string
+	record func(*T) []string
+}
+
+func (e *csvExportWriter[T]) contentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (e *csvExportWriter[T]) begin() error {
+	return e.w.Write(e.header)
+}
+
+func (e *csvExportWriter[T]) write(rows []T) error {
+	for i := range rows {
+		if err := e.w.Write(e.record(&rows[i])); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvExportWriter[T]) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// csvSafe neutralizes values a spreadsheet would evaluate as a formula
+// #SECURITY_ASSUMPTION: Supplier-controlled text (names, emails) ends up in auditors' spreadsheets
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// jsonExportWriter writes an export as a JSON array, one element per row
+type jsonExportWriter[T any] struct {
+	w     io.Writer
+	first bool
+}
+
+func (e *jsonExportWriter[T]) contentType() string {
+	return "application/json; charset=utf-8"
+}
+
+func (e *jsonExportWriter[T]) begin() error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonExportWriter[T]) write(rows []T) error {
+	for i := range rows {
+		data, err := json.Marshal(&rows[i])
+		if err != nil {
+			return err
+		}
+		if !e.first {
+			if _, err := io.WriteString(e.w, ","); err != nil {
+				return err
+			}
+		}
+		e.first = false
+		if _, err := e.w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *jsonExportWriter[T]) end() error {
+	_, err := io.WriteString(e.w, "]")
+	return err
+}
+
+// streamExport runs an export, writing each batch it produces as a download named
+// <name>-<date>.<format>; failMessage is sent when the export fails before any row is written
+func streamExport[T any](c *gin.Context, writer exportWriter[T], name, format, failMessage string, run func(fn func([]T) error) error) {
+	// Headers are sent with the first batch so that a failure before any row is written still gets a JSON error
+	started := false
+	begin := func() error {
+		started = true
+		c.Header("Content-Type", writer.contentType())
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.%s"`, name, time.Now().UTC().Format("2006-01-02"), format))
+		c.Status(http.StatusOK)
+		return writer.begin()
+	}
+
+	err := run(func(rows []T) error {
+		if !started {
+			if err := begin(); err != nil {
+				return err
+			}
+		}
+		if err := writer.write(rows); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+	if err == nil && !started {
+		err = begin()
+	}
+	if err == nil {
+		err = writer.end()
+	}
+	if err != nil {
+		if !started {
+			writeError(c, http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: failMessage,
+			})
+			return
+		}
+		// The status is already sent; the client sees a truncated file
+		c.Error(err) //nolint:errcheck // recorded for middleware, nothing else to do
+	}
+}
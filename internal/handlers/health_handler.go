@@ -5,6 +5,7 @@ import (
 	"context"
 	"net/http"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -18,21 +19,90 @@ const (
 	statusUnhealthy = "unhealthy"
 )
 
+// dependencyCheckTimeout bounds each readiness probe so a hung dependency fails fast
+const dependencyCheckTimeout = 2 * time.Second
+
+// DependencyCheck reports whether an external dependency is reachable
+type DependencyCheck func(ctx context.Context) error
+
+// dependency is a named readiness check
+type dependency struct {
+	name  string
+	check DependencyCheck
+}
+
+// dependencyResult is the outcome of a single dependency check
+type dependencyResult struct {
+	err     error
+	latency time.Duration
+}
+
 // HealthHandler handles health check endpoints
 // #INTEGRATION_POINT: Used by load balancers and monitoring systems
 type HealthHandler struct {
-	dbClient  *database.Client
-	version   string
-	startTime time.Time
+	dbClient     *database.Client
+	version      string
+	build        BuildInfo
+	startTime    time.Time
+	dependencies []dependency
+}
+
+// BuildInfo identifies the deployed build; the values are set via ldflags at build time
+type BuildInfo struct {
+	BuildTime string
+	GitCommit string
+	GitBranch string
 }
 
 // NewHealthHandler creates a new health handler
+// MongoDB is always checked for readiness when a client is given
 func NewHealthHandler(dbClient *database.Client, version string) *HealthHandler {
-	return &HealthHandler{
+	h := &HealthHandler{
 		dbClient:  dbClient,
 		version:   version,
 		startTime: time.Now(),
 	}
+	if dbClient != nil {
+		h.AddDependency("mongodb", dbClient.Ping)
+	}
+	return h
+}
+
+// SetBuildInfo sets the build metadata reported by the version endpoint
+func (h *HealthHandler) SetBuildInfo(build BuildInfo) {
+	h.build = build
+}
+
+// AddDependency registers an additional dependency checked by the readiness endpoints
+func (h *HealthHandler) AddDependency(name string, check DependencyCheck) {
+	h.dependencies = append(h.dependencies, dependency{name: name, check: check})
+}
+
+// checkDependencies runs all dependency checks concurrently, each with its own timeout
+func (h *HealthHandler) checkDependencies(ctx context.Context) map[string]dependencyResult {
+	results := make(map[string]dependencyResult, len(h.dependencies))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, dep := range h.dependencies {
+		wg.Add(1)
+		go func(dep dependency) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, dependencyCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := dep.check(checkCtx)
+
+			mu.Lock()
+			results[dep.name] = dependencyResult{err: err, latency: time.Since(start)}
+			mu.Unlock()
+		}(dep)
+	}
+
+	wg.Wait()
+	return results
 }
 
 // HealthResponse represents a health check response
@@ -53,6 +123,15 @@ type DetailedHealthResponse struct {
 	System    SystemInfo         `json:"system"`
 }
 
+// VersionResponse describes the deployed build
+type VersionResponse struct {
+	Version   string `json:"version"`
+	BuildTime string `json:"build_time"`
+	GitCommit string `json:"git_commit"`
+	GitBranch string `json:"git_branch"`
+	GoVersion string `json:"go_version"`
+}
+
 // Service represents service health
 type Service struct {
 	Status      string `json:"status"`
@@ -98,25 +177,23 @@ func (h *HealthHandler) Health(c *gin.Context) {
 
 // Ready handles GET /health/ready
 // @Summary Readiness check endpoint
-// @Description Checks if the service is ready to receive traffic (dependencies are healthy)
+// @Description Checks if the service is ready to receive traffic (MongoDB and, when configured, the CheckFix API are reachable)
 // @Tags Health
 // @Produce json
 // @Success 200 {object} HealthResponse
 // @Failure 503 {object} HealthResponse
 // @Router /health/ready [get]
 func (h *HealthHandler) Ready(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
-	defer cancel()
-
 	services := make(map[string]string)
 	allHealthy := true
 
-	// Check database
-	if err := h.dbClient.Ping(ctx); err != nil {
-		services["mongodb"] = statusUnhealthy
-		allHealthy = false
-	} else {
-		services["mongodb"] = statusHealthy
+	for name, result := range h.checkDependencies(c.Request.Context()) {
+		if result.err != nil {
+			services[name] = statusUnhealthy
+			allHealthy = false
+		} else {
+			services[name] = statusHealthy
+		}
 	}
 
 	status := "ready"
@@ -156,24 +233,22 @@ func (h *HealthHandler) Live(c *gin.Context) {
 // @Success 200 {object} DetailedHealthResponse
 // @Router /health/detailed [get]
 func (h *HealthHandler) Detailed(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
-	defer cancel()
-
 	services := make(map[string]Service)
 	allHealthy := true
 
-	// Check database with latency
-	dbStart := time.Now()
-	if err := h.dbClient.Ping(ctx); err != nil {
-		services["mongodb"] = Service{
-			Status:      statusUnhealthy,
-			Description: err.Error(),
-		}
-		allHealthy = false
-	} else {
-		services["mongodb"] = Service{
-			Status:  statusHealthy,
-			Latency: time.Since(dbStart).String(),
+	// Check dependencies with latency
+	for name, result := range h.checkDependencies(c.Request.Context()) {
+		if result.err != nil {
+			services[name] = Service{
+				Status:      statusUnhealthy,
+				Description: result.err.Error(),
+			}
+			allHealthy = false
+		} else {
+			services[name] = Service{
+				Status:  statusHealthy,
+				Latency: result.latency.String(),
+			}
 		}
 	}
 
@@ -201,6 +276,24 @@ func (h *HealthHandler) Detailed(c *gin.Context) {
 	})
 }
 
+// Version handles GET /health/version
+// @Summary Build version
+// @Description Returns the version, build time and git commit of the running build
+// @Tags Health
+// @Produce json
+// @Success 200 {object} VersionResponse
+// @Router /health/version [get]
+// #SECURITY_ASSUMPTION: Unauthenticated; build metadata only, no configuration or environment
+func (h *HealthHandler) Version(c *gin.Context) {
+	c.JSON(http.StatusOK, VersionResponse{
+		Version:   h.version,
+		BuildTime: h.build.BuildTime,
+		GitCommit: h.build.GitCommit,
+		GitBranch: h.build.GitBranch,
+		GoVersion: runtime.Version(),
+	})
+}
+
 // RegisterRoutes registers health handler routes
 func (h *HealthHandler) RegisterRoutes(router *gin.Engine) {
 	// Health endpoints at root level (not under /api/v1)
@@ -209,4 +302,5 @@ func (h *HealthHandler) RegisterRoutes(router *gin.Engine) {
 	router.GET("/health/ready", h.Ready)
 	router.GET("/health/live", h.Live)
 	router.GET("/health/detailed", h.Detailed)
+	router.GET("/health/version", h.Version)
 }
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -102,6 +104,36 @@ func TestHealthHandler_Live(t *testing.T) {
 	}
 }
 
+func TestHealthHandler_Ready_DependencyDown(t *testing.T) {
+	handler := NewHealthHandler(nil, "1.0.0")
+	handler.AddDependency("mongodb", func(ctx context.Context) error { return nil })
+	handler.AddDependency("checkfix_api", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	router := gin.New()
+	router.GET("/health/ready", handler.Ready)
+
+	req := httptest.NewRequest("GET", "/health/ready", http.NoBody)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+
+	var response HealthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if response.Services["mongodb"] != statusHealthy {
+		t.Errorf("Expected mongodb '%s', got '%s'", statusHealthy, response.Services["mongodb"])
+	}
+	if response.Services["checkfix_api"] != statusUnhealthy {
+		t.Errorf("Expected checkfix_api '%s', got '%s'", statusUnhealthy, response.Services["checkfix_api"])
+	}
+}
+
 func TestNewHealthHandler(t *testing.T) {
 	handler := NewHealthHandler(nil, "1.2.3")
 
@@ -117,3 +149,29 @@ func TestNewHealthHandler(t *testing.T) {
 		t.Error("Expected startTime to be set")
 	}
 }
+
+func TestHealthHandler_Version(t *testing.T) {
+	handler := NewHealthHandler(nil, "1.2.3")
+	handler.SetBuildInfo(BuildInfo{BuildTime: "2024-01-02T03:04:05Z", GitCommit: "abc1234", GitBranch: "main"})
+
+	router := gin.New()
+	router.GET("/health/version", handler.Version)
+
+	req := httptest.NewRequest("GET", "/health/version", http.NoBody)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response VersionResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if response.Version != "1.2.3" || response.GitCommit != "abc1234" || response.GitBranch != "main" || response.BuildTime != "2024-01-02T03:04:05Z" {
+		t.Errorf("Unexpected build metadata %+v", response)
+	}
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// orgNameResolver collects organization IDs from a page of results and resolves their names in one query
+// #QUERY_PATTERN: One GetByIDs call per page instead of a GetByID per row
+type orgNameResolver struct {
+	ids  []primitive.ObjectID
+	seen map[primitive.ObjectID]bool
+}
+
+// newOrgNameResolver creates an empty resolver
+func newOrgNameResolver() *orgNameResolver {
+	return &orgNameResolver{seen: make(map[primitive.ObjectID]bool)}
+}
+
+// add queues an organization ID for resolution; nil and duplicate IDs are ignored
+func (r *orgNameResolver) add(id *primitive.ObjectID) {
+	if id == nil || id.IsZero() || r.seen[*id] {
+		return
+	}
+	r.seen[*id] = true
+	r.ids = append(r.ids, *id)
+}
+
+// resolve looks up the queued organizations and returns their names keyed by ID
+// #IMPLEMENTATION_DECISION: Best-effort - names are display-only, so a lookup failure
+// leaves them empty rather than failing the list request
+func (r *orgNameResolver) resolve(ctx context.Context, orgRepo repository.OrganizationRepository) map[primitive.ObjectID]string {
+	names := make(map[primitive.ObjectID]string, len(r.ids))
+	if len(r.ids) == 0 {
+		return names
+	}
+
+	orgs, err := orgRepo.GetByIDs(ctx, r.ids)
+	if err != nil {
+		return names
+	}
+	for id, org := range orgs {
+		names[id] = org.Name
+	}
+	return names
+}
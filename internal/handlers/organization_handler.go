@@ -4,6 +4,7 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -11,18 +12,21 @@ import (
 	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
 	"github.com/checkfix-tools/nisfix_backend/internal/models"
 	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
 )
 
 // OrganizationHandler handles organization management endpoints
 // #INTEGRATION_POINT: Used by both company and supplier portals for settings
 type OrganizationHandler struct {
-	orgRepo repository.OrganizationRepository
+	orgRepo             repository.OrganizationRepository
+	organizationService services.OrganizationService
 }
 
 // NewOrganizationHandler creates a new organization handler
-func NewOrganizationHandler(orgRepo repository.OrganizationRepository) *OrganizationHandler {
+func NewOrganizationHandler(orgRepo repository.OrganizationRepository, organizationService services.OrganizationService) *OrganizationHandler {
 	return &OrganizationHandler{
-		orgRepo: orgRepo,
+		orgRepo:             orgRepo,
+		organizationService: organizationService,
 	}
 }
 
@@ -33,14 +37,25 @@ type OrganizationResponse struct {
 	Name         string                       `json:"name"`
 	Slug         string                       `json:"slug"`
 	Domain       string                       `json:"domain,omitempty"`
+	Domains      []string                     `json:"domains,omitempty"`
 	ContactEmail string                       `json:"contact_email,omitempty"`
 	ContactPhone string                       `json:"contact_phone,omitempty"`
 	Address      *AddressResponse             `json:"address,omitempty"`
 	Settings     OrganizationSettingsResponse `json:"settings"`
+	LogoURL      string                       `json:"logo_url,omitempty"`
 	CreatedAt    time.Time                    `json:"created_at"`
 	UpdatedAt    time.Time                    `json:"updated_at"`
 }
 
+// LogoResponse describes an uploaded organization logo
+type LogoResponse struct {
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	ETag        string    `json:"etag"`
+	URL         string    `json:"url"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
 // AddressResponse represents an address in API responses
 type AddressResponse struct {
 	Street     string `json:"street,omitempty"`
@@ -57,6 +72,24 @@ type OrganizationSettingsResponse struct {
 	NotificationEmails   []string `json:"notification_emails"`
 	DefaultLanguage      string   `json:"default_language"`
 	NotificationsEnabled bool     `json:"notifications_enabled"`
+	ReminderDaysBefore   int      `json:"reminder_days_before"`
+	AutoApprove          bool     `json:"auto_approve"`
+	// ScoringScale is the effective scale, the default points scale when none is configured
+	ScoringScale models.ScoringScale `json:"scoring_scale"`
+	// DefaultPassingScore and DefaultScoringMode are the effective defaults for new questionnaires
+	DefaultPassingScore int                `json:"default_passing_score"`
+	DefaultScoringMode  models.ScoringMode `json:"default_scoring_mode"`
+	// DefaultMaxReportAgeDays is the effective maximum report age for CheckFix requirements without one
+	DefaultMaxReportAgeDays int `json:"default_max_report_age_days"`
+	// Token expiry overrides; 0 means the global default applies
+	AccessTokenExpiryMinutes int `json:"access_token_expiry_minutes"`
+	RefreshTokenExpiryHours  int `json:"refresh_token_expiry_hours"`
+	// TerminatedRequirementStatus is what open requirements become when a relationship is terminated
+	TerminatedRequirementStatus models.RequirementStatus `json:"terminated_requirement_status" enums:"expired,cancelled"`
+	// PasswordLoginEnabled lets users sign in with a password when the deployment allows it
+	PasswordLoginEnabled bool `json:"password_login_enabled"`
+	// SSOProvisionDomains are the verified domains whose users are created on first SSO login
+	SSOProvisionDomains []string `json:"sso_provision_domains"`
 }
 
 // UpdateOrganizationRequest represents an organization update request
@@ -78,6 +111,7 @@ type UpdateAddressRequest struct {
 }
 
 // UpdateSettingsRequest represents settings update
+// #IMPLEMENTATION_DECISION: Unknown fields are ignored so older clients keep working as settings evolve
 type UpdateSettingsRequest struct {
 	DefaultDueDays       *int     `json:"default_due_days,omitempty"`
 	RequireCheckFix      *bool    `json:"require_checkfix,omitempty"`
@@ -85,6 +119,26 @@ type UpdateSettingsRequest struct {
 	NotificationEmails   []string `json:"notification_emails,omitempty"`
 	DefaultLanguage      *string  `json:"default_language,omitempty"`
 	NotificationsEnabled *bool    `json:"notifications_enabled,omitempty"`
+	ReminderDaysBefore   *int     `json:"reminder_days_before,omitempty"`
+	AutoApprove          *bool    `json:"auto_approve,omitempty"`
+	// ScoringScale replaces the scale option points are given on; existing questions are not re-checked
+	ScoringScale *models.ScoringScale `json:"scoring_scale,omitempty"`
+	// DefaultPassingScore applies to new questionnaires without a passing score; 0 restores the built-in default
+	DefaultPassingScore *int `json:"default_passing_score,omitempty"`
+	// DefaultScoringMode applies to new questionnaires without a scoring mode
+	DefaultScoringMode *models.ScoringMode `json:"default_scoring_mode,omitempty"`
+	// DefaultMaxReportAgeDays applies to CheckFix requirements without a maximum report age; 0 restores the built-in 90 days
+	DefaultMaxReportAgeDays *int `json:"default_max_report_age_days,omitempty"`
+	// AccessTokenExpiryMinutes and RefreshTokenExpiryHours shorten sessions; 0 restores the global default
+	AccessTokenExpiryMinutes *int `json:"access_token_expiry_minutes,omitempty"`
+	RefreshTokenExpiryHours  *int `json:"refresh_token_expiry_hours,omitempty"`
+	// TerminatedRequirementStatus is what open requirements become when a relationship is terminated
+	TerminatedRequirementStatus *models.RequirementStatus `json:"terminated_requirement_status,omitempty" enums:"expired,cancelled"`
+	// PasswordLoginEnabled lets users sign in with a password when the deployment allows it
+	PasswordLoginEnabled *bool `json:"password_login_enabled,omitempty"`
+	// SSOProvisionDomains replaces the verified domains whose users are created on first SSO login;
+	// an empty list turns provisioning off
+	SSOProvisionDomains []string `json:"sso_provision_domains,omitempty"`
 }
 
 // GetOrganization handles GET /api/v1/organization
@@ -101,7 +155,7 @@ type UpdateSettingsRequest struct {
 func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
 	orgID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -111,13 +165,13 @@ func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
 	org, err := h.orgRepo.GetByID(c.Request.Context(), orgID)
 	if err != nil {
 		if errors.Is(err, models.ErrOrganizationNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
+			writeError(c, http.StatusNotFound, ErrorResponse{
 				Error:   "not_found",
 				Message: "Organization not found",
 			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to get organization",
 		})
@@ -138,12 +192,13 @@ func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
 // @Success 200 {object} OrganizationResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /organization [patch]
 func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
 	orgID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -152,7 +207,7 @@ func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
 
 	var req UpdateOrganizationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Invalid request body",
 		})
@@ -162,13 +217,13 @@ func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
 	org, err := h.orgRepo.GetByID(c.Request.Context(), orgID)
 	if err != nil {
 		if errors.Is(err, models.ErrOrganizationNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
+			writeError(c, http.StatusNotFound, ErrorResponse{
 				Error:   "not_found",
 				Message: "Organization not found",
 			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to get organization",
 		})
@@ -210,30 +265,25 @@ func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
 
 	// Update settings
 	if req.Settings != nil {
-		if req.Settings.DefaultDueDays != nil {
-			org.Settings.DefaultDueDays = *req.Settings.DefaultDueDays
-		}
-		if req.Settings.RequireCheckFix != nil {
-			org.Settings.RequireCheckFix = *req.Settings.RequireCheckFix
-		}
-		if req.Settings.MinCheckFixGrade != nil {
-			org.Settings.MinCheckFixGrade = *req.Settings.MinCheckFixGrade
-		}
-		if req.Settings.NotificationEmails != nil {
-			org.Settings.NotificationEmails = req.Settings.NotificationEmails
+		previous := org.Settings
+		applySettingsUpdate(&org.Settings, req.Settings)
+		err := org.Settings.ValidateUpdate(previous)
+		if err == nil && req.Settings.SSOProvisionDomains != nil {
+			err = org.ValidateSSOProvisionDomains()
 		}
-		if req.Settings.DefaultLanguage != nil {
-			org.Settings.DefaultLanguage = *req.Settings.DefaultLanguage
-		}
-		if req.Settings.NotificationsEnabled != nil {
-			org.Settings.NotificationsEnabled = *req.Settings.NotificationsEnabled
+		if err != nil {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_settings",
+				Message: err.Error(),
+			})
+			return
 		}
 	}
 
 	org.BeforeUpdate()
 
 	if err := h.orgRepo.Update(c.Request.Context(), org); err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to update organization",
 		})
@@ -256,7 +306,7 @@ func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
 func (h *OrganizationHandler) GetOrganizationSettings(c *gin.Context) {
 	orgID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -265,26 +315,19 @@ func (h *OrganizationHandler) GetOrganizationSettings(c *gin.Context) {
 
 	org, err := h.orgRepo.GetByID(c.Request.Context(), orgID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to get organization",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, OrganizationSettingsResponse{
-		DefaultDueDays:       org.Settings.DefaultDueDays,
-		RequireCheckFix:      org.Settings.RequireCheckFix,
-		MinCheckFixGrade:     org.Settings.MinCheckFixGrade,
-		NotificationEmails:   org.Settings.NotificationEmails,
-		DefaultLanguage:      org.Settings.DefaultLanguage,
-		NotificationsEnabled: org.Settings.NotificationsEnabled,
-	})
+	c.JSON(http.StatusOK, toOrganizationSettingsResponse(&org.Settings))
 }
 
 // UpdateOrganizationSettings handles PATCH /api/v1/organization/settings
 // @Summary Update organization settings
-// @Description Updates the current organization's settings
+// @Description Updates the current organization's settings. Values are range-checked and unknown fields are ignored.
 // @Tags Organization
 // @Accept json
 // @Produce json
@@ -293,11 +336,12 @@ func (h *OrganizationHandler) GetOrganizationSettings(c *gin.Context) {
 // @Success 200 {object} OrganizationSettingsResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Router /organization/settings [patch]
 func (h *OrganizationHandler) UpdateOrganizationSettings(c *gin.Context) {
 	orgID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -306,7 +350,7 @@ func (h *OrganizationHandler) UpdateOrganizationSettings(c *gin.Context) {
 
 	var req UpdateSettingsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Invalid request body",
 		})
@@ -315,7 +359,7 @@ func (h *OrganizationHandler) UpdateOrganizationSettings(c *gin.Context) {
 
 	org, err := h.orgRepo.GetByID(c.Request.Context(), orgID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to get organization",
 		})
@@ -323,53 +367,239 @@ func (h *OrganizationHandler) UpdateOrganizationSettings(c *gin.Context) {
 	}
 
 	// Apply updates
-	if req.DefaultDueDays != nil {
-		org.Settings.DefaultDueDays = *req.DefaultDueDays
+	previous := org.Settings
+	applySettingsUpdate(&org.Settings, &req)
+	err = org.Settings.ValidateUpdate(previous)
+	if err == nil && req.SSOProvisionDomains != nil {
+		err = org.ValidateSSOProvisionDomains()
 	}
-	if req.RequireCheckFix != nil {
-		org.Settings.RequireCheckFix = *req.RequireCheckFix
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_settings",
+			Message: err.Error(),
+		})
+		return
 	}
-	if req.MinCheckFixGrade != nil {
-		org.Settings.MinCheckFixGrade = *req.MinCheckFixGrade
+
+	org.BeforeUpdate()
+
+	if err := h.orgRepo.Update(c.Request.Context(), org); err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to update settings",
+		})
+		return
 	}
-	if req.NotificationEmails != nil {
-		org.Settings.NotificationEmails = req.NotificationEmails
+
+	c.JSON(http.StatusOK, toOrganizationSettingsResponse(&org.Settings))
+}
+
+// UploadLogo handles PUT /api/v1/organization/logo
+// @Summary Upload organization logo
+// @Description Replaces the organization's logo (PNG, JPEG, GIF, or WebP up to 2 MiB)
+// @Tags Organization
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Logo image"
+// @Success 200 {object} LogoResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Router /organization/logo [put]
+func (h *OrganizationHandler) UploadLogo(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
 	}
-	if req.DefaultLanguage != nil {
-		org.Settings.DefaultLanguage = *req.DefaultLanguage
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
 	}
-	if req.NotificationsEnabled != nil {
-		org.Settings.NotificationsEnabled = *req.NotificationsEnabled
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(c, http.StatusRequestEntityTooLarge, ErrorResponse{
+				Error:   "logo_too_large",
+				Message: "Logo exceeds the maximum size of 2 MiB",
+			})
+			return
+		}
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "File is required",
+		})
+		return
 	}
 
-	org.BeforeUpdate()
+	// The declared type is checked up front; the service verifies the actual contents
+	if !strings.HasPrefix(file.Header.Get("Content-Type"), "image/") {
+		writeError(c, http.StatusUnsupportedMediaType, ErrorResponse{
+			Error:   "unsupported_media_type",
+			Message: services.ErrLogoTypeNotAllowed.Error(),
+		})
+		return
+	}
 
-	if err := h.orgRepo.Update(c.Request.Context(), org); err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+	f, err := file.Open()
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
-			Message: "Failed to update settings",
+			Message: "Failed to read file",
 		})
 		return
 	}
+	defer f.Close()
 
-	c.JSON(http.StatusOK, OrganizationSettingsResponse{
-		DefaultDueDays:       org.Settings.DefaultDueDays,
-		RequireCheckFix:      org.Settings.RequireCheckFix,
-		MinCheckFixGrade:     org.Settings.MinCheckFixGrade,
-		NotificationEmails:   org.Settings.NotificationEmails,
-		DefaultLanguage:      org.Settings.DefaultLanguage,
-		NotificationsEnabled: org.Settings.NotificationsEnabled,
+	logo, err := h.organizationService.SetLogo(c.Request.Context(), orgID, userID, f)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrLogoTypeNotAllowed):
+			writeError(c, http.StatusUnsupportedMediaType, ErrorResponse{
+				Error:   "unsupported_media_type",
+				Message: err.Error(),
+			})
+		case errors.Is(err, services.ErrLogoTooLarge):
+			writeError(c, http.StatusRequestEntityTooLarge, ErrorResponse{
+				Error:   "logo_too_large",
+				Message: "Logo exceeds the maximum size of 2 MiB",
+			})
+		case errors.Is(err, services.ErrLogoEmpty):
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Logo file is empty",
+			})
+		case errors.Is(err, services.ErrOrganizationNotFound):
+			writeError(c, http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Organization not found",
+			})
+		default:
+			writeError(c, http.StatusInternalServerError, ErrorResponse{
+				Error:   "internal_error",
+				Message: "Failed to upload logo",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, LogoResponse{
+		ContentType: logo.ContentType,
+		Size:        logo.Size,
+		ETag:        logo.ETag,
+		URL:         "/api/v1/organization/logo",
+		UpdatedAt:   logo.UpdatedAt,
+	})
+}
+
+// GetLogo handles GET /api/v1/organization/logo
+// @Summary Get organization logo
+// @Description Returns the current organization's logo image
+// @Tags Organization
+// @Produce image/png,image/jpeg,image/gif,image/webp
+// @Security BearerAuth
+// @Success 200 {file} file
+// @Success 304 "Not modified"
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /organization/logo [get]
+func (h *OrganizationHandler) GetLogo(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	logo, err := h.organizationService.GetLogo(c.Request.Context(), orgID)
+	h.serveLogo(c, logo, err, "private, no-cache")
+}
+
+// GetLogoBySlug handles GET /api/v1/organizations/:slug/logo
+// @Summary Get organization logo by slug
+// @Description Returns an organization's logo image without authentication
+// @Tags Organization
+// @Produce image/png,image/jpeg,image/gif,image/webp
+// @Param slug path string true "Organization slug"
+// @Success 200 {file} file
+// @Success 304 "Not modified"
+// @Failure 404 {object} ErrorResponse
+// @Router /organizations/{slug}/logo [get]
+func (h *OrganizationHandler) GetLogoBySlug(c *gin.Context) {
+	logo, err := h.organizationService.GetLogoBySlug(c.Request.Context(), c.Param("slug"))
+	h.serveLogo(c, logo, err, "public, max-age=3600")
+}
+
+// serveLogo writes a logo image, answering conditional requests from its content hash
+func (h *OrganizationHandler) serveLogo(c *gin.Context, logo *models.LogoRef, err error, cacheControl string) {
+	if err != nil {
+		if errors.Is(err, services.ErrLogoNotFound) || errors.Is(err, services.ErrOrganizationNotFound) {
+			writeError(c, http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Logo not found",
+			})
+			return
+		}
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to get logo",
+		})
+		return
+	}
+
+	etag := `"` + logo.ETag + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", cacheControl)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	content, err := h.organizationService.OpenLogo(c.Request.Context(), logo)
+	if err != nil {
+		writeError(c, http.StatusNotFound, ErrorResponse{
+			Error:   "not_found",
+			Message: "Logo not found",
+		})
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, logo.Size, logo.ContentType, content, map[string]string{
+		"X-Content-Type-Options": "nosniff",
+		"Last-Modified":          logo.UpdatedAt.UTC().Format(http.TimeFormat),
 	})
 }
 
 // RegisterRoutes registers organization handler routes
+// #SECURITY_ASSUMPTION: Mutating routes require the ADMIN role
 func (h *OrganizationHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
 	org := rg.Group("/organization")
 	org.Use(authMiddleware)
 	org.GET("", h.GetOrganization)
-	org.PATCH("", h.UpdateOrganization)
+	org.PATCH("", middleware.RequireAdmin(), h.UpdateOrganization)
 	org.GET("/settings", h.GetOrganizationSettings)
-	org.PATCH("/settings", h.UpdateOrganizationSettings)
+	org.PATCH("/settings", middleware.RequireAdmin(), h.UpdateOrganizationSettings)
+	org.GET("/logo", h.GetLogo)
+	org.PUT("/logo", middleware.RequireAdmin(), h.UploadLogo)
+
+	// Public logo lookup for certificates and unauthenticated pages
+	rg.GET("/organizations/:slug/logo", h.GetLogoBySlug)
 }
 
 // toOrganizationResponse converts an organization to API response
@@ -380,18 +610,15 @@ func toOrganizationResponse(org *models.Organization) OrganizationResponse {
 		Name:         org.Name,
 		Slug:         org.Slug,
 		Domain:       org.Domain,
+		Domains:      org.VerifiedDomains(),
 		ContactEmail: org.ContactEmail,
 		ContactPhone: org.ContactPhone,
-		Settings: OrganizationSettingsResponse{
-			DefaultDueDays:       org.Settings.DefaultDueDays,
-			RequireCheckFix:      org.Settings.RequireCheckFix,
-			MinCheckFixGrade:     org.Settings.MinCheckFixGrade,
-			NotificationEmails:   org.Settings.NotificationEmails,
-			DefaultLanguage:      org.Settings.DefaultLanguage,
-			NotificationsEnabled: org.Settings.NotificationsEnabled,
-		},
-		CreatedAt: org.CreatedAt,
-		UpdatedAt: org.UpdatedAt,
+		Settings:     toOrganizationSettingsResponse(&org.Settings),
+		CreatedAt:    org.CreatedAt,
+		UpdatedAt:    org.UpdatedAt,
+	}
+	if org.Logo != nil {
+		resp.LogoURL = "/api/v1/organizations/" + org.Slug + "/logo"
 	}
 
 	if org.Address != nil {
@@ -405,3 +632,93 @@ func toOrganizationResponse(org *models.Organization) OrganizationResponse {
 
 	return resp
 }
+
+// toOrganizationSettingsResponse converts organization settings to API response
+func toOrganizationSettingsResponse(s *models.OrganizationSettings) OrganizationSettingsResponse {
+	return OrganizationSettingsResponse{
+		DefaultDueDays:       s.DefaultDueDays,
+		RequireCheckFix:      s.RequireCheckFix,
+		MinCheckFixGrade:     s.MinCheckFixGrade,
+		NotificationEmails:   s.NotificationEmails,
+		DefaultLanguage:      s.DefaultLanguage,
+		NotificationsEnabled: s.NotificationsEnabled,
+		ReminderDaysBefore:   s.ReminderDaysBefore,
+		AutoApprove:          s.AutoApprove,
+		ScoringScale:         s.EffectiveScoringScale(),
+		DefaultPassingScore:  s.EffectiveDefaultPassingScore(),
+		DefaultScoringMode:   s.EffectiveDefaultScoringMode(),
+
+		DefaultMaxReportAgeDays: s.EffectiveDefaultMaxReportAgeDays(),
+
+		AccessTokenExpiryMinutes: s.AccessTokenExpiryMinutes,
+		RefreshTokenExpiryHours:  s.RefreshTokenExpiryHours,
+
+		TerminatedRequirementStatus: s.EffectiveTerminatedRequirementStatus(),
+
+		PasswordLoginEnabled: s.PasswordLoginEnabled,
+		SSOProvisionDomains:  s.SSOProvisionDomains,
+	}
+}
+
+// applySettingsUpdate applies the non-nil fields of a settings update
+// #IMPLEMENTATION_DECISION: Validation runs on the merged result so cross-field rules
+// (reminder before due date) see the effective values
+func applySettingsUpdate(settings *models.OrganizationSettings, req *UpdateSettingsRequest) {
+	if req.DefaultDueDays != nil {
+		settings.DefaultDueDays = *req.DefaultDueDays
+	}
+	if req.RequireCheckFix != nil {
+		settings.RequireCheckFix = *req.RequireCheckFix
+	}
+	if req.MinCheckFixGrade != nil {
+		settings.MinCheckFixGrade = strings.ToUpper(*req.MinCheckFixGrade)
+	}
+	if req.NotificationEmails != nil {
+		settings.NotificationEmails = req.NotificationEmails
+	}
+	if req.DefaultLanguage != nil {
+		settings.DefaultLanguage = strings.ToLower(*req.DefaultLanguage)
+	}
+	if req.NotificationsEnabled != nil {
+		settings.NotificationsEnabled = *req.NotificationsEnabled
+	}
+	if req.ReminderDaysBefore != nil {
+		settings.ReminderDaysBefore = *req.ReminderDaysBefore
+	}
+	if req.AutoApprove != nil {
+		settings.AutoApprove = *req.AutoApprove
+	}
+	if req.ScoringScale != nil {
+		scale := *req.ScoringScale
+		scale.Name = strings.TrimSpace(scale.Name)
+		settings.ScoringScale = &scale
+	}
+	if req.DefaultPassingScore != nil {
+		settings.DefaultPassingScore = *req.DefaultPassingScore
+	}
+	if req.DefaultScoringMode != nil {
+		settings.DefaultScoringMode = *req.DefaultScoringMode
+	}
+	if req.DefaultMaxReportAgeDays != nil {
+		settings.DefaultMaxReportAgeDays = *req.DefaultMaxReportAgeDays
+	}
+	if req.AccessTokenExpiryMinutes != nil {
+		settings.AccessTokenExpiryMinutes = *req.AccessTokenExpiryMinutes
+	}
+	if req.RefreshTokenExpiryHours != nil {
+		settings.RefreshTokenExpiryHours = *req.RefreshTokenExpiryHours
+	}
+	if req.TerminatedRequirementStatus != nil {
+		settings.TerminatedRequirementStatus = *req.TerminatedRequirementStatus
+	}
+	if req.PasswordLoginEnabled != nil {
+		settings.PasswordLoginEnabled = *req.PasswordLoginEnabled
+	}
+	if req.SSOProvisionDomains != nil {
+		domains := make([]string, 0, len(req.SSOProvisionDomains))
+		for _, domain := range req.SSOProvisionDomains {
+			domains = append(domains, models.NormalizeDomain(domain))
+		}
+		settings.SSOProvisionDomains = domains
+	}
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// validateSort returns sortBy if it is one of the allowed fields, otherwise the default sort field
+// #SECURITY_ASSUMPTION: sort_by reaches the Mongo sort document, so only known fields may pass;
+// unknown fields fall back silently instead of failing the request
+func validateSort(sortBy string, allowed []string) string {
+	if slices.Contains(allowed, sortBy) {
+		return sortBy
+	}
+	return repository.DefaultPaginationOptions().SortBy
+}
+
+// setPaginationHeaders mirrors an offset-paginated result in X-Total-Count, X-Page, X-Total-Pages
+// and a Link header with next/prev relations
+// #IMPLEMENTATION_DECISION: Link targets are the request path and query with only page replaced, so
+// filters and limit carry over; relative references avoid trusting the Host header
+func setPaginationHeaders[T any](c *gin.Context, result *repository.PaginatedResult[T]) {
+	c.Header("X-Total-Count", strconv.FormatInt(result.TotalCount, 10))
+	c.Header("X-Page", strconv.Itoa(result.Page))
+	c.Header("X-Total-Pages", strconv.Itoa(result.TotalPages))
+
+	var links []string
+	if result.Page < result.TotalPages {
+		links = append(links, paginationLink(c, result.Page+1, "next"))
+	}
+	if result.Page > 1 {
+		links = append(links, paginationLink(c, min(result.Page-1, max(result.TotalPages, 1)), "prev"))
+	}
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+}
+
+// paginationLink formats one Link header entry pointing at page of the current request
+func paginationLink(c *gin.Context, page int, rel string) string {
+	query := c.Request.URL.Query()
+	query.Set("page", strconv.Itoa(page))
+	return fmt.Sprintf(`<%s?%s>; rel="%s"`, c.Request.URL.Path, query.Encode(), rel)
+}
+
+// parseStatusFilter reads a comma-separated ?status= list such as "pending,in_progress", case-insensitively,
+// responding with 400 when no value in the list is a known status
+// #IMPLEMENTATION_DECISION: Unknown values next to known ones are dropped and reported in a Warning
+// header rather than failing the request, so a client sending a newer status still gets the rest of
+// its worklist; a list with nothing known is rejected instead of silently widening to every status
+func parseStatusFilter[S ~string](c *gin.Context, valid func(S) bool) ([]S, bool) {
+	raw := c.Query("status")
+	if raw == "" {
+		return nil, true
+	}
+
+	var statuses []S
+	var ignored []string
+	for _, value := range strings.Split(raw, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		status := S(strings.ToUpper(value))
+		if !valid(status) {
+			ignored = append(ignored, value)
+			continue
+		}
+		if !slices.Contains(statuses, status) {
+			statuses = append(statuses, status)
+		}
+	}
+	if len(statuses) == 0 && len(ignored) > 0 {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_status",
+			Message: fmt.Sprintf("Unknown status values: %s", strings.Join(ignored, ", ")),
+		})
+		return nil, false
+	}
+	if len(ignored) > 0 {
+		c.Header("Warning", fmt.Sprintf(`299 - "Ignored unknown status values: %s"`, strings.Join(ignored, ", ")))
+	}
+	return statuses, true
+}
+
+// parseCursorPagination reads keyset pagination parameters from the query string
+// #IMPLEMENTATION_DECISION: Cursor mode is opt-in via the presence of ?cursor= (an empty value
+// requests the first page), so existing page/limit clients keep offset pagination
+func parseCursorPagination(c *gin.Context) (repository.CursorPaginationOptions, bool) {
+	cursor, ok := c.GetQuery("cursor")
+	if !ok {
+		return repository.CursorPaginationOptions{}, false
+	}
+
+	opts := repository.CursorPaginationOptions{
+		After: cursor,
+		Limit: repository.DefaultPaginationOptions().Limit,
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 && limit <= 100 {
+		opts.Limit = limit
+	}
+	return opts, true
+}
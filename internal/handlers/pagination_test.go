@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+func TestSetPaginationHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     int
+		wantLink string
+	}{
+		{"first page", 1, `</suppliers?limit=20&page=2&status=active>; rel="next"`},
+		{"middle page", 2, `</suppliers?limit=20&page=3&status=active>; rel="next", </suppliers?limit=20&page=1&status=active>; rel="prev"`},
+		{"last page", 3, `</suppliers?limit=20&page=2&status=active>; rel="prev"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/suppliers", func(c *gin.Context) {
+				setPaginationHeaders(c, &repository.PaginatedResult[string]{TotalCount: 45, Page: tt.page, Limit: 20, TotalPages: 3})
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/suppliers?status=active&limit=20", nil))
+
+			if got := w.Header().Get("X-Total-Count"); got != "45" {
+				t.Errorf("X-Total-Count = %q, want 45", got)
+			}
+			if got := w.Header().Get("X-Total-Pages"); got != "3" {
+				t.Errorf("X-Total-Pages = %q, want 3", got)
+			}
+			if got, want := w.Header().Get("X-Page"), strconv.Itoa(tt.page); got != want {
+				t.Errorf("X-Page = %q, want %s", got, want)
+			}
+			if got := w.Header().Get("Link"); got != tt.wantLink {
+				t.Errorf("Link = %q, want %q", got, tt.wantLink)
+			}
+		})
+	}
+}
+
+func TestParseStatusFilter(t *testing.T) {
+	tests := []struct {
+		query       string
+		want        []models.RequirementStatus
+		wantWarning string
+		wantCode    int
+	}{
+		{"", nil, "", http.StatusOK},
+		{"pending,IN_PROGRESS,pending", []models.RequirementStatus{models.RequirementStatusPending, models.RequirementStatusInProgress}, "", http.StatusOK},
+		{"pending, bogus", []models.RequirementStatus{models.RequirementStatusPending}, `299 - "Ignored unknown status values: bogus"`, http.StatusOK},
+		// Only unknown values must not widen the list to every status
+		{"bogus,other", nil, "", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		router := gin.New()
+		var got []models.RequirementStatus
+		router.GET("/requirements", func(c *gin.Context) {
+			var ok bool
+			if got, ok = parseStatusFilter(c, models.RequirementStatus.IsValid); ok {
+				c.Status(http.StatusOK)
+			}
+		})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/requirements?status="+url.QueryEscape(tt.query), nil))
+
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%q: statuses = %v, want %v", tt.query, got, tt.want)
+		}
+		if warning := w.Header().Get("Warning"); warning != tt.wantWarning {
+			t.Errorf("%q: Warning = %q, want %q", tt.query, warning, tt.wantWarning)
+		}
+		if w.Code != tt.wantCode {
+			t.Errorf("%q: status code = %d, want %d", tt.query, w.Code, tt.wantCode)
+		}
+	}
+}
@@ -3,8 +3,10 @@ package handlers
 
 import (
 	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -25,12 +27,20 @@ const (
 // #INTEGRATION_POINT: Company portal uses these endpoints for questionnaire management
 type QuestionnaireHandler struct {
 	questionnaireService services.QuestionnaireService
+	orgRepo              repository.OrganizationRepository
+	idempotency          gin.HandlerFunc
 }
 
 // NewQuestionnaireHandler creates a new questionnaire handler
-func NewQuestionnaireHandler(questionnaireService services.QuestionnaireService) *QuestionnaireHandler {
+// idempotency deduplicates retried creates; nil disables it
+func NewQuestionnaireHandler(questionnaireService services.QuestionnaireService, orgRepo repository.OrganizationRepository, idempotency gin.HandlerFunc) *QuestionnaireHandler {
+	if idempotency == nil {
+		idempotency = func(c *gin.Context) { c.Next() }
+	}
 	return &QuestionnaireHandler{
 		questionnaireService: questionnaireService,
+		orgRepo:              orgRepo,
+		idempotency:          idempotency,
 	}
 }
 
@@ -42,6 +52,8 @@ type CreateQuestionnaireRequest struct {
 	ScoringMode  string         `json:"scoring_mode,omitempty"`
 	TemplateID   *string        `json:"template_id,omitempty"`
 	Topics       []TopicRequest `json:"topics,omitempty"`
+	// AppliesTo lists the supplier classifications the questionnaire targets, e.g. ["critical"]
+	AppliesTo []models.SupplierClassification `json:"applies_to,omitempty"`
 }
 
 // TopicRequest represents a topic in requests
@@ -54,21 +66,22 @@ type TopicRequest struct {
 
 // QuestionnaireResponse represents a questionnaire in API responses
 type QuestionnaireResponse struct {
-	ID               string          `json:"id"`
-	CompanyID        string          `json:"company_id"`
-	TemplateID       *string         `json:"template_id,omitempty"`
-	Name             string          `json:"name"`
-	Description      string          `json:"description,omitempty"`
-	Status           string          `json:"status"`
-	Version          int             `json:"version"`
-	PassingScore     int             `json:"passing_score"`
-	ScoringMode      string          `json:"scoring_mode"`
-	Topics           []TopicResponse `json:"topics"`
-	QuestionCount    int             `json:"question_count"`
-	MaxPossibleScore int             `json:"max_possible_score"`
-	CreatedAt        time.Time       `json:"created_at"`
-	UpdatedAt        time.Time       `json:"updated_at"`
-	PublishedAt      *time.Time      `json:"published_at,omitempty"`
+	ID               string                          `json:"id"`
+	CompanyID        string                          `json:"company_id"`
+	TemplateID       *string                         `json:"template_id,omitempty"`
+	Name             string                          `json:"name"`
+	Description      string                          `json:"description,omitempty"`
+	Status           string                          `json:"status"`
+	Version          int                             `json:"version"`
+	PassingScore     int                             `json:"passing_score"`
+	ScoringMode      string                          `json:"scoring_mode"`
+	Topics           []TopicResponse                 `json:"topics"`
+	AppliesTo        []models.SupplierClassification `json:"applies_to,omitempty"`
+	QuestionCount    int                             `json:"question_count"`
+	MaxPossibleScore int                             `json:"max_possible_score"`
+	CreatedAt        time.Time                       `json:"created_at"`
+	UpdatedAt        time.Time                       `json:"updated_at"`
+	PublishedAt      *time.Time                      `json:"published_at,omitempty"`
 }
 
 // TopicResponse represents a topic in responses
@@ -92,6 +105,9 @@ type QuestionResponse struct {
 	Weight          int              `json:"weight"`
 	MaxPoints       int              `json:"max_points"`
 	IsMustPass      bool             `json:"is_must_pass"`
+	Scored          bool             `json:"scored"`
+	RequireNote     bool             `json:"require_note"`
+	ControlRef      string           `json:"control_ref,omitempty"`
 	Options         []OptionResponse `json:"options,omitempty"`
 	CreatedAt       time.Time        `json:"created_at"`
 	UpdatedAt       time.Time        `json:"updated_at"`
@@ -129,6 +145,52 @@ type QuestionnaireStatsResponse struct {
 	Archived  int64 `json:"archived"`
 }
 
+// QuestionnaireAnalyticsResponse represents submission analytics for a questionnaire
+type QuestionnaireAnalyticsResponse struct {
+	QuestionnaireID string                      `json:"questionnaire_id"`
+	Submissions     int64                       `json:"submissions"`
+	PassRate        float64                     `json:"pass_rate"`
+	AverageScore    float64                     `json:"average_score"`
+	Questions       []QuestionAnalyticsResponse `json:"questions"`
+	Topics          []TopicAnalyticsResponse    `json:"topics"`
+}
+
+// QuestionAnalyticsResponse represents the failure rate of a question
+type QuestionAnalyticsResponse struct {
+	QuestionID  string  `json:"question_id"`
+	TopicID     string  `json:"topic_id"`
+	Text        string  `json:"text"`
+	Answered    int64   `json:"answered"`
+	Failed      int64   `json:"failed"`
+	FailureRate float64 `json:"failure_rate"`
+}
+
+// TopicAnalyticsResponse represents the average score of a topic
+type TopicAnalyticsResponse struct {
+	TopicID      string  `json:"topic_id"`
+	TopicName    string  `json:"topic_name,omitempty"`
+	AverageScore float64 `json:"average_score"`
+}
+
+// SelfTestRequest holds the company's own answers to a questionnaire
+type SelfTestRequest struct {
+	Answers []SubmitAnswerAPIRequest `json:"answers" binding:"required"`
+}
+
+// SelfTestResponse is the score breakdown of a self-test; nothing of it is stored
+type SelfTestResponse struct {
+	QuestionnaireID  string                     `json:"questionnaire_id"`
+	TotalScore       int                        `json:"total_score"`
+	MaxPossibleScore int                        `json:"max_possible_score"`
+	PercentageScore  float64                    `json:"percentage_score"`
+	PassingScore     int                        `json:"passing_score"`
+	Passed           bool                       `json:"passed"`
+	MustPassFailed   bool                       `json:"must_pass_failed"`
+	TopicScores      []TopicScoreResponse       `json:"topic_scores"`
+	Answers          []SubmissionAnswerResponse `json:"answers"`
+	ScoringScale     *models.ScoringScale       `json:"scoring_scale,omitempty"`
+}
+
 // CreateQuestionnaire handles POST /api/v1/questionnaires
 // @Summary Create a questionnaire
 // @Description Creates a new questionnaire, optionally from a template
@@ -146,7 +208,7 @@ type QuestionnaireStatsResponse struct {
 func (h *QuestionnaireHandler) CreateQuestionnaire(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -155,7 +217,7 @@ func (h *QuestionnaireHandler) CreateQuestionnaire(c *gin.Context) {
 
 	var req CreateQuestionnaireRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Name is required",
 		})
@@ -169,7 +231,7 @@ func (h *QuestionnaireHandler) CreateQuestionnaire(c *gin.Context) {
 		// Create from template
 		templateID, parseErr := primitive.ObjectIDFromHex(*req.TemplateID)
 		if parseErr != nil {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
+			writeError(c, http.StatusBadRequest, ErrorResponse{
 				Error:   "invalid_template_id",
 				Message: "Invalid template ID",
 			})
@@ -199,23 +261,13 @@ func (h *QuestionnaireHandler) CreateQuestionnaire(c *gin.Context) {
 			PassingScore: req.PassingScore,
 			ScoringMode:  scoringMode,
 			Topics:       topics,
+			AppliesTo:    req.AppliesTo,
 		}
 		questionnaire, err = h.questionnaireService.CreateQuestionnaire(c.Request.Context(), companyID, serviceReq)
 	}
 
 	if err != nil {
-		if errors.Is(err, services.ErrTemplateNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "template_not_found",
-				Message: "Template not found",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to create questionnaire",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -239,7 +291,7 @@ func (h *QuestionnaireHandler) CreateQuestionnaire(c *gin.Context) {
 func (h *QuestionnaireHandler) ListQuestionnaires(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -262,7 +314,7 @@ func (h *QuestionnaireHandler) ListQuestionnaires(c *gin.Context) {
 		opts.Limit = limit
 	}
 	if sortBy := c.Query("sort_by"); sortBy != "" {
-		opts.SortBy = sortBy
+		opts.SortBy = validateSort(sortBy, repository.QuestionnaireSortFields)
 	}
 	if sortDir := c.Query("sort_dir"); sortDir == sortDirectionAsc {
 		opts.SortDir = 1
@@ -270,7 +322,7 @@ func (h *QuestionnaireHandler) ListQuestionnaires(c *gin.Context) {
 
 	result, err := h.questionnaireService.ListQuestionnaires(c.Request.Context(), companyID, filters, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to list questionnaires",
 		})
@@ -282,6 +334,7 @@ func (h *QuestionnaireHandler) ListQuestionnaires(c *gin.Context) {
 		items[i] = toQuestionnaireResponse(&q)
 	}
 
+	setPaginationHeaders(c, result)
 	c.JSON(http.StatusOK, PaginatedQuestionnairesResponse{
 		Items:      items,
 		TotalCount: result.TotalCount,
@@ -307,7 +360,7 @@ func (h *QuestionnaireHandler) ListQuestionnaires(c *gin.Context) {
 func (h *QuestionnaireHandler) GetQuestionnaire(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -316,7 +369,7 @@ func (h *QuestionnaireHandler) GetQuestionnaire(c *gin.Context) {
 
 	questionnaireID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid questionnaire ID",
 		})
@@ -325,18 +378,7 @@ func (h *QuestionnaireHandler) GetQuestionnaire(c *gin.Context) {
 
 	result, err := h.questionnaireService.GetQuestionnaireWithQuestions(c.Request.Context(), questionnaireID, &companyID)
 	if err != nil {
-		if errors.Is(err, services.ErrQuestionnaireNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Questionnaire not found",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get questionnaire",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -351,33 +393,87 @@ func (h *QuestionnaireHandler) GetQuestionnaire(c *gin.Context) {
 	})
 }
 
+// ListQuestions handles GET /api/v1/questionnaires/:id/questions
+// @Summary List questionnaire questions
+// @Description Lists only the questions of a questionnaire in order, without the questionnaire itself
+// @Tags Questionnaires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Questionnaire ID"
+// @Param topic_id query string false "Only questions of this topic"
+// @Success 200 {array} QuestionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /questionnaires/{id}/questions [get]
+func (h *QuestionnaireHandler) ListQuestions(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	questionnaireID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid questionnaire ID",
+		})
+		return
+	}
+
+	result, err := h.questionnaireService.ListQuestions(c.Request.Context(), questionnaireID, companyID, c.Query("topic_id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	questions := make([]QuestionResponse, len(result))
+	for i := range result {
+		questions[i] = toQuestionResponse(&result[i])
+	}
+
+	c.JSON(http.StatusOK, questions)
+}
+
 // UpdateQuestionnaireRequest represents the update questionnaire request
 type UpdateQuestionnaireRequest struct {
 	Name         *string        `json:"name,omitempty"`
 	Description  *string        `json:"description,omitempty"`
 	PassingScore *int           `json:"passing_score,omitempty"`
 	Topics       []TopicRequest `json:"topics,omitempty"`
+	// AppliesTo replaces the targeted supplier classifications; an empty list clears them
+	AppliesTo []models.SupplierClassification `json:"applies_to,omitempty"`
+	// Version is the questionnaire version the changes are based on; the If-Match header takes precedence
+	Version *int `json:"version,omitempty"`
 }
 
 // UpdateQuestionnaire handles PATCH /api/v1/questionnaires/:id
 // @Summary Update questionnaire
-// @Description Updates a questionnaire (draft only)
+// @Description Updates a questionnaire (draft only). Send the version the changes are based on in If-Match or the body to have a stale update rejected with 409.
 // @Tags Questionnaires
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Questionnaire ID"
+// @Param If-Match header string false "Questionnaire version the changes are based on"
 // @Param request body UpdateQuestionnaireRequest true "Update request"
 // @Success 200 {object} QuestionnaireResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /questionnaires/{id} [patch]
 func (h *QuestionnaireHandler) UpdateQuestionnaire(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -386,7 +482,7 @@ func (h *QuestionnaireHandler) UpdateQuestionnaire(c *gin.Context) {
 
 	questionnaireID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid questionnaire ID",
 		})
@@ -395,13 +491,26 @@ func (h *QuestionnaireHandler) UpdateQuestionnaire(c *gin.Context) {
 
 	var req UpdateQuestionnaireRequest
 	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Invalid request body",
 		})
 		return
 	}
 
+	expectedVersion := req.Version
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		version, err := parseVersionTag(ifMatch)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_request",
+				Message: "If-Match must be a questionnaire version",
+			})
+			return
+		}
+		expectedVersion = &version
+	}
+
 	var topics []models.QuestionnaireTopic
 	if req.Topics != nil {
 		topics = make([]models.QuestionnaireTopic, len(req.Topics))
@@ -420,29 +529,14 @@ func (h *QuestionnaireHandler) UpdateQuestionnaire(c *gin.Context) {
 		Description:  req.Description,
 		PassingScore: req.PassingScore,
 		Topics:       topics,
+		AppliesTo:    req.AppliesTo,
+
+		ExpectedVersion: expectedVersion,
 	}
 
 	questionnaire, err := h.questionnaireService.UpdateQuestionnaire(c.Request.Context(), questionnaireID, companyID, serviceReq)
 	if err != nil {
-		if errors.Is(err, services.ErrQuestionnaireNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Questionnaire not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrQuestionnaireNotEditable) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "not_editable",
-				Message: "Only draft questionnaires can be edited",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to update questionnaire",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -462,11 +556,12 @@ func (h *QuestionnaireHandler) UpdateQuestionnaire(c *gin.Context) {
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /questionnaires/{id}/publish [post]
 func (h *QuestionnaireHandler) PublishQuestionnaire(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -475,7 +570,7 @@ func (h *QuestionnaireHandler) PublishQuestionnaire(c *gin.Context) {
 
 	questionnaireID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid questionnaire ID",
 		})
@@ -484,25 +579,7 @@ func (h *QuestionnaireHandler) PublishQuestionnaire(c *gin.Context) {
 
 	questionnaire, err := h.questionnaireService.PublishQuestionnaire(c.Request.Context(), questionnaireID, companyID)
 	if err != nil {
-		if errors.Is(err, services.ErrQuestionnaireNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Questionnaire not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrCannotPublish) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "cannot_publish",
-				Message: "Cannot publish: questionnaire must be in draft status and have at least one question",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to publish questionnaire",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -522,11 +599,12 @@ func (h *QuestionnaireHandler) PublishQuestionnaire(c *gin.Context) {
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /questionnaires/{id}/archive [post]
 func (h *QuestionnaireHandler) ArchiveQuestionnaire(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -535,7 +613,7 @@ func (h *QuestionnaireHandler) ArchiveQuestionnaire(c *gin.Context) {
 
 	questionnaireID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid questionnaire ID",
 		})
@@ -544,25 +622,7 @@ func (h *QuestionnaireHandler) ArchiveQuestionnaire(c *gin.Context) {
 
 	questionnaire, err := h.questionnaireService.ArchiveQuestionnaire(c.Request.Context(), questionnaireID, companyID)
 	if err != nil {
-		if errors.Is(err, services.ErrQuestionnaireNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Questionnaire not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrInvalidStatusTransition) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "invalid_transition",
-				Message: "Only published questionnaires can be archived",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to archive questionnaire",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -571,7 +631,7 @@ func (h *QuestionnaireHandler) ArchiveQuestionnaire(c *gin.Context) {
 
 // DeleteQuestionnaire handles DELETE /api/v1/questionnaires/:id
 // @Summary Delete questionnaire
-// @Description Deletes a draft questionnaire
+// @Description Deletes a draft questionnaire. It can be restored until it is purged
 // @Tags Questionnaires
 // @Accept json
 // @Produce json
@@ -586,7 +646,7 @@ func (h *QuestionnaireHandler) ArchiveQuestionnaire(c *gin.Context) {
 func (h *QuestionnaireHandler) DeleteQuestionnaire(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -595,7 +655,7 @@ func (h *QuestionnaireHandler) DeleteQuestionnaire(c *gin.Context) {
 
 	questionnaireID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid questionnaire ID",
 		})
@@ -604,29 +664,53 @@ func (h *QuestionnaireHandler) DeleteQuestionnaire(c *gin.Context) {
 
 	err = h.questionnaireService.DeleteQuestionnaire(c.Request.Context(), questionnaireID, companyID)
 	if err != nil {
-		if errors.Is(err, services.ErrQuestionnaireNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Questionnaire not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrQuestionnaireNotDeletable) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "not_deletable",
-				Message: "Only draft questionnaires can be deleted",
-			})
-			return
-		}
+		respondError(c, err)
+		return
+	}
 
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to delete questionnaire",
+	c.Status(http.StatusNoContent)
+}
+
+// RestoreQuestionnaire handles POST /api/v1/questionnaires/:id/restore
+// @Summary Restore questionnaire
+// @Description Restores a deleted questionnaire together with its questions
+// @Tags Questionnaires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Questionnaire ID"
+// @Success 200 {object} QuestionnaireResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /questionnaires/{id}/restore [post]
+func (h *QuestionnaireHandler) RestoreQuestionnaire(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
 		})
 		return
 	}
 
-	c.Status(http.StatusNoContent)
+	questionnaireID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid questionnaire ID",
+		})
+		return
+	}
+
+	questionnaire, err := h.questionnaireService.RestoreQuestionnaire(c.Request.Context(), questionnaireID, companyID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toQuestionnaireResponse(questionnaire))
 }
 
 // CreateQuestionAPIRequest represents the create question request body
@@ -638,6 +722,9 @@ type CreateQuestionAPIRequest struct {
 	Type        string          `json:"type" binding:"required"`
 	Weight      int             `json:"weight,omitempty"`
 	IsMustPass  bool            `json:"is_must_pass,omitempty"`
+	Scored      *bool           `json:"scored,omitempty"`
+	RequireNote bool            `json:"require_note,omitempty"`
+	ControlRef  string          `json:"control_ref,omitempty"`
 	Options     []OptionRequest `json:"options,omitempty"`
 }
 
@@ -668,7 +755,7 @@ type OptionRequest struct {
 func (h *QuestionnaireHandler) AddQuestion(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -677,7 +764,7 @@ func (h *QuestionnaireHandler) AddQuestion(c *gin.Context) {
 
 	questionnaireID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid questionnaire ID",
 		})
@@ -686,7 +773,7 @@ func (h *QuestionnaireHandler) AddQuestion(c *gin.Context) {
 
 	var req CreateQuestionAPIRequest
 	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Text and type are required",
 		})
@@ -712,37 +799,15 @@ func (h *QuestionnaireHandler) AddQuestion(c *gin.Context) {
 		Type:        models.QuestionType(req.Type),
 		Weight:      req.Weight,
 		IsMustPass:  req.IsMustPass,
+		Scored:      req.Scored,
+		RequireNote: req.RequireNote,
+		ControlRef:  req.ControlRef,
 		Options:     options,
 	}
 
 	question, err := h.questionnaireService.AddQuestion(c.Request.Context(), questionnaireID, companyID, serviceReq)
 	if err != nil {
-		if errors.Is(err, services.ErrQuestionnaireNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Questionnaire not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrQuestionnaireNotEditable) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "not_editable",
-				Message: "Only draft questionnaires can be edited",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrInvalidQuestionType) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "invalid_type",
-				Message: "Invalid question type",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to add question",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -757,6 +822,9 @@ type UpdateQuestionAPIRequest struct {
 	HelpText    *string         `json:"help_text,omitempty"`
 	Weight      *int            `json:"weight,omitempty"`
 	IsMustPass  *bool           `json:"is_must_pass,omitempty"`
+	Scored      *bool           `json:"scored,omitempty"`
+	RequireNote *bool           `json:"require_note,omitempty"`
+	ControlRef  *string         `json:"control_ref,omitempty"`
 	Options     []OptionRequest `json:"options,omitempty"`
 }
 
@@ -778,7 +846,7 @@ type UpdateQuestionAPIRequest struct {
 func (h *QuestionnaireHandler) UpdateQuestion(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -787,7 +855,7 @@ func (h *QuestionnaireHandler) UpdateQuestion(c *gin.Context) {
 
 	questionID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid question ID",
 		})
@@ -796,7 +864,7 @@ func (h *QuestionnaireHandler) UpdateQuestion(c *gin.Context) {
 
 	var req UpdateQuestionAPIRequest
 	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Invalid request body",
 		})
@@ -824,34 +892,66 @@ func (h *QuestionnaireHandler) UpdateQuestion(c *gin.Context) {
 		HelpText:    req.HelpText,
 		Weight:      req.Weight,
 		IsMustPass:  req.IsMustPass,
+		Scored:      req.Scored,
+		RequireNote: req.RequireNote,
+		ControlRef:  req.ControlRef,
 		Options:     options,
 	}
 
 	question, err := h.questionnaireService.UpdateQuestion(c.Request.Context(), questionID, companyID, serviceReq)
 	if err != nil {
-		if errors.Is(err, services.ErrQuestionNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Question not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrQuestionnaireNotEditable) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "not_editable",
-				Message: "Only draft questionnaires can be edited",
-			})
-			return
-		}
+		respondError(c, err)
+		return
+	}
 
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to update question",
+	c.JSON(http.StatusOK, toQuestionResponse(question))
+}
+
+// SearchQuestions handles GET /api/v1/questions/search
+// @Summary Search questions by control reference
+// @Description Finds questions across the company's questionnaires whose control reference starts with
+// @Description the given value, case-insensitively (e.g. "ISO A.9" matches "ISO A.9.2.3"). At most 100 results.
+// @Tags Questionnaires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param control_ref query string true "Control reference prefix"
+// @Success 200 {array} QuestionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /questions/search [get]
+func (h *QuestionnaireHandler) SearchQuestions(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, toQuestionResponse(question))
+	controlRef := strings.TrimSpace(c.Query("control_ref"))
+	if controlRef == "" {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_control_ref",
+			Message: "control_ref is required",
+		})
+		return
+	}
+
+	result, err := h.questionnaireService.SearchQuestionsByControlRef(c.Request.Context(), companyID, controlRef)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	questions := make([]QuestionResponse, len(result))
+	for i := range result {
+		questions[i] = toQuestionResponse(&result[i])
+	}
+
+	c.JSON(http.StatusOK, questions)
 }
 
 // DeleteQuestion handles DELETE /api/v1/questions/:id
@@ -871,7 +971,7 @@ func (h *QuestionnaireHandler) UpdateQuestion(c *gin.Context) {
 func (h *QuestionnaireHandler) DeleteQuestion(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -880,7 +980,7 @@ func (h *QuestionnaireHandler) DeleteQuestion(c *gin.Context) {
 
 	questionID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid question ID",
 		})
@@ -889,25 +989,7 @@ func (h *QuestionnaireHandler) DeleteQuestion(c *gin.Context) {
 
 	err = h.questionnaireService.DeleteQuestion(c.Request.Context(), questionID, companyID)
 	if err != nil {
-		if errors.Is(err, services.ErrQuestionNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Question not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrQuestionnaireNotEditable) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "not_editable",
-				Message: "Only draft questionnaires can be edited",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to delete question",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -937,7 +1019,7 @@ type ReorderQuestionsRequest struct {
 func (h *QuestionnaireHandler) ReorderQuestions(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -946,7 +1028,7 @@ func (h *QuestionnaireHandler) ReorderQuestions(c *gin.Context) {
 
 	questionnaireID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid questionnaire ID",
 		})
@@ -955,7 +1037,7 @@ func (h *QuestionnaireHandler) ReorderQuestions(c *gin.Context) {
 
 	var req ReorderQuestionsRequest
 	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Orders map is required",
 		})
@@ -964,29 +1046,511 @@ func (h *QuestionnaireHandler) ReorderQuestions(c *gin.Context) {
 
 	err = h.questionnaireService.ReorderQuestions(c.Request.Context(), questionnaireID, companyID, req.Orders)
 	if err != nil {
-		if errors.Is(err, services.ErrQuestionnaireNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Questionnaire not found",
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Questions reordered successfully"})
+}
+
+// DeleteQuestionsRequest represents the bulk delete questions request
+type DeleteQuestionsRequest struct {
+	QuestionIDs []string `json:"question_ids" binding:"required,min=1"`
+}
+
+// DeleteQuestionsResponse reports how many questions were deleted
+type DeleteQuestionsResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// DeleteQuestions handles POST /api/v1/questionnaires/:id/questions/delete-bulk
+// @Summary Delete questions in bulk
+// @Description Deletes several questions of a draft questionnaire and renumbers the remaining ones.
+// @Description Nothing is deleted if any ID is not a question of the questionnaire.
+// @Tags Questionnaires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Questionnaire ID"
+// @Param request body DeleteQuestionsRequest true "Question IDs"
+// @Success 200 {object} DeleteQuestionsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /questionnaires/{id}/questions/delete-bulk [post]
+func (h *QuestionnaireHandler) DeleteQuestions(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	questionnaireID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid questionnaire ID",
+		})
+		return
+	}
+
+	var req DeleteQuestionsRequest
+	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "At least one question ID is required",
+		})
+		return
+	}
+
+	questionIDs := make([]primitive.ObjectID, len(req.QuestionIDs))
+	for i, idStr := range req.QuestionIDs {
+		questionIDs[i], err = primitive.ObjectIDFromHex(idStr)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_id",
+				Message: "Invalid question ID: " + idStr,
 			})
 			return
 		}
-		if errors.Is(err, services.ErrQuestionnaireNotEditable) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "not_editable",
-				Message: "Only draft questionnaires can be edited",
+	}
+
+	deleted, err := h.questionnaireService.DeleteQuestions(c.Request.Context(), questionnaireID, companyID, questionIDs)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, DeleteQuestionsResponse{Deleted: deleted})
+}
+
+// ImportQuestionsResponse lists the questions added by a CSV import
+type ImportQuestionsResponse struct {
+	Imported  int                `json:"imported"`
+	Questions []QuestionResponse `json:"questions"`
+}
+
+// QuestionImportErrorResponse reports the CSV rows that could not be parsed
+type QuestionImportErrorResponse struct {
+	Error     string                           `json:"error"`
+	Message   string                           `json:"message"`
+	Rows      []QuestionImportRowErrorResponse `json:"rows"`
+	RequestID string                           `json:"request_id,omitempty"`
+}
+
+// QuestionImportRowErrorResponse describes a single invalid CSV row
+type QuestionImportRowErrorResponse struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ImportQuestions handles POST /api/v1/questionnaires/:id/questions/import
+// @Summary Import questions from CSV
+// @Description Appends questions from a CSV file to a draft questionnaire. The header must be
+// @Description topic_id,text,type,weight,is_must_pass,options[,control_ref] where options holds
+// @Description option_text|points|is_correct entries separated by ";". Nothing is imported if any row is invalid.
+// @Tags Questionnaires
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Questionnaire ID"
+// @Param file formData file true "CSV file"
+// @Success 201 {object} ImportQuestionsResponse
+// @Failure 400 {object} QuestionImportErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Router /questionnaires/{id}/questions/import [post]
+func (h *QuestionnaireHandler) ImportQuestions(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	questionnaireID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid questionnaire ID",
+		})
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(c, http.StatusRequestEntityTooLarge, ErrorResponse{
+				Error:   "request_too_large",
+				Message: "CSV file is too large",
 			})
 			return
 		}
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "File is required",
+		})
+		return
+	}
 
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+	if !strings.HasSuffix(strings.ToLower(file.Filename), ".csv") {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Only CSV files are supported",
+		})
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
-			Message: "Failed to reorder questions",
+			Message: "Failed to read file",
 		})
 		return
 	}
+	defer f.Close()
 
-	c.JSON(http.StatusOK, gin.H{"message": "Questions reordered successfully"})
+	content, err := io.ReadAll(f)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to read file content",
+		})
+		return
+	}
+
+	reqs, rowErrors, err := services.ParseQuestionImportCSV(content)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_csv",
+			Message: err.Error(),
+		})
+		return
+	}
+	if len(rowErrors) > 0 {
+		rows := make([]QuestionImportRowErrorResponse, len(rowErrors))
+		for i, rowErr := range rowErrors {
+			rows[i] = QuestionImportRowErrorResponse{Row: rowErr.Row, Message: rowErr.Message}
+		}
+		c.JSON(http.StatusBadRequest, QuestionImportErrorResponse{
+			Error:     "invalid_rows",
+			Message:   "Some rows could not be parsed; nothing was imported",
+			Rows:      rows,
+			RequestID: middleware.GetRequestID(c),
+		})
+		return
+	}
+
+	questions, err := h.questionnaireService.AddQuestions(c.Request.Context(), questionnaireID, companyID, reqs)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	resp := ImportQuestionsResponse{
+		Imported:  len(questions),
+		Questions: make([]QuestionResponse, len(questions)),
+	}
+	for i := range questions {
+		resp.Questions[i] = toQuestionResponse(&questions[i])
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// QuestionnaireSubmissionResponse summarizes one supplier's submission against a questionnaire
+type QuestionnaireSubmissionResponse struct {
+	ID               string     `json:"id"`
+	SupplierID       string     `json:"supplier_id"`
+	SupplierName     string     `json:"supplier_name"`
+	TotalScore       int        `json:"total_score"`
+	MaxPossibleScore int        `json:"max_possible_score"`
+	PercentageScore  float64    `json:"percentage_score"`
+	Passed           bool       `json:"passed"`
+	SubmittedAt      *time.Time `json:"submitted_at,omitempty"`
+}
+
+// PaginatedQuestionnaireSubmissionsResponse represents a page of questionnaire submissions
+type PaginatedQuestionnaireSubmissionsResponse struct {
+	Items      []QuestionnaireSubmissionResponse `json:"items"`
+	TotalCount int64                             `json:"total_count"`
+	Page       int                               `json:"page"`
+	Limit      int                               `json:"limit"`
+	TotalPages int                               `json:"total_pages"`
+}
+
+// ListSubmissions handles GET /api/v1/questionnaires/:id/submissions
+// @Summary List questionnaire submissions
+// @Description Lists submissions against a questionnaire across all suppliers, newest first
+// @Tags Questionnaires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Questionnaire ID"
+// @Param passed query bool false "Only passed (true) or failed (false) submissions"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} PaginatedQuestionnaireSubmissionsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /questionnaires/{id}/submissions [get]
+func (h *QuestionnaireHandler) ListSubmissions(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	questionnaireID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid questionnaire ID",
+		})
+		return
+	}
+
+	var passed *bool
+	if passedStr := c.Query("passed"); passedStr != "" {
+		value, parseErr := strconv.ParseBool(passedStr)
+		if parseErr != nil {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_passed",
+				Message: "passed must be true or false",
+			})
+			return
+		}
+		passed = &value
+	}
+
+	opts := repository.DefaultPaginationOptions()
+	opts.SortBy = "submitted_at"
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		opts.Page = page
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 && limit <= 100 {
+		opts.Limit = limit
+	}
+
+	result, err := h.questionnaireService.ListSubmissions(c.Request.Context(), questionnaireID, companyID, passed, opts)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	resolver := newOrgNameResolver()
+	for i := range result.Items {
+		resolver.add(&result.Items[i].SupplierID)
+	}
+	names := resolver.resolve(c.Request.Context(), h.orgRepo)
+
+	items := make([]QuestionnaireSubmissionResponse, len(result.Items))
+	for i := range result.Items {
+		s := &result.Items[i]
+		items[i] = QuestionnaireSubmissionResponse{
+			ID:               s.ID.Hex(),
+			SupplierID:       s.SupplierID.Hex(),
+			SupplierName:     names[s.SupplierID],
+			TotalScore:       s.TotalScore,
+			MaxPossibleScore: s.MaxPossibleScore,
+			PercentageScore:  s.PercentageScore,
+			Passed:           s.Passed,
+			SubmittedAt:      s.SubmittedAt,
+		}
+	}
+
+	setPaginationHeaders(c, result)
+	c.JSON(http.StatusOK, PaginatedQuestionnaireSubmissionsResponse{
+		Items:      items,
+		TotalCount: result.TotalCount,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		TotalPages: result.TotalPages,
+	})
+}
+
+// GetQuestionnaireAnalytics handles GET /api/v1/questionnaires/:id/analytics
+// @Summary Get questionnaire analytics
+// @Description Gets pass rate, average score, per-question failure rates (most failed first) and per-topic
+// @Description average scores (lowest first) over submitted responses. Percentages are 0 with no submissions.
+// @Tags Questionnaires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Questionnaire ID"
+// @Success 200 {object} QuestionnaireAnalyticsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /questionnaires/{id}/analytics [get]
+func (h *QuestionnaireHandler) GetQuestionnaireAnalytics(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	questionnaireID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid questionnaire ID",
+		})
+		return
+	}
+
+	analytics, err := h.questionnaireService.GetQuestionnaireAnalytics(c.Request.Context(), questionnaireID, companyID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	questions := make([]QuestionAnalyticsResponse, len(analytics.Questions))
+	for i, q := range analytics.Questions {
+		questions[i] = QuestionAnalyticsResponse{
+			QuestionID:  q.QuestionID.Hex(),
+			TopicID:     q.TopicID,
+			Text:        q.Text,
+			Answered:    q.Answered,
+			Failed:      q.Failed,
+			FailureRate: q.FailureRate,
+		}
+	}
+
+	topics := make([]TopicAnalyticsResponse, len(analytics.Topics))
+	for i, t := range analytics.Topics {
+		topics[i] = TopicAnalyticsResponse{
+			TopicID:      t.TopicID,
+			TopicName:    t.TopicName,
+			AverageScore: t.AverageScore,
+		}
+	}
+
+	c.JSON(http.StatusOK, QuestionnaireAnalyticsResponse{
+		QuestionnaireID: analytics.QuestionnaireID.Hex(),
+		Submissions:     analytics.Submissions,
+		PassRate:        analytics.PassRate,
+		AverageScore:    analytics.AverageScore,
+		Questions:       questions,
+		Topics:          topics,
+	})
+}
+
+// SelfTestQuestionnaire handles POST /api/v1/questionnaires/:id/self-test
+// @Summary Self-test a questionnaire
+// @Description Scores the company's own answers with the same rules as supplier submissions and returns
+// @Description the full breakdown. Works on draft questionnaires; no response or submission is created.
+// @Tags Questionnaires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Questionnaire ID"
+// @Param request body SelfTestRequest true "Answers to score"
+// @Success 200 {object} SelfTestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /questionnaires/{id}/self-test [post]
+func (h *QuestionnaireHandler) SelfTestQuestionnaire(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	questionnaireID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid questionnaire ID",
+		})
+		return
+	}
+
+	var req SelfTestRequest
+	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Answers are required",
+		})
+		return
+	}
+
+	answers := make([]services.SubmitAnswerRequest, len(req.Answers))
+	for i, a := range req.Answers {
+		answers[i] = services.SubmitAnswerRequest{
+			QuestionID:      a.QuestionID,
+			SelectedOptions: a.SelectedOptions,
+			TextAnswer:      a.TextAnswer,
+			Note:            a.Note,
+		}
+	}
+
+	result, err := h.questionnaireService.SelfTestQuestionnaire(c.Request.Context(), questionnaireID, companyID, answers)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	topicScores := make([]TopicScoreResponse, len(result.Submission.TopicScores))
+	for i, ts := range result.Submission.TopicScores {
+		topicScores[i] = TopicScoreResponse{
+			TopicID:         ts.TopicID,
+			TopicName:       ts.TopicName,
+			Score:           ts.Score,
+			MaxScore:        ts.MaxScore,
+			PercentageScore: ts.PercentageScore,
+		}
+	}
+
+	scoredAnswers := make([]SubmissionAnswerResponse, len(result.Submission.Answers))
+	for i, a := range result.Submission.Answers {
+		scoredAnswers[i] = SubmissionAnswerResponse{
+			QuestionID:      a.QuestionID.Hex(),
+			SelectedOptions: a.SelectedOptions,
+			TextAnswer:      a.TextAnswer,
+			Note:            a.Note,
+			PointsEarned:    a.PointsEarned,
+			MaxPoints:       a.MaxPoints,
+			IsMustPassMet:   a.IsMustPassMet,
+		}
+	}
+
+	c.JSON(http.StatusOK, SelfTestResponse{
+		QuestionnaireID:  questionnaireID.Hex(),
+		TotalScore:       result.Submission.TotalScore,
+		MaxPossibleScore: result.Submission.MaxPossibleScore,
+		PercentageScore:  result.Submission.PercentageScore,
+		PassingScore:     result.PassingScore,
+		Passed:           result.Submission.Passed,
+		MustPassFailed:   result.Submission.MustPassFailed,
+		TopicScores:      topicScores,
+		Answers:          scoredAnswers,
+		ScoringScale:     result.Submission.ScoringScale,
+	})
 }
 
 // GetQuestionnaireStats handles GET /api/v1/questionnaires/stats
@@ -1003,7 +1567,7 @@ func (h *QuestionnaireHandler) ReorderQuestions(c *gin.Context) {
 func (h *QuestionnaireHandler) GetQuestionnaireStats(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -1012,7 +1576,7 @@ func (h *QuestionnaireHandler) GetQuestionnaireStats(c *gin.Context) {
 
 	stats, err := h.questionnaireService.GetQuestionnaireStats(c.Request.Context(), companyID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to get questionnaire stats",
 		})
@@ -1027,29 +1591,76 @@ func (h *QuestionnaireHandler) GetQuestionnaireStats(c *gin.Context) {
 	})
 }
 
+// ListRecommendedQuestionnaires handles GET /api/v1/questionnaires/recommended
+// @Summary List recommended questionnaires
+// @Description Lists the company's published questionnaires that target a supplier classification. Targeting is advisory; any questionnaire can still be assigned.
+// @Tags Questionnaires
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param classification query string true "Supplier classification" Enums(critical, important, standard)
+// @Success 200 {array} QuestionnaireResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /questionnaires/recommended [get]
+func (h *QuestionnaireHandler) ListRecommendedQuestionnaires(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	classification := models.SupplierClassification(strings.ToUpper(c.Query("classification")))
+	questionnaires, err := h.questionnaireService.ListRecommended(c.Request.Context(), companyID, classification)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	items := make([]QuestionnaireResponse, len(questionnaires))
+	for i := range questionnaires {
+		items[i] = toQuestionnaireResponse(&questionnaires[i])
+	}
+	c.JSON(http.StatusOK, items)
+}
+
 // RegisterRoutes registers questionnaire handler routes
 // #INTEGRATION_POINT: Routes require authentication and company organization type
+// #SECURITY_ASSUMPTION: Mutating routes additionally require the ADMIN role
 func (h *QuestionnaireHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
 	questionnaires := rg.Group("/questionnaires")
 	questionnaires.Use(authMiddleware)
 	questionnaires.Use(middleware.RequireCompany())
-	questionnaires.POST("", h.CreateQuestionnaire)
+	questionnaires.POST("", middleware.RequireAdmin(), h.idempotency, h.CreateQuestionnaire)
 	questionnaires.GET("", h.ListQuestionnaires)
 	questionnaires.GET("/stats", h.GetQuestionnaireStats)
+	questionnaires.GET("/recommended", h.ListRecommendedQuestionnaires)
 	questionnaires.GET("/:id", h.GetQuestionnaire)
-	questionnaires.PATCH("/:id", h.UpdateQuestionnaire)
-	questionnaires.DELETE("/:id", h.DeleteQuestionnaire)
-	questionnaires.POST("/:id/publish", h.PublishQuestionnaire)
-	questionnaires.POST("/:id/archive", h.ArchiveQuestionnaire)
-	questionnaires.POST("/:id/questions", h.AddQuestion)
-	questionnaires.POST("/:id/questions/reorder", h.ReorderQuestions)
+	questionnaires.GET("/:id/analytics", h.GetQuestionnaireAnalytics)
+	questionnaires.GET("/:id/questions", h.ListQuestions)
+	questionnaires.GET("/:id/submissions", h.ListSubmissions)
+	questionnaires.PATCH("/:id", middleware.RequireAdmin(), h.UpdateQuestionnaire)
+	questionnaires.DELETE("/:id", middleware.RequireAdmin(), h.DeleteQuestionnaire)
+	questionnaires.POST("/:id/publish", middleware.RequireAdmin(), h.PublishQuestionnaire)
+	questionnaires.POST("/:id/archive", middleware.RequireAdmin(), h.ArchiveQuestionnaire)
+	questionnaires.POST("/:id/restore", middleware.RequireAdmin(), h.RestoreQuestionnaire)
+	questionnaires.POST("/:id/self-test", middleware.RequireAdmin(), h.SelfTestQuestionnaire)
+	questionnaires.POST("/:id/questions", middleware.RequireAdmin(), h.AddQuestion)
+	questionnaires.POST("/:id/questions/reorder", middleware.RequireAdmin(), h.ReorderQuestions)
+	questionnaires.POST("/:id/questions/delete-bulk", middleware.RequireAdmin(), h.DeleteQuestions)
+	questionnaires.POST("/:id/questions/import", middleware.RequireAdmin(), h.ImportQuestions)
 
 	// Question routes (not nested under questionnaires for simpler URLs)
 	questions := rg.Group("/questions")
 	questions.Use(authMiddleware)
 	questions.Use(middleware.RequireCompany())
-	questions.PATCH("/:id", h.UpdateQuestion)
-	questions.DELETE("/:id", h.DeleteQuestion)
+	questions.GET("/search", h.SearchQuestions)
+	questions.PATCH("/:id", middleware.RequireAdmin(), h.UpdateQuestion)
+	questions.DELETE("/:id", middleware.RequireAdmin(), h.DeleteQuestion)
 }
 
 // toQuestionnaireResponse converts a questionnaire model to response
@@ -1068,6 +1679,7 @@ func toQuestionnaireResponse(q *models.Questionnaire) QuestionnaireResponse {
 		CreatedAt:        q.CreatedAt,
 		UpdatedAt:        q.UpdatedAt,
 		PublishedAt:      q.PublishedAt,
+		AppliesTo:        q.AppliesTo,
 	}
 
 	if q.TemplateID != nil {
@@ -1102,6 +1714,9 @@ func toQuestionResponse(q *models.Question) QuestionResponse {
 		Weight:          q.Weight,
 		MaxPoints:       q.MaxPoints,
 		IsMustPass:      q.IsMustPass,
+		Scored:          q.Scored,
+		RequireNote:     q.RequireNote,
+		ControlRef:      q.ControlRef,
 		CreatedAt:       q.CreatedAt,
 		UpdatedAt:       q.UpdatedAt,
 	}
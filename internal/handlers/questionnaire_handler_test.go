@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// stubSubmissionsQuestionnaireService filters a fixed set of submissions; other methods are not used
+type stubSubmissionsQuestionnaireService struct {
+	services.QuestionnaireService
+	submissions []models.QuestionnaireSubmission
+}
+
+func (s *stubSubmissionsQuestionnaireService) ListSubmissions(_ context.Context, _, _ primitive.ObjectID, passed *bool, opts repository.PaginationOptions) (*repository.PaginatedResult[models.QuestionnaireSubmission], error) {
+	var items []models.QuestionnaireSubmission
+	for _, submission := range s.submissions {
+		if passed == nil || submission.Passed == *passed {
+			items = append(items, submission)
+		}
+	}
+	return &repository.PaginatedResult[models.QuestionnaireSubmission]{
+		Items:      items,
+		TotalCount: int64(len(items)),
+		Page:       opts.Page,
+		Limit:      opts.Limit,
+		TotalPages: 1,
+	}, nil
+}
+
+func TestQuestionnaireHandler_ListSubmissions(t *testing.T) {
+	acme, globex := primitive.NewObjectID(), primitive.NewObjectID()
+	service := &stubSubmissionsQuestionnaireService{submissions: []models.QuestionnaireSubmission{
+		{ID: primitive.NewObjectID(), SupplierID: acme, PercentageScore: 90, Passed: true},
+		{ID: primitive.NewObjectID(), SupplierID: globex, PercentageScore: 40, Passed: false},
+	}}
+	orgRepo := &stubPortalOrgRepo{orgs: map[primitive.ObjectID]*models.Organization{
+		acme:   {ID: acme, Name: "Acme"},
+		globex: {ID: globex, Name: "Globex"},
+	}}
+	handler := NewQuestionnaireHandler(service, orgRepo, nil)
+
+	router := gin.New()
+	router.GET("/questionnaires/:id/submissions", func(c *gin.Context) {
+		c.Set(middleware.ContextKeyOrgID, primitive.NewObjectID().Hex())
+		handler.ListSubmissions(c)
+	})
+	path := "/questionnaires/" + primitive.NewObjectID().Hex() + "/submissions"
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantNames  []string
+	}{
+		{"all", "", http.StatusOK, []string{"Acme", "Globex"}},
+		{"passed", "?passed=true", http.StatusOK, []string{"Acme"}},
+		{"failed", "?passed=false", http.StatusOK, []string{"Globex"}},
+		{"invalid filter", "?passed=maybe", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", path+tt.query, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var page PaginatedQuestionnaireSubmissionsResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(page.Items) != len(tt.wantNames) {
+				t.Fatalf("Expected %d submissions, got %d", len(tt.wantNames), len(page.Items))
+			}
+			for i, name := range tt.wantNames {
+				if page.Items[i].SupplierName != name {
+					t.Errorf("Expected supplier %q at %d, got %q", name, i, page.Items[i].SupplierName)
+				}
+			}
+		})
+	}
+}
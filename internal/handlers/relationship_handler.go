@@ -2,7 +2,7 @@
 package handlers
 
 import (
-	"errors"
+	"context"
 	"net/http"
 	"strconv"
 	"time"
@@ -20,12 +20,26 @@ import (
 // #INTEGRATION_POINT: Company portal uses these endpoints for supplier management
 type RelationshipHandler struct {
 	relationshipService services.RelationshipService
+	orgRepo             repository.OrganizationRepository
+	idempotency         gin.HandlerFunc
+	loginLinkRateLimit  gin.HandlerFunc
 }
 
 // NewRelationshipHandler creates a new relationship handler
-func NewRelationshipHandler(relationshipService services.RelationshipService) *RelationshipHandler {
+// idempotency deduplicates retried invitations and loginLinkRateLimit guards supplier login
+// links; nil disables either
+func NewRelationshipHandler(relationshipService services.RelationshipService, orgRepo repository.OrganizationRepository, idempotency, loginLinkRateLimit gin.HandlerFunc) *RelationshipHandler {
+	if idempotency == nil {
+		idempotency = func(c *gin.Context) { c.Next() }
+	}
+	if loginLinkRateLimit == nil {
+		loginLinkRateLimit = func(c *gin.Context) { c.Next() }
+	}
 	return &RelationshipHandler{
 		relationshipService: relationshipService,
+		orgRepo:             orgRepo,
+		idempotency:         idempotency,
+		loginLinkRateLimit:  loginLinkRateLimit,
 	}
 }
 
@@ -43,6 +57,7 @@ type RelationshipResponse struct {
 	ID               string                 `json:"id"`
 	CompanyID        string                 `json:"company_id"`
 	SupplierID       *string                `json:"supplier_id,omitempty"`
+	SupplierName     string                 `json:"supplier_name,omitempty"`
 	InvitedEmail     string                 `json:"invited_email"`
 	Status           string                 `json:"status"`
 	Classification   string                 `json:"classification"`
@@ -64,6 +79,18 @@ type StatusChangeResponse struct {
 	ChangedAt  time.Time `json:"changed_at"`
 }
 
+// RelationshipNoteResponse represents a relationship note in API responses
+type RelationshipNoteResponse struct {
+	Text      string    `json:"text"`
+	AuthorID  string    `json:"author_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddNoteRequest represents the add note request body
+type AddNoteRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
 // SupplierStatsResponse represents supplier statistics
 type SupplierStatsResponse struct {
 	Total     int64 `json:"total"`
@@ -75,6 +102,11 @@ type SupplierStatsResponse struct {
 	Standard  int64 `json:"standard"`
 }
 
+// SendLoginLinkResponse represents the send login link response
+type SendLoginLinkResponse struct {
+	Message string `json:"message"`
+}
+
 // PaginatedRelationshipsResponse represents paginated relationships
 type PaginatedRelationshipsResponse struct {
 	Items      []RelationshipResponse `json:"items"`
@@ -82,6 +114,7 @@ type PaginatedRelationshipsResponse struct {
 	Page       int                    `json:"page"`
 	Limit      int                    `json:"limit"`
 	TotalPages int                    `json:"total_pages"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
 }
 
 // InviteSupplier handles POST /api/v1/suppliers
@@ -101,7 +134,7 @@ type PaginatedRelationshipsResponse struct {
 func (h *RelationshipHandler) InviteSupplier(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -110,7 +143,7 @@ func (h *RelationshipHandler) InviteSupplier(c *gin.Context) {
 
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -119,7 +152,7 @@ func (h *RelationshipHandler) InviteSupplier(c *gin.Context) {
 
 	var req InviteSupplierRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Invalid request body",
 		})
@@ -142,25 +175,7 @@ func (h *RelationshipHandler) InviteSupplier(c *gin.Context) {
 
 	relationship, err := h.relationshipService.InviteSupplier(c.Request.Context(), companyID, userID, serviceReq)
 	if err != nil {
-		if errors.Is(err, services.ErrRelationshipExists) {
-			c.JSON(http.StatusConflict, ErrorResponse{
-				Error:   "relationship_exists",
-				Message: "A relationship already exists with this supplier email",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrInvalidClassification) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "invalid_classification",
-				Message: "Invalid supplier classification",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to invite supplier",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -174,35 +189,47 @@ func (h *RelationshipHandler) InviteSupplier(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param status query string false "Filter by status"
+// @Param status query string false "Filter by status; comma-separated values match any, unknown values are ignored with a Warning header"
 // @Param classification query string false "Filter by classification"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(20)
+// @Param cursor query string false "Keyset cursor; pass empty for the first page, then next_cursor (switches to cursor pagination)"
 // @Success 200 {object} PaginatedRelationshipsResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Router /suppliers [get]
 func (h *RelationshipHandler) ListSuppliers(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
 		return
 	}
 
-	// Parse query parameters
-	filters := services.SupplierFilters{}
-	if status := c.Query("status"); status != "" {
-		s := models.RelationshipStatus(status)
-		filters.Status = &s
+	filters, ok := parseSupplierFilters(c)
+	if !ok {
+		return
 	}
-	if classification := c.Query("classification"); classification != "" {
-		cl := models.SupplierClassification(classification)
-		filters.Classification = &cl
+
+	if cursorOpts, ok := parseCursorPagination(c); ok {
+		result, err := h.relationshipService.ListCompanySuppliersCursor(c.Request.Context(), companyID, filters, cursorOpts)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+
+		items := h.toRelationshipResponses(c.Request.Context(), result.Items)
+
+		c.JSON(http.StatusOK, PaginatedRelationshipsResponse{
+			Items:      items,
+			Limit:      result.Limit,
+			NextCursor: result.NextCursor,
+		})
+		return
 	}
-	filters.Search = c.Query("search")
 
 	opts := repository.DefaultPaginationOptions()
 	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
@@ -212,7 +239,7 @@ func (h *RelationshipHandler) ListSuppliers(c *gin.Context) {
 		opts.Limit = limit
 	}
 	if sortBy := c.Query("sort_by"); sortBy != "" {
-		opts.SortBy = sortBy
+		opts.SortBy = validateSort(sortBy, repository.RelationshipSortFields)
 	}
 	if sortDir := c.Query("sort_dir"); sortDir == sortDirectionAsc {
 		opts.SortDir = 1
@@ -220,18 +247,16 @@ func (h *RelationshipHandler) ListSuppliers(c *gin.Context) {
 
 	result, err := h.relationshipService.ListCompanySuppliers(c.Request.Context(), companyID, filters, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to list suppliers",
 		})
 		return
 	}
 
-	items := make([]RelationshipResponse, len(result.Items))
-	for i, r := range result.Items {
-		items[i] = toRelationshipResponse(&r)
-	}
+	items := h.toRelationshipResponses(c.Request.Context(), result.Items)
 
+	setPaginationHeaders(c, result)
 	c.JSON(http.StatusOK, PaginatedRelationshipsResponse{
 		Items:      items,
 		TotalCount: result.TotalCount,
@@ -241,6 +266,66 @@ func (h *RelationshipHandler) ListSuppliers(c *gin.Context) {
 	})
 }
 
+// ExportSuppliers handles GET /api/v1/suppliers/export
+// @Summary Export suppliers
+// @Description Downloads the company's full supplier list as CSV or JSON, without pagination
+// @Tags Suppliers
+// @Produce text/csv
+// @Produce json
+// @Security BearerAuth
+// @Param format query string false "Export format (csv, json)" default(csv)
+// @Param status query string false "Filter by status; comma-separated values match any, unknown values are ignored with a Warning header"
+// @Param classification query string false "Filter by classification"
+// @Success 200 {array} services.SupplierExportRow
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /suppliers/export [get]
+func (h *RelationshipHandler) ExportSuppliers(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	format := c.DefaultQuery("format", "csv")
+	writer, ok := newExportWriter(format, c.Writer, supplierExportColumns, supplierExportRecord)
+	if !ok {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_format",
+			Message: "Format must be csv or json",
+		})
+		return
+	}
+
+	filters, ok := parseSupplierFilters(c)
+	if !ok {
+		return
+	}
+	streamExport(c, writer, "suppliers", format, "Failed to export suppliers", func(fn func([]services.SupplierExportRow) error) error {
+		return h.relationshipService.ExportCompanySuppliers(c.Request.Context(), companyID, filters, fn)
+	})
+}
+
+// parseSupplierFilters reads the supplier list filters from the query string, responding with 400 on bad input
+func parseSupplierFilters(c *gin.Context) (services.SupplierFilters, bool) {
+	filters := services.SupplierFilters{}
+	statuses, ok := parseStatusFilter(c, models.RelationshipStatus.IsValid)
+	if !ok {
+		return filters, false
+	}
+	filters.Statuses = statuses
+	if classification := c.Query("classification"); classification != "" {
+		cl := models.SupplierClassification(classification)
+		filters.Classification = &cl
+	}
+	filters.Search = c.Query("search")
+	return filters, true
+}
+
 // GetSupplier handles GET /api/v1/suppliers/:id
 // @Summary Get supplier details
 // @Description Gets details of a specific supplier relationship
@@ -257,7 +342,7 @@ func (h *RelationshipHandler) ListSuppliers(c *gin.Context) {
 func (h *RelationshipHandler) GetSupplier(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -266,7 +351,7 @@ func (h *RelationshipHandler) GetSupplier(c *gin.Context) {
 
 	relationshipID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid relationship ID",
 		})
@@ -275,18 +360,7 @@ func (h *RelationshipHandler) GetSupplier(c *gin.Context) {
 
 	relationship, err := h.relationshipService.GetRelationship(c.Request.Context(), relationshipID, &companyID)
 	if err != nil {
-		if errors.Is(err, services.ErrRelationshipNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Supplier relationship not found",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get supplier",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -316,7 +390,7 @@ type UpdateClassificationRequest struct {
 func (h *RelationshipHandler) UpdateClassification(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -325,7 +399,7 @@ func (h *RelationshipHandler) UpdateClassification(c *gin.Context) {
 
 	relationshipID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid relationship ID",
 		})
@@ -334,7 +408,7 @@ func (h *RelationshipHandler) UpdateClassification(c *gin.Context) {
 
 	var req UpdateClassificationRequest
 	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Classification is required",
 		})
@@ -344,32 +418,7 @@ func (h *RelationshipHandler) UpdateClassification(c *gin.Context) {
 	classification := models.SupplierClassification(req.Classification)
 	relationship, err := h.relationshipService.UpdateClassification(c.Request.Context(), relationshipID, companyID, classification)
 	if err != nil {
-		if errors.Is(err, services.ErrRelationshipNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Supplier relationship not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrInvalidClassification) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "invalid_classification",
-				Message: "Invalid supplier classification",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrCannotModifyRelationship) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "cannot_modify",
-				Message: "Cannot modify terminated relationship",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to update classification",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -401,7 +450,7 @@ type UpdateDetailsRequest struct {
 func (h *RelationshipHandler) UpdateDetails(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -410,7 +459,7 @@ func (h *RelationshipHandler) UpdateDetails(c *gin.Context) {
 
 	relationshipID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid relationship ID",
 		})
@@ -419,7 +468,7 @@ func (h *RelationshipHandler) UpdateDetails(c *gin.Context) {
 
 	var req UpdateDetailsRequest
 	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Invalid request body",
 		})
@@ -434,29 +483,116 @@ func (h *RelationshipHandler) UpdateDetails(c *gin.Context) {
 
 	relationship, err := h.relationshipService.UpdateDetails(c.Request.Context(), relationshipID, companyID, serviceReq)
 	if err != nil {
-		if errors.Is(err, services.ErrRelationshipNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Supplier relationship not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrCannotModifyRelationship) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "cannot_modify",
-				Message: "Cannot modify terminated relationship",
-			})
-			return
-		}
+		respondError(c, err)
+		return
+	}
 
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to update details",
+	c.JSON(http.StatusOK, toRelationshipResponse(relationship))
+}
+
+// AddNote handles POST /api/v1/suppliers/:id/notes
+// @Summary Add supplier note
+// @Description Appends a note to the supplier relationship's note history
+// @Tags Suppliers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Relationship ID"
+// @Param request body AddNoteRequest true "Note"
+// @Success 201 {object} RelationshipNoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /suppliers/{id}/notes [post]
+func (h *RelationshipHandler) AddNote(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, toRelationshipResponse(relationship))
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	relationshipID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid relationship ID",
+		})
+		return
+	}
+
+	var req AddNoteRequest
+	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	note, err := h.relationshipService.AddNote(c.Request.Context(), relationshipID, companyID, userID, req.Text)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toRelationshipNoteResponse(note))
+}
+
+// ListNotes handles GET /api/v1/suppliers/:id/notes
+// @Summary List supplier notes
+// @Description Returns the supplier relationship's note history, oldest first
+// @Tags Suppliers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Relationship ID"
+// @Success 200 {object} []RelationshipNoteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /suppliers/{id}/notes [get]
+func (h *RelationshipHandler) ListNotes(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	relationshipID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid relationship ID",
+		})
+		return
+	}
+
+	notes, err := h.relationshipService.ListNotes(c.Request.Context(), relationshipID, companyID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	items := make([]RelationshipNoteResponse, len(notes))
+	for i := range notes {
+		items[i] = toRelationshipNoteResponse(&notes[i])
+	}
+	c.JSON(http.StatusOK, items)
 }
 
 // StatusActionRequest represents a status action request
@@ -482,7 +618,7 @@ type StatusActionRequest struct {
 func (h *RelationshipHandler) SuspendSupplier(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -491,7 +627,7 @@ func (h *RelationshipHandler) SuspendSupplier(c *gin.Context) {
 
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -500,7 +636,7 @@ func (h *RelationshipHandler) SuspendSupplier(c *gin.Context) {
 
 	relationshipID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid relationship ID",
 		})
@@ -513,25 +649,7 @@ func (h *RelationshipHandler) SuspendSupplier(c *gin.Context) {
 
 	relationship, err := h.relationshipService.SuspendRelationship(c.Request.Context(), relationshipID, companyID, userID, req.Reason)
 	if err != nil {
-		if errors.Is(err, services.ErrRelationshipNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Supplier relationship not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrInvalidStatusTransition) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "invalid_transition",
-				Message: "Cannot suspend this relationship",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to suspend supplier",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -556,7 +674,7 @@ func (h *RelationshipHandler) SuspendSupplier(c *gin.Context) {
 func (h *RelationshipHandler) ReactivateSupplier(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -565,7 +683,7 @@ func (h *RelationshipHandler) ReactivateSupplier(c *gin.Context) {
 
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -574,7 +692,7 @@ func (h *RelationshipHandler) ReactivateSupplier(c *gin.Context) {
 
 	relationshipID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid relationship ID",
 		})
@@ -587,25 +705,7 @@ func (h *RelationshipHandler) ReactivateSupplier(c *gin.Context) {
 
 	relationship, err := h.relationshipService.ReactivateRelationship(c.Request.Context(), relationshipID, companyID, userID, req.Reason)
 	if err != nil {
-		if errors.Is(err, services.ErrRelationshipNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Supplier relationship not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrInvalidStatusTransition) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "invalid_transition",
-				Message: "Cannot reactivate this relationship",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to reactivate supplier",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -614,7 +714,8 @@ func (h *RelationshipHandler) ReactivateSupplier(c *gin.Context) {
 
 // TerminateSupplier handles POST /api/v1/suppliers/:id/terminate
 // @Summary Terminate supplier
-// @Description Terminates a supplier relationship
+// @Description Terminates a supplier relationship. Its open requirements are closed as expired or
+// @Description cancelled, as set in the organization's terminated_requirement_status setting.
 // @Tags Suppliers
 // @Accept json
 // @Produce json
@@ -630,7 +731,7 @@ func (h *RelationshipHandler) ReactivateSupplier(c *gin.Context) {
 func (h *RelationshipHandler) TerminateSupplier(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -639,7 +740,7 @@ func (h *RelationshipHandler) TerminateSupplier(c *gin.Context) {
 
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -648,7 +749,7 @@ func (h *RelationshipHandler) TerminateSupplier(c *gin.Context) {
 
 	relationshipID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid relationship ID",
 		})
@@ -661,25 +762,7 @@ func (h *RelationshipHandler) TerminateSupplier(c *gin.Context) {
 
 	relationship, err := h.relationshipService.TerminateRelationship(c.Request.Context(), relationshipID, companyID, userID, req.Reason)
 	if err != nil {
-		if errors.Is(err, services.ErrRelationshipNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Supplier relationship not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrInvalidStatusTransition) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "invalid_transition",
-				Message: "Cannot terminate this relationship",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to terminate supplier",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -700,7 +783,7 @@ func (h *RelationshipHandler) TerminateSupplier(c *gin.Context) {
 func (h *RelationshipHandler) GetSupplierStats(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -709,7 +792,7 @@ func (h *RelationshipHandler) GetSupplierStats(c *gin.Context) {
 
 	stats, err := h.relationshipService.GetSupplierStats(c.Request.Context(), companyID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to get supplier stats",
 		})
@@ -727,21 +810,100 @@ func (h *RelationshipHandler) GetSupplierStats(c *gin.Context) {
 	})
 }
 
+// SendSupplierLoginLink handles POST /api/v1/suppliers/:id/send-login-link
+// @Summary Send supplier login link
+// @Description Sends the supplier contact of an active relationship a magic link to sign in.
+// @Description The response is the same whether or not the contact has an account.
+// @Tags Suppliers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Relationship ID"
+// @Success 202 {object} SendLoginLinkResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /suppliers/{id}/send-login-link [post]
+func (h *RelationshipHandler) SendSupplierLoginLink(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	relationshipID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid relationship ID",
+		})
+		return
+	}
+
+	if err := h.relationshipService.SendSupplierLoginLink(c.Request.Context(), relationshipID, companyID, userID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, SendLoginLinkResponse{
+		Message: "If the supplier contact has an account, a login link has been sent.",
+	})
+}
+
 // RegisterRoutes registers relationship handler routes
 // #INTEGRATION_POINT: Routes require authentication and company organization type
+// #SECURITY_ASSUMPTION: Mutating routes additionally require the ADMIN role
 func (h *RelationshipHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
 	suppliers := rg.Group("/suppliers")
 	suppliers.Use(authMiddleware)
+	suppliers.Use(auditRequestInfo())
 	suppliers.Use(middleware.RequireCompany())
-	suppliers.POST("", h.InviteSupplier)
+	suppliers.POST("", middleware.RequireAdmin(), h.idempotency, h.InviteSupplier)
 	suppliers.GET("", h.ListSuppliers)
 	suppliers.GET("/stats", h.GetSupplierStats)
+	suppliers.GET("/export", h.ExportSuppliers)
 	suppliers.GET("/:id", h.GetSupplier)
-	suppliers.PATCH("/:id", h.UpdateDetails)
-	suppliers.PATCH("/:id/classification", h.UpdateClassification)
-	suppliers.POST("/:id/suspend", h.SuspendSupplier)
-	suppliers.POST("/:id/reactivate", h.ReactivateSupplier)
-	suppliers.POST("/:id/terminate", h.TerminateSupplier)
+	suppliers.PATCH("/:id", middleware.RequireAdmin(), h.UpdateDetails)
+	suppliers.PATCH("/:id/classification", middleware.RequireAdmin(), h.UpdateClassification)
+	suppliers.GET("/:id/notes", h.ListNotes)
+	suppliers.POST("/:id/notes", middleware.RequireAdmin(), h.AddNote)
+	suppliers.POST("/:id/suspend", middleware.RequireAdmin(), h.SuspendSupplier)
+	suppliers.POST("/:id/reactivate", middleware.RequireAdmin(), h.ReactivateSupplier)
+	suppliers.POST("/:id/terminate", middleware.RequireAdmin(), h.TerminateSupplier)
+	suppliers.POST("/:id/send-login-link", middleware.RequireAdmin(), h.loginLinkRateLimit, h.SendSupplierLoginLink)
+}
+
+// toRelationshipResponses converts a page of relationships, resolving supplier names in one lookup
+func (h *RelationshipHandler) toRelationshipResponses(ctx context.Context, relationships []models.CompanySupplierRelationship) []RelationshipResponse {
+	resolver := newOrgNameResolver()
+	for i := range relationships {
+		resolver.add(relationships[i].SupplierID)
+	}
+	names := resolver.resolve(ctx, h.orgRepo)
+
+	items := make([]RelationshipResponse, len(relationships))
+	for i := range relationships {
+		items[i] = toRelationshipResponse(&relationships[i])
+		if relationships[i].SupplierID != nil {
+			items[i].SupplierName = names[*relationships[i].SupplierID]
+		}
+	}
+	return items
 }
 
 // toRelationshipResponse converts a relationship model to response
@@ -779,3 +941,12 @@ func toRelationshipResponse(r *models.CompanySupplierRelationship) RelationshipR
 
 	return resp
 }
+
+// toRelationshipNoteResponse converts a relationship note to response
+func toRelationshipNoteResponse(n *models.RelationshipNote) RelationshipNoteResponse {
+	return RelationshipNoteResponse{
+		Text:      n.Text,
+		AuthorID:  n.AuthorID.Hex(),
+		CreatedAt: n.CreatedAt,
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// stubExportRelationshipService streams fixed export batches; other methods are not used
+type stubExportRelationshipService struct {
+	services.RelationshipService
+	batches [][]services.SupplierExportRow
+}
+
+func (s *stubExportRelationshipService) ExportCompanySuppliers(_ context.Context, _ primitive.ObjectID, _ services.SupplierFilters, fn func([]services.SupplierExportRow) error) error {
+	for _, batch := range s.batches {
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestRelationshipHandler_ExportSuppliers_CSV(t *testing.T) {
+	invitedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	service := &stubExportRelationshipService{batches: [][]services.SupplierExportRow{
+		{{SupplierName: "Acme GmbH", Email: "it@acme.example", Classification: models.SupplierClassificationCritical,
+			Status: models.RelationshipStatusActive, InvitedAt: invitedAt, AcceptedAt: &invitedAt, PendingRequirements: 2}},
+		{{SupplierName: "=HYPERLINK(\"x\")", Email: "ops@evil.example", Classification: models.SupplierClassificationStandard,
+			Status: models.RelationshipStatusPending, InvitedAt: invitedAt}},
+	}}
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextKeyOrgID, primitive.NewObjectID().Hex())
+		c.Next()
+	})
+	router.GET("/api/v1/suppliers/export", NewRelationshipHandler(service, nil, nil, nil).ExportSuppliers)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/suppliers/export?format=csv", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Disposition"); !strings.HasPrefix(got, `attachment; filename="suppliers-`) {
+		t.Errorf("Unexpected Content-Disposition %q", got)
+	}
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d records", len(records))
+	}
+	if records[1][0] != "Acme GmbH" || records[1][6] != "2026-03-01T09:00:00Z" || records[1][7] != "2" {
+		t.Errorf("Unexpected first row %v", records[1])
+	}
+	if records[2][0] != `'=HYPERLINK("x")` || records[2][6] != "" {
+		t.Errorf("Expected formula-escaped name and empty accepted date, got %v", records[2])
+	}
+}
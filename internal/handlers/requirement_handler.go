@@ -2,7 +2,9 @@
 package handlers
 
 import (
+	"context"
 	"errors"
+	"mime"
 	"net/http"
 	"strconv"
 	"time"
@@ -20,27 +22,37 @@ import (
 // #INTEGRATION_POINT: Company portal uses these endpoints for requirement management
 type RequirementHandler struct {
 	requirementService services.RequirementService
+	orgRepo            repository.OrganizationRepository
+	idempotency        gin.HandlerFunc
 }
 
 // NewRequirementHandler creates a new requirement handler
-func NewRequirementHandler(requirementService services.RequirementService) *RequirementHandler {
+// idempotency deduplicates retried creates; nil disables it
+func NewRequirementHandler(requirementService services.RequirementService, orgRepo repository.OrganizationRepository, idempotency gin.HandlerFunc) *RequirementHandler {
+	if idempotency == nil {
+		idempotency = func(c *gin.Context) { c.Next() }
+	}
 	return &RequirementHandler{
 		requirementService: requirementService,
+		orgRepo:            orgRepo,
+		idempotency:        idempotency,
 	}
 }
 
 // CreateRequirementAPIRequest represents the create requirement request body
 type CreateRequirementAPIRequest struct {
-	RelationshipID   string     `json:"relationship_id" binding:"required"`
-	Type             string     `json:"type" binding:"required"`
-	Title            string     `json:"title" binding:"required"`
-	Description      string     `json:"description,omitempty"`
-	Priority         string     `json:"priority,omitempty"`
-	DueDate          *time.Time `json:"due_date,omitempty"`
-	QuestionnaireID  *string    `json:"questionnaire_id,omitempty"`
-	PassingScore     *int       `json:"passing_score,omitempty"`
-	MinimumGrade     *string    `json:"minimum_grade,omitempty"`
-	MaxReportAgeDays *int       `json:"max_report_age_days,omitempty"`
+	RelationshipID   string            `json:"relationship_id" binding:"required"`
+	Type             string            `json:"type" binding:"required"`
+	Title            string            `json:"title" binding:"required"`
+	Description      string            `json:"description,omitempty"`
+	Priority         string            `json:"priority,omitempty"`
+	DueDate          *time.Time        `json:"due_date,omitempty"`
+	QuestionnaireID  *string           `json:"questionnaire_id,omitempty"`
+	PassingScore     *int              `json:"passing_score,omitempty"`
+	AutoApprove      *bool             `json:"auto_approve,omitempty"`
+	MinimumGrade     *string           `json:"minimum_grade,omitempty"`
+	MaxReportAgeDays *int              `json:"max_report_age_days,omitempty"`
+	CategoryMinimums map[string]string `json:"category_minimums,omitempty"`
 }
 
 // RequirementResponse represents a requirement in API responses
@@ -49,6 +61,7 @@ type RequirementResponse struct {
 	RelationshipID   string                        `json:"relationship_id"`
 	CompanyID        string                        `json:"company_id"`
 	SupplierID       string                        `json:"supplier_id"`
+	SupplierName     string                        `json:"supplier_name,omitempty"`
 	Type             string                        `json:"type"`
 	Title            string                        `json:"title"`
 	Description      string                        `json:"description,omitempty"`
@@ -57,16 +70,28 @@ type RequirementResponse struct {
 	DueDate          *time.Time                    `json:"due_date,omitempty"`
 	QuestionnaireID  *string                       `json:"questionnaire_id,omitempty"`
 	PassingScore     *int                          `json:"passing_score,omitempty"`
+	AutoApprove      bool                          `json:"auto_approve"`
 	MinimumGrade     *string                       `json:"minimum_grade,omitempty"`
 	MaxReportAgeDays *int                          `json:"max_report_age_days,omitempty"`
+	CategoryMinimums map[string]string             `json:"category_minimums,omitempty"`
 	AssignedAt       time.Time                     `json:"assigned_at"`
 	StatusHistory    []RequirementStatusChangeResp `json:"status_history,omitempty"`
+	Attachments      []AttachmentResponse          `json:"attachments,omitempty"`
 	IsOverdue        bool                          `json:"is_overdue"`
 	DaysUntilDue     int                           `json:"days_until_due"`
 	CreatedAt        time.Time                     `json:"created_at"`
 	UpdatedAt        time.Time                     `json:"updated_at"`
 }
 
+// AttachmentResponse represents requirement attachment metadata in API responses
+type AttachmentResponse struct {
+	ID          string    `json:"id"`
+	Filename    string    `json:"filename"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"content_type"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
 // RequirementStatusChangeResp represents a status change in responses
 type RequirementStatusChangeResp struct {
 	FromStatus string    `json:"from_status"`
@@ -75,6 +100,17 @@ type RequirementStatusChangeResp struct {
 	ChangedAt  time.Time `json:"changed_at"`
 }
 
+// TimelineEventResponse represents one requirement timeline event in responses
+type TimelineEventResponse struct {
+	Type        string    `json:"type"`
+	OccurredAt  time.Time `json:"occurred_at"`
+	Description string    `json:"description,omitempty"`
+	ActorUserID *string   `json:"actor_user_id,omitempty"`
+	ActorEmail  string    `json:"actor_email,omitempty"`
+	FromStatus  string    `json:"from_status,omitempty"`
+	ToStatus    string    `json:"to_status,omitempty"`
+}
+
 // PaginatedRequirementsResponse represents paginated requirements
 type PaginatedRequirementsResponse struct {
 	Items      []RequirementResponse `json:"items"`
@@ -82,6 +118,7 @@ type PaginatedRequirementsResponse struct {
 	Page       int                   `json:"page"`
 	Limit      int                   `json:"limit"`
 	TotalPages int                   `json:"total_pages"`
+	NextCursor string                `json:"next_cursor,omitempty"`
 }
 
 // RequirementStatsResponse represents requirement statistics
@@ -93,6 +130,7 @@ type RequirementStatsResponse struct {
 	Approved   int64 `json:"approved"`
 	Rejected   int64 `json:"rejected"`
 	Expired    int64 `json:"expired"`
+	Cancelled  int64 `json:"cancelled"`
 	Overdue    int64 `json:"overdue"`
 }
 
@@ -109,11 +147,12 @@ type RequirementStatsResponse struct {
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /requirements [post]
 func (h *RequirementHandler) CreateRequirement(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -122,7 +161,7 @@ func (h *RequirementHandler) CreateRequirement(c *gin.Context) {
 
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -131,7 +170,7 @@ func (h *RequirementHandler) CreateRequirement(c *gin.Context) {
 
 	var req CreateRequirementAPIRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "relationship_id, type, and title are required",
 		})
@@ -152,52 +191,15 @@ func (h *RequirementHandler) CreateRequirement(c *gin.Context) {
 		DueDate:          req.DueDate,
 		QuestionnaireID:  req.QuestionnaireID,
 		PassingScore:     req.PassingScore,
+		AutoApprove:      req.AutoApprove,
 		MinimumGrade:     req.MinimumGrade,
 		MaxReportAgeDays: req.MaxReportAgeDays,
+		CategoryMinimums: req.CategoryMinimums,
 	}
 
 	requirement, err := h.requirementService.CreateRequirement(c.Request.Context(), companyID, userID, serviceReq)
 	if err != nil {
-		if errors.Is(err, services.ErrRelationshipNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "relationship_not_found",
-				Message: "Relationship not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrRelationshipNotActive) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "relationship_not_active",
-				Message: "Requirements can only be created for active relationships",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrQuestionnaireNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "questionnaire_not_found",
-				Message: "Questionnaire not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrQuestionnaireNotPublished) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "questionnaire_not_published",
-				Message: "Questionnaire must be published",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrInvalidRequirementType) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "invalid_type",
-				Message: "Invalid requirement type",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to create requirement",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -211,18 +213,20 @@ func (h *RequirementHandler) CreateRequirement(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param status query string false "Filter by status"
+// @Param status query string false "Filter by status; comma-separated values match any, unknown values are ignored with a Warning header"
 // @Param relationship_id query string false "Filter by relationship"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(20)
+// @Param cursor query string false "Keyset cursor; pass empty for the first page, then next_cursor (switches to cursor pagination)"
 // @Success 200 {object} PaginatedRequirementsResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Router /requirements [get]
 func (h *RequirementHandler) ListRequirements(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -233,32 +237,27 @@ func (h *RequirementHandler) ListRequirements(c *gin.Context) {
 	if relationshipIDStr := c.Query("relationship_id"); relationshipIDStr != "" {
 		relationshipID, err := primitive.ObjectIDFromHex(relationshipIDStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
+			writeError(c, http.StatusBadRequest, ErrorResponse{
 				Error:   "invalid_id",
 				Message: "Invalid relationship ID",
 			})
 			return
 		}
 
-		var status *models.RequirementStatus
-		if statusStr := c.Query("status"); statusStr != "" {
-			s := models.RequirementStatus(statusStr)
-			status = &s
+		statuses, ok := parseStatusFilter(c, models.RequirementStatus.IsValid)
+		if !ok {
+			return
 		}
-
-		requirements, err := h.requirementService.ListRequirementsByRelationship(c.Request.Context(), relationshipID, status)
+		requirements, err := h.requirementService.ListRequirementsByRelationship(c.Request.Context(), relationshipID, statuses)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, ErrorResponse{
+			writeError(c, http.StatusInternalServerError, ErrorResponse{
 				Error:   "internal_error",
 				Message: "Failed to list requirements",
 			})
 			return
 		}
 
-		items := make([]RequirementResponse, len(requirements))
-		for i, r := range requirements {
-			items[i] = toRequirementResponse(&r)
-		}
+		items := h.toRequirementResponses(c.Request.Context(), requirements)
 
 		c.JSON(http.StatusOK, PaginatedRequirementsResponse{
 			Items:      items,
@@ -271,10 +270,29 @@ func (h *RequirementHandler) ListRequirements(c *gin.Context) {
 	}
 
 	// Parse query parameters
-	filters := services.RequirementFilters{}
-	if status := c.Query("status"); status != "" {
-		s := models.RequirementStatus(status)
-		filters.Status = &s
+	statuses, ok := parseStatusFilter(c, models.RequirementStatus.IsValid)
+	if !ok {
+		return
+	}
+	filters := services.RequirementFilters{
+		Statuses: statuses,
+	}
+
+	if cursorOpts, ok := parseCursorPagination(c); ok {
+		result, err := h.requirementService.ListRequirementsByCompanyCursor(c.Request.Context(), companyID, filters, cursorOpts)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+
+		items := h.toRequirementResponses(c.Request.Context(), result.Items)
+
+		c.JSON(http.StatusOK, PaginatedRequirementsResponse{
+			Items:      items,
+			Limit:      result.Limit,
+			NextCursor: result.NextCursor,
+		})
+		return
 	}
 
 	opts := repository.DefaultPaginationOptions()
@@ -285,7 +303,7 @@ func (h *RequirementHandler) ListRequirements(c *gin.Context) {
 		opts.Limit = limit
 	}
 	if sortBy := c.Query("sort_by"); sortBy != "" {
-		opts.SortBy = sortBy
+		opts.SortBy = validateSort(sortBy, repository.RequirementSortFields)
 	}
 	if sortDir := c.Query("sort_dir"); sortDir == sortDirectionAsc {
 		opts.SortDir = 1
@@ -293,18 +311,16 @@ func (h *RequirementHandler) ListRequirements(c *gin.Context) {
 
 	result, err := h.requirementService.ListRequirementsByCompany(c.Request.Context(), companyID, filters, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to list requirements",
 		})
 		return
 	}
 
-	items := make([]RequirementResponse, len(result.Items))
-	for i, r := range result.Items {
-		items[i] = toRequirementResponse(&r)
-	}
+	items := h.toRequirementResponses(c.Request.Context(), result.Items)
 
+	setPaginationHeaders(c, result)
 	c.JSON(http.StatusOK, PaginatedRequirementsResponse{
 		Items:      items,
 		TotalCount: result.TotalCount,
@@ -330,7 +346,7 @@ func (h *RequirementHandler) ListRequirements(c *gin.Context) {
 func (h *RequirementHandler) GetRequirement(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -339,7 +355,7 @@ func (h *RequirementHandler) GetRequirement(c *gin.Context) {
 
 	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid requirement ID",
 		})
@@ -348,33 +364,81 @@ func (h *RequirementHandler) GetRequirement(c *gin.Context) {
 
 	requirement, err := h.requirementService.GetRequirement(c.Request.Context(), requirementID, &companyID)
 	if err != nil {
-		if errors.Is(err, services.ErrRequirementNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Requirement not found",
-			})
-			return
-		}
+		respondError(c, err)
+		return
+	}
 
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get requirement",
+	c.JSON(http.StatusOK, toRequirementResponse(requirement))
+}
+
+// GetRequirementTimeline handles GET /api/v1/requirements/:id/timeline
+// @Summary Get requirement timeline
+// @Description Lists everything that happened to a requirement, oldest first: status changes, the supplier's response progress, reviews, reminders and other audited activity
+// @Tags Requirements
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Requirement ID"
+// @Success 200 {array} TimelineEventResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /requirements/{id}/timeline [get]
+func (h *RequirementHandler) GetRequirementTimeline(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, toRequirementResponse(requirement))
+	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid requirement ID",
+		})
+		return
+	}
+
+	events, err := h.requirementService.GetTimeline(c.Request.Context(), requirementID, companyID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	items := make([]TimelineEventResponse, len(events))
+	for i, event := range events {
+		items[i] = TimelineEventResponse{
+			Type:        string(event.Type),
+			OccurredAt:  event.OccurredAt,
+			Description: event.Description,
+			ActorEmail:  event.ActorEmail,
+			FromStatus:  string(event.FromStatus),
+			ToStatus:    string(event.ToStatus),
+		}
+		if event.ActorUserID != nil {
+			actorID := event.ActorUserID.Hex()
+			items[i].ActorUserID = &actorID
+		}
+	}
+	c.JSON(http.StatusOK, items)
 }
 
 // UpdateRequirementAPIRequest represents the update requirement request
 type UpdateRequirementAPIRequest struct {
-	Title            *string    `json:"title,omitempty"`
-	Description      *string    `json:"description,omitempty"`
-	Priority         *string    `json:"priority,omitempty"`
-	DueDate          *time.Time `json:"due_date,omitempty"`
-	PassingScore     *int       `json:"passing_score,omitempty"`
-	MinimumGrade     *string    `json:"minimum_grade,omitempty"`
-	MaxReportAgeDays *int       `json:"max_report_age_days,omitempty"`
+	Title            *string           `json:"title,omitempty"`
+	Description      *string           `json:"description,omitempty"`
+	Priority         *string           `json:"priority,omitempty"`
+	DueDate          *time.Time        `json:"due_date,omitempty"`
+	PassingScore     *int              `json:"passing_score,omitempty"`
+	AutoApprove      *bool             `json:"auto_approve,omitempty"`
+	MinimumGrade     *string           `json:"minimum_grade,omitempty"`
+	MaxReportAgeDays *int              `json:"max_report_age_days,omitempty"`
+	CategoryMinimums map[string]string `json:"category_minimums,omitempty"`
 }
 
 // UpdateRequirement handles PATCH /api/v1/requirements/:id
@@ -395,7 +459,7 @@ type UpdateRequirementAPIRequest struct {
 func (h *RequirementHandler) UpdateRequirement(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -404,7 +468,7 @@ func (h *RequirementHandler) UpdateRequirement(c *gin.Context) {
 
 	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid requirement ID",
 		})
@@ -413,7 +477,7 @@ func (h *RequirementHandler) UpdateRequirement(c *gin.Context) {
 
 	var req UpdateRequirementAPIRequest
 	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Invalid request body",
 		})
@@ -432,24 +496,84 @@ func (h *RequirementHandler) UpdateRequirement(c *gin.Context) {
 		Priority:         priority,
 		DueDate:          req.DueDate,
 		PassingScore:     req.PassingScore,
+		AutoApprove:      req.AutoApprove,
 		MinimumGrade:     req.MinimumGrade,
 		MaxReportAgeDays: req.MaxReportAgeDays,
+		CategoryMinimums: req.CategoryMinimums,
 	}
 
 	requirement, err := h.requirementService.UpdateRequirement(c.Request.Context(), requirementID, companyID, serviceReq)
 	if err != nil {
-		if errors.Is(err, services.ErrRequirementNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Requirement not found",
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRequirementResponse(requirement))
+}
+
+// CancelRequirementRequest carries the optional reason for cancelling a requirement
+type CancelRequirementRequest struct {
+	Reason string `json:"reason,omitempty" binding:"max=1000"`
+}
+
+// CancelRequirement handles POST /api/v1/requirements/:id/cancel
+// @Summary Cancel requirement
+// @Description Cancels a pending requirement before the supplier starts it. The reason is kept in the status history.
+// @Tags Requirements
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Requirement ID"
+// @Param request body CancelRequirementRequest false "Cancellation reason"
+// @Success 200 {object} RequirementResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /requirements/{id}/cancel [post]
+func (h *RequirementHandler) CancelRequirement(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid requirement ID",
+		})
+		return
+	}
+
+	var req CancelRequirementRequest
+	if c.Request.ContentLength != 0 {
+		if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Reason must be at most 1000 characters",
 			})
 			return
 		}
+	}
 
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "update_failed",
-			Message: err.Error(),
-		})
+	requirement, err := h.requirementService.CancelRequirement(c.Request.Context(), requirementID, companyID, userID, req.Reason)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -470,7 +594,7 @@ func (h *RequirementHandler) UpdateRequirement(c *gin.Context) {
 func (h *RequirementHandler) GetRequirementStats(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -479,7 +603,7 @@ func (h *RequirementHandler) GetRequirementStats(c *gin.Context) {
 
 	stats, err := h.requirementService.GetRequirementStats(c.Request.Context(), companyID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to get requirement stats",
 		})
@@ -494,21 +618,220 @@ func (h *RequirementHandler) GetRequirementStats(c *gin.Context) {
 		Approved:   stats.Approved,
 		Rejected:   stats.Rejected,
 		Expired:    stats.Expired,
+		Cancelled:  stats.Cancelled,
 		Overdue:    stats.Overdue,
 	})
 }
 
+// ListOverdueRequirements handles GET /api/v1/requirements/overdue
+// @Summary List overdue requirements
+// @Description Lists all pending and in-progress requirements of the company past their due date, most overdue first
+// @Tags Requirements
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} RequirementResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /requirements/overdue [get]
+func (h *RequirementHandler) ListOverdueRequirements(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	requirements, err := h.requirementService.ListOverdueRequirements(c.Request.Context(), companyID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to list overdue requirements",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.toRequirementResponses(c.Request.Context(), requirements))
+}
+
+// UploadAttachment handles POST /api/v1/requirements/:id/attachments
+// @Summary Upload requirement attachment
+// @Description Attaches a reference document (PDF, DOCX, XLSX, TXT, CSV, PNG, JPEG; max 10 MiB) to a requirement
+// @Tags Requirements
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Requirement ID"
+// @Param file formData file true "Attachment"
+// @Success 201 {object} AttachmentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Router /requirements/{id}/attachments [post]
+func (h *RequirementHandler) UploadAttachment(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid requirement ID",
+		})
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(c, http.StatusRequestEntityTooLarge, ErrorResponse{
+				Error:   "attachment_too_large",
+				Message: "Attachment exceeds the maximum size of 10 MiB",
+			})
+			return
+		}
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "File is required",
+		})
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to read file",
+		})
+		return
+	}
+	defer f.Close()
+
+	attachment, err := h.requirementService.AddAttachment(c.Request.Context(), requirementID, companyID, userID, file.Filename, f)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toAttachmentResponse(attachment))
+}
+
+// DownloadAttachment handles GET /api/v1/requirements/:id/attachments/:fileID/download
+// @Summary Download requirement attachment
+// @Description Downloads a requirement attachment; available to the owning company and the assigned supplier
+// @Tags Requirements
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path string true "Requirement ID"
+// @Param fileID path string true "Attachment ID"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /requirements/{id}/attachments/{fileID}/download [get]
+func (h *RequirementHandler) DownloadAttachment(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid requirement ID",
+		})
+		return
+	}
+
+	attachmentID, err := primitive.ObjectIDFromHex(c.Param("fileID"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid attachment ID",
+		})
+		return
+	}
+
+	attachment, content, err := h.requirementService.OpenAttachment(c.Request.Context(), requirementID, attachmentID, orgID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer content.Close()
+
+	// #SECURITY_ASSUMPTION: Served as a download with sniffing disabled so browsers never render it inline
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          "private, no-store",
+	})
+}
+
 // RegisterRoutes registers requirement handler routes
 // #INTEGRATION_POINT: Routes require authentication and company organization type
+// #SECURITY_ASSUMPTION: Mutating routes additionally require the ADMIN role
 func (h *RequirementHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
 	requirements := rg.Group("/requirements")
 	requirements.Use(authMiddleware)
+	requirements.Use(auditRequestInfo())
 	requirements.Use(middleware.RequireCompany())
-	requirements.POST("", h.CreateRequirement)
+	requirements.POST("", middleware.RequireAdmin(), h.idempotency, h.CreateRequirement)
 	requirements.GET("", h.ListRequirements)
 	requirements.GET("/stats", h.GetRequirementStats)
+	requirements.GET("/overdue", h.ListOverdueRequirements)
 	requirements.GET("/:id", h.GetRequirement)
-	requirements.PATCH("/:id", h.UpdateRequirement)
+	requirements.GET("/:id/timeline", h.GetRequirementTimeline)
+	requirements.PATCH("/:id", middleware.RequireAdmin(), h.UpdateRequirement)
+	requirements.POST("/:id/attachments", middleware.RequireAdmin(), h.UploadAttachment)
+	requirements.POST("/:id/cancel", middleware.RequireAdmin(), h.CancelRequirement)
+
+	// Attachment downloads are shared with the assigned supplier, so they skip RequireCompany;
+	// the service checks the caller's organization against the requirement
+	shared := rg.Group("/requirements")
+	shared.Use(authMiddleware)
+	shared.GET("/:id/attachments/:fileID/download", h.DownloadAttachment)
+}
+
+// toRequirementResponses converts a page of requirements, resolving supplier names in one lookup
+func (h *RequirementHandler) toRequirementResponses(ctx context.Context, requirements []models.Requirement) []RequirementResponse {
+	resolver := newOrgNameResolver()
+	for i := range requirements {
+		resolver.add(&requirements[i].SupplierID)
+	}
+	names := resolver.resolve(ctx, h.orgRepo)
+
+	items := make([]RequirementResponse, len(requirements))
+	for i := range requirements {
+		items[i] = toRequirementResponse(&requirements[i])
+		items[i].SupplierName = names[requirements[i].SupplierID]
+	}
+	return items
 }
 
 // toRequirementResponse converts a requirement model to response
@@ -525,8 +848,10 @@ func toRequirementResponse(r *models.Requirement) RequirementResponse {
 		Status:           string(r.Status),
 		DueDate:          r.DueDate,
 		PassingScore:     r.PassingScore,
+		AutoApprove:      r.AutoApprove,
 		MinimumGrade:     r.MinimumGrade,
 		MaxReportAgeDays: r.MaxReportAgeDays,
+		CategoryMinimums: r.CategoryMinimums,
 		AssignedAt:       r.AssignedAt,
 		IsOverdue:        r.IsOverdue(),
 		DaysUntilDue:     r.DaysUntilDue(),
@@ -539,6 +864,8 @@ func toRequirementResponse(r *models.Requirement) RequirementResponse {
 		resp.QuestionnaireID = &qID
 	}
 
+	resp.Attachments = toAttachmentResponses(r.Attachments)
+
 	// Include status history
 	resp.StatusHistory = make([]RequirementStatusChangeResp, len(r.StatusHistory))
 	for i, change := range r.StatusHistory {
@@ -552,3 +879,26 @@ func toRequirementResponse(r *models.Requirement) RequirementResponse {
 
 	return resp
 }
+
+// toAttachmentResponses converts requirement attachments to responses
+func toAttachmentResponses(attachments []models.RequirementAttachment) []AttachmentResponse {
+	if len(attachments) == 0 {
+		return nil
+	}
+	items := make([]AttachmentResponse, len(attachments))
+	for i := range attachments {
+		items[i] = toAttachmentResponse(&attachments[i])
+	}
+	return items
+}
+
+// toAttachmentResponse converts a requirement attachment to response
+func toAttachmentResponse(a *models.RequirementAttachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:          a.ID.Hex(),
+		Filename:    a.Filename,
+		Size:        a.Size,
+		ContentType: a.ContentType,
+		UploadedAt:  a.UploadedAt,
+	}
+}
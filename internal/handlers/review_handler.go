@@ -2,14 +2,16 @@
 package handlers
 
 import (
-	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"github.com/checkfix-tools/nisfix_backend/internal/apierrors"
 	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
 	"github.com/checkfix-tools/nisfix_backend/internal/services"
 )
 
@@ -81,11 +83,82 @@ type SubmissionAnswerResponse struct {
 	QuestionID      string   `json:"question_id"`
 	SelectedOptions []string `json:"selected_options,omitempty"`
 	TextAnswer      string   `json:"text_answer,omitempty"`
+	Note            string   `json:"note,omitempty"`
 	PointsEarned    int      `json:"points_earned"`
 	MaxPoints       int      `json:"max_points"`
 	IsMustPassMet   *bool    `json:"is_must_pass_met,omitempty"`
 }
 
+// BulkReviewRequest represents a batch of review decisions
+type BulkReviewRequest struct {
+	Approvals []BulkReviewItemRequest `json:"approvals" binding:"required"`
+}
+
+// BulkReviewItemRequest represents a single decision in a bulk review
+type BulkReviewItemRequest struct {
+	RequirementID string `json:"requirement_id" binding:"required"`
+	Decision      string `json:"decision" binding:"required" enums:"approve,reject,request_revision"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// BulkReviewResponse reports the outcome of a bulk review
+type BulkReviewResponse struct {
+	Succeeded int                      `json:"succeeded"`
+	Failed    int                      `json:"failed"`
+	Results   []BulkReviewItemResponse `json:"results"`
+}
+
+// BulkReviewItemResponse reports the outcome for one requirement
+type BulkReviewItemResponse struct {
+	RequirementID string `json:"requirement_id"`
+	Success       bool   `json:"success"`
+	Status        string `json:"status,omitempty"`
+	Error         string `json:"error,omitempty"`
+	Message       string `json:"message,omitempty"`
+}
+
+// SubmissionDetailResponse is a question-by-question view of a submission
+type SubmissionDetailResponse struct {
+	RequirementID    string                         `json:"requirement_id"`
+	ResponseID       string                         `json:"response_id"`
+	SubmissionID     string                         `json:"submission_id"`
+	TotalScore       int                            `json:"total_score"`
+	MaxPossibleScore int                            `json:"max_possible_score"`
+	PercentageScore  float64                        `json:"percentage_score"`
+	Passed           bool                           `json:"passed"`
+	MustPassFailed   bool                           `json:"must_pass_failed"`
+	SubmittedAt      *time.Time                     `json:"submitted_at,omitempty"`
+	Questions        []SubmissionQuestionDetailItem `json:"questions"`
+	// ScoringScale is the scale the answers were scored on; unset for submissions made before scales existed
+	ScoringScale *models.ScoringScale `json:"scoring_scale,omitempty"`
+}
+
+// SubmissionQuestionDetailItem pairs a question with the supplier's answer and awarded points
+type SubmissionQuestionDetailItem struct {
+	QuestionID      string                   `json:"question_id"`
+	TopicID         string                   `json:"topic_id"`
+	Text            string                   `json:"text"`
+	Type            string                   `json:"type"`
+	Order           int                      `json:"order"`
+	IsMustPass      bool                     `json:"is_must_pass"`
+	Scored          bool                     `json:"scored"`
+	RequireNote     bool                     `json:"require_note"`
+	Answered        bool                     `json:"answered"`
+	SelectedOptions []SelectedOptionResponse `json:"selected_options"`
+	TextAnswer      string                   `json:"text_answer,omitempty"`
+	Note            string                   `json:"note,omitempty"`
+	PointsEarned    int                      `json:"points_earned"`
+	MaxPoints       int                      `json:"max_points"`
+	IsMustPassMet   *bool                    `json:"is_must_pass_met,omitempty"`
+}
+
+// SelectedOptionResponse represents an option chosen by the supplier
+type SelectedOptionResponse struct {
+	ID     string `json:"id"`
+	Text   string `json:"text"`
+	Points int    `json:"points"`
+}
+
 // GetSubmissionForReview handles GET /api/v1/requirements/:id/review
 // @Summary Get submission for review
 // @Description Gets the submission details for reviewing
@@ -102,7 +175,7 @@ type SubmissionAnswerResponse struct {
 func (h *ReviewHandler) GetSubmissionForReview(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -111,7 +184,7 @@ func (h *ReviewHandler) GetSubmissionForReview(c *gin.Context) {
 
 	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid requirement ID",
 		})
@@ -120,25 +193,7 @@ func (h *ReviewHandler) GetSubmissionForReview(c *gin.Context) {
 
 	result, err := h.reviewService.GetSubmissionForReview(c.Request.Context(), requirementID, companyID)
 	if err != nil {
-		if errors.Is(err, services.ErrRequirementNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Requirement not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrNoSubmission) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "no_submission",
-				Message: "No submission to review",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get submission",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -180,6 +235,7 @@ func (h *ReviewHandler) GetSubmissionForReview(c *gin.Context) {
 				QuestionID:      a.QuestionID.Hex(),
 				SelectedOptions: a.SelectedOptions,
 				TextAnswer:      a.TextAnswer,
+				Note:            a.Note,
 				PointsEarned:    a.PointsEarned,
 				MaxPoints:       a.MaxPoints,
 				IsMustPassMet:   a.IsMustPassMet,
@@ -202,6 +258,93 @@ func (h *ReviewHandler) GetSubmissionForReview(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// GetSubmissionDetail handles GET /api/v1/requirements/:id/submission
+// @Summary Get submission detail
+// @Description Gets each questionnaire question paired with the supplier's answer and points awarded
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Requirement ID"
+// @Success 200 {object} SubmissionDetailResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /requirements/{id}/submission [get]
+func (h *ReviewHandler) GetSubmissionDetail(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid requirement ID",
+		})
+		return
+	}
+
+	detail, err := h.reviewService.GetSubmissionDetail(c.Request.Context(), requirementID, companyID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSubmissionDetailResponse(detail))
+}
+
+// toSubmissionDetailResponse converts a service submission detail to its API representation
+func toSubmissionDetailResponse(detail *services.SubmissionDetail) SubmissionDetailResponse {
+	questions := make([]SubmissionQuestionDetailItem, len(detail.Items))
+	for i, item := range detail.Items {
+		q := SubmissionQuestionDetailItem{
+			QuestionID:      item.Question.ID.Hex(),
+			TopicID:         item.Question.TopicID,
+			Text:            item.Question.Text,
+			Type:            string(item.Question.Type),
+			Order:           item.Question.Order,
+			IsMustPass:      item.Question.IsMustPass,
+			Scored:          item.Question.Scored,
+			RequireNote:     item.Question.RequireNote,
+			MaxPoints:       item.Question.MaxPoints,
+			SelectedOptions: make([]SelectedOptionResponse, len(item.SelectedOptions)),
+		}
+		for j, opt := range item.SelectedOptions {
+			q.SelectedOptions[j] = SelectedOptionResponse{ID: opt.ID, Text: opt.Text, Points: opt.Points}
+		}
+		if item.Answer != nil {
+			q.Answered = true
+			q.TextAnswer = item.Answer.TextAnswer
+			q.Note = item.Answer.Note
+			q.PointsEarned = item.Answer.PointsEarned
+			q.MaxPoints = item.Answer.MaxPoints
+			q.IsMustPassMet = item.Answer.IsMustPassMet
+		}
+		questions[i] = q
+	}
+
+	return SubmissionDetailResponse{
+		RequirementID:    detail.Requirement.ID.Hex(),
+		ResponseID:       detail.Response.ID.Hex(),
+		SubmissionID:     detail.Submission.ID.Hex(),
+		TotalScore:       detail.Submission.TotalScore,
+		MaxPossibleScore: detail.Submission.MaxPossibleScore,
+		PercentageScore:  detail.Submission.PercentageScore,
+		Passed:           detail.Submission.Passed,
+		MustPassFailed:   detail.Submission.MustPassFailed,
+		SubmittedAt:      detail.Submission.SubmittedAt,
+		Questions:        questions,
+		ScoringScale:     detail.Submission.ScoringScale,
+	}
+}
+
 // ApproveRequirement handles POST /api/v1/requirements/:id/approve
 // @Summary Approve requirement
 // @Description Approves a submitted requirement
@@ -220,7 +363,7 @@ func (h *ReviewHandler) GetSubmissionForReview(c *gin.Context) {
 func (h *ReviewHandler) ApproveRequirement(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -229,7 +372,7 @@ func (h *ReviewHandler) ApproveRequirement(c *gin.Context) {
 
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -238,7 +381,7 @@ func (h *ReviewHandler) ApproveRequirement(c *gin.Context) {
 
 	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid requirement ID",
 		})
@@ -251,25 +394,7 @@ func (h *ReviewHandler) ApproveRequirement(c *gin.Context) {
 
 	requirement, err := h.reviewService.ApproveRequirement(c.Request.Context(), requirementID, companyID, userID, req.Notes)
 	if err != nil {
-		if errors.Is(err, services.ErrRequirementNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Requirement not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrCannotReview) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "cannot_review",
-				Message: "Cannot approve this requirement",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to approve requirement",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -294,7 +419,7 @@ func (h *ReviewHandler) ApproveRequirement(c *gin.Context) {
 func (h *ReviewHandler) RejectRequirement(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -303,7 +428,7 @@ func (h *ReviewHandler) RejectRequirement(c *gin.Context) {
 
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -312,7 +437,7 @@ func (h *ReviewHandler) RejectRequirement(c *gin.Context) {
 
 	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid requirement ID",
 		})
@@ -321,7 +446,7 @@ func (h *ReviewHandler) RejectRequirement(c *gin.Context) {
 
 	var req ReviewActionRequest
 	if bindErr := c.ShouldBindJSON(&req); bindErr != nil || req.Reason == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Rejection reason is required",
 		})
@@ -330,25 +455,7 @@ func (h *ReviewHandler) RejectRequirement(c *gin.Context) {
 
 	requirement, err := h.reviewService.RejectRequirement(c.Request.Context(), requirementID, companyID, userID, req.Reason)
 	if err != nil {
-		if errors.Is(err, services.ErrRequirementNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Requirement not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrCannotReview) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "cannot_review",
-				Message: "Cannot reject this requirement",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to reject requirement",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -373,7 +480,7 @@ func (h *ReviewHandler) RejectRequirement(c *gin.Context) {
 func (h *ReviewHandler) RequestRevision(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -382,7 +489,7 @@ func (h *ReviewHandler) RequestRevision(c *gin.Context) {
 
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -391,7 +498,7 @@ func (h *ReviewHandler) RequestRevision(c *gin.Context) {
 
 	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid requirement ID",
 		})
@@ -400,7 +507,7 @@ func (h *ReviewHandler) RequestRevision(c *gin.Context) {
 
 	var req ReviewActionRequest
 	if bindErr := c.ShouldBindJSON(&req); bindErr != nil || req.Reason == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Revision reason is required",
 		})
@@ -409,40 +516,178 @@ func (h *ReviewHandler) RequestRevision(c *gin.Context) {
 
 	requirement, err := h.reviewService.RequestRevision(c.Request.Context(), requirementID, companyID, userID, req.Reason)
 	if err != nil {
-		if errors.Is(err, services.ErrRequirementNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Requirement not found",
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRequirementResponse(requirement))
+}
+
+// BulkReview handles POST /api/v1/reviews/bulk
+// @Summary Bulk review requirements
+// @Description Approves, rejects or requests revision for several submitted requirements; each item succeeds or fails independently
+// @Tags Review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body BulkReviewRequest true "Review decisions"
+// @Success 200 {object} BulkReviewResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /reviews/bulk [post]
+func (h *ReviewHandler) BulkReview(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	var req BulkReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if len(req.Approvals) == 0 || len(req.Approvals) > services.MaxBulkReviewItems {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: fmt.Sprintf("Between 1 and %d approvals are required", services.MaxBulkReviewItems),
+		})
+		return
+	}
+
+	items := make([]services.BulkReviewItem, len(req.Approvals))
+	for i, a := range req.Approvals {
+		requirementID, err := primitive.ObjectIDFromHex(a.RequirementID)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_id",
+				Message: fmt.Sprintf("Invalid requirement ID at index %d", i),
 			})
 			return
 		}
-		if errors.Is(err, services.ErrCannotReview) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "cannot_review",
-				Message: "Cannot request revision for this requirement",
-			})
-			return
+		items[i] = services.BulkReviewItem{
+			RequirementID: requirementID,
+			Decision:      services.ReviewDecision(a.Decision),
+			Reason:        a.Reason,
 		}
+	}
 
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to request revision",
+	results, err := h.reviewService.BulkReview(c.Request.Context(), companyID, userID, items)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	resp := BulkReviewResponse{Results: make([]BulkReviewItemResponse, len(results))}
+	for i, r := range results {
+		item := BulkReviewItemResponse{RequirementID: r.RequirementID.Hex()}
+		if r.Err != nil {
+			item.Error, item.Message = bulkReviewError(r.Err)
+			resp.Failed++
+		} else {
+			item.Success = true
+			item.Status = string(r.Requirement.Status)
+			resp.Succeeded++
+		}
+		resp.Results[i] = item
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// bulkReviewError maps a per-item review error to its catalog code and message
+func bulkReviewError(err error) (code, message string) {
+	entry, _ := apierrors.Lookup(err)
+	return string(entry.Code), entry.MessageFor(err)
+}
+
+// ExportSubmissions handles GET /api/v1/submissions/export
+// @Summary Export submissions
+// @Description Downloads the outcome of every supplier submission to the company's questionnaires as CSV or JSON,
+// @Description newest first, optionally limited to a submission date range
+// @Tags Review
+// @Produce text/csv
+// @Produce json
+// @Security BearerAuth
+// @Param format query string false "Export format (csv, json)" default(csv)
+// @Param from query string false "Only submissions at or after this time (RFC 3339)"
+// @Param to query string false "Only submissions at or before this time (RFC 3339)"
+// @Success 200 {array} services.SubmissionExportRow
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /submissions/export [get]
+func (h *ReviewHandler) ExportSubmissions(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, toRequirementResponse(requirement))
+	format := c.DefaultQuery("format", "csv")
+	writer, ok := newExportWriter(format, c.Writer, submissionExportColumns, submissionExportRecord)
+	if !ok {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_format",
+			Message: "Format must be csv or json",
+		})
+		return
+	}
+
+	from, to, ok := parseTimeRange(c)
+	if !ok {
+		return
+	}
+
+	filters := services.SubmissionExportFilters{From: from, To: to}
+	streamExport(c, writer, "submissions", format, "Failed to export submissions", func(fn func([]services.SubmissionExportRow) error) error {
+		return h.reviewService.ExportCompanySubmissions(c.Request.Context(), companyID, filters, fn)
+	})
 }
 
 // RegisterRoutes registers review handler routes
 // #INTEGRATION_POINT: Routes require authentication and company organization type
+// #SECURITY_ASSUMPTION: Mutating routes additionally require the ADMIN role
 func (h *ReviewHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
 	// Review routes are under requirements
 	requirements := rg.Group("/requirements")
 	requirements.Use(authMiddleware)
 	requirements.Use(middleware.RequireCompany())
 	requirements.GET("/:id/review", h.GetSubmissionForReview)
-	requirements.POST("/:id/approve", h.ApproveRequirement)
-	requirements.POST("/:id/reject", h.RejectRequirement)
-	requirements.POST("/:id/request-revision", h.RequestRevision)
+	requirements.GET("/:id/submission", h.GetSubmissionDetail)
+	requirements.POST("/:id/approve", middleware.RequireAdmin(), h.ApproveRequirement)
+	requirements.POST("/:id/reject", middleware.RequireAdmin(), h.RejectRequirement)
+	requirements.POST("/:id/request-revision", middleware.RequireAdmin(), h.RequestRevision)
+
+	reviews := rg.Group("/reviews")
+	reviews.Use(authMiddleware)
+	reviews.Use(middleware.RequireCompany())
+	reviews.POST("/bulk", middleware.RequireAdmin(), h.BulkReview)
+
+	// #SECURITY_ASSUMPTION: The export spans every supplier of the company, so it is admin-only
+	submissions := rg.Group("/submissions")
+	submissions.Use(authMiddleware)
+	submissions.Use(middleware.RequireCompany())
+	submissions.GET("/export", middleware.RequireAdmin(), h.ExportSubmissions)
 }
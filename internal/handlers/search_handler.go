@@ -0,0 +1,116 @@
+// Package handlers provides HTTP handlers for API endpoints.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// SearchHandler handles the company-wide search endpoint
+// #INTEGRATION_POINT: Company portal global search box
+type SearchHandler struct {
+	searchService services.SearchService
+	orgRepo       repository.OrganizationRepository
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(searchService services.SearchService, orgRepo repository.OrganizationRepository) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+		orgRepo:       orgRepo,
+	}
+}
+
+// SearchResponse holds the best matches of each category
+type SearchResponse struct {
+	Suppliers      []RelationshipResponse  `json:"suppliers"`
+	Requirements   []RequirementResponse   `json:"requirements"`
+	Questionnaires []QuestionnaireResponse `json:"questionnaires"`
+}
+
+// Search handles GET /api/v1/search
+// @Summary Search company data
+// @Description Searches the company's suppliers by name or email, requirements by title and questionnaires by name. Each category returns at most 10 matches, best first.
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Search text (2-100 characters)"
+// @Success 200 {object} SearchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	companyID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	results, err := h.searchService.Search(ctx, companyID, c.Query("q"))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSearchQuery) {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_query",
+				Message: fmt.Sprintf("q must be between %d and %d characters", services.MinSearchQueryLength, services.MaxSearchQueryLength),
+			})
+			return
+		}
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to search",
+		})
+		return
+	}
+
+	resolver := newOrgNameResolver()
+	for i := range results.Suppliers {
+		resolver.add(results.Suppliers[i].SupplierID)
+	}
+	for i := range results.Requirements {
+		resolver.add(&results.Requirements[i].SupplierID)
+	}
+	names := resolver.resolve(ctx, h.orgRepo)
+
+	resp := SearchResponse{
+		Suppliers:      make([]RelationshipResponse, len(results.Suppliers)),
+		Requirements:   make([]RequirementResponse, len(results.Requirements)),
+		Questionnaires: make([]QuestionnaireResponse, len(results.Questionnaires)),
+	}
+	for i := range results.Suppliers {
+		resp.Suppliers[i] = toRelationshipResponse(&results.Suppliers[i])
+		if id := results.Suppliers[i].SupplierID; id != nil {
+			resp.Suppliers[i].SupplierName = names[*id]
+		}
+	}
+	for i := range results.Requirements {
+		resp.Requirements[i] = toRequirementResponse(&results.Requirements[i])
+		resp.Requirements[i].SupplierName = names[results.Requirements[i].SupplierID]
+	}
+	for i := range results.Questionnaires {
+		resp.Questionnaires[i] = toQuestionnaireResponse(&results.Questionnaires[i])
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RegisterRoutes registers search handler routes
+// #INTEGRATION_POINT: Routes require authentication and company organization type
+func (h *SearchHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	search := rg.Group("/search")
+	search.Use(authMiddleware)
+	search.Use(middleware.RequireCompany())
+	search.GET("", h.Search)
+}
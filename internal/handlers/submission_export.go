@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// submissionExportColumns is the CSV header row of a submission export
+var submissionExportColumns = []string{
+	"supplier_name", "questionnaire", "requirement_title", "score", "max_score",
+	"percentage_score", "passed", "submitted_at",
+}
+
+// submissionExportRecord converts a submission export row to a CSV record
+func submissionExportRecord(row *services.SubmissionExportRow) []string {
+	return []string{
+		csvSafe(row.SupplierName),
+		csvSafe(row.Questionnaire),
+		csvSafe(row.RequirementTitle),
+		strconv.Itoa(row.Score),
+		strconv.Itoa(row.MaxScore),
+		strconv.FormatFloat(row.PercentageScore, 'f', 2, 64),
+		strconv.FormatBool(row.Passed),
+		row.SubmittedAt.UTC().Format(time.RFC3339),
+	}
+}
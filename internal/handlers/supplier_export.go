@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// supplierExportColumns is the CSV header row of a supplier export
+var supplierExportColumns = []string{
+	"supplier_name", "email", "classification", "status", "contract_ref",
+	"invited_at", "accepted_at", "pending_requirements",
+}
+
+// supplierExportRecord converts a supplier export row to a CSV record
+func supplierExportRecord(row *services.SupplierExportRow) []string {
+	acceptedAt := ""
+	if row.AcceptedAt != nil {
+		acceptedAt = row.AcceptedAt.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		csvSafe(row.SupplierName),
+		csvSafe(row.Email),
+		string(row.Classification),
+		string(row.Status),
+		csvSafe(row.ContractRef),
+		row.InvitedAt.UTC().Format(time.RFC3339),
+		acceptedAt,
+		strconv.FormatInt(row.PendingRequirements, 10),
+	}
+}
@@ -2,9 +2,12 @@
 package handlers
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -19,21 +22,30 @@ import (
 // SupplierPortalHandler handles supplier-side endpoints
 // #INTEGRATION_POINT: Supplier portal uses these endpoints for viewing and responding to requirements
 type SupplierPortalHandler struct {
-	relationshipRepo repository.RelationshipRepository
-	requirementRepo  repository.RequirementRepository
-	responseService  services.ResponseService
+	relationshipRepo   repository.RelationshipRepository
+	requirementRepo    repository.RequirementRepository
+	orgRepo            repository.OrganizationRepository
+	userRepo           repository.UserRepository
+	responseService    services.ResponseService
+	requirementService services.RequirementService
 }
 
 // NewSupplierPortalHandler creates a new supplier portal handler
 func NewSupplierPortalHandler(
 	relationshipRepo repository.RelationshipRepository,
 	requirementRepo repository.RequirementRepository,
+	orgRepo repository.OrganizationRepository,
+	userRepo repository.UserRepository,
 	responseService services.ResponseService,
+	requirementService services.RequirementService,
 ) *SupplierPortalHandler {
 	return &SupplierPortalHandler{
-		relationshipRepo: relationshipRepo,
-		requirementRepo:  requirementRepo,
-		responseService:  responseService,
+		relationshipRepo:   relationshipRepo,
+		requirementRepo:    requirementRepo,
+		orgRepo:            orgRepo,
+		userRepo:           userRepo,
+		responseService:    responseService,
+		requirementService: requirementService,
 	}
 }
 
@@ -49,6 +61,41 @@ type CompanyRelationshipResponse struct {
 	PendingRequirements int        `json:"pending_requirements"`
 }
 
+// SupplierCompanyDetailResponse is one company relationship with its requirements, from the supplier's view
+type SupplierCompanyDetailResponse struct {
+	CompanyRelationshipResponse
+	RequirementGroups []RequirementStatusGroup `json:"requirement_groups"`
+}
+
+// RequirementStatusGroup lists a company's requirements in one status
+type RequirementStatusGroup struct {
+	Status       string               `json:"status"`
+	Count        int                  `json:"count"`
+	Requirements []RequirementSummary `json:"requirements"`
+}
+
+// RequirementSummary is a condensed requirement for overview lists
+type RequirementSummary struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Type      string     `json:"type"`
+	Priority  string     `json:"priority"`
+	DueDate   *time.Time `json:"due_date,omitempty"`
+	IsOverdue bool       `json:"is_overdue"`
+}
+
+// requirementStatusOrder is the workflow order of requirement status groups; cancelled
+// requirements are not shown to suppliers
+var requirementStatusOrder = []models.RequirementStatus{
+	models.RequirementStatusPending,
+	models.RequirementStatusInProgress,
+	models.RequirementStatusSubmitted,
+	models.RequirementStatusUnderReview,
+	models.RequirementStatusRejected,
+	models.RequirementStatusApproved,
+	models.RequirementStatusExpired,
+}
+
 // SupplierRequirementResponse represents a requirement from supplier's view
 type SupplierRequirementResponse struct {
 	ID              string     `json:"id"`
@@ -68,6 +115,25 @@ type SupplierRequirementResponse struct {
 	DaysUntilDue    int        `json:"days_until_due"`
 	AssignedAt      time.Time  `json:"assigned_at"`
 	CreatedAt       time.Time  `json:"created_at"`
+
+	// AssignedUser is the supplier team member owning the requirement
+	AssignedUser *SupplierAssigneeResponse `json:"assigned_user,omitempty"`
+
+	// Attachments are downloaded via /requirements/{id}/attachments/{fileID}/download
+	Attachments []AttachmentResponse `json:"attachments,omitempty"`
+}
+
+// SupplierAssigneeResponse is the supplier team member a requirement is assigned to
+type SupplierAssigneeResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// AssignRequirementRequest assigns a requirement to a supplier team member
+type AssignRequirementRequest struct {
+	// UserID is the team member to assign; null or empty clears the assignment
+	UserID *string `json:"user_id"`
 }
 
 // SupplierResponseResponse represents a response in API responses
@@ -80,6 +146,7 @@ type SupplierResponseResponse struct {
 	Passed           *bool                 `json:"passed,omitempty"`
 	Grade            *string               `json:"grade,omitempty"`
 	DraftAnswerCount int                   `json:"draft_answer_count"`
+	Version          int                   `json:"version"`
 	IsSubmitted      bool                  `json:"is_submitted"`
 	StartedAt        time.Time             `json:"started_at"`
 	SubmittedAt      *time.Time            `json:"submitted_at,omitempty"`
@@ -91,6 +158,7 @@ type DraftAnswerResponse struct {
 	QuestionID      string    `json:"question_id"`
 	SelectedOptions []string  `json:"selected_options,omitempty"`
 	TextAnswer      string    `json:"text_answer,omitempty"`
+	Note            string    `json:"note,omitempty"`
 	SavedAt         time.Time `json:"saved_at"`
 }
 
@@ -127,7 +195,7 @@ type PaginatedSupplierRequirementsResponse struct {
 func (h *SupplierPortalHandler) GetSupplierDashboard(c *gin.Context) {
 	supplierID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -160,20 +228,12 @@ func (h *SupplierPortalHandler) GetSupplierDashboard(c *gin.Context) {
 
 	// Get recent requirements
 	opts := repository.PaginationOptions{Page: 1, Limit: 5, SortBy: "created_at", SortDir: -1}
-	result, _ := h.requirementRepo.ListBySupplier(ctx, supplierID, nil, opts) //nolint:errcheck // best-effort
+	result, _ := h.requirementRepo.ListBySupplier(ctx, supplierID, nil, nil, opts) //nolint:errcheck // best-effort
 
-	recentReqs := make([]SupplierRequirementResponse, len(result.Items))
-	for i, r := range result.Items {
-		recentReqs[i] = toSupplierRequirementResponse(&r)
-	}
+	recentReqs := h.toSupplierRequirementResponses(ctx, result.Items)
 
-	// Count overdue (approximation)
-	overdue := int64(0)
-	for _, r := range result.Items {
-		if r.IsOverdue() {
-			overdue++
-		}
-	}
+	// Count overdue requirements
+	overdue, _ := h.requirementRepo.CountOverdueBySupplier(ctx, supplierID) //nolint:errcheck // best-effort
 
 	c.JSON(http.StatusOK, SupplierDashboardResponse{
 		TotalCompanies:        totalCompanies,
@@ -200,7 +260,7 @@ func (h *SupplierPortalHandler) GetSupplierDashboard(c *gin.Context) {
 func (h *SupplierPortalHandler) ListCompanies(c *gin.Context) {
 	supplierID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -216,26 +276,108 @@ func (h *SupplierPortalHandler) ListCompanies(c *gin.Context) {
 	opts := repository.PaginationOptions{Page: 1, Limit: 100, SortBy: "created_at", SortDir: -1}
 	result, err := h.relationshipRepo.ListBySupplier(c.Request.Context(), supplierID, status, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to list companies",
 		})
 		return
 	}
 
-	items := make([]CompanyRelationshipResponse, len(result.Items))
-	for i, r := range result.Items {
-		items[i] = CompanyRelationshipResponse{
-			ID:             r.ID.Hex(),
-			CompanyID:      r.CompanyID.Hex(),
-			Status:         string(r.Status),
-			Classification: string(r.Classification),
-			InvitedAt:      r.InvitedAt,
-			AcceptedAt:     r.AcceptedAt,
+	c.JSON(http.StatusOK, h.toCompanyRelationshipResponses(c.Request.Context(), result.Items))
+}
+
+// GetCompany handles GET /api/v1/supplier/companies/:relationshipID
+// @Summary Get company details
+// @Description Returns one company relationship with that company's requirements grouped by status
+// @Tags Supplier Portal
+// @Produce json
+// @Security BearerAuth
+// @Param relationshipID path string true "Relationship ID"
+// @Success 200 {object} SupplierCompanyDetailResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /supplier/companies/{relationshipID} [get]
+func (h *SupplierPortalHandler) GetCompany(c *gin.Context) {
+	supplierID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	relationshipID, err := primitive.ObjectIDFromHex(c.Param("relationshipID"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid relationship ID",
+		})
+		return
+	}
+
+	relationship, err := h.relationshipRepo.GetByID(c.Request.Context(), relationshipID)
+	if err != nil && !errors.Is(err, models.ErrRelationshipNotFound) {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to get company",
+		})
+		return
+	}
+	// #SECURITY_ASSUMPTION: Another supplier's relationship is reported as not found
+	if err != nil || relationship.SupplierID == nil || *relationship.SupplierID != supplierID {
+		writeError(c, http.StatusNotFound, ErrorResponse{
+			Error:   "not_found",
+			Message: "Company not found",
+		})
+		return
+	}
+
+	requirements, err := h.requirementRepo.ListByRelationship(c.Request.Context(), relationshipID, nil)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to list requirements",
+		})
+		return
+	}
+
+	byStatus := make(map[models.RequirementStatus][]RequirementSummary)
+	for i := range requirements {
+		r := &requirements[i]
+		if r.SupplierID != supplierID {
+			continue
+		}
+		byStatus[r.Status] = append(byStatus[r.Status], RequirementSummary{
+			ID:        r.ID.Hex(),
+			Title:     r.Title,
+			Type:      string(r.Type),
+			Priority:  string(r.Priority),
+			DueDate:   r.DueDate,
+			IsOverdue: r.IsOverdue(),
+		})
+	}
+
+	groups := make([]RequirementStatusGroup, 0, len(byStatus))
+	for _, status := range requirementStatusOrder {
+		if items := byStatus[status]; len(items) > 0 {
+			groups = append(groups, RequirementStatusGroup{
+				Status:       string(status),
+				Count:        len(items),
+				Requirements: items,
+			})
 		}
 	}
 
-	c.JSON(http.StatusOK, items)
+	detail := SupplierCompanyDetailResponse{
+		CompanyRelationshipResponse: h.toCompanyRelationshipResponses(c.Request.Context(), []models.CompanySupplierRelationship{*relationship})[0],
+		RequirementGroups:           groups,
+	}
+	detail.PendingRequirements = len(byStatus[models.RequirementStatusPending])
+
+	c.JSON(http.StatusOK, detail)
 }
 
 // ListPendingInvitations handles GET /api/v1/supplier/invitations
@@ -252,7 +394,7 @@ func (h *SupplierPortalHandler) ListCompanies(c *gin.Context) {
 func (h *SupplierPortalHandler) ListPendingInvitations(c *gin.Context) {
 	supplierID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -263,25 +405,14 @@ func (h *SupplierPortalHandler) ListPendingInvitations(c *gin.Context) {
 	opts := repository.PaginationOptions{Page: 1, Limit: 100, SortBy: "created_at", SortDir: -1}
 	result, err := h.relationshipRepo.ListBySupplier(c.Request.Context(), supplierID, &pendingStatus, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to list invitations",
 		})
 		return
 	}
 
-	items := make([]CompanyRelationshipResponse, len(result.Items))
-	for i, r := range result.Items {
-		items[i] = CompanyRelationshipResponse{
-			ID:             r.ID.Hex(),
-			CompanyID:      r.CompanyID.Hex(),
-			Status:         string(r.Status),
-			Classification: string(r.Classification),
-			InvitedAt:      r.InvitedAt,
-		}
-	}
-
-	c.JSON(http.StatusOK, items)
+	c.JSON(http.StatusOK, h.toCompanyRelationshipResponses(c.Request.Context(), result.Items))
 }
 
 // AcceptInvitation handles POST /api/v1/supplier/invitations/:id/accept
@@ -300,7 +431,7 @@ func (h *SupplierPortalHandler) ListPendingInvitations(c *gin.Context) {
 func (h *SupplierPortalHandler) AcceptInvitation(c *gin.Context) {
 	supplierID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -309,7 +440,7 @@ func (h *SupplierPortalHandler) AcceptInvitation(c *gin.Context) {
 
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -318,7 +449,7 @@ func (h *SupplierPortalHandler) AcceptInvitation(c *gin.Context) {
 
 	relationshipID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid relationship ID",
 		})
@@ -328,23 +459,13 @@ func (h *SupplierPortalHandler) AcceptInvitation(c *gin.Context) {
 	// Get relationship
 	relationship, err := h.relationshipRepo.GetByID(c.Request.Context(), relationshipID)
 	if err != nil {
-		if errors.Is(err, models.ErrRelationshipNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Invitation not found",
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get invitation",
-		})
+		respondError(c, err)
 		return
 	}
 
 	// Accept
 	if err := relationship.Accept(supplierID, userID); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_transition",
 			Message: "Cannot accept this invitation",
 		})
@@ -352,7 +473,7 @@ func (h *SupplierPortalHandler) AcceptInvitation(c *gin.Context) {
 	}
 
 	if err := h.relationshipRepo.Update(c.Request.Context(), relationship); err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to accept invitation",
 		})
@@ -391,7 +512,7 @@ type DeclineInvitationRequest struct {
 func (h *SupplierPortalHandler) DeclineInvitation(c *gin.Context) {
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -400,7 +521,7 @@ func (h *SupplierPortalHandler) DeclineInvitation(c *gin.Context) {
 
 	relationshipID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid relationship ID",
 		})
@@ -414,23 +535,13 @@ func (h *SupplierPortalHandler) DeclineInvitation(c *gin.Context) {
 	// Get relationship
 	relationship, err := h.relationshipRepo.GetByID(c.Request.Context(), relationshipID)
 	if err != nil {
-		if errors.Is(err, models.ErrRelationshipNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Invitation not found",
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get invitation",
-		})
+		respondError(c, err)
 		return
 	}
 
 	// Decline
 	if err := relationship.Decline(userID, req.Reason); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_transition",
 			Message: "Cannot decline this invitation",
 		})
@@ -438,7 +549,7 @@ func (h *SupplierPortalHandler) DeclineInvitation(c *gin.Context) {
 	}
 
 	if err := h.relationshipRepo.Update(c.Request.Context(), relationship); err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to decline invitation",
 		})
@@ -461,27 +572,49 @@ func (h *SupplierPortalHandler) DeclineInvitation(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param status query string false "Filter by status"
+// @Param status query string false "Filter by status; comma-separated values match any, unknown values are ignored with a Warning header"
+// @Param assigned_to query string false "Only requirements assigned to the caller" Enums(me)
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(20)
 // @Success 200 {object} PaginatedSupplierRequirementsResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Router /supplier/requirements [get]
 func (h *SupplierPortalHandler) ListRequirements(c *gin.Context) {
 	supplierID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
 		return
 	}
 
-	var status *models.RequirementStatus
-	if statusStr := c.Query("status"); statusStr != "" {
-		s := models.RequirementStatus(statusStr)
-		status = &s
+	statuses, ok := parseStatusFilter(c, models.RequirementStatus.IsValid)
+	if !ok {
+		return
+	}
+
+	var assigneeID *primitive.ObjectID
+	switch assignedTo := c.Query("assigned_to"); assignedTo {
+	case "":
+	case "me":
+		userID, ok := middleware.GetUserID(c)
+		if !ok {
+			writeError(c, http.StatusUnauthorized, ErrorResponse{
+				Error:   "unauthorized",
+				Message: "Invalid session",
+			})
+			return
+		}
+		assigneeID = &userID
+	default:
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_filter",
+			Message: "assigned_to must be 'me'",
+		})
+		return
 	}
 
 	opts := repository.DefaultPaginationOptions()
@@ -492,20 +625,18 @@ func (h *SupplierPortalHandler) ListRequirements(c *gin.Context) {
 		opts.Limit = limit
 	}
 
-	result, err := h.requirementRepo.ListBySupplier(c.Request.Context(), supplierID, status, opts)
+	result, err := h.requirementRepo.ListBySupplier(c.Request.Context(), supplierID, statuses, assigneeID, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to list requirements",
 		})
 		return
 	}
 
-	items := make([]SupplierRequirementResponse, len(result.Items))
-	for i, r := range result.Items {
-		items[i] = toSupplierRequirementResponse(&r)
-	}
+	items := h.toSupplierRequirementResponses(c.Request.Context(), result.Items)
 
+	setPaginationHeaders(c, result)
 	c.JSON(http.StatusOK, PaginatedSupplierRequirementsResponse{
 		Items:      items,
 		TotalCount: result.TotalCount,
@@ -515,6 +646,39 @@ func (h *SupplierPortalHandler) ListRequirements(c *gin.Context) {
 	})
 }
 
+// ListOverdueRequirements handles GET /api/v1/supplier/requirements/overdue
+// @Summary List overdue requirements
+// @Description Lists the supplier's pending and in-progress requirements past their due date across all companies, most overdue first
+// @Tags Supplier Portal
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} SupplierRequirementResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /supplier/requirements/overdue [get]
+func (h *SupplierPortalHandler) ListOverdueRequirements(c *gin.Context) {
+	supplierID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	requirements, err := h.requirementRepo.ListOverdueBySupplier(c.Request.Context(), supplierID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to list overdue requirements",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.toSupplierRequirementResponses(c.Request.Context(), requirements))
+}
+
 // GetRequirement handles GET /api/v1/supplier/requirements/:id
 // @Summary Get requirement details
 // @Description Gets details of a specific requirement
@@ -531,7 +695,7 @@ func (h *SupplierPortalHandler) ListRequirements(c *gin.Context) {
 func (h *SupplierPortalHandler) GetRequirement(c *gin.Context) {
 	supplierID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -540,7 +704,7 @@ func (h *SupplierPortalHandler) GetRequirement(c *gin.Context) {
 
 	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid requirement ID",
 		})
@@ -549,23 +713,13 @@ func (h *SupplierPortalHandler) GetRequirement(c *gin.Context) {
 
 	requirement, err := h.requirementRepo.GetByID(c.Request.Context(), requirementID)
 	if err != nil {
-		if errors.Is(err, models.ErrRequirementNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Requirement not found",
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get requirement",
-		})
+		respondError(c, err)
 		return
 	}
 
 	// Verify supplier ownership
 	if requirement.SupplierID != supplierID {
-		c.JSON(http.StatusNotFound, ErrorResponse{
+		writeError(c, http.StatusNotFound, ErrorResponse{
 			Error:   "not_found",
 			Message: "Requirement not found",
 		})
@@ -575,6 +729,80 @@ func (h *SupplierPortalHandler) GetRequirement(c *gin.Context) {
 	c.JSON(http.StatusOK, toSupplierRequirementResponse(requirement))
 }
 
+// AssignRequirement handles POST /api/v1/supplier/requirements/:id/assign
+// @Summary Assign requirement
+// @Description Assigns a requirement to a team member of the supplier organization, or clears the assignment when user_id is null or empty
+// @Tags Supplier Portal
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Requirement ID"
+// @Param request body AssignRequirementRequest true "Assignee"
+// @Success 200 {object} SupplierRequirementResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /supplier/requirements/{id}/assign [post]
+func (h *SupplierPortalHandler) AssignRequirement(c *gin.Context) {
+	supplierID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid requirement ID",
+		})
+		return
+	}
+
+	var req AssignRequirementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	var assigneeID *primitive.ObjectID
+	if req.UserID != nil && *req.UserID != "" {
+		id, err := primitive.ObjectIDFromHex(*req.UserID)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_id",
+				Message: "Invalid user ID",
+			})
+			return
+		}
+		assigneeID = &id
+	}
+
+	ctx := c.Request.Context()
+	requirement, err := h.requirementService.AssignSupplierUser(ctx, requirementID, supplierID, userID, assigneeID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.toSupplierRequirementResponses(ctx, []models.Requirement{*requirement})[0])
+}
+
 // StartResponse handles POST /api/v1/supplier/requirements/:id/start
 // @Summary Start response
 // @Description Starts a response for a requirement
@@ -588,11 +816,12 @@ func (h *SupplierPortalHandler) GetRequirement(c *gin.Context) {
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /supplier/requirements/{id}/start [post]
 func (h *SupplierPortalHandler) StartResponse(c *gin.Context) {
 	supplierID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -601,7 +830,7 @@ func (h *SupplierPortalHandler) StartResponse(c *gin.Context) {
 
 	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid requirement ID",
 		})
@@ -610,32 +839,7 @@ func (h *SupplierPortalHandler) StartResponse(c *gin.Context) {
 
 	response, err := h.responseService.StartResponse(c.Request.Context(), requirementID, supplierID)
 	if err != nil {
-		if errors.Is(err, services.ErrRequirementNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Requirement not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrCannotStartResponse) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "cannot_start",
-				Message: "Cannot start response for this requirement",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrResponseAlreadyExists) {
-			c.JSON(http.StatusConflict, ErrorResponse{
-				Error:   "response_exists",
-				Message: "Response already exists",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to start response",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -658,7 +862,7 @@ func (h *SupplierPortalHandler) StartResponse(c *gin.Context) {
 func (h *SupplierPortalHandler) GetResponse(c *gin.Context) {
 	supplierID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -667,7 +871,7 @@ func (h *SupplierPortalHandler) GetResponse(c *gin.Context) {
 
 	responseID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid response ID",
 		})
@@ -676,18 +880,7 @@ func (h *SupplierPortalHandler) GetResponse(c *gin.Context) {
 
 	response, err := h.responseService.GetResponse(c.Request.Context(), responseID, &supplierID)
 	if err != nil {
-		if errors.Is(err, services.ErrResponseNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Response not found",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get response",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -697,6 +890,14 @@ func (h *SupplierPortalHandler) GetResponse(c *gin.Context) {
 // SaveDraftRequest represents a save draft request
 type SaveDraftRequest struct {
 	Answers []SaveDraftAnswerAPIRequest `json:"answers" binding:"required"`
+	// Version is the response version the answers are based on; the If-Match header takes precedence
+	Version *int `json:"version,omitempty"`
+}
+
+// DraftSavedResponse confirms a draft save
+type DraftSavedResponse struct {
+	Message string `json:"message"`
+	Version int    `json:"version"`
 }
 
 // SaveDraftAnswerAPIRequest represents a draft answer in API requests
@@ -704,27 +905,32 @@ type SaveDraftAnswerAPIRequest struct {
 	QuestionID      string   `json:"question_id" binding:"required"`
 	SelectedOptions []string `json:"selected_options,omitempty"`
 	TextAnswer      string   `json:"text_answer,omitempty"`
+	Note            string   `json:"note,omitempty"`
 }
 
 // SaveDraft handles POST /api/v1/supplier/responses/:id/draft
 // @Summary Save draft answers
-// @Description Saves draft answers for a response
+// @Description Saves draft answers for a response. Pass the response version the answers are based on
+// @Description via If-Match or the version field; a 409 means another tab or user saved first, so the
+// @Description client should reload the response, reapply its unsaved answers and save with the new version.
 // @Tags Supplier Portal
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Response ID"
+// @Param If-Match header string false "Expected response version"
 // @Param request body SaveDraftRequest true "Draft answers"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} DraftSavedResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /supplier/responses/{id}/draft [post]
 func (h *SupplierPortalHandler) SaveDraft(c *gin.Context) {
 	supplierID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -733,7 +939,7 @@ func (h *SupplierPortalHandler) SaveDraft(c *gin.Context) {
 
 	responseID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid response ID",
 		})
@@ -742,13 +948,26 @@ func (h *SupplierPortalHandler) SaveDraft(c *gin.Context) {
 
 	var req SaveDraftRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Answers are required",
 		})
 		return
 	}
 
+	expectedVersion := req.Version
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		version, err := parseVersionTag(ifMatch)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_request",
+				Message: "If-Match must be a response version",
+			})
+			return
+		}
+		expectedVersion = &version
+	}
+
 	// Convert to service format
 	answers := make([]services.SaveDraftAnswerRequest, len(req.Answers))
 	for i, a := range req.Answers {
@@ -756,33 +975,268 @@ func (h *SupplierPortalHandler) SaveDraft(c *gin.Context) {
 			QuestionID:      a.QuestionID,
 			SelectedOptions: a.SelectedOptions,
 			TextAnswer:      a.TextAnswer,
+			Note:            a.Note,
 		}
 	}
 
-	if err := h.responseService.SaveMultipleDraftAnswers(c.Request.Context(), responseID, supplierID, answers); err != nil {
-		if errors.Is(err, services.ErrResponseNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Response not found",
-			})
-			return
+	version, err := h.responseService.SaveMultipleDraftAnswers(c.Request.Context(), responseID, supplierID, expectedVersion, answers)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+	c.JSON(http.StatusOK, DraftSavedResponse{
+		Message: "Draft saved successfully",
+		Version: version,
+	})
+}
+
+// QuestionnaireExportFormat identifies the offline answer file format
+const QuestionnaireExportFormat = "nisfix-questionnaire-answers/v1"
+
+// QuestionnaireExportResponse is a blank questionnaire for answering offline
+// #SECURITY_ASSUMPTION: Option points and correct flags are left out so the file does not reveal scoring
+type QuestionnaireExportResponse struct {
+	Format          string                   `json:"format"`
+	RequirementID   string                   `json:"requirement_id"`
+	QuestionnaireID string                   `json:"questionnaire_id"`
+	Name            string                   `json:"name"`
+	Description     string                   `json:"description,omitempty"`
+	Version         int                      `json:"version"`
+	ExportedAt      time.Time                `json:"exported_at"`
+	Topics          []TopicResponse          `json:"topics"`
+	Questions       []ExportQuestionResponse `json:"questions"`
+	// Answers holds one empty slot per question; fill them in and upload the file to the import endpoint
+	Answers []AnswerSlot `json:"answers"`
+}
+
+// ExportQuestionResponse is a question in an offline answer file
+type ExportQuestionResponse struct {
+	ID          string                 `json:"id"`
+	TopicID     string                 `json:"topic_id,omitempty"`
+	Text        string                 `json:"text"`
+	Description string                 `json:"description,omitempty"`
+	HelpText    string                 `json:"help_text,omitempty"`
+	Type        string                 `json:"type"`
+	Order       int                    `json:"order"`
+	RequireNote bool                   `json:"require_note"`
+	Options     []ExportOptionResponse `json:"options,omitempty"`
+}
+
+// ExportOptionResponse is an answer option in an offline answer file
+type ExportOptionResponse struct {
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+	Order int    `json:"order"`
+}
+
+// AnswerSlot is one answer of an offline answer file; empty fields are kept so the slot is easy to fill
+type AnswerSlot struct {
+	QuestionID      string   `json:"question_id" binding:"required"`
+	SelectedOptions []string `json:"selected_options"`
+	TextAnswer      string   `json:"text_answer"`
+	Note            string   `json:"note"`
+}
+
+// ImportAnswersRequest is a filled offline answer file; fields other than answers are ignored
+type ImportAnswersRequest struct {
+	Answers []AnswerSlot `json:"answers" binding:"required,dive"`
+}
+
+// AnswersImportedResponse confirms an answer file import
+type AnswersImportedResponse struct {
+	Message  string `json:"message"`
+	Version  int    `json:"version"`
+	Imported int    `json:"imported"`
+}
+
+// UnknownQuestionsErrorResponse lists imported question IDs that are not part of the questionnaire
+type UnknownQuestionsErrorResponse struct {
+	Error       string   `json:"error"`
+	Message     string   `json:"message"`
+	QuestionIDs []string `json:"question_ids"`
+	RequestID   string   `json:"request_id,omitempty"`
+}
+
+// ExportQuestionnaire handles GET /api/v1/supplier/requirements/:id/export
+// @Summary Export questionnaire for offline answering
+// @Description Returns the questionnaire of a questionnaire requirement with one empty answer slot per question.
+// @Description Fill in the answers and upload the file to POST /supplier/responses/{id}/import.
+// @Tags Supplier Portal
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Requirement ID"
+// @Success 200 {object} QuestionnaireExportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /supplier/requirements/{id}/export [get]
+func (h *SupplierPortalHandler) ExportQuestionnaire(c *gin.Context) {
+	supplierID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	requirementID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid requirement ID",
+		})
+		return
+	}
+
+	export, err := h.responseService.ExportQuestionnaire(c.Request.Context(), requirementID, supplierID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="questionnaire-%s.json"`, requirementID.Hex()))
+	c.JSON(http.StatusOK, toQuestionnaireExportResponse(export))
+}
+
+// ImportAnswers handles POST /api/v1/supplier/responses/:id/import
+// @Summary Import offline answers
+// @Description Saves the answers of a filled offline answer file as draft answers. Blank answer slots are
+// @Description skipped. The file is rejected as a whole with 400 unknown_questions when an answer names a
+// @Description question outside the questionnaire.
+// @Tags Supplier Portal
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Response ID"
+// @Param request body ImportAnswersRequest true "Filled answer file"
+// @Success 200 {object} AnswersImportedResponse
+// @Failure 400 {object} UnknownQuestionsErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /supplier/responses/{id}/import [post]
+func (h *SupplierPortalHandler) ImportAnswers(c *gin.Context) {
+	supplierID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	responseID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid response ID",
+		})
+		return
+	}
+
+	var req ImportAnswersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Answer file must contain answers with a question_id each",
+		})
+		return
+	}
+
+	answers := make([]services.SaveDraftAnswerRequest, len(req.Answers))
+	for i, a := range req.Answers {
+		answers[i] = services.SaveDraftAnswerRequest{
+			QuestionID:      a.QuestionID,
+			SelectedOptions: a.SelectedOptions,
+			TextAnswer:      a.TextAnswer,
+			Note:            a.Note,
 		}
-		if errors.Is(err, services.ErrResponseAlreadySubmitted) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "already_submitted",
-				Message: "Response has already been submitted",
+	}
+
+	result, err := h.responseService.ImportDraftAnswers(c.Request.Context(), responseID, supplierID, answers)
+	if err != nil {
+		var unknownQuestions *services.UnknownQuestionsError
+		if errors.As(err, &unknownQuestions) {
+			c.JSON(http.StatusBadRequest, UnknownQuestionsErrorResponse{
+				Error:       "unknown_questions",
+				Message:     "Answer file contains questions that are not part of this questionnaire",
+				QuestionIDs: unknownQuestions.QuestionIDs,
+				RequestID:   middleware.GetRequestID(c),
 			})
 			return
 		}
+		respondError(c, err)
+		return
+	}
 
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to save draft",
+	c.Header("ETag", strconv.Quote(strconv.Itoa(result.Version)))
+	c.JSON(http.StatusOK, AnswersImportedResponse{
+		Message:  "Answers imported as draft",
+		Version:  result.Version,
+		Imported: result.Imported,
+	})
+}
+
+// DraftAnswerDeletedResponse represents the result of clearing a draft answer
+type DraftAnswerDeletedResponse struct {
+	Message          string `json:"message"`
+	Version          int    `json:"version"`
+	DraftAnswerCount int    `json:"draft_answer_count"`
+}
+
+// DeleteDraftAnswer handles DELETE /api/v1/supplier/responses/:id/answers/:questionID
+// @Summary Clear a draft answer
+// @Description Removes the saved draft answer for a question, e.g. when a conditional question no longer applies.
+// @Description Clearing an answer that was never saved succeeds. The new version is returned in the body and ETag.
+// @Tags Supplier Portal
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Response ID"
+// @Param questionID path string true "Question ID"
+// @Success 200 {object} DraftAnswerDeletedResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /supplier/responses/{id}/answers/{questionID} [delete]
+func (h *SupplierPortalHandler) DeleteDraftAnswer(c *gin.Context) {
+	supplierID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Draft saved successfully"})
+	responseID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid response ID",
+		})
+		return
+	}
+
+	response, err := h.responseService.DeleteDraftAnswer(c.Request.Context(), responseID, supplierID, c.Param("questionID"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("ETag", strconv.Quote(strconv.Itoa(response.Version)))
+	c.JSON(http.StatusOK, DraftAnswerDeletedResponse{
+		Message:          "Draft answer cleared",
+		Version:          response.Version,
+		DraftAnswerCount: response.DraftAnswerCount(),
+	})
 }
 
 // SubmitResponseRequest represents a submit response request
@@ -795,11 +1249,22 @@ type SubmitAnswerAPIRequest struct {
 	QuestionID      string   `json:"question_id" binding:"required"`
 	SelectedOptions []string `json:"selected_options,omitempty"`
 	TextAnswer      string   `json:"text_answer,omitempty"`
+	// Note is the supplier's justification; required for questions with require_note
+	Note string `json:"note,omitempty"`
+}
+
+// MissingNotesErrorResponse lists the answered questions that still need a note
+type MissingNotesErrorResponse struct {
+	Error       string   `json:"error"`
+	Message     string   `json:"message"`
+	QuestionIDs []string `json:"question_ids"`
+	RequestID   string   `json:"request_id,omitempty"`
 }
 
 // SubmitResponse handles POST /api/v1/supplier/responses/:id/submit
 // @Summary Submit response
-// @Description Submits a questionnaire response
+// @Description Submits a questionnaire response. Answers to questions with require_note need a note;
+// @Description otherwise the 400 missing_notes error lists the affected question_ids.
 // @Tags Supplier Portal
 // @Accept json
 // @Produce json
@@ -811,11 +1276,12 @@ type SubmitAnswerAPIRequest struct {
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /supplier/responses/{id}/submit [post]
 func (h *SupplierPortalHandler) SubmitResponse(c *gin.Context) {
 	supplierID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -824,7 +1290,7 @@ func (h *SupplierPortalHandler) SubmitResponse(c *gin.Context) {
 
 	responseID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid response ID",
 		})
@@ -833,7 +1299,7 @@ func (h *SupplierPortalHandler) SubmitResponse(c *gin.Context) {
 
 	var req SubmitResponseRequest
 	if bindErr := c.ShouldBindJSON(&req); bindErr != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Answers are required",
 		})
@@ -847,30 +1313,23 @@ func (h *SupplierPortalHandler) SubmitResponse(c *gin.Context) {
 			QuestionID:      a.QuestionID,
 			SelectedOptions: a.SelectedOptions,
 			TextAnswer:      a.TextAnswer,
+			Note:            a.Note,
 		}
 	}
 
 	result, err := h.responseService.SubmitQuestionnaireResponse(c.Request.Context(), responseID, supplierID, answers)
 	if err != nil {
-		if errors.Is(err, services.ErrResponseNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Response not found",
-			})
-			return
-		}
-		if errors.Is(err, services.ErrResponseAlreadySubmitted) {
-			c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "already_submitted",
-				Message: "Response has already been submitted",
+		var missingNotes *services.MissingNotesError
+		if errors.As(err, &missingNotes) {
+			c.JSON(http.StatusBadRequest, MissingNotesErrorResponse{
+				Error:       "missing_notes",
+				Message:     "Some answers require a justification note",
+				QuestionIDs: missingNotes.QuestionIDs,
+				RequestID:   middleware.GetRequestID(c),
 			})
 			return
 		}
-
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to submit response",
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -880,6 +1339,7 @@ func (h *SupplierPortalHandler) SubmitResponse(c *gin.Context) {
 		Score:        result.Score,
 		MaxScore:     result.MaxScore,
 		Percentage:   result.Percentage,
+		ScoringScale: result.ScoringScale,
 	})
 }
 
@@ -890,6 +1350,8 @@ type SubmissionResultResponse struct {
 	Score        int     `json:"score"`
 	MaxScore     int     `json:"max_score"`
 	Percentage   float64 `json:"percentage"`
+	// ScoringScale is the company's scale the answers were scored on
+	ScoringScale models.ScoringScale `json:"scoring_scale"`
 }
 
 // RegisterRoutes registers supplier portal handler routes
@@ -898,12 +1360,14 @@ func (h *SupplierPortalHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddlewa
 	supplier := rg.Group("/supplier")
 	supplier.Use(authMiddleware)
 	supplier.Use(middleware.RequireSupplier())
+	supplier.Use(middleware.RequireWriteAccess())
 
 	// Dashboard
 	supplier.GET("/dashboard", h.GetSupplierDashboard)
 
 	// Companies
 	supplier.GET("/companies", h.ListCompanies)
+	supplier.GET("/companies/:relationshipID", h.GetCompany)
 
 	// Invitations
 	supplier.GET("/invitations", h.ListPendingInvitations)
@@ -912,15 +1376,89 @@ func (h *SupplierPortalHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddlewa
 
 	// Requirements
 	supplier.GET("/requirements", h.ListRequirements)
+	supplier.GET("/requirements/overdue", h.ListOverdueRequirements)
 	supplier.GET("/requirements/:id", h.GetRequirement)
+	supplier.POST("/requirements/:id/assign", h.AssignRequirement)
 	supplier.POST("/requirements/:id/start", h.StartResponse)
+	supplier.GET("/requirements/:id/export", h.ExportQuestionnaire)
 
 	// Responses
 	supplier.GET("/responses/:id", h.GetResponse)
 	supplier.POST("/responses/:id/draft", h.SaveDraft)
+	supplier.POST("/responses/:id/import", h.ImportAnswers)
+	supplier.DELETE("/responses/:id/answers/:questionID", h.DeleteDraftAnswer)
 	supplier.POST("/responses/:id/submit", h.SubmitResponse)
 }
 
+// toCompanyRelationshipResponses converts a page of relationships, resolving company names in one lookup
+func (h *SupplierPortalHandler) toCompanyRelationshipResponses(ctx context.Context, relationships []models.CompanySupplierRelationship) []CompanyRelationshipResponse {
+	resolver := newOrgNameResolver()
+	for i := range relationships {
+		resolver.add(&relationships[i].CompanyID)
+	}
+	names := resolver.resolve(ctx, h.orgRepo)
+
+	items := make([]CompanyRelationshipResponse, len(relationships))
+	for i, r := range relationships {
+		items[i] = CompanyRelationshipResponse{
+			ID:             r.ID.Hex(),
+			CompanyID:      r.CompanyID.Hex(),
+			CompanyName:    names[r.CompanyID],
+			Status:         string(r.Status),
+			Classification: string(r.Classification),
+			InvitedAt:      r.InvitedAt,
+			AcceptedAt:     r.AcceptedAt,
+		}
+	}
+	return items
+}
+
+// toSupplierRequirementResponses converts a page of requirements, resolving company names and
+// assignees in one lookup each
+func (h *SupplierPortalHandler) toSupplierRequirementResponses(ctx context.Context, requirements []models.Requirement) []SupplierRequirementResponse {
+	resolver := newOrgNameResolver()
+	for i := range requirements {
+		resolver.add(&requirements[i].CompanyID)
+	}
+	names := resolver.resolve(ctx, h.orgRepo)
+	assignees := h.resolveAssignees(ctx, requirements)
+
+	items := make([]SupplierRequirementResponse, len(requirements))
+	for i := range requirements {
+		items[i] = toSupplierRequirementResponse(&requirements[i])
+		items[i].CompanyName = names[requirements[i].CompanyID]
+		if items[i].AssignedUser != nil {
+			if user, ok := assignees[*requirements[i].AssignedUserID]; ok {
+				items[i].AssignedUser.Name = user.Name
+				items[i].AssignedUser.Email = user.Email
+			}
+		}
+	}
+	return items
+}
+
+// resolveAssignees looks up the assigned team members of a page of requirements
+// #IMPLEMENTATION_DECISION: Best-effort like company names - a failed lookup leaves only the assignee ID
+func (h *SupplierPortalHandler) resolveAssignees(ctx context.Context, requirements []models.Requirement) map[primitive.ObjectID]*models.User {
+	var ids []primitive.ObjectID
+	seen := make(map[primitive.ObjectID]bool)
+	for i := range requirements {
+		if id := requirements[i].AssignedUserID; id != nil && !seen[*id] {
+			seen[*id] = true
+			ids = append(ids, *id)
+		}
+	}
+	if len(ids) == 0 || h.userRepo == nil {
+		return nil
+	}
+
+	users, err := h.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil
+	}
+	return users
+}
+
 // toSupplierRequirementResponse converts a requirement to supplier response format
 func toSupplierRequirementResponse(r *models.Requirement) SupplierRequirementResponse {
 	resp := SupplierRequirementResponse{
@@ -938,12 +1476,60 @@ func toSupplierRequirementResponse(r *models.Requirement) SupplierRequirementRes
 		DaysUntilDue: r.DaysUntilDue(),
 		AssignedAt:   r.AssignedAt,
 		CreatedAt:    r.CreatedAt,
+		Attachments:  toAttachmentResponses(r.Attachments),
 	}
 
 	if r.QuestionnaireID != nil {
 		qID := r.QuestionnaireID.Hex()
 		resp.QuestionnaireID = &qID
 	}
+	if r.AssignedUserID != nil {
+		resp.AssignedUser = &SupplierAssigneeResponse{ID: r.AssignedUserID.Hex()}
+	}
+
+	return resp
+}
+
+// parseVersionTag parses a version from an If-Match value such as 3, "3" or W/"3"
+func parseVersionTag(tag string) (int, error) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+	return strconv.Atoi(strings.Trim(tag, `"`))
+}
+
+// toQuestionnaireExportResponse builds an offline answer file with one empty slot per question
+func toQuestionnaireExportResponse(e *services.QuestionnaireExport) QuestionnaireExportResponse {
+	q := e.Questionnaire
+	resp := QuestionnaireExportResponse{
+		Format:          QuestionnaireExportFormat,
+		RequirementID:   e.Requirement.ID.Hex(),
+		QuestionnaireID: q.ID.Hex(),
+		Name:            q.Name,
+		Description:     q.Description,
+		Version:         q.Version,
+		ExportedAt:      time.Now().UTC(),
+		Topics:          toQuestionnaireResponse(q).Topics,
+		Questions:       make([]ExportQuestionResponse, len(e.Questions)),
+		Answers:         make([]AnswerSlot, len(e.Questions)),
+	}
+
+	for i := range e.Questions {
+		question := &e.Questions[i]
+		exported := ExportQuestionResponse{
+			ID:          question.ID.Hex(),
+			TopicID:     question.TopicID,
+			Text:        question.Text,
+			Description: question.Description,
+			HelpText:    question.HelpText,
+			Type:        string(question.Type),
+			Order:       question.Order,
+			RequireNote: question.RequireNote,
+		}
+		for _, o := range question.Options {
+			exported.Options = append(exported.Options, ExportOptionResponse{ID: o.ID, Text: o.Text, Order: o.Order})
+		}
+		resp.Questions[i] = exported
+		resp.Answers[i] = AnswerSlot{QuestionID: exported.ID, SelectedOptions: []string{}}
+	}
 
 	return resp
 }
@@ -959,6 +1545,7 @@ func toSupplierResponseResponse(r *models.SupplierResponse) SupplierResponseResp
 		Passed:           r.Passed,
 		Grade:            r.Grade,
 		DraftAnswerCount: r.DraftAnswerCount(),
+		Version:          r.Version,
 		IsSubmitted:      r.IsSubmitted(),
 		StartedAt:        r.StartedAt,
 		SubmittedAt:      r.SubmittedAt,
@@ -971,6 +1558,7 @@ func toSupplierResponseResponse(r *models.SupplierResponse) SupplierResponseResp
 			QuestionID:      a.QuestionID.Hex(),
 			SelectedOptions: a.SelectedOptions,
 			TextAnswer:      a.TextAnswer,
+			Note:            a.Note,
 			SavedAt:         a.SavedAt,
 		}
 	}
@@ -0,0 +1,429 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// stubDraftResponseService emulates versioned draft saves; other methods are not used
+type stubDraftResponseService struct {
+	services.ResponseService
+	version int
+}
+
+func (s *stubDraftResponseService) SaveMultipleDraftAnswers(_ context.Context, _, _ primitive.ObjectID, expectedVersion *int, _ []services.SaveDraftAnswerRequest) (int, error) {
+	if expectedVersion != nil && *expectedVersion != s.version {
+		return 0, services.ErrResponseConflict
+	}
+	s.version++
+	return s.version, nil
+}
+
+func TestSupplierPortalHandler_SaveDraft_VersionConflict(t *testing.T) {
+	service := &stubDraftResponseService{version: 3}
+	handler := NewSupplierPortalHandler(nil, nil, nil, nil, service, nil)
+
+	router := gin.New()
+	router.POST("/responses/:id/draft", func(c *gin.Context) {
+		c.Set(middleware.ContextKeyOrgID, primitive.NewObjectID().Hex())
+		handler.SaveDraft(c)
+	})
+
+	body := `{"answers":[{"question_id":"` + primitive.NewObjectID().Hex() + `","text_answer":"yes"}]}`
+	path := "/responses/" + primitive.NewObjectID().Hex() + "/draft"
+
+	tests := []struct {
+		name       string
+		ifMatch    string
+		wantStatus int
+		wantETag   string
+	}{
+		{"current version", `"3"`, http.StatusOK, `"4"`},
+		{"stale version", `"3"`, http.StatusConflict, ""},
+		{"no version", "", http.StatusOK, `"5"`},
+		{"malformed version", "abc", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", path, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("Expected ETag %q, got %q", tt.wantETag, got)
+			}
+		})
+	}
+}
+
+// stubPortalRelationshipRepo serves a single relationship; other methods are not used
+type stubPortalRelationshipRepo struct {
+	repository.RelationshipRepository
+	relationship models.CompanySupplierRelationship
+}
+
+func (r *stubPortalRelationshipRepo) GetByID(_ context.Context, id primitive.ObjectID) (*models.CompanySupplierRelationship, error) {
+	if id != r.relationship.ID {
+		return nil, models.ErrRelationshipNotFound
+	}
+	relationship := r.relationship
+	return &relationship, nil
+}
+
+// stubPortalRequirementRepo serves fixed requirements; other methods are not used
+type stubPortalRequirementRepo struct {
+	repository.RequirementRepository
+	requirements []models.Requirement
+}
+
+func (r *stubPortalRequirementRepo) ListByRelationship(_ context.Context, _ primitive.ObjectID, _ []models.RequirementStatus) ([]models.Requirement, error) {
+	return r.requirements, nil
+}
+
+func (r *stubPortalRequirementRepo) ListBySupplier(_ context.Context, _ primitive.ObjectID, _ []models.RequirementStatus, _ *primitive.ObjectID, opts repository.PaginationOptions) (*repository.PaginatedResult[models.Requirement], error) {
+	items := r.requirements
+	if len(items) > opts.Limit {
+		items = items[:opts.Limit]
+	}
+	return &repository.PaginatedResult[models.Requirement]{Items: items, TotalCount: int64(len(r.requirements)), Page: 1, Limit: opts.Limit}, nil
+}
+
+func (r *stubPortalRequirementRepo) CountBySupplier(_ context.Context, _ primitive.ObjectID, status *models.RequirementStatus) (int64, error) {
+	var count int64
+	for _, req := range r.requirements {
+		if status == nil || req.Status == *status {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *stubPortalRequirementRepo) CountOverdueBySupplier(_ context.Context, _ primitive.ObjectID) (int64, error) {
+	var count int64
+	for _, req := range r.requirements {
+		if req.IsOverdue() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// stubPortalOrgRepo resolves organization names; other methods are not used
+type stubPortalOrgRepo struct {
+	repository.OrganizationRepository
+	orgs map[primitive.ObjectID]*models.Organization
+}
+
+func (r *stubPortalOrgRepo) GetByIDs(_ context.Context, _ []primitive.ObjectID) (map[primitive.ObjectID]*models.Organization, error) {
+	return r.orgs, nil
+}
+
+func TestSupplierPortalHandler_GetCompany(t *testing.T) {
+	companyID, supplierID := primitive.NewObjectID(), primitive.NewObjectID()
+	relationship := models.CompanySupplierRelationship{
+		ID:         primitive.NewObjectID(),
+		CompanyID:  companyID,
+		SupplierID: &supplierID,
+		Status:     models.RelationshipStatusActive,
+	}
+	requirement := func(status models.RequirementStatus) models.Requirement {
+		return models.Requirement{ID: primitive.NewObjectID(), CompanyID: companyID, SupplierID: supplierID, Status: status}
+	}
+
+	handler := NewSupplierPortalHandler(
+		&stubPortalRelationshipRepo{relationship: relationship},
+		&stubPortalRequirementRepo{requirements: []models.Requirement{
+			requirement(models.RequirementStatusApproved),
+			requirement(models.RequirementStatusPending),
+			requirement(models.RequirementStatusPending),
+		}},
+		&stubPortalOrgRepo{orgs: map[primitive.ObjectID]*models.Organization{companyID: {Name: "Bank AG"}}},
+		nil,
+		nil,
+		nil,
+	)
+
+	tests := []struct {
+		name       string
+		callerID   primitive.ObjectID
+		wantStatus int
+	}{
+		{"assigned supplier", supplierID, http.StatusOK},
+		{"other supplier", primitive.NewObjectID(), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/supplier/companies/:relationshipID", func(c *gin.Context) {
+				c.Set(middleware.ContextKeyOrgID, tt.callerID.Hex())
+				handler.GetCompany(c)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/supplier/companies/"+relationship.ID.Hex(), nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var detail SupplierCompanyDetailResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &detail); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if detail.CompanyName != "Bank AG" || detail.PendingRequirements != 2 {
+				t.Errorf("Unexpected company summary %+v", detail.CompanyRelationshipResponse)
+			}
+			if len(detail.RequirementGroups) != 2 || detail.RequirementGroups[0].Status != "PENDING" || detail.RequirementGroups[0].Count != 2 {
+				t.Errorf("Expected PENDING then APPROVED groups, got %+v", detail.RequirementGroups)
+			}
+		})
+	}
+}
+
+// stubDashboardRelationshipRepo counts no relationships; other methods are not used
+type stubDashboardRelationshipRepo struct {
+	repository.RelationshipRepository
+}
+
+func (r *stubDashboardRelationshipRepo) CountBySupplier(_ context.Context, _ primitive.ObjectID, _ *models.RelationshipStatus) (int64, error) {
+	return 0, nil
+}
+
+func TestSupplierPortalHandler_GetSupplierDashboard_OverdueCount(t *testing.T) {
+	supplierID := primitive.NewObjectID()
+	past := time.Now().UTC().AddDate(0, 0, -3)
+	future := time.Now().UTC().AddDate(0, 0, 3)
+
+	// The five most recent requirements are not overdue; the three older ones are
+	var requirements []models.Requirement
+	for i := 0; i < 8; i++ {
+		due := future
+		if i >= 5 {
+			due = past
+		}
+		requirements = append(requirements, models.Requirement{
+			ID:         primitive.NewObjectID(),
+			CompanyID:  primitive.NewObjectID(),
+			SupplierID: supplierID,
+			Status:     models.RequirementStatusPending,
+			DueDate:    &due,
+		})
+	}
+
+	handler := NewSupplierPortalHandler(
+		&stubDashboardRelationshipRepo{},
+		&stubPortalRequirementRepo{requirements: requirements},
+		&stubPortalOrgRepo{},
+		nil,
+		nil,
+		nil,
+	)
+
+	router := gin.New()
+	router.GET("/supplier/dashboard", func(c *gin.Context) {
+		c.Set(middleware.ContextKeyOrgID, supplierID.Hex())
+		handler.GetSupplierDashboard(c)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/supplier/dashboard", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var dashboard SupplierDashboardResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &dashboard); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(dashboard.RecentRequirements) != 5 {
+		t.Errorf("Expected 5 recent requirements, got %d", len(dashboard.RecentRequirements))
+	}
+	if dashboard.OverdueRequirements != 3 {
+		t.Errorf("Expected 3 overdue requirements, got %d", dashboard.OverdueRequirements)
+	}
+	if dashboard.PendingRequirements != 8 {
+		t.Errorf("Expected 8 pending requirements, got %d", dashboard.PendingRequirements)
+	}
+}
+
+// stubApprovedRequirementRepo serves a single requirement; other methods are not used
+type stubApprovedRequirementRepo struct {
+	repository.RequirementRepository
+	requirement *models.Requirement
+}
+
+func (r *stubApprovedRequirementRepo) GetByID(_ context.Context, _ primitive.ObjectID) (*models.Requirement, error) {
+	return r.requirement, nil
+}
+
+// stubApprovedResponseRepo serves a single response; other methods are not used
+type stubApprovedResponseRepo struct {
+	repository.ResponseRepository
+	response *models.SupplierResponse
+}
+
+func (r *stubApprovedResponseRepo) GetByID(_ context.Context, _ primitive.ObjectID) (*models.SupplierResponse, error) {
+	return r.response, nil
+}
+
+func TestSupplierPortalHandler_ApprovedRequirementIsImmutable(t *testing.T) {
+	supplierID := primitive.NewObjectID()
+	requirement := &models.Requirement{
+		ID:         primitive.NewObjectID(),
+		CompanyID:  primitive.NewObjectID(),
+		SupplierID: supplierID,
+		Status:     models.RequirementStatusApproved,
+	}
+	response := &models.SupplierResponse{
+		ID:            primitive.NewObjectID(),
+		RequirementID: requirement.ID,
+		SupplierID:    supplierID,
+	}
+
+	service := services.NewResponseService(
+		&stubApprovedResponseRepo{response: response},
+		nil,
+		&stubApprovedRequirementRepo{requirement: requirement},
+		nil, nil, nil, nil,
+	)
+	handler := NewSupplierPortalHandler(nil, nil, nil, nil, service, nil)
+
+	withSupplier := func(next gin.HandlerFunc) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Set(middleware.ContextKeyOrgID, supplierID.Hex())
+			next(c)
+		}
+	}
+	router := gin.New()
+	router.POST("/requirements/:id/start", withSupplier(handler.StartResponse))
+	router.POST("/responses/:id/draft", withSupplier(handler.SaveDraft))
+	router.POST("/responses/:id/submit", withSupplier(handler.SubmitResponse))
+	router.DELETE("/responses/:id/answers/:questionID", withSupplier(handler.DeleteDraftAnswer))
+
+	answers := `{"answers":[{"question_id":"` + primitive.NewObjectID().Hex() + `","text_answer":"yes"}]}`
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"start", "POST", "/requirements/" + requirement.ID.Hex() + "/start", ""},
+		{"draft", "POST", "/responses/" + response.ID.Hex() + "/draft", answers},
+		{"submit", "POST", "/responses/" + response.ID.Hex() + "/submit", answers},
+		{"clear answer", "DELETE", "/responses/" + response.ID.Hex() + "/answers/" + primitive.NewObjectID().Hex(), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusConflict {
+				t.Fatalf("Expected status 409, got %d: %s", w.Code, w.Body.String())
+			}
+			var body ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if body.Error != "requirement_approved" {
+				t.Errorf("Expected error requirement_approved, got %q", body.Error)
+			}
+		})
+	}
+}
+
+// stubDraftAnswerResponseRepo removes draft answers from a single response; other methods are not used
+type stubDraftAnswerResponseRepo struct {
+	stubApprovedResponseRepo
+}
+
+func (r *stubDraftAnswerResponseRepo) DeleteDraftAnswer(_ context.Context, _, questionID primitive.ObjectID) (*models.SupplierResponse, error) {
+	kept := r.response.DraftAnswers[:0]
+	for _, a := range r.response.DraftAnswers {
+		if a.QuestionID != questionID {
+			kept = append(kept, a)
+		}
+	}
+	r.response.DraftAnswers = kept
+	r.response.Version++
+	return r.response, nil
+}
+
+func TestSupplierPortalHandler_DeleteDraftAnswer(t *testing.T) {
+	supplierID := primitive.NewObjectID()
+	requirement := &models.Requirement{
+		ID:         primitive.NewObjectID(),
+		SupplierID: supplierID,
+		Status:     models.RequirementStatusInProgress,
+	}
+	cleared, kept := primitive.NewObjectID(), primitive.NewObjectID()
+	response := &models.SupplierResponse{
+		ID:            primitive.NewObjectID(),
+		RequirementID: requirement.ID,
+		SupplierID:    supplierID,
+		Version:       4,
+		DraftAnswers:  []models.DraftAnswer{{QuestionID: cleared, TextAnswer: "n/a"}, {QuestionID: kept, TextAnswer: "yes"}},
+	}
+
+	service := services.NewResponseService(
+		&stubDraftAnswerResponseRepo{stubApprovedResponseRepo{response: response}},
+		nil,
+		&stubApprovedRequirementRepo{requirement: requirement},
+		nil, nil, nil, nil,
+	)
+	handler := NewSupplierPortalHandler(nil, nil, nil, nil, service, nil)
+
+	router := gin.New()
+	router.DELETE("/responses/:id/answers/:questionID", func(c *gin.Context) {
+		c.Set(middleware.ContextKeyOrgID, supplierID.Hex())
+		handler.DeleteDraftAnswer(c)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/responses/"+response.ID.Hex()+"/answers/"+cleared.Hex(), http.NoBody))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var body DraftAnswerDeletedResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body.Version != 5 || body.DraftAnswerCount != 1 || w.Header().Get("ETag") != `"5"` {
+		t.Errorf("Unexpected result %+v with ETag %s", body, w.Header().Get("ETag"))
+	}
+	if len(response.DraftAnswers) != 1 || response.DraftAnswers[0].QuestionID != kept {
+		t.Errorf("Expected only the other answer to remain, got %+v", response.DraftAnswers)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/responses/"+response.ID.Hex()+"/answers/not-an-id", http.NoBody))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid question ID, got %d", w.Code)
+	}
+}
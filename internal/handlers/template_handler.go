@@ -3,6 +3,7 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strconv"
@@ -49,6 +50,8 @@ type TemplateResponse struct {
 	UsageCount          int                     `json:"usage_count"`
 	CreatedByOrgID      string                  `json:"created_by_org_id,omitempty"`
 	CreatedByUser       string                  `json:"created_by_user,omitempty"`
+	PreviousVersionID   string                  `json:"previous_version_id,omitempty"`
+	SupersededBy        string                  `json:"superseded_by,omitempty"`
 	CreatedAt           time.Time               `json:"created_at"`
 	UpdatedAt           time.Time               `json:"updated_at"`
 	PublishedAt         *time.Time              `json:"published_at,omitempty"`
@@ -77,6 +80,11 @@ type UpdateTemplateAPIRequest struct {
 	Tags                []string                      `json:"tags,omitempty"`
 }
 
+// CloneTemplateAPIRequest represents a template clone request
+type CloneTemplateAPIRequest struct {
+	Name string `json:"name,omitempty"`
+}
+
 // PublishTemplateAPIRequest represents a template publish request
 type PublishTemplateAPIRequest struct {
 	Visibility string `json:"visibility" binding:"required"`
@@ -107,7 +115,11 @@ type PaginatedTemplatesResponse struct {
 // @Produce json
 // @Security BearerAuth
 // @Param category query string false "Filter by category (ISO27001, GDPR, NIS2)"
+// @Param include_superseded query bool false "Include versions replaced by a newer version"
+// @Param tag query []string false "Filter by tag, repeatable" collectionFormat(multi)
+// @Param match query string false "any (default): templates with any of the tags; all: templates with every tag"
 // @Success 200 {object} []TemplateResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /templates [get]
 func (h *TemplateHandler) ListSystemTemplates(c *gin.Context) {
@@ -119,9 +131,16 @@ func (h *TemplateHandler) ListSystemTemplates(c *gin.Context) {
 		}
 	}
 
-	templates, err := h.templateRepo.ListSystemTemplates(c.Request.Context(), category)
+	includeSuperseded, _ := strconv.ParseBool(c.Query("include_superseded"))
+
+	tags, ok := parseTagFilter(c)
+	if !ok {
+		return
+	}
+
+	templates, err := h.templateRepo.ListSystemTemplates(c.Request.Context(), category, tags, includeSuperseded)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to list templates",
 		})
@@ -136,42 +155,70 @@ func (h *TemplateHandler) ListSystemTemplates(c *gin.Context) {
 	c.JSON(http.StatusOK, responses)
 }
 
+// maxTagFilters caps how many tags one template listing may filter by
+const maxTagFilters = 10
+
+// parseTagFilter reads the repeatable tag and the match query parameters, responding with 400 on bad input
+func parseTagFilter(c *gin.Context) (repository.TagFilter, bool) {
+	var filter repository.TagFilter
+	for _, tag := range c.QueryArray("tag") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			filter.Tags = append(filter.Tags, tag)
+		}
+	}
+	if len(filter.Tags) > maxTagFilters {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_tags",
+			Message: fmt.Sprintf("At most %d tags can be filtered by", maxTagFilters),
+		})
+		return filter, false
+	}
+
+	switch c.Query("match") {
+	case "", "any":
+	case "all":
+		filter.MatchAll = true
+	default:
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_match",
+			Message: "match must be any or all",
+		})
+		return filter, false
+	}
+	return filter, true
+}
+
 // GetTemplate handles GET /api/v1/templates/:id
 // @Summary Get template details
-// @Description Gets details of a specific template
+// @Description Gets details of a system, globally published or own organization's template
 // @Tags Templates
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Template ID"
 // @Success 200 {object} TemplateResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /templates/{id} [get]
 func (h *TemplateHandler) GetTemplate(c *gin.Context) {
 	templateID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid template ID",
 		})
 		return
 	}
 
-	template, err := h.templateRepo.GetByID(c.Request.Context(), templateID)
-	if err != nil {
-		if errors.Is(err, models.ErrTemplateNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Template not found",
-			})
-			return
-		}
+	var orgID *primitive.ObjectID
+	if id, ok := middleware.GetOrgID(c); ok {
+		orgID = &id
+	}
 
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "Failed to get template",
-		})
+	template, err := h.templateService.GetTemplate(c.Request.Context(), templateID, orgID)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -195,7 +242,7 @@ func (h *TemplateHandler) GetTemplate(c *gin.Context) {
 func (h *TemplateHandler) SearchTemplates(c *gin.Context) {
 	query := c.Query("q")
 	if query == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Search query is required",
 		})
@@ -212,7 +259,7 @@ func (h *TemplateHandler) SearchTemplates(c *gin.Context) {
 
 	result, err := h.templateRepo.SearchTemplates(c.Request.Context(), query, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to search templates",
 		})
@@ -224,6 +271,7 @@ func (h *TemplateHandler) SearchTemplates(c *gin.Context) {
 		items[i] = toTemplateResponse(&t)
 	}
 
+	setPaginationHeaders(c, result)
 	c.JSON(http.StatusOK, PaginatedTemplatesResponse{
 		Items:      items,
 		TotalCount: result.TotalCount,
@@ -249,7 +297,7 @@ func (h *TemplateHandler) SearchTemplates(c *gin.Context) {
 func (h *TemplateHandler) ListOrganizationTemplates(c *gin.Context) {
 	companyID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -266,7 +314,7 @@ func (h *TemplateHandler) ListOrganizationTemplates(c *gin.Context) {
 
 	result, err := h.templateRepo.ListByOrganization(c.Request.Context(), companyID, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to list templates",
 		})
@@ -278,6 +326,7 @@ func (h *TemplateHandler) ListOrganizationTemplates(c *gin.Context) {
 		items[i] = toTemplateResponse(&t)
 	}
 
+	setPaginationHeaders(c, result)
 	c.JSON(http.StatusOK, PaginatedTemplatesResponse{
 		Items:      items,
 		TotalCount: result.TotalCount,
@@ -302,7 +351,7 @@ func (h *TemplateHandler) ListOrganizationTemplates(c *gin.Context) {
 func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
 	orgID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -311,7 +360,7 @@ func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
 
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -320,7 +369,7 @@ func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
 
 	var req CreateTemplateAPIRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Invalid request body",
 		})
@@ -340,7 +389,7 @@ func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
 
 	template, err := h.templateService.CreateTemplate(c.Request.Context(), orgID, userID, serviceReq)
 	if err != nil {
-		h.handleTemplateError(c, err)
+		respondError(c, err)
 		return
 	}
 
@@ -362,7 +411,7 @@ func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
 func (h *TemplateHandler) ImportTemplate(c *gin.Context) {
 	orgID, ok := middleware.GetOrgID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -371,7 +420,7 @@ func (h *TemplateHandler) ImportTemplate(c *gin.Context) {
 
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -380,7 +429,15 @@ func (h *TemplateHandler) ImportTemplate(c *gin.Context) {
 
 	file, err := c.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(c, http.StatusRequestEntityTooLarge, ErrorResponse{
+				Error:   "request_too_large",
+				Message: "Template file is too large",
+			})
+			return
+		}
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "File is required",
 		})
@@ -389,7 +446,7 @@ func (h *TemplateHandler) ImportTemplate(c *gin.Context) {
 
 	// Validate file extension
 	if !strings.HasSuffix(strings.ToLower(file.Filename), ".json") {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Only JSON files are supported",
 		})
@@ -399,7 +456,7 @@ func (h *TemplateHandler) ImportTemplate(c *gin.Context) {
 	// Read file content
 	f, err := file.Open()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to read file",
 		})
@@ -409,7 +466,7 @@ func (h *TemplateHandler) ImportTemplate(c *gin.Context) {
 
 	content, err := io.ReadAll(f)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to read file content",
 		})
@@ -418,7 +475,7 @@ func (h *TemplateHandler) ImportTemplate(c *gin.Context) {
 
 	template, err := h.templateService.ImportTemplate(c.Request.Context(), orgID, userID, content)
 	if err != nil {
-		h.handleTemplateError(c, err)
+		respondError(c, err)
 		return
 	}
 
@@ -443,7 +500,7 @@ func (h *TemplateHandler) ImportTemplate(c *gin.Context) {
 func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -452,7 +509,7 @@ func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
 
 	templateID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid template ID",
 		})
@@ -461,7 +518,7 @@ func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
 
 	var req UpdateTemplateAPIRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Invalid request body",
 		})
@@ -480,7 +537,7 @@ func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
 
 	template, err := h.templateService.UpdateTemplate(c.Request.Context(), templateID, userID, serviceReq)
 	if err != nil {
-		h.handleTemplateError(c, err)
+		respondError(c, err)
 		return
 	}
 
@@ -503,7 +560,7 @@ func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
 func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -512,7 +569,7 @@ func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
 
 	templateID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid template ID",
 		})
@@ -520,13 +577,242 @@ func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
 	}
 
 	if err := h.templateService.DeleteTemplate(c.Request.Context(), templateID, userID); err != nil {
-		h.handleTemplateError(c, err)
+		respondError(c, err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
+// CloneTemplate handles POST /api/v1/templates/:id/clone
+// @Summary Clone a template
+// @Description Creates a draft copy of a system, global, or own organization template
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param request body CloneTemplateAPIRequest false "Clone options (name defaults to \"Copy of <name>\")"
+// @Success 201 {object} TemplateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{id}/clone [post]
+func (h *TemplateHandler) CloneTemplate(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	templateID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid template ID",
+		})
+		return
+	}
+
+	// The body is optional; an empty request clones with the default name
+	var req CloneTemplateAPIRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_request",
+				Message: "Invalid request body",
+			})
+			return
+		}
+	}
+
+	template, err := h.templateService.CloneTemplate(c.Request.Context(), templateID, orgID, userID, req.Name)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toTemplateResponse(template))
+}
+
+// ListTemplateVersions handles GET /api/v1/templates/:id/versions
+// @Summary List template versions
+// @Description Lists the visible versions in a template's lineage, oldest first
+// @Tags Templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Success 200 {object} []TemplateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{id}/versions [get]
+func (h *TemplateHandler) ListTemplateVersions(c *gin.Context) {
+	templateID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid template ID",
+		})
+		return
+	}
+
+	var orgID *primitive.ObjectID
+	if id, ok := middleware.GetOrgID(c); ok {
+		orgID = &id
+	}
+
+	versions, err := h.templateService.ListVersions(c.Request.Context(), templateID, orgID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	responses := make([]TemplateResponse, len(versions))
+	for i := range versions {
+		responses[i] = toTemplateResponse(&versions[i])
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// DiffTemplate handles GET /api/v1/templates/:id/diff
+// @Summary Diff two templates
+// @Description Lists topics added, removed, or changed in a template compared to another template
+// @Tags Templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Param against query string true "Base template ID to compare against"
+// @Success 200 {object} services.TemplateDiff
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{id}/diff [get]
+func (h *TemplateHandler) DiffTemplate(c *gin.Context) {
+	templateID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid template ID",
+		})
+		return
+	}
+
+	againstID, err := primitive.ObjectIDFromHex(c.Query("against"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Query parameter 'against' must be a template ID",
+		})
+		return
+	}
+
+	var orgID *primitive.ObjectID
+	if id, ok := middleware.GetOrgID(c); ok {
+		orgID = &id
+	}
+
+	diff, err := h.templateService.Diff(c.Request.Context(), templateID, againstID, orgID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// PreviewTemplate handles GET /api/v1/templates/:id/preview
+// @Summary Preview template
+// @Description Summarizes the questionnaire a template would produce: topics, question count, estimated minutes and max possible score.
+// @Description topics_only is set when the template defines no questions, in which case the counts are zero.
+// @Tags Templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Success 200 {object} services.TemplatePreview
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /templates/{id}/preview [get]
+func (h *TemplateHandler) PreviewTemplate(c *gin.Context) {
+	templateID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid template ID",
+		})
+		return
+	}
+
+	var orgID *primitive.ObjectID
+	if id, ok := middleware.GetOrgID(c); ok {
+		orgID = &id
+	}
+
+	preview, err := h.templateService.PreviewTemplate(c.Request.Context(), templateID, orgID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// CreateTemplateVersion handles POST /api/v1/templates/:id/versions
+// @Summary Create a new template version
+// @Description Creates a draft of the next version of a published template (owner only). Publishing it supersedes the source version.
+// @Tags Templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Template ID"
+// @Success 201 {object} TemplateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /templates/{id}/versions [post]
+func (h *TemplateHandler) CreateTemplateVersion(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	templateID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid template ID",
+		})
+		return
+	}
+
+	template, err := h.templateService.CreateVersion(c.Request.Context(), templateID, userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toTemplateResponse(template))
+}
+
 // PublishTemplate handles POST /api/v1/templates/:id/publish
 // @Summary Publish a template
 // @Description Publishes a draft template with specified visibility (owner only)
@@ -545,7 +831,7 @@ func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
 func (h *TemplateHandler) PublishTemplate(c *gin.Context) {
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -554,7 +840,7 @@ func (h *TemplateHandler) PublishTemplate(c *gin.Context) {
 
 	templateID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid template ID",
 		})
@@ -563,7 +849,7 @@ func (h *TemplateHandler) PublishTemplate(c *gin.Context) {
 
 	var req PublishTemplateAPIRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Visibility is required (local or global)",
 		})
@@ -573,7 +859,7 @@ func (h *TemplateHandler) PublishTemplate(c *gin.Context) {
 	// Parse visibility
 	visibility := models.TemplateVisibility(strings.ToUpper(req.Visibility))
 	if visibility != models.TemplateVisibilityLocal && visibility != models.TemplateVisibilityGlobal {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_request",
 			Message: "Visibility must be 'local' or 'global'",
 		})
@@ -582,7 +868,7 @@ func (h *TemplateHandler) PublishTemplate(c *gin.Context) {
 
 	template, err := h.templateService.PublishTemplate(c.Request.Context(), templateID, userID, visibility)
 	if err != nil {
-		h.handleTemplateError(c, err)
+		respondError(c, err)
 		return
 	}
 
@@ -605,7 +891,7 @@ func (h *TemplateHandler) PublishTemplate(c *gin.Context) {
 func (h *TemplateHandler) UnpublishTemplate(c *gin.Context) {
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -614,7 +900,7 @@ func (h *TemplateHandler) UnpublishTemplate(c *gin.Context) {
 
 	templateID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		writeError(c, http.StatusBadRequest, ErrorResponse{
 			Error:   "invalid_id",
 			Message: "Invalid template ID",
 		})
@@ -623,7 +909,7 @@ func (h *TemplateHandler) UnpublishTemplate(c *gin.Context) {
 
 	template, err := h.templateService.UnpublishTemplate(c.Request.Context(), templateID, userID)
 	if err != nil {
-		h.handleTemplateError(c, err)
+		respondError(c, err)
 		return
 	}
 
@@ -644,7 +930,7 @@ func (h *TemplateHandler) UnpublishTemplate(c *gin.Context) {
 func (h *TemplateHandler) ListMyTemplates(c *gin.Context) {
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
 			Error:   "unauthorized",
 			Message: "Invalid session",
 		})
@@ -661,7 +947,7 @@ func (h *TemplateHandler) ListMyTemplates(c *gin.Context) {
 
 	result, err := h.templateService.ListMyTemplates(c.Request.Context(), userID, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to list templates",
 		})
@@ -673,6 +959,7 @@ func (h *TemplateHandler) ListMyTemplates(c *gin.Context) {
 		items[i] = toTemplateResponse(&t)
 	}
 
+	setPaginationHeaders(c, result)
 	c.JSON(http.StatusOK, PaginatedTemplatesResponse{
 		Items:      items,
 		TotalCount: result.TotalCount,
@@ -682,89 +969,34 @@ func (h *TemplateHandler) ListMyTemplates(c *gin.Context) {
 	})
 }
 
-// handleTemplateError maps service errors to HTTP responses
-func (h *TemplateHandler) handleTemplateError(c *gin.Context, err error) {
-	switch {
-	case errors.Is(err, models.ErrTemplateNotFound):
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Error:   "not_found",
-			Message: "Template not found",
-		})
-	case errors.Is(err, models.ErrTemplateNotOwnedByUser):
-		c.JSON(http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "You can only modify templates you created",
-		})
-	case errors.Is(err, models.ErrTemplateNotEditable):
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "not_editable",
-			Message: "Template cannot be edited (already published or system template)",
-		})
-	case errors.Is(err, models.ErrTemplateNotDeletable):
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "not_deletable",
-			Message: "Template cannot be deleted",
-		})
-	case errors.Is(err, models.ErrTemplateAlreadyPublished):
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "already_published",
-			Message: "Template is already published",
-		})
-	case errors.Is(err, models.ErrTemplateNotPublished):
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "not_published",
-			Message: "Template is not published",
-		})
-	case errors.Is(err, models.ErrTemplateInUse):
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "in_use",
-			Message: "Template is in use and cannot be modified",
-		})
-	case errors.Is(err, models.ErrTemplateInvalidFormat):
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_format",
-			Message: err.Error(),
-		})
-	case errors.Is(err, models.ErrTemplateMissingFields):
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "missing_fields",
-			Message: err.Error(),
-		})
-	case errors.Is(err, models.ErrTemplateInvalidVisibility):
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "invalid_visibility",
-			Message: "Invalid visibility value",
-		})
-	default:
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_error",
-			Message: "An unexpected error occurred",
-		})
-	}
-}
-
 // RegisterRoutes registers template handler routes
 // #INTEGRATION_POINT: Routes require authentication
 func (h *TemplateHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
 	templates := rg.Group("/templates")
 	templates.Use(authMiddleware)
+	templates.Use(auditRequestInfo())
 
 	// Read-only endpoints (all authenticated users)
 	templates.GET("", h.ListSystemTemplates)
 	templates.GET("/search", h.SearchTemplates)
 	templates.GET("/:id", h.GetTemplate)
+	templates.GET("/:id/versions", h.ListTemplateVersions)
+	templates.GET("/:id/diff", h.DiffTemplate)
+	templates.GET("/:id/preview", h.PreviewTemplate)
 
 	// Organization-level endpoints
 	templates.GET("/organization", middleware.RequireCompany(), h.ListOrganizationTemplates)
 	templates.GET("/mine", middleware.RequireCompany(), h.ListMyTemplates)
 
-	// Write endpoints (company users only)
-	templates.POST("", middleware.RequireCompany(), h.CreateTemplate)
-	templates.POST("/import", middleware.RequireCompany(), h.ImportTemplate)
-	templates.PUT("/:id", middleware.RequireCompany(), h.UpdateTemplate)
-	templates.DELETE("/:id", middleware.RequireCompany(), h.DeleteTemplate)
-	templates.POST("/:id/publish", middleware.RequireCompany(), h.PublishTemplate)
-	templates.POST("/:id/unpublish", middleware.RequireCompany(), h.UnpublishTemplate)
+	// Write endpoints (company admins only)
+	templates.POST("", middleware.RequireCompany(), middleware.RequireAdmin(), h.CreateTemplate)
+	templates.POST("/import", middleware.RequireCompany(), middleware.RequireAdmin(), h.ImportTemplate)
+	templates.POST("/:id/clone", middleware.RequireCompany(), middleware.RequireAdmin(), h.CloneTemplate)
+	templates.POST("/:id/versions", middleware.RequireCompany(), middleware.RequireAdmin(), h.CreateTemplateVersion)
+	templates.PUT("/:id", middleware.RequireCompany(), middleware.RequireAdmin(), h.UpdateTemplate)
+	templates.DELETE("/:id", middleware.RequireCompany(), middleware.RequireAdmin(), h.DeleteTemplate)
+	templates.POST("/:id/publish", middleware.RequireCompany(), middleware.RequireAdmin(), h.PublishTemplate)
+	templates.POST("/:id/unpublish", middleware.RequireCompany(), middleware.RequireAdmin(), h.UnpublishTemplate)
 }
 
 // toTemplateResponse converts a template model to response
@@ -793,6 +1025,12 @@ func toTemplateResponse(t *models.QuestionnaireTemplate) TemplateResponse {
 	if t.CreatedByUser != nil {
 		resp.CreatedByUser = t.CreatedByUser.Hex()
 	}
+	if t.PreviousVersionID != nil {
+		resp.PreviousVersionID = t.PreviousVersionID.Hex()
+	}
+	if t.SupersededBy != nil {
+		resp.SupersededBy = t.SupersededBy.Hex()
+	}
 
 	resp.Topics = make([]TemplateTopicResponse, len(t.Topics))
 	for i, topic := range t.Topics {
@@ -0,0 +1,576 @@
+// Package handlers provides HTTP handlers for API endpoints.
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// UserHandler handles organization team-member endpoints
+// #INTEGRATION_POINT: Used by both company and supplier portals for team management
+type UserHandler struct {
+	userService services.UserService
+}
+
+// NewUserHandler creates a new user handler
+func NewUserHandler(userService services.UserService) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+	}
+}
+
+// InviteUserRequest represents the invite team member request body
+type InviteUserRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Name     string `json:"name,omitempty"`
+	Role     string `json:"role,omitempty"`
+	Language string `json:"language,omitempty"`
+}
+
+// UserResponse represents a team member in API responses
+type UserResponse struct {
+	ID          string     `json:"id"`
+	Email       string     `json:"email"`
+	Name        string     `json:"name,omitempty"`
+	Role        string     `json:"role"`
+	IsActive    bool       `json:"is_active"`
+	Language    string     `json:"language"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ProfileResponse represents the caller's own profile
+type ProfileResponse struct {
+	User         UserResponse               `json:"user"`
+	Organization ProfileOrganizationSummary `json:"organization"`
+}
+
+// ProfileOrganizationSummary is the organization shown alongside the caller's profile
+type ProfileOrganizationSummary struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Slug    string `json:"slug"`
+	LogoURL string `json:"logo_url,omitempty"`
+}
+
+// UpdateProfileAPIRequest represents a self-service profile update
+type UpdateProfileAPIRequest struct {
+	Name     *string `json:"name,omitempty"`
+	Language *string `json:"language,omitempty"`
+	// Email is accepted only to reject it explicitly instead of silently ignoring it
+	Email *string `json:"email,omitempty"`
+}
+
+// ChangeEmailRequest represents a request to change the caller's email
+type ChangeEmailRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ConfirmEmailChangeRequest carries the token from an email change link
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ChangeEmailResponse acknowledges an email change request
+type ChangeEmailResponse struct {
+	Message string `json:"message"`
+}
+
+// NotificationPreferenceItem represents one notification kind and whether the user receives it
+type NotificationPreferenceItem struct {
+	Kind     string `json:"kind"`
+	Enabled  bool   `json:"enabled"`
+	Optional bool   `json:"optional"`
+}
+
+// NotificationPreferencesResponse represents the caller's notification preferences
+type NotificationPreferencesResponse struct {
+	Notifications []NotificationPreferenceItem `json:"notifications"`
+}
+
+// UpdateNotificationPreferencesAPIRequest enables or disables notification kinds; omitted kinds are left unchanged
+type UpdateNotificationPreferencesAPIRequest struct {
+	Invitation *bool `json:"invitation,omitempty"`
+	Reminder   *bool `json:"reminder,omitempty"`
+	Overdue    *bool `json:"overdue,omitempty"`
+	Approval   *bool `json:"approval,omitempty"`
+}
+
+// PaginatedUsersResponse represents paginated team members
+type PaginatedUsersResponse struct {
+	Items      []UserResponse `json:"items"`
+	TotalCount int64          `json:"total_count"`
+	Page       int            `json:"page"`
+	Limit      int            `json:"limit"`
+	TotalPages int            `json:"total_pages"`
+}
+
+// InviteUser handles POST /api/v1/organization/users
+// @Summary Invite a team member
+// @Description Creates a user in the current organization and emails a sign-in link
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body InviteUserRequest true "Invitation request"
+// @Success 201 {object} UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /organization/users [post]
+func (h *UserHandler) InviteUser(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	var req InviteUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Valid email is required",
+		})
+		return
+	}
+
+	serviceReq := services.InviteUserRequest{
+		Email:    req.Email,
+		Name:     req.Name,
+		Role:     models.UserRole(strings.ToUpper(req.Role)),
+		Language: req.Language,
+	}
+
+	user, err := h.userService.InviteUser(c.Request.Context(), orgID, serviceReq)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toUserResponse(user))
+}
+
+// ListUsers handles GET /api/v1/organization/users
+// @Summary List team members
+// @Description Lists users in the current organization
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param include_inactive query bool false "Include inactive users"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} PaginatedUsersResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/users [get]
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	includeInactive := c.Query("include_inactive") == "true"
+
+	opts := repository.DefaultPaginationOptions()
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		opts.Page = page
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 && limit <= 100 {
+		opts.Limit = limit
+	}
+	if sortBy := c.Query("sort_by"); sortBy != "" {
+		opts.SortBy = validateSort(sortBy, repository.UserSortFields)
+	}
+	if sortDir := c.Query("sort_dir"); sortDir == sortDirectionAsc {
+		opts.SortDir = 1
+	}
+
+	result, err := h.userService.ListUsers(c.Request.Context(), orgID, includeInactive, opts)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to list users",
+		})
+		return
+	}
+
+	items := make([]UserResponse, len(result.Items))
+	for i, u := range result.Items {
+		items[i] = toUserResponse(&u)
+	}
+
+	setPaginationHeaders(c, result)
+	c.JSON(http.StatusOK, PaginatedUsersResponse{
+		Items:      items,
+		TotalCount: result.TotalCount,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		TotalPages: result.TotalPages,
+	})
+}
+
+// RemoveUser handles DELETE /api/v1/organization/users/:id
+// @Summary Remove a team member
+// @Description Soft deletes a user from the current organization
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /organization/users/{id} [delete]
+func (h *UserHandler) RemoveUser(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	userID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid user ID",
+		})
+		return
+	}
+
+	err = h.userService.RemoveUser(c.Request.Context(), orgID, userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetProfile handles GET /api/v1/me
+// @Summary Get own profile
+// @Description Returns the current user and a summary of their organization
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ProfileResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /me [get]
+func (h *UserHandler) GetProfile(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	user, org, err := h.userService.GetProfile(c.Request.Context(), userID)
+	if err != nil {
+		respondSessionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toProfileResponse(user, org))
+}
+
+// UpdateProfile handles PATCH /api/v1/me
+// @Summary Update own profile
+// @Description Updates the current user's display name and preferred language. Email cannot be changed here.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdateProfileAPIRequest true "Profile updates"
+// @Success 200 {object} ProfileResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /me [patch]
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	var req UpdateProfileAPIRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body",
+		})
+		return
+	}
+	if req.Email != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "email_not_editable",
+			Message: "Email cannot be changed through the profile",
+		})
+		return
+	}
+
+	_, err := h.userService.UpdateProfile(c.Request.Context(), userID, services.UpdateProfileRequest{
+		Name:     req.Name,
+		Language: req.Language,
+	})
+	if err != nil {
+		respondSessionError(c, err)
+		return
+	}
+
+	h.GetProfile(c)
+}
+
+// RequestEmailChange handles POST /api/v1/me/email
+// @Summary Change own email
+// @Description Sends a verification link to the new address. The email is changed once the link is confirmed.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ChangeEmailRequest true "New email"
+// @Success 202 {object} ChangeEmailResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /me/email [post]
+func (h *UserHandler) RequestEmailChange(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	var req ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Valid email is required",
+		})
+		return
+	}
+
+	err := h.userService.RequestEmailChange(c.Request.Context(), userID, req.Email)
+	if err != nil {
+		respondSessionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, ChangeEmailResponse{
+		Message: "A verification link has been sent to the new email address.",
+	})
+}
+
+// ConfirmEmailChange handles POST /api/v1/me/email/verify
+// @Summary Confirm email change
+// @Description Redeems the link sent to the new address and updates the email. No session is required because the link may be opened on another device.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body ConfirmEmailChangeRequest true "Email change token"
+// @Success 200 {object} UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /me/email/verify [post]
+func (h *UserHandler) ConfirmEmailChange(c *gin.Context) {
+	var req ConfirmEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Token is required",
+		})
+		return
+	}
+
+	user, err := h.userService.ConfirmEmailChange(c.Request.Context(), req.Token)
+	if err != nil {
+		respondSessionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toUserResponse(user))
+}
+
+// GetNotificationPreferences handles GET /api/v1/me/notifications
+// @Summary Get own notification preferences
+// @Description Lists the supplier notification kinds and whether the current user receives them.
+// @Description Invitations and approvals are always sent; reminders and overdue notices are optional.
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} NotificationPreferencesResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /me/notifications [get]
+func (h *UserHandler) GetNotificationPreferences(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	user, err := h.userService.GetNotificationPreferences(c.Request.Context(), userID)
+	if err != nil {
+		respondSessionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toNotificationPreferencesResponse(user))
+}
+
+// UpdateNotificationPreferences handles PATCH /api/v1/me/notifications
+// @Summary Update own notification preferences
+// @Description Enables or disables notification kinds for the current user. Invitations and approvals cannot be disabled.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdateNotificationPreferencesAPIRequest true "Notification preferences"
+// @Success 200 {object} NotificationPreferencesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /me/notifications [patch]
+func (h *UserHandler) UpdateNotificationPreferences(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	var req UpdateNotificationPreferencesAPIRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	changes := make(map[models.NotificationKind]bool)
+	for kind, enabled := range map[models.NotificationKind]*bool{
+		models.NotificationKindInvitation: req.Invitation,
+		models.NotificationKindReminder:   req.Reminder,
+		models.NotificationKindOverdue:    req.Overdue,
+		models.NotificationKindApproval:   req.Approval,
+	} {
+		if enabled != nil {
+			changes[kind] = *enabled
+		}
+	}
+
+	user, err := h.userService.UpdateNotificationPreferences(c.Request.Context(), userID, changes)
+	if err != nil {
+		respondSessionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toNotificationPreferencesResponse(user))
+}
+
+// RegisterRoutes registers user handler routes
+// #INTEGRATION_POINT: Team management is restricted to organization admins; any user may edit their own profile
+func (h *UserHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	users := rg.Group("/organization/users")
+	users.Use(authMiddleware)
+	users.Use(middleware.RequireRole(models.UserRoleAdmin))
+	users.POST("", h.InviteUser)
+	users.GET("", h.ListUsers)
+	users.DELETE("/:id", h.RemoveUser)
+
+	me := rg.Group("/me")
+	me.Use(authMiddleware)
+	me.GET("", h.GetProfile)
+	me.PATCH("", h.UpdateProfile)
+	me.POST("/email", h.RequestEmailChange)
+	me.GET("/notifications", middleware.RequireSupplier(), h.GetNotificationPreferences)
+	me.PATCH("/notifications", middleware.RequireSupplier(), h.UpdateNotificationPreferences)
+
+	// Public: possession of the link sent to the new address is the proof
+	rg.POST("/me/email/verify", h.ConfirmEmailChange)
+}
+
+// toNotificationPreferencesResponse converts a user's notification preferences to response
+func toNotificationPreferencesResponse(u *models.User) NotificationPreferencesResponse {
+	items := make([]NotificationPreferenceItem, len(models.NotificationKinds))
+	for i, kind := range models.NotificationKinds {
+		items[i] = NotificationPreferenceItem{
+			Kind:     string(kind),
+			Enabled:  u.WantsNotification(kind),
+			Optional: kind.IsOptional(),
+		}
+	}
+	return NotificationPreferencesResponse{Notifications: items}
+}
+
+// toProfileResponse converts a user and their organization to a profile response
+func toProfileResponse(u *models.User, org *models.Organization) ProfileResponse {
+	summary := ProfileOrganizationSummary{
+		ID:   org.ID.Hex(),
+		Type: string(org.Type),
+		Name: org.Name,
+		Slug: org.Slug,
+	}
+	if org.Logo != nil {
+		summary.LogoURL = "/api/v1/organizations/" + org.Slug + "/logo"
+	}
+
+	return ProfileResponse{
+		User:         toUserResponse(u),
+		Organization: summary,
+	}
+}
+
+// toUserResponse converts a user model to response
+func toUserResponse(u *models.User) UserResponse {
+	return UserResponse{
+		ID:          u.ID.Hex(),
+		Email:       u.Email,
+		Name:        u.Name,
+		Role:        string(u.Role),
+		IsActive:    u.IsActive,
+		Language:    u.Language,
+		LastLoginAt: u.LastLoginAt,
+		CreatedAt:   u.CreatedAt,
+	}
+}
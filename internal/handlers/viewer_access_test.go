@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+// viewerWriteExemptRoutes are mutating routes a viewer may call because they only touch the
+// viewer's own account
+var viewerWriteExemptRoutes = map[string]bool{
+	"PATCH /api/v1/me":               true,
+	"PATCH /api/v1/me/notifications": true,
+	"POST /api/v1/me/email":          true,
+	"POST /api/v1/me/email/verify":   true,
+}
+
+// TestViewerCannotMutate checks every mutating organization route rejects viewers before any
+// handler code runs; the handlers have no services, so reaching one would not yield a 403
+func TestViewerCannotMutate(t *testing.T) {
+	for _, orgType := range []models.OrganizationType{models.OrganizationTypeCompany, models.OrganizationTypeSupplier} {
+		auth := func(c *gin.Context) {
+			c.Set(middleware.ContextKeyUserID, primitive.NewObjectID().Hex())
+			c.Set(middleware.ContextKeyOrgID, primitive.NewObjectID().Hex())
+			c.Set(middleware.ContextKeyRole, string(models.UserRoleViewer))
+			c.Set(middleware.ContextKeyOrgType, string(orgType))
+			c.Next()
+		}
+
+		router := gin.New()
+		router.Use(gin.Recovery())
+		apiV1 := router.Group("/api/v1")
+		NewRelationshipHandler(nil, nil, nil, nil).RegisterRoutes(apiV1, auth)
+		NewQuestionnaireHandler(nil, nil, nil).RegisterRoutes(apiV1, auth)
+		NewTemplateHandler(nil, nil).RegisterRoutes(apiV1, auth)
+		NewRequirementHandler(nil, nil, nil).RegisterRoutes(apiV1, auth)
+		NewDashboardHandler(nil, nil).RegisterRoutes(apiV1, auth)
+		NewSearchHandler(nil, nil).RegisterRoutes(apiV1, auth)
+		NewSupplierPortalHandler(nil, nil, nil, nil, nil, nil).RegisterRoutes(apiV1, auth)
+		NewReviewHandler(nil).RegisterRoutes(apiV1, auth)
+		NewClarificationHandler(nil).RegisterRoutes(apiV1, auth)
+		NewCheckFixHandler(nil).RegisterRoutes(apiV1, auth)
+		NewOrganizationHandler(nil, nil).RegisterRoutes(apiV1, auth)
+		NewUserHandler(nil).RegisterRoutes(apiV1, auth)
+		NewAPIKeyHandler(nil).RegisterRoutes(apiV1, auth)
+		NewWebhookHandler(nil).RegisterRoutes(apiV1, auth)
+		NewAuditHandler(nil).RegisterRoutes(apiV1, auth)
+		NewEmailOutboxHandler(nil).RegisterRoutes(apiV1, auth)
+
+		for _, route := range router.Routes() {
+			if route.Method == http.MethodGet || viewerWriteExemptRoutes[route.Method+" "+route.Path] {
+				continue
+			}
+
+			path := strings.NewReplacer(":id", primitive.NewObjectID().Hex(), ":relationshipID", primitive.NewObjectID().Hex(), ":domain", "example.com").Replace(route.Path)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(route.Method, path, strings.NewReader("{}")))
+			if w.Code != http.StatusForbidden {
+				t.Errorf("%s viewer: %s %s returned %d, want 403", orgType, route.Method, route.Path, w.Code)
+			}
+		}
+	}
+}
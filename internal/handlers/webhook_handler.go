@@ -0,0 +1,268 @@
+// Package handlers provides HTTP handlers for API endpoints.
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/middleware"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/services"
+)
+
+// WebhookHandler handles outbound webhook management endpoints
+// #INTEGRATION_POINT: GRC tools register endpoints here to receive requirement and relationship events
+type WebhookHandler struct {
+	webhookService services.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookService services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhookRequest represents the create webhook request body
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required"`
+	Events []string `json:"events" binding:"required"`
+}
+
+// WebhookResponse represents a webhook in API responses
+type WebhookResponse struct {
+	ID                  string     `json:"id"`
+	URL                 string     `json:"url"`
+	Events              []string   `json:"events"`
+	LastDeliveryAt      *time.Time `json:"last_delivery_at,omitempty"`
+	LastStatusCode      int        `json:"last_status_code,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+// CreateWebhookResponse includes the signing secret, which is only returned once
+type CreateWebhookResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+// CreateWebhook handles POST /api/v1/organization/webhooks
+// @Summary Create a webhook
+// @Description Registers an https endpoint for lifecycle events. Deliveries are signed with HMAC-SHA256 of the body using the returned secret (X-NisFix-Signature: sha256=<hex>); the secret is only returned in this response.
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateWebhookRequest true "Webhook request"
+// @Success 201 {object} CreateWebhookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /organization/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "URL and events are required",
+		})
+		return
+	}
+
+	events := make([]models.WebhookEventType, len(req.Events))
+	for i, e := range req.Events {
+		events[i] = models.WebhookEventType(strings.ToLower(e))
+	}
+
+	webhook, secret, err := h.webhookService.CreateWebhook(c.Request.Context(), orgID, userID, services.CreateWebhookRequest{
+		URL:    req.URL,
+		Events: events,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidWebhookURL) {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_url",
+				Message: "URL must be an absolute https URL",
+			})
+			return
+		}
+		if errors.Is(err, services.ErrWebhookURLNotPublic) {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_url",
+				Message: "URL must resolve to a public address",
+			})
+			return
+		}
+		if errors.Is(err, services.ErrInvalidWebhookEventType) {
+			writeError(c, http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_event",
+				Message: "At least one valid event is required",
+			})
+			return
+		}
+		if errors.Is(err, services.ErrTooManyWebhooks) {
+			writeError(c, http.StatusConflict, ErrorResponse{
+				Error:   "too_many_webhooks",
+				Message: fmt.Sprintf("An organization can have at most %d webhooks", models.MaxWebhooksPerOrganization),
+			})
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to create webhook",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreateWebhookResponse{
+		WebhookResponse: toWebhookResponse(webhook),
+		Secret:          secret,
+	})
+}
+
+// ListWebhooks handles GET /api/v1/organization/webhooks
+// @Summary List webhooks
+// @Description Lists webhooks of the current organization with their last delivery status
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} WebhookResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	webhooks, err := h.webhookService.ListWebhooks(c.Request.Context(), orgID)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to list webhooks",
+		})
+		return
+	}
+
+	items := make([]WebhookResponse, len(webhooks))
+	for i := range webhooks {
+		items[i] = toWebhookResponse(&webhooks[i])
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// DeleteWebhook handles DELETE /api/v1/organization/webhooks/:id
+// @Summary Delete a webhook
+// @Description Deletes a webhook; pending retries for it are abandoned
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /organization/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	orgID, ok := middleware.GetOrgID(c)
+	if !ok {
+		writeError(c, http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid session",
+		})
+		return
+	}
+
+	webhookID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_id",
+			Message: "Invalid webhook ID",
+		})
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), orgID, webhookID); err != nil {
+		if errors.Is(err, services.ErrWebhookNotFound) {
+			writeError(c, http.StatusNotFound, ErrorResponse{
+				Error:   "not_found",
+				Message: "Webhook not found",
+			})
+			return
+		}
+
+		writeError(c, http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to delete webhook",
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RegisterRoutes registers webhook handler routes
+// #SECURITY_ASSUMPTION: Webhook management is restricted to organization admins
+func (h *WebhookHandler) RegisterRoutes(rg *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	webhooks := rg.Group("/organization/webhooks")
+	webhooks.Use(authMiddleware)
+	webhooks.Use(middleware.RequireAdmin())
+	webhooks.POST("", h.CreateWebhook)
+	webhooks.GET("", h.ListWebhooks)
+	webhooks.DELETE("/:id", h.DeleteWebhook)
+}
+
+// toWebhookResponse converts a webhook model to response
+func toWebhookResponse(w *models.Webhook) WebhookResponse {
+	events := make([]string, len(w.Events))
+	for i, e := range w.Events {
+		events[i] = string(e)
+	}
+
+	return WebhookResponse{
+		ID:                  w.ID.Hex(),
+		URL:                 w.URL,
+		Events:              events,
+		LastDeliveryAt:      w.LastDeliveryAt,
+		LastStatusCode:      w.LastStatusCode,
+		LastError:           w.LastError,
+		ConsecutiveFailures: w.ConsecutiveFailures,
+		CreatedAt:           w.CreatedAt,
+	}
+}
@@ -0,0 +1,66 @@
+// Package metrics defines the Prometheus collectors exposed on /metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// namespace prefixes every metric name
+const namespace = "nisfix"
+
+// Business event names used as the "event" label of BusinessEvents
+const (
+	EventQuestionnaireSubmitted = "questionnaire_submitted"
+	EventCheckFixVerified       = "checkfix_verified"
+	EventRequirementApproved    = "requirement_approved"
+	EventRequirementRejected    = "requirement_rejected"
+)
+
+// #LIBRARY_CHOICE: prometheus/client_golang - de facto standard, scraped by the cluster Prometheus
+// #IMPLEMENTATION_DECISION: Collectors are registered on the default registry so Go runtime
+// and process metrics are exported alongside them
+var (
+	// HTTPRequestsTotal counts handled requests by method, route and status
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Total number of HTTP requests handled.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration observes request latency by method, route and status
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency in seconds.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestsInFlight tracks requests currently being served by route
+	HTTPRequestsInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "Number of HTTP requests currently being served.",
+	}, []string{"method", "route"})
+
+	// BusinessEvents counts domain events such as submissions and verifications
+	BusinessEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "business_events_total",
+		Help:      "Total number of business events by type.",
+	}, []string{"event", "detail"})
+)
+
+// RecordEvent increments the business event counter
+// detail is a low-cardinality qualifier such as "passed"/"failed" or the requirement type
+func RecordEvent(event, detail string) {
+	BusinessEvents.WithLabelValues(event, detail).Inc()
+}
+
+// Outcome maps a boolean result to the "passed"/"failed" detail label
+func Outcome(passed bool) string {
+	if passed {
+		return "passed"
+	}
+	return "failed"
+}
@@ -0,0 +1,97 @@
+// Package middleware provides HTTP middleware for Gin framework.
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+// Context keys for API key authenticated requests
+const (
+	ContextKeyAPIKeyID     = "api_key_id"
+	ContextKeyAPIKeyScopes = "api_key_scopes"
+)
+
+// ErrInsufficientScope is returned when an API key lacks the scope required by a route
+var ErrInsufficientScope = errors.New("API key does not have the required scope")
+
+// APIKeyAuthenticator resolves a plaintext API key
+// #INTEGRATION_POINT: Implemented by services.APIKeyService
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, plaintext string) (*models.APIKey, *models.Organization, error)
+}
+
+// APIKeyAuth accepts "Authorization: ApiKey <token>" and falls back to next for any other scheme
+// #IMPLEMENTATION_DECISION: Only route groups registered with this middleware accept API keys,
+// and every route in such a group declares RequireScope or RequireUserSession
+func APIKeyAuth(authenticator APIKeyAuthenticator, next gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], "apikey") {
+			next(c)
+			return
+		}
+
+		key, org, err := authenticator.AuthenticateAPIKey(c.Request.Context(), strings.TrimSpace(parts[1]))
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, errorBody(c, "unauthorized", "invalid or revoked API key"))
+			c.Abort()
+			return
+		}
+
+		c.Set(ContextKeyUserID, key.CreatedBy.Hex())
+		c.Set(ContextKeyOrgID, key.OrganizationID.Hex())
+		c.Set(ContextKeyRole, string(key.Role))
+		c.Set(ContextKeyOrgType, string(org.Type))
+		c.Set(ContextKeyAPIKeyID, key.ID.Hex())
+		c.Set(ContextKeyAPIKeyScopes, key.Scopes)
+
+		c.Next()
+	}
+}
+
+// RequireScope allows interactive sessions and API keys that carry the given scope
+func RequireScope(scope models.APIKeyScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !IsAPIKeyRequest(c) {
+			c.Next()
+			return
+		}
+
+		scopesVal, _ := c.Get(ContextKeyAPIKeyScopes)
+		scopes, _ := scopesVal.([]models.APIKeyScope)
+		for _, s := range scopes {
+			if s == scope {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, errorBody(c, "forbidden", ErrInsufficientScope.Error()))
+		c.Abort()
+	}
+}
+
+// RequireUserSession rejects API keys on routes that need an interactive user
+func RequireUserSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if IsAPIKeyRequest(c) {
+			c.JSON(http.StatusForbidden, errorBody(c, "forbidden", "this endpoint is not available for API keys"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// IsAPIKeyRequest checks if the current request was authenticated with an API key
+func IsAPIKeyRequest(c *gin.Context) bool {
+	_, exists := c.Get(ContextKeyAPIKeyID)
+	return exists
+}
@@ -3,9 +3,11 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -29,29 +31,31 @@ var (
 	ErrAuthHeaderMissing = errors.New("authorization header is required")
 	ErrAuthHeaderFormat  = errors.New("authorization header format must be Bearer {token}")
 	ErrInvalidToken      = errors.New("invalid or expired token")
+	ErrTokenRevoked      = errors.New("token has been revoked")
 	ErrForbidden         = errors.New("access denied")
 )
 
+// TokenDenylist reports whether an access token has been revoked server-side
+// #INTEGRATION_POINT: Implemented by repository.RevokedTokenRepository
+type TokenDenylist interface {
+	IsRevoked(ctx context.Context, tokenID string, userID primitive.ObjectID, issuedAt time.Time) (bool, error)
+}
+
 // AuthMiddleware validates JWT tokens and extracts user claims
 // #IMPLEMENTATION_DECISION: Bearer token authentication
-func AuthMiddleware(jwtService auth.JWTService) gin.HandlerFunc {
+// #SECURITY_ASSUMPTION: A nil denylist disables revocation checks (tests, tooling)
+func AuthMiddleware(jwtService auth.JWTService, denylist TokenDenylist) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "unauthorized",
-				"message": ErrAuthHeaderMissing.Error(),
-			})
+			c.JSON(http.StatusUnauthorized, errorBody(c, "unauthorized", ErrAuthHeaderMissing.Error()))
 			c.Abort()
 			return
 		}
 
 		parts := strings.SplitN(authHeader, " ", 2)
 		if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error":   "unauthorized",
-				"message": ErrAuthHeaderFormat.Error(),
-			})
+			c.JSON(http.StatusUnauthorized, errorBody(c, "unauthorized", ErrAuthHeaderFormat.Error()))
 			c.Abort()
 			return
 		}
@@ -66,10 +70,19 @@ func AuthMiddleware(jwtService auth.JWTService) gin.HandlerFunc {
 				message = "token has expired"
 			}
 
-			c.JSON(statusCode, gin.H{
-				"error":   "unauthorized",
-				"message": message,
-			})
+			c.JSON(statusCode, errorBody(c, "unauthorized", message))
+			c.Abort()
+			return
+		}
+
+		revoked, err := isTokenRevoked(c.Request.Context(), denylist, claims)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, errorBody(c, "internal_error", "failed to verify token"))
+			c.Abort()
+			return
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, errorBody(c, "unauthorized", ErrTokenRevoked.Error()))
 			c.Abort()
 			return
 		}
@@ -87,7 +100,7 @@ func AuthMiddleware(jwtService auth.JWTService) gin.HandlerFunc {
 
 // OptionalAuthMiddleware extracts user claims if present but doesn't require authentication
 // #IMPLEMENTATION_DECISION: For endpoints that behave differently for authenticated users
-func OptionalAuthMiddleware(jwtService auth.JWTService) gin.HandlerFunc {
+func OptionalAuthMiddleware(jwtService auth.JWTService, denylist TokenDenylist) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -104,6 +117,10 @@ func OptionalAuthMiddleware(jwtService auth.JWTService) gin.HandlerFunc {
 		tokenString := parts[1]
 		claims, err := jwtService.ValidateAccessToken(tokenString)
 		if err == nil {
+			if revoked, revokedErr := isTokenRevoked(c.Request.Context(), denylist, claims); revokedErr != nil || revoked {
+				c.Next()
+				return
+			}
 			c.Set(ContextKeyClaims, claims)
 			c.Set(ContextKeyUserID, claims.UserID)
 			c.Set(ContextKeyOrgID, claims.OrgID)
@@ -115,26 +132,39 @@ func OptionalAuthMiddleware(jwtService auth.JWTService) gin.HandlerFunc {
 	}
 }
 
+// isTokenRevoked checks the denylist for the token's jti and user-wide revocations
+func isTokenRevoked(ctx context.Context, denylist TokenDenylist, claims *auth.Claims) (bool, error) {
+	if denylist == nil {
+		return false, nil
+	}
+
+	userID, err := primitive.ObjectIDFromHex(claims.UserID)
+	if err != nil {
+		return true, nil
+	}
+
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+
+	return denylist.IsRevoked(ctx, claims.ID, userID, issuedAt)
+}
+
 // RequireRole middleware checks if the user has one of the required roles
 // #IMPLEMENTATION_DECISION: Role-based access control
 func RequireRole(allowedRoles ...models.UserRole) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		roleVal, exists := c.Get(ContextKeyRole)
 		if !exists {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error":   "forbidden",
-				"message": ErrForbidden.Error(),
-			})
+			c.JSON(http.StatusForbidden, errorBody(c, "forbidden", ErrForbidden.Error()))
 			c.Abort()
 			return
 		}
 
 		roleStr, ok := roleVal.(string)
 		if !ok {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error":   "forbidden",
-				"message": ErrForbidden.Error(),
-			})
+			c.JSON(http.StatusForbidden, errorBody(c, "forbidden", ErrForbidden.Error()))
 			c.Abort()
 			return
 		}
@@ -146,10 +176,7 @@ func RequireRole(allowedRoles ...models.UserRole) gin.HandlerFunc {
 			}
 		}
 
-		c.JSON(http.StatusForbidden, gin.H{
-			"error":   "forbidden",
-			"message": "insufficient role permissions",
-		})
+		c.JSON(http.StatusForbidden, errorBody(c, "forbidden", "insufficient role permissions"))
 		c.Abort()
 	}
 }
@@ -160,20 +187,14 @@ func RequireOrgType(allowedTypes ...models.OrganizationType) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		orgTypeVal, exists := c.Get(ContextKeyOrgType)
 		if !exists {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error":   "forbidden",
-				"message": ErrForbidden.Error(),
-			})
+			c.JSON(http.StatusForbidden, errorBody(c, "forbidden", ErrForbidden.Error()))
 			c.Abort()
 			return
 		}
 
 		orgTypeStr, ok := orgTypeVal.(string)
 		if !ok {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error":   "forbidden",
-				"message": ErrForbidden.Error(),
-			})
+			c.JSON(http.StatusForbidden, errorBody(c, "forbidden", ErrForbidden.Error()))
 			c.Abort()
 			return
 		}
@@ -185,10 +206,7 @@ func RequireOrgType(allowedTypes ...models.OrganizationType) gin.HandlerFunc {
 			}
 		}
 
-		c.JSON(http.StatusForbidden, gin.H{
-			"error":   "forbidden",
-			"message": "this endpoint is not available for your organization type",
-		})
+		c.JSON(http.StatusForbidden, errorBody(c, "forbidden", "this endpoint is not available for your organization type"))
 		c.Abort()
 	}
 }
@@ -198,6 +216,26 @@ func RequireAdmin() gin.HandlerFunc {
 	return RequireRole(models.UserRoleAdmin)
 }
 
+// RequireWriteAccess rejects viewers on every request that is not a safe (read-only) method
+// #SECURITY_ASSUMPTION: Viewers are read-only; groups whose write routes are not already
+// admin-only use this so a new POST/PUT/PATCH/DELETE route cannot forget the check
+func RequireWriteAccess() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		if role, ok := GetRole(c); ok && role == models.UserRoleViewer {
+			c.JSON(http.StatusForbidden, errorBody(c, "forbidden", "viewers have read-only access"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
 // RequireCompany is a shorthand for requiring company organization type
 func RequireCompany() gin.HandlerFunc {
 	return RequireOrgType(models.OrganizationTypeCompany)
@@ -2,6 +2,12 @@
 package middleware
 
 import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -26,6 +32,17 @@ func RequestID() gin.HandlerFunc {
 	}
 }
 
+// errorBody builds an error response body in the shape of handlers.ErrorResponse, stamped with the request ID
+// #IMPLEMENTATION_DECISION: Codes here are the same stable codes as in the apierrors catalog; the
+// package is not imported to keep middleware free of service dependencies
+func errorBody(c *gin.Context, code, message string) gin.H {
+	body := gin.H{"error": code, "message": message}
+	if requestID := GetRequestID(c); requestID != "" {
+		body["request_id"] = requestID
+	}
+	return body
+}
+
 // GetRequestID extracts the request ID from context
 func GetRequestID(c *gin.Context) string {
 	if requestIDVal, exists := c.Get(ContextKeyRequestID); exists {
@@ -36,41 +53,141 @@ func GetRequestID(c *gin.Context) string {
 	return ""
 }
 
+// CORSConfig configures the CORS middleware
+type CORSConfig struct {
+	// AllowedOrigins lists exact origins (scheme://host[:port]); "*" allows any origin without credentials
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	ExposedHeaders []string
+	// AllowCredentials lets browsers send cookies and read responses to credentialed requests
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight result; 0 omits the header
+	MaxAge time.Duration
+}
+
 // CORS configures Cross-Origin Resource Sharing
-// #IMPLEMENTATION_DECISION: Configurable allowed origins for security
-func CORS(allowedOrigins []string) gin.HandlerFunc {
+// #SECURITY_ASSUMPTION: The request origin is echoed only when it is on the allowlist; "*" is
+// only ever sent without credentials, since browsers reject that combination
+func CORS(cfg CORSConfig) gin.HandlerFunc {
 	originsMap := make(map[string]bool)
-	for _, origin := range allowedOrigins {
-		originsMap[origin] = true
+	allowAny := false
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			allowAny = true
+			continue
+		}
+		originsMap[normalizeOrigin(origin)] = true
 	}
 
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+	exposed := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
 	return func(c *gin.Context) {
 		origin := c.GetHeader("Origin")
+		if origin == "" {
+			// Not a cross-origin request
+			if c.Request.Method == http.MethodOptions {
+				c.AbortWithStatus(http.StatusNoContent)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		// Responses differ by origin, so caches must key on it
+		c.Writer.Header().Add("Vary", "Origin")
 
-		// Check if origin is allowed
-		if originsMap[origin] || (len(allowedOrigins) == 1 && allowedOrigins[0] == "*") {
+		switch {
+		case originsMap[normalizeOrigin(origin)]:
 			c.Header("Access-Control-Allow-Origin", origin)
+			if cfg.AllowCredentials {
+				c.Header("Access-Control-Allow-Credentials", "true")
+			}
+		case allowAny && !cfg.AllowCredentials:
+			c.Header("Access-Control-Allow-Origin", "*")
+		default:
+			if c.Request.Method == http.MethodOptions {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			// The browser blocks the response for a disallowed origin; same-origin tools still work
+			c.Next()
+			return
+		}
+
+		if c.Request.Method == http.MethodOptions {
+			c.Header("Access-Control-Allow-Methods", methods)
+			c.Header("Access-Control-Allow-Headers", headers)
+			if cfg.MaxAge > 0 {
+				c.Header("Access-Control-Max-Age", maxAge)
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
 		}
 
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, Authorization, X-Request-ID")
-		c.Header("Access-Control-Expose-Headers", "Content-Length, X-Request-ID")
-		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Max-Age", "86400")
+		if exposed != "" {
+			c.Header("Access-Control-Expose-Headers", exposed)
+		}
+		c.Next()
+	}
+}
+
+// normalizeOrigin lower-cases an origin and drops a trailing slash for comparison
+func normalizeOrigin(origin string) string {
+	return strings.ToLower(strings.TrimSuffix(origin, "/"))
+}
+
+// MaxBodySize rejects request bodies larger than limit bytes with 413
+// #SECURITY_ASSUMPTION: Handlers that read whole bodies (io.ReadAll, multipart) cannot be made to
+// buffer more than the limit; bodies without a Content-Length fail on read once the limit is hit
+func MaxBodySize(limit int64) gin.HandlerFunc {
+	return MaxBodySizeByRoute(limit, nil)
+}
 
-		// Handle preflight requests
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+// MaxBodySizeByRoute is MaxBodySize with larger or smaller limits for specific routes, keyed by
+// route template (e.g. "/api/v1/templates/import")
+// #IMPLEMENTATION_DECISION: Overrides are resolved in the global middleware because a route-level
+// limit would run after, and nest inside, the global one
+func MaxBodySizeByRoute(limit int64, routes map[string]int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		effective := limit
+		if routeLimit, ok := routes[c.FullPath()]; ok {
+			effective = routeLimit
+		}
+
+		if c.Request.ContentLength > effective {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, errorBody(c, "request_too_large", fmt.Sprintf("Request body exceeds %d bytes", effective)))
 			return
 		}
 
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, effective)
 		c.Next()
 	}
 }
 
-// Logger provides structured logging middleware
+// Log output formats for the request logger
+const (
+	LogFormatPretty = "pretty"
+	LogFormatJSON   = "json"
+)
+
+// NewRequestLogger returns the logger for request entries in the given format;
+// nil selects the human-readable line format
+func NewRequestLogger(format string, w io.Writer) *slog.Logger {
+	if format != LogFormatJSON {
+		return nil
+	}
+	return slog.New(slog.NewJSONHandler(w, nil))
+}
+
+// Logger provides request logging middleware
 // #IMPLEMENTATION_DECISION: Log request details for debugging and monitoring
-func Logger() gin.HandlerFunc {
+// #INTEGRATION_POINT: With a non-nil logger each request is one structured entry keyed by
+// request_id, so log pipelines can correlate it with the X-Request-ID response header
+func Logger(logger *slog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Start timer
 		start := time.Now()
@@ -96,11 +213,37 @@ func Logger() gin.HandlerFunc {
 			path = path + "?" + raw
 		}
 
+		if logger != nil {
+			attrs := []slog.Attr{
+				slog.String("method", method),
+				slog.String("path", path),
+				slog.Int("status", statusCode),
+				slog.Float64("latency_ms", float64(latency.Microseconds())/1000),
+				slog.String("request_id", requestID),
+				slog.String("client_ip", clientIP),
+				slog.Int("bytes", bodySize),
+			}
+			if orgID, ok := GetOrgID(c); ok {
+				attrs = append(attrs, slog.String("org_id", orgID.Hex()))
+			}
+			if userID, ok := GetUserID(c); ok {
+				attrs = append(attrs, slog.String("user_id", userID.Hex()))
+			}
+			logger.LogAttrs(c.Request.Context(), statusLevel(statusCode), "request", attrs...)
+			return
+		}
+
+		// Short request ID keeps the line readable; client-supplied IDs may be shorter
+		shortID := requestID
+		if len(shortID) > 8 {
+			shortID = shortID[:8]
+		}
+
 		// Log entry using Gin's default logger format with additions
 		//nolint:errcheck // Logging errors should not crash the request
 		gin.DefaultWriter.Write([]byte(
 			time.Now().Format("2006/01/02 - 15:04:05") + " | " +
-				requestID[:8] + " | " +
+				shortID + " | " +
 				statusString(statusCode) + " | " +
 				latency.String() + " | " +
 				clientIP + " | " +
@@ -111,6 +254,18 @@ func Logger() gin.HandlerFunc {
 	}
 }
 
+// statusLevel maps a response status to a log level
+func statusLevel(code int) slog.Level {
+	switch {
+	case code >= 500:
+		return slog.LevelError
+	case code >= 400:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
 // statusString returns colored status code
 func statusString(code int) string {
 	switch {
@@ -156,13 +311,7 @@ func intToString(i int) string {
 // #IMPLEMENTATION_DECISION: Custom recovery with request ID for debugging
 func Recovery() gin.HandlerFunc {
 	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
-		requestID := GetRequestID(c)
-
-		c.JSON(500, gin.H{
-			"error":      "internal_server_error",
-			"message":    "An unexpected error occurred",
-			"request_id": requestID,
-		})
+		c.JSON(http.StatusInternalServerError, errorBody(c, "internal_error", "An unexpected error occurred"))
 	})
 }
 
@@ -179,53 +328,3 @@ func SecureHeaders() gin.HandlerFunc {
 		c.Next()
 	}
 }
-
-// RateLimiter provides basic rate limiting
-// #IMPLEMENTATION_DECISION: Simple in-memory rate limiting
-// #TECHNICAL_DEBT: Should use Redis for distributed rate limiting
-type RateLimiter struct {
-	requests map[string][]time.Time
-	limit    int
-	window   time.Duration
-}
-
-// NewRateLimiter creates a new rate limiter
-func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
-	return &RateLimiter{
-		requests: make(map[string][]time.Time),
-		limit:    limit,
-		window:   window,
-	}
-}
-
-// RateLimit middleware function
-func (rl *RateLimiter) RateLimit() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		clientIP := c.ClientIP()
-		now := time.Now()
-
-		// Clean old entries
-		windowStart := now.Add(-rl.window)
-		var validRequests []time.Time
-		for _, t := range rl.requests[clientIP] {
-			if t.After(windowStart) {
-				validRequests = append(validRequests, t)
-			}
-		}
-
-		// Check limit
-		if len(validRequests) >= rl.limit {
-			c.JSON(429, gin.H{
-				"error":   "too_many_requests",
-				"message": "Rate limit exceeded. Please try again later.",
-			})
-			c.Abort()
-			return
-		}
-
-		// Add current request
-		rl.requests[clientIP] = append(validRequests, now)
-
-		c.Next()
-	}
-}
@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+// Idempotency headers
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+const (
+	maxIdempotencyKeyLength = 255
+	idempotencyStoreTimeout = 5 * time.Second
+)
+
+// IdempotencyStore reserves idempotency keys and records the responses they produced
+// #INTEGRATION_POINT: Implemented by repository.IdempotencyRepository
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, key *models.IdempotencyKey) (*models.IdempotencyKey, error)
+	Complete(ctx context.Context, id primitive.ObjectID, statusCode int, contentType string, body []byte, resourceID string) error
+	Release(ctx context.Context, id primitive.ObjectID) error
+}
+
+// Idempotency replays the original response when a create request is retried with the same
+// Idempotency-Key header. Must run after the auth middleware.
+// #BUSINESS_RULE: Keys are scoped per organization and route and live for ttl. Only successful
+// responses are stored; a failed request releases its key so the client can retry it.
+// #SECURITY_ASSUMPTION: A key reused with a different body is rejected rather than replayed
+func Idempotency(store IdempotencyStore, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorBody(c, "invalid_idempotency_key", "Idempotency-Key must be at most 255 characters"))
+			return
+		}
+
+		orgID, ok := GetOrgID(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorBody(c, "invalid_request", "Failed to read request body"))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		hash := sha256.Sum256(body)
+
+		record := &models.IdempotencyKey{
+			OrganizationID: orgID,
+			Route:          c.Request.Method + " " + c.FullPath(),
+			Key:            key,
+			RequestHash:    hex.EncodeToString(hash[:]),
+			ExpiresAt:      time.Now().UTC().Add(ttl),
+		}
+
+		existing, err := store.Reserve(c.Request.Context(), record)
+		if err != nil {
+			// Fail open: a store outage must not block creating resources
+			c.Next()
+			return
+		}
+
+		if existing != nil {
+			switch {
+			case existing.RequestHash != record.RequestHash:
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, errorBody(c, "idempotency_key_reused", "Idempotency-Key was already used with a different request"))
+			case !existing.Completed:
+				c.AbortWithStatusJSON(http.StatusConflict, errorBody(c, "request_in_progress", "A request with this Idempotency-Key is still being processed"))
+			default:
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(existing.StatusCode, existing.ContentType, existing.ResponseBody)
+				c.Abort()
+			}
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// The client may have gone away; the outcome must still be recorded
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), idempotencyStoreTimeout)
+		defer cancel()
+
+		status := recorder.Status()
+		if status < 200 || status >= 300 {
+			store.Release(ctx, record.ID) //nolint:errcheck // the key expires via TTL otherwise
+			return
+		}
+		body = recorder.body.Bytes()
+		store.Complete(ctx, record.ID, status, recorder.Header().Get("Content-Type"), body, resourceIDFromBody(body)) //nolint:errcheck // best-effort
+	}
+}
+
+// responseRecorder copies the response body while writing it to the client
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write implements io.Writer
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString implements io.StringWriter
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// resourceIDFromBody returns the "id" of a JSON response body, if any
+func resourceIDFromBody(body []byte) string {
+	var resource struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &resource); err != nil {
+		return ""
+	}
+	return resource.ID
+}
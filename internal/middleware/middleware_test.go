@@ -1,15 +1,23 @@
 package middleware
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/checkfix-tools/nisfix_backend/internal/auth"
+	"github.com/checkfix-tools/nisfix_backend/internal/metrics"
 	"github.com/checkfix-tools/nisfix_backend/internal/models"
 )
 
@@ -41,6 +49,10 @@ func (m *MockJWTService) GenerateTokenPair(userID, orgID, role, orgType string)
 	}, nil
 }
 
+func (m *MockJWTService) RotateTokenPair(userID, orgID, role, orgType, familyID string, _ auth.TokenExpiry) (*auth.TokenPair, error) {
+	return m.GenerateTokenPair(userID, orgID, role, orgType)
+}
+
 func (m *MockJWTService) ValidateAccessToken(tokenString string) (*auth.Claims, error) {
 	if m.ExpiredError {
 		return nil, auth.ErrTokenExpired
@@ -59,6 +71,15 @@ func (m *MockJWTService) ValidateRefreshToken(tokenString string) (*auth.Refresh
 	return nil, auth.ErrInvalidToken
 }
 
+// MockTokenDenylist implements TokenDenylist for testing
+type MockTokenDenylist struct {
+	RevokedIDs map[string]bool
+}
+
+func (m *MockTokenDenylist) IsRevoked(ctx context.Context, tokenID string, userID primitive.ObjectID, issuedAt time.Time) (bool, error) {
+	return m.RevokedIDs[tokenID], nil
+}
+
 func TestAuthMiddleware_ValidToken(t *testing.T) {
 	mockJWT := &MockJWTService{
 		ValidToken: "valid-token",
@@ -71,7 +92,7 @@ func TestAuthMiddleware_ValidToken(t *testing.T) {
 	}
 
 	router := gin.New()
-	router.Use(AuthMiddleware(mockJWT))
+	router.Use(AuthMiddleware(mockJWT, nil))
 	router.GET("/test", func(c *gin.Context) {
 		c.Status(http.StatusOK)
 	})
@@ -87,11 +108,42 @@ func TestAuthMiddleware_ValidToken(t *testing.T) {
 	}
 }
 
+func TestAuthMiddleware_RevokedToken(t *testing.T) {
+	claims := &auth.Claims{
+		UserID:  primitive.NewObjectID().Hex(),
+		OrgID:   primitive.NewObjectID().Hex(),
+		Role:    "ADMIN",
+		OrgType: "COMPANY",
+	}
+	claims.ID = "revoked-jti"
+	mockJWT := &MockJWTService{
+		ValidToken:  "valid-token",
+		ValidClaims: claims,
+	}
+	denylist := &MockTokenDenylist{RevokedIDs: map[string]bool{"revoked-jti": true}}
+
+	router := gin.New()
+	router.Use(AuthMiddleware(mockJWT, denylist))
+	router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/test", http.NoBody)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
 func TestAuthMiddleware_MissingHeader(t *testing.T) {
 	mockJWT := &MockJWTService{}
 
 	router := gin.New()
-	router.Use(AuthMiddleware(mockJWT))
+	router.Use(AuthMiddleware(mockJWT, nil))
 	router.GET("/test", func(c *gin.Context) {
 		c.Status(http.StatusOK)
 	})
@@ -110,7 +162,7 @@ func TestAuthMiddleware_InvalidFormat(t *testing.T) {
 	mockJWT := &MockJWTService{}
 
 	router := gin.New()
-	router.Use(AuthMiddleware(mockJWT))
+	router.Use(AuthMiddleware(mockJWT, nil))
 	router.GET("/test", func(c *gin.Context) {
 		c.Status(http.StatusOK)
 	})
@@ -145,7 +197,7 @@ func TestAuthMiddleware_InvalidToken(t *testing.T) {
 	}
 
 	router := gin.New()
-	router.Use(AuthMiddleware(mockJWT))
+	router.Use(AuthMiddleware(mockJWT, nil))
 	router.GET("/test", func(c *gin.Context) {
 		c.Status(http.StatusOK)
 	})
@@ -167,7 +219,7 @@ func TestAuthMiddleware_ExpiredToken(t *testing.T) {
 	}
 
 	router := gin.New()
-	router.Use(AuthMiddleware(mockJWT))
+	router.Use(AuthMiddleware(mockJWT, nil))
 	router.GET("/test", func(c *gin.Context) {
 		c.Status(http.StatusOK)
 	})
@@ -194,7 +246,7 @@ func TestOptionalAuthMiddleware_WithToken(t *testing.T) {
 
 	var capturedUserID string
 	router := gin.New()
-	router.Use(OptionalAuthMiddleware(mockJWT))
+	router.Use(OptionalAuthMiddleware(mockJWT, nil))
 	router.GET("/test", func(c *gin.Context) {
 		if userID, exists := c.Get(ContextKeyUserID); exists {
 			capturedUserID = userID.(string)
@@ -221,7 +273,7 @@ func TestOptionalAuthMiddleware_WithoutToken(t *testing.T) {
 
 	var capturedUserID string
 	router := gin.New()
-	router.Use(OptionalAuthMiddleware(mockJWT))
+	router.Use(OptionalAuthMiddleware(mockJWT, nil))
 	router.GET("/test", func(c *gin.Context) {
 		if userID, exists := c.Get(ContextKeyUserID); exists {
 			capturedUserID = userID.(string)
@@ -301,6 +353,34 @@ func TestRequireRole_NoRole(t *testing.T) {
 	}
 }
 
+func TestRequireAdmin_ViewerTokenDenied(t *testing.T) {
+	mockJWT := &MockJWTService{
+		ValidToken: "viewer-token",
+		ValidClaims: &auth.Claims{
+			UserID:  primitive.NewObjectID().Hex(),
+			OrgID:   primitive.NewObjectID().Hex(),
+			Role:    "VIEWER",
+			OrgType: "COMPANY",
+		},
+	}
+
+	router := gin.New()
+	router.Use(AuthMiddleware(mockJWT, nil))
+	router.POST("/test", RequireAdmin(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest("POST", "/test", http.NoBody)
+	req.Header.Set("Authorization", "Bearer viewer-token")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d, got %d", http.StatusForbidden, w.Code)
+	}
+}
+
 func TestRequireOrgType_Allowed(t *testing.T) {
 	router := gin.New()
 	router.Use(func(c *gin.Context) {
@@ -390,9 +470,53 @@ func TestRequestID_UsesExisting(t *testing.T) {
 	}
 }
 
+func TestLogger_JSONIncludesRequestContext(t *testing.T) {
+	var buf bytes.Buffer
+	orgID := primitive.NewObjectID()
+
+	router := gin.New()
+	router.Use(RequestID())
+	router.Use(Logger(NewRequestLogger(LogFormatJSON, &buf)))
+	router.GET("/test", func(c *gin.Context) {
+		c.Set(ContextKeyOrgID, orgID.Hex())
+		c.Status(http.StatusNotFound)
+	})
+
+	req := httptest.NewRequest("GET", "/test?x=1", http.NoBody)
+	req.Header.Set("X-Request-ID", "req-123")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected one JSON log entry, got %q: %v", buf.String(), err)
+	}
+
+	want := map[string]interface{}{
+		"level":      "WARN",
+		"method":     "GET",
+		"path":       "/test?x=1",
+		"status":     float64(http.StatusNotFound),
+		"request_id": "req-123",
+		"org_id":     orgID.Hex(),
+	}
+	for key, value := range want {
+		if entry[key] != value {
+			t.Errorf("Expected %s=%v, got %v", key, value, entry[key])
+		}
+	}
+	if _, ok := entry["latency_ms"]; !ok {
+		t.Error("Expected latency_ms field")
+	}
+	if _, ok := entry["user_id"]; ok {
+		t.Error("Expected no user_id for an unauthenticated request")
+	}
+}
+
 func TestCORS_AllowedOrigin(t *testing.T) {
 	router := gin.New()
-	router.Use(CORS([]string{"http://localhost:3000"}))
+	router.Use(CORS(CORSConfig{AllowedOrigins: []string{"http://localhost:3000"}}))
 	router.GET("/test", func(c *gin.Context) {
 		c.Status(http.StatusOK)
 	})
@@ -410,7 +534,7 @@ func TestCORS_AllowedOrigin(t *testing.T) {
 
 func TestCORS_Preflight(t *testing.T) {
 	router := gin.New()
-	router.Use(CORS([]string{"*"}))
+	router.Use(CORS(CORSConfig{AllowedOrigins: []string{"*"}}))
 	router.GET("/test", func(c *gin.Context) {
 		c.Status(http.StatusOK)
 	})
@@ -426,6 +550,123 @@ func TestCORS_Preflight(t *testing.T) {
 	}
 }
 
+func TestCORS_PreflightHandling(t *testing.T) {
+	cfg := CORSConfig{
+		AllowedOrigins:   []string{"https://app.nisfix.io"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		ExposedHeaders:   []string{"X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+
+	tests := []struct {
+		name            string
+		method          string
+		origin          string
+		wantStatus      int
+		wantAllowOrigin string
+		wantPreflight   bool
+	}{
+		{"allowed preflight", "OPTIONS", "https://app.nisfix.io", http.StatusNoContent, "https://app.nisfix.io", true},
+		{"disallowed preflight", "OPTIONS", "https://evil.example", http.StatusForbidden, "", false},
+		{"allowed request", "GET", "https://app.nisfix.io", http.StatusOK, "https://app.nisfix.io", false},
+		{"disallowed request", "GET", "https://evil.example", http.StatusOK, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(CORS(cfg))
+			router.GET("/test", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/test", http.NoBody)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", "POST")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantAllowOrigin {
+				t.Errorf("Expected Allow-Origin %q, got %q", tt.wantAllowOrigin, got)
+			}
+			if tt.wantAllowOrigin != "" && w.Header().Get("Access-Control-Allow-Credentials") != "true" {
+				t.Error("Expected credentials to be allowed for an allowlisted origin")
+			}
+			if got := w.Header().Get("Access-Control-Max-Age") != ""; got != tt.wantPreflight {
+				t.Errorf("Expected Max-Age present=%v, got %v", tt.wantPreflight, got)
+			}
+			if tt.wantPreflight && w.Header().Get("Access-Control-Allow-Methods") != "GET, POST" {
+				t.Errorf("Unexpected Allow-Methods %q", w.Header().Get("Access-Control-Allow-Methods"))
+			}
+		})
+	}
+}
+
+func TestCORS_WildcardNeverEchoedWithCredentials(t *testing.T) {
+	router := gin.New()
+	router.Use(CORS(CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}))
+	router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/test", http.NoBody)
+	req.Header.Set("Origin", "https://evil.example")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Expected no Allow-Origin for a wildcard with credentials, got %q", got)
+	}
+}
+
+func TestMaxBodySizeByRoute(t *testing.T) {
+	router := gin.New()
+	router.Use(MaxBodySizeByRoute(16, map[string]int64{"/import": 64}))
+	echo := func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+	router.POST("/data", echo)
+	router.POST("/import", echo)
+
+	tests := []struct {
+		name       string
+		path       string
+		size       int
+		chunked    bool
+		wantStatus int
+	}{
+		{"within default", "/data", 16, false, http.StatusOK},
+		{"oversized body", "/data", 17, false, http.StatusRequestEntityTooLarge},
+		{"oversized chunked body", "/data", 17, true, http.StatusBadRequest},
+		{"within route override", "/import", 64, false, http.StatusOK},
+		{"oversized for route override", "/import", 65, false, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", tt.path, bytes.NewReader(bytes.Repeat([]byte("x"), tt.size)))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
 func TestSecureHeaders(t *testing.T) {
 	router := gin.New()
 	router.Use(SecureHeaders())
@@ -506,6 +747,107 @@ func TestRateLimiter(t *testing.T) {
 	}
 }
 
+func TestRateLimiter_RetryAfterPerIP(t *testing.T) {
+	limiter := NewRateLimiter(1, time.Minute)
+
+	router := gin.New()
+	router.GET("/test", limiter.RateLimit(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	send := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/test", http.NoBody)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send("10.0.0.1:1234"); w.Code != http.StatusOK {
+		t.Fatalf("First request: expected %d, got %d", http.StatusOK, w.Code)
+	}
+
+	w := send("10.0.0.1:1234")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Second request: expected %d, got %d", http.StatusTooManyRequests, w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Expected Retry-After header on rate limited response")
+	}
+
+	if w := send("10.0.0.2:1234"); w.Code != http.StatusOK {
+		t.Errorf("Other IP: expected %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestOrgRateLimit(t *testing.T) {
+	jwtService := &MockJWTService{ValidToken: "org-a", ValidClaims: &auth.Claims{OrgID: primitive.NewObjectID().Hex()}}
+	var logs bytes.Buffer
+
+	router := gin.New()
+	router.Use(OrgRateLimit(NewMemoryRateLimitStore(), jwtService, 2, slog.New(slog.NewJSONHandler(&logs, nil))))
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/api/v1/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	send := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, http.NoBody)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := send("/api/v1/test", "org-a"); w.Code != http.StatusOK {
+			t.Fatalf("Request %d: expected %d, got %d", i+1, http.StatusOK, w.Code)
+		}
+	}
+
+	w := send("/api/v1/test", "org-a")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Over budget: expected %d, got %d", http.StatusTooManyRequests, w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Expected Retry-After header on rate limited response")
+	}
+	if !strings.Contains(logs.String(), jwtService.ValidClaims.OrgID) {
+		t.Errorf("Expected the org ID in the log line, got %q", logs.String())
+	}
+
+	// Health checks and unauthenticated requests are not counted against the organization
+	if w := send("/health", "org-a"); w.Code != http.StatusOK {
+		t.Errorf("Health: expected %d, got %d", http.StatusOK, w.Code)
+	}
+	if w := send("/api/v1/test", ""); w.Code != http.StatusOK {
+		t.Errorf("Unauthenticated: expected %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestMetrics_LabelsByRouteTemplate(t *testing.T) {
+	router := gin.New()
+	router.Use(Metrics())
+	router.GET("/items/:id", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	counter := metrics.HTTPRequestsTotal.WithLabelValues("GET", "/items/:id", "204")
+	before := testutil.ToFloat64(counter)
+
+	for _, path := range []string{"/items/1", "/items/2"} {
+		req := httptest.NewRequest("GET", path, http.NoBody)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if got := testutil.ToFloat64(counter) - before; got != 2 {
+		t.Errorf("Expected 2 requests recorded for route template, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.HTTPRequestsInFlight.WithLabelValues("GET", "/items/:id")); got != 0 {
+		t.Errorf("Expected no in-flight requests after completion, got %v", got)
+	}
+}
+
 func TestGetUserID_Valid(t *testing.T) {
 	expectedID := primitive.NewObjectID()
 
@@ -645,3 +987,151 @@ func TestBytesString(t *testing.T) {
 		}
 	}
 }
+
+// MockAPIKeyAuthenticator implements APIKeyAuthenticator for testing
+type MockAPIKeyAuthenticator struct {
+	Key *models.APIKey
+}
+
+func (m *MockAPIKeyAuthenticator) AuthenticateAPIKey(ctx context.Context, plaintext string) (*models.APIKey, *models.Organization, error) {
+	if m.Key == nil || plaintext != "nfx_valid" {
+		return nil, nil, ErrInvalidToken
+	}
+	return m.Key, &models.Organization{Type: models.OrganizationTypeSupplier}, nil
+}
+
+func TestAPIKeyAuth_RequireScope(t *testing.T) {
+	authenticator := &MockAPIKeyAuthenticator{
+		Key: &models.APIKey{
+			ID:             primitive.NewObjectID(),
+			OrganizationID: primitive.NewObjectID(),
+			CreatedBy:      primitive.NewObjectID(),
+			Role:           models.UserRoleAdmin,
+			Scopes:         []models.APIKeyScope{models.APIKeyScopeCheckFixRead},
+		},
+	}
+
+	router := gin.New()
+	router.Use(APIKeyAuth(authenticator, AuthMiddleware(&MockJWTService{}, nil)))
+	router.GET("/read", RequireScope(models.APIKeyScopeCheckFixRead), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.POST("/submit", RequireScope(models.APIKeyScopeCheckFixSubmit), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.POST("/link", RequireUserSession(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		key    string
+		want   int
+	}{
+		{"scope granted", "GET", "/read", "nfx_valid", http.StatusOK},
+		{"scope missing", "POST", "/submit", "nfx_valid", http.StatusForbidden},
+		{"user session required", "POST", "/link", "nfx_valid", http.StatusForbidden},
+		{"invalid key", "GET", "/read", "nfx_invalid", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			req.Header.Set("Authorization", "ApiKey "+tt.key)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("Expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+// memoryIdempotencyStore keeps idempotency keys in memory
+type memoryIdempotencyStore struct {
+	keys map[string]*models.IdempotencyKey
+}
+
+func (s *memoryIdempotencyStore) Reserve(_ context.Context, key *models.IdempotencyKey) (*models.IdempotencyKey, error) {
+	id := key.OrganizationID.Hex() + key.Route + key.Key
+	if existing, ok := s.keys[id]; ok {
+		return existing, nil
+	}
+	key.BeforeCreate()
+	s.keys[id] = key
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, id primitive.ObjectID, statusCode int, contentType string, body []byte, resourceID string) error {
+	for _, k := range s.keys {
+		if k.ID == id {
+			k.Completed, k.StatusCode, k.ContentType, k.ResponseBody, k.ResourceID = true, statusCode, contentType, body, resourceID
+		}
+	}
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, id primitive.ObjectID) error {
+	for name, k := range s.keys {
+		if k.ID == id {
+			delete(s.keys, name)
+		}
+	}
+	return nil
+}
+
+func TestIdempotency(t *testing.T) {
+	store := &memoryIdempotencyStore{keys: map[string]*models.IdempotencyKey{}}
+	orgID := primitive.NewObjectID()
+	created := 0
+
+	router := gin.New()
+	router.POST("/suppliers", func(c *gin.Context) {
+		c.Set(ContextKeyOrgID, orgID.Hex())
+		c.Next()
+	}, Idempotency(store, time.Hour), func(c *gin.Context) {
+		created++
+		c.JSON(http.StatusCreated, gin.H{"id": "supplier-1"})
+	})
+
+	post := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/suppliers", bytes.NewBufferString(body))
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post("key-1", `{"email":"a@example.com"}`)
+	replay := post("key-1", `{"email":"a@example.com"}`)
+	if first.Code != http.StatusCreated || replay.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 twice, got %d and %d", first.Code, replay.Code)
+	}
+	if created != 1 {
+		t.Errorf("Expected the handler to run once, ran %d times", created)
+	}
+	if replay.Body.String() != first.Body.String() || replay.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Errorf("Expected replay of %q, got %q", first.Body.String(), replay.Body.String())
+	}
+	for _, k := range store.keys {
+		if k.ResourceID != "supplier-1" {
+			t.Errorf("Expected resource ID supplier-1, got %q", k.ResourceID)
+		}
+	}
+
+	if w := post("key-1", `{"email":"b@example.com"}`); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for a reused key, got %d", w.Code)
+	}
+	if post("key-2", `{"email":"a@example.com"}`); created != 2 {
+		t.Errorf("Expected a new key to create again")
+	}
+	if post("", `{"email":"a@example.com"}`); created != 3 {
+		t.Errorf("Expected requests without a key to pass through")
+	}
+}
@@ -0,0 +1,198 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/auth"
+)
+
+// RateLimitStore records hits per key and decides whether a key is over budget
+// #IMPLEMENTATION_DECISION: Storage is behind an interface so a shared (e.g. Redis) store
+// can replace the in-memory one when running multiple instances
+type RateLimitStore interface {
+	// Allow records a hit for key and reports whether it is within limit for the window.
+	// When the hit is rejected, retryAfter is the time until the oldest hit leaves the window.
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// MemoryRateLimitStore is a sliding-window log kept in process memory
+// #TECHNICAL_DEBT: Counters are per instance; use a shared store for distributed rate limiting
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	hits      map[string][]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryRateLimitStore creates an empty in-memory rate limit store
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		hits:      make(map[string][]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow implements RateLimitStore
+func (s *MemoryRateLimitStore) Allow(_ context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	windowStart := now.Add(-window)
+
+	// Drop keys that have gone quiet so one-off clients do not accumulate forever
+	if now.Sub(s.lastSweep) > window {
+		for k, times := range s.hits {
+			if len(times) == 0 || !times[len(times)-1].After(windowStart) {
+				delete(s.hits, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	valid := s.hits[key][:0]
+	for _, t := range s.hits[key] {
+		if t.After(windowStart) {
+			valid = append(valid, t)
+		}
+	}
+
+	if len(valid) >= limit {
+		s.hits[key] = valid
+		return false, valid[0].Add(window).Sub(now), nil
+	}
+
+	s.hits[key] = append(valid, now)
+	return true, 0, nil
+}
+
+var _ RateLimitStore = (*MemoryRateLimitStore)(nil)
+
+// RateLimiter limits requests per client IP
+// #SECURITY_ASSUMPTION: c.ClientIP() only honours X-Forwarded-For from the router's
+// trusted proxies (gin.Engine.SetTrustedProxies); otherwise the socket address is used
+type RateLimiter struct {
+	store  RateLimitStore
+	limit  int
+	window time.Duration
+}
+
+// NewRateLimiter creates a new rate limiter backed by an in-memory store
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return NewRateLimiterWithStore(NewMemoryRateLimitStore(), limit, window)
+}
+
+// NewRateLimiterWithStore creates a new rate limiter backed by the given store
+func NewRateLimiterWithStore(store RateLimitStore, limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		store:  store,
+		limit:  limit,
+		window: window,
+	}
+}
+
+// RateLimit middleware function
+// #IMPLEMENTATION_DECISION: Budgets are per route and IP, so verify attempts do not
+// consume the magic-link request budget of the same client
+func (rl *RateLimiter) RateLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.FullPath() + "|" + c.ClientIP()
+
+		allowed, retryAfter, err := rl.store.Allow(c.Request.Context(), key, rl.limit, rl.window)
+		if err != nil {
+			// Fail open: a store outage must not lock every user out of login
+			c.Next()
+			return
+		}
+
+		if !allowed {
+			abortRateLimited(c, retryAfter)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// orgRateLimitSkipPrefixes are infrastructure routes never counted against an organization
+var orgRateLimitSkipPrefixes = []string{"/health", "/metrics"}
+
+// OrgRateLimit limits authenticated requests per organization to perMinute requests
+// #BUSINESS_RULE: Protects shared capacity from a single noisy tenant; unauthenticated requests
+// are left to the per-IP limits and the auth middleware
+// #IMPLEMENTATION_DECISION: The org ID is read from the bearer token so the limiter can run on
+// the whole API group ahead of the per-route auth middleware; a token that fails validation is
+// not counted here and is rejected by auth afterwards
+// #SECURITY_ASSUMPTION: A nil logger falls back to slog.Default
+func OrgRateLimit(store RateLimitStore, jwtService auth.JWTService, perMinute int, logger *slog.Logger) gin.HandlerFunc {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(c *gin.Context) {
+		for _, prefix := range orgRateLimitSkipPrefixes {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		orgID := orgIDFromBearer(c, jwtService)
+		if orgID == "" {
+			c.Next()
+			return
+		}
+
+		allowed, retryAfter, err := store.Allow(c.Request.Context(), "org|"+orgID, perMinute, time.Minute)
+		if err != nil {
+			// Fail open: a store outage must not take the API down
+			c.Next()
+			return
+		}
+
+		if !allowed {
+			logger.LogAttrs(c.Request.Context(), slog.LevelWarn, "organization rate limit exceeded",
+				slog.String("org_id", orgID),
+				slog.String("method", c.Request.Method),
+				slog.String("path", c.Request.URL.Path),
+				slog.String("request_id", GetRequestID(c)),
+			)
+			abortRateLimited(c, retryAfter)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// orgIDFromBearer returns the organization of the bearer token, or "" when there is no valid token
+func orgIDFromBearer(c *gin.Context, jwtService auth.JWTService) string {
+	if orgID := c.GetString(ContextKeyOrgID); orgID != "" {
+		return orgID
+	}
+
+	parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+		return ""
+	}
+
+	claims, err := jwtService.ValidateAccessToken(parts[1])
+	if err != nil {
+		return ""
+	}
+	return claims.OrgID
+}
+
+// abortRateLimited rejects the request with 429 and a Retry-After header
+func abortRateLimited(c *gin.Context, retryAfter time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, errorBody(c, "too_many_requests", "Rate limit exceeded. Please try again later."))
+}
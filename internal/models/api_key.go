@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APIKeyScope restricts what an API key may be used for
+// #IMPLEMENTATION_DECISION: Scopes are "resource:action" strings checked per route
+type APIKeyScope string
+
+const (
+	APIKeyScopeCheckFixRead   APIKeyScope = "checkfix:read"
+	APIKeyScopeCheckFixSubmit APIKeyScope = "checkfix:submit"
+)
+
+// IsValid checks if the APIKeyScope is a valid value
+func (s APIKeyScope) IsValid() bool {
+	switch s {
+	case APIKeyScopeCheckFixRead, APIKeyScopeCheckFixSubmit:
+		return true
+	}
+	return false
+}
+
+// APIKey represents a machine-to-machine credential for an organization
+// #SECURITY_ASSUMPTION: Only the SHA-256 hash of the key is stored; the plaintext is shown once
+// #DATA_ASSUMPTION: Prefix is the first characters of the key, kept for identification in listings
+type APIKey struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OrganizationID primitive.ObjectID `bson:"organization_id" json:"organization_id"`
+	CreatedBy      primitive.ObjectID `bson:"created_by" json:"created_by"`
+	Name           string             `bson:"name" json:"name"`
+	Prefix         string             `bson:"prefix" json:"prefix"`
+	KeyHash        string             `bson:"key_hash" json:"-"`
+	Role           UserRole           `bson:"role" json:"role"`
+	Scopes         []APIKeyScope      `bson:"scopes" json:"scopes"`
+
+	// Validity
+	ExpiresAt  *time.Time `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+
+	// Audit field
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// CollectionName returns the MongoDB collection name for API keys
+func (APIKey) CollectionName() string {
+	return "api_keys"
+}
+
+// BeforeCreate sets default values before inserting a new API key
+func (k *APIKey) BeforeCreate() {
+	if k.ID.IsZero() {
+		k.ID = primitive.NewObjectID()
+	}
+	k.CreatedAt = time.Now().UTC()
+	if k.Scopes == nil {
+		k.Scopes = []APIKeyScope{}
+	}
+}
+
+// IsExpired returns true if the API key has an expiry in the past
+func (k *APIKey) IsExpired() bool {
+	return k.ExpiresAt != nil && time.Now().UTC().After(*k.ExpiresAt)
+}
+
+// IsRevoked returns true if the API key has been revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IsUsable returns true if the API key can authenticate requests
+func (k *APIKey) IsUsable() bool {
+	return !k.IsRevoked() && !k.IsExpired()
+}
+
+// HasScope returns true if the API key grants the given scope
+func (k *APIKey) HasScope(scope APIKeyScope) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
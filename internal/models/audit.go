@@ -13,22 +13,24 @@ import (
 type AuditAction string
 
 const (
-	AuditActionCreate   AuditAction = "CREATE"
-	AuditActionUpdate   AuditAction = "UPDATE"
-	AuditActionDelete   AuditAction = "DELETE"
-	AuditActionLogin    AuditAction = "LOGIN"
-	AuditActionLogout   AuditAction = "LOGOUT"
-	AuditActionApprove  AuditAction = "APPROVE"
-	AuditActionReject   AuditAction = "REJECT"
-	AuditActionSubmit   AuditAction = "SUBMIT"
-	AuditActionInvite   AuditAction = "INVITE"
-	AuditActionAccept   AuditAction = "ACCEPT"
-	AuditActionDecline  AuditAction = "DECLINE"
-	AuditActionSuspend  AuditAction = "SUSPEND"
-	AuditActionActivate AuditAction = "ACTIVATE"
-	AuditActionVerify   AuditAction = "VERIFY"
-	AuditActionPublish  AuditAction = "PUBLISH"
-	AuditActionArchive  AuditAction = "ARCHIVE"
+	AuditActionCreate    AuditAction = "CREATE"
+	AuditActionUpdate    AuditAction = "UPDATE"
+	AuditActionDelete    AuditAction = "DELETE"
+	AuditActionLogin     AuditAction = "LOGIN"
+	AuditActionLogout    AuditAction = "LOGOUT"
+	AuditActionApprove   AuditAction = "APPROVE"
+	AuditActionReject    AuditAction = "REJECT"
+	AuditActionSubmit    AuditAction = "SUBMIT"
+	AuditActionInvite    AuditAction = "INVITE"
+	AuditActionAccept    AuditAction = "ACCEPT"
+	AuditActionDecline   AuditAction = "DECLINE"
+	AuditActionSuspend   AuditAction = "SUSPEND"
+	AuditActionActivate  AuditAction = "ACTIVATE"
+	AuditActionVerify    AuditAction = "VERIFY"
+	AuditActionPublish   AuditAction = "PUBLISH"
+	AuditActionArchive   AuditAction = "ARCHIVE"
+	AuditActionTerminate AuditAction = "TERMINATE"
+	AuditActionNotify    AuditAction = "NOTIFY"
 )
 
 // MarshalJSON converts AuditAction to lowercase for JSON serialization
@@ -52,7 +54,8 @@ func (a AuditAction) IsValid() bool {
 	case AuditActionCreate, AuditActionUpdate, AuditActionDelete, AuditActionLogin,
 		AuditActionLogout, AuditActionApprove, AuditActionReject, AuditActionSubmit,
 		AuditActionInvite, AuditActionAccept, AuditActionDecline, AuditActionSuspend,
-		AuditActionActivate, AuditActionVerify, AuditActionPublish, AuditActionArchive:
+		AuditActionActivate, AuditActionVerify, AuditActionPublish, AuditActionArchive,
+		AuditActionTerminate, AuditActionNotify:
 		return true
 	}
 	return false
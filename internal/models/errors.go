@@ -11,6 +11,7 @@ var (
 	ErrUnauthorized            = errors.New("unauthorized")
 	ErrForbidden               = errors.New("forbidden")
 	ErrInvalidStatusTransition = errors.New("invalid status transition")
+	ErrInvalidCursor           = errors.New("invalid pagination cursor")
 
 	// Organization errors
 	ErrOrganizationNotFound    = errors.New("organization not found")
@@ -18,6 +19,7 @@ var (
 	ErrInvalidOrganizationType = errors.New("invalid organization type")
 	ErrSlugAlreadyExists       = errors.New("organization slug already exists")
 	ErrDomainAlreadyExists     = errors.New("domain already exists")
+	ErrInvalidSettings         = errors.New("invalid organization settings")
 
 	// User errors
 	ErrUserNotFound       = errors.New("user not found")
@@ -32,6 +34,17 @@ var (
 	ErrSecureLinkUsed     = errors.New("secure link has already been used")
 	ErrSecureLinkInvalid  = errors.New("secure link is invalid")
 
+	// API key errors
+	ErrAPIKeyNotFound     = errors.New("API key not found")
+	ErrInvalidAPIKeyScope = errors.New("invalid API key scope")
+
+	// Webhook errors
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	// Refresh token errors
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenUsed     = errors.New("refresh token has already been used")
+
 	// Questionnaire template errors
 	ErrTemplateNotFound         = errors.New("questionnaire template not found")
 	ErrTemplateNotEditable      = errors.New("template cannot be edited")
@@ -43,6 +56,7 @@ var (
 	ErrTemplateInvalidFormat    = errors.New("invalid template format")
 	ErrTemplateMissingFields    = errors.New("template missing required fields")
 	ErrTemplateInvalidVisibility = errors.New("invalid template visibility")
+	ErrTemplateSuperseded = errors.New("template has been superseded by a newer version")
 
 	// Questionnaire errors
 	ErrQuestionnaireNotFound     = errors.New("questionnaire not found")
@@ -50,12 +64,14 @@ var (
 	ErrQuestionnaireNotPublished = errors.New("questionnaire is not published")
 	ErrQuestionnaireNotEditable  = errors.New("questionnaire cannot be edited (not draft)")
 	ErrQuestionnaireNotDeletable = errors.New("questionnaire cannot be deleted (not draft)")
+	ErrQuestionnaireConflict     = errors.New("questionnaire was modified by another update")
 
 	// Question errors
 	ErrQuestionNotFound       = errors.New("question not found")
 	ErrInvalidQuestionType    = errors.New("invalid question type")
 	ErrMissingQuestionOptions = errors.New("choice questions require options")
 	ErrInvalidOptionID        = errors.New("invalid option ID")
+	ErrOptionPointsOutOfScale = errors.New("option points are outside the scoring scale")
 	ErrInvalidAnswerFormat    = errors.New("invalid answer format")
 
 	// Relationship errors
@@ -71,12 +87,14 @@ var (
 	ErrRequirementNotPending     = errors.New("requirement is not pending")
 	ErrRequirementNotSubmittable = errors.New("requirement cannot be submitted")
 	ErrRequirementNotReviewable  = errors.New("requirement cannot be reviewed")
+	ErrTooManyAttachments        = errors.New("requirement has too many attachments")
 
 	// Response errors
 	ErrResponseNotFound         = errors.New("response not found")
 	ErrResponseAlreadyExists    = errors.New("response already exists for this requirement")
 	ErrResponseNotSubmitted     = errors.New("response has not been submitted")
 	ErrResponseAlreadySubmitted = errors.New("response has already been submitted")
+	ErrResponseConflict         = errors.New("response was modified by another save")
 
 	// Submission errors
 	ErrSubmissionNotFound      = errors.New("submission not found")
@@ -100,6 +118,9 @@ func IsNotFoundError(err error) bool {
 		errors.Is(err, ErrOrganizationNotFound) ||
 		errors.Is(err, ErrUserNotFound) ||
 		errors.Is(err, ErrSecureLinkNotFound) ||
+		errors.Is(err, ErrRefreshTokenNotFound) ||
+		errors.Is(err, ErrAPIKeyNotFound) ||
+		errors.Is(err, ErrWebhookNotFound) ||
 		errors.Is(err, ErrTemplateNotFound) ||
 		errors.Is(err, ErrQuestionnaireNotFound) ||
 		errors.Is(err, ErrQuestionNotFound) ||
@@ -116,10 +137,13 @@ func IsValidationError(err error) bool {
 	return errors.Is(err, ErrInvalidInput) ||
 		errors.Is(err, ErrInvalidStatusTransition) ||
 		errors.Is(err, ErrInvalidOrganizationType) ||
+		errors.Is(err, ErrInvalidSettings) ||
+		errors.Is(err, ErrInvalidAPIKeyScope) ||
 		errors.Is(err, ErrInvalidUserRole) ||
 		errors.Is(err, ErrInvalidQuestionType) ||
 		errors.Is(err, ErrMissingQuestionOptions) ||
 		errors.Is(err, ErrInvalidOptionID) ||
+		errors.Is(err, ErrOptionPointsOutOfScale) ||
 		errors.Is(err, ErrInvalidAnswerFormat) ||
 		errors.Is(err, ErrTemplateInvalidFormat) ||
 		errors.Is(err, ErrTemplateMissingFields) ||
@@ -134,7 +158,8 @@ func IsAuthError(err error) bool {
 		errors.Is(err, ErrUserDeleted) ||
 		errors.Is(err, ErrSecureLinkExpired) ||
 		errors.Is(err, ErrSecureLinkUsed) ||
-		errors.Is(err, ErrSecureLinkInvalid)
+		errors.Is(err, ErrSecureLinkInvalid) ||
+		errors.Is(err, ErrRefreshTokenUsed)
 }
 
 // IsConflictError returns true if the error is a conflict/duplicate error
@@ -145,5 +170,7 @@ func IsConflictError(err error) bool {
 		errors.Is(err, ErrEmailAlreadyExists) ||
 		errors.Is(err, ErrRelationshipExists) ||
 		errors.Is(err, ErrResponseAlreadyExists) ||
+		errors.Is(err, ErrResponseConflict) ||
+		errors.Is(err, ErrQuestionnaireConflict) ||
 		errors.Is(err, ErrSubmissionAlreadyExists)
 }
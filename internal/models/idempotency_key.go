@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// IdempotencyKey records a create request made with an Idempotency-Key header and its outcome
+// #IMPLEMENTATION_DECISION: A key is reserved before the handler runs and completed with the
+// response afterwards, so a concurrent retry sees the reservation instead of creating a duplicate
+// #INDEX_STRATEGY: Unique index on organization_id + route + key; TTL index on expires_at
+type IdempotencyKey struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OrganizationID primitive.ObjectID `bson:"organization_id" json:"organization_id"`
+	Route          string             `bson:"route" json:"route"`
+	Key            string             `bson:"key" json:"key"`
+
+	// RequestHash is the SHA-256 of the request body; a replay must send the same body
+	RequestHash string `bson:"request_hash" json:"-"`
+
+	// Outcome, set once the original request succeeded
+	Completed    bool   `bson:"completed" json:"completed"`
+	StatusCode   int    `bson:"status_code,omitempty" json:"status_code,omitempty"`
+	ContentType  string `bson:"content_type,omitempty" json:"-"`
+	ResponseBody []byte `bson:"response_body,omitempty" json:"-"`
+	ResourceID   string `bson:"resource_id,omitempty" json:"resource_id,omitempty"`
+
+	ExpiresAt time.Time `bson:"expires_at" json:"expires_at"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// CollectionName returns the MongoDB collection name for idempotency keys
+func (IdempotencyKey) CollectionName() string {
+	return "idempotency_keys"
+}
+
+// BeforeCreate sets default values before inserting a new idempotency key
+func (k *IdempotencyKey) BeforeCreate() {
+	if k.ID.IsZero() {
+		k.ID = primitive.NewObjectID()
+	}
+	k.CreatedAt = time.Now().UTC()
+}
@@ -4,6 +4,9 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/mail"
+	"reflect"
 	"strings"
 	"time"
 
@@ -65,6 +68,119 @@ type OrganizationSettings struct {
 	DefaultLanguage      string `bson:"default_language" json:"default_language"`
 	NotificationsEnabled bool   `bson:"notifications_enabled" json:"notifications_enabled"`
 	ReminderDaysBefore   int    `bson:"reminder_days_before" json:"reminder_days_before"`
+
+	// AutoApprove is the default for new questionnaire requirements: passing submissions skip manual review
+	AutoApprove bool `bson:"auto_approve" json:"auto_approve"`
+
+	// ScoringScale is the range question option points are given on; nil uses DefaultScoringScale
+	ScoringScale *ScoringScale `bson:"scoring_scale,omitempty" json:"scoring_scale,omitempty"`
+
+	// DefaultPassingScore applies to new questionnaires that set none; 0 uses DefaultQuestionnairePassingScore
+	DefaultPassingScore int `bson:"default_passing_score,omitempty" json:"default_passing_score,omitempty"`
+	// DefaultScoringMode applies to new questionnaires that set none; empty uses percentage scoring
+	DefaultScoringMode ScoringMode `bson:"default_scoring_mode,omitempty" json:"default_scoring_mode,omitempty"`
+
+	// DefaultMaxReportAgeDays applies to CheckFix requirements that set no maximum report age;
+	// 0 uses DefaultCheckFixMaxReportAgeDays
+	DefaultMaxReportAgeDays int `bson:"default_max_report_age_days,omitempty" json:"default_max_report_age_days,omitempty"`
+
+	// AccessTokenExpiryMinutes and RefreshTokenExpiryHours shorten session lifetimes for the
+	// organization's users; 0 uses the global JWT configuration
+	AccessTokenExpiryMinutes int `bson:"access_token_expiry_minutes,omitempty" json:"access_token_expiry_minutes,omitempty"`
+	RefreshTokenExpiryHours  int `bson:"refresh_token_expiry_hours,omitempty" json:"refresh_token_expiry_hours,omitempty"`
+
+	// TerminatedRequirementStatus is what open requirements become when a relationship is
+	// terminated, EXPIRED or CANCELLED; empty uses CANCELLED
+	TerminatedRequirementStatus RequirementStatus `bson:"terminated_requirement_status,omitempty" json:"terminated_requirement_status,omitempty"`
+
+	// PasswordLoginEnabled lets the organization's users set a password and sign in with it
+	// when PASSWORD_LOGIN_ENABLED is also on; magic links keep working either way
+	PasswordLoginEnabled bool `bson:"password_login_enabled" json:"password_login_enabled"`
+
+	// SSOProvisionDomains are verified domains whose users are created as VIEWERs on their first
+	// SSO login when OIDC_AUTO_PROVISION is also on; empty means SSO only signs in existing users
+	SSOProvisionDomains []string `bson:"sso_provision_domains,omitempty" json:"sso_provision_domains,omitempty"`
+}
+
+// ScoringScale is the range question option points are given on, e.g. 0-4 for a maturity scale
+// #BUSINESS_RULE: Single-choice answers score relative to Min, so the lowest step of a 1-5 scale
+// earns 0% and submissions on different scales compare as percentages
+type ScoringScale struct {
+	Name string `bson:"name" json:"name"`
+	Min  int    `bson:"min" json:"min"`
+	Max  int    `bson:"max" json:"max"`
+}
+
+// DefaultScoringScale is the points scale used by organizations without a configured scale
+func DefaultScoringScale() ScoringScale {
+	return ScoringScale{Name: "points", Min: 0, Max: 100}
+}
+
+// Contains returns true if points lie within the scale
+func (s ScoringScale) Contains(points int) bool {
+	return points >= s.Min && points <= s.Max
+}
+
+// Validate checks that the scale is a usable range
+func (s ScoringScale) Validate() error {
+	if s.Name == "" || len(s.Name) > MaxScoringScaleNameLength {
+		return fmt.Errorf("%w: scoring_scale.name is required and at most %d characters", ErrInvalidSettings, MaxScoringScaleNameLength)
+	}
+	if s.Min < 0 || s.Max > MaxScoringScalePoints || s.Min >= s.Max {
+		return fmt.Errorf("%w: scoring_scale must satisfy 0 <= min < max <= %d", ErrInvalidSettings, MaxScoringScalePoints)
+	}
+	return nil
+}
+
+// EffectiveScoringScale returns the configured scoring scale or the default one
+func (s OrganizationSettings) EffectiveScoringScale() ScoringScale {
+	if s.ScoringScale == nil {
+		return DefaultScoringScale()
+	}
+	return *s.ScoringScale
+}
+
+// EffectiveDefaultPassingScore returns the configured default passing score or the built-in one
+func (s OrganizationSettings) EffectiveDefaultPassingScore() int {
+	if s.DefaultPassingScore == 0 {
+		return DefaultQuestionnairePassingScore
+	}
+	return s.DefaultPassingScore
+}
+
+// EffectiveDefaultScoringMode returns the configured default scoring mode or percentage scoring
+func (s OrganizationSettings) EffectiveDefaultScoringMode() ScoringMode {
+	if s.DefaultScoringMode == "" {
+		return ScoringModePercentage
+	}
+	return s.DefaultScoringMode
+}
+
+// EffectiveTerminatedRequirementStatus returns the configured status for requirements of a terminated relationship, or CANCELLED
+func (s OrganizationSettings) EffectiveTerminatedRequirementStatus() RequirementStatus {
+	if s.TerminatedRequirementStatus == "" {
+		return RequirementStatusCancelled
+	}
+	return s.TerminatedRequirementStatus
+}
+
+// EffectiveDefaultMaxReportAgeDays returns the configured default maximum report age or the built-in one
+// #BUSINESS_RULE: Precedence is requirement value > organization setting > DefaultCheckFixMaxReportAgeDays
+func (s OrganizationSettings) EffectiveDefaultMaxReportAgeDays() int {
+	if s.DefaultMaxReportAgeDays == 0 {
+		return DefaultCheckFixMaxReportAgeDays
+	}
+	return s.DefaultMaxReportAgeDays
+}
+
+// AccessTokenExpiry returns the access token lifetime override, 0 when unset
+func (s OrganizationSettings) AccessTokenExpiry() time.Duration {
+	return time.Duration(s.AccessTokenExpiryMinutes) * time.Minute
+}
+
+// RefreshTokenExpiry returns the refresh token lifetime override, 0 when unset
+func (s OrganizationSettings) RefreshTokenExpiry() time.Duration {
+	return time.Duration(s.RefreshTokenExpiryHours) * time.Hour
 }
 
 // DefaultOrganizationSettings returns default settings for a new organization
@@ -77,18 +193,244 @@ func DefaultOrganizationSettings() OrganizationSettings {
 		DefaultLanguage:      "en",
 		NotificationsEnabled: true,
 		ReminderDaysBefore:   7,
+		AutoApprove:          false,
 	}
 }
 
+// Organization settings bounds
+// #BUSINESS_RULE: Ranges enforced server-side on every settings update
+const (
+	MinDefaultDueDays     = 1
+	MaxDefaultDueDays     = 365
+	MinReminderDaysBefore = 0
+	MaxReminderDaysBefore = 90
+	MaxNotificationEmails = 20
+
+	MaxSSOProvisionDomains = 20
+
+	MaxScoringScalePoints     = 1000
+	MaxScoringScaleNameLength = 50
+
+	MaxDefaultPassingScore = 100
+
+	MaxDefaultMaxReportAgeDays = 365
+
+	// Token expiry overrides; the global JWT configuration still caps the effective lifetime
+	MinAccessTokenExpiryMinutes = 5
+	MaxAccessTokenExpiryMinutes = 24 * 60
+	MaxRefreshTokenExpiryHours  = 90 * 24
+)
+
+// SupportedLanguages lists the languages available for emails and the UI
+// #INTEGRATION_POINT: Each entry needs a translation file in internal/services/mailtemplates
+var SupportedLanguages = []string{"en", "de", "fr"}
+
+// settingsRule is one settings check together with the values it reads
+type settingsRule struct {
+	inputs func(s OrganizationSettings) []interface{}
+	check  func(s OrganizationSettings) error
+}
+
+// settingsRules are the settings checks in the order their errors are reported
+var settingsRules = []settingsRule{
+	{
+		inputs: func(s OrganizationSettings) []interface{} { return []interface{}{s.DefaultDueDays} },
+		check: func(s OrganizationSettings) error {
+			if s.DefaultDueDays < MinDefaultDueDays || s.DefaultDueDays > MaxDefaultDueDays {
+				return fmt.Errorf("%w: default_due_days must be between %d and %d", ErrInvalidSettings, MinDefaultDueDays, MaxDefaultDueDays)
+			}
+			return nil
+		},
+	},
+	{
+		inputs: func(s OrganizationSettings) []interface{} { return []interface{}{s.ReminderDaysBefore} },
+		check: func(s OrganizationSettings) error {
+			if s.ReminderDaysBefore < MinReminderDaysBefore || s.ReminderDaysBefore > MaxReminderDaysBefore {
+				return fmt.Errorf("%w: reminder_days_before must be between %d and %d", ErrInvalidSettings, MinReminderDaysBefore, MaxReminderDaysBefore)
+			}
+			return nil
+		},
+	},
+	{
+		inputs: func(s OrganizationSettings) []interface{} {
+			return []interface{}{s.ReminderDaysBefore, s.DefaultDueDays}
+		},
+		check: func(s OrganizationSettings) error {
+			if s.ReminderDaysBefore >= s.DefaultDueDays {
+				return fmt.Errorf("%w: reminder_days_before must be less than default_due_days", ErrInvalidSettings)
+			}
+			return nil
+		},
+	},
+	{
+		inputs: func(s OrganizationSettings) []interface{} { return []interface{}{s.MinCheckFixGrade} },
+		check: func(s OrganizationSettings) error {
+			if s.MinCheckFixGrade != "" && !CheckFixGrade(s.MinCheckFixGrade).IsValid() {
+				return fmt.Errorf("%w: min_checkfix_grade must be one of A, B, C, D, F", ErrInvalidSettings)
+			}
+			return nil
+		},
+	},
+	{
+		inputs: func(s OrganizationSettings) []interface{} { return []interface{}{s.NotificationEmails} },
+		check: func(s OrganizationSettings) error {
+			if len(s.NotificationEmails) > MaxNotificationEmails {
+				return fmt.Errorf("%w: at most %d notification_emails are allowed", ErrInvalidSettings, MaxNotificationEmails)
+			}
+			for _, email := range s.NotificationEmails {
+				if _, err := mail.ParseAddress(email); err != nil {
+					return fmt.Errorf("%w: invalid notification email %q", ErrInvalidSettings, email)
+				}
+			}
+			return nil
+		},
+	},
+	{
+		inputs: func(s OrganizationSettings) []interface{} { return []interface{}{s.SSOProvisionDomains} },
+		check: func(s OrganizationSettings) error {
+			if len(s.SSOProvisionDomains) > MaxSSOProvisionDomains {
+				return fmt.Errorf("%w: at most %d sso_provision_domains are allowed", ErrInvalidSettings, MaxSSOProvisionDomains)
+			}
+			return nil
+		},
+	},
+	{
+		inputs: func(s OrganizationSettings) []interface{} { return []interface{}{s.DefaultLanguage} },
+		check: func(s OrganizationSettings) error {
+			if !IsSupportedLanguage(s.DefaultLanguage) {
+				return fmt.Errorf("%w: default_language must be one of %s", ErrInvalidSettings, strings.Join(SupportedLanguages, ", "))
+			}
+			return nil
+		},
+	},
+	{
+		inputs: func(s OrganizationSettings) []interface{} { return []interface{}{s.ScoringScale} },
+		check: func(s OrganizationSettings) error {
+			if s.ScoringScale != nil {
+				return s.ScoringScale.Validate()
+			}
+			return nil
+		},
+	},
+	{
+		inputs: func(s OrganizationSettings) []interface{} { return []interface{}{s.DefaultPassingScore} },
+		check: func(s OrganizationSettings) error {
+			if s.DefaultPassingScore < 0 || s.DefaultPassingScore > MaxDefaultPassingScore {
+				return fmt.Errorf("%w: default_passing_score must be between 0 and %d", ErrInvalidSettings, MaxDefaultPassingScore)
+			}
+			return nil
+		},
+	},
+	{
+		inputs: func(s OrganizationSettings) []interface{} { return []interface{}{s.DefaultScoringMode} },
+		check: func(s OrganizationSettings) error {
+			if s.DefaultScoringMode != "" && !s.DefaultScoringMode.IsValid() {
+				return fmt.Errorf("%w: default_scoring_mode must be percentage or points", ErrInvalidSettings)
+			}
+			return nil
+		},
+	},
+	{
+		inputs: func(s OrganizationSettings) []interface{} { return []interface{}{s.DefaultMaxReportAgeDays} },
+		check: func(s OrganizationSettings) error {
+			if s.DefaultMaxReportAgeDays < 0 || s.DefaultMaxReportAgeDays > MaxDefaultMaxReportAgeDays {
+				return fmt.Errorf("%w: default_max_report_age_days must be positive and at most %d, or 0 for the built-in default", ErrInvalidSettings, MaxDefaultMaxReportAgeDays)
+			}
+			return nil
+		},
+	},
+	{
+		inputs: func(s OrganizationSettings) []interface{} { return []interface{}{s.TerminatedRequirementStatus} },
+		check: func(s OrganizationSettings) error {
+			switch s.TerminatedRequirementStatus {
+			case "", RequirementStatusExpired, RequirementStatusCancelled:
+				return nil
+			default:
+				return fmt.Errorf("%w: terminated_requirement_status must be expired or cancelled", ErrInvalidSettings)
+			}
+		},
+	},
+	{
+		inputs: func(s OrganizationSettings) []interface{} { return []interface{}{s.AccessTokenExpiryMinutes} },
+		check: func(s OrganizationSettings) error {
+			if s.AccessTokenExpiryMinutes != 0 && (s.AccessTokenExpiryMinutes < MinAccessTokenExpiryMinutes || s.AccessTokenExpiryMinutes > MaxAccessTokenExpiryMinutes) {
+				return fmt.Errorf("%w: access_token_expiry_minutes must be 0 or between %d and %d", ErrInvalidSettings, MinAccessTokenExpiryMinutes, MaxAccessTokenExpiryMinutes)
+			}
+			return nil
+		},
+	},
+	{
+		inputs: func(s OrganizationSettings) []interface{} { return []interface{}{s.RefreshTokenExpiryHours} },
+		check: func(s OrganizationSettings) error {
+			if s.RefreshTokenExpiryHours < 0 || s.RefreshTokenExpiryHours > MaxRefreshTokenExpiryHours {
+				return fmt.Errorf("%w: refresh_token_expiry_hours must be between 0 and %d", ErrInvalidSettings, MaxRefreshTokenExpiryHours)
+			}
+			return nil
+		},
+	},
+	{
+		inputs: func(s OrganizationSettings) []interface{} {
+			return []interface{}{s.AccessTokenExpiryMinutes, s.RefreshTokenExpiryHours}
+		},
+		check: func(s OrganizationSettings) error {
+			if s.AccessTokenExpiryMinutes != 0 && s.RefreshTokenExpiryHours != 0 && s.RefreshTokenExpiry() <= s.AccessTokenExpiry() {
+				return fmt.Errorf("%w: refresh_token_expiry_hours must be longer than access_token_expiry_minutes", ErrInvalidSettings)
+			}
+			return nil
+		},
+	},
+}
+
+// Validate checks that all settings are within their allowed ranges
+// #IMPLEMENTATION_DECISION: Whether SSOProvisionDomains are verified domains depends on the
+// organization, see Organization.ValidateSSOProvisionDomains
+func (s OrganizationSettings) Validate() error {
+	for _, rule := range settingsRules {
+		if err := rule.check(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateUpdate checks the settings that differ from previous, the stored settings they were patched from
+// #BUSINESS_RULE: Settings a patch leaves unchanged are not re-checked, so a value that was stored
+// before a bound was tightened does not block updates of other settings; cross-field rules are
+// checked when any of their fields changes
+func (s OrganizationSettings) ValidateUpdate(previous OrganizationSettings) error {
+	for _, rule := range settingsRules {
+		if reflect.DeepEqual(rule.inputs(s), rule.inputs(previous)) {
+			continue
+		}
+		if err := rule.check(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsSupportedLanguage checks if the language code is supported
+func IsSupportedLanguage(lang string) bool {
+	for _, l := range SupportedLanguages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
 // Organization represents both Company and Supplier entities
 // #DATA_ASSUMPTION: Slug generated from name, must be URL-safe lowercase alphanumeric with hyphens
 // #DATA_ASSUMPTION: Domain field populated by supplier when linking CheckFix, used for verification
+// #DATA_ASSUMPTION: Domains holds every verified domain including the primary Domain, which is
+// kept for backward compatibility
 type Organization struct {
 	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	Type        OrganizationType   `bson:"type" json:"type"`
 	Name        string             `bson:"name" json:"name"`
 	Slug        string             `bson:"slug" json:"slug"`
 	Domain      string             `bson:"domain,omitempty" json:"domain,omitempty"`
+	Domains     []string           `bson:"domains,omitempty" json:"domains,omitempty"`
 	Description string             `bson:"description,omitempty" json:"description,omitempty"`
 
 	// Contact Information
@@ -103,12 +445,25 @@ type Organization struct {
 	// Settings
 	Settings OrganizationSettings `bson:"settings" json:"settings"`
 
+	// Branding
+	Logo *LogoRef `bson:"logo,omitempty" json:"logo,omitempty"`
+
 	// Audit fields with soft delete support
 	CreatedAt time.Time  `bson:"created_at" json:"created_at"`
 	UpdatedAt time.Time  `bson:"updated_at" json:"updated_at"`
 	DeletedAt *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
 }
 
+// LogoRef points at an organization logo held in file storage
+// #NORMALIZATION_DECISION: Embedded as each organization has at most one logo
+type LogoRef struct {
+	StorageKey  string    `bson:"storage_key" json:"-"`
+	ContentType string    `bson:"content_type" json:"content_type"`
+	Size        int64     `bson:"size" json:"size"`
+	ETag        string    `bson:"etag" json:"etag"` // Hex SHA-256 of the contents
+	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+}
+
 // CollectionName returns the MongoDB collection name for organizations
 func (Organization) CollectionName() string {
 	return "organizations"
@@ -160,3 +515,121 @@ func (o *Organization) IsSupplier() bool {
 func (o *Organization) HasCheckFixLinked() bool {
 	return o.CheckFixAccountID != "" && o.CheckFixLinkedAt != nil
 }
+
+// NormalizeDomain lowercases a domain and strips surrounding whitespace and a trailing dot
+func NormalizeDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// VerifiedDomains returns the primary domain followed by the other verified domains
+// #DATA_ASSUMPTION: Organizations not yet backfilled only have the primary Domain
+func (o *Organization) VerifiedDomains() []string {
+	domains := make([]string, 0, len(o.Domains)+1)
+	if o.Domain != "" {
+		domains = append(domains, o.Domain)
+	}
+	for _, d := range o.Domains {
+		if !strings.EqualFold(d, o.Domain) {
+			domains = append(domains, d)
+		}
+	}
+	return domains
+}
+
+// MatchDomain returns the verified domain equal (case-insensitively) to the given domain
+func (o *Organization) MatchDomain(domain string) (string, bool) {
+	domain = NormalizeDomain(domain)
+	if domain == "" {
+		return "", false
+	}
+	for _, d := range o.VerifiedDomains() {
+		if NormalizeDomain(d) == domain {
+			return d, true
+		}
+	}
+	return "", false
+}
+
+// AddDomain adds a verified domain; returns false if it is already present
+func (o *Organization) AddDomain(domain string) bool {
+	domain = NormalizeDomain(domain)
+	if _, ok := o.MatchDomain(domain); ok || domain == "" {
+		return false
+	}
+	o.Domains = append(o.VerifiedDomains(), domain)
+	return true
+}
+
+// ValidateSSOProvisionDomains checks that SSO provisioning is only enabled for verified domains
+func (o *Organization) ValidateSSOProvisionDomains() error {
+	for _, domain := range o.Settings.SSOProvisionDomains {
+		if _, ok := o.MatchDomain(domain); !ok {
+			return fmt.Errorf("%w: sso_provision_domains may only contain verified domains, %q is not one", ErrInvalidSettings, domain)
+		}
+	}
+	return nil
+}
+
+// ProvisionsSSOUsers reports whether the organization opted in to creating users on first SSO
+// login for a verified email domain
+// #SECURITY_ASSUMPTION: Owning a domain is not consent; the organization must list it explicitly
+func (o *Organization) ProvisionsSSOUsers(domain string) bool {
+	if _, ok := o.MatchDomain(domain); !ok {
+		return false
+	}
+	domain = NormalizeDomain(domain)
+	for _, d := range o.Settings.SSOProvisionDomains {
+		if NormalizeDomain(d) == domain {
+			return true
+		}
+	}
+	return false
+}
+
+// RetainDomains keeps only the verified domains listed in allowed and drops SSO provisioning
+// for the domains removed
+// #SECURITY_ASSUMPTION: Verified domains are proven by the linked CheckFix account, so they are
+// narrowed whenever that account changes or is unlinked
+func (o *Organization) RetainDomains(allowed []string) {
+	keep := make(map[string]bool, len(allowed))
+	for _, d := range allowed {
+		keep[NormalizeDomain(d)] = true
+	}
+
+	var domains []string
+	for _, d := range o.VerifiedDomains() {
+		if keep[NormalizeDomain(d)] {
+			domains = append(domains, d)
+		}
+	}
+	if !keep[NormalizeDomain(o.Domain)] {
+		o.Domain = ""
+	}
+	o.Domains = domains
+
+	var provision []string
+	for _, d := range o.Settings.SSOProvisionDomains {
+		if _, ok := o.MatchDomain(d); ok {
+			provision = append(provision, d)
+		}
+	}
+	o.Settings.SSOProvisionDomains = provision
+}
+
+// RemoveDomain removes a secondary verified domain; returns false if it is not present
+// #BUSINESS_RULE: The primary domain cannot be removed, it belongs to the linked CheckFix account
+func (o *Organization) RemoveDomain(domain string) bool {
+	matched, ok := o.MatchDomain(domain)
+	if !ok || strings.EqualFold(matched, o.Domain) {
+		return false
+	}
+
+	remaining := make([]string, 0, len(o.Domains))
+	for _, d := range o.VerifiedDomains() {
+		if d != matched {
+			remaining = append(remaining, d)
+		}
+	}
+	o.Domains = remaining
+	return true
+}
@@ -97,6 +97,81 @@ func TestDefaultOrganizationSettings(t *testing.T) {
 	}
 }
 
+func TestOrganizationSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(s *OrganizationSettings)
+		wantErr bool
+	}{
+		{"defaults are valid", func(s *OrganizationSettings) {}, false},
+		{"due days zero", func(s *OrganizationSettings) { s.DefaultDueDays = 0 }, true},
+		{"due days too large", func(s *OrganizationSettings) { s.DefaultDueDays = 366 }, true},
+		{"negative reminder", func(s *OrganizationSettings) { s.ReminderDaysBefore = -1 }, true},
+		{"reminder not before due date", func(s *OrganizationSettings) { s.ReminderDaysBefore = 30 }, true},
+		{"invalid grade", func(s *OrganizationSettings) { s.MinCheckFixGrade = "E" }, true},
+		{"invalid email", func(s *OrganizationSettings) { s.NotificationEmails = []string{"not-an-email"} }, true},
+		{"valid email", func(s *OrganizationSettings) { s.NotificationEmails = []string{"sec@example.com"} }, false},
+		{"unsupported language", func(s *OrganizationSettings) { s.DefaultLanguage = "xx" }, true},
+		{"valid scoring scale", func(s *OrganizationSettings) { s.ScoringScale = &ScoringScale{Name: "maturity", Min: 1, Max: 5} }, false},
+		{"inverted scoring scale", func(s *OrganizationSettings) { s.ScoringScale = &ScoringScale{Name: "maturity", Min: 5, Max: 1} }, true},
+		{"scoring scale without name", func(s *OrganizationSettings) { s.ScoringScale = &ScoringScale{Min: 0, Max: 10} }, true},
+		{"default passing score", func(s *OrganizationSettings) { s.DefaultPassingScore = 85 }, false},
+		{"default passing score above 100", func(s *OrganizationSettings) { s.DefaultPassingScore = 101 }, true},
+		{"negative default passing score", func(s *OrganizationSettings) { s.DefaultPassingScore = -1 }, true},
+		{"default scoring mode", func(s *OrganizationSettings) { s.DefaultScoringMode = ScoringModePoints }, false},
+		{"unknown default scoring mode", func(s *OrganizationSettings) { s.DefaultScoringMode = "WEIGHTED" }, true},
+		{"default max report age", func(s *OrganizationSettings) { s.DefaultMaxReportAgeDays = 30 }, false},
+		{"negative default max report age", func(s *OrganizationSettings) { s.DefaultMaxReportAgeDays = -1 }, true},
+		{"default max report age above a year", func(s *OrganizationSettings) { s.DefaultMaxReportAgeDays = 366 }, true},
+		{"token expiry overrides", func(s *OrganizationSettings) { s.AccessTokenExpiryMinutes, s.RefreshTokenExpiryHours = 15, 8 }, false},
+		{"access token expiry too short", func(s *OrganizationSettings) { s.AccessTokenExpiryMinutes = 1 }, true},
+		{"refresh token expiry too long", func(s *OrganizationSettings) { s.RefreshTokenExpiryHours = 24 * 365 }, true},
+		{"refresh not longer than access", func(s *OrganizationSettings) { s.AccessTokenExpiryMinutes, s.RefreshTokenExpiryHours = 120, 1 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := DefaultOrganizationSettings()
+			tt.modify(&settings)
+			err := settings.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !IsValidationError(err) {
+				t.Errorf("Validate() error = %v, want validation error", err)
+			}
+		})
+	}
+}
+
+func TestOrganizationSettings_ValidateUpdate(t *testing.T) {
+	// Stored before the due days bound was introduced
+	stored := DefaultOrganizationSettings()
+	stored.DefaultDueDays = 400
+
+	tests := []struct {
+		name    string
+		modify  func(s *OrganizationSettings)
+		wantErr bool
+	}{
+		{"untouched invalid field", func(s *OrganizationSettings) { s.AutoApprove = true }, false},
+		{"repairs invalid field", func(s *OrganizationSettings) { s.DefaultDueDays = 60 }, false},
+		{"patched field still invalid", func(s *OrganizationSettings) { s.DefaultDueDays = 380 }, true},
+		{"patched field invalid", func(s *OrganizationSettings) { s.DefaultLanguage = "xx" }, true},
+		{"repair breaks cross-field rule", func(s *OrganizationSettings) { s.DefaultDueDays = 5 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := stored
+			tt.modify(&settings)
+			if err := settings.ValidateUpdate(stored); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestOrganization_BeforeCreate(t *testing.T) {
 	org := &Organization{
 		Name: "Test Org",
@@ -235,6 +310,38 @@ func TestOrganization_HasCheckFixLinked(t *testing.T) {
 	}
 }
 
+func TestOrganization_Domains(t *testing.T) {
+	// Not yet backfilled: only the primary domain is set
+	org := &Organization{Domain: "example.com"}
+
+	if matched, ok := org.MatchDomain("Example.COM."); !ok || matched != "example.com" {
+		t.Errorf("MatchDomain(primary) = %q, %v, want example.com, true", matched, ok)
+	}
+
+	if !org.AddDomain("Shop.Example.org") {
+		t.Fatal("AddDomain() = false, want true")
+	}
+	if org.AddDomain("shop.example.org") {
+		t.Error("AddDomain(duplicate) = true, want false")
+	}
+	if got := org.VerifiedDomains(); len(got) != 2 || got[0] != "example.com" || got[1] != "shop.example.org" {
+		t.Errorf("VerifiedDomains() = %v, want [example.com shop.example.org]", got)
+	}
+	if matched, ok := org.MatchDomain("shop.example.org"); !ok || matched != "shop.example.org" {
+		t.Errorf("MatchDomain(secondary) = %q, %v, want shop.example.org, true", matched, ok)
+	}
+
+	if org.RemoveDomain("example.com") {
+		t.Error("RemoveDomain(primary) = true, want false")
+	}
+	if !org.RemoveDomain("shop.example.org") {
+		t.Error("RemoveDomain(secondary) = false, want true")
+	}
+	if _, ok := org.MatchDomain("shop.example.org"); ok {
+		t.Error("MatchDomain() matched a removed domain")
+	}
+}
+
 func TestOrganization_CollectionName(t *testing.T) {
 	org := Organization{}
 	if got := org.CollectionName(); got != "organizations" {
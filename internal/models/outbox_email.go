@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutboxEmailStatus represents the delivery state of a queued email
+type OutboxEmailStatus string
+
+const (
+	OutboxEmailStatusPending OutboxEmailStatus = "pending"
+	OutboxEmailStatusSent    OutboxEmailStatus = "sent"
+	OutboxEmailStatusFailed  OutboxEmailStatus = "failed"
+)
+
+// OutboxEmailKind identifies which message a queued email renders
+type OutboxEmailKind string
+
+const (
+	OutboxEmailKindInvitation        OutboxEmailKind = "invitation"
+	OutboxEmailKindCheckFixRefresh   OutboxEmailKind = "checkfix_refresh"
+	OutboxEmailKindTeamInvitation    OutboxEmailKind = "team_invitation"
+	OutboxEmailKindEmailChangeNotice OutboxEmailKind = "email_change_notice"
+)
+
+// OutboxEmailSentRetention is how long delivered emails are kept before the TTL index removes them
+const OutboxEmailSentRetention = 7 * 24 * time.Hour
+
+// OutboxEmail is an email queued for delivery by the outbox worker
+// #IMPLEMENTATION_DECISION: The message parameters are stored rather than the rendered text so a
+// retry renders with the current templates
+// #INDEX_STRATEGY: TTL index on sent_at - only delivered emails have it, so pending and failed
+// emails are kept until handled
+type OutboxEmail struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OrganizationID primitive.ObjectID `bson:"organization_id" json:"organization_id"`
+	Kind           OutboxEmailKind    `bson:"kind" json:"kind"`
+	Recipient      string             `bson:"recipient" json:"recipient"`
+	Language       string             `bson:"language" json:"language"`
+	Params         map[string]string  `bson:"params" json:"params"`
+
+	// Delivery state
+	Status            OutboxEmailStatus `bson:"status" json:"status"`
+	Attempts          int               `bson:"attempts" json:"attempts"`
+	LastError         string            `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	NextAttemptAt     time.Time         `bson:"next_attempt_at" json:"next_attempt_at"`
+	SentAt            *time.Time        `bson:"sent_at,omitempty" json:"sent_at,omitempty"`
+	FailedAt          *time.Time        `bson:"failed_at,omitempty" json:"failed_at,omitempty"`
+	ProviderMessageID string            `bson:"provider_message_id,omitempty" json:"provider_message_id,omitempty"`
+
+	// Audit fields
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// CollectionName returns the MongoDB collection name for outbox emails
+func (OutboxEmail) CollectionName() string {
+	return "email_outbox"
+}
+
+// BeforeCreate sets default values before inserting a new outbox email
+func (e *OutboxEmail) BeforeCreate() {
+	now := time.Now().UTC()
+	if e.ID.IsZero() {
+		e.ID = primitive.NewObjectID()
+	}
+	e.CreatedAt = now
+	e.UpdatedAt = now
+	if e.Status == "" {
+		e.Status = OutboxEmailStatusPending
+	}
+	if e.NextAttemptAt.IsZero() {
+		e.NextAttemptAt = now
+	}
+	if e.Params == nil {
+		e.Params = map[string]string{}
+	}
+}
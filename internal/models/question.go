@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
@@ -53,6 +54,9 @@ func (qt QuestionType) IsChoiceType() bool {
 	return qt == QuestionTypeSingleChoice || qt == QuestionTypeMultipleChoice || qt == QuestionTypeYesNo
 }
 
+// MaxControlRefLength bounds a question's control reference in characters
+const MaxControlRefLength = 100
+
 // QuestionOption represents an answer option for choice-based questions
 // #NORMALIZATION_DECISION: Options embedded as they are never queried independently
 type QuestionOption struct {
@@ -66,6 +70,9 @@ type QuestionOption struct {
 // Question represents an individual question with options, scoring, and required flag
 // #DATA_ASSUMPTION: Weight defaults to 1, allows emphasizing critical questions
 // #DATA_ASSUMPTION: IsMustPass questions cause automatic fail regardless of total score
+// #BUSINESS_RULE: RequireNote questions must be answered with a free-text justification note
+// #BUSINESS_RULE: Questions with Scored unset are informational; their answers are recorded but
+// earn no points and add nothing to the max score
 // #CARDINALITY_ASSUMPTION: Questionnaire 1:N Questions - Questionnaire contains many questions
 type Question struct {
 	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id"`
@@ -86,6 +93,15 @@ type Question struct {
 	MaxPoints  int  `bson:"max_points" json:"max_points"`
 	IsMustPass bool `bson:"is_must_pass" json:"is_must_pass"`
 
+	// Scored counts the question towards the score; new questions are scored unless marked informational
+	Scored bool `bson:"scored" json:"scored"`
+
+	// RequireNote asks the supplier to justify the answer in a note
+	RequireNote bool `bson:"require_note,omitempty" json:"require_note,omitempty"`
+
+	// ControlRef maps the question to a framework control, e.g. "ISO A.9.2.3"; metadata only
+	ControlRef string `bson:"control_ref,omitempty" json:"control_ref,omitempty"`
+
 	// Options (embedded for single/multiple choice)
 	Options []QuestionOption `bson:"options,omitempty" json:"options,omitempty"`
 
@@ -222,6 +238,51 @@ func (q *Question) WeightedMaxPoints() int {
 	return q.MaxPoints * q.Weight
 }
 
+// ValidateOptionPoints checks that the points of every option lie within the scale
+func (q *Question) ValidateOptionPoints(scale ScoringScale) error {
+	for _, opt := range q.Options {
+		if !scale.Contains(opt.Points) {
+			return fmt.Errorf("%w: option %q has %d points, %s scale is %d-%d",
+				ErrOptionPointsOutOfScale, opt.Text, opt.Points, scale.Name, scale.Min, scale.Max)
+		}
+	}
+	return nil
+}
+
+// ScoreAnswer scores an answer on the scale and returns the points earned and the points achievable
+// #BUSINESS_RULE: Single-choice and yes/no answers are scale steps and count from scale.Min, so the
+// lowest step earns nothing; multiple-choice questions keep summing their correct options
+func (q *Question) ScoreAnswer(selectedOptionIDs []string, textAnswer string, scale ScoringScale) (earned, maxPoints int) {
+	if !q.Scored {
+		return 0, 0
+	}
+
+	switch q.Type {
+	case QuestionTypeSingleChoice, QuestionTypeYesNo:
+		maxPoints = q.MaxPoints - scale.Min
+		if maxPoints <= 0 {
+			// Every option sits at the bottom of the scale; score the raw points
+			return q.CalculateScore(selectedOptionIDs), q.MaxPoints
+		}
+		if len(selectedOptionIDs) == 0 {
+			return 0, maxPoints
+		}
+		earned = q.CalculateScore(selectedOptionIDs) - scale.Min
+		if earned < 0 {
+			earned = 0
+		}
+		return earned, maxPoints
+	case QuestionTypeMultipleChoice:
+		return q.CalculateScore(selectedOptionIDs), q.MaxPoints
+	case QuestionTypeText:
+		// Text questions get full points if answered
+		if textAnswer != "" {
+			return q.MaxPoints, q.MaxPoints
+		}
+	}
+	return 0, q.MaxPoints
+}
+
 // CalculateScore calculates the score for given selected option IDs
 func (q *Question) CalculateScore(selectedOptionIDs []string) int {
 	if len(selectedOptionIDs) == 0 {
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestQuestion_ScoreAnswer_Scale(t *testing.T) {
+	scale := ScoringScale{Name: "maturity", Min: 1, Max: 5}
+	q := &Question{
+		Type:   QuestionTypeSingleChoice,
+		Scored: true,
+		Options: []QuestionOption{
+			{ID: "a", Text: "Initial", Points: 1},
+			{ID: "b", Text: "Managed", Points: 3},
+			{ID: "c", Text: "Optimized", Points: 5},
+		},
+	}
+	q.MaxPoints = q.calculateMaxPoints()
+
+	tests := []struct {
+		name       string
+		selected   []string
+		wantEarned int
+	}{
+		{"lowest step earns nothing", []string{"a"}, 0},
+		{"middle step", []string{"b"}, 2},
+		{"top step", []string{"c"}, 4},
+		{"unanswered", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			earned, maxPoints := q.ScoreAnswer(tt.selected, "", scale)
+			if earned != tt.wantEarned || maxPoints != 4 {
+				t.Errorf("ScoreAnswer() = %d/%d, want %d/4", earned, maxPoints, tt.wantEarned)
+			}
+		})
+	}
+
+	earned, maxPoints := q.ScoreAnswer([]string{"c"}, "", DefaultScoringScale())
+	if earned != 5 || maxPoints != 5 {
+		t.Errorf("ScoreAnswer() on default scale = %d/%d, want 5/5", earned, maxPoints)
+	}
+}
+
+func TestQuestion_ValidateOptionPoints(t *testing.T) {
+	scale := ScoringScale{Name: "maturity", Min: 1, Max: 5}
+	q := &Question{Options: []QuestionOption{{ID: "a", Text: "Yes", Points: 5}}}
+	if err := q.ValidateOptionPoints(scale); err != nil {
+		t.Fatalf("ValidateOptionPoints() error = %v", err)
+	}
+
+	q.Options = append(q.Options, QuestionOption{ID: "b", Text: "No", Points: 0})
+	err := q.ValidateOptionPoints(scale)
+	if !errors.Is(err, ErrOptionPointsOutOfScale) {
+		t.Errorf("ValidateOptionPoints() error = %v, want ErrOptionPointsOutOfScale", err)
+	}
+}
@@ -66,6 +66,10 @@ func (sm *ScoringMode) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// DefaultQuestionnairePassingScore is the passing score of questionnaires when neither the
+// request nor the organization settings provide one
+const DefaultQuestionnairePassingScore = 70
+
 // IsValid checks if the ScoringMode is a valid value
 func (sm ScoringMode) IsValid() bool {
 	switch sm {
@@ -97,7 +101,9 @@ type Questionnaire struct {
 	Name        string              `bson:"name" json:"name"`
 	Description string              `bson:"description,omitempty" json:"description,omitempty"`
 	Status      QuestionnaireStatus `bson:"status" json:"status"`
-	Version     int                 `bson:"version" json:"version"`
+
+	// Version is incremented on every update and guards against concurrent edits
+	Version int `bson:"version" json:"version"`
 
 	// Scoring configuration
 	PassingScore int         `bson:"passing_score" json:"passing_score"`
@@ -106,6 +112,10 @@ type Questionnaire struct {
 	// Topics (copied from template, can be customized)
 	Topics []QuestionnaireTopic `bson:"topics" json:"topics"`
 
+	// AppliesTo lists the supplier classifications the questionnaire is meant for
+	// #BUSINESS_RULE: Targeting is metadata for recommendations only; any questionnaire can still be assigned to any supplier
+	AppliesTo []SupplierClassification `bson:"applies_to,omitempty" json:"applies_to,omitempty"`
+
 	// Statistics (denormalized for dashboard)
 	// #NORMALIZATION_DECISION: QuestionCount denormalized for dashboard performance
 	QuestionCount    int `bson:"question_count" json:"question_count"`
@@ -115,6 +125,10 @@ type Questionnaire struct {
 	CreatedAt   time.Time  `bson:"created_at" json:"created_at"`
 	UpdatedAt   time.Time  `bson:"updated_at" json:"updated_at"`
 	PublishedAt *time.Time `bson:"published_at,omitempty" json:"published_at,omitempty"`
+
+	// DeletedAt is set when the questionnaire is soft-deleted
+	// #DATA_ASSUMPTION: Soft-deleted questionnaires are hidden from all reads until restored or purged
+	DeletedAt *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
 }
 
 // CollectionName returns the MongoDB collection name for questionnaires
@@ -135,7 +149,7 @@ func (q *Questionnaire) BeforeCreate() {
 
 	// Set defaults
 	if q.PassingScore == 0 {
-		q.PassingScore = 70
+		q.PassingScore = DefaultQuestionnairePassingScore
 	}
 	if q.ScoringMode == "" {
 		q.ScoringMode = ScoringModePercentage
@@ -110,6 +110,12 @@ type QuestionnaireTemplate struct {
 	Visibility  TemplateVisibility  `bson:"visibility" json:"visibility"`             // DRAFT, LOCAL, or GLOBAL
 	PublishedBy *primitive.ObjectID `bson:"published_by,omitempty" json:"published_by,omitempty"` // User who published
 
+	// Versioning
+	// #DATA_ASSUMPTION: Versions form a linear chain; root_template_id is unset on the first version
+	PreviousVersionID *primitive.ObjectID `bson:"previous_version_id,omitempty" json:"previous_version_id,omitempty"`
+	RootTemplateID    *primitive.ObjectID `bson:"root_template_id,omitempty" json:"root_template_id,omitempty"`
+	SupersededBy      *primitive.ObjectID `bson:"superseded_by,omitempty" json:"superseded_by,omitempty"` // Set when a newer version is published
+
 	// Configuration
 	DefaultPassingScore int `bson:"default_passing_score" json:"default_passing_score"`
 	EstimatedMinutes    int `bson:"estimated_minutes" json:"estimated_minutes"`
@@ -201,6 +207,19 @@ func (qt *QuestionnaireTemplate) IsGlobal() bool {
 	return qt.Visibility == TemplateVisibilityGlobal
 }
 
+// IsSuperseded returns true if a newer version of the template has been published
+func (qt *QuestionnaireTemplate) IsSuperseded() bool {
+	return qt.SupersededBy != nil
+}
+
+// LineageRootID returns the ID of the first version in the template's version chain
+func (qt *QuestionnaireTemplate) LineageRootID() primitive.ObjectID {
+	if qt.RootTemplateID != nil {
+		return *qt.RootTemplateID
+	}
+	return qt.ID
+}
+
 // IsOwnedByUser returns true if the template was created by the specified user
 func (qt *QuestionnaireTemplate) IsOwnedByUser(userID primitive.ObjectID) bool {
 	return qt.CreatedByUser != nil && *qt.CreatedByUser == userID
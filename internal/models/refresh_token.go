@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RefreshToken tracks an issued refresh token for rotation and reuse detection
+// #SECURITY_ASSUMPTION: Only the token ID (jti) is stored, never the signed token itself
+// #DATA_ASSUMPTION: All tokens rotated from the same login share a FamilyID
+// #INDEX_STRATEGY: TTL index on expires_at for automatic cleanup
+type RefreshToken struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TokenID  string             `bson:"token_id" json:"token_id"`
+	FamilyID string             `bson:"family_id" json:"family_id"`
+	UserID   primitive.ObjectID `bson:"user_id" json:"user_id"`
+
+	// Validity
+	ExpiresAt  time.Time  `bson:"expires_at" json:"expires_at"`
+	UsedAt     *time.Time `bson:"used_at,omitempty" json:"used_at,omitempty"`
+	ReplacedBy string     `bson:"replaced_by,omitempty" json:"replaced_by,omitempty"`
+	RevokedAt  *time.Time `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+
+	// Audit field (no update/delete - tokens are ephemeral)
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// CollectionName returns the MongoDB collection name for refresh tokens
+func (RefreshToken) CollectionName() string {
+	return "refresh_tokens"
+}
+
+// BeforeCreate sets default values before inserting a new refresh token
+func (rt *RefreshToken) BeforeCreate() {
+	if rt.ID.IsZero() {
+		rt.ID = primitive.NewObjectID()
+	}
+	rt.CreatedAt = time.Now().UTC()
+}
+
+// IsExpired returns true if the refresh token has expired
+func (rt *RefreshToken) IsExpired() bool {
+	return time.Now().UTC().After(rt.ExpiresAt)
+}
+
+// IsUsed returns true if the refresh token has already been exchanged
+func (rt *RefreshToken) IsUsed() bool {
+	return rt.UsedAt != nil
+}
+
+// IsRevoked returns true if the refresh token has been revoked
+func (rt *RefreshToken) IsRevoked() bool {
+	return rt.RevokedAt != nil
+}
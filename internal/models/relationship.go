@@ -128,6 +128,14 @@ type StatusChange struct {
 	ChangedAt  time.Time          `bson:"changed_at" json:"changed_at"`
 }
 
+// RelationshipNote is an entry in the company's note history for a supplier relationship
+// #NORMALIZATION_DECISION: Embedded like StatusHistory; notes are append-only and never edited
+type RelationshipNote struct {
+	Text      string             `bson:"text" json:"text"`
+	AuthorID  primitive.ObjectID `bson:"author_id" json:"author_id"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
 // CompanySupplierRelationship tracks the business relationship between a Company and a Supplier
 // #DATA_ASSUMPTION: SupplierID is null until invitation accepted (email-based invite)
 // #DATA_ASSUMPTION: One relationship per Company-Supplier pair (enforced by unique index)
@@ -150,6 +158,10 @@ type CompanySupplierRelationship struct {
 	Classification SupplierClassification `bson:"classification" json:"classification"`
 	Notes          string                 `bson:"notes,omitempty" json:"notes,omitempty"`
 
+	// NoteHistory is the company-internal note timeline; Notes stays a short summary
+	// #SECURITY_ASSUMPTION: Never exposed to the supplier organization
+	NoteHistory []RelationshipNote `bson:"note_history,omitempty" json:"note_history,omitempty"`
+
 	// Service details
 	ServicesProvided []string `bson:"services_provided,omitempty" json:"services_provided,omitempty"`
 	ContractRef      string   `bson:"contract_ref,omitempty" json:"contract_ref,omitempty"`
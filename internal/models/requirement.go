@@ -53,6 +53,7 @@ const (
 	RequirementStatusApproved    RequirementStatus = "APPROVED"
 	RequirementStatusRejected    RequirementStatus = "REJECTED"
 	RequirementStatusExpired     RequirementStatus = "EXPIRED"
+	RequirementStatusCancelled   RequirementStatus = "CANCELLED"
 )
 
 // MarshalJSON converts RequirementStatus to lowercase with underscores for JSON serialization
@@ -75,7 +76,7 @@ func (rs RequirementStatus) IsValid() bool {
 	switch rs {
 	case RequirementStatusPending, RequirementStatusInProgress, RequirementStatusSubmitted,
 		RequirementStatusUnderReview, RequirementStatusApproved, RequirementStatusRejected,
-		RequirementStatusExpired:
+		RequirementStatusExpired, RequirementStatusCancelled:
 		return true
 	}
 	return false
@@ -83,22 +84,23 @@ func (rs RequirementStatus) IsValid() bool {
 
 // IsTerminal returns true if this status is a terminal state
 func (rs RequirementStatus) IsTerminal() bool {
-	return rs == RequirementStatusApproved || rs == RequirementStatusExpired
+	return rs == RequirementStatusApproved || rs == RequirementStatusExpired || rs == RequirementStatusCancelled
 }
 
 // CanTransitionTo checks if a transition to the target status is allowed
 // #BUSINESS_RULE: RequirementStatus transitions:
-// PENDING -> IN_PROGRESS (start) | EXPIRED (timeout)
+// PENDING -> IN_PROGRESS (start) | EXPIRED (timeout) | CANCELLED (company)
 // IN_PROGRESS -> SUBMITTED (submit) | EXPIRED (timeout)
 // SUBMITTED -> APPROVED (company) | REJECTED (company) | UNDER_REVIEW (revision)
 // UNDER_REVIEW -> SUBMITTED (resubmit)
 // APPROVED -> (terminal state)
 // REJECTED -> IN_PROGRESS (retry allowed)
 // EXPIRED -> (terminal state)
+// CANCELLED -> (terminal state)
 func (rs RequirementStatus) CanTransitionTo(target RequirementStatus) bool {
 	switch rs {
 	case RequirementStatusPending:
-		return target == RequirementStatusInProgress || target == RequirementStatusExpired
+		return target == RequirementStatusInProgress || target == RequirementStatusExpired || target == RequirementStatusCancelled
 	case RequirementStatusInProgress:
 		return target == RequirementStatusSubmitted || target == RequirementStatusExpired
 	case RequirementStatusSubmitted:
@@ -107,7 +109,7 @@ func (rs RequirementStatus) CanTransitionTo(target RequirementStatus) bool {
 		return target == RequirementStatusSubmitted
 	case RequirementStatusRejected:
 		return target == RequirementStatusInProgress
-	case RequirementStatusApproved, RequirementStatusExpired:
+	case RequirementStatusApproved, RequirementStatusExpired, RequirementStatusCancelled:
 		return false // Terminal states
 	}
 	return false
@@ -155,6 +157,18 @@ type RequirementStatusChange struct {
 	ChangedAt  time.Time          `bson:"changed_at" json:"changed_at"`
 }
 
+// RequirementAttachment is a reference document the company attached to a requirement
+// #NORMALIZATION_DECISION: Metadata embedded on the requirement; contents live in file storage under StorageKey
+type RequirementAttachment struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	Filename    string             `bson:"filename" json:"filename"`
+	Size        int64              `bson:"size" json:"size"`
+	ContentType string             `bson:"content_type" json:"content_type"`
+	StorageKey  string             `bson:"storage_key" json:"-"`
+	UploadedBy  primitive.ObjectID `bson:"uploaded_by" json:"uploaded_by"`
+	UploadedAt  time.Time          `bson:"uploaded_at" json:"uploaded_at"`
+}
+
 // Requirement represents a specific requirement that a Company assigns to a Supplier
 // #DATA_ASSUMPTION: SupplierID denormalized from relationship for efficient querying
 // #DATA_ASSUMPTION: CompanyID denormalized from relationship for efficient querying
@@ -174,10 +188,14 @@ type Requirement struct {
 	// For Questionnaire requirements
 	QuestionnaireID *primitive.ObjectID `bson:"questionnaire_id,omitempty" json:"questionnaire_id,omitempty"`
 	PassingScore    *int                `bson:"passing_score,omitempty" json:"passing_score,omitempty"`
+	// AutoApprove approves a passing submission immediately instead of waiting for review
+	AutoApprove bool `bson:"auto_approve" json:"auto_approve"`
 
 	// For CheckFix requirements
 	MinimumGrade     *string `bson:"minimum_grade,omitempty" json:"minimum_grade,omitempty"`
 	MaxReportAgeDays *int    `bson:"max_report_age_days,omitempty" json:"max_report_age_days,omitempty"`
+	// CategoryMinimums maps CheckFix category name to the minimum grade required for it
+	CategoryMinimums map[string]string `bson:"category_minimums,omitempty" json:"category_minimums,omitempty"`
 
 	// Timing
 	DueDate        *time.Time `bson:"due_date,omitempty" json:"due_date,omitempty"`
@@ -191,6 +209,13 @@ type Requirement struct {
 	AssignedByUserID primitive.ObjectID `bson:"assigned_by_user_id" json:"assigned_by_user_id"`
 	AssignedAt       time.Time          `bson:"assigned_at" json:"assigned_at"`
 
+	// AssignedUserID is the supplier team member who owns the requirement
+	// #SECURITY_ASSUMPTION: Always a user of the supplier organization; set by the supplier, never the company
+	AssignedUserID *primitive.ObjectID `bson:"assigned_user_id,omitempty" json:"assigned_user_id,omitempty"`
+
+	// Attachments are company-provided reference documents, visible to the assigned supplier
+	Attachments []RequirementAttachment `bson:"attachments,omitempty" json:"attachments,omitempty"`
+
 	// Audit fields
 	CreatedAt time.Time `bson:"created_at" json:"created_at"`
 	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
@@ -240,6 +265,24 @@ func (r *Requirement) TransitionStatus(newStatus RequirementStatus, changedBy pr
 		return ErrInvalidStatusTransition
 	}
 
+	r.recordStatusChange(newStatus, changedBy, reason)
+	return nil
+}
+
+// CloseForTermination expires or cancels an open requirement because its relationship was terminated
+// #BUSINESS_RULE: Any non-terminal status may be closed here, unlike the regular transitions, since
+// the supplier can no longer work on the requirement; the change is kept in StatusHistory
+func (r *Requirement) CloseForTermination(newStatus RequirementStatus, changedBy primitive.ObjectID, reason string) error {
+	if (newStatus != RequirementStatusExpired && newStatus != RequirementStatusCancelled) || r.Status.IsTerminal() {
+		return ErrInvalidStatusTransition
+	}
+
+	r.recordStatusChange(newStatus, changedBy, reason)
+	return nil
+}
+
+// recordStatusChange sets the status and appends the change to StatusHistory
+func (r *Requirement) recordStatusChange(newStatus RequirementStatus, changedBy primitive.ObjectID, reason string) {
 	now := time.Now().UTC()
 	change := RequirementStatusChange{
 		FromStatus: r.Status,
@@ -252,8 +295,6 @@ func (r *Requirement) TransitionStatus(newStatus RequirementStatus, changedBy pr
 	r.StatusHistory = append(r.StatusHistory, change)
 	r.Status = newStatus
 	r.UpdatedAt = now
-
-	return nil
 }
 
 // Start marks the requirement as in progress
@@ -266,6 +307,20 @@ func (r *Requirement) Submit(changedBy primitive.ObjectID) error {
 	return r.TransitionStatus(RequirementStatusSubmitted, changedBy, "Response submitted")
 }
 
+// CompleteSubmission moves a just-submitted requirement on according to its outcome
+// #BUSINESS_RULE: A passing submission is approved on the spot when auto-approval is enabled;
+// everything else stays submitted for manual review. Both steps are kept in StatusHistory.
+// Auto-approvals are recorded with a nil ChangedBy since no user made the decision.
+func (r *Requirement) CompleteSubmission(changedBy primitive.ObjectID, passed bool) error {
+	if err := r.Submit(changedBy); err != nil {
+		return err
+	}
+	if passed && r.AutoApprove {
+		return r.Approve(primitive.NilObjectID, "Automatically approved: passing score")
+	}
+	return nil
+}
+
 // Approve marks the requirement as approved
 func (r *Requirement) Approve(changedBy primitive.ObjectID, reason string) error {
 	return r.TransitionStatus(RequirementStatusApproved, changedBy, reason)
@@ -291,6 +346,14 @@ func (r *Requirement) Expire() error {
 	return nil
 }
 
+// Cancel withdraws a pending requirement that the supplier has not started
+func (r *Requirement) Cancel(changedBy primitive.ObjectID, reason string) error {
+	if reason == "" {
+		reason = "Requirement cancelled"
+	}
+	return r.TransitionStatus(RequirementStatusCancelled, changedBy, reason)
+}
+
 // Retry allows retrying a rejected requirement
 func (r *Requirement) Retry(changedBy primitive.ObjectID) error {
 	return r.TransitionStatus(RequirementStatusInProgress, changedBy, "Retrying after rejection")
@@ -301,6 +364,16 @@ func (r *Requirement) Resubmit(changedBy primitive.ObjectID) error {
 	return r.TransitionStatus(RequirementStatusSubmitted, changedBy, "Resubmitted after revision")
 }
 
+// FindAttachment returns the attachment with the given ID, or nil
+func (r *Requirement) FindAttachment(id primitive.ObjectID) *RequirementAttachment {
+	for i := range r.Attachments {
+		if r.Attachments[i].ID == id {
+			return &r.Attachments[i]
+		}
+	}
+	return nil
+}
+
 // IsPending returns true if the requirement is pending
 func (r *Requirement) IsPending() bool {
 	return r.Status == RequirementStatusPending
@@ -331,6 +404,11 @@ func (r *Requirement) IsExpired() bool {
 	return r.Status == RequirementStatusExpired
 }
 
+// IsCancelled returns true if the requirement has been cancelled
+func (r *Requirement) IsCancelled() bool {
+	return r.Status == RequirementStatusCancelled
+}
+
 // IsUnderReview returns true if the requirement is under review for revision
 func (r *Requirement) IsUnderReview() bool {
 	return r.Status == RequirementStatusUnderReview
@@ -65,6 +65,7 @@ func TestRequirementStatus_IsValid(t *testing.T) {
 		{"Approved is valid", RequirementStatusApproved, true},
 		{"Rejected is valid", RequirementStatusRejected, true},
 		{"Expired is valid", RequirementStatusExpired, true},
+		{"Cancelled is valid", RequirementStatusCancelled, true},
 		{"Invalid status", RequirementStatus("INVALID"), false},
 	}
 
@@ -85,6 +86,7 @@ func TestRequirementStatus_IsTerminal(t *testing.T) {
 	}{
 		{"Approved is terminal", RequirementStatusApproved, true},
 		{"Expired is terminal", RequirementStatusExpired, true},
+		{"Cancelled is terminal", RequirementStatusCancelled, true},
 		{"Pending is not terminal", RequirementStatusPending, false},
 		{"InProgress is not terminal", RequirementStatusInProgress, false},
 		{"Submitted is not terminal", RequirementStatusSubmitted, false},
@@ -112,11 +114,13 @@ func TestRequirementStatus_CanTransitionTo(t *testing.T) {
 		{"Pending -> Expired", RequirementStatusPending, RequirementStatusExpired, true},
 		{"Pending -> Submitted", RequirementStatusPending, RequirementStatusSubmitted, false},
 		{"Pending -> Approved", RequirementStatusPending, RequirementStatusApproved, false},
+		{"Pending -> Cancelled", RequirementStatusPending, RequirementStatusCancelled, true},
 
 		// From InProgress
 		{"InProgress -> Submitted", RequirementStatusInProgress, RequirementStatusSubmitted, true},
 		{"InProgress -> Expired", RequirementStatusInProgress, RequirementStatusExpired, true},
 		{"InProgress -> Approved", RequirementStatusInProgress, RequirementStatusApproved, false},
+		{"InProgress -> Cancelled", RequirementStatusInProgress, RequirementStatusCancelled, false},
 
 		// From Submitted
 		{"Submitted -> Approved", RequirementStatusSubmitted, RequirementStatusApproved, true},
@@ -135,6 +139,7 @@ func TestRequirementStatus_CanTransitionTo(t *testing.T) {
 		// From Terminal states
 		{"Approved -> anything", RequirementStatusApproved, RequirementStatusRejected, false},
 		{"Expired -> anything", RequirementStatusExpired, RequirementStatusPending, false},
+		{"Cancelled -> anything", RequirementStatusCancelled, RequirementStatusPending, false},
 	}
 
 	for _, tt := range tests {
@@ -255,6 +260,36 @@ func TestRequirement_Submit(t *testing.T) {
 	}
 }
 
+func TestRequirement_CompleteSubmission(t *testing.T) {
+	tests := []struct {
+		name        string
+		autoApprove bool
+		passed      bool
+		wantStatus  RequirementStatus
+		wantHistory int
+	}{
+		{"manual review", false, true, RequirementStatusSubmitted, 1},
+		{"auto-approve passed", true, true, RequirementStatusApproved, 2},
+		{"auto-approve failed", true, false, RequirementStatusSubmitted, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &Requirement{Status: RequirementStatusInProgress, AutoApprove: tt.autoApprove}
+
+			if err := req.CompleteSubmission(primitive.NewObjectID(), tt.passed); err != nil {
+				t.Fatalf("CompleteSubmission() error = %v", err)
+			}
+			if req.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", req.Status, tt.wantStatus)
+			}
+			if len(req.StatusHistory) != tt.wantHistory {
+				t.Errorf("StatusHistory length = %d, want %d", len(req.StatusHistory), tt.wantHistory)
+			}
+		})
+	}
+}
+
 func TestRequirement_Approve(t *testing.T) {
 	userID := primitive.NewObjectID()
 	req := &Requirement{
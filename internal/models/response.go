@@ -30,6 +30,13 @@ type SupplierResponse struct {
 	// Draft answers (saved progress for questionnaire responses)
 	DraftAnswers []DraftAnswer `bson:"draft_answers,omitempty" json:"draft_answers,omitempty"`
 
+	// Version increments on every draft save so concurrent editors can detect conflicts
+	// #DATA_ASSUMPTION: Responses created before versioning have no field and read as 0
+	Version int `bson:"version" json:"version"`
+
+	// Clarifications are the per-question threads between supplier and company, oldest first
+	Clarifications []Clarification `bson:"clarifications,omitempty" json:"clarifications,omitempty"`
+
 	// Review
 	ReviewedByUserID *primitive.ObjectID `bson:"reviewed_by_user_id,omitempty" json:"reviewed_by_user_id,omitempty"`
 	ReviewedAt       *time.Time          `bson:"reviewed_at,omitempty" json:"reviewed_at,omitempty"`
@@ -47,9 +54,31 @@ type DraftAnswer struct {
 	QuestionID      primitive.ObjectID `bson:"question_id" json:"question_id"`
 	SelectedOptions []string           `bson:"selected_options,omitempty" json:"selected_options,omitempty"`
 	TextAnswer      string             `bson:"text_answer,omitempty" json:"text_answer,omitempty"`
+	Note            string             `bson:"note,omitempty" json:"note,omitempty"`
 	SavedAt         time.Time          `bson:"saved_at" json:"saved_at"`
 }
 
+// Clarification is a message in the thread about one question of a response
+// #NORMALIZATION_DECISION: Embedded like the draft answers; threads are short and append-only
+type Clarification struct {
+	QuestionID   primitive.ObjectID `bson:"question_id" json:"question_id"`
+	FromSupplier bool               `bson:"from_supplier" json:"from_supplier"`
+	Message      string             `bson:"message" json:"message"`
+	AuthorID     primitive.ObjectID `bson:"author_id" json:"author_id"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// ClarificationsFor returns the thread about a question, oldest first
+func (r *SupplierResponse) ClarificationsFor(questionID primitive.ObjectID) []Clarification {
+	thread := []Clarification{}
+	for _, c := range r.Clarifications {
+		if c.QuestionID == questionID {
+			thread = append(thread, c)
+		}
+	}
+	return thread
+}
+
 // CollectionName returns the MongoDB collection name for supplier responses
 func (SupplierResponse) CollectionName() string {
 	return "supplier_responses"
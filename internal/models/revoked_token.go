@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RevokedToken is a denylist entry for access tokens
+// #IMPLEMENTATION_DECISION: An entry with a TokenID revokes that single token (logout).
+// An entry without a TokenID revokes every token of the user issued at or before CreatedAt
+// (user removal).
+// #INDEX_STRATEGY: TTL index on expires_at - entries only need to outlive the tokens they deny
+type RevokedToken struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TokenID   string             `bson:"token_id,omitempty" json:"token_id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	ExpiresAt time.Time          `bson:"expires_at" json:"expires_at"`
+
+	// Audit field (no update/delete - entries are ephemeral)
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// CollectionName returns the MongoDB collection name for revoked tokens
+func (RevokedToken) CollectionName() string {
+	return "revoked_tokens"
+}
+
+// BeforeCreate sets default values before inserting a new denylist entry
+func (rt *RevokedToken) BeforeCreate() {
+	if rt.ID.IsZero() {
+		rt.ID = primitive.NewObjectID()
+	}
+	rt.CreatedAt = time.Now().UTC()
+}
@@ -9,12 +9,14 @@ import (
 )
 
 // SecureLinkType represents the type of secure link
-// #IMPLEMENTATION_DECISION: AUTH for magic links, INVITATION for supplier invites
+// #IMPLEMENTATION_DECISION: AUTH for magic links, INVITATION for supplier invites, EMAIL_CHANGE
+// for confirming a new email address
 type SecureLinkType string
 
 const (
-	SecureLinkTypeAuth       SecureLinkType = "AUTH"
-	SecureLinkTypeInvitation SecureLinkType = "INVITATION"
+	SecureLinkTypeAuth        SecureLinkType = "AUTH"
+	SecureLinkTypeInvitation  SecureLinkType = "INVITATION"
+	SecureLinkTypeEmailChange SecureLinkType = "EMAIL_CHANGE"
 )
 
 // MarshalJSON converts SecureLinkType to lowercase for JSON serialization
@@ -35,7 +37,7 @@ func (slt *SecureLinkType) UnmarshalJSON(data []byte) error {
 // IsValid checks if the SecureLinkType is a valid value
 func (slt SecureLinkType) IsValid() bool {
 	switch slt {
-	case SecureLinkTypeAuth, SecureLinkTypeInvitation:
+	case SecureLinkTypeAuth, SecureLinkTypeInvitation, SecureLinkTypeEmailChange:
 		return true
 	}
 	return false
@@ -43,7 +45,8 @@ func (slt SecureLinkType) IsValid() bool {
 
 // SecureLink represents a magic link token for passwordless authentication
 // #DATA_ASSUMPTION: SecureIdentifier is 64-character cryptographically random string
-// #DATA_ASSUMPTION: Auth links expire in 15 minutes, invitation links in 7 days
+// #DATA_ASSUMPTION: Auth links expire in 15 minutes, email change links in 24 hours, invitation links in 7 days
+// #DATA_ASSUMPTION: For EMAIL_CHANGE links Email is the new address and UserID the user changing it
 // #INDEX_STRATEGY: TTL index on expires_at for automatic cleanup
 type SecureLink struct {
 	ID               primitive.ObjectID `bson:"_id,omitempty" json:"id"`
@@ -79,12 +82,16 @@ const AuthLinkExpiryDuration = 15 * time.Minute
 // InvitationLinkExpiryDuration is the expiry duration for invitation links (7 days)
 const InvitationLinkExpiryDuration = 7 * 24 * time.Hour
 
+// EmailChangeLinkExpiryDuration is the expiry duration for email change links (24 hours)
+const EmailChangeLinkExpiryDuration = 24 * time.Hour
+
 // BeforeCreate sets default values before inserting a new secure link
 func (sl *SecureLink) BeforeCreate() {
 	now := time.Now().UTC()
 	if sl.ID.IsZero() {
 		sl.ID = primitive.NewObjectID()
 	}
+	sl.Email = NormalizeEmail(sl.Email)
 	sl.CreatedAt = now
 	sl.IsValid = true
 
@@ -95,6 +102,8 @@ func (sl *SecureLink) BeforeCreate() {
 			sl.ExpiresAt = now.Add(AuthLinkExpiryDuration)
 		case SecureLinkTypeInvitation:
 			sl.ExpiresAt = now.Add(InvitationLinkExpiryDuration)
+		case SecureLinkTypeEmailChange:
+			sl.ExpiresAt = now.Add(EmailChangeLinkExpiryDuration)
 		default:
 			// Default to auth link expiry
 			sl.ExpiresAt = now.Add(AuthLinkExpiryDuration)
@@ -139,6 +148,11 @@ func (sl *SecureLink) IsInvitationLink() bool {
 	return sl.Type == SecureLinkTypeInvitation
 }
 
+// IsEmailChangeLink returns true if this link confirms a new email address
+func (sl *SecureLink) IsEmailChangeLink() bool {
+	return sl.Type == SecureLinkTypeEmailChange
+}
+
 // TimeUntilExpiry returns the duration until the link expires
 func (sl *SecureLink) TimeUntilExpiry() time.Duration {
 	return time.Until(sl.ExpiresAt)
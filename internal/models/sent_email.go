@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SentEmailStatus represents the outcome of one send attempt to the mail provider
+type SentEmailStatus string
+
+const (
+	SentEmailStatusAccepted SentEmailStatus = "accepted"
+	SentEmailStatusFailed   SentEmailStatus = "failed"
+)
+
+// SentEmailRetention is how long send records are kept before the TTL index removes them
+const SentEmailRetention = 30 * 24 * time.Hour
+
+// SentEmail records one attempt to hand an email to the mail provider
+// #IMPLEMENTATION_DECISION: Every attempt gets its own record, so retries of an outbox email show up
+// as separate records sharing the outbox email ID
+// #DATA_ASSUMPTION: Accepted means the provider took the email; later bounces are not reported back
+// #INDEX_STRATEGY: TTL index on created_at, records are for troubleshooting only
+type SentEmail struct {
+	ID                primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	OrganizationID    *primitive.ObjectID `bson:"organization_id,omitempty" json:"organization_id,omitempty"`
+	Kind              string              `bson:"kind" json:"kind"`
+	Recipient         string              `bson:"recipient" json:"recipient"`
+	Status            SentEmailStatus     `bson:"status" json:"status"`
+	ProviderMessageID string              `bson:"provider_message_id,omitempty" json:"provider_message_id,omitempty"`
+	Error             string              `bson:"error,omitempty" json:"error,omitempty"`
+
+	// Outbox correlation, set when the email was sent by the outbox worker
+	OutboxEmailID *primitive.ObjectID `bson:"outbox_email_id,omitempty" json:"outbox_email_id,omitempty"`
+	Attempt       int                 `bson:"attempt,omitempty" json:"attempt,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// CollectionName returns the MongoDB collection name for sent emails
+func (SentEmail) CollectionName() string {
+	return "sent_emails"
+}
+
+// BeforeCreate sets default values before inserting a new sent email record
+func (e *SentEmail) BeforeCreate() {
+	if e.ID.IsZero() {
+		e.ID = primitive.NewObjectID()
+	}
+	e.CreatedAt = time.Now().UTC()
+}
@@ -17,6 +17,11 @@ type QuestionnaireSubmission struct {
 	QuestionnaireID primitive.ObjectID `bson:"questionnaire_id" json:"questionnaire_id"`
 	SupplierID      primitive.ObjectID `bson:"supplier_id" json:"supplier_id"`
 
+	// Requesting company and requirement, denormalized for company-wide exports
+	// #DATA_ASSUMPTION: Submissions created before these fields existed are backfilled by migration
+	CompanyID     primitive.ObjectID `bson:"company_id" json:"company_id"`
+	RequirementID primitive.ObjectID `bson:"requirement_id" json:"requirement_id"`
+
 	// Answers
 	Answers []SubmissionAnswer `bson:"answers" json:"answers"`
 
@@ -27,6 +32,9 @@ type QuestionnaireSubmission struct {
 	Passed           bool    `bson:"passed" json:"passed"`
 	MustPassFailed   bool    `bson:"must_pass_failed" json:"must_pass_failed"`
 
+	// ScoringScale is the company's scale the answers were scored on
+	ScoringScale *ScoringScale `bson:"scoring_scale,omitempty" json:"scoring_scale,omitempty"`
+
 	// Topic-level scores
 	TopicScores []TopicScore `bson:"topic_scores" json:"topic_scores"`
 
@@ -45,6 +53,7 @@ type SubmissionAnswer struct {
 	QuestionID      primitive.ObjectID `bson:"question_id" json:"question_id"`
 	SelectedOptions []string           `bson:"selected_options,omitempty" json:"selected_options,omitempty"`
 	TextAnswer      string             `bson:"text_answer,omitempty" json:"text_answer,omitempty"`
+	Note            string             `bson:"note,omitempty" json:"note,omitempty"`
 	PointsEarned    int                `bson:"points_earned" json:"points_earned"`
 	MaxPoints       int                `bson:"max_points" json:"max_points"`
 	IsMustPassMet   *bool              `bson:"is_must_pass_met,omitempty" json:"is_must_pass_met,omitempty"`
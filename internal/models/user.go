@@ -8,6 +8,13 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// NormalizeEmail lowercases an email address and strips surrounding whitespace
+// #BUSINESS_RULE: Emails are stored and looked up in this form, so addresses differing only in
+// case refer to the same user and the unique email index enforces case-insensitive uniqueness
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
 // UserRole represents the role of a user within an organization
 // #IMPLEMENTATION_DECISION: UPPERCASE in Go code, lowercase in JSON serialization
 type UserRole string
@@ -41,6 +48,40 @@ func (ur UserRole) IsValid() bool {
 	return false
 }
 
+// NotificationKind identifies a category of supplier notification email
+type NotificationKind string
+
+const (
+	NotificationKindInvitation NotificationKind = "invitation"
+	NotificationKindReminder   NotificationKind = "reminder"
+	NotificationKindOverdue    NotificationKind = "overdue"
+	NotificationKindApproval   NotificationKind = "approval"
+)
+
+// NotificationKinds lists the notification kinds in display order
+var NotificationKinds = []NotificationKind{
+	NotificationKindInvitation,
+	NotificationKindReminder,
+	NotificationKindOverdue,
+	NotificationKindApproval,
+}
+
+// IsValid checks if the NotificationKind is a valid value
+func (k NotificationKind) IsValid() bool {
+	switch k {
+	case NotificationKindInvitation, NotificationKindReminder, NotificationKindOverdue, NotificationKindApproval:
+		return true
+	}
+	return false
+}
+
+// IsOptional reports whether users may opt out of the notification kind
+// #BUSINESS_RULE: Invitations and approvals are lifecycle emails and always sent; reminders and
+// overdue notices can be muted
+func (k NotificationKind) IsOptional() bool {
+	return k == NotificationKindReminder || k == NotificationKindOverdue
+}
+
 // User represents a user account with role-based access within an organization
 // #DATA_ASSUMPTION: Email is unique across entire system (not per organization)
 // #DATA_ASSUMPTION: Users belong to exactly ONE organization (no multi-org membership)
@@ -56,10 +97,19 @@ type User struct {
 	IsActive    bool       `bson:"is_active" json:"is_active"`
 	LastLoginAt *time.Time `bson:"last_login_at,omitempty" json:"last_login_at,omitempty"`
 
+	// Optional password login, a fallback for users whose mail filters swallow magic links
+	// #SECURITY_ASSUMPTION: Only the bcrypt hash is stored and it is never serialized
+	PasswordHash  string     `bson:"password_hash,omitempty" json:"-"`
+	PasswordSetAt *time.Time `bson:"password_set_at,omitempty" json:"password_set_at,omitempty"`
+
 	// Preferences
 	Language string `bson:"language" json:"language"`
 	Timezone string `bson:"timezone,omitempty" json:"timezone,omitempty"`
 
+	// MutedNotifications lists the optional notification kinds the user opted out of
+	// #IMPLEMENTATION_DECISION: Stored as opt-outs so existing users keep receiving everything
+	MutedNotifications []NotificationKind `bson:"muted_notifications,omitempty" json:"muted_notifications,omitempty"`
+
 	// Audit fields with soft delete support
 	CreatedAt time.Time  `bson:"created_at" json:"created_at"`
 	UpdatedAt time.Time  `bson:"updated_at" json:"updated_at"`
@@ -76,12 +126,26 @@ func (u *User) IsDeleted() bool {
 	return u.DeletedAt != nil
 }
 
+// Password bounds for password login
+// #SECURITY_ASSUMPTION: bcrypt ignores input beyond 72 bytes, so longer passwords are rejected
+// instead of being silently truncated
+const (
+	MinPasswordLength = 12 // characters
+	MaxPasswordBytes  = 72
+)
+
+// HasPassword returns true if the user has set a password for password login
+func (u *User) HasPassword() bool {
+	return u.PasswordHash != ""
+}
+
 // BeforeCreate sets default values before inserting a new user
 func (u *User) BeforeCreate() {
 	now := time.Now().UTC()
 	if u.ID.IsZero() {
 		u.ID = primitive.NewObjectID()
 	}
+	u.Email = NormalizeEmail(u.Email)
 	u.CreatedAt = now
 	u.UpdatedAt = now
 	u.IsActive = true
@@ -141,3 +205,30 @@ func (u *User) CanCreateRequirements() bool {
 func (u *User) CanReviewResponses() bool {
 	return u.IsAdmin() && u.IsActive && !u.IsDeleted()
 }
+
+// WantsNotification reports whether the user should receive notifications of the given kind
+func (u *User) WantsNotification(kind NotificationKind) bool {
+	if !kind.IsOptional() {
+		return true
+	}
+	for _, muted := range u.MutedNotifications {
+		if muted == kind {
+			return false
+		}
+	}
+	return true
+}
+
+// SetNotification mutes or unmutes an optional notification kind
+func (u *User) SetNotification(kind NotificationKind, enabled bool) {
+	var muted []NotificationKind
+	for _, k := range u.MutedNotifications {
+		if k != kind {
+			muted = append(muted, k)
+		}
+	}
+	if !enabled {
+		muted = append(muted, kind)
+	}
+	u.MutedNotifications = muted
+}
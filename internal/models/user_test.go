@@ -102,6 +102,20 @@ func TestUser_BeforeCreate(t *testing.T) {
 	}
 }
 
+func TestNormalizeEmail(t *testing.T) {
+	for _, email := range []string{"user@example.com", "User@Example.COM", "  user@example.com\n"} {
+		if got := NormalizeEmail(email); got != "user@example.com" {
+			t.Errorf("NormalizeEmail(%q) = %q, want user@example.com", email, got)
+		}
+	}
+
+	user := &User{Email: " Admin@Example.com"}
+	user.BeforeCreate()
+	if user.Email != "admin@example.com" {
+		t.Errorf("BeforeCreate() Email = %q, want admin@example.com", user.Email)
+	}
+}
+
 func TestUser_BeforeCreate_PreservesExistingID(t *testing.T) {
 	existingID := primitive.NewObjectID()
 	user := &User{
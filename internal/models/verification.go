@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"sort"
 	"strings"
 	"time"
 
@@ -71,6 +72,43 @@ func (g CheckFixGrade) IsPassing() bool {
 	return g.Score() >= CheckFixGradeC.Score()
 }
 
+// CheckFix report categories
+// #DATA_ASSUMPTION: Names match the category field of CheckFix report category_grades
+const (
+	CheckFixCategoryEmailSecurity   = "Email Security"
+	CheckFixCategoryWebSecurity     = "Web Security"
+	CheckFixCategoryDNSSecurity     = "DNS Security"
+	CheckFixCategoryNetworkSecurity = "Network Security"
+	CheckFixCategoryEncryption      = "Encryption"
+)
+
+// KnownCheckFixCategories lists the categories a requirement may set minimums for
+var KnownCheckFixCategories = []string{
+	CheckFixCategoryEmailSecurity,
+	CheckFixCategoryWebSecurity,
+	CheckFixCategoryDNSSecurity,
+	CheckFixCategoryNetworkSecurity,
+	CheckFixCategoryEncryption,
+}
+
+// NormalizeCheckFixCategory returns the canonical name of a known category, matched case-insensitively
+func NormalizeCheckFixCategory(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	for _, known := range KnownCheckFixCategories {
+		if strings.EqualFold(known, name) {
+			return known, true
+		}
+	}
+	return "", false
+}
+
+// CategoryShortfall describes a category that does not meet its required minimum
+type CategoryShortfall struct {
+	Category string
+	Grade    CheckFixGrade // Empty when the report has no grade for the category
+	Minimum  CheckFixGrade
+}
+
 // CategoryGrade represents a grade for a specific security category
 type CategoryGrade struct {
 	Category string `bson:"category" json:"category"`
@@ -89,9 +127,12 @@ type CheckFixVerification struct {
 	SupplierID primitive.ObjectID `bson:"supplier_id" json:"supplier_id"`
 
 	// Domain verification
+	// #BUSINESS_RULE: The report may cover any of the supplier's verified domains;
+	// MatchedDomain records which one (empty if none matched)
 	Domain         string `bson:"domain" json:"domain"`
 	VerifiedDomain string `bson:"verified_domain" json:"verified_domain"`
 	DomainMatch    bool   `bson:"domain_match" json:"domain_match"`
+	MatchedDomain  string `bson:"matched_domain,omitempty" json:"matched_domain,omitempty"`
 
 	// Report details
 	ReportHash string    `bson:"report_hash" json:"report_hash"`
@@ -125,6 +166,10 @@ func (CheckFixVerification) CollectionName() string {
 	return "checkfix_verifications"
 }
 
+// DefaultCheckFixMaxReportAgeDays is the maximum report age of CheckFix requirements when neither
+// the requirement nor the organization settings provide one
+const DefaultCheckFixMaxReportAgeDays = 90
+
 // VerificationValidityDuration is the duration a verification is valid (30 days)
 const VerificationValidityDuration = 30 * 24 * time.Hour
 
@@ -136,7 +181,10 @@ func (v *CheckFixVerification) BeforeCreate() {
 	}
 	v.CreatedAt = now
 	v.UpdatedAt = now
-	v.VerifiedAt = now
+	// Copies of an earlier verification keep the date it was actually verified
+	if v.VerifiedAt.IsZero() {
+		v.VerifiedAt = now
+	}
 
 	// Set expiry if not already set
 	if v.ExpiresAt.IsZero() {
@@ -183,10 +231,10 @@ func (v *CheckFixVerification) HasHighFindings() bool {
 	return v.HighFindings > 0
 }
 
-// GetCategoryGrade returns the grade for a specific category
+// GetCategoryGrade returns the grade for a specific category (case-insensitive)
 func (v *CheckFixVerification) GetCategoryGrade(category string) *CategoryGrade {
 	for i := range v.CategoryGrades {
-		if v.CategoryGrades[i].Category == category {
+		if strings.EqualFold(v.CategoryGrades[i].Category, category) {
 			return &v.CategoryGrades[i]
 		}
 	}
@@ -229,9 +277,33 @@ func (v *CheckFixVerification) IsReportTooOld(maxAgeDays int) bool {
 	return v.ReportAgeDays() > maxAgeDays
 }
 
+// CategoryShortfalls returns the categories graded below their required minimum, sorted by category
+// #BUSINESS_RULE: A required category missing from the report counts as not met
+func (v *CheckFixVerification) CategoryShortfalls(minimums map[string]string) []CategoryShortfall {
+	var shortfalls []CategoryShortfall
+	for category, minimum := range minimums {
+		required := CheckFixGrade(minimum)
+		categoryGrade := v.GetCategoryGrade(category)
+		if categoryGrade == nil {
+			shortfalls = append(shortfalls, CategoryShortfall{Category: category, Minimum: required})
+			continue
+		}
+		grade := CheckFixGrade(strings.ToUpper(categoryGrade.Grade))
+		if !grade.MeetsMinimum(required) {
+			shortfalls = append(shortfalls, CategoryShortfall{Category: category, Grade: grade, Minimum: required})
+		}
+	}
+
+	sort.Slice(shortfalls, func(i, j int) bool {
+		return shortfalls[i].Category < shortfalls[j].Category
+	})
+	return shortfalls
+}
+
 // PassesRequirement checks if the verification passes a CheckFix requirement
 // #BUSINESS_RULE: CheckFix verification requires domain match with supplier organization
-func (v *CheckFixVerification) PassesRequirement(minimumGrade CheckFixGrade, maxReportAgeDays int) bool {
+// #BUSINESS_RULE: Every category minimum must be met, even when the overall grade passes
+func (v *CheckFixVerification) PassesRequirement(minimumGrade CheckFixGrade, maxReportAgeDays int, categoryMinimums map[string]string) bool {
 	if !v.IsValid() {
 		return false
 	}
@@ -241,5 +313,8 @@ func (v *CheckFixVerification) PassesRequirement(minimumGrade CheckFixGrade, max
 	if maxReportAgeDays > 0 && v.IsReportTooOld(maxReportAgeDays) {
 		return false
 	}
+	if len(v.CategoryShortfalls(categoryMinimums)) > 0 {
+		return false
+	}
 	return true
 }
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckFixVerification_PassesRequirement_CategoryMinimums(t *testing.T) {
+	v := &CheckFixVerification{
+		DomainMatch:       true,
+		VerificationValid: true,
+		ExpiresAt:         time.Now().UTC().Add(time.Hour),
+		ReportDate:        time.Now().UTC(),
+		OverallGrade:      CheckFixGradeA,
+		CategoryGrades: []CategoryGrade{
+			{Category: CheckFixCategoryEmailSecurity, Grade: "F"},
+			{Category: CheckFixCategoryWebSecurity, Grade: "B"},
+		},
+	}
+
+	if !v.PassesRequirement(CheckFixGradeC, 0, nil) {
+		t.Fatal("Expected verification to pass without category minimums")
+	}
+
+	minimums := map[string]string{
+		CheckFixCategoryEmailSecurity: "C",
+		CheckFixCategoryWebSecurity:   "C",
+		CheckFixCategoryDNSSecurity:   "D",
+	}
+	if v.PassesRequirement(CheckFixGradeC, 0, minimums) {
+		t.Error("Expected verification to fail when a category is below its minimum")
+	}
+
+	shortfalls := v.CategoryShortfalls(minimums)
+	if len(shortfalls) != 2 {
+		t.Fatalf("Expected 2 shortfalls, got %d", len(shortfalls))
+	}
+	if shortfalls[0].Category != CheckFixCategoryDNSSecurity || shortfalls[0].Grade != "" {
+		t.Errorf("Expected ungraded DNS Security shortfall, got %+v", shortfalls[0])
+	}
+	if shortfalls[1].Category != CheckFixCategoryEmailSecurity || shortfalls[1].Grade != CheckFixGradeF {
+		t.Errorf("Expected Email Security grade F shortfall, got %+v", shortfalls[1])
+	}
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebhookEventType identifies a lifecycle event delivered to outbound webhooks
+type WebhookEventType string
+
+const (
+	WebhookEventRequirementSubmitted         WebhookEventType = "requirement.submitted"
+	WebhookEventRequirementApproved          WebhookEventType = "requirement.approved"
+	WebhookEventRequirementRejected          WebhookEventType = "requirement.rejected"
+	WebhookEventRequirementRevisionRequested WebhookEventType = "requirement.revision_requested"
+	WebhookEventRelationshipAccepted         WebhookEventType = "relationship.accepted"
+)
+
+// IsValid checks if the WebhookEventType is a valid value
+func (e WebhookEventType) IsValid() bool {
+	switch e {
+	case WebhookEventRequirementSubmitted, WebhookEventRequirementApproved, WebhookEventRequirementRejected,
+		WebhookEventRequirementRevisionRequested, WebhookEventRelationshipAccepted:
+		return true
+	}
+	return false
+}
+
+// MaxWebhooksPerOrganization caps outbound webhooks so one event cannot fan out unboundedly
+const MaxWebhooksPerOrganization = 10
+
+// Webhook is an outbound HTTP endpoint an organization registered for lifecycle events
+// #SECURITY_ASSUMPTION: The secret is stored in plaintext because every delivery must be signed
+// with it; it is only returned to the client once, at creation
+// #DATA_ASSUMPTION: Delivery state only tracks the latest attempt; there is no delivery log
+type Webhook struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OrganizationID primitive.ObjectID `bson:"organization_id" json:"organization_id"`
+	CreatedBy      primitive.ObjectID `bson:"created_by" json:"created_by"`
+	URL            string             `bson:"url" json:"url"`
+	Secret         string             `bson:"secret" json:"-"`
+	Events         []WebhookEventType `bson:"events" json:"events"`
+
+	// Last delivery
+	LastDeliveryAt      *time.Time `bson:"last_delivery_at,omitempty" json:"last_delivery_at,omitempty"`
+	LastStatusCode      int        `bson:"last_status_code,omitempty" json:"last_status_code,omitempty"`
+	LastError           string     `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	ConsecutiveFailures int        `bson:"consecutive_failures" json:"consecutive_failures"`
+
+	// Audit fields
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// CollectionName returns the MongoDB collection name for webhooks
+func (Webhook) CollectionName() string {
+	return "webhooks"
+}
+
+// BeforeCreate sets default values before inserting a new webhook
+func (w *Webhook) BeforeCreate() {
+	now := time.Now().UTC()
+	if w.ID.IsZero() {
+		w.ID = primitive.NewObjectID()
+	}
+	w.CreatedAt = now
+	w.UpdatedAt = now
+	if w.Events == nil {
+		w.Events = []WebhookEventType{}
+	}
+}
+
+// Subscribes returns true if the webhook receives the given event
+func (w *Webhook) Subscribes(event WebhookEventType) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryResult is the outcome of delivering one event to a webhook, after retries
+type WebhookDeliveryResult struct {
+	DeliveredAt time.Time
+	StatusCode  int
+	Error       string
+}
+
+// Succeeded returns true if the endpoint acknowledged the delivery with a 2xx status
+func (r WebhookDeliveryResult) Succeeded() bool {
+	return r.Error == "" && r.StatusCode >= 200 && r.StatusCode < 300
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebhookEventRetention is how long processed webhook event IDs are remembered for deduplication
+const WebhookEventRetention = 7 * 24 * time.Hour
+
+// WebhookEvent records an inbound webhook event that has been claimed for processing
+// #IMPLEMENTATION_DECISION: The unique event ID index makes the claim atomic, so a redelivered
+// event is processed by exactly one replica
+// #INDEX_STRATEGY: TTL index on expires_at - IDs only need to outlive the sender's retry window
+type WebhookEvent struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Source    string             `bson:"source" json:"source"`
+	EventID   string             `bson:"event_id" json:"event_id"`
+	EventType string             `bson:"event_type" json:"event_type"`
+	ExpiresAt time.Time          `bson:"expires_at" json:"expires_at"`
+
+	// Audit field (no update - entries are ephemeral)
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// CollectionName returns the MongoDB collection name for webhook events
+func (WebhookEvent) CollectionName() string {
+	return "webhook_events"
+}
+
+// BeforeCreate sets default values before inserting a new webhook event
+func (e *WebhookEvent) BeforeCreate() {
+	now := time.Now().UTC()
+	if e.ID.IsZero() {
+		e.ID = primitive.NewObjectID()
+	}
+	e.CreatedAt = now
+	if e.ExpiresAt.IsZero() {
+		e.ExpiresAt = now.Add(WebhookEventRetention)
+	}
+}
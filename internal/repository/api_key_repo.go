@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+// MongoAPIKeyRepository implements APIKeyRepository for MongoDB
+// #ORM_INTEGRATION: MongoDB driver-based repository implementation
+type MongoAPIKeyRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoAPIKeyRepository creates a new MongoDB API key repository
+func NewMongoAPIKeyRepository(db *mongo.Database) *MongoAPIKeyRepository {
+	return &MongoAPIKeyRepository{
+		collection: db.Collection(models.APIKey{}.CollectionName()),
+	}
+}
+
+// Create stores a new API key
+func (r *MongoAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	key.BeforeCreate()
+	_, err := r.collection.InsertOne(ctx, key)
+	if mongo.IsDuplicateKeyError(err) {
+		return models.ErrAlreadyExists
+	}
+	return err
+}
+
+// GetByHash finds an API key by the hash of its plaintext value
+func (r *MongoAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	var key models.APIKey
+	filter := bson.M{
+		"key_hash": keyHash,
+	}
+	err := r.collection.FindOne(ctx, filter).Decode(&key)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// ListByOrganization lists API keys of an organization, newest first
+func (r *MongoAPIKeyRepository) ListByOrganization(ctx context.Context, orgID primitive.ObjectID) ([]models.APIKey, error) {
+	filter := bson.M{
+		"organization_id": orgID,
+	}
+	findOpts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	keys := []models.APIKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Revoke revokes an API key owned by the organization
+func (r *MongoAPIKeyRepository) Revoke(ctx context.Context, orgID, id primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":             id,
+		"organization_id": orgID,
+		"revoked_at":      nil,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"revoked_at": time.Now().UTC(),
+		},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// UpdateLastUsed records when an API key was last used
+func (r *MongoAPIKeyRepository) UpdateLastUsed(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{
+		"_id": id,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"last_used_at": time.Now().UTC(),
+		},
+	}
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// Ensure MongoAPIKeyRepository implements APIKeyRepository
+var _ APIKeyRepository = (*MongoAPIKeyRepository)(nil)
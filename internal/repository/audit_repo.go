@@ -23,22 +23,31 @@ type AuditRepository interface {
 	// GetByID retrieves an audit log by ID
 	GetByID(ctx context.Context, id primitive.ObjectID) (*models.AuditLog, error)
 
-	// ListByResource lists audit logs for a specific resource
-	ListByResource(ctx context.Context, resourceType string, resourceID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
+	// ListByResource lists audit logs for a specific resource created between the optional from and to
+	ListByResource(ctx context.Context, resourceType string, resourceID primitive.ObjectID, from, to *time.Time, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
 
-	// ListByActor lists audit logs by actor
-	ListByActor(ctx context.Context, actorUserID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
+	// ListByActor lists audit logs by actor created between the optional from and to
+	ListByActor(ctx context.Context, actorUserID primitive.ObjectID, from, to *time.Time, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
 
-	// ListByOrganization lists audit logs for an organization
-	ListByOrganization(ctx context.Context, orgID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
+	// ListByOrganization lists audit logs for an organization, narrowed by the optional filter
+	ListByOrganization(ctx context.Context, orgID primitive.ObjectID, filter AuditLogFilter, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
 
-	// ListByAction lists audit logs by action type
-	ListByAction(ctx context.Context, action models.AuditAction, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
+	// ListByAction lists audit logs by action type created between the optional from and to
+	ListByAction(ctx context.Context, action models.AuditAction, from, to *time.Time, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
 
 	// ListByDateRange lists audit logs within a date range
 	ListByDateRange(ctx context.Context, startDate, endDate time.Time, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
 }
 
+// AuditLogFilter narrows organization audit log queries; zero values are ignored
+type AuditLogFilter struct {
+	Action       *models.AuditAction
+	ResourceType string
+	ActorUserID  *primitive.ObjectID
+	From         *time.Time
+	To           *time.Time
+}
+
 // MongoAuditRepository implements AuditRepository for MongoDB
 type MongoAuditRepository struct {
 	collection *mongo.Collection
@@ -71,30 +80,47 @@ func (r *MongoAuditRepository) GetByID(ctx context.Context, id primitive.ObjectI
 	return &log, nil
 }
 
-// ListByResource lists audit logs for a specific resource
-func (r *MongoAuditRepository) ListByResource(ctx context.Context, resourceType string, resourceID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error) {
+// ListByResource lists audit logs for a specific resource created between the optional from and to
+// #INDEX_STRATEGY: Uses idx_resource_created
+func (r *MongoAuditRepository) ListByResource(ctx context.Context, resourceType string, resourceID primitive.ObjectID, from, to *time.Time, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error) {
 	filter := bson.M{
 		"resource_type": resourceType,
 		"resource_id":   resourceID,
 	}
+	addCreatedAtRange(filter, from, to)
 	return r.listWithPagination(ctx, filter, opts)
 }
 
-// ListByActor lists audit logs by actor
-func (r *MongoAuditRepository) ListByActor(ctx context.Context, actorUserID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error) {
+// ListByActor lists audit logs by actor created between the optional from and to
+// #INDEX_STRATEGY: Uses idx_actor_created
+func (r *MongoAuditRepository) ListByActor(ctx context.Context, actorUserID primitive.ObjectID, from, to *time.Time, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error) {
 	filter := bson.M{"actor_user_id": actorUserID}
+	addCreatedAtRange(filter, from, to)
 	return r.listWithPagination(ctx, filter, opts)
 }
 
-// ListByOrganization lists audit logs for an organization
-func (r *MongoAuditRepository) ListByOrganization(ctx context.Context, orgID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error) {
+// ListByOrganization lists audit logs for an organization, narrowed by the optional filter
+// #INDEX_STRATEGY: Uses idx_org_created; other filter fields are applied on that range
+func (r *MongoAuditRepository) ListByOrganization(ctx context.Context, orgID primitive.ObjectID, f AuditLogFilter, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error) {
 	filter := bson.M{"actor_org_id": orgID}
+	if f.Action != nil {
+		filter["action"] = *f.Action
+	}
+	if f.ResourceType != "" {
+		filter["resource_type"] = f.ResourceType
+	}
+	if f.ActorUserID != nil {
+		filter["actor_user_id"] = *f.ActorUserID
+	}
+	addCreatedAtRange(filter, f.From, f.To)
 	return r.listWithPagination(ctx, filter, opts)
 }
 
-// ListByAction lists audit logs by action type
-func (r *MongoAuditRepository) ListByAction(ctx context.Context, action models.AuditAction, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error) {
+// ListByAction lists audit logs by action type created between the optional from and to
+// #INDEX_STRATEGY: Uses idx_action_created
+func (r *MongoAuditRepository) ListByAction(ctx context.Context, action models.AuditAction, from, to *time.Time, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error) {
 	filter := bson.M{"action": action}
+	addCreatedAtRange(filter, from, to)
 	return r.listWithPagination(ctx, filter, opts)
 }
 
@@ -109,6 +135,22 @@ func (r *MongoAuditRepository) ListByDateRange(ctx context.Context, startDate, e
 	return r.listWithPagination(ctx, filter, opts)
 }
 
+// addCreatedAtRange restricts filter to entries created between from and to, both inclusive;
+// a nil bound leaves that side open
+func addCreatedAtRange(filter bson.M, from, to *time.Time) {
+	if from == nil && to == nil {
+		return
+	}
+	createdAt := bson.M{}
+	if from != nil {
+		createdAt["$gte"] = *from
+	}
+	if to != nil {
+		createdAt["$lte"] = *to
+	}
+	filter["created_at"] = createdAt
+}
+
 // listWithPagination is a helper for paginated queries
 func (r *MongoAuditRepository) listWithPagination(ctx context.Context, filter bson.M, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error) {
 	// Count total
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+// assertFindFilter checks that the last command was a find on _id $in wantIDs, excluding
+// soft-deleted documents when wantNotDeleted is set
+func assertFindFilter(t *testing.T, mt *mtest.T, wantIDs []primitive.ObjectID, wantNotDeleted bool) {
+	t.Helper()
+
+	started := mt.GetStartedEvent()
+	if started == nil || started.CommandName != "find" {
+		t.Fatalf("Expected a find command, got %+v", started)
+	}
+	filter := started.Command.Lookup("filter").Document()
+
+	var gotIDs []primitive.ObjectID
+	values, err := filter.Lookup("_id", "$in").Array().Values()
+	if err != nil {
+		t.Fatalf("Expected an _id $in filter, got %v", filter)
+	}
+	for _, value := range values {
+		gotIDs = append(gotIDs, value.ObjectID())
+	}
+	if !slices.Equal(gotIDs, wantIDs) {
+		t.Errorf("Expected _id $in %v, got %v", wantIDs, gotIDs)
+	}
+
+	deletedAt, err := filter.LookupErr("deleted_at")
+	if wantNotDeleted && (err != nil || deletedAt.Type != bson.TypeNull) {
+		t.Errorf("Expected deleted_at: null in the filter, got %v", filter)
+	}
+	if !wantNotDeleted && err == nil {
+		t.Errorf("Expected no deleted_at condition, got %v", filter)
+	}
+}
+
+func TestGetByIDs_ReturnsOnlyFoundDocuments(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	found := primitive.NewObjectID()
+	missing := primitive.NewObjectID()
+	ids := []primitive.ObjectID{found, missing}
+
+	mt.Run("organizations", func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + models.Organization{}.CollectionName()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{Key: "_id", Value: found}, {Key: "name", Value: "Acme"}}),
+		)
+
+		orgs, err := NewMongoOrganizationRepository(mt.DB).GetByIDs(context.Background(), ids)
+		if err != nil {
+			t.Fatalf("GetByIDs failed: %v", err)
+		}
+		assertFindFilter(t, mt, ids, true)
+		if len(orgs) != 1 || orgs[found] == nil || orgs[found].Name != "Acme" {
+			t.Errorf("Expected only the found organization, got %v", orgs)
+		}
+		if _, ok := orgs[missing]; ok {
+			t.Error("Expected missing ID to be absent")
+		}
+	})
+
+	mt.Run("users", func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + models.User{}.CollectionName()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{Key: "_id", Value: found}, {Key: "email", Value: "a@example.com"}}),
+		)
+
+		users, err := NewMongoUserRepository(mt.DB).GetByIDs(context.Background(), ids)
+		if err != nil {
+			t.Fatalf("GetByIDs failed: %v", err)
+		}
+		assertFindFilter(t, mt, ids, true)
+		if len(users) != 1 || users[found] == nil || users[found].Email != "a@example.com" {
+			t.Errorf("Expected only the found user, got %v", users)
+		}
+		if _, ok := users[missing]; ok {
+			t.Error("Expected missing ID to be absent")
+		}
+	})
+
+	mt.Run("requirements with duplicates", func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + models.Requirement{}.CollectionName()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{Key: "_id", Value: found}, {Key: "title", Value: "ISO 27001"}}),
+		)
+
+		requirements, err := NewMongoRequirementRepository(mt.DB).GetByIDs(context.Background(), []primitive.ObjectID{found, missing, found})
+		if err != nil {
+			t.Fatalf("GetByIDs failed: %v", err)
+		}
+		// Duplicates are collapsed before querying
+		assertFindFilter(t, mt, ids, false)
+		if len(requirements) != 1 || requirements[found] == nil || requirements[found].Title != "ISO 27001" {
+			t.Errorf("Expected only the found requirement, got %v", requirements)
+		}
+		if _, ok := requirements[missing]; ok {
+			t.Error("Expected missing ID to be absent")
+		}
+	})
+
+	mt.Run("empty input skips query", func(mt *mtest.T) {
+		orgs, err := NewMongoOrganizationRepository(mt.DB).GetByIDs(context.Background(), nil)
+		if err != nil || len(orgs) != 0 {
+			t.Errorf("Expected empty result without error, got %v, %v", orgs, err)
+		}
+		if started := mt.GetStartedEvent(); started != nil {
+			t.Errorf("Expected no command, got %s", started.CommandName)
+		}
+	})
+}
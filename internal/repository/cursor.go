@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+// applyCursor restricts a filter to documents after the cursor
+// #QUERY_PATTERN: Keyset pagination on _id, newest first - served by (company_id, _id) indexes and
+// stable while documents are inserted or removed between pages
+func applyCursor(filter bson.M, opts CursorPaginationOptions) error {
+	if opts.After == "" {
+		return nil
+	}
+	after, err := primitive.ObjectIDFromHex(opts.After)
+	if err != nil {
+		return models.ErrInvalidCursor
+	}
+	filter["_id"] = bson.M{"$lt": after}
+	return nil
+}
+
+// cursorFindOptions sorts by _id and fetches one extra document to detect a next page
+func cursorFindOptions(opts CursorPaginationOptions) *options.FindOptions {
+	return options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(int64(opts.Limit) + 1)
+}
+
+// newCursorResult trims the look-ahead document and derives the next cursor
+func newCursorResult[T any](items []T, opts CursorPaginationOptions, idOf func(*T) primitive.ObjectID) *CursorResult[T] {
+	result := &CursorResult[T]{Items: items, Limit: opts.Limit}
+	if len(items) > opts.Limit {
+		result.Items = items[:opts.Limit]
+		result.NextCursor = idOf(&result.Items[opts.Limit-1]).Hex()
+	}
+	if result.Items == nil {
+		result.Items = []T{}
+	}
+	return result
+}
@@ -21,6 +21,21 @@ func NewSecureLinkRepository(client *database.Client) SecureLinkRepository {
 	return NewMongoSecureLinkRepository(client.Database())
 }
 
+// NewRefreshTokenRepository creates a new refresh token repository using our database client
+func NewRefreshTokenRepository(client *database.Client) RefreshTokenRepository {
+	return NewMongoRefreshTokenRepository(client.Database())
+}
+
+// NewRevokedTokenRepository creates a new revoked token repository using our database client
+func NewRevokedTokenRepository(client *database.Client) RevokedTokenRepository {
+	return NewMongoRevokedTokenRepository(client.Database())
+}
+
+// NewAPIKeyRepository creates a new API key repository using our database client
+func NewAPIKeyRepository(client *database.Client) APIKeyRepository {
+	return NewMongoAPIKeyRepository(client.Database())
+}
+
 // NewQuestionnaireTemplateRepository creates a new questionnaire template repository
 func NewQuestionnaireTemplateRepository(client *database.Client) QuestionnaireTemplateRepository {
 	return NewMongoQuestionnaireTemplateRepository(client.Database())
@@ -65,3 +80,28 @@ func NewVerificationRepository(client *database.Client) VerificationRepository {
 func NewAuditRepository(client *database.Client) AuditRepository {
 	return NewMongoAuditRepository(client.Database())
 }
+
+// NewWebhookEventRepository creates a new webhook event repository
+func NewWebhookEventRepository(client *database.Client) WebhookEventRepository {
+	return NewMongoWebhookEventRepository(client.Database())
+}
+
+// NewOutboxRepository creates a new email outbox repository
+func NewOutboxRepository(client *database.Client) OutboxRepository {
+	return NewMongoOutboxRepository(client.Database())
+}
+
+// NewSentEmailRepository creates a new sent email repository
+func NewSentEmailRepository(client *database.Client) SentEmailRepository {
+	return NewMongoSentEmailRepository(client.Database())
+}
+
+// NewIdempotencyRepository creates a new idempotency key repository
+func NewIdempotencyRepository(client *database.Client) IdempotencyRepository {
+	return NewMongoIdempotencyRepository(client.Database())
+}
+
+// NewWebhookRepository creates a new outbound webhook repository
+func NewWebhookRepository(client *database.Client) WebhookRepository {
+	return NewMongoWebhookRepository(client.Database())
+}
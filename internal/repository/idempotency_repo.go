@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+// MongoIdempotencyRepository implements IdempotencyRepository for MongoDB
+// #ORM_INTEGRATION: MongoDB driver-based repository implementation
+type MongoIdempotencyRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoIdempotencyRepository creates a new MongoDB idempotency key repository
+func NewMongoIdempotencyRepository(db *mongo.Database) *MongoIdempotencyRepository {
+	return &MongoIdempotencyRepository{
+		collection: db.Collection(models.IdempotencyKey{}.CollectionName()),
+	}
+}
+
+// Reserve stores a new key, or returns the existing record if the key is already in use
+// #IMPLEMENTATION_DECISION: The unique index arbitrates concurrent retries - exactly one insert wins.
+// The TTL monitor runs about once a minute, so an expired key that still exists is replaced
+func (r *MongoIdempotencyRepository) Reserve(ctx context.Context, key *models.IdempotencyKey) (*models.IdempotencyKey, error) {
+	key.BeforeCreate()
+
+	for attempt := 0; attempt < 2; attempt++ {
+		_, err := r.collection.InsertOne(ctx, key)
+		if err == nil {
+			return nil, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+
+		var existing models.IdempotencyKey
+		err = r.collection.FindOne(ctx, bson.M{
+			"organization_id": key.OrganizationID,
+			"route":           key.Route,
+			"key":             key.Key,
+		}).Decode(&existing)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			// Removed between the insert and the lookup
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if existing.ExpiresAt.After(time.Now().UTC()) {
+			return &existing, nil
+		}
+
+		if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": existing.ID}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Lost the race for a just-freed key to another request
+	return nil, errors.New("idempotency key is contended")
+}
+
+// Complete stores the response of the original request
+func (r *MongoIdempotencyRepository) Complete(ctx context.Context, id primitive.ObjectID, statusCode int, contentType string, body []byte, resourceID string) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{
+			"completed":     true,
+			"status_code":   statusCode,
+			"content_type":  contentType,
+			"response_body": body,
+			"resource_id":   resourceID,
+		},
+	})
+	return err
+}
+
+// Release removes a reservation so the key can be used again
+func (r *MongoIdempotencyRepository) Release(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// Ensure MongoIdempotencyRepository implements IdempotencyRepository
+var _ IdempotencyRepository = (*MongoIdempotencyRepository)(nil)
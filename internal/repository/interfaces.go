@@ -4,6 +4,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
@@ -38,6 +39,28 @@ type PaginatedResult[T any] struct {
 	TotalPages int   `json:"total_pages"`
 }
 
+// CursorPaginationOptions contains keyset pagination parameters
+// #IMPLEMENTATION_DECISION: Offered alongside offset pagination for large lists, where skip
+// gets slow and pages can skip or repeat rows as data changes
+type CursorPaginationOptions struct {
+	After string // Opaque cursor from a previous page; empty for the first page
+	Limit int
+}
+
+// CursorResult contains keyset-paginated query results
+type CursorResult[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"` // Empty on the last page
+	Limit      int    `json:"limit"`
+}
+
+// TagFilter restricts a template listing to tagged templates; no tags means no restriction
+// #IMPLEMENTATION_DECISION: A template matches if it has any of the tags, or all of them with MatchAll
+type TagFilter struct {
+	Tags     []string
+	MatchAll bool
+}
+
 // OrganizationRepository defines operations for organizations
 // #QUERY_INTERFACE: Organization data access patterns
 type OrganizationRepository interface {
@@ -47,10 +70,13 @@ type OrganizationRepository interface {
 	// GetByID finds an organization by ID
 	GetByID(ctx context.Context, id primitive.ObjectID) (*models.Organization, error)
 
+	// GetByIDs finds organizations by ID in one query, keyed by ID; missing IDs are omitted
+	GetByIDs(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]*models.Organization, error)
+
 	// GetBySlug finds an organization by slug
 	GetBySlug(ctx context.Context, slug string) (*models.Organization, error)
 
-	// GetByDomain finds an organization by domain
+	// GetByDomain finds an organization by its primary or any verified domain
 	GetByDomain(ctx context.Context, domain string) (*models.Organization, error)
 
 	// Update updates an organization
@@ -59,8 +85,14 @@ type OrganizationRepository interface {
 	// SoftDelete soft deletes an organization
 	SoftDelete(ctx context.Context, id primitive.ObjectID) error
 
+	// SetLogo replaces the organization's logo reference
+	SetLogo(ctx context.Context, id primitive.ObjectID, logo models.LogoRef) error
+
 	// List lists organizations with filtering and pagination
 	List(ctx context.Context, orgType *models.OrganizationType, opts PaginationOptions) (*PaginatedResult[models.Organization], error)
+
+	// SearchByName full-text searches the names of the given organizations, best match first
+	SearchByName(ctx context.Context, ids []primitive.ObjectID, query string, limit int) ([]models.Organization, error)
 }
 
 // UserRepository defines operations for users
@@ -72,6 +104,9 @@ type UserRepository interface {
 	// GetByID finds a user by ID
 	GetByID(ctx context.Context, id primitive.ObjectID) (*models.User, error)
 
+	// GetByIDs finds users by ID in one query, keyed by ID; missing IDs are omitted
+	GetByIDs(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]*models.User, error)
+
 	// GetByEmail finds a user by email
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 
@@ -84,11 +119,17 @@ type UserRepository interface {
 	// UpdateLastLogin updates the last login timestamp
 	UpdateLastLogin(ctx context.Context, id primitive.ObjectID) error
 
+	// SetPasswordHash stores a user's password hash and when it was set
+	SetPasswordHash(ctx context.Context, id primitive.ObjectID, hash string) error
+
 	// ListByOrganization lists users in an organization
 	ListByOrganization(ctx context.Context, orgID primitive.ObjectID, includeInactive bool, opts PaginationOptions) (*PaginatedResult[models.User], error)
 
 	// CountByOrganization counts users in an organization
 	CountByOrganization(ctx context.Context, orgID primitive.ObjectID) (int64, error)
+
+	// CountByOrganizationAndRole counts active users with a given role in an organization
+	CountByOrganizationAndRole(ctx context.Context, orgID primitive.ObjectID, role models.UserRole) (int64, error)
 }
 
 // SecureLinkRepository defines operations for secure links
@@ -109,6 +150,9 @@ type SecureLinkRepository interface {
 	// InvalidateAllForEmail invalidates all links for an email
 	InvalidateAllForEmail(ctx context.Context, email string) error
 
+	// InvalidateAllForUser invalidates all links of a type issued to a user
+	InvalidateAllForUser(ctx context.Context, userID primitive.ObjectID, linkType models.SecureLinkType) error
+
 	// CountRecentByEmail counts recent links for rate limiting
 	CountRecentByEmail(ctx context.Context, email string, withinMinutes int) (int64, error)
 
@@ -116,6 +160,121 @@ type SecureLinkRepository interface {
 	DeleteExpired(ctx context.Context) (int64, error)
 }
 
+// RefreshTokenRepository defines operations for refresh token tracking
+// #QUERY_INTERFACE: Refresh token rotation and reuse detection
+type RefreshTokenRepository interface {
+	// Create records a newly issued refresh token
+	Create(ctx context.Context, token *models.RefreshToken) error
+
+	// GetByTokenID finds a refresh token by its jti
+	GetByTokenID(ctx context.Context, tokenID string) (*models.RefreshToken, error)
+
+	// MarkAsUsed atomically marks an unused refresh token as exchanged for replacedBy
+	MarkAsUsed(ctx context.Context, tokenID, replacedBy string) error
+
+	// RevokeFamily revokes every token in a rotation chain
+	RevokeFamily(ctx context.Context, familyID string) error
+
+	// RevokeAllForUser revokes every refresh token issued to a user
+	RevokeAllForUser(ctx context.Context, userID primitive.ObjectID) error
+}
+
+// RevokedTokenRepository defines operations for the access token denylist
+// #QUERY_INTERFACE: Access token revocation (logout, user removal)
+type RevokedTokenRepository interface {
+	// Revoke adds a single token to the denylist until it expires
+	Revoke(ctx context.Context, tokenID string, userID primitive.ObjectID, expiresAt time.Time) error
+
+	// RevokeAllForUser denies every token of the user issued up to now
+	RevokeAllForUser(ctx context.Context, userID primitive.ObjectID, expiresAt time.Time) error
+
+	// IsRevoked checks whether a token is denied
+	IsRevoked(ctx context.Context, tokenID string, userID primitive.ObjectID, issuedAt time.Time) (bool, error)
+}
+
+// APIKeyRepository defines operations for organization API keys
+// #QUERY_INTERFACE: API key minting, lookup by hash and revocation
+type APIKeyRepository interface {
+	// Create stores a new API key
+	Create(ctx context.Context, key *models.APIKey) error
+
+	// GetByHash finds an API key by the hash of its plaintext value
+	GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+
+	// ListByOrganization lists API keys of an organization, newest first
+	ListByOrganization(ctx context.Context, orgID primitive.ObjectID) ([]models.APIKey, error)
+
+	// Revoke revokes an API key owned by the organization
+	Revoke(ctx context.Context, orgID, id primitive.ObjectID) error
+
+	// UpdateLastUsed records when an API key was last used
+	UpdateLastUsed(ctx context.Context, id primitive.ObjectID) error
+}
+
+// WebhookRepository defines operations for outbound webhooks
+type WebhookRepository interface {
+	// Create stores a new webhook
+	Create(ctx context.Context, webhook *models.Webhook) error
+
+	// ListByOrganization lists webhooks of an organization, newest first
+	ListByOrganization(ctx context.Context, orgID primitive.ObjectID) ([]models.Webhook, error)
+
+	// CountByOrganization counts webhooks of an organization
+	CountByOrganization(ctx context.Context, orgID primitive.ObjectID) (int64, error)
+
+	// ListSubscribed lists webhooks of an organization subscribed to an event
+	ListSubscribed(ctx context.Context, orgID primitive.ObjectID, event models.WebhookEventType) ([]models.Webhook, error)
+
+	// Delete deletes a webhook owned by the organization
+	Delete(ctx context.Context, orgID, id primitive.ObjectID) error
+
+	// RecordDelivery stores the outcome of the latest delivery attempt
+	RecordDelivery(ctx context.Context, id primitive.ObjectID, result models.WebhookDeliveryResult) error
+}
+
+// OutboxRepository defines operations for queued outgoing emails
+type OutboxRepository interface {
+	// Create queues a new email
+	Create(ctx context.Context, email *models.OutboxEmail) error
+
+	// ClaimDue atomically claims the oldest pending email due at now by pushing its next attempt
+	// lease into the future; returns nil when nothing is due
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*models.OutboxEmail, error)
+
+	// MarkSent records a successful delivery and the mail provider's message ID
+	MarkSent(ctx context.Context, id primitive.ObjectID, providerMessageID string) error
+
+	// RecordFailure records a failed attempt; a nil nextAttemptAt marks the email permanently failed
+	RecordFailure(ctx context.Context, id primitive.ObjectID, lastError string, nextAttemptAt *time.Time) error
+
+	// ListFailedByOrganization lists permanently failed emails of an organization, newest first
+	ListFailedByOrganization(ctx context.Context, orgID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.OutboxEmail], error)
+}
+
+// SentEmailRepository defines operations for records of emails handed to the mail provider
+type SentEmailRepository interface {
+	// Create stores a send record
+	Create(ctx context.Context, email *models.SentEmail) error
+
+	// ListByOrganization lists send records of an organization, newest first; a non-empty
+	// recipient limits the list to that address
+	ListByOrganization(ctx context.Context, orgID primitive.ObjectID, recipient string, opts PaginationOptions) (*PaginatedResult[models.SentEmail], error)
+}
+
+// IdempotencyRepository defines operations for idempotency keys of create requests
+// #INTEGRATION_POINT: Implements middleware.IdempotencyStore
+type IdempotencyRepository interface {
+	// Reserve stores a new key; if the organization already used the key on the route and it
+	// has not expired, the existing record is returned instead
+	Reserve(ctx context.Context, key *models.IdempotencyKey) (*models.IdempotencyKey, error)
+
+	// Complete stores the response of the original request
+	Complete(ctx context.Context, id primitive.ObjectID, statusCode int, contentType string, body []byte, resourceID string) error
+
+	// Release removes a reservation so the key can be used again
+	Release(ctx context.Context, id primitive.ObjectID) error
+}
+
 // QuestionnaireTemplateRepository defines operations for questionnaire templates
 // #QUERY_INTERFACE: Template data access patterns
 type QuestionnaireTemplateRepository interface {
@@ -134,8 +293,23 @@ type QuestionnaireTemplateRepository interface {
 	// IncrementUsageCount increments the usage count
 	IncrementUsageCount(ctx context.Context, id primitive.ObjectID) error
 
-	// ListSystemTemplates lists all system templates
-	ListSystemTemplates(ctx context.Context, category *models.TemplateCategory) ([]models.QuestionnaireTemplate, error)
+	// DecrementUsageCount decrements the usage count; a count of zero is left unchanged
+	DecrementUsageCount(ctx context.Context, id primitive.ObjectID) error
+
+	// MarkSuperseded points a template at its newer version; fails with ErrTemplateSuperseded if already set
+	MarkSuperseded(ctx context.Context, id, supersededBy primitive.ObjectID) error
+
+	// ClearSuperseded removes the superseded pointer if it still references the given version
+	ClearSuperseded(ctx context.Context, id, supersededBy primitive.ObjectID) error
+
+	// ListVersions lists every version in a lineage, oldest first
+	ListVersions(ctx context.Context, rootID primitive.ObjectID) ([]models.QuestionnaireTemplate, error)
+
+	// ListSystemTemplates lists all system templates, excluding superseded versions unless requested
+	ListSystemTemplates(ctx context.Context, category *models.TemplateCategory, tags TagFilter, includeSuperseded bool) ([]models.QuestionnaireTemplate, error)
+
+	// ListByTags lists current system and globally published templates matching the tags
+	ListByTags(ctx context.Context, tags TagFilter, opts PaginationOptions) (*PaginatedResult[models.QuestionnaireTemplate], error)
 
 	// ListByOrganization lists templates created by an organization
 	ListByOrganization(ctx context.Context, orgID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.QuestionnaireTemplate], error)
@@ -145,7 +319,7 @@ type QuestionnaireTemplateRepository interface {
 
 	// ListAvailableTemplates lists templates available to an organization
 	// Returns: system templates + globally published + org's own templates (any visibility)
-	ListAvailableTemplates(ctx context.Context, orgID primitive.ObjectID, category *models.TemplateCategory, opts PaginationOptions) (*PaginatedResult[models.QuestionnaireTemplate], error)
+	ListAvailableTemplates(ctx context.Context, orgID primitive.ObjectID, category *models.TemplateCategory, tags TagFilter, includeSuperseded bool, opts PaginationOptions) (*PaginatedResult[models.QuestionnaireTemplate], error)
 
 	// ListByUser lists templates created by a specific user
 	ListByUser(ctx context.Context, userID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.QuestionnaireTemplate], error)
@@ -160,20 +334,41 @@ type QuestionnaireRepository interface {
 	// GetByID finds a questionnaire by ID
 	GetByID(ctx context.Context, id primitive.ObjectID) (*models.Questionnaire, error)
 
+	// GetByIDs finds questionnaires by ID in one query, keyed by ID, including soft-deleted ones; missing IDs are omitted
+	GetByIDs(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]*models.Questionnaire, error)
+
 	// Update updates a questionnaire
 	Update(ctx context.Context, questionnaire *models.Questionnaire) error
 
-	// Delete deletes a questionnaire (draft only)
+	// Delete soft-deletes a questionnaire (draft only)
 	Delete(ctx context.Context, id primitive.ObjectID) error
 
+	// Restore restores a soft-deleted questionnaire of a company
+	Restore(ctx context.Context, id, companyID primitive.ObjectID) error
+
+	// ListDeletedBefore lists the IDs of questionnaires soft-deleted before the given time
+	ListDeletedBefore(ctx context.Context, before time.Time) ([]primitive.ObjectID, error)
+
+	// Purge permanently deletes a soft-deleted questionnaire
+	Purge(ctx context.Context, id primitive.ObjectID) error
+
 	// UpdateStatistics updates question count and max score
 	UpdateStatistics(ctx context.Context, id primitive.ObjectID, questionCount, maxScore int) error
 
 	// ListByCompany lists questionnaires for a company
 	ListByCompany(ctx context.Context, companyID primitive.ObjectID, status *models.QuestionnaireStatus, opts PaginationOptions) (*PaginatedResult[models.Questionnaire], error)
 
+	// ListPublishedByClassification lists a company's published questionnaires that target a supplier classification
+	ListPublishedByClassification(ctx context.Context, companyID primitive.ObjectID, classification models.SupplierClassification) ([]models.Questionnaire, error)
+
 	// CountByCompany counts questionnaires for a company
 	CountByCompany(ctx context.Context, companyID primitive.ObjectID, status *models.QuestionnaireStatus) (int64, error)
+
+	// ListIDsByCompany lists the IDs of a company's questionnaires that are not deleted
+	ListIDsByCompany(ctx context.Context, companyID primitive.ObjectID) ([]primitive.ObjectID, error)
+
+	// SearchByCompany full-text searches the names of a company's questionnaires, best match first
+	SearchByCompany(ctx context.Context, companyID primitive.ObjectID, query string, limit int) ([]models.Questionnaire, error)
 }
 
 // QuestionRepository defines operations for questions
@@ -200,6 +395,9 @@ type QuestionRepository interface {
 	// DeleteByQuestionnaire deletes all questions for a questionnaire
 	DeleteByQuestionnaire(ctx context.Context, questionnaireID primitive.ObjectID) (int64, error)
 
+	// DeleteByIDs deletes the given questions of a questionnaire
+	DeleteByIDs(ctx context.Context, questionnaireID primitive.ObjectID, ids []primitive.ObjectID) (int64, error)
+
 	// UpdateOrder updates the order of questions
 	UpdateOrder(ctx context.Context, questionnaireID primitive.ObjectID, orders map[primitive.ObjectID]int) error
 
@@ -208,6 +406,9 @@ type QuestionRepository interface {
 
 	// CountByQuestionnaire counts questions for a questionnaire
 	CountByQuestionnaire(ctx context.Context, questionnaireID primitive.ObjectID) (int64, error)
+
+	// SearchByControlRef lists questions of the given questionnaires whose control reference starts with prefix
+	SearchByControlRef(ctx context.Context, questionnaireIDs []primitive.ObjectID, prefix string, limit int) ([]models.Question, error)
 }
 
 // RelationshipRepository defines operations for company-supplier relationships
@@ -228,8 +429,17 @@ type RelationshipRepository interface {
 	// Update updates a relationship
 	Update(ctx context.Context, relationship *models.CompanySupplierRelationship) error
 
-	// ListByCompany lists relationships for a company
-	ListByCompany(ctx context.Context, companyID primitive.ObjectID, status *models.RelationshipStatus, classification *models.SupplierClassification, opts PaginationOptions) (*PaginatedResult[models.CompanySupplierRelationship], error)
+	// AddNote appends a note to a company's relationship note history
+	AddNote(ctx context.Context, id, companyID primitive.ObjectID, note models.RelationshipNote) error
+
+	// ListByCompany lists relationships for a company, matching any of statuses when given
+	ListByCompany(ctx context.Context, companyID primitive.ObjectID, statuses []models.RelationshipStatus, classification *models.SupplierClassification, opts PaginationOptions) (*PaginatedResult[models.CompanySupplierRelationship], error)
+
+	// ListByCompanyCursor lists relationships for a company using keyset pagination, newest first
+	ListByCompanyCursor(ctx context.Context, companyID primitive.ObjectID, statuses []models.RelationshipStatus, classification *models.SupplierClassification, opts CursorPaginationOptions) (*CursorResult[models.CompanySupplierRelationship], error)
+
+	// ForEachByCompany streams all matching relationships for a company in batches of at most batchSize
+	ForEachByCompany(ctx context.Context, companyID primitive.ObjectID, statuses []models.RelationshipStatus, classification *models.SupplierClassification, batchSize int, fn func([]models.CompanySupplierRelationship) error) error
 
 	// ListBySupplier lists relationships for a supplier
 	ListBySupplier(ctx context.Context, supplierID primitive.ObjectID, status *models.RelationshipStatus, opts PaginationOptions) (*PaginatedResult[models.CompanySupplierRelationship], error)
@@ -242,6 +452,23 @@ type RelationshipRepository interface {
 
 	// CountBySupplier counts relationships for a supplier
 	CountBySupplier(ctx context.Context, supplierID primitive.ObjectID, status *models.RelationshipStatus) (int64, error)
+
+	// CountByStatusAndClassification counts a company's relationships per status and classification in one aggregation
+	CountByStatusAndClassification(ctx context.Context, companyID primitive.ObjectID) ([]RelationshipGroupCount, error)
+
+	// ListSupplierIDsByCompany lists the IDs of the supplier organizations linked to a company
+	ListSupplierIDsByCompany(ctx context.Context, companyID primitive.ObjectID) ([]primitive.ObjectID, error)
+
+	// SearchByCompany lists a company's relationships whose invited email contains query or whose
+	// supplier is one of supplierIDs
+	SearchByCompany(ctx context.Context, companyID primitive.ObjectID, query string, supplierIDs []primitive.ObjectID, limit int) ([]models.CompanySupplierRelationship, error)
+}
+
+// RelationshipGroupCount is the number of a company's relationships with one status and classification
+type RelationshipGroupCount struct {
+	Status         models.RelationshipStatus     `bson:"status"`
+	Classification models.SupplierClassification `bson:"classification"`
+	Count          int64                         `bson:"count"`
 }
 
 // RequirementRepository defines operations for requirements
@@ -253,27 +480,43 @@ type RequirementRepository interface {
 	// GetByID finds a requirement by ID
 	GetByID(ctx context.Context, id primitive.ObjectID) (*models.Requirement, error)
 
+	// GetByIDs finds requirements by ID in one query, keyed by ID; missing IDs are omitted
+	GetByIDs(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]*models.Requirement, error)
+
 	// Update updates a requirement
 	Update(ctx context.Context, requirement *models.Requirement) error
 
-	// ListByCompany lists requirements for a company
-	ListByCompany(ctx context.Context, companyID primitive.ObjectID, status *models.RequirementStatus, opts PaginationOptions) (*PaginatedResult[models.Requirement], error)
+	// ListByCompany lists requirements for a company, matching any of statuses when given
+	ListByCompany(ctx context.Context, companyID primitive.ObjectID, statuses []models.RequirementStatus, opts PaginationOptions) (*PaginatedResult[models.Requirement], error)
+
+	// ListByCompanyCursor lists requirements for a company using keyset pagination, newest first
+	ListByCompanyCursor(ctx context.Context, companyID primitive.ObjectID, statuses []models.RequirementStatus, opts CursorPaginationOptions) (*CursorResult[models.Requirement], error)
 
-	// ListBySupplier lists requirements for a supplier
-	ListBySupplier(ctx context.Context, supplierID primitive.ObjectID, status *models.RequirementStatus, opts PaginationOptions) (*PaginatedResult[models.Requirement], error)
+	// ListBySupplier lists requirements for a supplier, optionally only those assigned to assigneeID;
+	// without a status filter cancelled requirements are left out
+	ListBySupplier(ctx context.Context, supplierID primitive.ObjectID, statuses []models.RequirementStatus, assigneeID *primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.Requirement], error)
 
-	// ListByRelationship lists requirements for a relationship
-	ListByRelationship(ctx context.Context, relationshipID primitive.ObjectID, status *models.RequirementStatus) ([]models.Requirement, error)
+	// ListByRelationship lists requirements for a relationship, matching any of statuses when given
+	ListByRelationship(ctx context.Context, relationshipID primitive.ObjectID, statuses []models.RequirementStatus) ([]models.Requirement, error)
 
 	// ListOverdue lists overdue requirements
 	ListOverdue(ctx context.Context, companyID *primitive.ObjectID) ([]models.Requirement, error)
 
+	// ListOverdueBySupplier lists a supplier's overdue requirements across all companies
+	ListOverdueBySupplier(ctx context.Context, supplierID primitive.ObjectID) ([]models.Requirement, error)
+
+	// CountOverdueBySupplier counts a supplier's overdue requirements across all companies
+	CountOverdueBySupplier(ctx context.Context, supplierID primitive.ObjectID) (int64, error)
+
 	// ListNeedingReminder lists requirements that need reminders
 	ListNeedingReminder(ctx context.Context, reminderDaysBefore int) ([]models.Requirement, error)
 
 	// MarkReminderSent marks a requirement's reminder as sent
 	MarkReminderSent(ctx context.Context, id primitive.ObjectID) error
 
+	// SetAssignee sets or, with a nil userID, clears the supplier team member owning a supplier's requirement
+	SetAssignee(ctx context.Context, id, supplierID primitive.ObjectID, userID *primitive.ObjectID) error
+
 	// ExpireOverdue marks overdue requirements as expired
 	ExpireOverdue(ctx context.Context) (int64, error)
 
@@ -282,6 +525,38 @@ type RequirementRepository interface {
 
 	// CountBySupplier counts requirements for a supplier
 	CountBySupplier(ctx context.Context, supplierID primitive.ObjectID, status *models.RequirementStatus) (int64, error)
+
+	// AddAttachment appends attachment metadata to a company's requirement, failing once maxAttachments is reached
+	AddAttachment(ctx context.Context, id, companyID primitive.ObjectID, attachment models.RequirementAttachment, maxAttachments int) error
+
+	// CountByRelationships counts requirements per relationship; relationships without matches are omitted
+	CountByRelationships(ctx context.Context, relationshipIDs []primitive.ObjectID, status *models.RequirementStatus) (map[primitive.ObjectID]int64, error)
+
+	// GetCompanyOverview summarizes a company's requirements in one aggregation; listLimit bounds
+	// the awaiting-review and top-supplier lists
+	GetCompanyOverview(ctx context.Context, companyID primitive.ObjectID, listLimit int) (*CompanyRequirementOverview, error)
+
+	// SearchByCompany full-text searches the titles of a company's requirements, best match first
+	SearchByCompany(ctx context.Context, companyID primitive.ObjectID, query string, limit int) ([]models.Requirement, error)
+}
+
+// CompanyRequirementOverview summarizes a company's requirements for its dashboard
+type CompanyRequirementOverview struct {
+	StatusCounts map[models.RequirementStatus]int64
+	Overdue      int64
+
+	// AwaitingReview are submitted requirements, longest waiting first
+	AwaitingReview []models.Requirement
+
+	// TopOutstanding are the suppliers with the most requirements awaiting their action
+	TopOutstanding []SupplierOutstandingCount
+}
+
+// SupplierOutstandingCount is the number of a supplier's requirements awaiting its action
+type SupplierOutstandingCount struct {
+	SupplierID  primitive.ObjectID `bson:"_id"`
+	Outstanding int64              `bson:"outstanding"`
+	Overdue     int64              `bson:"overdue"`
 }
 
 // ResponseRepository defines operations for supplier responses
@@ -302,6 +577,17 @@ type ResponseRepository interface {
 	// SaveDraftAnswer saves a draft answer
 	SaveDraftAnswer(ctx context.Context, responseID primitive.ObjectID, answer models.DraftAnswer) error
 
+	// ReplaceDraftAnswers replaces all draft answers if the response is unsubmitted and still at
+	// expectedVersion, returning the new version, ErrResponseAlreadySubmitted or ErrResponseConflict
+	ReplaceDraftAnswers(ctx context.Context, responseID primitive.ObjectID, expectedVersion int, answers []models.DraftAnswer) (int, error)
+
+	// DeleteDraftAnswer removes the draft answer for a question from an unsubmitted response and
+	// returns the updated response
+	DeleteDraftAnswer(ctx context.Context, responseID, questionID primitive.ObjectID) (*models.SupplierResponse, error)
+
+	// AddClarification appends a message to a response's clarification threads
+	AddClarification(ctx context.Context, responseID primitive.ObjectID, clarification models.Clarification) error
+
 	// ListBySupplier lists responses for a supplier
 	ListBySupplier(ctx context.Context, supplierID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.SupplierResponse], error)
 
@@ -321,11 +607,45 @@ type SubmissionRepository interface {
 	// GetByResponse finds a submission by response ID
 	GetByResponse(ctx context.Context, responseID primitive.ObjectID) (*models.QuestionnaireSubmission, error)
 
-	// ListByQuestionnaire lists submissions for a questionnaire
-	ListByQuestionnaire(ctx context.Context, questionnaireID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.QuestionnaireSubmission], error)
+	// ListByQuestionnaire lists submissions for a questionnaire, optionally only passed or failed ones
+	ListByQuestionnaire(ctx context.Context, questionnaireID primitive.ObjectID, passed *bool, opts PaginationOptions) (*PaginatedResult[models.QuestionnaireSubmission], error)
+
+	// ForEachByCompany streams a company's submissions submitted within [from, to] in batches of at most batchSize;
+	// answers are not loaded
+	ForEachByCompany(ctx context.Context, companyID primitive.ObjectID, from, to *time.Time, batchSize int, fn func([]models.QuestionnaireSubmission) error) error
 
 	// GetPassRateByQuestionnaire calculates pass rate for a questionnaire
 	GetPassRateByQuestionnaire(ctx context.Context, questionnaireID primitive.ObjectID) (float64, error)
+
+	// GetSummaryByQuestionnaire aggregates submission count, passes and average score for a questionnaire
+	GetSummaryByQuestionnaire(ctx context.Context, questionnaireID primitive.ObjectID) (*SubmissionSummary, error)
+
+	// GetQuestionFailureStats counts answers and failed answers per question for a questionnaire
+	GetQuestionFailureStats(ctx context.Context, questionnaireID primitive.ObjectID) ([]QuestionFailureStat, error)
+
+	// GetTopicScoreStats averages topic percentage scores for a questionnaire
+	GetTopicScoreStats(ctx context.Context, questionnaireID primitive.ObjectID) ([]TopicScoreStat, error)
+}
+
+// SubmissionSummary aggregates the submitted submissions of a questionnaire
+type SubmissionSummary struct {
+	Submissions  int64   `bson:"submissions"`
+	Passed       int64   `bson:"passed"`
+	AverageScore float64 `bson:"average_score"` // Mean percentage score
+}
+
+// QuestionFailureStat counts how often a question was answered and failed
+// #BUSINESS_RULE: An answer fails when it earns less than the question's max points
+type QuestionFailureStat struct {
+	QuestionID primitive.ObjectID `bson:"_id"`
+	Answered   int64              `bson:"answered"`
+	Failed     int64              `bson:"failed"`
+}
+
+// TopicScoreStat is the mean percentage score of a topic across submissions
+type TopicScoreStat struct {
+	TopicID      string  `bson:"_id"`
+	AverageScore float64 `bson:"average_score"`
 }
 
 // VerificationRepository defines operations for CheckFix verifications
@@ -343,6 +663,9 @@ type VerificationRepository interface {
 	// GetLatestBySupplier finds the latest verification for a supplier
 	GetLatestBySupplier(ctx context.Context, supplierID primitive.ObjectID) (*models.CheckFixVerification, error)
 
+	// ListBySupplier lists a supplier's verifications, newest first
+	ListBySupplier(ctx context.Context, supplierID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.CheckFixVerification], error)
+
 	// Update updates a verification
 	Update(ctx context.Context, verification *models.CheckFixVerification) error
 
@@ -350,6 +673,16 @@ type VerificationRepository interface {
 	ListExpiringVerifications(ctx context.Context, daysBeforeExpiry int) ([]models.CheckFixVerification, error)
 }
 
+// WebhookEventRepository defines operations for inbound webhook deduplication
+// #QUERY_INTERFACE: Claim-once semantics for webhook event IDs
+type WebhookEventRepository interface {
+	// Claim records an event as being processed; returns false if it was already claimed
+	Claim(ctx context.Context, event *models.WebhookEvent) (bool, error)
+
+	// Release removes a claim so the event can be processed again when redelivered
+	Release(ctx context.Context, source, eventID string) error
+}
+
 // AuditLogRepository defines operations for audit logs
 // #QUERY_INTERFACE: Audit log data access patterns
 type AuditLogRepository interface {
@@ -359,15 +692,15 @@ type AuditLogRepository interface {
 	// CreateAsync creates an audit log entry asynchronously
 	CreateAsync(log *models.AuditLog)
 
-	// ListByActor lists audit logs by actor
-	ListByActor(ctx context.Context, userID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
+	// ListByActor lists audit logs by actor created between the optional from and to
+	ListByActor(ctx context.Context, userID primitive.ObjectID, from, to *time.Time, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
 
-	// ListByResource lists audit logs by resource
-	ListByResource(ctx context.Context, resourceType string, resourceID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
+	// ListByResource lists audit logs by resource created between the optional from and to
+	ListByResource(ctx context.Context, resourceType string, resourceID primitive.ObjectID, from, to *time.Time, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
 
 	// ListByOrganization lists audit logs by organization
 	ListByOrganization(ctx context.Context, orgID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
 
-	// ListByAction lists audit logs by action type
-	ListByAction(ctx context.Context, action models.AuditAction, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
+	// ListByAction lists audit logs by action type created between the optional from and to
+	ListByAction(ctx context.Context, action models.AuditAction, from, to *time.Time, opts PaginationOptions) (*PaginatedResult[models.AuditLog], error)
 }
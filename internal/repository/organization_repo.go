@@ -53,6 +53,37 @@ func (r *MongoOrganizationRepository) GetByID(ctx context.Context, id primitive.
 	return &org, nil
 }
 
+// GetByIDs finds organizations by ID in one query, keyed by ID
+// #QUERY_PATTERN: Single $in lookup so list handlers can resolve names without N+1 queries
+func (r *MongoOrganizationRepository) GetByIDs(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]*models.Organization, error) {
+	orgs := make(map[primitive.ObjectID]*models.Organization, len(ids))
+	if len(ids) == 0 {
+		return orgs, nil
+	}
+
+	filter := bson.M{
+		"_id":        bson.M{"$in": ids},
+		"deleted_at": nil,
+	}
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	for cursor.Next(ctx) {
+		var org models.Organization
+		if err := cursor.Decode(&org); err != nil {
+			return nil, err
+		}
+		orgs[org.ID] = &org
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
+
 // GetBySlug finds an organization by slug
 func (r *MongoOrganizationRepository) GetBySlug(ctx context.Context, slug string) (*models.Organization, error) {
 	var org models.Organization
@@ -71,10 +102,14 @@ func (r *MongoOrganizationRepository) GetBySlug(ctx context.Context, slug string
 }
 
 // GetByDomain finds an organization by domain
+// #DATA_ASSUMPTION: Matches the primary domain and every additional verified domain
 func (r *MongoOrganizationRepository) GetByDomain(ctx context.Context, domain string) (*models.Organization, error) {
 	var org models.Organization
 	filter := bson.M{
-		"domain":     domain,
+		"$or": bson.A{
+			bson.M{"domain": domain},
+			bson.M{"domains": domain},
+		},
 		"deleted_at": nil,
 	}
 	err := r.collection.FindOne(ctx, filter).Decode(&org)
@@ -94,7 +129,10 @@ func (r *MongoOrganizationRepository) Update(ctx context.Context, org *models.Or
 		"_id":        org.ID,
 		"deleted_at": nil,
 	}
-	update := bson.M{"$set": org}
+	// #IMPLEMENTATION_DECISION: The logo is only written by SetLogo so a stale copy cannot revert an upload
+	doc := *org
+	doc.Logo = nil
+	update := bson.M{"$set": doc}
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		if mongo.IsDuplicateKeyError(err) {
@@ -108,6 +146,28 @@ func (r *MongoOrganizationRepository) Update(ctx context.Context, org *models.Or
 	return nil
 }
 
+// SetLogo replaces the organization's logo reference
+func (r *MongoOrganizationRepository) SetLogo(ctx context.Context, id primitive.ObjectID, logo models.LogoRef) error {
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": nil,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"logo":       logo,
+			"updated_at": time.Now().UTC(),
+		},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrOrganizationNotFound
+	}
+	return nil
+}
+
 // SoftDelete soft deletes an organization
 func (r *MongoOrganizationRepository) SoftDelete(ctx context.Context, id primitive.ObjectID) error {
 	now := time.Now().UTC()
@@ -176,5 +236,35 @@ func (r *MongoOrganizationRepository) List(ctx context.Context, orgType *models.
 	}, nil
 }
 
+// SearchByName full-text searches the names of the given organizations, best match first
+// #QUERY_PATTERN: $text restricted to an ID set so callers can scope the search to related organizations
+func (r *MongoOrganizationRepository) SearchByName(ctx context.Context, ids []primitive.ObjectID, query string, limit int) ([]models.Organization, error) {
+	if len(ids) == 0 {
+		return []models.Organization{}, nil
+	}
+
+	filter := bson.M{
+		"$text":      bson.M{"$search": query},
+		"_id":        bson.M{"$in": ids},
+		"deleted_at": nil,
+	}
+	findOpts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}}).
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var orgs []models.Organization
+	if err := cursor.All(ctx, &orgs); err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
+
 // Ensure MongoOrganizationRepository implements OrganizationRepository
 var _ OrganizationRepository = (*MongoOrganizationRepository)(nil)
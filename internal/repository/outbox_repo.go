@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+// MongoOutboxRepository implements OutboxRepository for MongoDB
+// #ORM_INTEGRATION: MongoDB driver-based repository implementation
+type MongoOutboxRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoOutboxRepository creates a new MongoDB outbox repository
+func NewMongoOutboxRepository(db *mongo.Database) *MongoOutboxRepository {
+	return &MongoOutboxRepository{
+		collection: db.Collection(models.OutboxEmail{}.CollectionName()),
+	}
+}
+
+// Create queues a new email
+func (r *MongoOutboxRepository) Create(ctx context.Context, email *models.OutboxEmail) error {
+	email.BeforeCreate()
+	_, err := r.collection.InsertOne(ctx, email)
+	return err
+}
+
+// ClaimDue atomically claims the oldest pending email due at now
+// #IMPLEMENTATION_DECISION: The claim pushes next_attempt_at forward by the lease instead of using a
+// separate lock field, so a worker that dies mid-send releases the email once the lease runs out
+// #QUERY_PATTERN: Uses the status + next_attempt_at index
+func (r *MongoOutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*models.OutboxEmail, error) {
+	filter := bson.M{
+		"status":          models.OutboxEmailStatusPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"next_attempt_at": now.Add(lease),
+			"updated_at":      now,
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+	findOpts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var email models.OutboxEmail
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, findOpts).Decode(&email)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &email, nil
+}
+
+// MarkSent records a successful delivery and the mail provider's message ID
+func (r *MongoOutboxRepository) MarkSent(ctx context.Context, id primitive.ObjectID, providerMessageID string) error {
+	now := time.Now().UTC()
+	update := bson.M{
+		"$set": bson.M{
+			"status":              models.OutboxEmailStatusSent,
+			"sent_at":             now,
+			"provider_message_id": providerMessageID,
+			"updated_at":          now,
+		},
+		"$unset": bson.M{"last_error": ""},
+	}
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+// RecordFailure records a failed attempt; a nil nextAttemptAt marks the email permanently failed
+func (r *MongoOutboxRepository) RecordFailure(ctx context.Context, id primitive.ObjectID, lastError string, nextAttemptAt *time.Time) error {
+	now := time.Now().UTC()
+	set := bson.M{
+		"last_error": lastError,
+		"updated_at": now,
+	}
+	if nextAttemptAt != nil {
+		set["next_attempt_at"] = *nextAttemptAt
+	} else {
+		set["status"] = models.OutboxEmailStatusFailed
+		set["failed_at"] = now
+	}
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": set})
+	return err
+}
+
+// ListFailedByOrganization lists permanently failed emails of an organization, newest first
+func (r *MongoOutboxRepository) ListFailedByOrganization(ctx context.Context, orgID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.OutboxEmail], error) {
+	filter := bson.M{
+		"organization_id": orgID,
+		"status":          models.OutboxEmailStatusFailed,
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: "failed_at", Value: -1}}).
+		SetSkip(int64((opts.Page - 1) * opts.Limit)).
+		SetLimit(int64(opts.Limit))
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	emails := []models.OutboxEmail{}
+	if err := cursor.All(ctx, &emails); err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / opts.Limit
+	if int(total)%opts.Limit > 0 {
+		totalPages++
+	}
+
+	return &PaginatedResult[models.OutboxEmail]{
+		Items:      emails,
+		TotalCount: total,
+		Page:       opts.Page,
+		Limit:      opts.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// Ensure MongoOutboxRepository implements OutboxRepository
+var _ OutboxRepository = (*MongoOutboxRepository)(nil)
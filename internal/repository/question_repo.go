@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"regexp"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -74,11 +75,17 @@ func (r *MongoQuestionRepository) Delete(ctx context.Context, id primitive.Objec
 	return nil
 }
 
+// questionOrderSort is the questionnaire order of questions
+// #IMPLEMENTATION_DECISION: _id breaks ties between equal orders, e.g. after a partial reorder, so
+// rendering and scoring always see the same sequence; conditional questions rely on it
+// #QUERY_PATTERN: Served by the questionnaire_id + order index
+var questionOrderSort = bson.D{{Key: "order", Value: 1}, {Key: "_id", Value: 1}}
+
 // ListByQuestionnaire lists all questions for a questionnaire
 // #QUERY_PATTERN: Fetch all questions for a questionnaire at once, sorted by order
 func (r *MongoQuestionRepository) ListByQuestionnaire(ctx context.Context, questionnaireID primitive.ObjectID) ([]models.Question, error) {
 	filter := bson.M{"questionnaire_id": questionnaireID}
-	findOpts := options.Find().SetSort(bson.D{{Key: "topic_id", Value: 1}, {Key: "order", Value: 1}})
+	findOpts := options.Find().SetSort(questionOrderSort)
 
 	cursor, err := r.collection.Find(ctx, filter, findOpts)
 	if err != nil {
@@ -94,13 +101,43 @@ func (r *MongoQuestionRepository) ListByQuestionnaire(ctx context.Context, quest
 	return questions, nil
 }
 
+// SearchByControlRef lists questions of the given questionnaires whose control reference starts with prefix
+// #QUERY_PATTERN: Case-insensitive prefix match, so "A.9" finds "A.9.2.3"; the prefix is escaped
+// and the questionnaire scope keeps the scan on the questionnaire_id index
+func (r *MongoQuestionRepository) SearchByControlRef(ctx context.Context, questionnaireIDs []primitive.ObjectID, prefix string, limit int) ([]models.Question, error) {
+	if len(questionnaireIDs) == 0 {
+		return []models.Question{}, nil
+	}
+
+	filter := bson.M{
+		"questionnaire_id": bson.M{"$in": questionnaireIDs},
+		"control_ref":      primitive.Regex{Pattern: "^" + regexp.QuoteMeta(prefix), Options: "i"},
+	}
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: "control_ref", Value: 1}, {Key: "questionnaire_id", Value: 1}, {Key: "order", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	questions := []models.Question{}
+	if err := cursor.All(ctx, &questions); err != nil {
+		return nil, err
+	}
+
+	return questions, nil
+}
+
 // ListByQuestionnaireAndTopic lists questions for a specific topic
 func (r *MongoQuestionRepository) ListByQuestionnaireAndTopic(ctx context.Context, questionnaireID primitive.ObjectID, topicID string) ([]models.Question, error) {
 	filter := bson.M{
 		"questionnaire_id": questionnaireID,
 		"topic_id":         topicID,
 	}
-	findOpts := options.Find().SetSort(bson.D{{Key: "order", Value: 1}})
+	findOpts := options.Find().SetSort(questionOrderSort)
 
 	cursor, err := r.collection.Find(ctx, filter, findOpts)
 	if err != nil {
@@ -127,6 +164,20 @@ func (r *MongoQuestionRepository) DeleteByQuestionnaire(ctx context.Context, que
 	return result.DeletedCount, nil
 }
 
+// DeleteByIDs deletes the given questions of a questionnaire
+// #QUERY_PATTERN: One DeleteMany; IDs of other questionnaires' questions never match
+func (r *MongoQuestionRepository) DeleteByIDs(ctx context.Context, questionnaireID primitive.ObjectID, ids []primitive.ObjectID) (int64, error) {
+	filter := bson.M{
+		"_id":              bson.M{"$in": ids},
+		"questionnaire_id": questionnaireID,
+	}
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
 // UpdateOrder updates the order of questions
 func (r *MongoQuestionRepository) UpdateOrder(ctx context.Context, questionnaireID primitive.ObjectID, orders map[primitive.ObjectID]int) error {
 	// Use bulk write for efficiency
@@ -149,10 +200,11 @@ func (r *MongoQuestionRepository) UpdateOrder(ctx context.Context, questionnaire
 }
 
 // CalculateMaxScore calculates the max possible score for a questionnaire
+// #BUSINESS_RULE: Informational questions are left out of the max score
 func (r *MongoQuestionRepository) CalculateMaxScore(ctx context.Context, questionnaireID primitive.ObjectID) (int, error) {
 	pipeline := []bson.M{
 		{
-			"$match": bson.M{"questionnaire_id": questionnaireID},
+			"$match": bson.M{"questionnaire_id": questionnaireID, "scored": bson.M{"$ne": false}},
 		},
 		{
 			"$group": bson.M{
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+func TestQuestionRepository_ListByQuestionnaire_Order(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("sorts by order then id", func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + models.Question{}.CollectionName()
+		questionnaireID := primitive.NewObjectID()
+
+		// Seeded out of insertion order: the third question created has the lowest order, and two
+		// questions share an order; the server returns them sorted as requested
+		first, second, third := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: third}, {Key: "questionnaire_id", Value: questionnaireID}, {Key: "order", Value: 1}},
+			bson.D{{Key: "_id", Value: first}, {Key: "questionnaire_id", Value: questionnaireID}, {Key: "order", Value: 2}},
+			bson.D{{Key: "_id", Value: second}, {Key: "questionnaire_id", Value: questionnaireID}, {Key: "order", Value: 2}},
+		))
+
+		questions, err := NewMongoQuestionRepository(mt.DB).ListByQuestionnaire(context.Background(), questionnaireID)
+		if err != nil {
+			t.Fatalf("ListByQuestionnaire failed: %v", err)
+		}
+
+		sort, err := mt.GetStartedEvent().Command.LookupErr("sort")
+		if err != nil {
+			t.Fatalf("Expected a sort in the find command: %v", err)
+		}
+		keys, _ := sort.Document().Elements()
+		if len(keys) != 2 || keys[0].Key() != "order" || keys[1].Key() != "_id" {
+			t.Errorf("Expected sort by order then _id, got %v", sort)
+		}
+
+		want := []primitive.ObjectID{third, first, second}
+		if len(questions) != len(want) {
+			t.Fatalf("Expected %d questions, got %d", len(want), len(questions))
+		}
+		for i := range want {
+			if questions[i].ID != want[i] {
+				t.Errorf("Question %d: expected %s, got %s", i, want[i].Hex(), questions[i].ID.Hex())
+			}
+		}
+	})
+}
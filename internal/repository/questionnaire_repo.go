@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -75,6 +76,8 @@ func (r *MongoQuestionnaireTemplateRepository) Delete(ctx context.Context, id pr
 }
 
 // IncrementUsageCount increments the usage count
+// #IMPLEMENTATION_DECISION: $inc is applied by the server, so concurrent creations from one
+// template never lose an increment the way a read-modify-write would
 func (r *MongoQuestionnaireTemplateRepository) IncrementUsageCount(ctx context.Context, id primitive.ObjectID) error {
 	filter := bson.M{"_id": id}
 	update := bson.M{
@@ -90,12 +93,97 @@ func (r *MongoQuestionnaireTemplateRepository) IncrementUsageCount(ctx context.C
 	return nil
 }
 
+// DecrementUsageCount decrements the usage count; a count of zero is left unchanged
+// #IMPLEMENTATION_DECISION: The usage_count > 0 condition keeps the count from going negative
+// when decrements race or outnumber recorded uses
+func (r *MongoQuestionnaireTemplateRepository) DecrementUsageCount(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{"_id": id, "usage_count": bson.M{"$gt": 0}}
+	update := bson.M{
+		"$inc": bson.M{"usage_count": -1},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	// Nothing matched: either the count is already zero or the template does not exist
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return models.ErrTemplateNotFound
+	}
+	return nil
+}
+
+// MarkSuperseded points a template at its newer version
+// #IMPLEMENTATION_DECISION: Conditional update so two versions published concurrently cannot both supersede the same template
+func (r *MongoQuestionnaireTemplateRepository) MarkSuperseded(ctx context.Context, id, supersededBy primitive.ObjectID) error {
+	filter := bson.M{"_id": id, "superseded_by": bson.M{"$exists": false}}
+	update := bson.M{
+		"$set": bson.M{"superseded_by": supersededBy, "updated_at": time.Now().UTC()},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrTemplateSuperseded
+	}
+	return nil
+}
+
+// ClearSuperseded removes the superseded pointer if it still references the given version
+func (r *MongoQuestionnaireTemplateRepository) ClearSuperseded(ctx context.Context, id, supersededBy primitive.ObjectID) error {
+	filter := bson.M{"_id": id, "superseded_by": supersededBy}
+	update := bson.M{
+		"$unset": bson.M{"superseded_by": ""},
+		"$set":   bson.M{"updated_at": time.Now().UTC()},
+	}
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// ListVersions lists every version in a lineage, oldest first
+// #QUERY_PATTERN: Root is matched by _id, later versions by root_template_id
+func (r *MongoQuestionnaireTemplateRepository) ListVersions(ctx context.Context, rootID primitive.ObjectID) ([]models.QuestionnaireTemplate, error) {
+	filter := bson.M{
+		"$or": []bson.M{
+			{"_id": rootID},
+			{"root_template_id": rootID},
+		},
+	}
+
+	findOpts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var templates []models.QuestionnaireTemplate
+	if err := cursor.All(ctx, &templates); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
 // ListSystemTemplates lists all system templates
-func (r *MongoQuestionnaireTemplateRepository) ListSystemTemplates(ctx context.Context, category *models.TemplateCategory) ([]models.QuestionnaireTemplate, error) {
+func (r *MongoQuestionnaireTemplateRepository) ListSystemTemplates(ctx context.Context, category *models.TemplateCategory, tags TagFilter, includeSuperseded bool) ([]models.QuestionnaireTemplate, error) {
 	filter := bson.M{"is_system": true}
 	if category != nil {
 		filter["category"] = *category
 	}
+	applyTagFilter(filter, tags)
+	if !includeSuperseded {
+		filter["superseded_by"] = bson.M{"$exists": false}
+	}
 
 	findOpts := options.Find().SetSort(bson.D{{Key: "category", Value: 1}, {Key: "name", Value: 1}})
 
@@ -113,6 +201,68 @@ func (r *MongoQuestionnaireTemplateRepository) ListSystemTemplates(ctx context.C
 	return templates, nil
 }
 
+// ListByTags lists current system and globally published templates matching the tags
+// #QUERY_PATTERN: Uses the multikey tags index; superseded versions are always excluded
+func (r *MongoQuestionnaireTemplateRepository) ListByTags(ctx context.Context, tags TagFilter, opts PaginationOptions) (*PaginatedResult[models.QuestionnaireTemplate], error) {
+	filter := bson.M{
+		"$or": []bson.M{
+			{"is_system": true},
+			{"visibility": models.TemplateVisibilityGlobal},
+		},
+		"superseded_by": bson.M{"$exists": false},
+	}
+	applyTagFilter(filter, tags)
+
+	// Count total
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply pagination
+	skip := int64((opts.Page - 1) * opts.Limit)
+	findOpts := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(opts.Limit)).
+		SetSort(bson.D{{Key: opts.SortBy, Value: opts.SortDir}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var templates []models.QuestionnaireTemplate
+	if err := cursor.All(ctx, &templates); err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / opts.Limit
+	if int(total)%opts.Limit > 0 {
+		totalPages++
+	}
+
+	return &PaginatedResult[models.QuestionnaireTemplate]{
+		Items:      templates,
+		TotalCount: total,
+		Page:       opts.Page,
+		Limit:      opts.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// applyTagFilter adds the tag condition to a template filter; an empty tag list adds nothing
+func applyTagFilter(filter bson.M, tags TagFilter) {
+	if len(tags.Tags) == 0 {
+		return
+	}
+	if tags.MatchAll {
+		filter["tags"] = bson.M{"$all": tags.Tags}
+	} else {
+		filter["tags"] = bson.M{"$in": tags.Tags}
+	}
+}
+
 // ListByOrganization lists templates created by an organization
 func (r *MongoQuestionnaireTemplateRepository) ListByOrganization(ctx context.Context, orgID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.QuestionnaireTemplate], error) {
 	filter := bson.M{"created_by_org_id": orgID}
@@ -202,7 +352,7 @@ func (r *MongoQuestionnaireTemplateRepository) SearchTemplates(ctx context.Conte
 
 // ListAvailableTemplates lists templates available to an organization
 // Returns: system templates + globally published + org's own templates (any visibility)
-func (r *MongoQuestionnaireTemplateRepository) ListAvailableTemplates(ctx context.Context, orgID primitive.ObjectID, category *models.TemplateCategory, opts PaginationOptions) (*PaginatedResult[models.QuestionnaireTemplate], error) {
+func (r *MongoQuestionnaireTemplateRepository) ListAvailableTemplates(ctx context.Context, orgID primitive.ObjectID, category *models.TemplateCategory, tags TagFilter, includeSuperseded bool, opts PaginationOptions) (*PaginatedResult[models.QuestionnaireTemplate], error) {
 	// Build filter: system OR global-published OR owned by org
 	filter := bson.M{
 		"$or": []bson.M{
@@ -216,6 +366,10 @@ func (r *MongoQuestionnaireTemplateRepository) ListAvailableTemplates(ctx contex
 	if category != nil {
 		filter["category"] = *category
 	}
+	applyTagFilter(filter, tags)
+	if !includeSuperseded {
+		filter["superseded_by"] = bson.M{"$exists": false}
+	}
 
 	// Count total
 	total, err := r.collection.CountDocuments(ctx, filter)
@@ -327,7 +481,7 @@ func (r *MongoQuestionnaireRepository) Create(ctx context.Context, questionnaire
 // GetByID finds a questionnaire by ID
 func (r *MongoQuestionnaireRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.Questionnaire, error) {
 	var questionnaire models.Questionnaire
-	filter := bson.M{"_id": id}
+	filter := bson.M{"_id": id, "deleted_at": nil}
 	err := r.collection.FindOne(ctx, filter).Decode(&questionnaire)
 	if errors.Is(err, mongo.ErrNoDocuments) {
 		return nil, models.ErrQuestionnaireNotFound
@@ -338,41 +492,181 @@ func (r *MongoQuestionnaireRepository) GetByID(ctx context.Context, id primitive
 	return &questionnaire, nil
 }
 
+// GetByIDs finds questionnaires by ID in one query, keyed by ID
+// #QUERY_PATTERN: Single $in lookup on _id for batch name resolution; soft-deleted questionnaires
+// are included, since exports still name the questionnaire a past submission answered
+func (r *MongoQuestionnaireRepository) GetByIDs(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]*models.Questionnaire, error) {
+	questionnaires := make(map[primitive.ObjectID]*models.Questionnaire, len(ids))
+	if len(ids) == 0 {
+		return questionnaires, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	for cursor.Next(ctx) {
+		var questionnaire models.Questionnaire
+		if err := cursor.Decode(&questionnaire); err != nil {
+			return nil, err
+		}
+		questionnaires[questionnaire.ID] = &questionnaire
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return questionnaires, nil
+}
+
 // Update updates a questionnaire
+// #IMPLEMENTATION_DECISION: Compare-and-set on version - the whole document, including the topics
+// array, is replaced, so a write based on a stale copy is rejected instead of clobbering newer edits
 func (r *MongoQuestionnaireRepository) Update(ctx context.Context, questionnaire *models.Questionnaire) error {
 	questionnaire.BeforeUpdate()
-	filter := bson.M{"_id": questionnaire.ID}
+	expectedVersion := questionnaire.Version
+	filter := bson.M{"_id": questionnaire.ID, "deleted_at": nil, "version": expectedVersion}
+
+	questionnaire.Version = expectedVersion + 1
 	update := bson.M{"$set": questionnaire}
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
+		questionnaire.Version = expectedVersion
 		return err
 	}
 	if result.MatchedCount == 0 {
-		return models.ErrQuestionnaireNotFound
+		questionnaire.Version = expectedVersion
+		count, err := r.collection.CountDocuments(ctx, bson.M{"_id": questionnaire.ID, "deleted_at": nil})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return models.ErrQuestionnaireNotFound
+		}
+		return models.ErrQuestionnaireConflict
 	}
 	return nil
 }
 
-// Delete deletes a questionnaire (draft only)
+// Delete soft-deletes a questionnaire (draft only)
+// #IMPLEMENTATION_DECISION: Questions are left in place so a restore brings them back as they
+// were; they are removed together with the questionnaire when it is purged
 func (r *MongoQuestionnaireRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
 	// Only allow deleting draft questionnaires
 	filter := bson.M{
-		"_id":    id,
-		"status": models.QuestionnaireStatusDraft,
+		"_id":        id,
+		"status":     models.QuestionnaireStatusDraft,
+		"deleted_at": nil,
+	}
+	now := time.Now().UTC()
+	update := bson.M{
+		"$set": bson.M{
+			"deleted_at": now,
+			"updated_at": now,
+		},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrQuestionnaireNotDeletable
+	}
+	return nil
+}
+
+// Restore restores a soft-deleted questionnaire of a company
+func (r *MongoQuestionnaireRepository) Restore(ctx context.Context, id, companyID primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":        id,
+		"company_id": companyID,
+		"deleted_at": bson.M{"$ne": nil},
+	}
+	update := bson.M{
+		"$unset": bson.M{"deleted_at": ""},
+		"$set":   bson.M{"updated_at": time.Now().UTC()},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrQuestionnaireNotFound
+	}
+	return nil
+}
+
+// ListDeletedBefore lists the IDs of questionnaires soft-deleted before the given time
+// #QUERY_PATTERN: Uses the sparse deleted_at index
+func (r *MongoQuestionnaireRepository) ListDeletedBefore(ctx context.Context, before time.Time) ([]primitive.ObjectID, error) {
+	filter := bson.M{"deleted_at": bson.M{"$lt": before}}
+	findOpts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var docs []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ID
+	}
+	return ids, nil
+}
+
+// ListIDsByCompany lists the IDs of a company's questionnaires that are not deleted
+func (r *MongoQuestionnaireRepository) ListIDsByCompany(ctx context.Context, companyID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	filter := bson.M{"company_id": companyID, "deleted_at": nil}
+	findOpts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var docs []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ID
+	}
+	return ids, nil
+}
+
+// Purge permanently deletes a soft-deleted questionnaire
+func (r *MongoQuestionnaireRepository) Purge(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$ne": nil},
 	}
 	result, err := r.collection.DeleteOne(ctx, filter)
 	if err != nil {
 		return err
 	}
 	if result.DeletedCount == 0 {
-		return models.ErrQuestionnaireNotDeletable
+		return models.ErrQuestionnaireNotFound
 	}
 	return nil
 }
 
 // UpdateStatistics updates question count and max score
 func (r *MongoQuestionnaireRepository) UpdateStatistics(ctx context.Context, id primitive.ObjectID, questionCount, maxScore int) error {
-	filter := bson.M{"_id": id}
+	filter := bson.M{"_id": id, "deleted_at": nil}
 	update := bson.M{
 		"$set": bson.M{
 			"question_count":     questionCount,
@@ -389,9 +683,14 @@ func (r *MongoQuestionnaireRepository) UpdateStatistics(ctx context.Context, id
 	return nil
 }
 
+// QuestionnaireSortFields are the fields ListByCompany accepts as PaginationOptions.SortBy
+// #INDEX_STRATEGY: created_at is covered by the company/status index; the rest sort a single
+// company's questionnaires, which stay small enough to sort in memory
+var QuestionnaireSortFields = []string{"created_at", "updated_at", "name", "published_at"}
+
 // ListByCompany lists questionnaires for a company
 func (r *MongoQuestionnaireRepository) ListByCompany(ctx context.Context, companyID primitive.ObjectID, status *models.QuestionnaireStatus, opts PaginationOptions) (*PaginatedResult[models.Questionnaire], error) {
-	filter := bson.M{"company_id": companyID}
+	filter := bson.M{"company_id": companyID, "deleted_at": nil}
 	if status != nil {
 		filter["status"] = *status
 	}
@@ -434,14 +733,64 @@ func (r *MongoQuestionnaireRepository) ListByCompany(ctx context.Context, compan
 	}, nil
 }
 
+// ListPublishedByClassification lists a company's published questionnaires that target a supplier classification
+// #QUERY_PATTERN: Equality on the applies_to array matches any element, served by the company/applies_to index
+func (r *MongoQuestionnaireRepository) ListPublishedByClassification(ctx context.Context, companyID primitive.ObjectID, classification models.SupplierClassification) ([]models.Questionnaire, error) {
+	filter := bson.M{
+		"company_id": companyID,
+		"deleted_at": nil,
+		"status":     models.QuestionnaireStatusPublished,
+		"applies_to": classification,
+	}
+	findOpts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var questionnaires []models.Questionnaire
+	if err := cursor.All(ctx, &questionnaires); err != nil {
+		return nil, err
+	}
+	return questionnaires, nil
+}
+
 // CountByCompany counts questionnaires for a company
 func (r *MongoQuestionnaireRepository) CountByCompany(ctx context.Context, companyID primitive.ObjectID, status *models.QuestionnaireStatus) (int64, error) {
-	filter := bson.M{"company_id": companyID}
+	filter := bson.M{"company_id": companyID, "deleted_at": nil}
 	if status != nil {
 		filter["status"] = *status
 	}
 	return r.collection.CountDocuments(ctx, filter)
 }
 
+// SearchByCompany full-text searches the names of a company's questionnaires, best match first
+// #QUERY_PATTERN: $text with a company_id equality, served by the company-prefixed text index
+func (r *MongoQuestionnaireRepository) SearchByCompany(ctx context.Context, companyID primitive.ObjectID, query string, limit int) ([]models.Questionnaire, error) {
+	filter := bson.M{
+		"company_id": companyID,
+		"deleted_at": nil,
+		"$text":      bson.M{"$search": query},
+	}
+	findOpts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}}).
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var questionnaires []models.Questionnaire
+	if err := cursor.All(ctx, &questionnaires); err != nil {
+		return nil, err
+	}
+	return questionnaires, nil
+}
+
 // Ensure MongoQuestionnaireRepository implements QuestionnaireRepository
 var _ QuestionnaireRepository = (*MongoQuestionnaireRepository)(nil)
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+func TestQuestionnaireTemplateRepository_DecrementUsageCount_AtZero(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	noMatch := mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0})
+
+	mt.Run("template with zero usage", func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + models.QuestionnaireTemplate{}.CollectionName()
+		mt.AddMockResponses(
+			noMatch,
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{Key: "n", Value: 1}}),
+		)
+
+		if err := NewMongoQuestionnaireTemplateRepository(mt.DB).DecrementUsageCount(context.Background(), primitive.NewObjectID()); err != nil {
+			t.Errorf("Expected no error at zero usage, got %v", err)
+		}
+	})
+
+	mt.Run("missing template", func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + models.QuestionnaireTemplate{}.CollectionName()
+		mt.AddMockResponses(
+			noMatch,
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch),
+		)
+
+		err := NewMongoQuestionnaireTemplateRepository(mt.DB).DecrementUsageCount(context.Background(), primitive.NewObjectID())
+		if !errors.Is(err, models.ErrTemplateNotFound) {
+			t.Errorf("Expected ErrTemplateNotFound, got %v", err)
+		}
+	})
+}
+
+func TestQuestionnaireTemplateRepository_ListSystemTemplates_TagFilter(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name     string
+		tags     TagFilter
+		operator string
+	}{
+		{"any", TagFilter{Tags: []string{"supplier", "cloud"}}, "$in"},
+		{"all", TagFilter{Tags: []string{"supplier", "cloud"}, MatchAll: true}, "$all"},
+		{"none", TagFilter{}, ""},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			ns := mt.Coll.Database().Name() + "." + models.QuestionnaireTemplate{}.CollectionName()
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch))
+
+			if _, err := NewMongoQuestionnaireTemplateRepository(mt.DB).ListSystemTemplates(context.Background(), nil, tt.tags, false); err != nil {
+				t.Fatalf("ListSystemTemplates failed: %v", err)
+			}
+
+			tagsFilter, err := mt.GetStartedEvent().Command.LookupErr("filter", "tags")
+			if tt.operator == "" {
+				if err == nil {
+					t.Errorf("Expected no tags condition, got %v", tagsFilter)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected a tags condition: %v", err)
+			}
+			if _, err := tagsFilter.Document().LookupErr(tt.operator); err != nil {
+				t.Errorf("Expected %s condition, got %v", tt.operator, tagsFilter)
+			}
+		})
+	}
+}
+
+func TestQuestionnaireRepository_Update_Version(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	noMatch := mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0})
+
+	mt.Run("current version", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+
+		questionnaire := &models.Questionnaire{ID: primitive.NewObjectID(), Version: 3}
+		if err := NewMongoQuestionnaireRepository(mt.DB).Update(context.Background(), questionnaire); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		if questionnaire.Version != 4 {
+			t.Errorf("Expected version 4, got %d", questionnaire.Version)
+		}
+		if version, err := mt.GetStartedEvent().Command.LookupErr("updates", "0", "q", "version"); err != nil || version.AsInt64() != 3 {
+			t.Errorf("Expected the update to match version 3, got %v", version)
+		}
+	})
+
+	mt.Run("stale version", func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + models.Questionnaire{}.CollectionName()
+		mt.AddMockResponses(
+			noMatch,
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{Key: "n", Value: 1}}),
+		)
+
+		questionnaire := &models.Questionnaire{ID: primitive.NewObjectID(), Version: 3}
+		err := NewMongoQuestionnaireRepository(mt.DB).Update(context.Background(), questionnaire)
+		if !errors.Is(err, models.ErrQuestionnaireConflict) {
+			t.Errorf("Expected ErrQuestionnaireConflict, got %v", err)
+		}
+		if questionnaire.Version != 3 {
+			t.Errorf("Expected version to stay 3 after a conflict, got %d", questionnaire.Version)
+		}
+	})
+
+	mt.Run("missing questionnaire", func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + models.Questionnaire{}.CollectionName()
+		mt.AddMockResponses(noMatch, mtest.CreateCursorResponse(0, ns, mtest.FirstBatch))
+
+		err := NewMongoQuestionnaireRepository(mt.DB).Update(context.Background(), &models.Questionnaire{ID: primitive.NewObjectID()})
+		if !errors.Is(err, models.ErrQuestionnaireNotFound) {
+			t.Errorf("Expected ErrQuestionnaireNotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+// MongoRefreshTokenRepository implements RefreshTokenRepository for MongoDB
+// #ORM_INTEGRATION: MongoDB driver-based repository implementation
+type MongoRefreshTokenRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoRefreshTokenRepository creates a new MongoDB refresh token repository
+func NewMongoRefreshTokenRepository(db *mongo.Database) *MongoRefreshTokenRepository {
+	return &MongoRefreshTokenRepository{
+		collection: db.Collection(models.RefreshToken{}.CollectionName()),
+	}
+}
+
+// Create records a newly issued refresh token
+func (r *MongoRefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	token.BeforeCreate()
+	_, err := r.collection.InsertOne(ctx, token)
+	if mongo.IsDuplicateKeyError(err) {
+		return models.ErrAlreadyExists
+	}
+	return err
+}
+
+// GetByTokenID finds a refresh token by its jti
+func (r *MongoRefreshTokenRepository) GetByTokenID(ctx context.Context, tokenID string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	filter := bson.M{
+		"token_id": tokenID,
+	}
+	err := r.collection.FindOne(ctx, filter).Decode(&token)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrRefreshTokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkAsUsed atomically marks an unused refresh token as exchanged
+// #IMPLEMENTATION_DECISION: Conditional update on used_at so two concurrent refreshes
+// with the same token cannot both succeed
+func (r *MongoRefreshTokenRepository) MarkAsUsed(ctx context.Context, tokenID, replacedBy string) error {
+	now := time.Now().UTC()
+	filter := bson.M{
+		"token_id":   tokenID,
+		"used_at":    nil,
+		"revoked_at": nil,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"used_at":     now,
+			"replaced_by": replacedBy,
+		},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrRefreshTokenUsed
+	}
+	return nil
+}
+
+// RevokeFamily revokes every token in a rotation chain
+func (r *MongoRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	filter := bson.M{
+		"family_id":  familyID,
+		"revoked_at": nil,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"revoked_at": time.Now().UTC(),
+		},
+	}
+	_, err := r.collection.UpdateMany(ctx, filter, update)
+	return err
+}
+
+// RevokeAllForUser revokes every refresh token issued to a user
+func (r *MongoRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID primitive.ObjectID) error {
+	filter := bson.M{
+		"user_id":    userID,
+		"revoked_at": nil,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"revoked_at": time.Now().UTC(),
+		},
+	}
+	_, err := r.collection.UpdateMany(ctx, filter, update)
+	return err
+}
+
+// Ensure MongoRefreshTokenRepository implements RefreshTokenRepository
+var _ RefreshTokenRepository = (*MongoRefreshTokenRepository)(nil)
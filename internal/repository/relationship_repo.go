@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"regexp"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -26,6 +27,8 @@ func NewMongoRelationshipRepository(db *mongo.Database) *MongoRelationshipReposi
 }
 
 // Create creates a new relationship
+// #SECURITY_ASSUMPTION: The partial unique index on (company_id, invited_email) rejects the loser
+// of two concurrent invitations that both passed the service-level duplicate check
 func (r *MongoRelationshipRepository) Create(ctx context.Context, relationship *models.CompanySupplierRelationship) error {
 	relationship.BeforeCreate()
 	_, err := r.collection.InsertOne(ctx, relationship)
@@ -71,7 +74,7 @@ func (r *MongoRelationshipRepository) GetByInvitedEmail(ctx context.Context, ema
 	var relationship models.CompanySupplierRelationship
 	filter := bson.M{
 		"company_id":    companyID,
-		"invited_email": email,
+		"invited_email": models.NormalizeEmail(email),
 		"status":        models.RelationshipStatusPending,
 	}
 	err := r.collection.FindOne(ctx, filter).Decode(&relationship)
@@ -87,8 +90,13 @@ func (r *MongoRelationshipRepository) GetByInvitedEmail(ctx context.Context, ema
 // Update updates a relationship
 func (r *MongoRelationshipRepository) Update(ctx context.Context, relationship *models.CompanySupplierRelationship) error {
 	relationship.BeforeUpdate()
+
+	// Note history is only written by AddNote, so a stale copy cannot drop a concurrent note
+	doc := *relationship
+	doc.NoteHistory = nil
+
 	filter := bson.M{"_id": relationship.ID}
-	update := bson.M{"$set": relationship}
+	update := bson.M{"$set": doc}
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
@@ -99,16 +107,33 @@ func (r *MongoRelationshipRepository) Update(ctx context.Context, relationship *
 	return nil
 }
 
-// ListByCompany lists relationships for a company
-// #QUERY_PATTERN: Company dashboard queries by status and classification
-func (r *MongoRelationshipRepository) ListByCompany(ctx context.Context, companyID primitive.ObjectID, status *models.RelationshipStatus, classification *models.SupplierClassification, opts PaginationOptions) (*PaginatedResult[models.CompanySupplierRelationship], error) {
-	filter := bson.M{"company_id": companyID}
-	if status != nil {
-		filter["status"] = *status
+// AddNote appends a note to a company's relationship note history
+// #IMPLEMENTATION_DECISION: $push keeps concurrent notes from overwriting each other
+func (r *MongoRelationshipRepository) AddNote(ctx context.Context, id, companyID primitive.ObjectID, note models.RelationshipNote) error {
+	filter := bson.M{"_id": id, "company_id": companyID}
+	update := bson.M{
+		"$push": bson.M{"note_history": note},
+		"$set":  bson.M{"updated_at": note.CreatedAt},
 	}
-	if classification != nil {
-		filter["classification"] = *classification
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
 	}
+	if result.MatchedCount == 0 {
+		return models.ErrRelationshipNotFound
+	}
+	return nil
+}
+
+// RelationshipSortFields are the fields the relationship lists accept as PaginationOptions.SortBy
+// #INDEX_STRATEGY: Company lists sort by classification first (indexed with company and status);
+// these only order suppliers within a classification
+var RelationshipSortFields = []string{"created_at", "updated_at", "invited_at", "accepted_at", "status", "invited_email"}
+
+// ListByCompany lists relationships for a company
+// #QUERY_PATTERN: Company dashboard queries by status and classification
+func (r *MongoRelationshipRepository) ListByCompany(ctx context.Context, companyID primitive.ObjectID, statuses []models.RelationshipStatus, classification *models.SupplierClassification, opts PaginationOptions) (*PaginatedResult[models.CompanySupplierRelationship], error) {
+	filter := companyRelationshipFilter(companyID, statuses, classification)
 
 	// Count total
 	total, err := r.collection.CountDocuments(ctx, filter)
@@ -148,6 +173,79 @@ func (r *MongoRelationshipRepository) ListByCompany(ctx context.Context, company
 	}, nil
 }
 
+// ListByCompanyCursor lists relationships for a company using keyset pagination, newest first
+func (r *MongoRelationshipRepository) ListByCompanyCursor(ctx context.Context, companyID primitive.ObjectID, statuses []models.RelationshipStatus, classification *models.SupplierClassification, opts CursorPaginationOptions) (*CursorResult[models.CompanySupplierRelationship], error) {
+	filter := companyRelationshipFilter(companyID, statuses, classification)
+	if err := applyCursor(filter, opts); err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, cursorFindOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var relationships []models.CompanySupplierRelationship
+	if err := cursor.All(ctx, &relationships); err != nil {
+		return nil, err
+	}
+
+	return newCursorResult(relationships, opts, func(r *models.CompanySupplierRelationship) primitive.ObjectID { return r.ID }), nil
+}
+
+// ForEachByCompany streams all matching relationships for a company in batches, ordered like ListByCompany
+// #QUERY_PATTERN: Exports read the full list without pagination limits, holding one batch in memory
+func (r *MongoRelationshipRepository) ForEachByCompany(ctx context.Context, companyID primitive.ObjectID, statuses []models.RelationshipStatus, classification *models.SupplierClassification, batchSize int, fn func([]models.CompanySupplierRelationship) error) error {
+	filter := companyRelationshipFilter(companyID, statuses, classification)
+	findOpts := options.Find().
+		SetBatchSize(int32(batchSize)).
+		SetProjection(bson.M{"note_history": 0}).
+		SetSort(bson.D{{Key: "classification", Value: 1}, {Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	batch := make([]models.CompanySupplierRelationship, 0, batchSize)
+	for cursor.Next(ctx) {
+		var relationship models.CompanySupplierRelationship
+		if err := cursor.Decode(&relationship); err != nil {
+			return err
+		}
+		batch = append(batch, relationship)
+
+		if len(batch) == batchSize {
+			if err := fn(batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	if len(batch) > 0 {
+		return fn(batch)
+	}
+	return nil
+}
+
+// companyRelationshipFilter builds the company supplier list filter; any of statuses matches
+func companyRelationshipFilter(companyID primitive.ObjectID, statuses []models.RelationshipStatus, classification *models.SupplierClassification) bson.M {
+	filter := bson.M{"company_id": companyID}
+	if len(statuses) > 0 {
+		filter["status"] = bson.M{"$in": statuses}
+	}
+	if classification != nil {
+		filter["classification"] = *classification
+	}
+	return filter
+}
+
 // ListBySupplier lists relationships for a supplier
 func (r *MongoRelationshipRepository) ListBySupplier(ctx context.Context, supplierID primitive.ObjectID, status *models.RelationshipStatus, opts PaginationOptions) (*PaginatedResult[models.CompanySupplierRelationship], error) {
 	filter := bson.M{"supplier_id": supplierID}
@@ -197,7 +295,7 @@ func (r *MongoRelationshipRepository) ListBySupplier(ctx context.Context, suppli
 // #QUERY_PATTERN: Supplier lookup of pending invitations by email
 func (r *MongoRelationshipRepository) ListPendingByEmail(ctx context.Context, email string) ([]models.CompanySupplierRelationship, error) {
 	filter := bson.M{
-		"invited_email": email,
+		"invited_email": models.NormalizeEmail(email),
 		"status":        models.RelationshipStatusPending,
 	}
 	findOpts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
@@ -234,5 +332,83 @@ func (r *MongoRelationshipRepository) CountBySupplier(ctx context.Context, suppl
 	return r.collection.CountDocuments(ctx, filter)
 }
 
+// CountByStatusAndClassification counts a company's relationships per status and classification in one aggregation
+// #QUERY_PATTERN: Company dashboard breakdown, served by the company/status/classification index
+func (r *MongoRelationshipRepository) CountByStatusAndClassification(ctx context.Context, companyID primitive.ObjectID) ([]RelationshipGroupCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"company_id": companyID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"status": "$status", "classification": "$classification"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":            0,
+			"status":         "$_id.status",
+			"classification": "$_id.classification",
+			"count":          1,
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var counts []RelationshipGroupCount
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// ListSupplierIDsByCompany lists the IDs of the supplier organizations linked to a company
+// #DATA_ASSUMPTION: Pending invitations have no supplier yet and are left out
+func (r *MongoRelationshipRepository) ListSupplierIDsByCompany(ctx context.Context, companyID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	filter := bson.M{"company_id": companyID, "supplier_id": bson.M{"$ne": nil}}
+	values, err := r.collection.Distinct(ctx, "supplier_id", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(values))
+	for _, value := range values {
+		if id, ok := value.(primitive.ObjectID); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// SearchByCompany lists a company's relationships whose invited email contains query or whose
+// supplier is one of supplierIDs
+// #IMPLEMENTATION_DECISION: Emails are matched by escaped substring rather than $text, since the
+// text tokenizer does not split addresses into useful words
+func (r *MongoRelationshipRepository) SearchByCompany(ctx context.Context, companyID primitive.ObjectID, query string, supplierIDs []primitive.ObjectID, limit int) ([]models.CompanySupplierRelationship, error) {
+	match := []bson.M{
+		{"invited_email": bson.M{"$regex": regexp.QuoteMeta(query), "$options": "i"}},
+	}
+	if len(supplierIDs) > 0 {
+		match = append(match, bson.M{"supplier_id": bson.M{"$in": supplierIDs}})
+	}
+
+	filter := bson.M{"company_id": companyID, "$or": match}
+	findOpts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var relationships []models.CompanySupplierRelationship
+	if err := cursor.All(ctx, &relationships); err != nil {
+		return nil, err
+	}
+	return relationships, nil
+}
+
 // Ensure MongoRelationshipRepository implements RelationshipRepository
 var _ RelationshipRepository = (*MongoRelationshipRepository)(nil)
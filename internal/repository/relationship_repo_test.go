@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/database"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+func TestRelationshipRepository_Create_DuplicateKey(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("duplicate invitation", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
+			Index:   0,
+			Code:    11000,
+			Message: "E11000 duplicate key error",
+		}))
+
+		err := NewMongoRelationshipRepository(mt.DB).Create(context.Background(), &models.CompanySupplierRelationship{
+			CompanyID:    primitive.NewObjectID(),
+			InvitedEmail: "supplier@example.com",
+		})
+		if !errors.Is(err, models.ErrRelationshipExists) {
+			t.Errorf("Expected ErrRelationshipExists, got %v", err)
+		}
+	})
+}
+
+func TestRelationshipRepository_AddNote_OtherCompany(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("no matching relationship", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}))
+
+		err := NewMongoRelationshipRepository(mt.DB).AddNote(context.Background(), primitive.NewObjectID(), primitive.NewObjectID(), models.RelationshipNote{
+			Text:      "Contract renewal under review",
+			AuthorID:  primitive.NewObjectID(),
+			CreatedAt: time.Now().UTC(),
+		})
+		if !errors.Is(err, models.ErrRelationshipNotFound) {
+			t.Errorf("Expected ErrRelationshipNotFound, got %v", err)
+		}
+	})
+}
+
+// TestRelationshipRepository_Create_ConcurrentInvites needs a real MongoDB (6.0+) because the
+// guard is the partial unique index; set NISFIX_TEST_DATABASE_URI to run it
+func TestRelationshipRepository_Create_ConcurrentInvites(t *testing.T) {
+	uri := os.Getenv("NISFIX_TEST_DATABASE_URI")
+	if uri == "" {
+		t.Skip("NISFIX_TEST_DATABASE_URI not set")
+	}
+
+	cfg := database.DefaultConfig()
+	cfg.URI = uri
+	cfg.Database = fmt.Sprintf("nisfix_test_%d", time.Now().UnixNano())
+	client, err := database.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	ctx := context.Background()
+	defer client.Close(ctx)        //nolint:errcheck // test cleanup
+	defer client.DropDatabase(ctx) //nolint:errcheck // test cleanup
+
+	if err := client.EnsureIndexes(ctx); err != nil {
+		t.Fatalf("Failed to create indexes: %v", err)
+	}
+
+	repo := NewRelationshipRepository(client)
+	companyID := primitive.NewObjectID()
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repo.Create(ctx, &models.CompanySupplierRelationship{
+				CompanyID:       companyID,
+				InvitedEmail:    "supplier@example.com",
+				InvitedByUserID: primitive.NewObjectID(),
+				Classification:  models.SupplierClassificationStandard,
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	created, rejected := 0, 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case errors.Is(err, models.ErrRelationshipExists):
+			rejected++
+		default:
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if created != 1 || rejected != 1 {
+		t.Errorf("Expected exactly one invite to win, got %d created and %d rejected", created, rejected)
+	}
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -47,11 +48,53 @@ func (r *MongoRequirementRepository) GetByID(ctx context.Context, id primitive.O
 	return &requirement, nil
 }
 
+// GetByIDs finds requirements by ID in one query, keyed by ID
+// #QUERY_PATTERN: Single $in lookup on _id for batch resolution (review queue, dashboards);
+// duplicate IDs are collapsed before querying
+func (r *MongoRequirementRepository) GetByIDs(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]*models.Requirement, error) {
+	requirements := make(map[primitive.ObjectID]*models.Requirement, len(ids))
+	if len(ids) == 0 {
+		return requirements, nil
+	}
+
+	seen := make(map[primitive.ObjectID]bool, len(ids))
+	unique := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": unique}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	for cursor.Next(ctx) {
+		var requirement models.Requirement
+		if err := cursor.Decode(&requirement); err != nil {
+			return nil, err
+		}
+		requirements[requirement.ID] = &requirement
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return requirements, nil
+}
+
 // Update updates a requirement
 func (r *MongoRequirementRepository) Update(ctx context.Context, requirement *models.Requirement) error {
 	requirement.BeforeUpdate()
+
+	// Attachments are only written by AddAttachment, so a stale copy cannot drop a concurrent upload
+	doc := *requirement
+	doc.Attachments = nil
+
 	filter := bson.M{"_id": requirement.ID}
-	update := bson.M{"$set": requirement}
+	update := bson.M{"$set": doc}
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
@@ -62,11 +105,45 @@ func (r *MongoRequirementRepository) Update(ctx context.Context, requirement *mo
 	return nil
 }
 
+// AddAttachment appends attachment metadata to a company's requirement
+// #IMPLEMENTATION_DECISION: The size cap in the filter keeps concurrent uploads from exceeding maxAttachments
+func (r *MongoRequirementRepository) AddAttachment(ctx context.Context, id, companyID primitive.ObjectID, attachment models.RequirementAttachment, maxAttachments int) error {
+	filter := bson.M{
+		"_id":        id,
+		"company_id": companyID,
+		fmt.Sprintf("attachments.%d", maxAttachments-1): bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$push": bson.M{"attachments": attachment},
+		"$set":  bson.M{"updated_at": attachment.UploadedAt},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id, "company_id": companyID})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return models.ErrRequirementNotFound
+		}
+		return models.ErrTooManyAttachments
+	}
+	return nil
+}
+
+// RequirementSortFields are the fields the requirement lists accept as PaginationOptions.SortBy
+// #INDEX_STRATEGY: Lists always sort by due_date first (indexed with company/supplier and status);
+// these only break ties within a due date
+var RequirementSortFields = []string{"created_at", "updated_at", "assigned_at", "due_date", "priority", "status", "title"}
+
 // ListByCompany lists requirements for a company
-func (r *MongoRequirementRepository) ListByCompany(ctx context.Context, companyID primitive.ObjectID, status *models.RequirementStatus, opts PaginationOptions) (*PaginatedResult[models.Requirement], error) {
+func (r *MongoRequirementRepository) ListByCompany(ctx context.Context, companyID primitive.ObjectID, statuses []models.RequirementStatus, opts PaginationOptions) (*PaginatedResult[models.Requirement], error) {
 	filter := bson.M{"company_id": companyID}
-	if status != nil {
-		filter["status"] = *status
+	if len(statuses) > 0 {
+		filter["status"] = bson.M{"$in": statuses}
 	}
 
 	// Count total
@@ -107,11 +184,42 @@ func (r *MongoRequirementRepository) ListByCompany(ctx context.Context, companyI
 	}, nil
 }
 
+// ListByCompanyCursor lists requirements for a company using keyset pagination, newest first
+func (r *MongoRequirementRepository) ListByCompanyCursor(ctx context.Context, companyID primitive.ObjectID, statuses []models.RequirementStatus, opts CursorPaginationOptions) (*CursorResult[models.Requirement], error) {
+	filter := bson.M{"company_id": companyID}
+	if len(statuses) > 0 {
+		filter["status"] = bson.M{"$in": statuses}
+	}
+	if err := applyCursor(filter, opts); err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, cursorFindOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var requirements []models.Requirement
+	if err := cursor.All(ctx, &requirements); err != nil {
+		return nil, err
+	}
+
+	return newCursorResult(requirements, opts, func(r *models.Requirement) primitive.ObjectID { return r.ID }), nil
+}
+
 // ListBySupplier lists requirements for a supplier
-func (r *MongoRequirementRepository) ListBySupplier(ctx context.Context, supplierID primitive.ObjectID, status *models.RequirementStatus, opts PaginationOptions) (*PaginatedResult[models.Requirement], error) {
+// #BUSINESS_RULE: Without a status filter cancelled requirements are left out; the supplier
+// never has to act on them
+func (r *MongoRequirementRepository) ListBySupplier(ctx context.Context, supplierID primitive.ObjectID, statuses []models.RequirementStatus, assigneeID *primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.Requirement], error) {
 	filter := bson.M{"supplier_id": supplierID}
-	if status != nil {
-		filter["status"] = *status
+	if len(statuses) > 0 {
+		filter["status"] = bson.M{"$in": statuses}
+	} else {
+		filter["status"] = bson.M{"$ne": models.RequirementStatusCancelled}
+	}
+	if assigneeID != nil {
+		filter["assigned_user_id"] = *assigneeID
 	}
 
 	// Count total
@@ -153,10 +261,10 @@ func (r *MongoRequirementRepository) ListBySupplier(ctx context.Context, supplie
 }
 
 // ListByRelationship lists requirements for a relationship
-func (r *MongoRequirementRepository) ListByRelationship(ctx context.Context, relationshipID primitive.ObjectID, status *models.RequirementStatus) ([]models.Requirement, error) {
+func (r *MongoRequirementRepository) ListByRelationship(ctx context.Context, relationshipID primitive.ObjectID, statuses []models.RequirementStatus) ([]models.Requirement, error) {
 	filter := bson.M{"relationship_id": relationshipID}
-	if status != nil {
-		filter["status"] = *status
+	if len(statuses) > 0 {
+		filter["status"] = bson.M{"$in": statuses}
 	}
 
 	findOpts := options.Find().SetSort(bson.D{{Key: "due_date", Value: 1}})
@@ -178,7 +286,32 @@ func (r *MongoRequirementRepository) ListByRelationship(ctx context.Context, rel
 // ListOverdue lists overdue requirements
 // #QUERY_PATTERN: Dashboard queries: "overdue requirements"
 func (r *MongoRequirementRepository) ListOverdue(ctx context.Context, companyID *primitive.ObjectID) ([]models.Requirement, error) {
-	filter := bson.M{
+	filter := overdueFilter()
+	if companyID != nil {
+		filter["company_id"] = *companyID
+	}
+	return r.findOverdue(ctx, filter)
+}
+
+// ListOverdueBySupplier lists a supplier's overdue requirements across all companies
+// #QUERY_PATTERN: Supplier worklist: "what am I late on"
+func (r *MongoRequirementRepository) ListOverdueBySupplier(ctx context.Context, supplierID primitive.ObjectID) ([]models.Requirement, error) {
+	filter := overdueFilter()
+	filter["supplier_id"] = supplierID
+	return r.findOverdue(ctx, filter)
+}
+
+// CountOverdueBySupplier counts a supplier's overdue requirements across all companies
+// #QUERY_PATTERN: Served by the supplier/status/due_date index
+func (r *MongoRequirementRepository) CountOverdueBySupplier(ctx context.Context, supplierID primitive.ObjectID) (int64, error) {
+	filter := overdueFilter()
+	filter["supplier_id"] = supplierID
+	return r.collection.CountDocuments(ctx, filter)
+}
+
+// overdueFilter matches open requirements whose due date has passed
+func overdueFilter() bson.M {
+	return bson.M{
 		"status": bson.M{
 			"$in": []models.RequirementStatus{
 				models.RequirementStatusPending,
@@ -189,10 +322,10 @@ func (r *MongoRequirementRepository) ListOverdue(ctx context.Context, companyID
 			"$lt": time.Now().UTC(),
 		},
 	}
-	if companyID != nil {
-		filter["company_id"] = *companyID
-	}
+}
 
+// findOverdue runs an overdue query, most overdue first
+func (r *MongoRequirementRepository) findOverdue(ctx context.Context, filter bson.M) ([]models.Requirement, error) {
 	findOpts := options.Find().SetSort(bson.D{{Key: "due_date", Value: 1}})
 
 	cursor, err := r.collection.Find(ctx, filter, findOpts)
@@ -260,6 +393,28 @@ func (r *MongoRequirementRepository) MarkReminderSent(ctx context.Context, id pr
 	return nil
 }
 
+// SetAssignee sets or, with a nil userID, clears the supplier team member owning a supplier's requirement
+// #SECURITY_ASSUMPTION: Filtered by supplier_id so one supplier cannot assign another supplier's requirement
+func (r *MongoRequirementRepository) SetAssignee(ctx context.Context, id, supplierID primitive.ObjectID, userID *primitive.ObjectID) error {
+	filter := bson.M{"_id": id, "supplier_id": supplierID}
+	set := bson.M{"updated_at": time.Now().UTC()}
+	update := bson.M{"$set": set}
+	if userID != nil {
+		set["assigned_user_id"] = *userID
+	} else {
+		update["$unset"] = bson.M{"assigned_user_id": ""}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrRequirementNotFound
+	}
+	return nil
+}
+
 // ExpireOverdue marks overdue requirements as expired
 func (r *MongoRequirementRepository) ExpireOverdue(ctx context.Context) (int64, error) {
 	now := time.Now().UTC()
@@ -313,5 +468,148 @@ func (r *MongoRequirementRepository) CountBySupplier(ctx context.Context, suppli
 	return r.collection.CountDocuments(ctx, filter)
 }
 
+// CountByRelationships counts requirements per relationship in a single aggregation
+func (r *MongoRequirementRepository) CountByRelationships(ctx context.Context, relationshipIDs []primitive.ObjectID, status *models.RequirementStatus) (map[primitive.ObjectID]int64, error) {
+	counts := make(map[primitive.ObjectID]int64, len(relationshipIDs))
+	if len(relationshipIDs) == 0 {
+		return counts, nil
+	}
+
+	match := bson.M{"relationship_id": bson.M{"$in": relationshipIDs}}
+	if status != nil {
+		match["status"] = *status
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": "$relationship_id", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var rows []struct {
+		RelationshipID primitive.ObjectID `bson:"_id"`
+		Count          int64              `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.RelationshipID] = row.Count
+	}
+	return counts, nil
+}
+
+// outstandingRequirementStatuses are the statuses in which a requirement awaits the supplier
+var outstandingRequirementStatuses = []models.RequirementStatus{
+	models.RequirementStatusPending,
+	models.RequirementStatusInProgress,
+	models.RequirementStatusUnderReview,
+}
+
+// GetCompanyOverview summarizes a company's requirements in one aggregation
+// #QUERY_PATTERN: A single $facet over the company's requirements instead of one count per status
+func (r *MongoRequirementRepository) GetCompanyOverview(ctx context.Context, companyID primitive.ObjectID, listLimit int) (*CompanyRequirementOverview, error) {
+	now := time.Now().UTC()
+
+	// Null and missing due dates sort below any date, so they must be excluded explicitly
+	isOverdue := bson.M{"$and": bson.A{
+		bson.M{"$in": bson.A{"$status", bson.A{models.RequirementStatusPending, models.RequirementStatusInProgress}}},
+		bson.M{"$gt": bson.A{"$due_date", nil}},
+		bson.M{"$lt": bson.A{"$due_date", now}},
+	}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"company_id": companyID}}},
+		{{Key: "$facet", Value: bson.M{
+			"by_status": bson.A{
+				bson.M{"$group": bson.M{"_id": "$status", "count": bson.M{"$sum": 1}}},
+			},
+			"overdue": bson.A{
+				bson.M{"$match": overdueFilter()},
+				bson.M{"$count": "count"},
+			},
+			"awaiting_review": bson.A{
+				bson.M{"$match": bson.M{"status": models.RequirementStatusSubmitted}},
+				bson.M{"$sort": bson.D{{Key: "updated_at", Value: 1}}},
+				bson.M{"$limit": listLimit},
+			},
+			"top_outstanding": bson.A{
+				bson.M{"$match": bson.M{"status": bson.M{"$in": outstandingRequirementStatuses}}},
+				bson.M{"$group": bson.M{
+					"_id":         "$supplier_id",
+					"outstanding": bson.M{"$sum": 1},
+					"overdue":     bson.M{"$sum": bson.M{"$cond": bson.A{isOverdue, 1, 0}}},
+				}},
+				bson.M{"$sort": bson.D{{Key: "outstanding", Value: -1}, {Key: "overdue", Value: -1}, {Key: "_id", Value: 1}}},
+				bson.M{"$limit": listLimit},
+			},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var facets []struct {
+		ByStatus []struct {
+			Status models.RequirementStatus `bson:"_id"`
+			Count  int64                    `bson:"count"`
+		} `bson:"by_status"`
+		Overdue []struct {
+			Count int64 `bson:"count"`
+		} `bson:"overdue"`
+		AwaitingReview []models.Requirement       `bson:"awaiting_review"`
+		TopOutstanding []SupplierOutstandingCount `bson:"top_outstanding"`
+	}
+	if err := cursor.All(ctx, &facets); err != nil {
+		return nil, err
+	}
+
+	overview := &CompanyRequirementOverview{StatusCounts: make(map[models.RequirementStatus]int64)}
+	if len(facets) == 0 {
+		return overview, nil
+	}
+	for _, row := range facets[0].ByStatus {
+		overview.StatusCounts[row.Status] = row.Count
+	}
+	if len(facets[0].Overdue) > 0 {
+		overview.Overdue = facets[0].Overdue[0].Count
+	}
+	overview.AwaitingReview = facets[0].AwaitingReview
+	overview.TopOutstanding = facets[0].TopOutstanding
+	return overview, nil
+}
+
+// SearchByCompany full-text searches the titles of a company's requirements, best match first
+// #QUERY_PATTERN: $text with a company_id equality, served by the company-prefixed text index
+func (r *MongoRequirementRepository) SearchByCompany(ctx context.Context, companyID primitive.ObjectID, query string, limit int) ([]models.Requirement, error) {
+	filter := bson.M{
+		"company_id": companyID,
+		"$text":      bson.M{"$search": query},
+	}
+	findOpts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}}).
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var requirements []models.Requirement
+	if err := cursor.All(ctx, &requirements); err != nil {
+		return nil, err
+	}
+	return requirements, nil
+}
+
 // Ensure MongoRequirementRepository implements RequirementRepository
 var _ RequirementRepository = (*MongoRequirementRepository)(nil)
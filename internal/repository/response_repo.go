@@ -94,6 +94,7 @@ func (r *MongoResponseRepository) SaveDraftAnswer(ctx context.Context, responseI
 			"draft_answers.$": answer,
 			"updated_at":      now,
 		},
+		"$inc": bson.M{"version": 1},
 	}
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
@@ -106,6 +107,7 @@ func (r *MongoResponseRepository) SaveDraftAnswer(ctx context.Context, responseI
 		update = bson.M{
 			"$push": bson.M{"draft_answers": answer},
 			"$set":  bson.M{"updated_at": now},
+			"$inc":  bson.M{"version": 1},
 		}
 		result, err = r.collection.UpdateOne(ctx, filter, update)
 		if err != nil {
@@ -119,6 +121,87 @@ func (r *MongoResponseRepository) SaveDraftAnswer(ctx context.Context, responseI
 	return nil
 }
 
+// ReplaceDraftAnswers replaces all draft answers if the response is unsubmitted and still at expectedVersion
+// #IMPLEMENTATION_DECISION: Compare-and-set on version makes a multi-answer save atomic and
+// rejects saves based on a stale copy instead of silently overwriting newer answers; submission
+// does not bump the version, so the filter also excludes submitted responses
+func (r *MongoResponseRepository) ReplaceDraftAnswers(ctx context.Context, responseID primitive.ObjectID, expectedVersion int, answers []models.DraftAnswer) (int, error) {
+	filter := bson.M{"_id": responseID, "version": expectedVersion, "submitted_at": nil}
+	if expectedVersion == 0 {
+		// Responses created before versioning have no version field
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"draft_answers": answers,
+			"version":       expectedVersion + 1,
+			"updated_at":    time.Now().UTC(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	if result.MatchedCount == 0 {
+		var current models.SupplierResponse
+		findOpts := options.FindOne().SetProjection(bson.M{"submitted_at": 1})
+		err := r.collection.FindOne(ctx, bson.M{"_id": responseID}, findOpts).Decode(&current)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, models.ErrResponseNotFound
+		}
+		if err != nil {
+			return 0, err
+		}
+		if current.IsSubmitted() {
+			return 0, models.ErrResponseAlreadySubmitted
+		}
+		return 0, models.ErrResponseConflict
+	}
+	return expectedVersion + 1, nil
+}
+
+// DeleteDraftAnswer removes the draft answer for a question from an unsubmitted response
+// #IMPLEMENTATION_DECISION: Clearing an answer that was never saved still bumps the version, so
+// the call is idempotent for the client and its ETag stays in step with the stored response
+func (r *MongoResponseRepository) DeleteDraftAnswer(ctx context.Context, responseID, questionID primitive.ObjectID) (*models.SupplierResponse, error) {
+	filter := bson.M{"_id": responseID, "submitted_at": nil}
+	update := bson.M{
+		"$pull": bson.M{"draft_answers": bson.M{"question_id": questionID}},
+		"$set":  bson.M{"updated_at": time.Now().UTC()},
+		"$inc":  bson.M{"version": 1},
+	}
+	findOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var response models.SupplierResponse
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, findOpts).Decode(&response)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, models.ErrResponseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// AddClarification appends a message to a response's clarification threads
+// #IMPLEMENTATION_DECISION: Does not bump the version; clarifications are not draft edits and must
+// not make a concurrent draft save conflict
+func (r *MongoResponseRepository) AddClarification(ctx context.Context, responseID primitive.ObjectID, clarification models.Clarification) error {
+	update := bson.M{
+		"$push": bson.M{"clarifications": clarification},
+		"$set":  bson.M{"updated_at": clarification.CreatedAt},
+	}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": responseID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrResponseNotFound
+	}
+	return nil
+}
+
 // ListBySupplier lists responses for a supplier
 func (r *MongoResponseRepository) ListBySupplier(ctx context.Context, supplierID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.SupplierResponse], error) {
 	filter := bson.M{"supplier_id": supplierID}
@@ -221,9 +304,13 @@ func (r *MongoSubmissionRepository) GetByResponse(ctx context.Context, responseI
 	return &submission, nil
 }
 
-// ListByQuestionnaire lists submissions for a questionnaire
-func (r *MongoSubmissionRepository) ListByQuestionnaire(ctx context.Context, questionnaireID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.QuestionnaireSubmission], error) {
+// ListByQuestionnaire lists submissions for a questionnaire, optionally only passed or failed ones
+// #QUERY_PATTERN: Served by idx_questionnaire_submitted when sorted by submitted_at
+func (r *MongoSubmissionRepository) ListByQuestionnaire(ctx context.Context, questionnaireID primitive.ObjectID, passed *bool, opts PaginationOptions) (*PaginatedResult[models.QuestionnaireSubmission], error) {
 	filter := bson.M{"questionnaire_id": questionnaireID}
+	if passed != nil {
+		filter["passed"] = *passed
+	}
 
 	// Count total
 	total, err := r.collection.CountDocuments(ctx, filter)
@@ -263,6 +350,54 @@ func (r *MongoSubmissionRepository) ListByQuestionnaire(ctx context.Context, que
 	}, nil
 }
 
+// ForEachByCompany streams a company's submitted submissions in batches, newest first
+// #QUERY_PATTERN: Served by idx_company_submitted; answers and topic scores are not loaded,
+// so one batch stays small however long the questionnaires are
+func (r *MongoSubmissionRepository) ForEachByCompany(ctx context.Context, companyID primitive.ObjectID, from, to *time.Time, batchSize int, fn func([]models.QuestionnaireSubmission) error) error {
+	submittedAt := bson.M{"$ne": nil}
+	if from != nil {
+		submittedAt["$gte"] = *from
+	}
+	if to != nil {
+		submittedAt["$lte"] = *to
+	}
+	filter := bson.M{"company_id": companyID, "submitted_at": submittedAt}
+	findOpts := options.Find().
+		SetBatchSize(int32(batchSize)).
+		SetProjection(bson.M{"answers": 0, "topic_scores": 0}).
+		SetSort(bson.D{{Key: "submitted_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	batch := make([]models.QuestionnaireSubmission, 0, batchSize)
+	for cursor.Next(ctx) {
+		var submission models.QuestionnaireSubmission
+		if err := cursor.Decode(&submission); err != nil {
+			return err
+		}
+		batch = append(batch, submission)
+
+		if len(batch) == batchSize {
+			if err := fn(batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	if len(batch) > 0 {
+		return fn(batch)
+	}
+	return nil
+}
+
 // GetPassRateByQuestionnaire calculates pass rate for a questionnaire
 func (r *MongoSubmissionRepository) GetPassRateByQuestionnaire(ctx context.Context, questionnaireID primitive.ObjectID) (float64, error) {
 	pipeline := []bson.M{
@@ -304,6 +439,99 @@ func (r *MongoSubmissionRepository) GetPassRateByQuestionnaire(ctx context.Conte
 	return 0, nil
 }
 
+// submittedFilter matches submitted submissions of a questionnaire
+func submittedFilter(questionnaireID primitive.ObjectID) bson.M {
+	return bson.M{
+		"questionnaire_id": questionnaireID,
+		"submitted_at":     bson.M{"$ne": nil},
+	}
+}
+
+// GetSummaryByQuestionnaire aggregates submission count, passes and average score for a questionnaire
+// #QUERY_PATTERN: Returns a zero summary when there are no submissions
+func (r *MongoSubmissionRepository) GetSummaryByQuestionnaire(ctx context.Context, questionnaireID primitive.ObjectID) (*SubmissionSummary, error) {
+	pipeline := []bson.M{
+		{"$match": submittedFilter(questionnaireID)},
+		{
+			"$group": bson.M{
+				"_id":           nil,
+				"submissions":   bson.M{"$sum": 1},
+				"passed":        bson.M{"$sum": bson.M{"$cond": []interface{}{"$passed", 1, 0}}},
+				"average_score": bson.M{"$avg": "$percentage_score"},
+			},
+		},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	summary := &SubmissionSummary{}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(summary); err != nil {
+			return nil, err
+		}
+	}
+	return summary, cursor.Err()
+}
+
+// GetQuestionFailureStats counts answers and failed answers per question for a questionnaire
+func (r *MongoSubmissionRepository) GetQuestionFailureStats(ctx context.Context, questionnaireID primitive.ObjectID) ([]QuestionFailureStat, error) {
+	pipeline := []bson.M{
+		{"$match": submittedFilter(questionnaireID)},
+		{"$unwind": "$answers"},
+		{
+			"$group": bson.M{
+				"_id":      "$answers.question_id",
+				"answered": bson.M{"$sum": 1},
+				"failed": bson.M{"$sum": bson.M{"$cond": []interface{}{
+					bson.M{"$lt": []string{"$answers.points_earned", "$answers.max_points"}}, 1, 0,
+				}}},
+			},
+		},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var stats []QuestionFailureStat
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// GetTopicScoreStats averages topic percentage scores for a questionnaire
+func (r *MongoSubmissionRepository) GetTopicScoreStats(ctx context.Context, questionnaireID primitive.ObjectID) ([]TopicScoreStat, error) {
+	pipeline := []bson.M{
+		{"$match": submittedFilter(questionnaireID)},
+		{"$unwind": "$topic_scores"},
+		{
+			"$group": bson.M{
+				"_id":           "$topic_scores.topic_id",
+				"average_score": bson.M{"$avg": "$topic_scores.percentage_score"},
+			},
+		},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var stats []TopicScoreStat
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
 // Ensure MongoSubmissionRepository implements SubmissionRepository
 var _ SubmissionRepository = (*MongoSubmissionRepository)(nil)
 
@@ -373,6 +601,48 @@ func (r *MongoVerificationRepository) GetLatestBySupplier(ctx context.Context, s
 	return &verification, nil
 }
 
+// ListBySupplier lists a supplier's verifications, newest first
+// #QUERY_PATTERN: Served by the supplier_id+verified_at index; the sort is fixed so the history
+// always reads as a timeline
+func (r *MongoVerificationRepository) ListBySupplier(ctx context.Context, supplierID primitive.ObjectID, opts PaginationOptions) (*PaginatedResult[models.CheckFixVerification], error) {
+	filter := bson.M{"supplier_id": supplierID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	skip := int64((opts.Page - 1) * opts.Limit)
+	findOpts := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(opts.Limit)).
+		SetSort(bson.D{{Key: "verified_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	var verifications []models.CheckFixVerification
+	if err := cursor.All(ctx, &verifications); err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / opts.Limit
+	if int(total)%opts.Limit > 0 {
+		totalPages++
+	}
+
+	return &PaginatedResult[models.CheckFixVerification]{
+		Items:      verifications,
+		TotalCount: total,
+		Page:       opts.Page,
+		Limit:      opts.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
 // Update updates a verification
 func (r *MongoVerificationRepository) Update(ctx context.Context, verification *models.CheckFixVerification) error {
 	verification.BeforeUpdate()
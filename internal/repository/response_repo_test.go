@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+func TestSubmissionRepository_ForEachByCompany(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("filters by company and date and batches", func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + models.QuestionnaireSubmission{}.CollectionName()
+		companyID := primitive.NewObjectID()
+		from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "company_id", Value: companyID}},
+			bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "company_id", Value: companyID}},
+			bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "company_id", Value: companyID}},
+		))
+
+		var batches []int
+		err := NewMongoSubmissionRepository(mt.DB).ForEachByCompany(context.Background(), companyID, &from, nil, 2,
+			func(submissions []models.QuestionnaireSubmission) error {
+				batches = append(batches, len(submissions))
+				return nil
+			})
+		if err != nil {
+			t.Fatalf("ForEachByCompany failed: %v", err)
+		}
+		if len(batches) != 2 || batches[0] != 2 || batches[1] != 1 {
+			t.Errorf("Expected batches of 2 and 1, got %v", batches)
+		}
+
+		filter := mt.GetStartedEvent().Command.Lookup("filter").Document()
+		if got := filter.Lookup("company_id").ObjectID(); got != companyID {
+			t.Errorf("Expected company filter %s, got %s", companyID.Hex(), got.Hex())
+		}
+		submittedAt := filter.Lookup("submitted_at").Document()
+		if _, err := submittedAt.LookupErr("$gte"); err != nil {
+			t.Errorf("Expected a lower submitted_at bound, got %v", submittedAt)
+		}
+		if _, err := submittedAt.LookupErr("$lte"); err == nil {
+			t.Errorf("Expected no upper submitted_at bound, got %v", submittedAt)
+		}
+	})
+}
+
+func TestResponseRepository_ReplaceDraftAnswers(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	responseID := primitive.NewObjectID()
+
+	tests := []struct {
+		name    string
+		current bson.D
+		wantErr error
+	}{
+		{"submitted since it was read", bson.D{{Key: "_id", Value: responseID}, {Key: "submitted_at", Value: time.Now().UTC()}}, models.ErrResponseAlreadySubmitted},
+		{"stale version", bson.D{{Key: "_id", Value: responseID}}, models.ErrResponseConflict},
+		{"missing", nil, models.ErrResponseNotFound},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			ns := mt.Coll.Database().Name() + "." + models.SupplierResponse{}.CollectionName()
+			var found []bson.D
+			if tt.current != nil {
+				found = append(found, tt.current)
+			}
+			mt.AddMockResponses(
+				bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 0}, {Key: "nModified", Value: 0}},
+				mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, found...),
+			)
+
+			_, err := NewMongoResponseRepository(mt.DB).ReplaceDraftAnswers(context.Background(), responseID, 3, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+
+			events := mt.GetAllStartedEvents()
+			if len(events) == 0 || events[0].CommandName != "update" {
+				t.Fatalf("Expected an update first, got %+v", events)
+			}
+			filter := events[0].Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("q").Document()
+			if submittedAt, err := filter.LookupErr("submitted_at"); err != nil || submittedAt.Type != bson.TypeNull {
+				t.Errorf("Expected the update to skip submitted responses, got %v", filter)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+// MongoRevokedTokenRepository implements RevokedTokenRepository for MongoDB
+// #ORM_INTEGRATION: MongoDB driver-based repository implementation
+type MongoRevokedTokenRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoRevokedTokenRepository creates a new MongoDB revoked token repository
+func NewMongoRevokedTokenRepository(db *mongo.Database) *MongoRevokedTokenRepository {
+	return &MongoRevokedTokenRepository{
+		collection: db.Collection(models.RevokedToken{}.CollectionName()),
+	}
+}
+
+// Revoke adds a single token to the denylist until it expires
+func (r *MongoRevokedTokenRepository) Revoke(ctx context.Context, tokenID string, userID primitive.ObjectID, expiresAt time.Time) error {
+	entry := &models.RevokedToken{
+		TokenID:   tokenID,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	}
+	entry.BeforeCreate()
+	_, err := r.collection.InsertOne(ctx, entry)
+	if mongo.IsDuplicateKeyError(err) {
+		// Already revoked
+		return nil
+	}
+	return err
+}
+
+// RevokeAllForUser denies every token of the user issued up to now
+func (r *MongoRevokedTokenRepository) RevokeAllForUser(ctx context.Context, userID primitive.ObjectID, expiresAt time.Time) error {
+	entry := &models.RevokedToken{
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	}
+	entry.BeforeCreate()
+	_, err := r.collection.InsertOne(ctx, entry)
+	return err
+}
+
+// IsRevoked checks whether a token is denied, either individually or by a user-wide entry
+// #IMPLEMENTATION_DECISION: JWT iat has second precision, so a user-wide entry also covers
+// tokens issued within the same second
+func (r *MongoRevokedTokenRepository) IsRevoked(ctx context.Context, tokenID string, userID primitive.ObjectID, issuedAt time.Time) (bool, error) {
+	or := bson.A{
+		bson.M{
+			"user_id":  userID,
+			"token_id": bson.M{"$exists": false},
+			"created_at": bson.M{
+				"$gte": issuedAt.UTC().Truncate(time.Second),
+			},
+		},
+	}
+	if tokenID != "" {
+		or = append(or, bson.M{"token_id": tokenID})
+	}
+	filter := bson.M{
+		"$or": or,
+	}
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Ensure MongoRevokedTokenRepository implements RevokedTokenRepository
+var _ RevokedTokenRepository = (*MongoRevokedTokenRepository)(nil)
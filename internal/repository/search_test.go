@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/database"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+// TestSearch_StartupTextIndexes needs a real MongoDB because $text fails without a text index;
+// it checks that EnsureIndexes creates every index the company-wide search relies on.
+// Set NISFIX_TEST_DATABASE_URI to run it
+func TestSearch_StartupTextIndexes(t *testing.T) {
+	uri := os.Getenv("NISFIX_TEST_DATABASE_URI")
+	if uri == "" {
+		t.Skip("NISFIX_TEST_DATABASE_URI not set")
+	}
+
+	cfg := database.DefaultConfig()
+	cfg.URI = uri
+	cfg.Database = fmt.Sprintf("nisfix_test_%d", time.Now().UnixNano())
+	client, err := database.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	ctx := context.Background()
+	defer client.Close(ctx)        //nolint:errcheck // test cleanup
+	defer client.DropDatabase(ctx) //nolint:errcheck // test cleanup
+
+	if err := client.EnsureIndexes(ctx); err != nil {
+		t.Fatalf("Failed to create indexes: %v", err)
+	}
+
+	db := client.Database()
+	orgRepo := NewMongoOrganizationRepository(db)
+	questionnaireRepo := NewMongoQuestionnaireRepository(db)
+	requirementRepo := NewMongoRequirementRepository(db)
+	companyID := primitive.NewObjectID()
+
+	supplier := &models.Organization{Name: "Acme Hosting", Slug: "acme-hosting", Type: models.OrganizationTypeSupplier}
+	other := &models.Organization{Name: "Globex Logistics", Slug: "globex-logistics", Type: models.OrganizationTypeSupplier}
+	for _, org := range []*models.Organization{supplier, other} {
+		if err := orgRepo.Create(ctx, org); err != nil {
+			t.Fatalf("Failed to create organization: %v", err)
+		}
+	}
+	if err := questionnaireRepo.Create(ctx, &models.Questionnaire{CompanyID: companyID, Name: "Hosting security baseline"}); err != nil {
+		t.Fatalf("Failed to create questionnaire: %v", err)
+	}
+	if err := questionnaireRepo.Create(ctx, &models.Questionnaire{CompanyID: primitive.NewObjectID(), Name: "Hosting security baseline"}); err != nil {
+		t.Fatalf("Failed to create questionnaire: %v", err)
+	}
+	if err := requirementRepo.Create(ctx, &models.Requirement{CompanyID: companyID, SupplierID: supplier.ID, Title: "Annual hosting review"}); err != nil {
+		t.Fatalf("Failed to create requirement: %v", err)
+	}
+
+	orgs, err := orgRepo.SearchByName(ctx, []primitive.ObjectID{supplier.ID, other.ID}, "hosting", 10)
+	if err != nil {
+		t.Fatalf("SearchByName failed: %v", err)
+	}
+	if len(orgs) != 1 || orgs[0].ID != supplier.ID {
+		t.Errorf("Expected only the matching supplier, got %+v", orgs)
+	}
+
+	questionnaires, err := questionnaireRepo.SearchByCompany(ctx, companyID, "hosting", 10)
+	if err != nil {
+		t.Fatalf("Questionnaire SearchByCompany failed: %v", err)
+	}
+	if len(questionnaires) != 1 || questionnaires[0].CompanyID != companyID {
+		t.Errorf("Expected the company's questionnaire only, got %+v", questionnaires)
+	}
+
+	requirements, err := requirementRepo.SearchByCompany(ctx, companyID, "hosting", 10)
+	if err != nil {
+		t.Fatalf("Requirement SearchByCompany failed: %v", err)
+	}
+	if len(requirements) != 1 {
+		t.Errorf("Expected one requirement, got %+v", requirements)
+	}
+}
@@ -111,7 +111,25 @@ func (r *MongoSecureLinkRepository) Invalidate(ctx context.Context, id primitive
 // InvalidateAllForEmail invalidates all links for an email
 func (r *MongoSecureLinkRepository) InvalidateAllForEmail(ctx context.Context, email string) error {
 	filter := bson.M{
-		"email":    email,
+		"email":    models.NormalizeEmail(email),
+		"is_valid": true,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"is_valid": false,
+		},
+	}
+	_, err := r.collection.UpdateMany(ctx, filter, update)
+	return err
+}
+
+// InvalidateAllForUser invalidates all links of a type issued to a user
+// #QUERY_PATTERN: Used to supersede pending email changes; no dedicated index since a user has
+// only a handful of short-lived links
+func (r *MongoSecureLinkRepository) InvalidateAllForUser(ctx context.Context, userID primitive.ObjectID, linkType models.SecureLinkType) error {
+	filter := bson.M{
+		"user_id":  userID,
+		"type":     linkType,
 		"is_valid": true,
 	}
 	update := bson.M{
@@ -128,7 +146,7 @@ func (r *MongoSecureLinkRepository) InvalidateAllForEmail(ctx context.Context, e
 func (r *MongoSecureLinkRepository) CountRecentByEmail(ctx context.Context, email string, withinMinutes int) (int64, error) {
 	since := time.Now().UTC().Add(-time.Duration(withinMinutes) * time.Minute)
 	filter := bson.M{
-		"email": email,
+		"email": models.NormalizeEmail(email),
 		"created_at": bson.M{
 			"$gte": since,
 		},
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+// MongoSentEmailRepository implements SentEmailRepository for MongoDB
+// #ORM_INTEGRATION: MongoDB driver-based repository implementation
+type MongoSentEmailRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoSentEmailRepository creates a new MongoDB sent email repository
+func NewMongoSentEmailRepository(db *mongo.Database) *MongoSentEmailRepository {
+	return &MongoSentEmailRepository{
+		collection: db.Collection(models.SentEmail{}.CollectionName()),
+	}
+}
+
+// Create stores a send record
+func (r *MongoSentEmailRepository) Create(ctx context.Context, email *models.SentEmail) error {
+	email.BeforeCreate()
+	_, err := r.collection.InsertOne(ctx, email)
+	return err
+}
+
+// ListByOrganization lists send records of an organization, newest first
+// #QUERY_PATTERN: Uses the organization + recipient + created_at index, or its prefix without a recipient
+func (r *MongoSentEmailRepository) ListByOrganization(ctx context.Context, orgID primitive.ObjectID, recipient string, opts PaginationOptions) (*PaginatedResult[models.SentEmail], error) {
+	filter := bson.M{"organization_id": orgID}
+	if recipient != "" {
+		filter["recipient"] = recipient
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64((opts.Page - 1) * opts.Limit)).
+		SetLimit(int64(opts.Limit))
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	emails := []models.SentEmail{}
+	if err := cursor.All(ctx, &emails); err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / opts.Limit
+	if int(total)%opts.Limit > 0 {
+		totalPages++
+	}
+
+	return &PaginatedResult[models.SentEmail]{
+		Items:      emails,
+		TotalCount: total,
+		Page:       opts.Page,
+		Limit:      opts.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// Ensure MongoSentEmailRepository implements SentEmailRepository
+var _ SentEmailRepository = (*MongoSentEmailRepository)(nil)
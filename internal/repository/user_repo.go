@@ -53,11 +53,42 @@ func (r *MongoUserRepository) GetByID(ctx context.Context, id primitive.ObjectID
 	return &user, nil
 }
 
+// GetByIDs finds users by ID in one query, keyed by ID
+// #QUERY_PATTERN: Single $in lookup so list handlers can resolve users without N+1 queries
+func (r *MongoUserRepository) GetByIDs(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]*models.User, error) {
+	users := make(map[primitive.ObjectID]*models.User, len(ids))
+	if len(ids) == 0 {
+		return users, nil
+	}
+
+	filter := bson.M{
+		"_id":        bson.M{"$in": ids},
+		"deleted_at": nil,
+	}
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	for cursor.Next(ctx) {
+		var user models.User
+		if err := cursor.Decode(&user); err != nil {
+			return nil, err
+		}
+		users[user.ID] = &user
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 // GetByEmail finds a user by email
 func (r *MongoUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
 	filter := bson.M{
-		"email":      email,
+		"email":      models.NormalizeEmail(email),
 		"deleted_at": nil,
 	}
 	err := r.collection.FindOne(ctx, filter).Decode(&user)
@@ -138,6 +169,34 @@ func (r *MongoUserRepository) UpdateLastLogin(ctx context.Context, id primitive.
 	return nil
 }
 
+// SetPasswordHash stores a user's password hash and when it was set
+// #IMPLEMENTATION_DECISION: Targeted update, so setting a password never races a profile edit
+func (r *MongoUserRepository) SetPasswordHash(ctx context.Context, id primitive.ObjectID, hash string) error {
+	now := time.Now().UTC()
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": nil,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"password_hash":   hash,
+			"password_set_at": now,
+			"updated_at":      now,
+		},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return models.ErrUserNotFound
+	}
+	return nil
+}
+
+// UserSortFields are the fields ListByOrganization accepts as PaginationOptions.SortBy
+var UserSortFields = []string{"created_at", "updated_at", "last_login_at", "email", "name", "role"}
+
 // ListByOrganization lists users in an organization
 func (r *MongoUserRepository) ListByOrganization(ctx context.Context, orgID primitive.ObjectID, includeInactive bool, opts PaginationOptions) (*PaginatedResult[models.User], error) {
 	filter := bson.M{
@@ -196,5 +255,17 @@ func (r *MongoUserRepository) CountByOrganization(ctx context.Context, orgID pri
 	return r.collection.CountDocuments(ctx, filter)
 }
 
+// CountByOrganizationAndRole counts active users with a given role in an organization
+// #QUERY_PATTERN: Used to guard against removing the last admin of an organization
+func (r *MongoUserRepository) CountByOrganizationAndRole(ctx context.Context, orgID primitive.ObjectID, role models.UserRole) (int64, error) {
+	filter := bson.M{
+		"organization_id": orgID,
+		"role":            role,
+		"deleted_at":      nil,
+		"is_active":       true,
+	}
+	return r.collection.CountDocuments(ctx, filter)
+}
+
 // Ensure MongoUserRepository implements UserRepository
 var _ UserRepository = (*MongoUserRepository)(nil)
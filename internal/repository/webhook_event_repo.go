@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+// MongoWebhookEventRepository implements WebhookEventRepository for MongoDB
+// #ORM_INTEGRATION: MongoDB driver-based repository implementation
+type MongoWebhookEventRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoWebhookEventRepository creates a new MongoDB webhook event repository
+func NewMongoWebhookEventRepository(db *mongo.Database) *MongoWebhookEventRepository {
+	return &MongoWebhookEventRepository{
+		collection: db.Collection(models.WebhookEvent{}.CollectionName()),
+	}
+}
+
+// Claim records an event as being processed; returns false if it was already claimed
+func (r *MongoWebhookEventRepository) Claim(ctx context.Context, event *models.WebhookEvent) (bool, error) {
+	event.BeforeCreate()
+	_, err := r.collection.InsertOne(ctx, event)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Release removes a claim so the event can be processed again when redelivered
+func (r *MongoWebhookEventRepository) Release(ctx context.Context, source, eventID string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"source": source, "event_id": eventID})
+	return err
+}
+
+// Ensure MongoWebhookEventRepository implements WebhookEventRepository
+var _ WebhookEventRepository = (*MongoWebhookEventRepository)(nil)
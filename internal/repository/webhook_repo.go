@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+// MongoWebhookRepository implements WebhookRepository for MongoDB
+// #ORM_INTEGRATION: MongoDB driver-based repository implementation
+type MongoWebhookRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoWebhookRepository creates a new MongoDB webhook repository
+func NewMongoWebhookRepository(db *mongo.Database) *MongoWebhookRepository {
+	return &MongoWebhookRepository{
+		collection: db.Collection(models.Webhook{}.CollectionName()),
+	}
+}
+
+// Create stores a new webhook
+func (r *MongoWebhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	webhook.BeforeCreate()
+	_, err := r.collection.InsertOne(ctx, webhook)
+	return err
+}
+
+// ListByOrganization lists webhooks of an organization, newest first
+func (r *MongoWebhookRepository) ListByOrganization(ctx context.Context, orgID primitive.ObjectID) ([]models.Webhook, error) {
+	return r.find(ctx, bson.M{
+		"organization_id": orgID,
+	})
+}
+
+// CountByOrganization counts webhooks of an organization
+func (r *MongoWebhookRepository) CountByOrganization(ctx context.Context, orgID primitive.ObjectID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"organization_id": orgID})
+}
+
+// ListSubscribed lists webhooks of an organization subscribed to an event
+// #QUERY_PATTERN: Uses the organization_id + events index
+func (r *MongoWebhookRepository) ListSubscribed(ctx context.Context, orgID primitive.ObjectID, event models.WebhookEventType) ([]models.Webhook, error) {
+	return r.find(ctx, bson.M{
+		"organization_id": orgID,
+		"events":          event,
+	})
+}
+
+// find runs a query sorted newest first
+func (r *MongoWebhookRepository) find(ctx context.Context, filter bson.M) ([]models.Webhook, error) {
+	findOpts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx) //nolint:errcheck // defer close
+
+	webhooks := []models.Webhook{}
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// Delete deletes a webhook owned by the organization
+func (r *MongoWebhookRepository) Delete(ctx context.Context, orgID, id primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":             id,
+		"organization_id": orgID,
+	}
+	result, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return models.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// RecordDelivery stores the outcome of the latest delivery attempt
+// #IMPLEMENTATION_DECISION: Failures increment a counter so operators can spot dead endpoints;
+// a success resets it
+func (r *MongoWebhookRepository) RecordDelivery(ctx context.Context, id primitive.ObjectID, result models.WebhookDeliveryResult) error {
+	set := bson.M{
+		"last_delivery_at": result.DeliveredAt,
+		"last_status_code": result.StatusCode,
+		"last_error":       result.Error,
+		"updated_at":       time.Now().UTC(),
+	}
+	update := bson.M{"$set": set}
+	if result.Succeeded() {
+		set["consecutive_failures"] = 0
+	} else {
+		update["$inc"] = bson.M{"consecutive_failures": 1}
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+// Ensure MongoWebhookRepository implements WebhookRepository
+var _ WebhookRepository = (*MongoWebhookRepository)(nil)
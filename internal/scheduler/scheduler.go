@@ -0,0 +1,97 @@
+// Package scheduler runs periodic background jobs inside the API server process.
+// #IMPLEMENTATION_DECISION: In-process tickers instead of an external cron - jobs are
+// idempotent and cheap, so running them on every replica is acceptable
+// #TECHNICAL_DEBT: No leader election; with several replicas each job runs once per replica
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// JobFunc is a unit of periodic work
+type JobFunc func(ctx context.Context) error
+
+// job is a registered periodic job
+type job struct {
+	name     string
+	interval time.Duration
+	run      JobFunc
+}
+
+// Scheduler runs registered jobs at fixed intervals until stopped
+type Scheduler struct {
+	jobs   []job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates an empty scheduler
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every registers a job to run at the given interval; must be called before Start
+func (s *Scheduler) Every(name string, interval time.Duration, run JobFunc) {
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Start runs every job once immediately and then at its interval
+// #IMPLEMENTATION_DECISION: Running on startup keeps long-interval jobs from being
+// skipped entirely when the server restarts more often than the interval
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, j := range s.jobs {
+		if j.interval <= 0 {
+			log.Printf("[SCHEDULER] Job %s disabled (interval %s)", j.name, j.interval)
+			continue
+		}
+
+		s.wg.Add(1)
+		go func(j job) {
+			defer s.wg.Done()
+
+			ticker := time.NewTicker(j.interval)
+			defer ticker.Stop()
+
+			for {
+				s.runJob(ctx, j)
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}(j)
+	}
+}
+
+// Stop cancels running jobs and waits for them to return
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// runJob runs a single job invocation, recovering from panics so one failure does not stop the schedule
+func (s *Scheduler) runJob(ctx context.Context, j job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[SCHEDULER] Job %s panicked: %v", j.name, r)
+		}
+	}()
+
+	start := time.Now()
+	if err := j.run(ctx); err != nil {
+		if ctx.Err() == nil {
+			log.Printf("[SCHEDULER] Job %s failed after %s: %v", j.name, time.Since(start), err)
+		}
+		return
+	}
+	log.Printf("[SCHEDULER] Job %s completed in %s", j.name, time.Since(start))
+}
@@ -0,0 +1,34 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScheduler_RunsJobUntilStopped(t *testing.T) {
+	var runs atomic.Int32
+	s := New()
+	s.Every("test", 5*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	s.Start(context.Background())
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	s.Stop()
+
+	if got := runs.Load(); got < 3 {
+		t.Fatalf("Expected at least 3 runs, got %d", got)
+	}
+
+	stopped := runs.Load()
+	time.Sleep(20 * time.Millisecond)
+	if got := runs.Load(); got != stopped {
+		t.Errorf("Expected no runs after Stop, got %d more", got-stopped)
+	}
+}
@@ -0,0 +1,163 @@
+// Package services provides business logic implementations.
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// Custom errors for API key service
+var (
+	ErrAPIKeyNotFound     = errors.New("API key not found")
+	ErrInvalidAPIKey      = errors.New("invalid API key")
+	ErrInvalidAPIKeyScope = errors.New("invalid API key scope")
+	ErrInvalidAPIKeyTTL   = errors.New("API key expiry must be in the future")
+)
+
+// apiKeyPrefix marks NisFix API keys so leaked keys are easy to recognize in secret scanners
+const apiKeyPrefix = "nfx_"
+
+// apiKeyDisplayPrefixLen is the number of leading characters kept for identification
+const apiKeyDisplayPrefixLen = 12
+
+// APIKeyService handles API keys for machine-to-machine access
+// #INTEGRATION_POINT: Used by API key handler and middleware.APIKeyAuth
+type APIKeyService interface {
+	// CreateAPIKey mints a new key and returns it together with the one-time plaintext value
+	CreateAPIKey(ctx context.Context, orgID, userID primitive.ObjectID, role models.UserRole, req CreateAPIKeyRequest) (*models.APIKey, string, error)
+
+	// ListAPIKeys lists API keys of the organization
+	ListAPIKeys(ctx context.Context, orgID primitive.ObjectID) ([]models.APIKey, error)
+
+	// RevokeAPIKey revokes an API key of the organization
+	RevokeAPIKey(ctx context.Context, orgID, keyID primitive.ObjectID) error
+
+	// AuthenticateAPIKey resolves a plaintext key to its API key and organization
+	AuthenticateAPIKey(ctx context.Context, plaintext string) (*models.APIKey, *models.Organization, error)
+}
+
+// CreateAPIKeyRequest represents the request to mint an API key
+type CreateAPIKeyRequest struct {
+	Name      string               `json:"name"`
+	Scopes    []models.APIKeyScope `json:"scopes"`
+	ExpiresAt *time.Time           `json:"expires_at,omitempty"`
+}
+
+// apiKeyService implements APIKeyService
+type apiKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+	orgRepo    repository.OrganizationRepository
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(
+	apiKeyRepo repository.APIKeyRepository,
+	orgRepo repository.OrganizationRepository,
+) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+		orgRepo:    orgRepo,
+	}
+}
+
+// CreateAPIKey mints a new key and returns it together with the one-time plaintext value
+// #SECURITY_ASSUMPTION: The plaintext is never persisted and cannot be retrieved again
+// #IMPLEMENTATION_DECISION: The key inherits the creating user's role at mint time
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, orgID, userID primitive.ObjectID, role models.UserRole, req CreateAPIKeyRequest) (*models.APIKey, string, error) {
+	if len(req.Scopes) == 0 {
+		return nil, "", ErrInvalidAPIKeyScope
+	}
+	for _, scope := range req.Scopes {
+		if !scope.IsValid() {
+			return nil, "", ErrInvalidAPIKeyScope
+		}
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, "", ErrInvalidAPIKeyTTL
+	}
+
+	secret, err := generateSecureIdentifier()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	plaintext := apiKeyPrefix + secret
+
+	key := &models.APIKey{
+		OrganizationID: orgID,
+		CreatedBy:      userID,
+		Name:           strings.TrimSpace(req.Name),
+		Prefix:         plaintext[:apiKeyDisplayPrefixLen],
+		KeyHash:        hashAPIKey(plaintext),
+		Role:           role,
+		Scopes:         req.Scopes,
+		ExpiresAt:      req.ExpiresAt,
+	}
+
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return key, plaintext, nil
+}
+
+// ListAPIKeys lists API keys of the organization
+func (s *apiKeyService) ListAPIKeys(ctx context.Context, orgID primitive.ObjectID) ([]models.APIKey, error) {
+	return s.apiKeyRepo.ListByOrganization(ctx, orgID)
+}
+
+// RevokeAPIKey revokes an API key of the organization
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, orgID, keyID primitive.ObjectID) error {
+	if err := s.apiKeyRepo.Revoke(ctx, orgID, keyID); err != nil {
+		if errors.Is(err, models.ErrAPIKeyNotFound) {
+			return ErrAPIKeyNotFound
+		}
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	return nil
+}
+
+// AuthenticateAPIKey resolves a plaintext key to its API key and organization
+func (s *apiKeyService) AuthenticateAPIKey(ctx context.Context, plaintext string) (*models.APIKey, *models.Organization, error) {
+	if !strings.HasPrefix(plaintext, apiKeyPrefix) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.apiKeyRepo.GetByHash(ctx, hashAPIKey(plaintext))
+	if err != nil {
+		if errors.Is(err, models.ErrAPIKeyNotFound) {
+			return nil, nil, ErrInvalidAPIKey
+		}
+		return nil, nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	if !key.IsUsable() {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, key.OrganizationID)
+	if err != nil || org == nil || org.IsDeleted() {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	//nolint:errcheck // Best-effort usage tracking
+	s.apiKeyRepo.UpdateLastUsed(ctx, key.ID)
+
+	return key, org, nil
+}
+
+// hashAPIKey returns the hex SHA-256 of an API key
+// #SECURITY_ASSUMPTION: Keys carry 256 bits of entropy, so a fast unsalted hash is sufficient
+func hashAPIKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
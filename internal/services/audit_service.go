@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
@@ -21,11 +22,11 @@ type AuditService interface {
 	// LogAsync logs asynchronously (non-blocking)
 	LogAsync(entry AuditEntry)
 
-	// ListByResource lists audit logs for a resource
-	ListByResource(ctx context.Context, resourceType string, resourceID primitive.ObjectID, opts repository.PaginationOptions) (*repository.PaginatedResult[models.AuditLog], error)
+	// ListByResource lists audit logs for a resource created between the optional from and to
+	ListByResource(ctx context.Context, resourceType string, resourceID primitive.ObjectID, from, to *time.Time, opts repository.PaginationOptions) (*repository.PaginatedResult[models.AuditLog], error)
 
-	// ListByOrganization lists audit logs for an organization
-	ListByOrganization(ctx context.Context, orgID primitive.ObjectID, opts repository.PaginationOptions) (*repository.PaginatedResult[models.AuditLog], error)
+	// ListByOrganization lists audit logs for an organization, narrowed by the optional filter
+	ListByOrganization(ctx context.Context, orgID primitive.ObjectID, filter repository.AuditLogFilter, opts repository.PaginationOptions) (*repository.PaginatedResult[models.AuditLog], error)
 }
 
 // AuditEntry represents an audit log entry to be created
@@ -43,6 +44,63 @@ type AuditEntry struct {
 	RequestID    string
 }
 
+// AuditRequestInfo carries the actor and request metadata of the current HTTP request
+// #INTEGRATION_POINT: Populated by handlers from the gin context, read by services when emitting entries
+type AuditRequestInfo struct {
+	ActorUserID *primitive.ObjectID
+	ActorOrgID  *primitive.ObjectID
+	IPAddress   string
+	UserAgent   string
+	RequestID   string
+}
+
+type auditRequestInfoKey struct{}
+
+// WithAuditRequestInfo returns a context carrying the request's audit metadata
+func WithAuditRequestInfo(ctx context.Context, info AuditRequestInfo) context.Context {
+	return context.WithValue(ctx, auditRequestInfoKey{}, info)
+}
+
+// auditRequestInfoFrom returns the audit metadata stored in ctx, if any
+func auditRequestInfoFrom(ctx context.Context) AuditRequestInfo {
+	info, _ := ctx.Value(auditRequestInfoKey{}).(AuditRequestInfo)
+	return info
+}
+
+// newAuditEntry builds an entry for the acting user and organization, filling request
+// metadata from ctx. Explicit actor IDs take precedence over those in the context.
+func newAuditEntry(ctx context.Context, actorUserID, actorOrgID primitive.ObjectID, action models.AuditAction, resourceType string, resourceID primitive.ObjectID, description string) AuditEntry {
+	info := auditRequestInfoFrom(ctx)
+
+	entry := AuditEntry{
+		ActorUserID:  info.ActorUserID,
+		ActorOrgID:   info.ActorOrgID,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Description:  description,
+		IPAddress:    info.IPAddress,
+		UserAgent:    info.UserAgent,
+		RequestID:    info.RequestID,
+	}
+	if !actorUserID.IsZero() {
+		entry.ActorUserID = &actorUserID
+	}
+	if !actorOrgID.IsZero() {
+		entry.ActorOrgID = &actorOrgID
+	}
+	return entry
+}
+
+// logAudit queues an audit entry when an audit service is configured
+// #IMPLEMENTATION_DECISION: Auditing never fails the business operation
+func logAudit(auditService AuditService, entry AuditEntry) {
+	if auditService == nil {
+		return
+	}
+	auditService.LogAsync(entry)
+}
+
 // auditService implements AuditService
 type auditService struct {
 	auditRepo repository.AuditRepository
@@ -110,14 +168,14 @@ func (s *auditService) LogAsync(entry AuditEntry) {
 	}
 }
 
-// ListByResource lists audit logs for a resource
-func (s *auditService) ListByResource(ctx context.Context, resourceType string, resourceID primitive.ObjectID, opts repository.PaginationOptions) (*repository.PaginatedResult[models.AuditLog], error) {
-	return s.auditRepo.ListByResource(ctx, resourceType, resourceID, opts)
+// ListByResource lists audit logs for a resource created between the optional from and to
+func (s *auditService) ListByResource(ctx context.Context, resourceType string, resourceID primitive.ObjectID, from, to *time.Time, opts repository.PaginationOptions) (*repository.PaginatedResult[models.AuditLog], error) {
+	return s.auditRepo.ListByResource(ctx, resourceType, resourceID, from, to, opts)
 }
 
-// ListByOrganization lists audit logs for an organization
-func (s *auditService) ListByOrganization(ctx context.Context, orgID primitive.ObjectID, opts repository.PaginationOptions) (*repository.PaginatedResult[models.AuditLog], error) {
-	return s.auditRepo.ListByOrganization(ctx, orgID, opts)
+// ListByOrganization lists audit logs for an organization, narrowed by the optional filter
+func (s *auditService) ListByOrganization(ctx context.Context, orgID primitive.ObjectID, filter repository.AuditLogFilter, opts repository.PaginationOptions) (*repository.PaginatedResult[models.AuditLog], error) {
+	return s.auditRepo.ListByOrganization(ctx, orgID, filter, opts)
 }
 
 // AuditHelpers provides convenient methods for common audit operations
@@ -8,8 +8,12 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/checkfix-tools/nisfix_backend/internal/auth"
 	"github.com/checkfix-tools/nisfix_backend/internal/models"
@@ -25,6 +29,12 @@ var (
 	ErrInvalidSecureLink    = errors.New("invalid or expired secure link")
 	ErrRateLimitExceeded    = errors.New("rate limit exceeded for magic links")
 	ErrInvalidRefreshToken  = errors.New("invalid refresh token")
+	ErrRefreshTokenReused   = errors.New("refresh token reuse detected")
+
+	ErrPasswordLoginDisabled = errors.New("password login is not enabled")
+	ErrInvalidPassword       = fmt.Errorf("password must be at least %d characters and at most %d bytes", models.MinPasswordLength, models.MaxPasswordBytes)
+	ErrInvalidCredentials    = errors.New("invalid email or password")
+	ErrWrongCurrentPassword  = errors.New("current password is incorrect")
 )
 
 // AuthService handles authentication logic
@@ -36,11 +46,24 @@ type AuthService interface {
 	// VerifyMagicLink validates a magic link and returns token pair
 	VerifyMagicLink(ctx context.Context, identifier string) (*auth.TokenPair, *models.User, *models.Organization, error)
 
+	// LoginWithOIDC signs in a user whose email was verified by the identity provider
+	LoginWithOIDC(ctx context.Context, email, name string) (*auth.TokenPair, *models.User, *models.Organization, error)
+
+	// SetPassword sets or replaces the password a signed-in user can log in with, returning a
+	// token pair for the current session since the user's other sessions are signed out
+	SetPassword(ctx context.Context, userID primitive.ObjectID, currentPassword, password string) (*auth.TokenPair, error)
+
+	// LoginWithPassword signs in a user with email and password
+	LoginWithPassword(ctx context.Context, email, password string) (*auth.TokenPair, *models.User, *models.Organization, error)
+
 	// RefreshAccessToken refreshes an access token using a refresh token
 	RefreshAccessToken(ctx context.Context, refreshToken string) (*auth.TokenPair, error)
 
-	// InvalidateRefreshToken invalidates a refresh token (logout)
-	InvalidateRefreshToken(ctx context.Context, userID primitive.ObjectID) error
+	// Logout revokes the presented access token and, if given, its refresh token chain
+	Logout(ctx context.Context, claims *auth.Claims, refreshToken string) error
+
+	// RevokeUserTokens revokes every access and refresh token issued to a user
+	RevokeUserTokens(ctx context.Context, userID primitive.ObjectID) error
 
 	// GetUserContext retrieves user context from token claims
 	GetUserContext(ctx context.Context, userID primitive.ObjectID) (*models.User, *models.Organization, error)
@@ -48,21 +71,32 @@ type AuthService interface {
 
 // MailService interface for sending emails
 // #INTEGRATION_POINT: External mail service integration
+// Each send returns the mail provider's message ID; WithMailOrigin attributes sends to an organization
 type MailService interface {
-	SendMagicLink(ctx context.Context, email, name, magicLink string) error
-	SendInvitation(ctx context.Context, email, companyName, magicLink string) error
+	// Language is the recipient's preferred language; unsupported languages fall back to English
+	SendMagicLink(ctx context.Context, email, name, magicLink, language string) (string, error)
+	SendInvitation(ctx context.Context, email, companyName, magicLink, language string) (string, error)
+	SendTeamInvitation(ctx context.Context, email, name, organizationName, magicLink, language string) (string, error)
+	SendCheckFixRefreshNeeded(ctx context.Context, email, supplierName, domain, language string) (string, error)
+	SendEmailChangeVerification(ctx context.Context, email, name, verifyLink, language string) (string, error)
+	SendEmailChangeNotice(ctx context.Context, email, name, newEmail, language string) (string, error)
 }
 
 // authService implements AuthService
 type authService struct {
-	userRepo       repository.UserRepository
-	orgRepo        repository.OrganizationRepository
-	secureLinkRepo repository.SecureLinkRepository
-	jwtService     auth.JWTService
-	mailService    MailService
-	magicLinkBase  string
-	rateLimitCount int
-	rateLimitMins  int
+	userRepo         repository.UserRepository
+	orgRepo          repository.OrganizationRepository
+	secureLinkRepo   repository.SecureLinkRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	revokedTokenRepo repository.RevokedTokenRepository
+	jwtService       auth.JWTService
+	mailService      MailService
+	magicLinkBase    string
+	rateLimitCount   int
+	rateLimitMins    int
+	accessTokenTTL   time.Duration
+	oidcProvision    bool
+	passwordLogin    bool
 }
 
 // AuthServiceConfig holds configuration for the auth service
@@ -70,6 +104,10 @@ type AuthServiceConfig struct {
 	MagicLinkBaseURL    string
 	RateLimitCount      int
 	RateLimitWindowMins int
+	AccessTokenExpiry   time.Duration
+	OIDCAutoProvision   bool
+	// PasswordLoginEnabled allows password login for organizations that enable it in their settings
+	PasswordLoginEnabled bool
 }
 
 // NewAuthService creates a new auth service instance
@@ -78,19 +116,26 @@ func NewAuthService(
 	userRepo repository.UserRepository,
 	orgRepo repository.OrganizationRepository,
 	secureLinkRepo repository.SecureLinkRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	revokedTokenRepo repository.RevokedTokenRepository,
 	jwtService auth.JWTService,
 	mailService MailService,
 	cfg AuthServiceConfig,
 ) AuthService {
 	return &authService{
-		userRepo:       userRepo,
-		orgRepo:        orgRepo,
-		secureLinkRepo: secureLinkRepo,
-		jwtService:     jwtService,
-		mailService:    mailService,
-		magicLinkBase:  cfg.MagicLinkBaseURL,
-		rateLimitCount: cfg.RateLimitCount,
-		rateLimitMins:  cfg.RateLimitWindowMins,
+		userRepo:         userRepo,
+		orgRepo:          orgRepo,
+		secureLinkRepo:   secureLinkRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revokedTokenRepo: revokedTokenRepo,
+		jwtService:       jwtService,
+		mailService:      mailService,
+		magicLinkBase:    cfg.MagicLinkBaseURL,
+		rateLimitCount:   cfg.RateLimitCount,
+		rateLimitMins:    cfg.RateLimitWindowMins,
+		accessTokenTTL:   cfg.AccessTokenExpiry,
+		oidcProvision:    cfg.OIDCAutoProvision,
+		passwordLogin:    cfg.PasswordLoginEnabled,
 	}
 }
 
@@ -98,6 +143,8 @@ func NewAuthService(
 // #IMPLEMENTATION_DECISION: Rate limit of 5 requests per 15 minutes per email
 // #SECURITY_CONCERN: Always return success even for non-existent emails to prevent enumeration
 func (s *authService) RequestMagicLink(ctx context.Context, email string) error {
+	email = models.NormalizeEmail(email)
+
 	// Check rate limit
 	count, err := s.secureLinkRepo.CountRecentByEmail(ctx, email, s.rateLimitMins)
 	if err != nil {
@@ -153,7 +200,8 @@ func (s *authService) RequestMagicLink(ctx context.Context, email string) error
 	magicLinkURL := fmt.Sprintf("%s/auth/verify/%s", s.magicLinkBase, identifier)
 
 	// Send email
-	if err := s.mailService.SendMagicLink(ctx, email, user.Name, magicLinkURL); err != nil {
+	mailCtx := WithMailOrigin(ctx, MailOrigin{OrganizationID: user.OrganizationID})
+	if _, err := s.mailService.SendMagicLink(mailCtx, email, user.Name, magicLinkURL, user.Language); err != nil {
 		// #TECHNICAL_DEBT: Should handle email send failures with retry queue
 		return fmt.Errorf("failed to send magic link email: %w", err)
 	}
@@ -171,7 +219,8 @@ func (s *authService) VerifyMagicLink(ctx context.Context, identifier string) (*
 	}
 
 	// Validate link
-	if link == nil || !link.CanBeUsed() {
+	// #SECURITY_ASSUMPTION: Only AUTH links sign a user in; email change links are redeemed by the user service
+	if link == nil || !link.CanBeUsed() || !link.IsAuthLink() {
 		return nil, nil, nil, ErrInvalidSecureLink
 	}
 
@@ -209,28 +258,101 @@ func (s *authService) VerifyMagicLink(ctx context.Context, identifier string) (*
 		// #TECHNICAL_DEBT: Log error but don't fail login
 	}
 
-	// Generate token pair
-	tokenPair, err := s.jwtService.GenerateTokenPair(
-		user.ID.Hex(),
-		org.ID.Hex(),
-		string(user.Role),
-		string(org.Type),
-	)
+	// Generate token pair starting a new refresh token family
+	tokenPair, err := s.issueTokenPair(ctx, user, org, "")
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to generate tokens: %w", err)
+		return nil, nil, nil, err
 	}
 
 	return tokenPair, user, org, nil
 }
 
-// RefreshAccessToken refreshes an access token
-// #SECURITY_CONCERN: Refresh tokens should ideally be stored and tracked for rotation
+// LoginWithOIDC signs in a user whose email was verified by the identity provider
+// #BUSINESS_RULE: Unknown emails are rejected unless auto-provisioning is enabled, in which case
+// a VIEWER is created in the organization whose domain matches the email domain, provided that
+// organization listed the domain in its SSO provisioning settings
+func (s *authService) LoginWithOIDC(ctx context.Context, email, name string) (*auth.TokenPair, *models.User, *models.Organization, error) {
+	email = models.NormalizeEmail(email)
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil && !errors.Is(err, models.ErrUserNotFound) {
+		return nil, nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user == nil {
+		if !s.oidcProvision {
+			return nil, nil, nil, ErrUserNotFound
+		}
+		user, err = s.provisionOIDCUser(ctx, email, name)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if !user.IsActive || user.IsDeleted() {
+		return nil, nil, nil, ErrUserInactive
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, user.OrganizationID)
+	if err != nil || org == nil {
+		return nil, nil, nil, ErrOrganizationNotFound
+	}
+
+	if org.IsDeleted() {
+		return nil, nil, nil, ErrOrganizationInactive
+	}
+
+	//nolint:errcheck // Best-effort, don't fail login
+	s.userRepo.UpdateLastLogin(ctx, user.ID)
+
+	tokenPair, err := s.issueTokenPair(ctx, user, org, "")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return tokenPair, user, org, nil
+}
+
+// provisionOIDCUser creates a VIEWER in the organization owning the email domain
+// #SECURITY_ASSUMPTION: Only organizations that opted in for the domain get users this way
+func (s *authService) provisionOIDCUser(ctx context.Context, email, name string) (*models.User, error) {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return nil, ErrUserNotFound
+	}
+	domain := email[at+1:]
+
+	org, err := s.orgRepo.GetByDomain(ctx, domain)
+	if err != nil || org == nil || org.IsDeleted() || !org.ProvisionsSSOUsers(domain) {
+		return nil, ErrUserNotFound
+	}
+
+	user := &models.User{
+		Email:          email,
+		Name:           name,
+		OrganizationID: org.ID,
+		Role:           models.UserRoleViewer,
+		Language:       org.Settings.DefaultLanguage,
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		// Email belongs to a previously removed user
+		if errors.Is(err, models.ErrEmailAlreadyExists) {
+			return nil, ErrUserInactive
+		}
+		return nil, fmt.Errorf("failed to provision user: %w", err)
+	}
+
+	return user, nil
+}
+
+// RefreshAccessToken exchanges a refresh token for a new token pair
+// #SECURITY_ASSUMPTION: Refresh tokens are single-use. Each exchange marks the presented
+// token as used and issues a replacement in the same family.
+// #SECURITY_CONCERN: Presenting an already-used token means it was likely stolen, so the
+// whole family is revoked and both the attacker and the legitimate client must log in again
 func (s *authService) RefreshAccessToken(ctx context.Context, refreshToken string) (*auth.TokenPair, error) {
 	claims, err := s.jwtService.ValidateRefreshToken(refreshToken)
 	if err != nil {
-		if errors.Is(err, auth.ErrTokenExpired) {
-			return nil, ErrInvalidRefreshToken
-		}
 		return nil, ErrInvalidRefreshToken
 	}
 
@@ -240,6 +362,25 @@ func (s *authService) RefreshAccessToken(ctx context.Context, refreshToken strin
 		return nil, ErrInvalidRefreshToken
 	}
 
+	// Look up the tracked token
+	// #IMPLEMENTATION_DECISION: Untracked tokens (e.g. issued before rotation existed) are rejected
+	stored, err := s.refreshTokenRepo.GetByTokenID(ctx, claims.ID)
+	if err != nil {
+		if errors.Is(err, models.ErrRefreshTokenNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	if stored.UserID != userID || stored.IsRevoked() || stored.IsExpired() {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if stored.IsUsed() {
+		s.revokeFamily(ctx, stored.FamilyID)
+		return nil, ErrRefreshTokenReused
+	}
+
 	// Get user
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil || user == nil {
@@ -256,26 +397,98 @@ func (s *authService) RefreshAccessToken(ctx context.Context, refreshToken strin
 		return nil, ErrOrganizationNotFound
 	}
 
-	// Generate new token pair
-	tokenPair, err := s.jwtService.GenerateTokenPair(
+	// Generate new token pair in the same family
+	tokenPair, err := s.issueTokenPair(ctx, user, org, stored.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Consume the presented token; losing this race means a concurrent exchange already used it
+	if err := s.refreshTokenRepo.MarkAsUsed(ctx, stored.TokenID, tokenPair.RefreshTokenID); err != nil {
+		if errors.Is(err, models.ErrRefreshTokenUsed) {
+			s.revokeFamily(ctx, stored.FamilyID)
+			return nil, ErrRefreshTokenReused
+		}
+		return nil, fmt.Errorf("failed to mark refresh token as used: %w", err)
+	}
+
+	return tokenPair, nil
+}
+
+// issueTokenPair generates a token pair and records the refresh token for rotation
+// #SECURITY_ASSUMPTION: Organization expiry overrides can only shorten lifetimes (capped by the JWT service)
+func (s *authService) issueTokenPair(ctx context.Context, user *models.User, org *models.Organization, familyID string) (*auth.TokenPair, error) {
+	tokenPair, err := s.jwtService.RotateTokenPair(
 		user.ID.Hex(),
 		org.ID.Hex(),
 		string(user.Role),
 		string(org.Type),
+		familyID,
+		auth.TokenExpiry{
+			Access:  org.Settings.AccessTokenExpiry(),
+			Refresh: org.Settings.RefreshTokenExpiry(),
+		},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate tokens: %w", err)
 	}
 
+	record := &models.RefreshToken{
+		TokenID:   tokenPair.RefreshTokenID,
+		FamilyID:  tokenPair.RefreshTokenFamilyID,
+		UserID:    user.ID,
+		ExpiresAt: tokenPair.RefreshTokenExpiresAt,
+	}
+	if err := s.refreshTokenRepo.Create(ctx, record); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
 	return tokenPair, nil
 }
 
-// InvalidateRefreshToken invalidates all tokens for a user (logout)
-// #IMPLEMENTATION_DECISION: Stateless JWT - no server-side invalidation currently
-// #SECURITY_CONCERN: For proper logout, would need token blacklist or short expiry
-func (s *authService) InvalidateRefreshToken(ctx context.Context, userID primitive.ObjectID) error {
-	// #TECHNICAL_DEBT: Implement token blacklist for proper server-side invalidation
-	// For now, client should discard tokens and frontend handles session cleanup
+// revokeFamily revokes a refresh token family after reuse was detected
+func (s *authService) revokeFamily(ctx context.Context, familyID string) {
+	//nolint:errcheck // Best-effort revocation, the caller is rejected regardless
+	s.refreshTokenRepo.RevokeFamily(ctx, familyID)
+}
+
+// Logout revokes the presented access token and, if given, its refresh token chain
+// #SECURITY_ASSUMPTION: The access token jti is denylisted until the token's own expiry
+func (s *authService) Logout(ctx context.Context, claims *auth.Claims, refreshToken string) error {
+	userID, err := primitive.ObjectIDFromHex(claims.UserID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if claims.ID != "" && claims.ExpiresAt != nil {
+		if err := s.revokedTokenRepo.Revoke(ctx, claims.ID, userID, claims.ExpiresAt.Time); err != nil {
+			return fmt.Errorf("failed to revoke access token: %w", err)
+		}
+	}
+
+	if refreshToken != "" {
+		refreshClaims, err := s.jwtService.ValidateRefreshToken(refreshToken)
+		if err == nil && refreshClaims.UserID == claims.UserID {
+			if err := s.refreshTokenRepo.RevokeFamily(ctx, refreshClaims.FamilyID); err != nil {
+				return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RevokeUserTokens revokes every access and refresh token issued to a user
+// #IMPLEMENTATION_DECISION: A user-wide denylist entry lives as long as the longest-lived
+// access token that could have been issued before it
+func (s *authService) RevokeUserTokens(ctx context.Context, userID primitive.ObjectID) error {
+	expiresAt := time.Now().UTC().Add(s.accessTokenTTL)
+	if err := s.revokedTokenRepo.RevokeAllForUser(ctx, userID, expiresAt); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
 	return nil
 }
 
@@ -294,6 +507,89 @@ func (s *authService) GetUserContext(ctx context.Context, userID primitive.Objec
 	return user, org, nil
 }
 
+// timingHash is compared against when a login names no usable account, so unknown emails
+// take as long to reject as wrong passwords
+const timingHash = "$2a$10$HQ4nZIcsFvyXRb/eMw3vbuUuaOstySlvD.D2obGFXiQzIG0xDvQbG"
+
+// SetPassword sets or replaces the password a signed-in user can log in with
+// #BUSINESS_RULE: Users set their first password after signing in with a magic link, which proves
+// they own the email; replacing an existing password requires the current one
+// #SECURITY_ASSUMPTION: Requires both the deployment flag and the organization setting. A stolen
+// access token alone cannot take over an account that already has a password, and every other
+// session's refresh tokens are revoked once the password changes
+func (s *authService) SetPassword(ctx context.Context, userID primitive.ObjectID, currentPassword, password string) (*auth.TokenPair, error) {
+	if !s.passwordLogin {
+		return nil, ErrPasswordLoginDisabled
+	}
+	if utf8.RuneCountInString(password) < models.MinPasswordLength || len(password) > models.MaxPasswordBytes {
+		return nil, ErrInvalidPassword
+	}
+
+	user, org, err := s.GetUserContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !org.Settings.PasswordLoginEnabled {
+		return nil, ErrPasswordLoginDisabled
+	}
+	if user.HasPassword() && bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(currentPassword)) != nil {
+		return nil, ErrWrongCurrentPassword
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	if err := s.userRepo.SetPasswordHash(ctx, user.ID, string(hash)); err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to store password: %w", err)
+	}
+
+	// Sign out every other session, then start a new refresh token family for this one
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return s.issueTokenPair(ctx, user, org, "")
+}
+
+// LoginWithPassword signs in a user with email and password
+// #SECURITY_CONCERN: Unknown emails, inactive accounts, organizations without password login and
+// wrong passwords all fail with ErrInvalidCredentials, so responses do not reveal which accounts exist
+func (s *authService) LoginWithPassword(ctx context.Context, email, password string) (*auth.TokenPair, *models.User, *models.Organization, error) {
+	if !s.passwordLogin {
+		return nil, nil, nil, ErrPasswordLoginDisabled
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, models.NormalizeEmail(email))
+	if err != nil && !errors.Is(err, models.ErrUserNotFound) {
+		return nil, nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil || !user.HasPassword() || !user.IsActive || user.IsDeleted() {
+		_ = bcrypt.CompareHashAndPassword([]byte(timingHash), []byte(password))
+		return nil, nil, nil, ErrInvalidCredentials
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return nil, nil, nil, ErrInvalidCredentials
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, user.OrganizationID)
+	if err != nil || org == nil || org.IsDeleted() || !org.Settings.PasswordLoginEnabled {
+		return nil, nil, nil, ErrInvalidCredentials
+	}
+
+	if updateErr := s.userRepo.UpdateLastLogin(ctx, user.ID); updateErr != nil { //nolint:staticcheck // Log error but don't fail login
+		// #TECHNICAL_DEBT: Log error but don't fail login
+	}
+
+	tokenPair, err := s.issueTokenPair(ctx, user, org, "")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return tokenPair, user, org, nil
+}
+
 // generateSecureIdentifier generates a cryptographically secure random identifier
 // #IMPLEMENTATION_DECISION: 32 bytes = 64 hex characters
 func generateSecureIdentifier() (string, error) {
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/auth"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// exactEmailUserRepo matches emails byte for byte, like the users collection; other methods are not used
+type exactEmailUserRepo struct {
+	repository.UserRepository
+	users map[string]*models.User
+}
+
+func (r *exactEmailUserRepo) GetByEmail(_ context.Context, email string) (*models.User, error) {
+	if user, ok := r.users[email]; ok {
+		return user, nil
+	}
+	return nil, models.ErrUserNotFound
+}
+
+// memoryOrgRepoByID serves organizations by ID; other methods are not used
+type memoryOrgRepoByID struct {
+	repository.OrganizationRepository
+	orgs map[primitive.ObjectID]*models.Organization
+}
+
+func (r *memoryOrgRepoByID) GetByID(_ context.Context, id primitive.ObjectID) (*models.Organization, error) {
+	if org, ok := r.orgs[id]; ok {
+		return org, nil
+	}
+	return nil, models.ErrOrganizationNotFound
+}
+
+func (r *memoryOrgRepoByID) Update(_ context.Context, org *models.Organization) error {
+	r.orgs[org.ID] = org
+	return nil
+}
+
+func (r *memoryOrgRepoByID) GetByDomain(_ context.Context, domain string) (*models.Organization, error) {
+	for _, org := range r.orgs {
+		if _, ok := org.MatchDomain(domain); ok {
+			return org, nil
+		}
+	}
+	return nil, models.ErrOrganizationNotFound
+}
+
+// recordingSecureLinkRepo records created links; other methods are not used
+type recordingSecureLinkRepo struct {
+	repository.SecureLinkRepository
+	rateLimitedEmails []string
+	links             []*models.SecureLink
+}
+
+func (r *recordingSecureLinkRepo) CountRecentByEmail(_ context.Context, email string, _ int) (int64, error) {
+	r.rateLimitedEmails = append(r.rateLimitedEmails, email)
+	return 0, nil
+}
+
+func (r *recordingSecureLinkRepo) InvalidateAllForEmail(_ context.Context, _ string) error {
+	return nil
+}
+
+func (r *recordingSecureLinkRepo) Create(_ context.Context, link *models.SecureLink) error {
+	r.links = append(r.links, link)
+	return nil
+}
+
+// recordingMagicLinkMailer records magic link recipients; other methods are not used
+type recordingMagicLinkMailer struct {
+	MailService
+	recipients []string
+}
+
+func (m *recordingMagicLinkMailer) SendMagicLink(_ context.Context, email, _, _, _ string) (string, error) {
+	m.recipients = append(m.recipients, email)
+	return "", nil
+}
+
+func TestAuthService_RequestMagicLink_MixedCaseEmail(t *testing.T) {
+	org := &models.Organization{ID: primitive.NewObjectID()}
+	user := &models.User{ID: primitive.NewObjectID(), Email: "user@example.com", OrganizationID: org.ID, IsActive: true}
+	links := &recordingSecureLinkRepo{}
+	mailer := &recordingMagicLinkMailer{}
+
+	service := NewAuthService(
+		&exactEmailUserRepo{users: map[string]*models.User{user.Email: user}},
+		&memoryOrgRepoByID{orgs: map[primitive.ObjectID]*models.Organization{org.ID: org}},
+		links, nil, nil, nil, mailer,
+		AuthServiceConfig{RateLimitCount: 5, RateLimitWindowMins: 15},
+	)
+
+	if err := service.RequestMagicLink(context.Background(), " User@Example.COM "); err != nil {
+		t.Fatalf("RequestMagicLink() error = %v", err)
+	}
+	if len(mailer.recipients) != 1 || mailer.recipients[0] != user.Email {
+		t.Fatalf("Expected one magic link to %s, got %v", user.Email, mailer.recipients)
+	}
+	if len(links.links) != 1 || links.links[0].Email != user.Email {
+		t.Errorf("Expected the secure link to be stored for %s", user.Email)
+	}
+	if links.rateLimitedEmails[0] != user.Email {
+		t.Errorf("Rate limit counted %q, want %q", links.rateLimitedEmails[0], user.Email)
+	}
+}
+
+func (r *exactEmailUserRepo) GetByID(_ context.Context, id primitive.ObjectID) (*models.User, error) {
+	for _, user := range r.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, models.ErrUserNotFound
+}
+
+func (r *exactEmailUserRepo) SetPasswordHash(ctx context.Context, id primitive.ObjectID, hash string) error {
+	user, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = hash
+	return nil
+}
+
+func (r *exactEmailUserRepo) UpdateLastLogin(_ context.Context, _ primitive.ObjectID) error {
+	return nil
+}
+
+// stubTokenJWTService issues fixed token pairs; other methods are not used
+type stubTokenJWTService struct {
+	auth.JWTService
+}
+
+func (stubTokenJWTService) RotateTokenPair(_, _, _, _, _ string, _ auth.TokenExpiry) (*auth.TokenPair, error) {
+	return &auth.TokenPair{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: time.Now().Add(time.Hour)}, nil
+}
+
+// discardRefreshTokenRepo accepts refresh token records; other methods are not used
+type discardRefreshTokenRepo struct {
+	repository.RefreshTokenRepository
+}
+
+func (discardRefreshTokenRepo) Create(_ context.Context, _ *models.RefreshToken) error {
+	return nil
+}
+
+// recordingRefreshTokenRepo records refresh token issues and user-wide revocations in order
+type recordingRefreshTokenRepo struct {
+	repository.RefreshTokenRepository
+	events []string
+}
+
+func (r *recordingRefreshTokenRepo) Create(_ context.Context, _ *models.RefreshToken) error {
+	r.events = append(r.events, "create")
+	return nil
+}
+
+func (r *recordingRefreshTokenRepo) RevokeAllForUser(_ context.Context, _ primitive.ObjectID) error {
+	r.events = append(r.events, "revoke")
+	return nil
+}
+
+func TestAuthService_LoginWithOIDC_ProvisioningRequiresOptIn(t *testing.T) {
+	ctx := context.Background()
+	org := &models.Organization{ID: primitive.NewObjectID(), Domain: "acme.example", Domains: []string{"acme.example", "acme-labs.example"}}
+	users := &memoryUserRepo{users: map[primitive.ObjectID]*models.User{}}
+
+	service := NewAuthService(
+		users,
+		&memoryOrgRepoByID{orgs: map[primitive.ObjectID]*models.Organization{org.ID: org}},
+		nil, discardRefreshTokenRepo{}, nil, stubTokenJWTService{}, nil,
+		AuthServiceConfig{OIDCAutoProvision: true},
+	)
+
+	// Owning the domain is not enough
+	if _, _, _, err := service.LoginWithOIDC(ctx, "ann@acme.example", "Ann"); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("Expected ErrUserNotFound without opt-in, got %v", err)
+	}
+
+	org.Settings.SSOProvisionDomains = []string{"acme.example"}
+	if _, _, _, err := service.LoginWithOIDC(ctx, "bob@acme-labs.example", "Bob"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound for a domain without opt-in, got %v", err)
+	}
+
+	_, user, _, err := service.LoginWithOIDC(ctx, "Ann@Acme.example", "Ann")
+	if err != nil {
+		t.Fatalf("LoginWithOIDC failed: %v", err)
+	}
+	if user.OrganizationID != org.ID || user.Role != models.UserRoleViewer || len(users.users) != 1 {
+		t.Errorf("Expected a provisioned viewer, got %+v", user)
+	}
+}
+
+func TestAuthService_PasswordLogin(t *testing.T) {
+	ctx := context.Background()
+	org := &models.Organization{ID: primitive.NewObjectID(), Settings: models.OrganizationSettings{PasswordLoginEnabled: true}}
+	user := &models.User{ID: primitive.NewObjectID(), Email: "user@example.com", OrganizationID: org.ID, IsActive: true}
+	refreshTokens := &recordingRefreshTokenRepo{}
+
+	service := NewAuthService(
+		&exactEmailUserRepo{users: map[string]*models.User{user.Email: user}},
+		&memoryOrgRepoByID{orgs: map[primitive.ObjectID]*models.Organization{org.ID: org}},
+		nil, refreshTokens, nil, stubTokenJWTService{}, nil,
+		AuthServiceConfig{PasswordLoginEnabled: true},
+	)
+
+	if _, err := service.SetPassword(ctx, user.ID, "", "too short"); !errors.Is(err, ErrInvalidPassword) {
+		t.Errorf("Expected ErrInvalidPassword, got %v", err)
+	}
+	// The first password needs no current one
+	tokens, err := service.SetPassword(ctx, user.ID, "", "correct horse battery")
+	if err != nil {
+		t.Fatalf("SetPassword failed: %v", err)
+	}
+	if !user.HasPassword() || user.PasswordHash == "correct horse battery" {
+		t.Fatalf("Expected a password hash to be stored, got %q", user.PasswordHash)
+	}
+	// Other sessions are signed out before this one gets a new token family
+	if tokens == nil || !slices.Equal(refreshTokens.events, []string{"revoke", "create"}) {
+		t.Errorf("Expected revocation followed by new tokens, got %v", refreshTokens.events)
+	}
+
+	// Replacing a password requires the current one
+	for _, current := range []string{"", "wrong horse battery"} {
+		if _, err := service.SetPassword(ctx, user.ID, current, "another horse battery"); !errors.Is(err, ErrWrongCurrentPassword) {
+			t.Errorf("Expected ErrWrongCurrentPassword for %q, got %v", current, err)
+		}
+	}
+	if _, err := service.SetPassword(ctx, user.ID, "correct horse battery", "correct horse battery two"); err != nil {
+		t.Fatalf("SetPassword with the current password failed: %v", err)
+	}
+	if _, err := service.SetPassword(ctx, user.ID, "correct horse battery two", "correct horse battery"); err != nil {
+		t.Fatalf("SetPassword with the current password failed: %v", err)
+	}
+
+	tokens, loggedIn, _, err := service.LoginWithPassword(ctx, " User@Example.com ", "correct horse battery")
+	if err != nil || tokens == nil || loggedIn.ID != user.ID {
+		t.Fatalf("Expected a successful login, got %v", err)
+	}
+
+	// Wrong passwords and unknown emails are indistinguishable
+	if _, _, _, err := service.LoginWithPassword(ctx, user.Email, "wrong horse battery"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected ErrInvalidCredentials for a wrong password, got %v", err)
+	}
+	if _, _, _, err := service.LoginWithPassword(ctx, "nobody@example.com", "correct horse battery"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected ErrInvalidCredentials for an unknown email, got %v", err)
+	}
+
+	// An organization that turns password login off falls back to magic links only
+	org.Settings.PasswordLoginEnabled = false
+	if _, _, _, err := service.LoginWithPassword(ctx, user.Email, "correct horse battery"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected ErrInvalidCredentials with the organization setting off, got %v", err)
+	}
+	if _, err := service.SetPassword(ctx, user.ID, "correct horse battery", "another horse battery"); !errors.Is(err, ErrPasswordLoginDisabled) {
+		t.Errorf("Expected ErrPasswordLoginDisabled, got %v", err)
+	}
+}
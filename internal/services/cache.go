@@ -0,0 +1,78 @@
+// Package services provides business logic implementations.
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Cache is a byte-oriented key/value store with per-entry expiry
+// #IMPLEMENTATION_DECISION: Values are opaque bytes so a shared (e.g. Redis) cache can
+// replace the in-memory one without changing callers
+type Cache interface {
+	// Get returns the cached value and whether it was found and not expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores a value for the given TTL
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// memoryCacheEntry is a cached value with its expiry
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-process Cache
+// #TECHNICAL_DEBT: Entries are per instance; use a shared cache when running multiple replicas
+type MemoryCache struct {
+	mu        sync.RWMutex
+	entries   map[string]memoryCacheEntry
+	lastSweep time.Time
+}
+
+// memoryCacheSweepInterval controls how often expired entries are purged on write
+const memoryCacheSweepInterval = time.Minute
+
+// NewMemoryCache creates an empty in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries:   make(map[string]memoryCacheEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+// Get implements Cache
+func (c *MemoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+// Set implements Cache
+func (c *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(c.lastSweep) > memoryCacheSweepInterval {
+		for k, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		c.lastSweep = now
+	}
+
+	c.entries[key] = memoryCacheEntry{value: value, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+// Ensure MemoryCache implements Cache
+var _ Cache = (*MemoryCache)(nil)
@@ -7,12 +7,16 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
+	"math/rand/v2"
 	"net/http"
 	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/sync/singleflight"
 
+	"github.com/checkfix-tools/nisfix_backend/internal/metrics"
 	"github.com/checkfix-tools/nisfix_backend/internal/models"
 	"github.com/checkfix-tools/nisfix_backend/internal/repository"
 )
@@ -20,14 +24,30 @@ import (
 // Custom errors for CheckFix service
 var (
 	ErrCheckFixAPIError       = errors.New("checkfix API error")
+	ErrCheckFixTimeout        = fmt.Errorf("%w: request timed out", ErrCheckFixAPIError)
 	ErrCheckFixNotLinked      = errors.New("checkfix account not linked")
 	ErrCheckFixReportNotFound = errors.New("checkfix report not found")
 	ErrCheckFixDomainMismatch = errors.New("domain does not match organization")
 	ErrCheckFixReportExpired  = errors.New("checkfix report is too old")
 	ErrCheckFixGradeNotMet    = errors.New("checkfix grade does not meet requirement")
 	ErrVerificationNotFound   = errors.New("verification not found")
+	ErrInvalidWebhookEvent    = errors.New("invalid webhook event")
+	ErrDomainNotInAccount     = errors.New("domain is not verified for the checkfix account")
+	ErrDomainAlreadyClaimed   = errors.New("domain is already verified by another organization")
+	ErrPrimaryDomain          = errors.New("primary domain cannot be removed")
+	ErrDomainNotFound         = errors.New("domain not found")
+	ErrNoValidVerification    = errors.New("no valid checkfix verification")
 )
 
+// CheckFixShortfallError reports why a verification does not satisfy a requirement
+type CheckFixShortfallError struct {
+	Reason string
+}
+
+func (e *CheckFixShortfallError) Error() string {
+	return "checkfix verification does not meet requirement: " + e.Reason
+}
+
 // CheckFixAPIClient defines the interface for CheckFix API operations
 // #INTEGRATION_POINT: External CheckFix API for report verification
 type CheckFixAPIClient interface {
@@ -37,8 +57,14 @@ type CheckFixAPIClient interface {
 	// GetAccountDomain gets the domain associated with a CheckFix account
 	GetAccountDomain(ctx context.Context, accountID string) (string, error)
 
+	// ListAccountDomains lists every domain verified for a CheckFix account
+	ListAccountDomains(ctx context.Context, accountID string) ([]string, error)
+
 	// ValidateAccountAccess validates that an account ID is valid and accessible
 	ValidateAccountAccess(ctx context.Context, accountID string) (bool, error)
+
+	// Ping checks that the CheckFix API is reachable
+	Ping(ctx context.Context) error
 }
 
 // CheckFixReportData represents data from a CheckFix report
@@ -67,6 +93,12 @@ type CheckFixService interface {
 	// GetLinkStatus gets the current CheckFix link status for a supplier
 	GetLinkStatus(ctx context.Context, supplierID primitive.ObjectID) (*CheckFixLinkStatus, error)
 
+	// AddDomain adds a domain verified for the supplier's CheckFix account
+	AddDomain(ctx context.Context, supplierID primitive.ObjectID, domain string) (*models.Organization, error)
+
+	// RemoveDomain removes a secondary domain from the supplier
+	RemoveDomain(ctx context.Context, supplierID primitive.ObjectID, domain string) (*models.Organization, error)
+
 	// VerifyReport verifies a CheckFix report and stores the verification
 	VerifyReport(ctx context.Context, supplierID, responseID primitive.ObjectID, reportHash string) (*models.CheckFixVerification, error)
 
@@ -76,11 +108,92 @@ type CheckFixService interface {
 	// GetLatestVerification gets the most recent verification for a supplier
 	GetLatestVerification(ctx context.Context, supplierID primitive.ObjectID) (*models.CheckFixVerification, error)
 
+	// ListVerifications lists a supplier's verification history, newest first
+	ListVerifications(ctx context.Context, supplierID primitive.ObjectID, opts repository.PaginationOptions) (*repository.PaginatedResult[models.CheckFixVerification], error)
+
+	// GetGradeTrend returns the grade history of a company's supplier and its trend over the last points verifications
+	GetGradeTrend(ctx context.Context, companyID, relationshipID primitive.ObjectID, points int) (*GradeTrend, error)
+
 	// CheckRequirementMet checks if a CheckFix requirement is met
-	CheckRequirementMet(ctx context.Context, responseID primitive.ObjectID, minimumGrade models.CheckFixGrade, maxReportAgeDays int) (bool, error)
+	CheckRequirementMet(ctx context.Context, responseID primitive.ObjectID, minimumGrade models.CheckFixGrade, maxReportAgeDays int, categoryMinimums map[string]string) (bool, error)
 
 	// SubmitCheckFixResponse submits a CheckFix verification as a response
 	SubmitCheckFixResponse(ctx context.Context, requirementID, supplierID primitive.ObjectID, reportHash string) (*CheckFixSubmissionResult, error)
+
+	// SatisfyFromLatest submits the supplier's latest valid verification as the response to a requirement
+	SatisfyFromLatest(ctx context.Context, requirementID, supplierID primitive.ObjectID) (*CheckFixSubmissionResult, error)
+
+	// HandleWebhookEvent processes a CheckFix webhook event exactly once per event ID
+	HandleWebhookEvent(ctx context.Context, event *CheckFixWebhookEvent) (*CheckFixWebhookResult, error)
+
+	// RefreshExpiringVerifications re-verifies verifications expiring within the given number of days
+	RefreshExpiringVerifications(ctx context.Context, daysBeforeExpiry int) (*VerificationRefreshSummary, error)
+}
+
+// VerificationRefreshSummary reports the outcome of a re-verification run
+type VerificationRefreshSummary struct {
+	Checked     int
+	Refreshed   int
+	Invalidated int
+	Failed      int
+	Notified    int
+}
+
+// CheckFixEventReportUpdated is sent by CheckFix when a domain's report is re-scanned
+const CheckFixEventReportUpdated = "report.updated"
+
+// checkFixWebhookSource namespaces CheckFix event IDs in the webhook event store
+const checkFixWebhookSource = "checkfix"
+
+// CheckFixWebhookEvent is an event delivered to the CheckFix webhook
+type CheckFixWebhookEvent struct {
+	EventID    string    `json:"event_id"`
+	Type       string    `json:"type"`
+	Domain     string    `json:"domain"`
+	ReportHash string    `json:"report_hash,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// CheckFixWebhookResult describes how a webhook event was handled
+type CheckFixWebhookResult struct {
+	// Duplicate is true if the event ID was already processed
+	Duplicate bool
+	// Ignored is true if the event did not affect any stored verification
+	Ignored bool
+	// Verification is the refreshed verification, if any
+	Verification *models.CheckFixVerification
+	// OutcomeChanged is true if the linked response flipped between passed and failed
+	OutcomeChanged bool
+}
+
+// Grade trend directions
+const (
+	GradeTrendImproving = "improving"
+	GradeTrendStable    = "stable"
+	GradeTrendDeclining = "declining"
+)
+
+// Grade trend window limits
+const (
+	DefaultGradeTrendPoints = 5
+	MaxGradeTrendPoints     = 50
+)
+
+// gradeTrendScoreThreshold is the overall score change below which a supplier counts as stable
+const gradeTrendScoreThreshold = 5
+
+// GradeTrendPoint is one verification in a supplier's grade history
+type GradeTrendPoint struct {
+	VerifiedAt   time.Time            `json:"verified_at"`
+	Grade        models.CheckFixGrade `json:"grade"`
+	OverallScore int                  `json:"overall_score"`
+}
+
+// GradeTrend is a supplier's recent grade history, oldest first
+type GradeTrend struct {
+	SupplierID *primitive.ObjectID `json:"supplier_id,omitempty"`
+	Points     []GradeTrendPoint   `json:"points"`
+	Trend      string              `json:"trend"`
 }
 
 // CheckFixLinkStatus represents the current CheckFix link status
@@ -88,6 +201,7 @@ type CheckFixLinkStatus struct {
 	IsLinked         bool                         `json:"is_linked"`
 	AccountID        string                       `json:"account_id,omitempty"`
 	Domain           string                       `json:"domain,omitempty"`
+	Domains          []string                     `json:"domains,omitempty"`
 	LinkedAt         *time.Time                   `json:"linked_at,omitempty"`
 	LatestGrade      *models.CheckFixGrade        `json:"latest_grade,omitempty"`
 	LatestVerifiedAt *time.Time                   `json:"latest_verified_at,omitempty"`
@@ -110,23 +224,46 @@ type checkFixService struct {
 	verificationRepo repository.VerificationRepository
 	responseRepo     repository.ResponseRepository
 	requirementRepo  repository.RequirementRepository
+	relationshipRepo repository.RelationshipRepository
 	orgRepo          repository.OrganizationRepository
+	userRepo         repository.UserRepository
+	webhookEventRepo repository.WebhookEventRepository
+	mailOutbox       EmailOutbox
+	reportCache      Cache
+	reportCacheTTL   time.Duration
+	reportFetches    singleflight.Group
+	webhooks         WebhookDispatcher
 }
 
 // NewCheckFixService creates a new CheckFix service
+// reportCache may be nil, or reportCacheTTL zero, to disable report caching
 func NewCheckFixService(
 	apiClient CheckFixAPIClient,
 	verificationRepo repository.VerificationRepository,
 	responseRepo repository.ResponseRepository,
 	requirementRepo repository.RequirementRepository,
+	relationshipRepo repository.RelationshipRepository,
 	orgRepo repository.OrganizationRepository,
+	userRepo repository.UserRepository,
+	webhookEventRepo repository.WebhookEventRepository,
+	mailOutbox EmailOutbox,
+	reportCache Cache,
+	reportCacheTTL time.Duration,
+	webhooks WebhookDispatcher,
 ) CheckFixService {
 	return &checkFixService{
 		apiClient:        apiClient,
 		verificationRepo: verificationRepo,
 		responseRepo:     responseRepo,
 		requirementRepo:  requirementRepo,
+		relationshipRepo: relationshipRepo,
 		orgRepo:          orgRepo,
+		userRepo:         userRepo,
+		webhookEventRepo: webhookEventRepo,
+		mailOutbox:       mailOutbox,
+		reportCache:      reportCache,
+		reportCacheTTL:   reportCacheTTL,
+		webhooks:         webhooks,
 	}
 }
 
@@ -157,20 +294,95 @@ func (s *checkFixService) LinkAccount(ctx context.Context, supplierID primitive.
 	if err != nil {
 		return fmt.Errorf("failed to get account domain: %w", err)
 	}
+	accountDomains, err := s.apiClient.ListAccountDomains(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to list account domains: %w", err)
+	}
 
 	// Update organization
+	// #BUSINESS_RULE: The account domain becomes the primary domain; previously verified
+	// domains are kept only if the newly linked account has verified them too
 	now := time.Now().UTC()
 	org.CheckFixAccountID = accountID
 	org.CheckFixLinkedAt = &now
-	org.Domain = domain
+	org.Domain = models.NormalizeDomain(domain)
+	org.RetainDomains(append(accountDomains, domain))
 
 	if err := s.orgRepo.Update(ctx, org); err != nil {
+		if errors.Is(err, models.ErrAlreadyExists) {
+			return ErrDomainAlreadyClaimed
+		}
 		return fmt.Errorf("failed to update organization: %w", err)
 	}
 
 	return nil
 }
 
+// AddDomain adds a domain verified for the supplier's CheckFix account
+// #SECURITY_ASSUMPTION: Only domains CheckFix has verified for the linked account can be added,
+// so a supplier cannot claim reports for domains it does not control
+func (s *checkFixService) AddDomain(ctx context.Context, supplierID primitive.ObjectID, domain string) (*models.Organization, error) {
+	org, err := s.orgRepo.GetByID(ctx, supplierID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	if !org.HasCheckFixLinked() {
+		return nil, ErrCheckFixNotLinked
+	}
+
+	domain = models.NormalizeDomain(domain)
+	if _, ok := org.MatchDomain(domain); ok {
+		return org, nil
+	}
+
+	accountDomains, err := s.apiClient.ListAccountDomains(ctx, org.CheckFixAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account domains: %w", err)
+	}
+	verified := false
+	for _, d := range accountDomains {
+		if models.NormalizeDomain(d) == domain {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, ErrDomainNotInAccount
+	}
+
+	org.AddDomain(domain)
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		if errors.Is(err, models.ErrAlreadyExists) {
+			return nil, ErrDomainAlreadyClaimed
+		}
+		return nil, fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	return org, nil
+}
+
+// RemoveDomain removes a secondary domain from the supplier
+func (s *checkFixService) RemoveDomain(ctx context.Context, supplierID primitive.ObjectID, domain string) (*models.Organization, error) {
+	org, err := s.orgRepo.GetByID(ctx, supplierID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	matched, ok := org.MatchDomain(domain)
+	if !ok {
+		return nil, ErrDomainNotFound
+	}
+	if !org.RemoveDomain(matched) {
+		return nil, ErrPrimaryDomain
+	}
+
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		return nil, fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	return org, nil
+}
+
 // UnlinkAccount removes the CheckFix link from a supplier
 func (s *checkFixService) UnlinkAccount(ctx context.Context, supplierID primitive.ObjectID) error {
 	org, err := s.orgRepo.GetByID(ctx, supplierID)
@@ -178,8 +390,11 @@ func (s *checkFixService) UnlinkAccount(ctx context.Context, supplierID primitiv
 		return fmt.Errorf("failed to get organization: %w", err)
 	}
 
+	// #SECURITY_ASSUMPTION: The verified domains were proven by the unlinked account, so they
+	// go with it; a later link starts from the new account's domains
 	org.CheckFixAccountID = ""
 	org.CheckFixLinkedAt = nil
+	org.RetainDomains(nil)
 
 	if err := s.orgRepo.Update(ctx, org); err != nil {
 		return fmt.Errorf("failed to update organization: %w", err)
@@ -199,6 +414,7 @@ func (s *checkFixService) GetLinkStatus(ctx context.Context, supplierID primitiv
 		IsLinked:  org.HasCheckFixLinked(),
 		AccountID: org.CheckFixAccountID,
 		Domain:    org.Domain,
+		Domains:   org.VerifiedDomains(),
 		LinkedAt:  org.CheckFixLinkedAt,
 	}
 
@@ -228,33 +444,19 @@ func (s *checkFixService) VerifyReport(ctx context.Context, supplierID, response
 		return nil, ErrCheckFixNotLinked
 	}
 
-	// Verify report via API
-	reportData, err := s.apiClient.VerifyReport(ctx, reportHash)
+	// Verify report via API (or a recent cached verification)
+	reportData, err := s.fetchReportData(ctx, reportHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify report: %w", err)
 	}
 
-	// Check domain match
-	domainMatch := strings.EqualFold(reportData.Domain, org.Domain)
-
 	// Create verification
 	verification := &models.CheckFixVerification{
 		ResponseID:        responseID,
 		SupplierID:        supplierID,
-		Domain:            org.Domain,
-		VerifiedDomain:    reportData.Domain,
-		DomainMatch:       domainMatch,
-		ReportHash:        reportData.ReportHash,
-		ReportDate:        reportData.ReportDate,
-		OverallGrade:      models.CheckFixGrade(reportData.OverallGrade),
-		OverallScore:      reportData.OverallScore,
-		CategoryGrades:    reportData.CategoryGrades,
-		CriticalFindings:  reportData.CriticalFindings,
-		HighFindings:      reportData.HighFindings,
-		MediumFindings:    reportData.MediumFindings,
-		LowFindings:       reportData.LowFindings,
 		VerificationValid: true,
 	}
+	applyReportData(verification, org, reportData)
 	verification.BeforeCreate()
 
 	if err := s.verificationRepo.Create(ctx, verification); err != nil {
@@ -264,6 +466,140 @@ func (s *checkFixService) VerifyReport(ctx context.Context, supplierID, response
 	return verification, nil
 }
 
+// fetchReportData returns report data for a hash, serving recent successful
+// verifications from the cache and collapsing concurrent lookups of the same hash
+// #IMPLEMENTATION_DECISION: Only successful API responses are cached so a transient
+// failure or a not-yet-published report is retried on the next request
+func (s *checkFixService) fetchReportData(ctx context.Context, reportHash string) (*CheckFixReportData, error) {
+	if s.reportCache == nil || s.reportCacheTTL <= 0 {
+		return s.apiClient.VerifyReport(ctx, reportHash)
+	}
+
+	if cached, ok, err := s.reportCache.Get(ctx, reportCacheKey(reportHash)); err == nil && ok {
+		var data CheckFixReportData
+		if json.Unmarshal(cached, &data) == nil {
+			return &data, nil
+		}
+	}
+
+	// The shared fetch must not be cancelled by whichever caller happened to start it; each caller
+	// still stops waiting at its own deadline
+	fetchCtx := context.WithoutCancel(ctx)
+	results := s.reportFetches.DoChan(reportHash, func() (interface{}, error) {
+		data, err := s.apiClient.VerifyReport(fetchCtx, reportHash)
+		if err != nil {
+			return nil, err
+		}
+
+		s.cacheReportData(fetchCtx, reportHash, data)
+		return data, nil
+	})
+
+	var result singleflight.Result
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		return nil, callError(ctx, fmt.Errorf("%w: %w", ErrCheckFixAPIError, ctx.Err()))
+	}
+	if result.Err != nil {
+		return nil, result.Err
+	}
+
+	// Shared result: hand each caller its own copy
+	data := *result.Val.(*CheckFixReportData)
+	return &data, nil
+}
+
+// cacheReportData stores report data for later verifications of the same hash
+func (s *checkFixService) cacheReportData(ctx context.Context, reportHash string, data *CheckFixReportData) {
+	if s.reportCache == nil || s.reportCacheTTL <= 0 {
+		return
+	}
+	if encoded, err := json.Marshal(data); err == nil {
+		//nolint:errcheck // Best-effort cache write
+		s.reportCache.Set(ctx, reportCacheKey(reportHash), encoded, s.reportCacheTTL)
+	}
+}
+
+// reportCacheKey returns the cache key for a report hash
+func reportCacheKey(reportHash string) string {
+	return "checkfix:report:" + reportHash
+}
+
+// applyReportData copies CheckFix report data onto a verification
+// #BUSINESS_RULE: Report domain must match one of the organization's verified domains
+func applyReportData(verification *models.CheckFixVerification, org *models.Organization, reportData *CheckFixReportData) {
+	matched, ok := org.MatchDomain(reportData.Domain)
+	verification.Domain = org.Domain
+	if ok {
+		verification.Domain = matched
+	}
+	verification.VerifiedDomain = reportData.Domain
+	verification.DomainMatch = ok
+	verification.MatchedDomain = matched
+	verification.ReportHash = reportData.ReportHash
+	verification.ReportDate = reportData.ReportDate
+	verification.OverallGrade = models.CheckFixGrade(reportData.OverallGrade)
+	verification.OverallScore = reportData.OverallScore
+	verification.CategoryGrades = reportData.CategoryGrades
+	verification.CriticalFindings = reportData.CriticalFindings
+	verification.HighFindings = reportData.HighFindings
+	verification.MediumFindings = reportData.MediumFindings
+	verification.LowFindings = reportData.LowFindings
+	if verification.CategoryGrades == nil {
+		verification.CategoryGrades = []models.CategoryGrade{}
+	}
+}
+
+// checkFixThresholds returns the minimum grade and maximum report age of a CheckFix requirement
+// #BUSINESS_RULE: The maximum report age is the requirement's own value, else the company's
+// DefaultMaxReportAgeDays setting, else DefaultCheckFixMaxReportAgeDays
+func (s *checkFixService) checkFixThresholds(ctx context.Context, requirement *models.Requirement) (models.CheckFixGrade, int, error) {
+	minimumGrade := models.CheckFixGradeC
+	if requirement.MinimumGrade != nil {
+		minimumGrade = models.CheckFixGrade(*requirement.MinimumGrade)
+	}
+	if requirement.MaxReportAgeDays != nil {
+		return minimumGrade, *requirement.MaxReportAgeDays, nil
+	}
+	if s.orgRepo == nil {
+		return minimumGrade, models.DefaultCheckFixMaxReportAgeDays, nil
+	}
+
+	company, err := s.orgRepo.GetByID(ctx, requirement.CompanyID)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get company: %w", err)
+	}
+	return minimumGrade, company.Settings.EffectiveDefaultMaxReportAgeDays(), nil
+}
+
+// checkFixShortfall describes the first reason a verification fails a requirement
+func checkFixShortfall(verification *models.CheckFixVerification, requirement *models.Requirement, minimumGrade models.CheckFixGrade, maxAgeDays int) string {
+	switch {
+	case !verification.DomainMatch:
+		return "Domain does not match organization"
+	case !verification.MeetsMinimumGrade(minimumGrade):
+		return fmt.Sprintf("Grade %s does not meet minimum %s", verification.OverallGrade, minimumGrade)
+	case verification.IsReportTooOld(maxAgeDays):
+		return fmt.Sprintf("Report is %d days old, maximum is %d days", verification.ReportAgeDays(), maxAgeDays)
+	}
+	if shortfalls := verification.CategoryShortfalls(requirement.CategoryMinimums); len(shortfalls) > 0 {
+		return categoryShortfallMessage(shortfalls[0])
+	}
+	if !verification.IsValid() {
+		return "Verification is no longer valid"
+	}
+	return "CheckFix verification did not pass"
+}
+
+// categoryShortfallMessage describes why a category minimum was not met
+func categoryShortfallMessage(shortfall models.CategoryShortfall) string {
+	if shortfall.Grade == "" {
+		return fmt.Sprintf("Category %s is not graded in the report, minimum is %s", shortfall.Category, shortfall.Minimum)
+	}
+	return fmt.Sprintf("Category %s grade %s does not meet minimum %s", shortfall.Category, shortfall.Grade, shortfall.Minimum)
+}
+
 // GetVerification retrieves a verification by response ID
 func (s *checkFixService) GetVerification(ctx context.Context, responseID primitive.ObjectID) (*models.CheckFixVerification, error) {
 	verification, err := s.verificationRepo.GetByResponse(ctx, responseID)
@@ -288,20 +624,175 @@ func (s *checkFixService) GetLatestVerification(ctx context.Context, supplierID
 	return verification, nil
 }
 
+// ListVerifications lists a supplier's verification history, newest first
+// #SECURITY_ASSUMPTION: Scoped by supplierID only, which callers take from the session
+func (s *checkFixService) ListVerifications(ctx context.Context, supplierID primitive.ObjectID, opts repository.PaginationOptions) (*repository.PaginatedResult[models.CheckFixVerification], error) {
+	result, err := s.verificationRepo.ListBySupplier(ctx, supplierID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list verifications: %w", err)
+	}
+	return result, nil
+}
+
+// GetGradeTrend returns the grade history of a company's supplier and its trend
+// #SECURITY_ASSUMPTION: The relationship must belong to the company, so a company only sees
+// the history of suppliers it works with
+// #BUSINESS_RULE: A pending invitation has no supplier yet and reports an empty, stable history
+func (s *checkFixService) GetGradeTrend(ctx context.Context, companyID, relationshipID primitive.ObjectID, points int) (*GradeTrend, error) {
+	relationship, err := s.relationshipRepo.GetByID(ctx, relationshipID)
+	if err != nil {
+		if errors.Is(err, models.ErrRelationshipNotFound) {
+			return nil, ErrRelationshipNotFound
+		}
+		return nil, fmt.Errorf("failed to get relationship: %w", err)
+	}
+	if relationship.CompanyID != companyID {
+		return nil, ErrRelationshipNotFound
+	}
+
+	trend := &GradeTrend{SupplierID: relationship.SupplierID, Points: []GradeTrendPoint{}, Trend: GradeTrendStable}
+	if relationship.SupplierID == nil {
+		return trend, nil
+	}
+
+	if points <= 0 || points > MaxGradeTrendPoints {
+		points = DefaultGradeTrendPoints
+	}
+	opts := repository.PaginationOptions{Page: 1, Limit: points}
+	result, err := s.verificationRepo.ListBySupplier(ctx, *relationship.SupplierID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list verifications: %w", err)
+	}
+
+	// Verifications arrive newest first; the series reads oldest first
+	for i := len(result.Items) - 1; i >= 0; i-- {
+		v := result.Items[i]
+		trend.Points = append(trend.Points, GradeTrendPoint{
+			VerifiedAt:   v.VerifiedAt,
+			Grade:        v.OverallGrade,
+			OverallScore: v.OverallScore,
+		})
+	}
+	trend.Trend = gradeTrendDirection(trend.Points)
+	return trend, nil
+}
+
+// gradeTrendDirection compares the oldest and newest point of the window
+// #BUSINESS_RULE: A grade change always counts; within the same grade the overall score must
+// move by at least gradeTrendScoreThreshold to leave "stable"
+func gradeTrendDirection(points []GradeTrendPoint) string {
+	if len(points) < 2 {
+		return GradeTrendStable
+	}
+	first, last := points[0], points[len(points)-1]
+
+	gradeDelta := last.Grade.Score() - first.Grade.Score()
+	switch {
+	case gradeDelta > 0:
+		return GradeTrendImproving
+	case gradeDelta < 0:
+		return GradeTrendDeclining
+	}
+
+	scoreDelta := last.OverallScore - first.OverallScore
+	switch {
+	case scoreDelta >= gradeTrendScoreThreshold:
+		return GradeTrendImproving
+	case scoreDelta <= -gradeTrendScoreThreshold:
+		return GradeTrendDeclining
+	}
+	return GradeTrendStable
+}
+
 // CheckRequirementMet checks if a CheckFix requirement is met
-func (s *checkFixService) CheckRequirementMet(ctx context.Context, responseID primitive.ObjectID, minimumGrade models.CheckFixGrade, maxReportAgeDays int) (bool, error) {
+func (s *checkFixService) CheckRequirementMet(ctx context.Context, responseID primitive.ObjectID, minimumGrade models.CheckFixGrade, maxReportAgeDays int, categoryMinimums map[string]string) (bool, error) {
 	verification, err := s.verificationRepo.GetByResponse(ctx, responseID)
 	if err != nil {
 		return false, err
 	}
 
-	return verification.PassesRequirement(minimumGrade, maxReportAgeDays), nil
+	return verification.PassesRequirement(minimumGrade, maxReportAgeDays, categoryMinimums), nil
 }
 
 // SubmitCheckFixResponse submits a CheckFix verification as a response
 // #BUSINESS_RULE: Creates response, verifies report, updates requirement status
 func (s *checkFixService) SubmitCheckFixResponse(ctx context.Context, requirementID, supplierID primitive.ObjectID, reportHash string) (*CheckFixSubmissionResult, error) {
-	// Get requirement
+	requirement, err := s.getSupplierCheckFixRequirement(ctx, requirementID, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.getOrCreateResponse(ctx, requirementID, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the report
+	verification, err := s.VerifyReport(ctx, supplierID, response.ID, reportHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.completeCheckFixSubmission(ctx, requirement, response, verification, supplierID)
+}
+
+// SatisfyFromLatest submits the supplier's latest valid verification as the response to a requirement
+// #BUSINESS_RULE: Only a verification that is still valid and meets the requirement's minimum grade,
+// report age and category minimums is used; otherwise nothing is created and the reason is returned
+// #IMPLEMENTATION_DECISION: The verification is copied onto the requirement's response with its
+// original verification and expiry dates, so reusing it never extends its validity
+func (s *checkFixService) SatisfyFromLatest(ctx context.Context, requirementID, supplierID primitive.ObjectID) (*CheckFixSubmissionResult, error) {
+	requirement, err := s.getSupplierCheckFixRequirement(ctx, requirementID, supplierID)
+	if err != nil {
+		return nil, err
+	}
+	if requirement.Status.IsTerminal() {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	latest, err := s.GetLatestVerification(ctx, supplierID)
+	if err != nil {
+		if errors.Is(err, ErrVerificationNotFound) {
+			return nil, ErrNoValidVerification
+		}
+		return nil, err
+	}
+	if !latest.IsValid() {
+		return nil, ErrNoValidVerification
+	}
+
+	minimumGrade, maxAgeDays, err := s.checkFixThresholds(ctx, requirement)
+	if err != nil {
+		return nil, err
+	}
+	if !latest.PassesRequirement(minimumGrade, maxAgeDays, requirement.CategoryMinimums) {
+		return nil, &CheckFixShortfallError{Reason: checkFixShortfall(latest, requirement, minimumGrade, maxAgeDays)}
+	}
+
+	response, err := s.getOrCreateResponse(ctx, requirementID, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	verification := *latest
+	verification.ID = primitive.NilObjectID
+	verification.ResponseID = response.ID
+	if err := s.verificationRepo.Create(ctx, &verification); err != nil {
+		return nil, fmt.Errorf("failed to create verification: %w", err)
+	}
+
+	// A requirement the supplier never opened is started so it can move on to submitted
+	if requirement.IsPending() {
+		if err := requirement.Start(supplierID); err != nil {
+			return nil, ErrInvalidStatusTransition
+		}
+	}
+
+	return s.completeCheckFixSubmission(ctx, requirement, response, &verification, supplierID)
+}
+
+// getSupplierCheckFixRequirement returns a CheckFix requirement assigned to the supplier
+func (s *checkFixService) getSupplierCheckFixRequirement(ctx context.Context, requirementID, supplierID primitive.ObjectID) (*models.Requirement, error) {
 	requirement, err := s.requirementRepo.GetByID(ctx, requirementID)
 	if err != nil {
 		return nil, ErrRequirementNotFound
@@ -316,38 +807,35 @@ func (s *checkFixService) SubmitCheckFixResponse(ctx context.Context, requiremen
 	if !requirement.IsCheckFixRequirement() {
 		return nil, errors.New("requirement is not a CheckFix requirement")
 	}
+	return requirement, nil
+}
 
-	// Get or create response
+// getOrCreateResponse returns the requirement's response, creating it when there is none yet
+func (s *checkFixService) getOrCreateResponse(ctx context.Context, requirementID, supplierID primitive.ObjectID) (*models.SupplierResponse, error) {
 	response, err := s.responseRepo.GetByRequirement(ctx, requirementID)
-	if err != nil {
-		// Create new response
-		response = &models.SupplierResponse{
-			RequirementID: requirementID,
-			SupplierID:    supplierID,
-		}
-		response.BeforeCreate()
-		if createErr := s.responseRepo.Create(ctx, response); createErr != nil {
-			return nil, fmt.Errorf("failed to create response: %w", createErr)
-		}
+	if err == nil {
+		return response, nil
 	}
 
-	// Verify the report
-	verification, err := s.VerifyReport(ctx, supplierID, response.ID, reportHash)
-	if err != nil {
-		return nil, err
+	response = &models.SupplierResponse{
+		RequirementID: requirementID,
+		SupplierID:    supplierID,
+	}
+	response.BeforeCreate()
+	if err := s.responseRepo.Create(ctx, response); err != nil {
+		return nil, fmt.Errorf("failed to create response: %w", err)
 	}
+	return response, nil
+}
 
+// completeCheckFixSubmission grades the response by the verification and submits the requirement
+func (s *checkFixService) completeCheckFixSubmission(ctx context.Context, requirement *models.Requirement, response *models.SupplierResponse, verification *models.CheckFixVerification, supplierID primitive.ObjectID) (*CheckFixSubmissionResult, error) {
 	// Determine if passed
-	minimumGrade := models.CheckFixGradeC
-	if requirement.MinimumGrade != nil {
-		minimumGrade = models.CheckFixGrade(*requirement.MinimumGrade)
-	}
-	maxAgeDays := 90
-	if requirement.MaxReportAgeDays != nil {
-		maxAgeDays = *requirement.MaxReportAgeDays
+	minimumGrade, maxAgeDays, err := s.checkFixThresholds(ctx, requirement)
+	if err != nil {
+		return nil, err
 	}
-
-	passed := verification.PassesRequirement(minimumGrade, maxAgeDays)
+	passed := verification.PassesRequirement(minimumGrade, maxAgeDays, requirement.CategoryMinimums)
 
 	// Update response
 	gradeStr := string(verification.OverallGrade)
@@ -363,20 +851,19 @@ func (s *checkFixService) SubmitCheckFixResponse(ctx context.Context, requiremen
 	if submitErr := requirement.Submit(supplierID); submitErr == nil {
 		//nolint:errcheck // Best-effort update
 		s.requirementRepo.Update(ctx, requirement)
+
+		dispatchWebhook(ctx, s.webhooks, requirement.CompanyID, models.WebhookEventRequirementSubmitted,
+			newRequirementWebhookData(requirement, &passed, ""))
 	}
 
 	// Build message
 	message := "CheckFix verification successful"
 	if !passed {
-		if !verification.DomainMatch {
-			message = "Domain does not match organization"
-		} else if !verification.MeetsMinimumGrade(minimumGrade) {
-			message = fmt.Sprintf("Grade %s does not meet minimum %s", verification.OverallGrade, minimumGrade)
-		} else if verification.IsReportTooOld(maxAgeDays) {
-			message = fmt.Sprintf("Report is %d days old, maximum is %d days", verification.ReportAgeDays(), maxAgeDays)
-		}
+		message = checkFixShortfall(verification, requirement, minimumGrade, maxAgeDays)
 	}
 
+	metrics.RecordEvent(metrics.EventCheckFixVerified, metrics.Outcome(passed))
+
 	return &CheckFixSubmissionResult{
 		Verification: verification,
 		Response:     response,
@@ -387,38 +874,401 @@ func (s *checkFixService) SubmitCheckFixResponse(ctx context.Context, requiremen
 	}, nil
 }
 
+// HandleWebhookEvent processes a CheckFix webhook event exactly once per event ID
+// #IMPLEMENTATION_DECISION: The event is claimed before processing and released again on
+// failure, so a redelivery after an error is retried while concurrent duplicates are dropped
+func (s *checkFixService) HandleWebhookEvent(ctx context.Context, event *CheckFixWebhookEvent) (*CheckFixWebhookResult, error) {
+	if event.EventID == "" {
+		return nil, ErrInvalidWebhookEvent
+	}
+
+	claimed, err := s.webhookEventRepo.Claim(ctx, &models.WebhookEvent{
+		Source:    checkFixWebhookSource,
+		EventID:   event.EventID,
+		EventType: event.Type,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook event: %w", err)
+	}
+	if !claimed {
+		return &CheckFixWebhookResult{Duplicate: true}, nil
+	}
+
+	result, err := s.processWebhookEvent(ctx, event)
+	if err != nil {
+		//nolint:errcheck // Best-effort release; the claim expires on its own otherwise
+		s.webhookEventRepo.Release(context.WithoutCancel(ctx), checkFixWebhookSource, event.EventID)
+		return nil, err
+	}
+	return result, nil
+}
+
+// processWebhookEvent refreshes the latest verification of the supplier owning the event's domain
+func (s *checkFixService) processWebhookEvent(ctx context.Context, event *CheckFixWebhookEvent) (*CheckFixWebhookResult, error) {
+	if event.Type != CheckFixEventReportUpdated || event.Domain == "" {
+		return &CheckFixWebhookResult{Ignored: true}, nil
+	}
+
+	org, err := s.orgRepo.GetByDomain(ctx, models.NormalizeDomain(event.Domain))
+	if err != nil {
+		if errors.Is(err, models.ErrOrganizationNotFound) {
+			return &CheckFixWebhookResult{Ignored: true}, nil
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	if !org.IsSupplier() || !org.HasCheckFixLinked() {
+		return &CheckFixWebhookResult{Ignored: true}, nil
+	}
+
+	verification, err := s.verificationRepo.GetLatestBySupplier(ctx, org.ID)
+	if err != nil {
+		if errors.Is(err, models.ErrVerificationNotFound) {
+			return &CheckFixWebhookResult{Ignored: true}, nil
+		}
+		return nil, fmt.Errorf("failed to get verification: %w", err)
+	}
+
+	reportHash := event.ReportHash
+	if reportHash == "" {
+		reportHash = verification.ReportHash
+	}
+
+	// The report is known to have changed, so bypass the cache
+	reportData, err := s.lookupReport(ctx, reportHash, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refreshVerification(ctx, org, verification, reportData); err != nil {
+		return nil, err
+	}
+
+	changed, err := s.syncResponseOutcome(ctx, verification)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CheckFixWebhookResult{
+		Verification:   verification,
+		OutcomeChanged: changed,
+	}, nil
+}
+
+// lookupReport fetches report data, returning nil without error if the report no longer exists.
+// fresh bypasses (and then updates) the report cache.
+func (s *checkFixService) lookupReport(ctx context.Context, reportHash string, fresh bool) (*CheckFixReportData, error) {
+	var reportData *CheckFixReportData
+	var err error
+	if fresh {
+		reportData, err = s.apiClient.VerifyReport(ctx, reportHash)
+		if err == nil {
+			s.cacheReportData(ctx, reportHash, reportData)
+		}
+	} else {
+		reportData, err = s.fetchReportData(ctx, reportHash)
+	}
+
+	if errors.Is(err, ErrCheckFixReportNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify report: %w", err)
+	}
+	return reportData, nil
+}
+
+// refreshVerification stores re-verified report data on a verification
+// #BUSINESS_RULE: A report that can no longer be found (nil reportData) invalidates the verification
+func (s *checkFixService) refreshVerification(ctx context.Context, org *models.Organization, verification *models.CheckFixVerification, reportData *CheckFixReportData) error {
+	if reportData == nil {
+		verification.VerificationValid = false
+	} else {
+		applyReportData(verification, org, reportData)
+		verification.VerificationValid = true
+		verification.Refresh()
+	}
+
+	if err := s.verificationRepo.Update(ctx, verification); err != nil {
+		return fmt.Errorf("failed to update verification: %w", err)
+	}
+	return nil
+}
+
+// syncResponseOutcome re-evaluates the response linked to a verification against its requirement
+// #BUSINESS_RULE: Only responses still awaiting review are updated; decisions already made by
+// the company are not overturned
+func (s *checkFixService) syncResponseOutcome(ctx context.Context, verification *models.CheckFixVerification) (bool, error) {
+	if verification.ResponseID.IsZero() {
+		return false, nil
+	}
+
+	response, err := s.responseRepo.GetByID(ctx, verification.ResponseID)
+	if err != nil {
+		if errors.Is(err, models.ErrResponseNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get response: %w", err)
+	}
+
+	requirement, err := s.requirementRepo.GetByID(ctx, response.RequirementID)
+	if err != nil {
+		if errors.Is(err, models.ErrRequirementNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get requirement: %w", err)
+	}
+	if requirement.Status != models.RequirementStatusSubmitted && requirement.Status != models.RequirementStatusUnderReview {
+		return false, nil
+	}
+
+	minimumGrade, maxAgeDays, err := s.checkFixThresholds(ctx, requirement)
+	if err != nil {
+		return false, err
+	}
+	passed := verification.PassesRequirement(minimumGrade, maxAgeDays, requirement.CategoryMinimums)
+	changed := response.HasPassed() != passed
+
+	grade := string(verification.OverallGrade)
+	response.Grade = &grade
+	response.Passed = &passed
+	if err := s.responseRepo.Update(ctx, response); err != nil {
+		return false, fmt.Errorf("failed to update response: %w", err)
+	}
+
+	return changed, nil
+}
+
+// RefreshExpiringVerifications re-verifies verifications expiring within the given number of days
+// #BUSINESS_RULE: Suppliers whose report can no longer be verified are emailed once per run
+// so they can provide a current report before companies rely on a stale one
+func (s *checkFixService) RefreshExpiringVerifications(ctx context.Context, daysBeforeExpiry int) (*VerificationRefreshSummary, error) {
+	verifications, err := s.verificationRepo.ListExpiringVerifications(ctx, daysBeforeExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expiring verifications: %w", err)
+	}
+
+	summary := &VerificationRefreshSummary{Checked: len(verifications)}
+	notified := make(map[primitive.ObjectID]bool)
+
+	for i := range verifications {
+		if ctx.Err() != nil {
+			return summary, ctx.Err()
+		}
+		verification := &verifications[i]
+
+		org, err := s.orgRepo.GetByID(ctx, verification.SupplierID)
+		if err != nil {
+			summary.Failed++
+			log.Printf("Failed to refresh verification %s: %v", verification.ID.Hex(), err)
+			continue
+		}
+
+		reportData, err := s.lookupReport(ctx, verification.ReportHash, false)
+		if err == nil {
+			err = s.refreshVerification(ctx, org, verification, reportData)
+		}
+		if err != nil {
+			summary.Failed++
+			log.Printf("Failed to refresh verification %s: %v", verification.ID.Hex(), err)
+			continue
+		}
+
+		//nolint:errcheck // Best-effort; the outcome is re-evaluated on the next refresh
+		s.syncResponseOutcome(ctx, verification)
+
+		if verification.IsValid() {
+			summary.Refreshed++
+			continue
+		}
+		summary.Invalidated++
+
+		if !notified[org.ID] {
+			notified[org.ID] = true
+			summary.Notified += s.notifyRefreshNeeded(ctx, org)
+		}
+	}
+
+	return summary, nil
+}
+
+// notifyRefreshNeeded queues emails telling a supplier that their CheckFix report needs
+// refreshing and returns the number of emails queued
+// #BUSINESS_RULE: Goes to the organization's notification emails, or its active admins if none are set
+func (s *checkFixService) notifyRefreshNeeded(ctx context.Context, org *models.Organization) int {
+	if s.mailOutbox == nil || !org.Settings.NotificationsEnabled {
+		return 0
+	}
+
+	// Notification addresses are not users, so they get the organization's default language
+	languages := make(map[string]string, len(org.Settings.NotificationEmails))
+	recipients := org.Settings.NotificationEmails
+	for _, email := range recipients {
+		languages[email] = org.Settings.DefaultLanguage
+	}
+	if len(recipients) == 0 {
+		opts := repository.DefaultPaginationOptions()
+		opts.Limit = 100
+		users, err := s.userRepo.ListByOrganization(ctx, org.ID, false, opts)
+		if err != nil {
+			log.Printf("Failed to list users of organization %s: %v", org.ID.Hex(), err)
+			return 0
+		}
+		for i := range users.Items {
+			if users.Items[i].IsAdmin() {
+				recipients = append(recipients, users.Items[i].Email)
+				languages[users.Items[i].Email] = users.Items[i].Language
+			}
+		}
+	}
+
+	sent := 0
+	for _, email := range recipients {
+		if err := s.mailOutbox.EnqueueCheckFixRefreshNeeded(ctx, org.ID, email, org.Name, org.Domain, languages[email]); err != nil {
+			log.Printf("Failed to queue CheckFix refresh notice to %s: %v", email, err)
+			continue
+		}
+		sent++
+	}
+	return sent
+}
+
+// CheckFixRetryConfig controls retries of CheckFix API calls
+// #IMPLEMENTATION_DECISION: Exponential backoff with jitter; only network errors and 5xx
+// responses are retried since 4xx responses will not change on retry
+type CheckFixRetryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first (minimum 1)
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry; it doubles on each further retry
+	BaseDelay time.Duration
+	// Jitter adds up to this fraction of the delay at random (0 disables, 1 = up to 100%)
+	Jitter float64
+}
+
+// DefaultCheckFixRetryConfig returns the retry settings used in production
+func DefaultCheckFixRetryConfig() CheckFixRetryConfig {
+	return CheckFixRetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		Jitter:      0.5,
+	}
+}
+
+// backoff returns the delay before the given retry (1 = first retry)
+func (r CheckFixRetryConfig) backoff(retry int) time.Duration {
+	delay := r.BaseDelay << (retry - 1)
+	if r.Jitter > 0 && delay > 0 {
+		delay += time.Duration(rand.Float64() * r.Jitter * float64(delay)) //nolint:gosec // Jitter does not need a CSPRNG
+	}
+	return delay
+}
+
 // HTTPCheckFixAPIClient implements CheckFixAPIClient using HTTP
 type HTTPCheckFixAPIClient struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
+	baseURL     string
+	apiKey      string
+	httpClient  *http.Client
+	retry       CheckFixRetryConfig
+	callTimeout time.Duration
 }
 
 // NewHTTPCheckFixAPIClient creates a new HTTP-based CheckFix API client
-func NewHTTPCheckFixAPIClient(baseURL, apiKey string) *HTTPCheckFixAPIClient {
+// #IMPLEMENTATION_DECISION: callTimeout bounds a whole call including retries and should stay below
+// the server write timeout; 0 leaves calls bounded by the caller's context and the 30s client timeout
+func NewHTTPCheckFixAPIClient(baseURL, apiKey string, retry CheckFixRetryConfig, callTimeout time.Duration) *HTTPCheckFixAPIClient {
+	if retry.MaxAttempts < 1 {
+		retry.MaxAttempts = 1
+	}
 	return &HTTPCheckFixAPIClient{
 		baseURL: strings.TrimSuffix(baseURL, "/"),
 		apiKey:  apiKey,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		retry:       retry,
+		callTimeout: callTimeout,
+	}
+}
+
+// withCallTimeout derives the context of one API call; the earlier of the caller's deadline and
+// the call timeout applies
+func (c *HTTPCheckFixAPIClient) withCallTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.callTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.callTimeout)
+}
+
+// callError reports err as ErrCheckFixTimeout when the call ran out of time
+func callError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ErrCheckFixTimeout
+	}
+	return err
+}
+
+// get performs an authenticated GET, retrying network errors and 5xx responses.
+// The caller must close the returned response body.
+// #SECURITY_ASSUMPTION: Only idempotent GET requests are retried
+func (c *HTTPCheckFixAPIClient) get(ctx context.Context, url string) (*http.Response, error) {
+	var lastErr error
+
+	for attempt := 1; attempt <= c.retry.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			delay := c.retry.backoff(attempt - 1)
+
+			// Give up early rather than sleep past the caller's deadline
+			if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+				break
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, callError(ctx, fmt.Errorf("%w: %w", ErrCheckFixAPIError, ctx.Err()))
+			case <-timer.C:
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, callError(ctx, fmt.Errorf("%w: %w", ErrCheckFixAPIError, ctx.Err()))
+			}
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode >= http.StatusInternalServerError && attempt < c.retry.MaxAttempts {
+			//nolint:errcheck // Drain so the connection can be reused
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close() //nolint:errcheck // Discarded response
+			lastErr = fmt.Errorf("status %d", resp.StatusCode)
+			continue
+		}
+
+		return resp, nil
 	}
+
+	return nil, fmt.Errorf("%w: %w", ErrCheckFixAPIError, lastErr)
 }
 
 // VerifyReport verifies a report via the CheckFix API
 func (c *HTTPCheckFixAPIClient) VerifyReport(ctx context.Context, reportHash string) (*CheckFixReportData, error) {
 	url := fmt.Sprintf("%s/api/v1/reports/%s/verify", c.baseURL, reportHash)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Accept", "application/json")
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.get(ctx, url)
 	if err != nil {
-		return nil, ErrCheckFixAPIError
+		return nil, callError(ctx, ErrCheckFixAPIError)
 	}
 	defer resp.Body.Close() //nolint:errcheck // defer close
 
@@ -432,7 +1282,7 @@ func (c *HTTPCheckFixAPIClient) VerifyReport(ctx context.Context, reportHash str
 
 	var data CheckFixReportData
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, callError(ctx, fmt.Errorf("failed to decode response: %w", err))
 	}
 
 	return &data, nil
@@ -442,16 +1292,12 @@ func (c *HTTPCheckFixAPIClient) VerifyReport(ctx context.Context, reportHash str
 func (c *HTTPCheckFixAPIClient) GetAccountDomain(ctx context.Context, accountID string) (string, error) {
 	url := fmt.Sprintf("%s/api/v1/accounts/%s", c.baseURL, accountID)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return "", err
-	}
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Accept", "application/json")
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.get(ctx, url)
 	if err != nil {
-		return "", ErrCheckFixAPIError
+		return "", callError(ctx, ErrCheckFixAPIError)
 	}
 	defer resp.Body.Close() //nolint:errcheck // defer close
 
@@ -463,36 +1309,96 @@ func (c *HTTPCheckFixAPIClient) GetAccountDomain(ctx context.Context, accountID
 		Domain string `json:"domain"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return "", err
+		return "", callError(ctx, err)
 	}
 
 	return data.Domain, nil
 }
 
+// ListAccountDomains lists the domains verified for a CheckFix account
+func (c *HTTPCheckFixAPIClient) ListAccountDomains(ctx context.Context, accountID string) ([]string, error) {
+	url := fmt.Sprintf("%s/api/v1/accounts/%s/domains", c.baseURL, accountID)
+
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return nil, callError(ctx, ErrCheckFixAPIError)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrCheckFixAPIError
+	}
+
+	var data struct {
+		Domains []string `json:"domains"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, callError(ctx, err)
+	}
+
+	return data.Domains, nil
+}
+
 // ValidateAccountAccess validates account access
+// #IMPLEMENTATION_DECISION: Only a definite 401, 403 or 404 marks the account invalid; a slow or
+// failing API says nothing about the account and is reported as an error
 func (c *HTTPCheckFixAPIClient) ValidateAccountAccess(ctx context.Context, accountID string) (bool, error) {
 	url := fmt.Sprintf("%s/api/v1/accounts/%s/validate", c.baseURL, accountID)
 
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return false, callError(ctx, ErrCheckFixAPIError)
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: status %d", ErrCheckFixAPIError, resp.StatusCode)
+	}
+}
+
+// Ping checks CheckFix API reachability via its health endpoint
+// #INTEGRATION_POINT: Used by the readiness probe, so it must stay cheap
+func (c *HTTPCheckFixAPIClient) Ping(ctx context.Context) error {
+	url := fmt.Sprintf("%s/api/v1/health", c.baseURL)
+
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return false, err
+		return err
 	}
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Accept", "application/json")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return false, nil // Treat network errors as invalid
+		return callError(ctx, fmt.Errorf("%w: %w", ErrCheckFixAPIError, err))
 	}
 	defer resp.Body.Close() //nolint:errcheck // defer close
 
-	return resp.StatusCode == http.StatusOK, nil
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: health check returned status %d", ErrCheckFixAPIError, resp.StatusCode)
+	}
+
+	return nil
 }
 
 // MockCheckFixAPIClient is a mock implementation for development/testing
 type MockCheckFixAPIClient struct {
-	MockDomain string
-	MockGrade  string
+	MockDomain  string
+	MockDomains []string
+	MockGrade   string
 }
 
 // NewMockCheckFixAPIClient creates a mock CheckFix API client
@@ -524,7 +1430,20 @@ func (c *MockCheckFixAPIClient) GetAccountDomain(ctx context.Context, accountID
 	return c.MockDomain, nil
 }
 
+// ListAccountDomains returns the mock domains, or just the mock domain if none are set
+func (c *MockCheckFixAPIClient) ListAccountDomains(ctx context.Context, accountID string) ([]string, error) {
+	if len(c.MockDomains) > 0 {
+		return c.MockDomains, nil
+	}
+	return []string{c.MockDomain}, nil
+}
+
 // ValidateAccountAccess always returns true for mock
 func (c *MockCheckFixAPIClient) ValidateAccountAccess(ctx context.Context, accountID string) (bool, error) {
 	return true, nil
 }
+
+// Ping always succeeds for mock
+func (c *MockCheckFixAPIClient) Ping(ctx context.Context) error {
+	return nil
+}
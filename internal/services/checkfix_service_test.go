@@ -0,0 +1,345 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+func TestHTTPCheckFixAPIClient_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		//nolint:errcheck // Test response
+		json.NewEncoder(w).Encode(CheckFixReportData{ReportHash: "abc", Domain: "example.com", OverallGrade: "B"})
+	}))
+	defer server.Close()
+
+	client := NewHTTPCheckFixAPIClient(server.URL, "key", CheckFixRetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+	}, 0)
+
+	data, err := client.VerifyReport(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("Expected success after retries, got %v", err)
+	}
+	if data.Domain != "example.com" {
+		t.Errorf("Expected domain 'example.com', got '%s'", data.Domain)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("Expected 3 attempts, got %d", got)
+	}
+}
+
+func TestHTTPCheckFixAPIClient_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewHTTPCheckFixAPIClient(server.URL, "key", CheckFixRetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+	}, 0)
+
+	_, err := client.VerifyReport(context.Background(), "missing")
+	if !errors.Is(err, ErrCheckFixReportNotFound) {
+		t.Errorf("Expected ErrCheckFixReportNotFound, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("Expected 1 attempt, got %d", got)
+	}
+}
+
+func TestHTTPCheckFixAPIClient_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewHTTPCheckFixAPIClient(server.URL, "key", CheckFixRetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond}, 50*time.Millisecond)
+
+	start := time.Now()
+	if _, err := client.VerifyReport(context.Background(), "slow"); !errors.Is(err, ErrCheckFixTimeout) {
+		t.Errorf("Expected ErrCheckFixTimeout from the call timeout, got %v", err)
+	}
+
+	// A shorter caller deadline wins over the call timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.ListAccountDomains(ctx, "acct"); !errors.Is(err, ErrCheckFixTimeout) || !errors.Is(err, ErrCheckFixAPIError) {
+		t.Errorf("Expected ErrCheckFixTimeout wrapping ErrCheckFixAPIError, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected both calls to give up quickly, took %s", elapsed)
+	}
+}
+
+func TestHTTPCheckFixAPIClient_ValidateAccountAccess(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		wantValid bool
+		wantErr   error
+	}{
+		{"valid", http.StatusOK, true, nil},
+		{"unknown account", http.StatusNotFound, false, nil},
+		{"no access", http.StatusForbidden, false, nil},
+		{"server error after retries", http.StatusBadGateway, false, ErrCheckFixAPIError},
+		{"unexpected status", http.StatusTooManyRequests, false, ErrCheckFixAPIError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewHTTPCheckFixAPIClient(server.URL, "key", CheckFixRetryConfig{MaxAttempts: 2, BaseDelay: time.Millisecond}, 0)
+			valid, err := client.ValidateAccountAccess(context.Background(), "acct")
+			if valid != tt.wantValid || !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateAccountAccess() = %v, %v; want %v, %v", valid, err, tt.wantValid, tt.wantErr)
+			}
+		})
+	}
+
+	// Network errors are not a verdict on the account either
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.Close()
+	client := NewHTTPCheckFixAPIClient(server.URL, "key", CheckFixRetryConfig{MaxAttempts: 2, BaseDelay: time.Millisecond}, 0)
+	if valid, err := client.ValidateAccountAccess(context.Background(), "acct"); valid || !errors.Is(err, ErrCheckFixAPIError) {
+		t.Errorf("Expected ErrCheckFixAPIError for an unreachable API, got %v, %v", valid, err)
+	}
+}
+
+// memoryTrendRelationshipRepo serves relationships by ID; other methods are not used
+type memoryTrendRelationshipRepo struct {
+	repository.RelationshipRepository
+	relationships map[primitive.ObjectID]*models.CompanySupplierRelationship
+}
+
+func (r *memoryTrendRelationshipRepo) GetByID(_ context.Context, id primitive.ObjectID) (*models.CompanySupplierRelationship, error) {
+	if rel, ok := r.relationships[id]; ok {
+		return rel, nil
+	}
+	return nil, models.ErrRelationshipNotFound
+}
+
+// memoryTrendVerificationRepo lists verifications newest first; other methods are not used
+type memoryTrendVerificationRepo struct {
+	repository.VerificationRepository
+	verifications []models.CheckFixVerification
+}
+
+func (r *memoryTrendVerificationRepo) ListBySupplier(_ context.Context, supplierID primitive.ObjectID, opts repository.PaginationOptions) (*repository.PaginatedResult[models.CheckFixVerification], error) {
+	var items []models.CheckFixVerification
+	for _, v := range r.verifications {
+		if v.SupplierID == supplierID && len(items) < opts.Limit {
+			items = append(items, v)
+		}
+	}
+	return &repository.PaginatedResult[models.CheckFixVerification]{Items: items, TotalCount: int64(len(items)), Page: 1, Limit: opts.Limit}, nil
+}
+
+func TestCheckFixService_GetGradeTrend(t *testing.T) {
+	companyID, supplierID := primitive.NewObjectID(), primitive.NewObjectID()
+	relationship := &models.CompanySupplierRelationship{ID: primitive.NewObjectID(), CompanyID: companyID, SupplierID: &supplierID}
+	now := time.Now()
+	verifications := &memoryTrendVerificationRepo{verifications: []models.CheckFixVerification{
+		{SupplierID: supplierID, OverallGrade: models.CheckFixGradeB, OverallScore: 80, VerifiedAt: now},
+		{SupplierID: supplierID, OverallGrade: models.CheckFixGradeC, OverallScore: 65, VerifiedAt: now.Add(-24 * time.Hour)},
+		{SupplierID: supplierID, OverallGrade: models.CheckFixGradeB, OverallScore: 82, VerifiedAt: now.Add(-48 * time.Hour)},
+	}}
+	service := &checkFixService{
+		verificationRepo: verifications,
+		relationshipRepo: &memoryTrendRelationshipRepo{relationships: map[primitive.ObjectID]*models.CompanySupplierRelationship{relationship.ID: relationship}},
+	}
+
+	trend, err := service.GetGradeTrend(context.Background(), companyID, relationship.ID, 2)
+	if err != nil {
+		t.Fatalf("GetGradeTrend() error = %v", err)
+	}
+	if len(trend.Points) != 2 || !trend.Points[0].VerifiedAt.Before(trend.Points[1].VerifiedAt) {
+		t.Fatalf("Expected the 2 newest points oldest first, got %+v", trend.Points)
+	}
+	if trend.Trend != GradeTrendImproving {
+		t.Errorf("Trend = %q, want %q", trend.Trend, GradeTrendImproving)
+	}
+
+	trend, err = service.GetGradeTrend(context.Background(), companyID, relationship.ID, 3)
+	if err != nil {
+		t.Fatalf("GetGradeTrend() error = %v", err)
+	}
+	if trend.Trend != GradeTrendStable {
+		t.Errorf("Trend over B/82 to B/80 = %q, want %q", trend.Trend, GradeTrendStable)
+	}
+
+	if _, err := service.GetGradeTrend(context.Background(), primitive.NewObjectID(), relationship.ID, 3); !errors.Is(err, ErrRelationshipNotFound) {
+		t.Errorf("GetGradeTrend() for another company error = %v, want ErrRelationshipNotFound", err)
+	}
+}
+
+// latestVerificationRepo serves one latest verification and records created copies; other methods are not used
+type latestVerificationRepo struct {
+	repository.VerificationRepository
+	latest  *models.CheckFixVerification
+	created []models.CheckFixVerification
+}
+
+func (r *latestVerificationRepo) GetLatestBySupplier(_ context.Context, supplierID primitive.ObjectID) (*models.CheckFixVerification, error) {
+	if r.latest == nil || r.latest.SupplierID != supplierID {
+		return nil, models.ErrVerificationNotFound
+	}
+	clone := *r.latest
+	return &clone, nil
+}
+
+func (r *latestVerificationRepo) Create(_ context.Context, verification *models.CheckFixVerification) error {
+	verification.BeforeCreate()
+	r.created = append(r.created, *verification)
+	return nil
+}
+
+// memoryResponseRepo keeps responses by requirement; other methods are not used
+type memoryResponseRepo struct {
+	repository.ResponseRepository
+	responses map[primitive.ObjectID]*models.SupplierResponse
+}
+
+func (r *memoryResponseRepo) GetByRequirement(_ context.Context, requirementID primitive.ObjectID) (*models.SupplierResponse, error) {
+	if response, ok := r.responses[requirementID]; ok {
+		return response, nil
+	}
+	return nil, models.ErrResponseNotFound
+}
+
+func (r *memoryResponseRepo) Create(_ context.Context, response *models.SupplierResponse) error {
+	r.responses[response.RequirementID] = response
+	return nil
+}
+
+func (r *memoryResponseRepo) Update(_ context.Context, response *models.SupplierResponse) error {
+	r.responses[response.RequirementID] = response
+	return nil
+}
+
+func TestCheckFixService_SatisfyFromLatest(t *testing.T) {
+	ctx := context.Background()
+	supplierID := primitive.NewObjectID()
+	minimumGrade := string(models.CheckFixGradeB)
+	requirement := &models.Requirement{ID: primitive.NewObjectID(), SupplierID: supplierID, Type: models.RequirementTypeCheckFix, Status: models.RequirementStatusPending, MinimumGrade: &minimumGrade}
+
+	verifiedAt := time.Now().UTC().Add(-72 * time.Hour)
+	verifications := &latestVerificationRepo{latest: &models.CheckFixVerification{
+		ID: primitive.NewObjectID(), SupplierID: supplierID, OverallGrade: models.CheckFixGradeD, DomainMatch: true, VerificationValid: true,
+		ReportDate: verifiedAt, VerifiedAt: verifiedAt, ExpiresAt: verifiedAt.Add(models.VerificationValidityDuration),
+	}}
+	responses := &memoryResponseRepo{responses: map[primitive.ObjectID]*models.SupplierResponse{}}
+	requirements := &memoryRequirementRepo{requirements: map[primitive.ObjectID]*models.Requirement{requirement.ID: requirement}}
+	service := &checkFixService{verificationRepo: verifications, responseRepo: responses, requirementRepo: requirements}
+
+	// A grade below the minimum is refused with the reason and creates nothing
+	var shortfall *CheckFixShortfallError
+	if _, err := service.SatisfyFromLatest(ctx, requirement.ID, supplierID); !errors.As(err, &shortfall) {
+		t.Fatalf("Expected CheckFixShortfallError, got %v", err)
+	}
+	if len(responses.responses) != 0 || len(verifications.created) != 0 {
+		t.Errorf("Expected nothing to be created for a failing verification")
+	}
+
+	verifications.latest.OverallGrade = models.CheckFixGradeA
+	result, err := service.SatisfyFromLatest(ctx, requirement.ID, supplierID)
+	if err != nil {
+		t.Fatalf("SatisfyFromLatest failed: %v", err)
+	}
+	if !result.Passed || result.Requirement.Status != models.RequirementStatusSubmitted || !result.Response.IsSubmitted() {
+		t.Errorf("Expected a passed, submitted requirement, got %+v", result)
+	}
+	if len(verifications.created) != 1 {
+		t.Fatalf("Expected one verification copy, got %d", len(verifications.created))
+	}
+	copied := verifications.created[0]
+	if copied.ResponseID != result.Response.ID || copied.ID == verifications.latest.ID || !copied.VerifiedAt.Equal(verifiedAt) {
+		t.Errorf("Expected a copy for the response keeping the original verification date, got %+v", copied)
+	}
+
+	verifications.latest.ExpiresAt = time.Now().UTC().Add(-time.Hour)
+	if _, err := service.SatisfyFromLatest(ctx, requirement.ID, supplierID); !errors.Is(err, ErrNoValidVerification) {
+		t.Errorf("Expected ErrNoValidVerification for an expired verification, got %v", err)
+	}
+}
+
+func TestCheckFixService_RelinkNarrowsDomains(t *testing.T) {
+	ctx := context.Background()
+	org := &models.Organization{ID: primitive.NewObjectID(), Type: models.OrganizationTypeSupplier}
+	orgs := &memoryOrgRepoByID{orgs: map[primitive.ObjectID]*models.Organization{org.ID: org}}
+	client := &MockCheckFixAPIClient{MockDomain: "acme.com", MockDomains: []string{"acme.com", "acme.io"}}
+	service := &checkFixService{apiClient: client, orgRepo: orgs}
+
+	if err := service.LinkAccount(ctx, org.ID, "acct-acme"); err != nil {
+		t.Fatalf("LinkAccount failed: %v", err)
+	}
+	if _, err := service.AddDomain(ctx, org.ID, "acme.io"); err != nil {
+		t.Fatalf("AddDomain failed: %v", err)
+	}
+	org.Settings.SSOProvisionDomains = []string{"acme.io"}
+
+	// Unlinking drops every domain the account proved
+	if err := service.UnlinkAccount(ctx, org.ID); err != nil {
+		t.Fatalf("UnlinkAccount failed: %v", err)
+	}
+	if len(org.VerifiedDomains()) != 0 || len(org.Settings.SSOProvisionDomains) != 0 {
+		t.Errorf("Expected no domains after unlinking, got %v and SSO domains %v", org.VerifiedDomains(), org.Settings.SSOProvisionDomains)
+	}
+
+	// Relinking another account only brings that account's domains
+	client.MockDomain, client.MockDomains = "other.org", []string{"other.org"}
+	if err := service.LinkAccount(ctx, org.ID, "acct-other"); err != nil {
+		t.Fatalf("LinkAccount failed: %v", err)
+	}
+	if domains := org.VerifiedDomains(); len(domains) != 1 || domains[0] != "other.org" {
+		t.Errorf("Expected only other.org after relinking, got %v", domains)
+	}
+
+	// Linking over an existing link keeps only the domains both accounts verified
+	client.MockDomain, client.MockDomains = "acme.com", []string{"acme.com", "acme.io"}
+	if err := service.LinkAccount(ctx, org.ID, "acct-acme"); err != nil {
+		t.Fatalf("LinkAccount failed: %v", err)
+	}
+	if _, err := service.AddDomain(ctx, org.ID, "acme.io"); err != nil {
+		t.Fatalf("AddDomain failed: %v", err)
+	}
+	client.MockDomain, client.MockDomains = "other.org", []string{"other.org", "acme.io"}
+	if err := service.LinkAccount(ctx, org.ID, "acct-other"); err != nil {
+		t.Fatalf("LinkAccount failed: %v", err)
+	}
+	if _, ok := org.MatchDomain("acme.com"); ok {
+		t.Error("Expected acme.com to be dropped when the new account has not verified it")
+	}
+	if _, ok := org.MatchDomain("acme.io"); !ok {
+		t.Error("Expected acme.io to be kept since the new account verified it")
+	}
+}
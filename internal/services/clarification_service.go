@@ -0,0 +1,235 @@
+// Package services provides business logic implementations.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// ErrInvalidClarification is returned for an empty or overlong clarification message
+var ErrInvalidClarification = errors.New("clarification message is required and must not exceed 2000 characters")
+
+// maxClarificationLength bounds a clarification message in characters
+const maxClarificationLength = 2000
+
+// ClarificationService handles the per-question threads between a supplier and the requesting company
+// #INTEGRATION_POINT: Used by the supplier portal to ask about a question and by companies to answer
+type ClarificationService interface {
+	// Ask adds a supplier question about a questionnaire question to the response's thread
+	Ask(ctx context.Context, responseID, supplierID, authorID primitive.ObjectID, questionID, message string) (*models.Clarification, error)
+
+	// Respond adds a company answer to the thread about a question of the requirement's response
+	Respond(ctx context.Context, requirementID, companyID, authorID primitive.ObjectID, questionID, message string) (*models.Clarification, error)
+
+	// ListForSupplier returns the thread about a question of one of the supplier's responses
+	ListForSupplier(ctx context.Context, responseID, supplierID primitive.ObjectID, questionID string) ([]models.Clarification, error)
+
+	// ListForCompany returns the thread about a question of the requirement's response
+	ListForCompany(ctx context.Context, requirementID, companyID primitive.ObjectID, questionID string) ([]models.Clarification, error)
+}
+
+// clarificationService implements ClarificationService
+type clarificationService struct {
+	responseRepo     repository.ResponseRepository
+	requirementRepo  repository.RequirementRepository
+	relationshipRepo repository.RelationshipRepository
+	questionRepo     repository.QuestionRepository
+}
+
+// NewClarificationService creates a new clarification service
+func NewClarificationService(
+	responseRepo repository.ResponseRepository,
+	requirementRepo repository.RequirementRepository,
+	relationshipRepo repository.RelationshipRepository,
+	questionRepo repository.QuestionRepository,
+) ClarificationService {
+	return &clarificationService{
+		responseRepo:     responseRepo,
+		requirementRepo:  requirementRepo,
+		relationshipRepo: relationshipRepo,
+		questionRepo:     questionRepo,
+	}
+}
+
+// clarificationThread is a response with the requirement and relationship it belongs to
+type clarificationThread struct {
+	response     *models.SupplierResponse
+	requirement  *models.Requirement
+	relationship *models.CompanySupplierRelationship
+	questionID   primitive.ObjectID
+}
+
+// Ask adds a supplier question about a questionnaire question to the response's thread
+// #BUSINESS_RULE: Questions can be asked until the requirement is approved, also after submission,
+// since a submitted response may still be sent back for revision
+func (s *clarificationService) Ask(ctx context.Context, responseID, supplierID, authorID primitive.ObjectID, questionID, message string) (*models.Clarification, error) {
+	response, err := s.responseRepo.GetByID(ctx, responseID)
+	if err != nil {
+		if errors.Is(err, models.ErrResponseNotFound) {
+			return nil, ErrResponseNotFound
+		}
+		return nil, fmt.Errorf("failed to get response: %w", err)
+	}
+	if response.SupplierID != supplierID {
+		return nil, ErrResponseNotFound
+	}
+
+	thread, err := s.loadThread(ctx, response, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if thread.requirement.SupplierID != supplierID || thread.relationship.SupplierID == nil || *thread.relationship.SupplierID != supplierID {
+		return nil, ErrResponseNotFound
+	}
+
+	return s.add(ctx, thread, authorID, true, message)
+}
+
+// Respond adds a company answer to the thread about a question of the requirement's response
+func (s *clarificationService) Respond(ctx context.Context, requirementID, companyID, authorID primitive.ObjectID, questionID, message string) (*models.Clarification, error) {
+	thread, err := s.loadCompanyThread(ctx, requirementID, companyID, questionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.add(ctx, thread, authorID, false, message)
+}
+
+// ListForSupplier returns the thread about a question of one of the supplier's responses
+func (s *clarificationService) ListForSupplier(ctx context.Context, responseID, supplierID primitive.ObjectID, questionID string) ([]models.Clarification, error) {
+	response, err := s.responseRepo.GetByID(ctx, responseID)
+	if err != nil {
+		if errors.Is(err, models.ErrResponseNotFound) {
+			return nil, ErrResponseNotFound
+		}
+		return nil, fmt.Errorf("failed to get response: %w", err)
+	}
+	if response.SupplierID != supplierID {
+		return nil, ErrResponseNotFound
+	}
+
+	thread, err := s.loadThread(ctx, response, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if thread.requirement.SupplierID != supplierID || thread.relationship.SupplierID == nil || *thread.relationship.SupplierID != supplierID {
+		return nil, ErrResponseNotFound
+	}
+
+	return response.ClarificationsFor(thread.questionID), nil
+}
+
+// ListForCompany returns the thread about a question of the requirement's response
+func (s *clarificationService) ListForCompany(ctx context.Context, requirementID, companyID primitive.ObjectID, questionID string) ([]models.Clarification, error) {
+	thread, err := s.loadCompanyThread(ctx, requirementID, companyID, questionID)
+	if err != nil {
+		return nil, err
+	}
+	return thread.response.ClarificationsFor(thread.questionID), nil
+}
+
+// loadCompanyThread loads the thread of a company's requirement
+// #SECURITY_ASSUMPTION: Both the requirement and its relationship must belong to the company;
+// anything else is reported as not found
+func (s *clarificationService) loadCompanyThread(ctx context.Context, requirementID, companyID primitive.ObjectID, questionID string) (*clarificationThread, error) {
+	response, err := s.responseRepo.GetByRequirement(ctx, requirementID)
+	if err != nil {
+		if errors.Is(err, models.ErrResponseNotFound) {
+			return nil, ErrResponseNotFound
+		}
+		return nil, fmt.Errorf("failed to get response: %w", err)
+	}
+
+	thread, err := s.loadThread(ctx, response, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if thread.requirement.CompanyID != companyID || thread.relationship.CompanyID != companyID {
+		return nil, ErrResponseNotFound
+	}
+	return thread, nil
+}
+
+// loadThread loads the requirement and relationship of a response and checks that the question
+// belongs to the requirement's questionnaire
+func (s *clarificationService) loadThread(ctx context.Context, response *models.SupplierResponse, questionID string) (*clarificationThread, error) {
+	requirement, err := s.requirementRepo.GetByID(ctx, response.RequirementID)
+	if err != nil {
+		if errors.Is(err, models.ErrRequirementNotFound) {
+			return nil, ErrResponseNotFound
+		}
+		return nil, fmt.Errorf("failed to get requirement: %w", err)
+	}
+	if !requirement.IsQuestionnaireRequirement() || requirement.QuestionnaireID == nil {
+		return nil, ErrNotQuestionnaire
+	}
+
+	relationship, err := s.relationshipRepo.GetByID(ctx, requirement.RelationshipID)
+	if err != nil {
+		if errors.Is(err, models.ErrRelationshipNotFound) {
+			return nil, ErrResponseNotFound
+		}
+		return nil, fmt.Errorf("failed to get relationship: %w", err)
+	}
+
+	qID, err := primitive.ObjectIDFromHex(questionID)
+	if err != nil {
+		return nil, ErrQuestionNotFound
+	}
+	question, err := s.questionRepo.GetByID(ctx, qID)
+	if err != nil {
+		if errors.Is(err, models.ErrQuestionNotFound) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	if question.QuestionnaireID != *requirement.QuestionnaireID {
+		return nil, ErrQuestionNotFound
+	}
+
+	return &clarificationThread{
+		response:     response,
+		requirement:  requirement,
+		relationship: relationship,
+		questionID:   qID,
+	}, nil
+}
+
+// add appends a message to a loaded thread
+// #BUSINESS_RULE: Threads only take new messages while the relationship is active and the
+// requirement is not approved; existing threads stay readable
+func (s *clarificationService) add(ctx context.Context, thread *clarificationThread, authorID primitive.ObjectID, fromSupplier bool, message string) (*models.Clarification, error) {
+	message = strings.TrimSpace(message)
+	if message == "" || len([]rune(message)) > maxClarificationLength {
+		return nil, ErrInvalidClarification
+	}
+	if !thread.relationship.IsActive() {
+		return nil, ErrRelationshipNotActive
+	}
+	if thread.requirement.IsApproved() {
+		return nil, ErrRequirementApproved
+	}
+
+	clarification := models.Clarification{
+		QuestionID:   thread.questionID,
+		FromSupplier: fromSupplier,
+		Message:      message,
+		AuthorID:     authorID,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if err := s.responseRepo.AddClarification(ctx, thread.response.ID, clarification); err != nil {
+		if errors.Is(err, models.ErrResponseNotFound) {
+			return nil, ErrResponseNotFound
+		}
+		return nil, fmt.Errorf("failed to add clarification: %w", err)
+	}
+
+	return &clarification, nil
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+func (r *memoryResponseRepo) AddClarification(_ context.Context, responseID primitive.ObjectID, clarification models.Clarification) error {
+	for _, response := range r.responses {
+		if response.ID == responseID {
+			response.Clarifications = append(response.Clarifications, clarification)
+			return nil
+		}
+	}
+	return models.ErrResponseNotFound
+}
+
+func TestClarificationService_Thread(t *testing.T) {
+	ctx := context.Background()
+	companyID, supplierID, questionnaireID := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	supplierUser, companyUser := primitive.NewObjectID(), primitive.NewObjectID()
+	question := models.Question{ID: primitive.NewObjectID(), QuestionnaireID: questionnaireID}
+	foreignQuestion := models.Question{ID: primitive.NewObjectID(), QuestionnaireID: primitive.NewObjectID()}
+
+	relationship := &models.CompanySupplierRelationship{ID: primitive.NewObjectID(), CompanyID: companyID, SupplierID: &supplierID, Status: models.RelationshipStatusActive}
+	requirement := &models.Requirement{
+		ID: primitive.NewObjectID(), RelationshipID: relationship.ID, CompanyID: companyID, SupplierID: supplierID,
+		Type: models.RequirementTypeQuestionnaire, Status: models.RequirementStatusInProgress, QuestionnaireID: &questionnaireID,
+	}
+	response := &models.SupplierResponse{ID: primitive.NewObjectID(), RequirementID: requirement.ID, SupplierID: supplierID}
+
+	service := NewClarificationService(
+		&memoryResponseRepo{responses: map[primitive.ObjectID]*models.SupplierResponse{requirement.ID: response}},
+		&memoryRequirementRepo{requirements: map[primitive.ObjectID]*models.Requirement{requirement.ID: requirement}},
+		&memoryRelationshipRepo{relationships: map[primitive.ObjectID]*models.CompanySupplierRelationship{relationship.ID: relationship}},
+		&memoryQuestionRepo{questions: map[primitive.ObjectID]models.Question{question.ID: question, foreignQuestion.ID: foreignQuestion}},
+	)
+
+	if _, err := service.Ask(ctx, response.ID, supplierID, supplierUser, question.ID.Hex(), " What counts as MFA? "); err != nil {
+		t.Fatalf("Ask failed: %v", err)
+	}
+	if _, err := service.Respond(ctx, requirement.ID, companyID, companyUser, question.ID.Hex(), "Any second factor."); err != nil {
+		t.Fatalf("Respond failed: %v", err)
+	}
+
+	thread, err := service.ListForSupplier(ctx, response.ID, supplierID, question.ID.Hex())
+	if err != nil {
+		t.Fatalf("ListForSupplier failed: %v", err)
+	}
+	if len(thread) != 2 || !thread[0].FromSupplier || thread[0].Message != "What counts as MFA?" || thread[1].FromSupplier {
+		t.Errorf("Expected the supplier question then the company answer, got %+v", thread)
+	}
+
+	// Other organizations cannot reach the thread
+	if _, err := service.ListForSupplier(ctx, response.ID, primitive.NewObjectID(), question.ID.Hex()); !errors.Is(err, ErrResponseNotFound) {
+		t.Errorf("Expected ErrResponseNotFound for another supplier, got %v", err)
+	}
+	if _, err := service.Respond(ctx, requirement.ID, primitive.NewObjectID(), companyUser, question.ID.Hex(), "Hi"); !errors.Is(err, ErrResponseNotFound) {
+		t.Errorf("Expected ErrResponseNotFound for another company, got %v", err)
+	}
+	if _, err := service.Ask(ctx, response.ID, supplierID, supplierUser, foreignQuestion.ID.Hex(), "Hi"); !errors.Is(err, ErrQuestionNotFound) {
+		t.Errorf("Expected ErrQuestionNotFound for a question of another questionnaire, got %v", err)
+	}
+
+	// A suspended relationship keeps the thread readable but closed
+	relationship.Status = models.RelationshipStatusSuspended
+	if _, err := service.Ask(ctx, response.ID, supplierID, supplierUser, question.ID.Hex(), "Still there?"); !errors.Is(err, ErrRelationshipNotActive) {
+		t.Errorf("Expected ErrRelationshipNotActive, got %v", err)
+	}
+	if thread, err := service.ListForCompany(ctx, requirement.ID, companyID, question.ID.Hex()); err != nil || len(thread) != 2 {
+		t.Errorf("Expected the thread to stay readable, got %v and %v", thread, err)
+	}
+}
@@ -0,0 +1,105 @@
+// Package services provides business logic implementations.
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// DashboardListLimit bounds the lists shown on the company dashboard
+const DashboardListLimit = 5
+
+// DashboardService builds the company dashboard
+// #INTEGRATION_POINT: Company counterpart of the supplier portal dashboard
+type DashboardService interface {
+	// GetCompanyDashboard returns the supplier and requirement overview of a company
+	GetCompanyDashboard(ctx context.Context, companyID primitive.ObjectID) (*CompanyDashboard, error)
+}
+
+// CompanyDashboard is the overview of a company's suppliers and requirements
+type CompanyDashboard struct {
+	Suppliers    SupplierBreakdown
+	Requirements RequirementBreakdown
+
+	// AwaitingReview are submitted requirements, longest waiting first
+	AwaitingReview []models.Requirement
+
+	// TopOutstanding are the suppliers with the most requirements awaiting their action
+	TopOutstanding []repository.SupplierOutstandingCount
+}
+
+// SupplierBreakdown counts a company's supplier relationships
+type SupplierBreakdown struct {
+	Total            int64
+	ByStatus         map[models.RelationshipStatus]int64
+	ByClassification map[models.SupplierClassification]int64
+}
+
+// RequirementBreakdown counts a company's requirements
+type RequirementBreakdown struct {
+	Total    int64
+	ByStatus map[models.RequirementStatus]int64
+	Overdue  int64
+}
+
+// dashboardService implements DashboardService
+type dashboardService struct {
+	relationshipRepo repository.RelationshipRepository
+	requirementRepo  repository.RequirementRepository
+}
+
+// NewDashboardService creates a new dashboard service
+func NewDashboardService(
+	relationshipRepo repository.RelationshipRepository,
+	requirementRepo repository.RequirementRepository,
+) DashboardService {
+	return &dashboardService{
+		relationshipRepo: relationshipRepo,
+		requirementRepo:  requirementRepo,
+	}
+}
+
+// GetCompanyDashboard returns the supplier and requirement overview of a company
+// #QUERY_PATTERN: Two aggregations - one over relationships, one $facet over requirements
+func (s *dashboardService) GetCompanyDashboard(ctx context.Context, companyID primitive.ObjectID) (*CompanyDashboard, error) {
+	groups, err := s.relationshipRepo.CountByStatusAndClassification(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count suppliers: %w", err)
+	}
+
+	overview, err := s.requirementRepo.GetCompanyOverview(ctx, companyID, DashboardListLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize requirements: %w", err)
+	}
+
+	dashboard := &CompanyDashboard{
+		Suppliers: SupplierBreakdown{
+			ByStatus:         make(map[models.RelationshipStatus]int64),
+			ByClassification: make(map[models.SupplierClassification]int64),
+		},
+		Requirements: RequirementBreakdown{
+			ByStatus: overview.StatusCounts,
+			Overdue:  overview.Overdue,
+		},
+		AwaitingReview: overview.AwaitingReview,
+		TopOutstanding: overview.TopOutstanding,
+	}
+
+	for _, g := range groups {
+		dashboard.Suppliers.Total += g.Count
+		dashboard.Suppliers.ByStatus[g.Status] += g.Count
+		if g.Classification != "" {
+			dashboard.Suppliers.ByClassification[g.Classification] += g.Count
+		}
+	}
+	for _, count := range overview.StatusCounts {
+		dashboard.Requirements.Total += count
+	}
+
+	return dashboard, nil
+}
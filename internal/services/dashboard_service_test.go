@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// groupCountRelationshipRepo returns fixed relationship group counts
+type groupCountRelationshipRepo struct {
+	repository.RelationshipRepository
+	groups []repository.RelationshipGroupCount
+}
+
+func (r *groupCountRelationshipRepo) CountByStatusAndClassification(_ context.Context, _ primitive.ObjectID) ([]repository.RelationshipGroupCount, error) {
+	return r.groups, nil
+}
+
+// overviewRequirementRepo returns a fixed requirement overview
+type overviewRequirementRepo struct {
+	repository.RequirementRepository
+	overview *repository.CompanyRequirementOverview
+}
+
+func (r *overviewRequirementRepo) GetCompanyOverview(_ context.Context, _ primitive.ObjectID, _ int) (*repository.CompanyRequirementOverview, error) {
+	return r.overview, nil
+}
+
+func TestDashboardService_GetCompanyDashboard(t *testing.T) {
+	relationships := &groupCountRelationshipRepo{groups: []repository.RelationshipGroupCount{
+		{Status: models.RelationshipStatusActive, Classification: models.SupplierClassificationCritical, Count: 3},
+		{Status: models.RelationshipStatusActive, Classification: models.SupplierClassificationStandard, Count: 2},
+		{Status: models.RelationshipStatusPending, Classification: models.SupplierClassificationCritical, Count: 1},
+	}}
+	requirements := &overviewRequirementRepo{overview: &repository.CompanyRequirementOverview{
+		StatusCounts: map[models.RequirementStatus]int64{
+			models.RequirementStatusPending:   4,
+			models.RequirementStatusSubmitted: 2,
+		},
+		Overdue: 1,
+	}}
+
+	dashboard, err := NewDashboardService(relationships, requirements).GetCompanyDashboard(context.Background(), primitive.NewObjectID())
+	if err != nil {
+		t.Fatalf("GetCompanyDashboard: %v", err)
+	}
+
+	if dashboard.Suppliers.Total != 6 {
+		t.Errorf("expected 6 suppliers, got %d", dashboard.Suppliers.Total)
+	}
+	if got := dashboard.Suppliers.ByStatus[models.RelationshipStatusActive]; got != 5 {
+		t.Errorf("expected 5 active suppliers, got %d", got)
+	}
+	if got := dashboard.Suppliers.ByClassification[models.SupplierClassificationCritical]; got != 4 {
+		t.Errorf("expected 4 critical suppliers, got %d", got)
+	}
+	if dashboard.Requirements.Total != 6 || dashboard.Requirements.Overdue != 1 {
+		t.Errorf("unexpected requirement breakdown %+v", dashboard.Requirements)
+	}
+}
@@ -0,0 +1,226 @@
+// Package services provides business logic implementations.
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// Outbox delivery policy
+const (
+	// MaxOutboxAttempts is how many times an email is tried before it is marked failed
+	MaxOutboxAttempts = 8
+
+	// outboxBaseBackoff doubles after each failed attempt, capped at outboxMaxBackoff
+	outboxBaseBackoff = time.Minute
+	outboxMaxBackoff  = 6 * time.Hour
+
+	// outboxClaimLease keeps other workers off an email while it is being sent
+	outboxClaimLease = 5 * time.Minute
+
+	// outboxBatchSize bounds the emails sent per worker run
+	outboxBatchSize = 100
+)
+
+// EmailOutbox queues emails for delivery by the outbox worker
+// #INTEGRATION_POINT: Used by services whose emails must survive mail API outages
+type EmailOutbox interface {
+	// EnqueueInvitation queues a supplier invitation on behalf of the inviting organization
+	EnqueueInvitation(ctx context.Context, orgID primitive.ObjectID, email, companyName, inviteLink, language string) error
+
+	// EnqueueCheckFixRefreshNeeded queues a CheckFix refresh notice for a supplier organization
+	EnqueueCheckFixRefreshNeeded(ctx context.Context, orgID primitive.ObjectID, email, supplierName, domain, language string) error
+
+	// EnqueueTeamInvitation queues an invitation to join an organization's team
+	EnqueueTeamInvitation(ctx context.Context, orgID primitive.ObjectID, email, name, organizationName, magicLink, language string) error
+
+	// EnqueueEmailChangeNotice queues the notice to a user's former address that their email changed
+	EnqueueEmailChangeNotice(ctx context.Context, orgID primitive.ObjectID, oldEmail, name, newEmail, language string) error
+}
+
+// EmailOutboxService queues emails and delivers them with retries
+// #INTEGRATION_POINT: ProcessDue runs as a scheduler job; ListFailed and ListSent back the admin email views
+type EmailOutboxService interface {
+	EmailOutbox
+
+	// ProcessDue sends due emails, rescheduling or failing those the mail API rejects
+	ProcessDue(ctx context.Context) (*OutboxRunSummary, error)
+
+	// ListFailed lists permanently failed emails of an organization
+	ListFailed(ctx context.Context, orgID primitive.ObjectID, opts repository.PaginationOptions) (*repository.PaginatedResult[models.OutboxEmail], error)
+
+	// ListSent lists the send attempts of an organization's emails, optionally for one recipient
+	ListSent(ctx context.Context, orgID primitive.ObjectID, recipient string, opts repository.PaginationOptions) (*repository.PaginatedResult[models.SentEmail], error)
+}
+
+// OutboxRunSummary reports the outcome of one outbox worker run
+type OutboxRunSummary struct {
+	Sent    int `json:"sent"`
+	Retried int `json:"retried"`
+	Failed  int `json:"failed"`
+}
+
+// emailOutboxService implements EmailOutboxService
+type emailOutboxService struct {
+	outboxRepo    repository.OutboxRepository
+	sentEmailRepo repository.SentEmailRepository
+	mailService   MailService
+}
+
+// NewEmailOutboxService creates a new email outbox service
+func NewEmailOutboxService(outboxRepo repository.OutboxRepository, sentEmailRepo repository.SentEmailRepository, mailService MailService) EmailOutboxService {
+	return &emailOutboxService{
+		outboxRepo:    outboxRepo,
+		sentEmailRepo: sentEmailRepo,
+		mailService:   mailService,
+	}
+}
+
+// EnqueueInvitation queues a supplier invitation on behalf of the inviting organization
+func (s *emailOutboxService) EnqueueInvitation(ctx context.Context, orgID primitive.ObjectID, email, companyName, inviteLink, language string) error {
+	return s.enqueue(ctx, orgID, models.OutboxEmailKindInvitation, email, language, map[string]string{
+		"company_name": companyName,
+		"invite_link":  inviteLink,
+	})
+}
+
+// EnqueueCheckFixRefreshNeeded queues a CheckFix refresh notice for a supplier organization
+func (s *emailOutboxService) EnqueueCheckFixRefreshNeeded(ctx context.Context, orgID primitive.ObjectID, email, supplierName, domain, language string) error {
+	return s.enqueue(ctx, orgID, models.OutboxEmailKindCheckFixRefresh, email, language, map[string]string{
+		"supplier_name": supplierName,
+		"domain":        domain,
+	})
+}
+
+// EnqueueTeamInvitation queues an invitation to join an organization's team
+func (s *emailOutboxService) EnqueueTeamInvitation(ctx context.Context, orgID primitive.ObjectID, email, name, organizationName, magicLink, language string) error {
+	return s.enqueue(ctx, orgID, models.OutboxEmailKindTeamInvitation, email, language, map[string]string{
+		"name":              name,
+		"organization_name": organizationName,
+		"magic_link":        magicLink,
+	})
+}
+
+// EnqueueEmailChangeNotice queues the notice to a user's former address that their email changed
+func (s *emailOutboxService) EnqueueEmailChangeNotice(ctx context.Context, orgID primitive.ObjectID, oldEmail, name, newEmail, language string) error {
+	return s.enqueue(ctx, orgID, models.OutboxEmailKindEmailChangeNotice, oldEmail, language, map[string]string{
+		"name":      name,
+		"new_email": newEmail,
+	})
+}
+
+// enqueue stores an email for the worker
+func (s *emailOutboxService) enqueue(ctx context.Context, orgID primitive.ObjectID, kind models.OutboxEmailKind, recipient, language string, params map[string]string) error {
+	email := &models.OutboxEmail{
+		OrganizationID: orgID,
+		Kind:           kind,
+		Recipient:      recipient,
+		Language:       language,
+		Params:         params,
+	}
+	if err := s.outboxRepo.Create(ctx, email); err != nil {
+		return fmt.Errorf("failed to queue %s email: %w", kind, err)
+	}
+	return nil
+}
+
+// ProcessDue sends due emails, rescheduling or failing those the mail API rejects
+// #BUSINESS_RULE: Failed attempts back off exponentially; after MaxOutboxAttempts the email is
+// marked failed and only surfaces in the failed-emails view
+func (s *emailOutboxService) ProcessDue(ctx context.Context) (*OutboxRunSummary, error) {
+	summary := &OutboxRunSummary{}
+
+	for i := 0; i < outboxBatchSize; i++ {
+		if ctx.Err() != nil {
+			return summary, ctx.Err()
+		}
+
+		now := time.Now().UTC()
+		email, err := s.outboxRepo.ClaimDue(ctx, now, outboxClaimLease)
+		if err != nil {
+			return summary, fmt.Errorf("failed to claim outbox email: %w", err)
+		}
+		if email == nil {
+			break
+		}
+
+		providerMessageID, sendErr := s.send(ctx, email)
+		if sendErr == nil {
+			if err := s.outboxRepo.MarkSent(ctx, email.ID, providerMessageID); err != nil {
+				log.Printf("Failed to mark outbox email %s sent: %v", email.ID.Hex(), err)
+			}
+			summary.Sent++
+			continue
+		}
+
+		var nextAttemptAt *time.Time
+		if email.Attempts < MaxOutboxAttempts {
+			next := now.Add(outboxBackoff(email.Attempts))
+			nextAttemptAt = &next
+			summary.Retried++
+		} else {
+			log.Printf("Outbox email %s to %s failed permanently after %d attempts: %v",
+				email.ID.Hex(), email.Recipient, email.Attempts, sendErr)
+			summary.Failed++
+		}
+		if err := s.outboxRepo.RecordFailure(ctx, email.ID, sendErr.Error(), nextAttemptAt); err != nil {
+			log.Printf("Failed to record outbox failure for %s: %v", email.ID.Hex(), err)
+		}
+	}
+
+	return summary, nil
+}
+
+// send delivers a queued email through the mail service and returns the provider message ID
+// #INTEGRATION_POINT: The mail origin ties each send record to the outbox email and attempt
+func (s *emailOutboxService) send(ctx context.Context, email *models.OutboxEmail) (string, error) {
+	outboxEmailID := email.ID
+	ctx = WithMailOrigin(ctx, MailOrigin{
+		OrganizationID: email.OrganizationID,
+		OutboxEmailID:  &outboxEmailID,
+		Attempt:        email.Attempts,
+	})
+
+	p := email.Params
+	switch email.Kind {
+	case models.OutboxEmailKindInvitation:
+		return s.mailService.SendInvitation(ctx, email.Recipient, p["company_name"], p["invite_link"], email.Language)
+	case models.OutboxEmailKindCheckFixRefresh:
+		return s.mailService.SendCheckFixRefreshNeeded(ctx, email.Recipient, p["supplier_name"], p["domain"], email.Language)
+	case models.OutboxEmailKindTeamInvitation:
+		return s.mailService.SendTeamInvitation(ctx, email.Recipient, p["name"], p["organization_name"], p["magic_link"], email.Language)
+	case models.OutboxEmailKindEmailChangeNotice:
+		return s.mailService.SendEmailChangeNotice(ctx, email.Recipient, p["name"], p["new_email"], email.Language)
+	default:
+		return "", fmt.Errorf("unknown outbox email kind %q", email.Kind)
+	}
+}
+
+// outboxBackoff returns the wait after the given number of failed attempts
+func outboxBackoff(attempts int) time.Duration {
+	backoff := outboxBaseBackoff
+	for i := 1; i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > outboxMaxBackoff {
+		backoff = outboxMaxBackoff
+	}
+	return backoff
+}
+
+// ListFailed lists permanently failed emails of an organization
+func (s *emailOutboxService) ListFailed(ctx context.Context, orgID primitive.ObjectID, opts repository.PaginationOptions) (*repository.PaginatedResult[models.OutboxEmail], error) {
+	return s.outboxRepo.ListFailedByOrganization(ctx, orgID, opts)
+}
+
+// ListSent lists the send attempts of an organization's emails, optionally for one recipient
+func (s *emailOutboxService) ListSent(ctx context.Context, orgID primitive.ObjectID, recipient string, opts repository.PaginationOptions) (*repository.PaginatedResult[models.SentEmail], error) {
+	return s.sentEmailRepo.ListByOrganization(ctx, orgID, models.NormalizeEmail(recipient), opts)
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// memoryOutboxRepo keeps queued emails in memory
+type memoryOutboxRepo struct {
+	repository.OutboxRepository
+	emails []*models.OutboxEmail
+}
+
+func (r *memoryOutboxRepo) Create(_ context.Context, email *models.OutboxEmail) error {
+	email.BeforeCreate()
+	r.emails = append(r.emails, email)
+	return nil
+}
+
+func (r *memoryOutboxRepo) ClaimDue(_ context.Context, now time.Time, lease time.Duration) (*models.OutboxEmail, error) {
+	for _, e := range r.emails {
+		if e.Status == models.OutboxEmailStatusPending && !e.NextAttemptAt.After(now) {
+			e.Attempts++
+			e.NextAttemptAt = now.Add(lease)
+			claimed := *e
+			return &claimed, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryOutboxRepo) MarkSent(_ context.Context, id primitive.ObjectID, providerMessageID string) error {
+	for _, e := range r.emails {
+		if e.ID == id {
+			now := time.Now().UTC()
+			e.Status = models.OutboxEmailStatusSent
+			e.SentAt = &now
+			e.ProviderMessageID = providerMessageID
+		}
+	}
+	return nil
+}
+
+func (r *memoryOutboxRepo) RecordFailure(_ context.Context, id primitive.ObjectID, lastError string, nextAttemptAt *time.Time) error {
+	for _, e := range r.emails {
+		if e.ID != id {
+			continue
+		}
+		e.LastError = lastError
+		if nextAttemptAt == nil {
+			now := time.Now().UTC()
+			e.Status = models.OutboxEmailStatusFailed
+			e.FailedAt = &now
+		} else {
+			e.NextAttemptAt = *nextAttemptAt
+		}
+	}
+	return nil
+}
+
+// failingInvitationMail fails every invitation
+type failingInvitationMail struct {
+	MailService
+	calls int
+}
+
+func (m *failingInvitationMail) SendInvitation(_ context.Context, _, _, _, _ string) (string, error) {
+	m.calls++
+	return "", errors.New("mail API unavailable")
+}
+
+// acceptingRefreshMail accepts every CheckFix refresh notice and records the mail origins
+type acceptingRefreshMail struct {
+	MailService
+	origins []MailOrigin
+}
+
+func (m *acceptingRefreshMail) SendCheckFixRefreshNeeded(ctx context.Context, _, _, _, _ string) (string, error) {
+	m.origins = append(m.origins, mailOriginFrom(ctx))
+	return "reception-1", nil
+}
+
+func TestEmailOutboxService_RetriesThenFails(t *testing.T) {
+	repo := &memoryOutboxRepo{}
+	mail := &failingInvitationMail{}
+	svc := NewEmailOutboxService(repo, nil, mail)
+	ctx := context.Background()
+
+	if err := svc.EnqueueInvitation(ctx, primitive.NewObjectID(), "supplier@example.com", "Acme", "https://app/invite", "en"); err != nil {
+		t.Fatalf("EnqueueInvitation: %v", err)
+	}
+
+	for attempt := 1; attempt <= MaxOutboxAttempts; attempt++ {
+		// Make the email due again
+		repo.emails[0].NextAttemptAt = time.Now().UTC().Add(-time.Second)
+
+		summary, err := svc.ProcessDue(ctx)
+		if err != nil {
+			t.Fatalf("ProcessDue: %v", err)
+		}
+		if attempt < MaxOutboxAttempts && summary.Retried != 1 {
+			t.Fatalf("attempt %d: expected a retry, got %+v", attempt, summary)
+		}
+		if attempt == MaxOutboxAttempts && summary.Failed != 1 {
+			t.Fatalf("attempt %d: expected permanent failure, got %+v", attempt, summary)
+		}
+	}
+
+	email := repo.emails[0]
+	if email.Status != models.OutboxEmailStatusFailed || email.FailedAt == nil {
+		t.Errorf("expected email to be failed, got status %s", email.Status)
+	}
+	if email.LastError != "mail API unavailable" {
+		t.Errorf("unexpected last error %q", email.LastError)
+	}
+	if mail.calls != MaxOutboxAttempts {
+		t.Errorf("expected %d send attempts, got %d", MaxOutboxAttempts, mail.calls)
+	}
+
+	// A failed email is no longer retried
+	repo.emails[0].NextAttemptAt = time.Now().UTC().Add(-time.Second)
+	summary, _ := svc.ProcessDue(ctx)
+	if summary.Sent+summary.Retried+summary.Failed != 0 || mail.calls != MaxOutboxAttempts {
+		t.Errorf("failed email was retried: %+v", summary)
+	}
+}
+
+func TestEmailOutboxService_RecordsProviderMessageID(t *testing.T) {
+	repo := &memoryOutboxRepo{}
+	mail := &acceptingRefreshMail{}
+	svc := NewEmailOutboxService(repo, nil, mail)
+	ctx := context.Background()
+	orgID := primitive.NewObjectID()
+
+	if err := svc.EnqueueCheckFixRefreshNeeded(ctx, orgID, "supplier@example.com", "Acme", "acme.example", "en"); err != nil {
+		t.Fatalf("EnqueueCheckFixRefreshNeeded: %v", err)
+	}
+	if summary, err := svc.ProcessDue(ctx); err != nil || summary.Sent != 1 {
+		t.Fatalf("ProcessDue: %+v, %v", summary, err)
+	}
+
+	email := repo.emails[0]
+	if email.ProviderMessageID != "reception-1" {
+		t.Errorf("expected the provider message ID on the outbox email, got %q", email.ProviderMessageID)
+	}
+	// The send record must be attributable to the organization, outbox email and attempt
+	origin := mail.origins[0]
+	if origin.OrganizationID != orgID || origin.OutboxEmailID == nil || *origin.OutboxEmailID != email.ID || origin.Attempt != 1 {
+		t.Errorf("unexpected mail origin %+v", origin)
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	cases := map[int]time.Duration{
+		1:  time.Minute,
+		2:  2 * time.Minute,
+		4:  8 * time.Minute,
+		20: outboxMaxBackoff,
+	}
+	for attempts, want := range cases {
+		if got := outboxBackoff(attempts); got != want {
+			t.Errorf("outboxBackoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
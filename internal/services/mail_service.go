@@ -10,9 +10,14 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
 	"github.com/checkfix-tools/nisfix_backend/internal/config"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
 )
 
 // TemplateEmailRequest represents a template-based email request to mailsendAPI.
@@ -38,17 +43,45 @@ type MailErrorResponse struct {
 	Message string `json:"message"`
 }
 
+// MailOrigin identifies on whose behalf an email is sent, for the sent email records
+type MailOrigin struct {
+	OrganizationID primitive.ObjectID
+	OutboxEmailID  *primitive.ObjectID
+	Attempt        int
+}
+
+type mailOriginKey struct{}
+
+// WithMailOrigin returns a context carrying the origin of the emails sent with it
+func WithMailOrigin(ctx context.Context, origin MailOrigin) context.Context {
+	return context.WithValue(ctx, mailOriginKey{}, origin)
+}
+
+// mailOriginFrom returns the mail origin stored in ctx, if any
+func mailOriginFrom(ctx context.Context) MailOrigin {
+	origin, _ := ctx.Value(mailOriginKey{}).(MailOrigin)
+	return origin
+}
+
 // HTTPMailService implements MailService using HTTP calls to mailsendAPI.
 // #INTEGRATION_POINT: Real mail service for production
+// #INTEGRATION_POINT: Subject and body text are rendered locally from MailTemplates; the mailsendAPI
+// template only provides the layout and receives the text in the "body" variable
+// #INTEGRATION_POINT: Every send attempt is stored in sentEmailRepo when one is configured
 type HTTPMailService struct {
-	config *config.MailConfig
-	client *http.Client
+	config        *config.MailConfig
+	templates     *MailTemplates
+	sentEmailRepo repository.SentEmailRepository
+	client        *http.Client
 }
 
 // NewHTTPMailService creates a new HTTP mail service.
-func NewHTTPMailService(cfg *config.MailConfig) *HTTPMailService {
+// sentEmailRepo may be nil, in which case sends are not recorded.
+func NewHTTPMailService(cfg *config.MailConfig, templates *MailTemplates, sentEmailRepo repository.SentEmailRepository) *HTTPMailService {
 	return &HTTPMailService{
-		config: cfg,
+		config:        cfg,
+		templates:     templates,
+		sentEmailRepo: sentEmailRepo,
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -56,34 +89,150 @@ func NewHTTPMailService(cfg *config.MailConfig) *HTTPMailService {
 }
 
 // SendMagicLink sends a magic link email via mailsendAPI template.
-func (m *HTTPMailService) SendMagicLink(ctx context.Context, email, name, magicLink string) error {
-	// Default to English template
-	template := m.config.SecureLinkMailEN
-	subject := "Your NisFix Login Link"
-
+func (m *HTTPMailService) SendMagicLink(ctx context.Context, email, name, magicLink, language string) (string, error) {
 	variables := map[string]interface{}{
 		"secure_link": magicLink,
 	}
+	data := map[string]interface{}{
+		"Name": name,
+		"Link": magicLink,
+	}
 
-	return m.sendTemplateEmail(ctx, email, template, subject, variables)
+	layout := layoutTemplate(language, m.config.SecureLinkMailDE, m.config.SecureLinkMailEN)
+	return m.sendLocalizedEmail(ctx, email, language, MailMessageMagicLink, layout, data, variables)
 }
 
 // SendInvitation sends a supplier invitation email via mailsendAPI template.
-func (m *HTTPMailService) SendInvitation(ctx context.Context, email, companyName, inviteLink string) error {
-	// Default to English template
-	template := m.config.InviteSupplierEN
-	subject := fmt.Sprintf("%s has invited you to NisFix", companyName)
-
+func (m *HTTPMailService) SendInvitation(ctx context.Context, email, companyName, inviteLink, language string) (string, error) {
 	variables := map[string]interface{}{
 		"invite_link":  inviteLink,
 		"company_name": companyName,
 	}
+	data := map[string]interface{}{
+		"CompanyName": companyName,
+		"Link":        inviteLink,
+	}
+
+	layout := layoutTemplate(language, m.config.InviteSupplierDE, m.config.InviteSupplierEN)
+	return m.sendLocalizedEmail(ctx, email, language, MailMessageInvitation, layout, data, variables)
+}
 
-	return m.sendTemplateEmail(ctx, email, template, subject, variables)
+// SendTeamInvitation invites a new member of the sender's own organization via mailsendAPI template.
+// #IMPLEMENTATION_DECISION: The invitation is a sign-in link, so it shares the secure link layout
+func (m *HTTPMailService) SendTeamInvitation(ctx context.Context, email, name, organizationName, inviteLink, language string) (string, error) {
+	variables := map[string]interface{}{
+		"secure_link":       inviteLink,
+		"organization_name": organizationName,
+	}
+	data := map[string]interface{}{
+		"Name":             name,
+		"OrganizationName": organizationName,
+		"Link":             inviteLink,
+	}
+
+	layout := layoutTemplate(language, m.config.SecureLinkMailDE, m.config.SecureLinkMailEN)
+	return m.sendLocalizedEmail(ctx, email, language, MailMessageTeamInvitation, layout, data, variables)
+}
+
+// SendCheckFixRefreshNeeded asks a supplier to provide a current CheckFix report via mailsendAPI template.
+func (m *HTTPMailService) SendCheckFixRefreshNeeded(ctx context.Context, email, supplierName, domain, language string) (string, error) {
+	variables := map[string]interface{}{
+		"supplier_name": supplierName,
+		"domain":        domain,
+	}
+	data := map[string]interface{}{
+		"SupplierName": supplierName,
+		"Domain":       domain,
+	}
+
+	layout := layoutTemplate(language, m.config.CheckFixRefreshDE, m.config.CheckFixRefreshEN)
+	return m.sendLocalizedEmail(ctx, email, language, MailMessageCheckFixRefresh, layout, data, variables)
+}
+
+// SendEmailChangeVerification sends the confirmation link for a new email address via mailsendAPI template.
+// #IMPLEMENTATION_DECISION: Account security mails share the secure link layout
+func (m *HTTPMailService) SendEmailChangeVerification(ctx context.Context, email, name, verifyLink, language string) (string, error) {
+	variables := map[string]interface{}{
+		"secure_link": verifyLink,
+	}
+	data := map[string]interface{}{
+		"Name": name,
+		"Link": verifyLink,
+	}
+
+	layout := layoutTemplate(language, m.config.SecureLinkMailDE, m.config.SecureLinkMailEN)
+	return m.sendLocalizedEmail(ctx, email, language, MailMessageEmailChangeVerification, layout, data, variables)
+}
+
+// SendEmailChangeNotice tells the previous address that the account email was changed.
+func (m *HTTPMailService) SendEmailChangeNotice(ctx context.Context, email, name, newEmail, language string) (string, error) {
+	variables := map[string]interface{}{
+		"new_email": newEmail,
+	}
+	data := map[string]interface{}{
+		"Name":     name,
+		"NewEmail": newEmail,
+	}
+
+	layout := layoutTemplate(language, m.config.SecureLinkMailDE, m.config.SecureLinkMailEN)
+	return m.sendLocalizedEmail(ctx, email, language, MailMessageEmailChangeNotice, layout, data, variables)
+}
+
+// layoutTemplate picks the mailsendAPI layout for a language; only DE and EN layouts exist
+func layoutTemplate(language, de, en string) string {
+	if strings.EqualFold(language, "de") {
+		return de
+	}
+	return en
+}
+
+// sendLocalizedEmail renders a message in the recipient's language, sends it with the given layout
+// and records the attempt. It returns the provider message ID.
+func (m *HTTPMailService) sendLocalizedEmail(ctx context.Context, recipient, language, message, layout string, data, variables map[string]interface{}) (string, error) {
+	subject, body, err := m.templates.Render(language, message, data)
+	if err != nil {
+		return "", err
+	}
+	variables["body"] = body
+
+	receptionID, err := m.sendTemplateEmail(ctx, recipient, layout, subject, variables)
+	m.recordSend(ctx, recipient, message, receptionID, err)
+	return receptionID, err
+}
+
+// recordSend stores the outcome of a send attempt
+// #IMPLEMENTATION_DECISION: Recording is best-effort; a lost record must not fail or repeat the send
+func (m *HTTPMailService) recordSend(ctx context.Context, recipient, message, receptionID string, sendErr error) {
+	if m.sentEmailRepo == nil {
+		return
+	}
+
+	origin := mailOriginFrom(ctx)
+	record := &models.SentEmail{
+		Kind:              message,
+		Recipient:         recipient,
+		Status:            models.SentEmailStatusAccepted,
+		ProviderMessageID: receptionID,
+		OutboxEmailID:     origin.OutboxEmailID,
+		Attempt:           origin.Attempt,
+	}
+	if !origin.OrganizationID.IsZero() {
+		orgID := origin.OrganizationID
+		record.OrganizationID = &orgID
+	}
+	if sendErr != nil {
+		record.Status = models.SentEmailStatusFailed
+		record.Error = sendErr.Error()
+	}
+
+	// The request context may already be done once the provider answered
+	if err := m.sentEmailRepo.Create(context.WithoutCancel(ctx), record); err != nil {
+		log.Printf("[MAIL] Failed to record sent email to %s: %v", recipient, err)
+	}
 }
 
-// sendTemplateEmail sends a template-based email to mailsendAPI.
-func (m *HTTPMailService) sendTemplateEmail(ctx context.Context, recipient, template, subject string, variables map[string]interface{}) error {
+// sendTemplateEmail sends a template-based email to mailsendAPI and returns its reception ID.
+func (m *HTTPMailService) sendTemplateEmail(ctx context.Context, recipient, template, subject string, variables map[string]interface{}) (string, error) {
 	req := TemplateEmailRequest{
 		Recipient:  recipient,
 		Subject:    subject,
@@ -97,12 +246,12 @@ func (m *HTTPMailService) sendTemplateEmail(ctx context.Context, recipient, temp
 
 	reqBody, err := json.Marshal(req)
 	if err != nil {
-		return fmt.Errorf("failed to marshal email request: %w", err)
+		return "", fmt.Errorf("failed to marshal email request: %w", err)
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBody))
 	if err != nil {
-		return fmt.Errorf("failed to create HTTP request: %w", err)
+		return "", fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
@@ -113,7 +262,7 @@ func (m *HTTPMailService) sendTemplateEmail(ctx context.Context, recipient, temp
 	resp, err := m.client.Do(httpReq)
 	if err != nil {
 		log.Printf("[MAIL] HTTP request failed: %v", err)
-		return fmt.Errorf("mail API request failed: %w", err)
+		return "", fmt.Errorf("mail API request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -124,20 +273,20 @@ func (m *HTTPMailService) sendTemplateEmail(ctx context.Context, recipient, temp
 		var errorResp MailErrorResponse
 		if err := json.Unmarshal(bodyBytes, &errorResp); err == nil {
 			log.Printf("[MAIL] API error (status %d): %s - %s", resp.StatusCode, errorResp.Error, errorResp.Message)
-			return fmt.Errorf("mail API error: %s - %s", errorResp.Error, errorResp.Message)
+			return "", fmt.Errorf("mail API error: %s - %s", errorResp.Error, errorResp.Message)
 		}
 
 		log.Printf("[MAIL] API error (status %d): %s", resp.StatusCode, string(bodyBytes))
-		return fmt.Errorf("mail API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return "", fmt.Errorf("mail API returned status %d: %s", resp.StatusCode, string(bodyBytes))
 	}
 
 	var emailResp EmailResponse
 	if err := json.NewDecoder(resp.Body).Decode(&emailResp); err != nil {
 		log.Printf("[MAIL] Failed to decode success response: %v", err)
-		return fmt.Errorf("failed to decode mail API response: %w", err)
+		return "", fmt.Errorf("failed to decode mail API response: %w", err)
 	}
 
 	log.Printf("[MAIL] Email sent successfully: recipient=%s, reception_id=%s", recipient, emailResp.ReceptionID)
-	return nil
+	return emailResp.ReceptionID, nil
 }
 
@@ -0,0 +1,114 @@
+// Package services provides business logic implementations.
+package services
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+)
+
+// Mail message keys shared by every locale file
+const (
+	MailMessageMagicLink               = "magic_link"
+	MailMessageInvitation              = "invitation"
+	MailMessageTeamInvitation          = "team_invitation"
+	MailMessageCheckFixRefresh         = "checkfix_refresh"
+	MailMessageEmailChangeVerification = "email_change_verification"
+	MailMessageEmailChangeNotice       = "email_change_notice"
+)
+
+// DefaultMailLocale is used when the recipient's language has no translation
+const DefaultMailLocale = "en"
+
+// EmbeddedMailTemplates holds the translations shipped with the binary, one <locale>.json per language
+//
+//go:embed mailtemplates/*.json
+var EmbeddedMailTemplates embed.FS
+
+// mailMessageSource is a single message as stored in a locale file
+type mailMessageSource struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// mailMessage is a parsed localized message
+type mailMessage struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// MailTemplates renders localized email subjects and bodies
+// #IMPLEMENTATION_DECISION: Translations are data files so a new language is a new JSON file;
+// missing locales and missing messages fall back to English
+type MailTemplates struct {
+	locales map[string]map[string]mailMessage
+}
+
+// LoadMailTemplates parses every <locale>.json file found in the mailtemplates directory of fsys
+func LoadMailTemplates(fsys fs.FS) (*MailTemplates, error) {
+	files, err := fs.Glob(fsys, "mailtemplates/*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mail templates: %w", err)
+	}
+
+	t := &MailTemplates{locales: make(map[string]map[string]mailMessage, len(files))}
+	for _, file := range files {
+		locale := strings.ToLower(strings.TrimSuffix(path.Base(file), ".json"))
+
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read mail templates %s: %w", file, err)
+		}
+
+		var sources map[string]mailMessageSource
+		if err := json.Unmarshal(data, &sources); err != nil {
+			return nil, fmt.Errorf("invalid mail templates %s: %w", file, err)
+		}
+
+		messages := make(map[string]mailMessage, len(sources))
+		for key, src := range sources {
+			subject, err := template.New(key + ".subject").Option("missingkey=error").Parse(src.Subject)
+			if err != nil {
+				return nil, fmt.Errorf("invalid subject for %s in %s: %w", key, file, err)
+			}
+			body, err := template.New(key + ".body").Option("missingkey=error").Parse(src.Body)
+			if err != nil {
+				return nil, fmt.Errorf("invalid body for %s in %s: %w", key, file, err)
+			}
+			messages[key] = mailMessage{subject: subject, body: body}
+		}
+		t.locales[locale] = messages
+	}
+
+	if _, ok := t.locales[DefaultMailLocale]; !ok {
+		return nil, fmt.Errorf("mail templates for default locale %q are missing", DefaultMailLocale)
+	}
+	return t, nil
+}
+
+// Render returns the subject and body of a message in the given locale, falling back to English
+func (t *MailTemplates) Render(locale, key string, data any) (subject, body string, err error) {
+	msg, ok := t.locales[strings.ToLower(locale)][key]
+	if !ok {
+		msg, ok = t.locales[DefaultMailLocale][key]
+		if !ok {
+			return "", "", fmt.Errorf("unknown mail message %q", key)
+		}
+	}
+
+	var sb strings.Builder
+	if err := msg.subject.Execute(&sb, data); err != nil {
+		return "", "", fmt.Errorf("failed to render subject of %s: %w", key, err)
+	}
+	subject = sb.String()
+
+	sb.Reset()
+	if err := msg.body.Execute(&sb, data); err != nil {
+		return "", "", fmt.Errorf("failed to render body of %s: %w", key, err)
+	}
+	return subject, sb.String(), nil
+}
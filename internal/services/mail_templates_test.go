@@ -0,0 +1,62 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMailTemplates_EmbeddedLocalesAreComplete(t *testing.T) {
+	templates, err := LoadMailTemplates(EmbeddedMailTemplates)
+	if err != nil {
+		t.Fatalf("LoadMailTemplates failed: %v", err)
+	}
+
+	data := map[string]interface{}{
+		"Name":             "Ada",
+		"Link":             "https://example.com/link",
+		"CompanyName":      "Acme",
+		"OrganizationName": "Acme",
+		"SupplierName":     "Supplier GmbH",
+		"Domain":           "example.com",
+		"NewEmail":         "new@example.com",
+	}
+	for locale, messages := range templates.locales {
+		for _, key := range []string{MailMessageMagicLink, MailMessageInvitation, MailMessageTeamInvitation, MailMessageCheckFixRefresh, MailMessageEmailChangeVerification, MailMessageEmailChangeNotice} {
+			if _, ok := messages[key]; !ok {
+				t.Errorf("Locale %s is missing message %s", locale, key)
+				continue
+			}
+			if _, _, err := templates.Render(locale, key, data); err != nil {
+				t.Errorf("Render(%s, %s) failed: %v", locale, key, err)
+			}
+		}
+	}
+}
+
+func TestMailTemplates_Render(t *testing.T) {
+	templates, err := LoadMailTemplates(EmbeddedMailTemplates)
+	if err != nil {
+		t.Fatalf("LoadMailTemplates failed: %v", err)
+	}
+	data := map[string]interface{}{"CompanyName": "Acme", "Link": "https://example.com/invite"}
+
+	subject, body, err := templates.Render("de", MailMessageInvitation, data)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if subject != "Acme hat Sie zu NisFix eingeladen" {
+		t.Errorf("Unexpected German subject %q", subject)
+	}
+	if !strings.Contains(body, "https://example.com/invite") {
+		t.Errorf("Expected body to contain the link, got %q", body)
+	}
+
+	// Unsupported languages fall back to English
+	subject, _, err = templates.Render("es", MailMessageInvitation, data)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if subject != "Acme has invited you to NisFix" {
+		t.Errorf("Expected English fallback subject, got %q", subject)
+	}
+}
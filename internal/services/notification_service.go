@@ -5,11 +5,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"time"
 
 	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
 )
 
 // NotificationService handles all email notifications
@@ -41,10 +44,12 @@ type notificationService struct {
 	httpClient     *http.Client
 	fromEmail      string
 	fromName       string
+	userRepo       repository.UserRepository
 }
 
 // NewNotificationService creates a new notification service
-func NewNotificationService(mailServiceURL, apiKey, fromEmail, fromName string) NotificationService {
+// #INTEGRATION_POINT: userRepo supplies the recipients' notification preferences
+func NewNotificationService(mailServiceURL, apiKey, fromEmail, fromName string, userRepo repository.UserRepository) NotificationService {
 	return &notificationService{
 		mailServiceURL: mailServiceURL,
 		apiKey:         apiKey,
@@ -53,9 +58,28 @@ func NewNotificationService(mailServiceURL, apiKey, fromEmail, fromName string)
 		},
 		fromEmail: fromEmail,
 		fromName:  fromName,
+		userRepo:  userRepo,
 	}
 }
 
+// wantsNotification checks the recipient's notification preferences
+// #BUSINESS_RULE: Recipients without an account, such as invited contacts, get every notification
+// #IMPLEMENTATION_DECISION: Fails open - a lookup error sends the email rather than dropping it
+func (s *notificationService) wantsNotification(ctx context.Context, email string, kind models.NotificationKind) bool {
+	if !kind.IsOptional() {
+		return true
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, models.NormalizeEmail(email))
+	if err != nil {
+		if !errors.Is(err, models.ErrUserNotFound) {
+			log.Printf("Failed to load notification preferences, sending %s notification: %v", kind, err)
+		}
+		return true
+	}
+	return user.WantsNotification(kind)
+}
+
 // EmailRequest represents an email send request
 type EmailRequest struct {
 	To       string            `json:"to"`
@@ -95,6 +119,10 @@ func (s *notificationService) sendEmail(ctx context.Context, req EmailRequest) e
 
 // SendSupplierInvitation sends a supplier invitation email
 func (s *notificationService) SendSupplierInvitation(ctx context.Context, email, companyName, supplierName, inviteLink string) error {
+	if !s.wantsNotification(ctx, email, models.NotificationKindInvitation) {
+		return nil
+	}
+
 	return s.sendEmail(ctx, EmailRequest{
 		To:       email,
 		From:     s.fromEmail,
@@ -133,6 +161,10 @@ func (s *notificationService) SendRequirementAssigned(ctx context.Context, email
 
 // SendRequirementReminder sends a reminder for an upcoming due date
 func (s *notificationService) SendRequirementReminder(ctx context.Context, email, supplierName, requirementTitle string, daysUntilDue int) error {
+	if !s.wantsNotification(ctx, email, models.NotificationKindReminder) {
+		return nil
+	}
+
 	daysText := fmt.Sprintf("%d days", daysUntilDue)
 	if daysUntilDue == 1 {
 		daysText = "1 day"
@@ -154,6 +186,10 @@ func (s *notificationService) SendRequirementReminder(ctx context.Context, email
 
 // SendRequirementOverdue sends a notification when a requirement is overdue
 func (s *notificationService) SendRequirementOverdue(ctx context.Context, email, supplierName, requirementTitle string) error {
+	if !s.wantsNotification(ctx, email, models.NotificationKindOverdue) {
+		return nil
+	}
+
 	return s.sendEmail(ctx, EmailRequest{
 		To:       email,
 		From:     s.fromEmail,
@@ -185,6 +221,10 @@ func (s *notificationService) SendSubmissionReceived(ctx context.Context, email,
 
 // SendSubmissionApproved sends a notification when a submission is approved
 func (s *notificationService) SendSubmissionApproved(ctx context.Context, email, supplierName, companyName, requirementTitle string, notes string) error {
+	if !s.wantsNotification(ctx, email, models.NotificationKindApproval) {
+		return nil
+	}
+
 	return s.sendEmail(ctx, EmailRequest{
 		To:       email,
 		From:     s.fromEmail,
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+func TestNotificationService_RespectsPreferences(t *testing.T) {
+	ctx := context.Background()
+	var sent int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		sent++
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	supplier := &models.User{ID: primitive.NewObjectID(), Email: "it@acme.example"}
+	users := &memoryUserRepo{users: map[primitive.ObjectID]*models.User{supplier.ID: supplier}}
+	userService := NewUserService(users, nil, nil, nil, nil, nil, "")
+	notifications := NewNotificationService(server.URL, "key", "noreply@nisfix.example", "NisFix", users)
+
+	if _, err := userService.UpdateNotificationPreferences(ctx, supplier.ID, map[models.NotificationKind]bool{
+		models.NotificationKindReminder: false,
+	}); err != nil {
+		t.Fatalf("UpdateNotificationPreferences failed: %v", err)
+	}
+	if _, err := userService.UpdateNotificationPreferences(ctx, supplier.ID, map[models.NotificationKind]bool{
+		models.NotificationKindApproval: false,
+	}); !errors.Is(err, ErrInvalidNotificationPreference) {
+		t.Errorf("Expected approvals to be non-optional, got %v", err)
+	}
+
+	if err := notifications.SendRequirementReminder(ctx, supplier.Email, "Acme", "NIS2 questionnaire", 3); err != nil || sent != 0 {
+		t.Errorf("Expected the muted reminder to be skipped, got %v after %d sends", err, sent)
+	}
+	if err := notifications.SendRequirementOverdue(ctx, supplier.Email, "Acme", "NIS2 questionnaire"); err != nil || sent != 1 {
+		t.Errorf("Expected the overdue notice to be sent, got %v after %d sends", err, sent)
+	}
+	if err := notifications.SendSubmissionApproved(ctx, supplier.Email, "Acme", "Contoso", "NIS2 questionnaire", ""); err != nil || sent != 2 {
+		t.Errorf("Expected the approval to be sent, got %v after %d sends", err, sent)
+	}
+	// Contacts without an account get reminders
+	if err := notifications.SendRequirementReminder(ctx, "new@acme.example", "Acme", "NIS2 questionnaire", 3); err != nil || sent != 3 {
+		t.Errorf("Expected a reminder for an unknown recipient, got %v after %d sends", err, sent)
+	}
+}
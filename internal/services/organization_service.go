@@ -0,0 +1,181 @@
+// Package services provides business logic implementations.
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+	"github.com/checkfix-tools/nisfix_backend/internal/storage"
+)
+
+// Custom errors for organization service
+var (
+	ErrLogoNotFound       = errors.New("organization has no logo")
+	ErrLogoTooLarge       = errors.New("logo exceeds the maximum size")
+	ErrLogoEmpty          = errors.New("logo is empty")
+	ErrLogoTypeNotAllowed = errors.New("logo must be a PNG, JPEG, GIF, or WebP image")
+)
+
+// MaxLogoSize is the largest accepted organization logo in bytes
+const MaxLogoSize = 2 << 20
+
+// allowedLogoTypes lists the image types accepted as logos, as reported by http.DetectContentType
+// #SECURITY_ASSUMPTION: SVG is excluded because it can carry scripts and logos are served publicly
+var allowedLogoTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// OrganizationService handles organization business logic that goes beyond plain CRUD
+// #INTEGRATION_POINT: Used by organization handler for logo management
+type OrganizationService interface {
+	// SetLogo validates and stores a new logo for the organization, replacing any previous one
+	SetLogo(ctx context.Context, orgID, userID primitive.ObjectID, content io.Reader) (*models.LogoRef, error)
+
+	// GetLogo returns the organization's logo reference
+	GetLogo(ctx context.Context, orgID primitive.ObjectID) (*models.LogoRef, error)
+
+	// GetLogoBySlug returns the logo reference of the organization with the given slug
+	GetLogoBySlug(ctx context.Context, slug string) (*models.LogoRef, error)
+
+	// OpenLogo returns the contents of a logo; the caller must close the reader
+	OpenLogo(ctx context.Context, logo *models.LogoRef) (io.ReadCloser, error)
+}
+
+// organizationService implements OrganizationService
+type organizationService struct {
+	orgRepo      repository.OrganizationRepository
+	fileStorage  storage.FileStorage
+	auditService AuditService
+}
+
+// NewOrganizationService creates a new organization service
+func NewOrganizationService(orgRepo repository.OrganizationRepository, fileStorage storage.FileStorage, auditService AuditService) OrganizationService {
+	return &organizationService{
+		orgRepo:      orgRepo,
+		fileStorage:  fileStorage,
+		auditService: auditService,
+	}
+}
+
+// SetLogo validates and stores a new logo for the organization
+// #IMPLEMENTATION_DECISION: Each upload gets a fresh storage key so a rejected upload never
+// overwrites the current logo; the previous file is removed once the new reference is saved
+func (s *organizationService) SetLogo(ctx context.Context, orgID, userID primitive.ObjectID, content io.Reader) (*models.LogoRef, error) {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, models.ErrOrganizationNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read logo: %w", err)
+	}
+	if n == 0 {
+		return nil, ErrLogoEmpty
+	}
+	contentType := http.DetectContentType(head[:n])
+	if !allowedLogoTypes[contentType] {
+		return nil, ErrLogoTypeNotAllowed
+	}
+
+	logo := models.LogoRef{
+		StorageKey:  fmt.Sprintf("organizations/%s/logo-%s", orgID.Hex(), primitive.NewObjectID().Hex()),
+		ContentType: contentType,
+		UpdatedAt:   time.Now().UTC(),
+	}
+
+	// Read one byte past the limit so oversized uploads are detected without buffering them
+	hash := sha256.New()
+	reader := io.TeeReader(io.LimitReader(io.MultiReader(bytes.NewReader(head[:n]), content), MaxLogoSize+1), hash)
+	logo.Size, err = s.fileStorage.Save(ctx, logo.StorageKey, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store logo: %w", err)
+	}
+	if logo.Size > MaxLogoSize {
+		s.fileStorage.Delete(ctx, logo.StorageKey) //nolint:errcheck // best-effort cleanup
+		return nil, ErrLogoTooLarge
+	}
+	logo.ETag = hex.EncodeToString(hash.Sum(nil))
+
+	if err := s.orgRepo.SetLogo(ctx, orgID, logo); err != nil {
+		s.fileStorage.Delete(ctx, logo.StorageKey) //nolint:errcheck // best-effort cleanup
+		if errors.Is(err, models.ErrOrganizationNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to save logo: %w", err)
+	}
+
+	if org.Logo != nil {
+		s.fileStorage.Delete(ctx, org.Logo.StorageKey) //nolint:errcheck // orphaned files are harmless
+	}
+
+	logAudit(s.auditService, newAuditEntry(ctx, userID, orgID, models.AuditActionUpdate,
+		models.ResourceTypeOrganization, orgID, "Updated organization logo"))
+
+	return &logo, nil
+}
+
+// GetLogo returns the organization's logo reference
+func (s *organizationService) GetLogo(ctx context.Context, orgID primitive.ObjectID) (*models.LogoRef, error) {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, models.ErrOrganizationNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	if org.Logo == nil {
+		return nil, ErrLogoNotFound
+	}
+	return org.Logo, nil
+}
+
+// GetLogoBySlug returns the logo reference of the organization with the given slug
+// #SECURITY_ASSUMPTION: Logos are public branding; a missing organization and a missing logo
+// return the same error so the endpoint reveals no more than the logo itself
+func (s *organizationService) GetLogoBySlug(ctx context.Context, slug string) (*models.LogoRef, error) {
+	org, err := s.orgRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, models.ErrOrganizationNotFound) {
+			return nil, ErrLogoNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	if org.Logo == nil {
+		return nil, ErrLogoNotFound
+	}
+	return org.Logo, nil
+}
+
+// OpenLogo returns the contents of a logo
+func (s *organizationService) OpenLogo(ctx context.Context, logo *models.LogoRef) (io.ReadCloser, error) {
+	content, err := s.fileStorage.Open(ctx, logo.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrFileNotFound) {
+			return nil, ErrLogoNotFound
+		}
+		return nil, fmt.Errorf("failed to open logo: %w", err)
+	}
+	return content, nil
+}
+
+// Ensure organizationService implements OrganizationService
+var _ OrganizationService = (*organizationService)(nil)
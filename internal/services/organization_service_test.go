@@ -0,0 +1,92 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+	"github.com/checkfix-tools/nisfix_backend/internal/storage"
+)
+
+// memoryOrganizationRepo keeps organizations in memory; unused methods are not implemented
+type memoryOrganizationRepo struct {
+	repository.OrganizationRepository
+	orgs map[primitive.ObjectID]*models.Organization
+}
+
+func (r *memoryOrganizationRepo) GetByID(_ context.Context, id primitive.ObjectID) (*models.Organization, error) {
+	org, ok := r.orgs[id]
+	if !ok {
+		return nil, models.ErrOrganizationNotFound
+	}
+	clone := *org
+	return &clone, nil
+}
+
+func (r *memoryOrganizationRepo) SetLogo(_ context.Context, id primitive.ObjectID, logo models.LogoRef) error {
+	org, ok := r.orgs[id]
+	if !ok {
+		return models.ErrOrganizationNotFound
+	}
+	org.Logo = &logo
+	return nil
+}
+
+func TestOrganizationService_SetLogo(t *testing.T) {
+	ctx := context.Background()
+	org := &models.Organization{ID: primitive.NewObjectID(), Slug: "acme"}
+
+	fileStorage, err := storage.NewLocalFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalFileStorage failed: %v", err)
+	}
+	service := NewOrganizationService(&memoryOrganizationRepo{orgs: map[primitive.ObjectID]*models.Organization{org.ID: org}}, fileStorage, nil)
+
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	t.Run("rejects non-image content", func(t *testing.T) {
+		_, err := service.SetLogo(ctx, org.ID, primitive.NewObjectID(), strings.NewReader("<svg onload=alert(1)></svg>"))
+		if !errors.Is(err, ErrLogoTypeNotAllowed) {
+			t.Errorf("Expected ErrLogoTypeNotAllowed, got %v", err)
+		}
+	})
+
+	t.Run("rejects oversized logo", func(t *testing.T) {
+		content := append(append([]byte{}, png...), make([]byte, MaxLogoSize)...)
+		_, err := service.SetLogo(ctx, org.ID, primitive.NewObjectID(), bytes.NewReader(content))
+		if !errors.Is(err, ErrLogoTooLarge) {
+			t.Errorf("Expected ErrLogoTooLarge, got %v", err)
+		}
+		if org.Logo != nil {
+			t.Errorf("Expected rejected upload not to set a logo")
+		}
+	})
+
+	t.Run("replaces previous logo", func(t *testing.T) {
+		first, err := service.SetLogo(ctx, org.ID, primitive.NewObjectID(), bytes.NewReader(png))
+		if err != nil {
+			t.Fatalf("SetLogo failed: %v", err)
+		}
+		if first.ContentType != "image/png" || first.ETag == "" {
+			t.Errorf("Unexpected logo metadata: %+v", first)
+		}
+
+		second, err := service.SetLogo(ctx, org.ID, primitive.NewObjectID(), bytes.NewReader(append(png, 0)))
+		if err != nil {
+			t.Fatalf("SetLogo failed: %v", err)
+		}
+		if second.ETag == first.ETag {
+			t.Errorf("Expected ETag to change with content")
+		}
+
+		if _, err := fileStorage.Open(ctx, first.StorageKey); !errors.Is(err, storage.ErrFileNotFound) {
+			t.Errorf("Expected previous logo file to be deleted, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+// MaxQuestionImportRows caps the number of questions a single CSV import may add
+const MaxQuestionImportRows = 200
+
+// ErrInvalidQuestionImport is returned when a question CSV cannot be read as a whole
+var ErrInvalidQuestionImport = errors.New("invalid question import")
+
+// questionImportHeader lists the expected CSV columns in order
+// #DATA_ASSUMPTION: options are encoded as "option_text|points|is_correct" entries separated by ";"
+// #IMPLEMENTATION_DECISION: control_ref was added later and may be left out, so older files still import
+var questionImportHeader = []string{"topic_id", "text", "type", "weight", "is_must_pass", "options", "control_ref"}
+
+// questionImportRequiredColumns is the number of leading header columns every file must have
+const questionImportRequiredColumns = 6
+
+// QuestionImportRowError describes why a single CSV row could not be parsed
+type QuestionImportRowError struct {
+	Row     int
+	Message string
+}
+
+// ParseQuestionImportCSV parses a question CSV into create requests
+// #BUSINESS_RULE: Problems with individual rows are collected and returned together; an unreadable
+// file, a wrong header or too many rows fail the whole import with ErrInvalidQuestionImport
+func ParseQuestionImportCSV(content []byte) ([]CreateQuestionRequest, []QuestionImportRowError, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("%w: file is empty", ErrInvalidQuestionImport)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidQuestionImport, err)
+	}
+	columns, err := validateQuestionImportHeader(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var reqs []CreateQuestionRequest
+	var rowErrors []QuestionImportRowError
+	rows := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidQuestionImport, err)
+		}
+
+		rows++
+		if rows > MaxQuestionImportRows {
+			return nil, nil, fmt.Errorf("%w: more than %d questions", ErrInvalidQuestionImport, MaxQuestionImportRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		req, err := parseQuestionImportRow(record, columns)
+		if err != nil {
+			rowErrors = append(rowErrors, QuestionImportRowError{Row: line, Message: err.Error()})
+			continue
+		}
+		reqs = append(reqs, req)
+	}
+
+	if rows == 0 {
+		return nil, nil, fmt.Errorf("%w: no questions found", ErrInvalidQuestionImport)
+	}
+
+	return reqs, rowErrors, nil
+}
+
+// validateQuestionImportHeader checks the header row against questionImportHeader and returns its column count
+func validateQuestionImportHeader(header []string) (int, error) {
+	if len(header) < questionImportRequiredColumns || len(header) > len(questionImportHeader) {
+		return 0, fmt.Errorf("%w: header must be %s", ErrInvalidQuestionImport, strings.Join(questionImportHeader, ","))
+	}
+	for i, column := range header {
+		if !strings.EqualFold(strings.TrimSpace(column), questionImportHeader[i]) {
+			return 0, fmt.Errorf("%w: header must be %s", ErrInvalidQuestionImport, strings.Join(questionImportHeader, ","))
+		}
+	}
+	return len(header), nil
+}
+
+// parseQuestionImportRow converts one CSV record into a create request
+func parseQuestionImportRow(record []string, columns int) (CreateQuestionRequest, error) {
+	if len(record) != columns {
+		return CreateQuestionRequest{}, fmt.Errorf("expected %d columns, got %d", columns, len(record))
+	}
+	for i := range record {
+		record[i] = strings.TrimSpace(record[i])
+	}
+
+	req := CreateQuestionRequest{
+		TopicID: record[0],
+		Text:    record[1],
+		Type:    models.QuestionType(strings.ToUpper(record[2])),
+	}
+	if req.Text == "" {
+		return req, errors.New("text is required")
+	}
+	if !req.Type.IsValid() {
+		return req, fmt.Errorf("invalid type %q", record[2])
+	}
+
+	if record[3] != "" {
+		weight, err := strconv.Atoi(record[3])
+		if err != nil || weight < 0 {
+			return req, fmt.Errorf("invalid weight %q", record[3])
+		}
+		req.Weight = weight
+	}
+
+	if record[4] != "" {
+		mustPass, err := strconv.ParseBool(record[4])
+		if err != nil {
+			return req, fmt.Errorf("invalid is_must_pass %q", record[4])
+		}
+		req.IsMustPass = mustPass
+	}
+
+	options, err := parseQuestionImportOptions(record[5])
+	if err != nil {
+		return req, err
+	}
+	if req.Type.RequiresOptions() && len(options) == 0 {
+		return req, fmt.Errorf("options are required for type %s", req.Type)
+	}
+	if !req.Type.IsChoiceType() && len(options) > 0 {
+		return req, fmt.Errorf("type %s does not take options", req.Type)
+	}
+	req.Options = options
+
+	if columns > questionImportRequiredColumns {
+		req.ControlRef = record[6]
+		if len(req.ControlRef) > models.MaxControlRefLength {
+			return req, fmt.Errorf("control_ref must be at most %d characters", models.MaxControlRefLength)
+		}
+	}
+
+	return req, nil
+}
+
+// parseQuestionImportOptions parses "option_text|points|is_correct" entries separated by ";"
+func parseQuestionImportOptions(value string) ([]models.QuestionOption, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var options []models.QuestionOption
+	for i, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, "|")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("option %d: expected option_text|points|is_correct", i+1)
+		}
+		option := models.QuestionOption{Text: strings.TrimSpace(parts[0])}
+		if option.Text == "" {
+			return nil, fmt.Errorf("option %d: text is required", i+1)
+		}
+		if len(parts) > 1 && strings.TrimSpace(parts[1]) != "" {
+			points, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+			if err != nil {
+				return nil, fmt.Errorf("option %d: invalid points %q", i+1, parts[1])
+			}
+			option.Points = points
+		}
+		if len(parts) > 2 && strings.TrimSpace(parts[2]) != "" {
+			correct, err := strconv.ParseBool(strings.TrimSpace(parts[2]))
+			if err != nil {
+				return nil, fmt.Errorf("option %d: invalid is_correct %q", i+1, parts[2])
+			}
+			option.IsCorrect = correct
+		}
+		options = append(options, option)
+	}
+
+	return options, nil
+}
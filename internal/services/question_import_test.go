@@ -0,0 +1,73 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+func TestParseQuestionImportCSV(t *testing.T) {
+	content := "\xef\xbb\xbftopic_id,text,type,weight,is_must_pass,options\n" +
+		"gov,Do you have an ISMS?,yes_no,2,true,Yes|10|true;No|0\n" +
+		"gov,\"Describe your backups, briefly\",TEXT,,,\n" +
+		"inc,Incident plan?,single_choice,1,maybe,A|1\n" +
+		"inc,Which controls?,MULTIPLE_CHOICE,1,false,\n"
+
+	reqs, rowErrors, err := ParseQuestionImportCSV([]byte(content))
+	if err != nil {
+		t.Fatalf("ParseQuestionImportCSV() error = %v", err)
+	}
+	if len(reqs) != 2 {
+		t.Fatalf("Expected 2 parsed questions, got %d", len(reqs))
+	}
+	first := reqs[0]
+	if first.Type != models.QuestionTypeYesNo || first.Weight != 2 || !first.IsMustPass || len(first.Options) != 2 {
+		t.Errorf("Unexpected first question %+v", first)
+	}
+	if first.Options[0].Points != 10 || !first.Options[0].IsCorrect || first.Options[1].IsCorrect {
+		t.Errorf("Unexpected options %+v", first.Options)
+	}
+	if reqs[1].Text != "Describe your backups, briefly" || reqs[1].Type != models.QuestionTypeText {
+		t.Errorf("Unexpected second question %+v", reqs[1])
+	}
+
+	if len(rowErrors) != 2 || rowErrors[0].Row != 4 || rowErrors[1].Row != 5 {
+		t.Fatalf("Expected errors on rows 4 and 5, got %+v", rowErrors)
+	}
+	if !strings.Contains(rowErrors[0].Message, "is_must_pass") || !strings.Contains(rowErrors[1].Message, "options are required") {
+		t.Errorf("Unexpected row errors %+v", rowErrors)
+	}
+
+	withControlRef := "topic_id,text,type,weight,is_must_pass,options,control_ref\n" +
+		"acc,Are accounts reviewed?,yes_no,1,false,,ISO A.9.2.5\n" +
+		"acc,Is MFA enforced?,yes_no,1,false,\n"
+	reqs, rowErrors, err = ParseQuestionImportCSV([]byte(withControlRef))
+	if err != nil {
+		t.Fatalf("ParseQuestionImportCSV() with control_ref error = %v", err)
+	}
+	if len(reqs) != 1 || reqs[0].ControlRef != "ISO A.9.2.5" {
+		t.Errorf("Expected the control reference to be read, got %+v", reqs)
+	}
+	if len(rowErrors) != 1 || rowErrors[0].Row != 3 {
+		t.Errorf("Expected a column count error on row 3, got %+v", rowErrors)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"empty file", ""},
+		{"wrong header", "text,type\nQ,TEXT\n"},
+		{"no rows", "topic_id,text,type,weight,is_must_pass,options\n"},
+		{"too many rows", "topic_id,text,type,weight,is_must_pass,options\n" + strings.Repeat(",Q,TEXT,,,\n", MaxQuestionImportRows+1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ParseQuestionImportCSV([]byte(tt.content)); !errors.Is(err, ErrInvalidQuestionImport) {
+				t.Errorf("ParseQuestionImportCSV() error = %v, want %v", err, ErrInvalidQuestionImport)
+			}
+		})
+	}
+}
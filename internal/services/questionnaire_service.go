@@ -5,6 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -22,8 +25,13 @@ var (
 	ErrQuestionNotFound          = errors.New("question not found")
 	ErrInvalidQuestionType       = errors.New("invalid question type")
 	ErrCannotPublish             = errors.New("cannot publish questionnaire")
+	ErrInvalidControlRef         = errors.New("invalid control reference")
+	ErrQuestionnaireConflict     = errors.New("questionnaire was modified by another update")
 )
 
+// MaxControlRefSearchResults caps how many questions a control reference search returns
+const MaxControlRefSearchResults = 100
+
 // QuestionnaireService handles questionnaire business logic
 // #INTEGRATION_POINT: Used by questionnaire handler for CRUD operations
 type QuestionnaireService interface {
@@ -39,6 +47,15 @@ type QuestionnaireService interface {
 	// GetQuestionnaireWithQuestions retrieves a questionnaire with its questions
 	GetQuestionnaireWithQuestions(ctx context.Context, id primitive.ObjectID, companyID *primitive.ObjectID) (*QuestionnaireWithQuestions, error)
 
+	// ListQuestions lists a questionnaire's questions in order, limited to one topic when topicID is set
+	ListQuestions(ctx context.Context, id, companyID primitive.ObjectID, topicID string) ([]models.Question, error)
+
+	// SearchQuestionsByControlRef finds the company's questions mapped to controls starting with prefix
+	SearchQuestionsByControlRef(ctx context.Context, companyID primitive.ObjectID, prefix string) ([]models.Question, error)
+
+	// ListRecommended lists the company's published questionnaires that target a supplier classification
+	ListRecommended(ctx context.Context, companyID primitive.ObjectID, classification models.SupplierClassification) ([]models.Questionnaire, error)
+
 	// ListQuestionnaires lists questionnaires for a company
 	ListQuestionnaires(ctx context.Context, companyID primitive.ObjectID, filters QuestionnaireFilters, opts repository.PaginationOptions) (*repository.PaginatedResult[models.Questionnaire], error)
 
@@ -51,23 +68,44 @@ type QuestionnaireService interface {
 	// ArchiveQuestionnaire archives a published questionnaire
 	ArchiveQuestionnaire(ctx context.Context, id, companyID primitive.ObjectID) (*models.Questionnaire, error)
 
-	// DeleteQuestionnaire deletes a draft questionnaire
+	// DeleteQuestionnaire soft-deletes a draft questionnaire
 	DeleteQuestionnaire(ctx context.Context, id, companyID primitive.ObjectID) error
 
+	// RestoreQuestionnaire restores a soft-deleted questionnaire together with its questions
+	RestoreQuestionnaire(ctx context.Context, id, companyID primitive.ObjectID) (*models.Questionnaire, error)
+
+	// PurgeDeletedQuestionnaires permanently deletes questionnaires soft-deleted more than retentionDays ago
+	PurgeDeletedQuestionnaires(ctx context.Context, retentionDays int) (int, error)
+
 	// AddQuestion adds a question to a questionnaire
 	AddQuestion(ctx context.Context, questionnaireID, companyID primitive.ObjectID, req CreateQuestionRequest) (*models.Question, error)
 
+	// AddQuestions appends several questions to a questionnaire in the given order
+	AddQuestions(ctx context.Context, questionnaireID, companyID primitive.ObjectID, reqs []CreateQuestionRequest) ([]models.Question, error)
+
 	// UpdateQuestion updates a question
 	UpdateQuestion(ctx context.Context, questionID, companyID primitive.ObjectID, req UpdateQuestionRequest) (*models.Question, error)
 
 	// DeleteQuestion deletes a question from a questionnaire
 	DeleteQuestion(ctx context.Context, questionID, companyID primitive.ObjectID) error
 
+	// DeleteQuestions deletes several questions of a draft questionnaire and returns the number deleted
+	DeleteQuestions(ctx context.Context, questionnaireID, companyID primitive.ObjectID, questionIDs []primitive.ObjectID) (int, error)
+
 	// ReorderQuestions reorders questions in a questionnaire
 	ReorderQuestions(ctx context.Context, questionnaireID, companyID primitive.ObjectID, questionOrders map[string]int) error
 
 	// GetQuestionnaireStats returns questionnaire statistics for a company
 	GetQuestionnaireStats(ctx context.Context, companyID primitive.ObjectID) (*QuestionnaireStats, error)
+
+	// ListSubmissions lists the submissions made against a company's questionnaire, optionally only passed or failed ones
+	ListSubmissions(ctx context.Context, id, companyID primitive.ObjectID, passed *bool, opts repository.PaginationOptions) (*repository.PaginatedResult[models.QuestionnaireSubmission], error)
+
+	// GetQuestionnaireAnalytics returns submission analytics for a questionnaire owned by the company
+	GetQuestionnaireAnalytics(ctx context.Context, id, companyID primitive.ObjectID) (*QuestionnaireAnalytics, error)
+
+	// SelfTestQuestionnaire scores the company's own answers to its questionnaire without saving anything
+	SelfTestQuestionnaire(ctx context.Context, id, companyID primitive.ObjectID, answers []SubmitAnswerRequest) (*SelfTestResult, error)
 }
 
 // CreateQuestionnaireRequest represents the request to create a questionnaire
@@ -77,6 +115,9 @@ type CreateQuestionnaireRequest struct {
 	PassingScore int                         `json:"passing_score,omitempty"`
 	ScoringMode  models.ScoringMode          `json:"scoring_mode,omitempty"`
 	Topics       []models.QuestionnaireTopic `json:"topics,omitempty"`
+
+	// AppliesTo lists the supplier classifications the questionnaire targets
+	AppliesTo []models.SupplierClassification `json:"applies_to,omitempty"`
 }
 
 // UpdateQuestionnaireRequest represents the request to update a questionnaire
@@ -85,6 +126,12 @@ type UpdateQuestionnaireRequest struct {
 	Description  *string                     `json:"description,omitempty"`
 	PassingScore *int                        `json:"passing_score,omitempty"`
 	Topics       []models.QuestionnaireTopic `json:"topics,omitempty"`
+
+	// AppliesTo replaces the targeted classifications when set; an empty list clears them
+	AppliesTo []models.SupplierClassification `json:"applies_to,omitempty"`
+
+	// ExpectedVersion is the version the changes are based on; nil skips the up-front check
+	ExpectedVersion *int `json:"expected_version,omitempty"`
 }
 
 // CreateQuestionRequest represents the request to create a question
@@ -96,7 +143,12 @@ type CreateQuestionRequest struct {
 	Type        models.QuestionType     `json:"type" binding:"required"`
 	Weight      int                     `json:"weight,omitempty"`
 	IsMustPass  bool                    `json:"is_must_pass,omitempty"`
+	RequireNote bool                    `json:"require_note,omitempty"`
+	ControlRef  string                  `json:"control_ref,omitempty"`
 	Options     []models.QuestionOption `json:"options,omitempty"`
+
+	// Scored marks the question as counting towards the score; nil means scored
+	Scored *bool `json:"scored,omitempty"`
 }
 
 // UpdateQuestionRequest represents the request to update a question
@@ -107,6 +159,9 @@ type UpdateQuestionRequest struct {
 	HelpText    *string                 `json:"help_text,omitempty"`
 	Weight      *int                    `json:"weight,omitempty"`
 	IsMustPass  *bool                   `json:"is_must_pass,omitempty"`
+	RequireNote *bool                   `json:"require_note,omitempty"`
+	ControlRef  *string                 `json:"control_ref,omitempty"`
+	Scored      *bool                   `json:"scored,omitempty"`
 	Options     []models.QuestionOption `json:"options,omitempty"`
 }
 
@@ -130,11 +185,47 @@ type QuestionnaireStats struct {
 	Archived  int64 `json:"archived"`
 }
 
+// QuestionnaireAnalytics summarizes how suppliers perform on a questionnaire
+// #BUSINESS_RULE: Rates and averages are percentages (0-100) and are 0 when nothing was submitted
+type QuestionnaireAnalytics struct {
+	QuestionnaireID primitive.ObjectID
+	Submissions     int64
+	PassRate        float64
+	AverageScore    float64
+	Questions       []QuestionAnalytics // Most failed first
+	Topics          []TopicAnalytics    // Lowest scoring first
+}
+
+// QuestionAnalytics is the failure rate of a single question
+type QuestionAnalytics struct {
+	QuestionID  primitive.ObjectID
+	TopicID     string
+	Text        string
+	Answered    int64
+	Failed      int64
+	FailureRate float64
+}
+
+// SelfTestResult is the unsaved outcome of a company scoring its own answers
+type SelfTestResult struct {
+	Submission   *models.QuestionnaireSubmission
+	PassingScore int
+}
+
+// TopicAnalytics is the average score of a topic
+type TopicAnalytics struct {
+	TopicID      string
+	TopicName    string
+	AverageScore float64
+}
+
 // questionnaireService implements QuestionnaireService
 type questionnaireService struct {
 	questionnaireRepo repository.QuestionnaireRepository
 	templateRepo      repository.QuestionnaireTemplateRepository
 	questionRepo      repository.QuestionRepository
+	submissionRepo    repository.SubmissionRepository
+	orgRepo           repository.OrganizationRepository
 }
 
 // NewQuestionnaireService creates a new questionnaire service
@@ -142,16 +233,25 @@ func NewQuestionnaireService(
 	questionnaireRepo repository.QuestionnaireRepository,
 	templateRepo repository.QuestionnaireTemplateRepository,
 	questionRepo repository.QuestionRepository,
+	submissionRepo repository.SubmissionRepository,
+	orgRepo repository.OrganizationRepository,
 ) QuestionnaireService {
 	return &questionnaireService{
 		questionnaireRepo: questionnaireRepo,
 		templateRepo:      templateRepo,
 		questionRepo:      questionRepo,
+		submissionRepo:    submissionRepo,
+		orgRepo:           orgRepo,
 	}
 }
 
 // CreateQuestionnaire creates a new questionnaire from scratch
 func (s *questionnaireService) CreateQuestionnaire(ctx context.Context, companyID primitive.ObjectID, req CreateQuestionnaireRequest) (*models.Questionnaire, error) {
+	appliesTo, err := normalizeAppliesTo(req.AppliesTo)
+	if err != nil {
+		return nil, err
+	}
+
 	questionnaire := &models.Questionnaire{
 		CompanyID:    companyID,
 		Name:         req.Name,
@@ -159,14 +259,26 @@ func (s *questionnaireService) CreateQuestionnaire(ctx context.Context, companyI
 		PassingScore: req.PassingScore,
 		ScoringMode:  req.ScoringMode,
 		Topics:       req.Topics,
+		AppliesTo:    appliesTo,
 	}
 
 	// Set defaults
-	if questionnaire.PassingScore == 0 {
-		questionnaire.PassingScore = 70
-	}
-	if questionnaire.ScoringMode == "" {
-		questionnaire.ScoringMode = models.ScoringModePercentage
+	// #BUSINESS_RULE: Omitted scoring fields come from the company settings, then the built-in defaults
+	if questionnaire.PassingScore == 0 || questionnaire.ScoringMode == "" {
+		settings := models.DefaultOrganizationSettings()
+		if s.orgRepo != nil {
+			company, err := s.orgRepo.GetByID(ctx, companyID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get company: %w", err)
+			}
+			settings = company.Settings
+		}
+		if questionnaire.PassingScore == 0 {
+			questionnaire.PassingScore = settings.EffectiveDefaultPassingScore()
+		}
+		if questionnaire.ScoringMode == "" {
+			questionnaire.ScoringMode = settings.EffectiveDefaultScoringMode()
+		}
 	}
 	if questionnaire.Topics == nil {
 		questionnaire.Topics = []models.QuestionnaireTopic{}
@@ -191,8 +303,29 @@ func (s *questionnaireService) CreateQuestionnaire(ctx context.Context, companyI
 	return questionnaire, nil
 }
 
+// normalizeAppliesTo validates targeted classifications and drops duplicates, keeping the first occurrence
+func normalizeAppliesTo(classifications []models.SupplierClassification) ([]models.SupplierClassification, error) {
+	if len(classifications) == 0 {
+		return nil, nil
+	}
+	seen := make(map[models.SupplierClassification]bool, len(classifications))
+	result := make([]models.SupplierClassification, 0, len(classifications))
+	for _, classification := range classifications {
+		if !classification.IsValid() {
+			return nil, ErrInvalidClassification
+		}
+		if !seen[classification] {
+			seen[classification] = true
+			result = append(result, classification)
+		}
+	}
+	return result, nil
+}
+
 // CreateFromTemplate creates a questionnaire from a template
 // #BUSINESS_RULE: Template topics and default passing score are copied
+// #BUSINESS_RULE: An explicit template ID is honoured even if that version has been superseded;
+// listings steer new creations to the latest version
 func (s *questionnaireService) CreateFromTemplate(ctx context.Context, companyID primitive.ObjectID, templateID primitive.ObjectID, name string) (*models.Questionnaire, error) {
 	// Get template
 	template, err := s.templateRepo.GetByID(ctx, templateID)
@@ -273,11 +406,69 @@ func (s *questionnaireService) GetQuestionnaireWithQuestions(ctx context.Context
 	}, nil
 }
 
+// ListQuestions lists a questionnaire's questions in order, limited to one topic when topicID is set
+func (s *questionnaireService) ListQuestions(ctx context.Context, id, companyID primitive.ObjectID, topicID string) ([]models.Question, error) {
+	if _, err := s.GetQuestionnaire(ctx, id, &companyID); err != nil {
+		return nil, err
+	}
+
+	var questions []models.Question
+	var err error
+	if topicID != "" {
+		questions, err = s.questionRepo.ListByQuestionnaireAndTopic(ctx, id, topicID)
+	} else {
+		questions, err = s.questionRepo.ListByQuestionnaire(ctx, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	return questions, nil
+}
+
+// SearchQuestionsByControlRef finds the company's questions mapped to controls starting with prefix
+// #BUSINESS_RULE: Searches every questionnaire of the company that is not deleted, any status,
+// so coverage of a control can be shown across drafts, published and archived questionnaires
+func (s *questionnaireService) SearchQuestionsByControlRef(ctx context.Context, companyID primitive.ObjectID, prefix string) ([]models.Question, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" || len(prefix) > models.MaxControlRefLength {
+		return nil, ErrInvalidControlRef
+	}
+
+	questionnaireIDs, err := s.questionnaireRepo.ListIDsByCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list questionnaires: %w", err)
+	}
+
+	questions, err := s.questionRepo.SearchByControlRef(ctx, questionnaireIDs, prefix, MaxControlRefSearchResults)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search questions: %w", err)
+	}
+	return questions, nil
+}
+
 // ListQuestionnaires lists questionnaires for a company
 func (s *questionnaireService) ListQuestionnaires(ctx context.Context, companyID primitive.ObjectID, filters QuestionnaireFilters, opts repository.PaginationOptions) (*repository.PaginatedResult[models.Questionnaire], error) {
 	return s.questionnaireRepo.ListByCompany(ctx, companyID, filters.Status, opts)
 }
 
+// ListRecommended lists the company's published questionnaires that target a supplier classification
+// #BUSINESS_RULE: A recommendation only; assignment never checks targeting
+func (s *questionnaireService) ListRecommended(ctx context.Context, companyID primitive.ObjectID, classification models.SupplierClassification) ([]models.Questionnaire, error) {
+	if !classification.IsValid() {
+		return nil, ErrInvalidClassification
+	}
+
+	questionnaires, err := s.questionnaireRepo.ListPublishedByClassification(ctx, companyID, classification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recommended questionnaires: %w", err)
+	}
+	if questionnaires == nil {
+		questionnaires = []models.Questionnaire{}
+	}
+	return questionnaires, nil
+}
+
 // UpdateQuestionnaire updates questionnaire metadata
 // #BUSINESS_RULE: Only draft questionnaires can be edited
 func (s *questionnaireService) UpdateQuestionnaire(ctx context.Context, id, companyID primitive.ObjectID, req UpdateQuestionnaireRequest) (*models.Questionnaire, error) {
@@ -290,6 +481,10 @@ func (s *questionnaireService) UpdateQuestionnaire(ctx context.Context, id, comp
 		return nil, ErrQuestionnaireNotEditable
 	}
 
+	if req.ExpectedVersion != nil && *req.ExpectedVersion != questionnaire.Version {
+		return nil, ErrQuestionnaireConflict
+	}
+
 	// Update fields if provided
 	if req.Name != nil {
 		questionnaire.Name = *req.Name
@@ -312,11 +507,18 @@ func (s *questionnaireService) UpdateQuestionnaire(ctx context.Context, id, comp
 		}
 		questionnaire.Topics = req.Topics
 	}
+	if req.AppliesTo != nil {
+		appliesTo, err := normalizeAppliesTo(req.AppliesTo)
+		if err != nil {
+			return nil, err
+		}
+		questionnaire.AppliesTo = appliesTo
+	}
 
 	questionnaire.BeforeUpdate()
 
 	if err := s.questionnaireRepo.Update(ctx, questionnaire); err != nil {
-		return nil, fmt.Errorf("failed to update questionnaire: %w", err)
+		return nil, mapQuestionnaireUpdateError(err, "failed to update questionnaire")
 	}
 
 	return questionnaire, nil
@@ -355,7 +557,7 @@ func (s *questionnaireService) PublishQuestionnaire(ctx context.Context, id, com
 	}
 
 	if err := s.questionnaireRepo.Update(ctx, questionnaire); err != nil {
-		return nil, fmt.Errorf("failed to publish questionnaire: %w", err)
+		return nil, mapQuestionnaireUpdateError(err, "failed to publish questionnaire")
 	}
 
 	return questionnaire, nil
@@ -373,14 +575,29 @@ func (s *questionnaireService) ArchiveQuestionnaire(ctx context.Context, id, com
 	}
 
 	if err := s.questionnaireRepo.Update(ctx, questionnaire); err != nil {
-		return nil, fmt.Errorf("failed to archive questionnaire: %w", err)
+		return nil, mapQuestionnaireUpdateError(err, "failed to archive questionnaire")
 	}
 
 	return questionnaire, nil
 }
 
-// DeleteQuestionnaire deletes a draft questionnaire
-// #BUSINESS_RULE: Only draft questionnaires can be deleted
+// mapQuestionnaireUpdateError translates repository update errors into service errors
+// #BUSINESS_RULE: A write based on a stale version fails with ErrQuestionnaireConflict; the client
+// must reload the questionnaire and reapply its changes
+func mapQuestionnaireUpdateError(err error, action string) error {
+	switch {
+	case errors.Is(err, models.ErrQuestionnaireConflict):
+		return ErrQuestionnaireConflict
+	case errors.Is(err, models.ErrQuestionnaireNotFound):
+		return ErrQuestionnaireNotFound
+	default:
+		return fmt.Errorf("%s: %w", action, err)
+	}
+}
+
+// DeleteQuestionnaire soft-deletes a draft questionnaire
+// #BUSINESS_RULE: Only draft questionnaires can be deleted. Questions stay in place until the
+// questionnaire is purged, so a restore brings them back unchanged
 func (s *questionnaireService) DeleteQuestionnaire(ctx context.Context, id, companyID primitive.ObjectID) error {
 	questionnaire, err := s.GetQuestionnaire(ctx, id, &companyID)
 	if err != nil {
@@ -391,11 +608,6 @@ func (s *questionnaireService) DeleteQuestionnaire(ctx context.Context, id, comp
 		return ErrQuestionnaireNotDeletable
 	}
 
-	// Delete all questions first
-	if _, err := s.questionRepo.DeleteByQuestionnaire(ctx, id); err != nil {
-		return fmt.Errorf("failed to delete questions: %w", err)
-	}
-
 	if err := s.questionnaireRepo.Delete(ctx, id); err != nil {
 		if errors.Is(err, models.ErrQuestionnaireNotDeletable) {
 			return ErrQuestionnaireNotDeletable
@@ -406,9 +618,60 @@ func (s *questionnaireService) DeleteQuestionnaire(ctx context.Context, id, comp
 	return nil
 }
 
+// RestoreQuestionnaire restores a soft-deleted questionnaire together with its questions
+func (s *questionnaireService) RestoreQuestionnaire(ctx context.Context, id, companyID primitive.ObjectID) (*models.Questionnaire, error) {
+	if err := s.questionnaireRepo.Restore(ctx, id, companyID); err != nil {
+		if errors.Is(err, models.ErrQuestionnaireNotFound) {
+			return nil, ErrQuestionnaireNotFound
+		}
+		return nil, fmt.Errorf("failed to restore questionnaire: %w", err)
+	}
+
+	return s.GetQuestionnaire(ctx, id, &companyID)
+}
+
+// PurgeDeletedQuestionnaires permanently deletes questionnaires soft-deleted more than retentionDays ago
+// #IMPLEMENTATION_DECISION: The questionnaire is purged before its questions, so one restored
+// while the job runs keeps its questions
+func (s *questionnaireService) PurgeDeletedQuestionnaires(ctx context.Context, retentionDays int) (int, error) {
+	before := time.Now().UTC().AddDate(0, 0, -retentionDays)
+	ids, err := s.questionnaireRepo.ListDeletedBefore(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list deleted questionnaires: %w", err)
+	}
+
+	purged := 0
+	for _, id := range ids {
+		if err := s.questionnaireRepo.Purge(ctx, id); err != nil {
+			if errors.Is(err, models.ErrQuestionnaireNotFound) {
+				// Restored in the meantime
+				continue
+			}
+			return purged, fmt.Errorf("failed to purge questionnaire: %w", err)
+		}
+		if _, err := s.questionRepo.DeleteByQuestionnaire(ctx, id); err != nil {
+			return purged, fmt.Errorf("failed to delete questions: %w", err)
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
 // AddQuestion adds a question to a questionnaire
 // #BUSINESS_RULE: Questions can only be added to draft questionnaires
 func (s *questionnaireService) AddQuestion(ctx context.Context, questionnaireID, companyID primitive.ObjectID, req CreateQuestionRequest) (*models.Question, error) {
+	questions, err := s.AddQuestions(ctx, questionnaireID, companyID, []CreateQuestionRequest{req})
+	if err != nil {
+		return nil, err
+	}
+	return &questions[0], nil
+}
+
+// AddQuestions appends several questions to a questionnaire in the given order
+// #BUSINESS_RULE: Every question is validated before the first one is stored, so an invalid
+// question leaves the questionnaire unchanged
+func (s *questionnaireService) AddQuestions(ctx context.Context, questionnaireID, companyID primitive.ObjectID, reqs []CreateQuestionRequest) ([]models.Question, error) {
 	questionnaire, err := s.GetQuestionnaire(ctx, questionnaireID, &companyID)
 	if err != nil {
 		return nil, err
@@ -418,8 +681,9 @@ func (s *questionnaireService) AddQuestion(ctx context.Context, questionnaireID,
 		return nil, ErrQuestionnaireNotEditable
 	}
 
-	if !req.Type.IsValid() {
-		return nil, ErrInvalidQuestionType
+	scale, err := s.scoringScale(ctx, companyID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get current question count for ordering
@@ -428,39 +692,65 @@ func (s *questionnaireService) AddQuestion(ctx context.Context, questionnaireID,
 		return nil, fmt.Errorf("failed to count questions: %w", err)
 	}
 
-	// Generate option IDs if not provided
-	for i := range req.Options {
-		if req.Options[i].ID == "" {
-			req.Options[i].ID = uuid.New().String()
+	questions := make([]models.Question, len(reqs))
+	for i, req := range reqs {
+		if !req.Type.IsValid() {
+			return nil, ErrInvalidQuestionType
 		}
-		if req.Options[i].Order == 0 {
-			req.Options[i].Order = i + 1
+		controlRef := strings.TrimSpace(req.ControlRef)
+		if len(controlRef) > models.MaxControlRefLength {
+			if len(reqs) > 1 {
+				return nil, fmt.Errorf("question %d: %w", i+1, ErrInvalidControlRef)
+			}
+			return nil, ErrInvalidControlRef
 		}
-	}
 
-	question := &models.Question{
-		QuestionnaireID: questionnaireID,
-		TopicID:         req.TopicID,
-		Text:            req.Text,
-		Description:     req.Description,
-		HelpText:        req.HelpText,
-		Type:            req.Type,
-		Order:           int(count) + 1,
-		Weight:          req.Weight,
-		IsMustPass:      req.IsMustPass,
-		Options:         req.Options,
-	}
+		// Generate option IDs if not provided
+		for j := range req.Options {
+			if req.Options[j].ID == "" {
+				req.Options[j].ID = uuid.New().String()
+			}
+			if req.Options[j].Order == 0 {
+				req.Options[j].Order = j + 1
+			}
+		}
 
-	question.BeforeCreate()
+		questions[i] = models.Question{
+			QuestionnaireID: questionnaireID,
+			TopicID:         req.TopicID,
+			Text:            req.Text,
+			Description:     req.Description,
+			HelpText:        req.HelpText,
+			Type:            req.Type,
+			Order:           int(count) + i + 1,
+			Weight:          req.Weight,
+			IsMustPass:      req.IsMustPass,
+			Scored:          req.Scored == nil || *req.Scored,
+			RequireNote:     req.RequireNote,
+			ControlRef:      controlRef,
+			Options:         req.Options,
+		}
 
-	if err := s.questionRepo.Create(ctx, question); err != nil {
-		return nil, fmt.Errorf("failed to create question: %w", err)
+		if err := questions[i].ValidateOptionPoints(scale); err != nil {
+			if len(reqs) > 1 {
+				return nil, fmt.Errorf("question %d: %w", i+1, err)
+			}
+			return nil, err
+		}
+	}
+
+	for i := range questions {
+		questions[i].BeforeCreate()
+		if err := s.questionRepo.Create(ctx, &questions[i]); err != nil {
+			s.updateQuestionnaireStats(ctx, questionnaireID)
+			return nil, fmt.Errorf("failed to create question: %w", err)
+		}
 	}
 
 	// Update questionnaire statistics
 	s.updateQuestionnaireStats(ctx, questionnaireID)
 
-	return question, nil
+	return questions, nil
 }
 
 // UpdateQuestion updates a question
@@ -502,6 +792,19 @@ func (s *questionnaireService) UpdateQuestion(ctx context.Context, questionID, c
 	if req.IsMustPass != nil {
 		question.IsMustPass = *req.IsMustPass
 	}
+	if req.RequireNote != nil {
+		question.RequireNote = *req.RequireNote
+	}
+	if req.Scored != nil {
+		question.Scored = *req.Scored
+	}
+	if req.ControlRef != nil {
+		controlRef := strings.TrimSpace(*req.ControlRef)
+		if len(controlRef) > models.MaxControlRefLength {
+			return nil, ErrInvalidControlRef
+		}
+		question.ControlRef = controlRef
+	}
 	if req.Options != nil {
 		// Generate option IDs if not provided
 		for i := range req.Options {
@@ -513,9 +816,17 @@ func (s *questionnaireService) UpdateQuestion(ctx context.Context, questionID, c
 			}
 		}
 		question.Options = req.Options
-		question.RecalculateMaxPoints()
+
+		// Only changed options are checked, so a later scale change does not block other edits
+		if err := s.validateOptionPoints(ctx, companyID, question); err != nil {
+			return nil, err
+		}
 	}
 
+	// #BUSINESS_RULE: MaxPoints is recomputed on every edit, not only when options change,
+	// so questions stored with stale points cannot skew the questionnaire's max score;
+	// weight is applied on top of it when the max score is aggregated
+	question.RecalculateMaxPoints()
 	question.BeforeUpdate()
 
 	if err := s.questionRepo.Update(ctx, question); err != nil {
@@ -560,6 +871,70 @@ func (s *questionnaireService) DeleteQuestion(ctx context.Context, questionID, c
 	return nil
 }
 
+// DeleteQuestions deletes several questions of a draft questionnaire and returns the number deleted
+// #BUSINESS_RULE: All or nothing - if any ID is not a question of the questionnaire, nothing is deleted.
+// The remaining questions are renumbered 1..n in their current order
+func (s *questionnaireService) DeleteQuestions(ctx context.Context, questionnaireID, companyID primitive.ObjectID, questionIDs []primitive.ObjectID) (int, error) {
+	questionnaire, err := s.GetQuestionnaire(ctx, questionnaireID, &companyID)
+	if err != nil {
+		return 0, err
+	}
+
+	if !questionnaire.CanBeEdited() {
+		return 0, ErrQuestionnaireNotEditable
+	}
+
+	questions, err := s.questionRepo.ListByQuestionnaire(ctx, questionnaireID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list questions: %w", err)
+	}
+
+	existing := make(map[primitive.ObjectID]bool, len(questions))
+	for i := range questions {
+		existing[questions[i].ID] = true
+	}
+	toDelete := make(map[primitive.ObjectID]bool, len(questionIDs))
+	for _, id := range questionIDs {
+		if !existing[id] {
+			return 0, ErrQuestionNotFound
+		}
+		toDelete[id] = true
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(toDelete))
+	for id := range toDelete {
+		ids = append(ids, id)
+	}
+	deleted, err := s.questionRepo.DeleteByIDs(ctx, questionnaireID, ids)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete questions: %w", err)
+	}
+
+	// Close the gaps left in the order sequence
+	remaining := make([]models.Question, 0, len(questions))
+	for i := range questions {
+		if !toDelete[questions[i].ID] {
+			remaining = append(remaining, questions[i])
+		}
+	}
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return remaining[i].Order < remaining[j].Order
+	})
+	orders := make(map[primitive.ObjectID]int)
+	for i := range remaining {
+		if remaining[i].Order != i+1 {
+			orders[remaining[i].ID] = i + 1
+		}
+	}
+	if err := s.questionRepo.UpdateOrder(ctx, questionnaireID, orders); err != nil {
+		return int(deleted), fmt.Errorf("failed to renumber questions: %w", err)
+	}
+
+	s.updateQuestionnaireStats(ctx, questionnaireID)
+
+	return int(deleted), nil
+}
+
 // ReorderQuestions reorders questions in a questionnaire
 func (s *questionnaireService) ReorderQuestions(ctx context.Context, questionnaireID, companyID primitive.ObjectID, questionOrders map[string]int) error {
 	questionnaire, err := s.GetQuestionnaire(ctx, questionnaireID, &companyID)
@@ -621,6 +996,150 @@ func (s *questionnaireService) GetQuestionnaireStats(ctx context.Context, compan
 	}, nil
 }
 
+// ListSubmissions lists the submissions made against a company's questionnaire, optionally only passed or failed ones
+func (s *questionnaireService) ListSubmissions(ctx context.Context, id, companyID primitive.ObjectID, passed *bool, opts repository.PaginationOptions) (*repository.PaginatedResult[models.QuestionnaireSubmission], error) {
+	if _, err := s.GetQuestionnaire(ctx, id, &companyID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.submissionRepo.ListByQuestionnaire(ctx, id, passed, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list submissions: %w", err)
+	}
+	return result, nil
+}
+
+// GetQuestionnaireAnalytics returns submission analytics for a questionnaire owned by the company
+func (s *questionnaireService) GetQuestionnaireAnalytics(ctx context.Context, id, companyID primitive.ObjectID) (*QuestionnaireAnalytics, error) {
+	questionnaire, err := s.GetQuestionnaire(ctx, id, &companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.submissionRepo.GetSummaryByQuestionnaire(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize submissions: %w", err)
+	}
+
+	analytics := &QuestionnaireAnalytics{
+		QuestionnaireID: id,
+		Submissions:     summary.Submissions,
+		AverageScore:    summary.AverageScore,
+		Questions:       []QuestionAnalytics{},
+		Topics:          []TopicAnalytics{},
+	}
+	if summary.Submissions > 0 {
+		analytics.PassRate = float64(summary.Passed) / float64(summary.Submissions) * 100
+	}
+
+	questions, err := s.questionRepo.ListByQuestionnaire(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	failureStats, err := s.submissionRepo.GetQuestionFailureStats(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate question failures: %w", err)
+	}
+	failures := make(map[primitive.ObjectID]repository.QuestionFailureStat, len(failureStats))
+	for _, stat := range failureStats {
+		failures[stat.QuestionID] = stat
+	}
+
+	for _, q := range questions {
+		stat := failures[q.ID]
+		qa := QuestionAnalytics{
+			QuestionID: q.ID,
+			TopicID:    q.TopicID,
+			Text:       q.Text,
+			Answered:   stat.Answered,
+			Failed:     stat.Failed,
+		}
+		if stat.Answered > 0 {
+			qa.FailureRate = float64(stat.Failed) / float64(stat.Answered) * 100
+		}
+		analytics.Questions = append(analytics.Questions, qa)
+	}
+	sort.SliceStable(analytics.Questions, func(i, j int) bool {
+		return analytics.Questions[i].FailureRate > analytics.Questions[j].FailureRate
+	})
+
+	topicStats, err := s.submissionRepo.GetTopicScoreStats(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate topic scores: %w", err)
+	}
+	topicNames := make(map[string]string, len(questionnaire.Topics))
+	for _, t := range questionnaire.Topics {
+		topicNames[t.ID] = t.Name
+	}
+	for _, stat := range topicStats {
+		analytics.Topics = append(analytics.Topics, TopicAnalytics{
+			TopicID:      stat.TopicID,
+			TopicName:    topicNames[stat.TopicID],
+			AverageScore: stat.AverageScore,
+		})
+	}
+	sort.SliceStable(analytics.Topics, func(i, j int) bool {
+		return analytics.Topics[i].AverageScore < analytics.Topics[j].AverageScore
+	})
+
+	return analytics, nil
+}
+
+// SelfTestQuestionnaire scores the company's own answers to its questionnaire without saving anything
+// #BUSINESS_RULE: Uses the same scoring as supplier submissions, so draft questionnaires can be
+// checked before publishing; no response, submission or relationship is involved
+// #BUSINESS_RULE: Missing justification notes are not enforced, the result is only a preview
+func (s *questionnaireService) SelfTestQuestionnaire(ctx context.Context, id, companyID primitive.ObjectID, answers []SubmitAnswerRequest) (*SelfTestResult, error) {
+	questionnaire, err := s.GetQuestionnaire(ctx, id, &companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := s.questionRepo.ListByQuestionnaire(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	questionMap := make(map[string]*models.Question, len(questions))
+	for i := range questions {
+		questionMap[questions[i].ID.Hex()] = &questions[i]
+	}
+
+	scale, err := s.scoringScale(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	submission := &models.QuestionnaireSubmission{
+		QuestionnaireID: id,
+		ScoringScale:    &scale,
+		Answers:         []models.SubmissionAnswer{},
+		TopicScores:     []models.TopicScore{},
+	}
+	scoreAnswers(submission, questionnaire.Topics, questionMap, answers, scale, questionnaire.PassingScore)
+	return &SelfTestResult{Submission: submission, PassingScore: questionnaire.PassingScore}, nil
+}
+
+// validateOptionPoints checks a question's option points against the company's scoring scale
+func (s *questionnaireService) validateOptionPoints(ctx context.Context, companyID primitive.ObjectID, question *models.Question) error {
+	scale, err := s.scoringScale(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	return question.ValidateOptionPoints(scale)
+}
+
+// scoringScale returns the company's scoring scale, or the default one without an organization repository
+func (s *questionnaireService) scoringScale(ctx context.Context, companyID primitive.ObjectID) (models.ScoringScale, error) {
+	if s.orgRepo == nil {
+		return models.DefaultScoringScale(), nil
+	}
+	company, err := s.orgRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return models.ScoringScale{}, fmt.Errorf("failed to get company: %w", err)
+	}
+	return company.Settings.EffectiveScoringScale(), nil
+}
+
 // updateQuestionnaireStats updates the questionnaire's denormalized statistics
 func (s *questionnaireService) updateQuestionnaireStats(ctx context.Context, questionnaireID primitive.ObjectID) {
 	count, err := s.questionRepo.CountByQuestionnaire(ctx, questionnaireID)
@@ -0,0 +1,592 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/database"
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// memoryQuestionRepo stores questions in memory; unused methods are not implemented
+type memoryQuestionRepo struct {
+	repository.QuestionRepository
+	questions map[primitive.ObjectID]models.Question
+}
+
+func (r *memoryQuestionRepo) GetByID(_ context.Context, id primitive.ObjectID) (*models.Question, error) {
+	q, ok := r.questions[id]
+	if !ok {
+		return nil, models.ErrQuestionNotFound
+	}
+	return &q, nil
+}
+
+func (r *memoryQuestionRepo) Update(_ context.Context, question *models.Question) error {
+	r.questions[question.ID] = *question
+	return nil
+}
+
+func (r *memoryQuestionRepo) CountByQuestionnaire(_ context.Context, questionnaireID primitive.ObjectID) (int64, error) {
+	var count int64
+	for _, q := range r.questions {
+		if q.QuestionnaireID == questionnaireID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryQuestionRepo) CalculateMaxScore(_ context.Context, questionnaireID primitive.ObjectID) (int, error) {
+	total := 0
+	for _, q := range r.questions {
+		if q.QuestionnaireID == questionnaireID {
+			total += q.WeightedMaxPoints()
+		}
+	}
+	return total, nil
+}
+
+// memoryQuestionnaireRepo stores questionnaires in memory; unused methods are not implemented
+type memoryQuestionnaireRepo struct {
+	repository.QuestionnaireRepository
+	questionnaires map[primitive.ObjectID]*models.Questionnaire
+}
+
+func (r *memoryQuestionnaireRepo) GetByID(_ context.Context, id primitive.ObjectID) (*models.Questionnaire, error) {
+	q, ok := r.questionnaires[id]
+	if !ok || q.DeletedAt != nil {
+		return nil, models.ErrQuestionnaireNotFound
+	}
+	return q, nil
+}
+
+func (r *memoryQuestionnaireRepo) Delete(_ context.Context, id primitive.ObjectID) error {
+	q, ok := r.questionnaires[id]
+	if !ok || q.DeletedAt != nil || !q.IsDraft() {
+		return models.ErrQuestionnaireNotDeletable
+	}
+	now := time.Now().UTC()
+	q.DeletedAt = &now
+	return nil
+}
+
+func (r *memoryQuestionnaireRepo) Restore(_ context.Context, id, companyID primitive.ObjectID) error {
+	q, ok := r.questionnaires[id]
+	if !ok || q.CompanyID != companyID || q.DeletedAt == nil {
+		return models.ErrQuestionnaireNotFound
+	}
+	q.DeletedAt = nil
+	return nil
+}
+
+func (r *memoryQuestionnaireRepo) ListDeletedBefore(_ context.Context, before time.Time) ([]primitive.ObjectID, error) {
+	var ids []primitive.ObjectID
+	for id, q := range r.questionnaires {
+		if q.DeletedAt != nil && q.DeletedAt.Before(before) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *memoryQuestionnaireRepo) Purge(_ context.Context, id primitive.ObjectID) error {
+	if q, ok := r.questionnaires[id]; !ok || q.DeletedAt == nil {
+		return models.ErrQuestionnaireNotFound
+	}
+	delete(r.questionnaires, id)
+	return nil
+}
+
+func (r *memoryQuestionRepo) DeleteByQuestionnaire(_ context.Context, questionnaireID primitive.ObjectID) (int64, error) {
+	var deleted int64
+	for id, q := range r.questions {
+		if q.QuestionnaireID == questionnaireID {
+			delete(r.questions, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (r *memoryQuestionnaireRepo) UpdateStatistics(_ context.Context, id primitive.ObjectID, questionCount, maxScore int) error {
+	r.questionnaires[id].UpdateStatistics(questionCount, maxScore)
+	return nil
+}
+
+func TestQuestionnaireService_UpdateQuestion_WeightChangesMaxScore(t *testing.T) {
+	companyID := primitive.NewObjectID()
+	questionnaire := &models.Questionnaire{
+		ID:        primitive.NewObjectID(),
+		CompanyID: companyID,
+		Status:    models.QuestionnaireStatusDraft,
+	}
+	question := models.Question{
+		ID:              primitive.NewObjectID(),
+		QuestionnaireID: questionnaire.ID,
+		Type:            models.QuestionTypeSingleChoice,
+		Weight:          1,
+		Options: []models.QuestionOption{
+			{ID: "a", Points: 4},
+			{ID: "b", Points: 10},
+		},
+	}
+
+	questionRepo := &memoryQuestionRepo{questions: map[primitive.ObjectID]models.Question{question.ID: question}}
+	questionnaireRepo := &memoryQuestionnaireRepo{questionnaires: map[primitive.ObjectID]*models.Questionnaire{questionnaire.ID: questionnaire}}
+	service := NewQuestionnaireService(questionnaireRepo, nil, questionRepo, nil, nil)
+
+	weight := 3
+	updated, err := service.UpdateQuestion(context.Background(), question.ID, companyID, UpdateQuestionRequest{Weight: &weight})
+	if err != nil {
+		t.Fatalf("UpdateQuestion failed: %v", err)
+	}
+
+	if updated.MaxPoints != 10 {
+		t.Errorf("Expected MaxPoints 10 (best option), got %d", updated.MaxPoints)
+	}
+	if questionnaire.MaxPossibleScore != 30 {
+		t.Errorf("Expected MaxPossibleScore 30 (weight 3 x 10), got %d", questionnaire.MaxPossibleScore)
+	}
+}
+
+// emptySubmissionRepo reports no submissions; unused methods are not implemented
+type emptySubmissionRepo struct {
+	repository.SubmissionRepository
+}
+
+func (emptySubmissionRepo) GetSummaryByQuestionnaire(context.Context, primitive.ObjectID) (*repository.SubmissionSummary, error) {
+	return &repository.SubmissionSummary{}, nil
+}
+
+func (emptySubmissionRepo) GetQuestionFailureStats(context.Context, primitive.ObjectID) ([]repository.QuestionFailureStat, error) {
+	return nil, nil
+}
+
+func (emptySubmissionRepo) GetTopicScoreStats(context.Context, primitive.ObjectID) ([]repository.TopicScoreStat, error) {
+	return nil, nil
+}
+
+// ListByQuestionnaire returns questions in questionnaire order like the MongoDB repository
+func (r *memoryQuestionRepo) ListByQuestionnaire(_ context.Context, questionnaireID primitive.ObjectID) ([]models.Question, error) {
+	var questions []models.Question
+	for _, q := range r.questions {
+		if q.QuestionnaireID == questionnaireID {
+			questions = append(questions, q)
+		}
+	}
+	sort.Slice(questions, func(i, j int) bool {
+		if questions[i].Order != questions[j].Order {
+			return questions[i].Order < questions[j].Order
+		}
+		return questions[i].ID.Hex() < questions[j].ID.Hex()
+	})
+	return questions, nil
+}
+
+func TestQuestionnaireService_GetQuestionnaireAnalytics_NoSubmissions(t *testing.T) {
+	companyID := primitive.NewObjectID()
+	questionnaire := &models.Questionnaire{ID: primitive.NewObjectID(), CompanyID: companyID}
+	question := models.Question{ID: primitive.NewObjectID(), QuestionnaireID: questionnaire.ID, Text: "MFA enabled?"}
+
+	service := NewQuestionnaireService(
+		&memoryQuestionnaireRepo{questionnaires: map[primitive.ObjectID]*models.Questionnaire{questionnaire.ID: questionnaire}},
+		nil,
+		&memoryQuestionRepo{questions: map[primitive.ObjectID]models.Question{question.ID: question}},
+		emptySubmissionRepo{},
+		nil,
+	)
+
+	analytics, err := service.GetQuestionnaireAnalytics(context.Background(), questionnaire.ID, companyID)
+	if err != nil {
+		t.Fatalf("GetQuestionnaireAnalytics failed: %v", err)
+	}
+	if analytics.Submissions != 0 || analytics.PassRate != 0 || analytics.AverageScore != 0 {
+		t.Errorf("Expected zero analytics, got %+v", analytics)
+	}
+	if len(analytics.Questions) != 1 || analytics.Questions[0].FailureRate != 0 {
+		t.Errorf("Expected the question with a zero failure rate, got %+v", analytics.Questions)
+	}
+
+	if _, err := service.GetQuestionnaireAnalytics(context.Background(), questionnaire.ID, primitive.NewObjectID()); err != ErrQuestionnaireNotFound {
+		t.Errorf("Expected ErrQuestionnaireNotFound for another company, got %v", err)
+	}
+}
+
+func TestQuestionnaireService_DeleteRestoreAndPurge(t *testing.T) {
+	ctx := context.Background()
+	companyID := primitive.NewObjectID()
+	restored := &models.Questionnaire{ID: primitive.NewObjectID(), CompanyID: companyID, Status: models.QuestionnaireStatusDraft}
+	expired := &models.Questionnaire{ID: primitive.NewObjectID(), CompanyID: companyID, Status: models.QuestionnaireStatusDraft}
+	restoredQuestion := models.Question{ID: primitive.NewObjectID(), QuestionnaireID: restored.ID}
+	expiredQuestion := models.Question{ID: primitive.NewObjectID(), QuestionnaireID: expired.ID}
+
+	questionRepo := &memoryQuestionRepo{questions: map[primitive.ObjectID]models.Question{
+		restoredQuestion.ID: restoredQuestion,
+		expiredQuestion.ID:  expiredQuestion,
+	}}
+	questionnaireRepo := &memoryQuestionnaireRepo{questionnaires: map[primitive.ObjectID]*models.Questionnaire{
+		restored.ID: restored,
+		expired.ID:  expired,
+	}}
+	service := NewQuestionnaireService(questionnaireRepo, nil, questionRepo, nil, nil)
+
+	for _, id := range []primitive.ObjectID{restored.ID, expired.ID} {
+		if err := service.DeleteQuestionnaire(ctx, id, companyID); err != nil {
+			t.Fatalf("DeleteQuestionnaire failed: %v", err)
+		}
+	}
+	if _, err := service.GetQuestionnaire(ctx, restored.ID, &companyID); err != ErrQuestionnaireNotFound {
+		t.Errorf("Expected deleted questionnaire to be hidden, got %v", err)
+	}
+
+	if _, err := service.RestoreQuestionnaire(ctx, restored.ID, primitive.NewObjectID()); err != ErrQuestionnaireNotFound {
+		t.Errorf("Expected restore by another company to fail, got %v", err)
+	}
+	if _, err := service.RestoreQuestionnaire(ctx, restored.ID, companyID); err != nil {
+		t.Fatalf("RestoreQuestionnaire failed: %v", err)
+	}
+
+	// Only questionnaires deleted before the retention window are purged
+	purged, err := service.PurgeDeletedQuestionnaires(ctx, 1)
+	if err != nil || purged != 0 {
+		t.Fatalf("Expected nothing purged within retention, got %d, %v", purged, err)
+	}
+	past := time.Now().UTC().AddDate(0, 0, -2)
+	expired.DeletedAt = &past
+
+	purged, err = service.PurgeDeletedQuestionnaires(ctx, 1)
+	if err != nil || purged != 1 {
+		t.Fatalf("Expected 1 questionnaire purged, got %d, %v", purged, err)
+	}
+	if _, ok := questionnaireRepo.questionnaires[expired.ID]; ok {
+		t.Error("Expected expired questionnaire to be purged")
+	}
+	if _, ok := questionRepo.questions[expiredQuestion.ID]; ok {
+		t.Error("Expected questions of the purged questionnaire to be deleted")
+	}
+	if _, ok := questionRepo.questions[restoredQuestion.ID]; !ok {
+		t.Error("Expected questions of the restored questionnaire to be kept")
+	}
+}
+
+func (r *memoryQuestionRepo) DeleteByIDs(_ context.Context, questionnaireID primitive.ObjectID, ids []primitive.ObjectID) (int64, error) {
+	var deleted int64
+	for _, id := range ids {
+		if q, ok := r.questions[id]; ok && q.QuestionnaireID == questionnaireID {
+			delete(r.questions, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (r *memoryQuestionRepo) UpdateOrder(_ context.Context, _ primitive.ObjectID, orders map[primitive.ObjectID]int) error {
+	for id, order := range orders {
+		q := r.questions[id]
+		q.Order = order
+		r.questions[id] = q
+	}
+	return nil
+}
+
+func TestQuestionnaireService_DeleteQuestions(t *testing.T) {
+	ctx := context.Background()
+	companyID := primitive.NewObjectID()
+	questionnaire := &models.Questionnaire{ID: primitive.NewObjectID(), CompanyID: companyID, Status: models.QuestionnaireStatusDraft}
+
+	questionRepo := &memoryQuestionRepo{questions: map[primitive.ObjectID]models.Question{}}
+	ids := make([]primitive.ObjectID, 4)
+	for i := range ids {
+		ids[i] = primitive.NewObjectID()
+		questionRepo.questions[ids[i]] = models.Question{
+			ID:              ids[i],
+			QuestionnaireID: questionnaire.ID,
+			Type:            models.QuestionTypeYesNo,
+			Order:           i + 1,
+			MaxPoints:       10,
+			Weight:          1,
+		}
+	}
+	questionnaireRepo := &memoryQuestionnaireRepo{questionnaires: map[primitive.ObjectID]*models.Questionnaire{questionnaire.ID: questionnaire}}
+	service := NewQuestionnaireService(questionnaireRepo, nil, questionRepo, nil, nil)
+
+	// A question of another questionnaire aborts the whole request
+	if _, err := service.DeleteQuestions(ctx, questionnaire.ID, companyID, []primitive.ObjectID{ids[0], primitive.NewObjectID()}); err != ErrQuestionNotFound {
+		t.Fatalf("Expected ErrQuestionNotFound, got %v", err)
+	}
+	if len(questionRepo.questions) != 4 {
+		t.Fatalf("Expected no questions deleted, %d remain", len(questionRepo.questions))
+	}
+
+	deleted, err := service.DeleteQuestions(ctx, questionnaire.ID, companyID, []primitive.ObjectID{ids[0], ids[2], ids[2]})
+	if err != nil {
+		t.Fatalf("DeleteQuestions failed: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("Expected 2 questions deleted, got %d", deleted)
+	}
+	if got := questionRepo.questions[ids[1]].Order; got != 1 {
+		t.Errorf("Expected second question to become order 1, got %d", got)
+	}
+	if got := questionRepo.questions[ids[3]].Order; got != 2 {
+		t.Errorf("Expected fourth question to become order 2, got %d", got)
+	}
+	if questionnaire.QuestionCount != 2 {
+		t.Errorf("Expected QuestionCount 2, got %d", questionnaire.QuestionCount)
+	}
+}
+
+func (r *memoryQuestionRepo) ListByQuestionnaireAndTopic(_ context.Context, questionnaireID primitive.ObjectID, topicID string) ([]models.Question, error) {
+	var questions []models.Question
+	for _, q := range r.questions {
+		if q.QuestionnaireID == questionnaireID && q.TopicID == topicID {
+			questions = append(questions, q)
+		}
+	}
+	return questions, nil
+}
+
+func TestQuestionnaireService_ListQuestions(t *testing.T) {
+	ctx := context.Background()
+	companyID := primitive.NewObjectID()
+	questionnaire := &models.Questionnaire{ID: primitive.NewObjectID(), CompanyID: companyID, Status: models.QuestionnaireStatusDraft}
+
+	questionRepo := &memoryQuestionRepo{questions: map[primitive.ObjectID]models.Question{}}
+	for i, topic := range []string{"gov", "gov", "inc"} {
+		id := primitive.NewObjectID()
+		questionRepo.questions[id] = models.Question{ID: id, QuestionnaireID: questionnaire.ID, TopicID: topic, Order: i + 1}
+	}
+	questionnaireRepo := &memoryQuestionnaireRepo{questionnaires: map[primitive.ObjectID]*models.Questionnaire{questionnaire.ID: questionnaire}}
+	service := NewQuestionnaireService(questionnaireRepo, nil, questionRepo, nil, nil)
+
+	all, err := service.ListQuestions(ctx, questionnaire.ID, companyID, "")
+	if err != nil {
+		t.Fatalf("ListQuestions failed: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("Expected 3 questions, got %d", len(all))
+	}
+
+	gov, err := service.ListQuestions(ctx, questionnaire.ID, companyID, "gov")
+	if err != nil {
+		t.Fatalf("ListQuestions failed: %v", err)
+	}
+	if len(gov) != 2 {
+		t.Errorf("Expected 2 questions for topic gov, got %d", len(gov))
+	}
+
+	if _, err := service.ListQuestions(ctx, questionnaire.ID, primitive.NewObjectID(), ""); err != ErrQuestionnaireNotFound {
+		t.Errorf("Expected ErrQuestionnaireNotFound for another company, got %v", err)
+	}
+}
+
+func (r *memoryQuestionnaireRepo) Create(_ context.Context, questionnaire *models.Questionnaire) error {
+	r.questionnaires[questionnaire.ID] = questionnaire
+	return nil
+}
+
+func TestQuestionnaireService_CreateQuestionnaire_OrganizationDefaults(t *testing.T) {
+	ctx := context.Background()
+	strictID := primitive.NewObjectID()
+	plainID := primitive.NewObjectID()
+	strict := &models.Organization{ID: strictID, Settings: models.DefaultOrganizationSettings()}
+	strict.Settings.DefaultPassingScore = 85
+	strict.Settings.DefaultScoringMode = models.ScoringModePoints
+	plain := &models.Organization{ID: plainID, Settings: models.DefaultOrganizationSettings()}
+
+	orgRepo := &memoryOrganizationRepo{orgs: map[primitive.ObjectID]*models.Organization{strictID: strict, plainID: plain}}
+	questionnaireRepo := &memoryQuestionnaireRepo{questionnaires: map[primitive.ObjectID]*models.Questionnaire{}}
+	service := NewQuestionnaireService(questionnaireRepo, nil, nil, nil, orgRepo)
+
+	tests := []struct {
+		name      string
+		companyID primitive.ObjectID
+		req       CreateQuestionnaireRequest
+		wantScore int
+		wantMode  models.ScoringMode
+	}{
+		{"organization defaults", strictID, CreateQuestionnaireRequest{Name: "Q"}, 85, models.ScoringModePoints},
+		{"request wins", strictID, CreateQuestionnaireRequest{Name: "Q", PassingScore: 60, ScoringMode: models.ScoringModePercentage}, 60, models.ScoringModePercentage},
+		{"built-in fallback", plainID, CreateQuestionnaireRequest{Name: "Q"}, models.DefaultQuestionnairePassingScore, models.ScoringModePercentage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questionnaire, err := service.CreateQuestionnaire(ctx, tt.companyID, tt.req)
+			if err != nil {
+				t.Fatalf("CreateQuestionnaire failed: %v", err)
+			}
+			if questionnaire.PassingScore != tt.wantScore || questionnaire.ScoringMode != tt.wantMode {
+				t.Errorf("Expected %d/%s, got %d/%s", tt.wantScore, tt.wantMode, questionnaire.PassingScore, questionnaire.ScoringMode)
+			}
+		})
+	}
+}
+
+// TestQuestionnaireService_CreateFromTemplate_ConcurrentUsageCount needs a real MongoDB because
+// the guarantee comes from the server-side $inc; set NISFIX_TEST_DATABASE_URI to run it
+func TestQuestionnaireService_CreateFromTemplate_ConcurrentUsageCount(t *testing.T) {
+	uri := os.Getenv("NISFIX_TEST_DATABASE_URI")
+	if uri == "" {
+		t.Skip("NISFIX_TEST_DATABASE_URI not set")
+	}
+
+	cfg := database.DefaultConfig()
+	cfg.URI = uri
+	cfg.Database = fmt.Sprintf("nisfix_test_%d", time.Now().UnixNano())
+	client, err := database.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	ctx := context.Background()
+	defer client.Close(ctx)        //nolint:errcheck // test cleanup
+	defer client.DropDatabase(ctx) //nolint:errcheck // test cleanup
+
+	templateRepo := repository.NewQuestionnaireTemplateRepository(client)
+	template := &models.QuestionnaireTemplate{Name: "Baseline", Category: models.TemplateCategoryNIS2}
+	template.BeforeCreate()
+	if err := templateRepo.Create(ctx, template); err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+
+	service := NewQuestionnaireService(repository.NewQuestionnaireRepository(client), templateRepo, nil, nil, nil)
+
+	const creations = 25
+	var wg sync.WaitGroup
+	errs := make([]error, creations)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = service.CreateFromTemplate(ctx, primitive.NewObjectID(), template.ID, fmt.Sprintf("Questionnaire %d", i))
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("CreateFromTemplate failed: %v", err)
+		}
+	}
+
+	stored, err := templateRepo.GetByID(ctx, template.ID)
+	if err != nil {
+		t.Fatalf("Failed to reload template: %v", err)
+	}
+	if stored.UsageCount != creations {
+		t.Errorf("Expected usage count %d, got %d", creations, stored.UsageCount)
+	}
+}
+
+func TestQuestionnaireService_SelfTestQuestionnaire(t *testing.T) {
+	ctx := context.Background()
+	companyID := primitive.NewObjectID()
+	questionnaire := &models.Questionnaire{
+		ID:           primitive.NewObjectID(),
+		CompanyID:    companyID,
+		Status:       models.QuestionnaireStatusDraft,
+		PassingScore: 40,
+		Topics:       []models.QuestionnaireTopic{{ID: "gov", Name: "Governance"}, {ID: "inc", Name: "Incidents"}},
+	}
+
+	gov := models.Question{ID: primitive.NewObjectID(), QuestionnaireID: questionnaire.ID, TopicID: "gov", Type: models.QuestionTypeText, MaxPoints: 10, Scored: true}
+	inc := models.Question{ID: primitive.NewObjectID(), QuestionnaireID: questionnaire.ID, TopicID: "inc", Type: models.QuestionTypeText, MaxPoints: 10, IsMustPass: true, Scored: true}
+	questionRepo := &memoryQuestionRepo{questions: map[primitive.ObjectID]models.Question{gov.ID: gov, inc.ID: inc}}
+	questionnaireRepo := &memoryQuestionnaireRepo{questionnaires: map[primitive.ObjectID]*models.Questionnaire{questionnaire.ID: questionnaire}}
+	// No submission repository: a self-test must not store anything
+	service := NewQuestionnaireService(questionnaireRepo, nil, questionRepo, nil, nil)
+
+	answers := []SubmitAnswerRequest{
+		{QuestionID: gov.ID.Hex(), TextAnswer: "Policy reviewed yearly"},
+		{QuestionID: inc.ID.Hex()},
+		{QuestionID: primitive.NewObjectID().Hex(), TextAnswer: "ignored"},
+	}
+	result, err := service.SelfTestQuestionnaire(ctx, questionnaire.ID, companyID, answers)
+	if err != nil {
+		t.Fatalf("SelfTestQuestionnaire failed: %v", err)
+	}
+
+	submission := result.Submission
+	if submission.TotalScore != 10 || submission.MaxPossibleScore != 20 || len(submission.Answers) != 2 {
+		t.Errorf("Expected 10/20 over 2 answers, got %d/%d over %d", submission.TotalScore, submission.MaxPossibleScore, len(submission.Answers))
+	}
+	// 50% clears the passing score, but the failed must-pass question still fails it
+	if submission.Passed || !submission.MustPassFailed || result.PassingScore != 40 {
+		t.Errorf("Expected a must-pass failure against passing score 40, got %+v", result)
+	}
+	if len(submission.TopicScores) != 2 || submission.TopicScores[0].TopicID != "gov" || submission.TopicScores[1].TopicID != "inc" {
+		t.Errorf("Expected topic scores in questionnaire order, got %+v", submission.TopicScores)
+	}
+
+	if _, err := service.SelfTestQuestionnaire(ctx, questionnaire.ID, primitive.NewObjectID(), answers); err != ErrQuestionnaireNotFound {
+		t.Errorf("Expected ErrQuestionnaireNotFound for another company, got %v", err)
+	}
+}
+
+func (r *memoryQuestionnaireRepo) ListPublishedByClassification(_ context.Context, companyID primitive.ObjectID, classification models.SupplierClassification) ([]models.Questionnaire, error) {
+	var result []models.Questionnaire
+	for _, q := range r.questionnaires {
+		if q.CompanyID != companyID || q.DeletedAt != nil || q.Status != models.QuestionnaireStatusPublished {
+			continue
+		}
+		for _, c := range q.AppliesTo {
+			if c == classification {
+				result = append(result, *q)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func TestQuestionnaireService_ListRecommended(t *testing.T) {
+	ctx := context.Background()
+	companyID := primitive.NewObjectID()
+	questionnaireRepo := &memoryQuestionnaireRepo{questionnaires: map[primitive.ObjectID]*models.Questionnaire{}}
+	service := NewQuestionnaireService(questionnaireRepo, nil, nil, nil, nil)
+
+	targeted, err := service.CreateQuestionnaire(ctx, companyID, CreateQuestionnaireRequest{
+		Name:      "NIS2 critical suppliers",
+		AppliesTo: []models.SupplierClassification{models.SupplierClassificationCritical, models.SupplierClassificationCritical, models.SupplierClassificationImportant},
+	})
+	if err != nil {
+		t.Fatalf("CreateQuestionnaire failed: %v", err)
+	}
+	if len(targeted.AppliesTo) != 2 {
+		t.Errorf("Expected duplicate classifications to be dropped, got %v", targeted.AppliesTo)
+	}
+	draft, err := service.CreateQuestionnaire(ctx, companyID, CreateQuestionnaireRequest{
+		Name:      "Draft",
+		AppliesTo: []models.SupplierClassification{models.SupplierClassificationCritical},
+	})
+	if err != nil {
+		t.Fatalf("CreateQuestionnaire failed: %v", err)
+	}
+	targeted.Status = models.QuestionnaireStatusPublished
+
+	if _, err := service.CreateQuestionnaire(ctx, companyID, CreateQuestionnaireRequest{
+		Name:      "Bad",
+		AppliesTo: []models.SupplierClassification{"VITAL"},
+	}); err != ErrInvalidClassification {
+		t.Errorf("Expected ErrInvalidClassification, got %v", err)
+	}
+
+	recommended, err := service.ListRecommended(ctx, companyID, models.SupplierClassificationCritical)
+	if err != nil || len(recommended) != 1 || recommended[0].ID != targeted.ID {
+		t.Errorf("Expected only the published targeted questionnaire and not %s, got %v and %v", draft.ID.Hex(), recommended, err)
+	}
+	if recommended, err := service.ListRecommended(ctx, companyID, models.SupplierClassificationStandard); err != nil || recommended == nil || len(recommended) != 0 {
+		t.Errorf("Expected an empty list for standard suppliers, got %v and %v", recommended, err)
+	}
+	if _, err := service.ListRecommended(ctx, companyID, ""); err != ErrInvalidClassification {
+		t.Errorf("Expected ErrInvalidClassification for a missing classification, got %v", err)
+	}
+}
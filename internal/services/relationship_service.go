@@ -5,7 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
@@ -22,8 +24,12 @@ var (
 	ErrSupplierNotFound         = errors.New("supplier not found")
 	ErrNotPendingInvitation     = errors.New("invitation is not pending")
 	ErrInvalidClassification    = errors.New("invalid supplier classification")
+	ErrInvalidNote              = errors.New("note text is required and must not exceed 5000 characters")
 )
 
+// maxNoteLength bounds a single relationship note in characters
+const maxNoteLength = 5000
+
 // RelationshipService handles supplier relationship business logic
 // #INTEGRATION_POINT: Used by relationship handler for supplier management
 type RelationshipService interface {
@@ -36,6 +42,12 @@ type RelationshipService interface {
 	// ListCompanySuppliers lists suppliers for a company
 	ListCompanySuppliers(ctx context.Context, companyID primitive.ObjectID, filters SupplierFilters, opts repository.PaginationOptions) (*repository.PaginatedResult[models.CompanySupplierRelationship], error)
 
+	// ListCompanySuppliersCursor lists suppliers for a company using keyset pagination
+	ListCompanySuppliersCursor(ctx context.Context, companyID primitive.ObjectID, filters SupplierFilters, opts repository.CursorPaginationOptions) (*repository.CursorResult[models.CompanySupplierRelationship], error)
+
+	// ExportCompanySuppliers streams the company's full supplier list to fn in batches
+	ExportCompanySuppliers(ctx context.Context, companyID primitive.ObjectID, filters SupplierFilters, fn func([]SupplierExportRow) error) error
+
 	// ListPendingInvitations lists pending invitations for a supplier email
 	ListPendingInvitations(ctx context.Context, email string) ([]models.CompanySupplierRelationship, error)
 
@@ -51,6 +63,12 @@ type RelationshipService interface {
 	// UpdateDetails updates relationship details (notes, services, contract ref)
 	UpdateDetails(ctx context.Context, relationshipID, companyID primitive.ObjectID, req UpdateRelationshipRequest) (*models.CompanySupplierRelationship, error)
 
+	// AddNote appends a note to the relationship's note history
+	AddNote(ctx context.Context, relationshipID, companyID, authorID primitive.ObjectID, text string) (*models.RelationshipNote, error)
+
+	// ListNotes returns the relationship's note history, oldest first
+	ListNotes(ctx context.Context, relationshipID, companyID primitive.ObjectID) ([]models.RelationshipNote, error)
+
 	// SuspendRelationship suspends a supplier relationship
 	SuspendRelationship(ctx context.Context, relationshipID, companyID, userID primitive.ObjectID, reason string) (*models.CompanySupplierRelationship, error)
 
@@ -60,6 +78,9 @@ type RelationshipService interface {
 	// TerminateRelationship terminates a relationship
 	TerminateRelationship(ctx context.Context, relationshipID, companyID, userID primitive.ObjectID, reason string) (*models.CompanySupplierRelationship, error)
 
+	// SendSupplierLoginLink sends the supplier contact a magic link on behalf of the company
+	SendSupplierLoginLink(ctx context.Context, relationshipID, companyID, userID primitive.ObjectID) error
+
 	// GetSupplierStats returns supplier statistics for a company
 	GetSupplierStats(ctx context.Context, companyID primitive.ObjectID) (*SupplierStats, error)
 }
@@ -82,11 +103,27 @@ type UpdateRelationshipRequest struct {
 
 // SupplierFilters contains filters for listing suppliers
 type SupplierFilters struct {
-	Status         *models.RelationshipStatus
+	// Statuses matches suppliers in any of the statuses; empty matches all
+	Statuses       []models.RelationshipStatus
 	Classification *models.SupplierClassification
 	Search         string
 }
 
+// SupplierExportRow is one supplier in a supplier list export
+type SupplierExportRow struct {
+	SupplierName        string                        `json:"supplier_name"`
+	Email               string                        `json:"email"`
+	Classification      models.SupplierClassification `json:"classification"`
+	Status              models.RelationshipStatus     `json:"status"`
+	ContractRef         string                        `json:"contract_ref"`
+	InvitedAt           time.Time                     `json:"invited_at"`
+	AcceptedAt          *time.Time                    `json:"accepted_at"`
+	PendingRequirements int64                         `json:"pending_requirements"`
+}
+
+// supplierExportBatchSize is the number of relationships enriched and written per batch
+const supplierExportBatchSize = 200
+
 // SupplierStats contains supplier statistics
 type SupplierStats struct {
 	Total     int64 `json:"total"`
@@ -101,25 +138,37 @@ type SupplierStats struct {
 // relationshipService implements RelationshipService
 type relationshipService struct {
 	relationshipRepo repository.RelationshipRepository
+	requirementRepo  repository.RequirementRepository
 	orgRepo          repository.OrganizationRepository
 	userRepo         repository.UserRepository
-	mailService      MailService
+	mailOutbox       EmailOutbox
+	auditService     AuditService
+	webhooks         WebhookDispatcher
+	authService      AuthService
 	inviteBaseURL    string
 }
 
 // NewRelationshipService creates a new relationship service
 func NewRelationshipService(
 	relationshipRepo repository.RelationshipRepository,
+	requirementRepo repository.RequirementRepository,
 	orgRepo repository.OrganizationRepository,
 	userRepo repository.UserRepository,
-	mailService MailService,
+	mailOutbox EmailOutbox,
+	auditService AuditService,
+	webhooks WebhookDispatcher,
+	authService AuthService,
 	inviteBaseURL string,
 ) RelationshipService {
 	return &relationshipService{
 		relationshipRepo: relationshipRepo,
+		requirementRepo:  requirementRepo,
 		orgRepo:          orgRepo,
 		userRepo:         userRepo,
-		mailService:      mailService,
+		mailOutbox:       mailOutbox,
+		auditService:     auditService,
+		webhooks:         webhooks,
+		authService:      authService,
 		inviteBaseURL:    inviteBaseURL,
 	}
 }
@@ -129,7 +178,7 @@ func NewRelationshipService(
 // #BUSINESS_RULE: Duplicate invitations to same email from same company are not allowed
 func (s *relationshipService) InviteSupplier(ctx context.Context, companyID, inviterUserID primitive.ObjectID, req InviteSupplierRequest) (*models.CompanySupplierRelationship, error) {
 	// Normalize email
-	email := strings.ToLower(strings.TrimSpace(req.Email))
+	email := models.NormalizeEmail(req.Email)
 
 	// Check for existing relationship with this email
 	existing, err := s.relationshipRepo.GetByInvitedEmail(ctx, email, companyID)
@@ -167,14 +216,22 @@ func (s *relationshipService) InviteSupplier(ctx context.Context, companyID, inv
 		return nil, fmt.Errorf("failed to create relationship: %w", err)
 	}
 
-	// Send invitation email
-	// #IMPLEMENTATION_DECISION: Non-blocking email send - log error but don't fail
+	// Queue invitation email
+	// #IMPLEMENTATION_DECISION: The outbox worker sends and retries the email; a queueing
+	// failure is logged but doesn't fail the invitation
+	// #BUSINESS_RULE: Existing users get their own language, new contacts the inviting company's default
 	inviteURL := fmt.Sprintf("%s/supplier/invitations", s.inviteBaseURL)
-	if err := s.mailService.SendInvitation(ctx, email, company.Name, inviteURL); err != nil {
-		// Log error but don't fail the operation
-		// #TECHNICAL_DEBT: Should queue email for retry
+	language := company.Settings.DefaultLanguage
+	if invitee, err := s.userRepo.GetByEmail(ctx, email); err == nil {
+		language = invitee.Language
+	}
+	if err := s.mailOutbox.EnqueueInvitation(ctx, companyID, email, company.Name, inviteURL, language); err != nil {
+		log.Printf("Failed to queue invitation to %s: %v", email, err)
 	}
 
+	logAudit(s.auditService, newAuditEntry(ctx, inviterUserID, companyID, models.AuditActionInvite,
+		models.ResourceTypeRelationship, relationship.ID, fmt.Sprintf("Invited supplier: %s", email)))
+
 	return relationship, nil
 }
 
@@ -198,12 +255,69 @@ func (s *relationshipService) GetRelationship(ctx context.Context, id primitive.
 
 // ListCompanySuppliers lists suppliers for a company
 func (s *relationshipService) ListCompanySuppliers(ctx context.Context, companyID primitive.ObjectID, filters SupplierFilters, opts repository.PaginationOptions) (*repository.PaginatedResult[models.CompanySupplierRelationship], error) {
-	return s.relationshipRepo.ListByCompany(ctx, companyID, filters.Status, filters.Classification, opts)
+	return s.relationshipRepo.ListByCompany(ctx, companyID, filters.Statuses, filters.Classification, opts)
+}
+
+// ListCompanySuppliersCursor lists suppliers for a company using keyset pagination
+func (s *relationshipService) ListCompanySuppliersCursor(ctx context.Context, companyID primitive.ObjectID, filters SupplierFilters, opts repository.CursorPaginationOptions) (*repository.CursorResult[models.CompanySupplierRelationship], error) {
+	return s.relationshipRepo.ListByCompanyCursor(ctx, companyID, filters.Statuses, filters.Classification, opts)
+}
+
+// ExportCompanySuppliers streams the company's full supplier list to fn in batches
+// #QUERY_PATTERN: Per batch, one organization lookup and one requirement count aggregation
+// #IMPLEMENTATION_DECISION: Batches keep memory flat for large supplier lists
+func (s *relationshipService) ExportCompanySuppliers(ctx context.Context, companyID primitive.ObjectID, filters SupplierFilters, fn func([]SupplierExportRow) error) error {
+	pending := models.RequirementStatusPending
+
+	return s.relationshipRepo.ForEachByCompany(ctx, companyID, filters.Statuses, filters.Classification, supplierExportBatchSize,
+		func(relationships []models.CompanySupplierRelationship) error {
+			relationshipIDs := make([]primitive.ObjectID, len(relationships))
+			var supplierIDs []primitive.ObjectID
+			for i := range relationships {
+				relationshipIDs[i] = relationships[i].ID
+				if relationships[i].SupplierID != nil {
+					supplierIDs = append(supplierIDs, *relationships[i].SupplierID)
+				}
+			}
+
+			suppliers := map[primitive.ObjectID]*models.Organization{}
+			if len(supplierIDs) > 0 {
+				var err error
+				if suppliers, err = s.orgRepo.GetByIDs(ctx, supplierIDs); err != nil {
+					return fmt.Errorf("failed to get supplier organizations: %w", err)
+				}
+			}
+
+			pendingCounts, err := s.requirementRepo.CountByRelationships(ctx, relationshipIDs, &pending)
+			if err != nil {
+				return fmt.Errorf("failed to count pending requirements: %w", err)
+			}
+
+			rows := make([]SupplierExportRow, len(relationships))
+			for i := range relationships {
+				r := &relationships[i]
+				rows[i] = SupplierExportRow{
+					Email:               r.InvitedEmail,
+					Classification:      r.Classification,
+					Status:              r.Status,
+					ContractRef:         r.ContractRef,
+					InvitedAt:           r.InvitedAt,
+					AcceptedAt:          r.AcceptedAt,
+					PendingRequirements: pendingCounts[r.ID],
+				}
+				if r.SupplierID != nil {
+					if org, ok := suppliers[*r.SupplierID]; ok {
+						rows[i].SupplierName = org.Name
+					}
+				}
+			}
+			return fn(rows)
+		})
 }
 
 // ListPendingInvitations lists pending invitations for a supplier email
 func (s *relationshipService) ListPendingInvitations(ctx context.Context, email string) ([]models.CompanySupplierRelationship, error) {
-	email = strings.ToLower(strings.TrimSpace(email))
+	email = models.NormalizeEmail(email)
 	return s.relationshipRepo.ListPendingByEmail(ctx, email)
 }
 
@@ -231,6 +345,12 @@ func (s *relationshipService) AcceptInvitation(ctx context.Context, relationship
 		return nil, fmt.Errorf("failed to update relationship: %w", err)
 	}
 
+	dispatchWebhook(ctx, s.webhooks, relationship.CompanyID, models.WebhookEventRelationshipAccepted, RelationshipWebhookData{
+		RelationshipID: relationship.ID.Hex(),
+		SupplierID:     supplierID.Hex(),
+		Status:         string(relationship.Status),
+	})
+
 	return relationship, nil
 }
 
@@ -289,6 +409,11 @@ func (s *relationshipService) UpdateClassification(ctx context.Context, relation
 		return nil, fmt.Errorf("failed to update relationship: %w", err)
 	}
 
+	entry := newAuditEntry(ctx, primitive.NilObjectID, companyID, models.AuditActionUpdate,
+		models.ResourceTypeRelationship, relationship.ID, "Updated supplier classification")
+	entry.Changes = map[string]interface{}{"classification": classification}
+	logAudit(s.auditService, entry)
+
 	return relationship, nil
 }
 
@@ -329,9 +454,52 @@ func (s *relationshipService) UpdateDetails(ctx context.Context, relationshipID,
 		return nil, fmt.Errorf("failed to update relationship: %w", err)
 	}
 
+	logAudit(s.auditService, newAuditEntry(ctx, primitive.NilObjectID, companyID, models.AuditActionUpdate,
+		models.ResourceTypeRelationship, relationship.ID, "Updated relationship details"))
+
 	return relationship, nil
 }
 
+// AddNote appends a note to the relationship's note history
+// #BUSINESS_RULE: Notes are append-only so the relationship timeline stays auditable
+// #BUSINESS_RULE: Notes may still be added to terminated relationships, e.g. to record offboarding
+func (s *relationshipService) AddNote(ctx context.Context, relationshipID, companyID, authorID primitive.ObjectID, text string) (*models.RelationshipNote, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || len([]rune(text)) > maxNoteLength {
+		return nil, ErrInvalidNote
+	}
+
+	note := models.RelationshipNote{
+		Text:      text,
+		AuthorID:  authorID,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := s.relationshipRepo.AddNote(ctx, relationshipID, companyID, note); err != nil {
+		if errors.Is(err, models.ErrRelationshipNotFound) {
+			return nil, ErrRelationshipNotFound
+		}
+		return nil, fmt.Errorf("failed to add note: %w", err)
+	}
+
+	logAudit(s.auditService, newAuditEntry(ctx, authorID, companyID, models.AuditActionUpdate,
+		models.ResourceTypeRelationship, relationshipID, "Added relationship note"))
+
+	return &note, nil
+}
+
+// ListNotes returns the relationship's note history, oldest first
+func (s *relationshipService) ListNotes(ctx context.Context, relationshipID, companyID primitive.ObjectID) ([]models.RelationshipNote, error) {
+	relationship, err := s.GetRelationship(ctx, relationshipID, &companyID)
+	if err != nil {
+		return nil, err
+	}
+	if relationship.NoteHistory == nil {
+		return []models.RelationshipNote{}, nil
+	}
+	return relationship.NoteHistory, nil
+}
+
 // SuspendRelationship suspends a supplier relationship
 func (s *relationshipService) SuspendRelationship(ctx context.Context, relationshipID, companyID, userID primitive.ObjectID, reason string) (*models.CompanySupplierRelationship, error) {
 	relationship, err := s.relationshipRepo.GetByID(ctx, relationshipID)
@@ -355,6 +523,11 @@ func (s *relationshipService) SuspendRelationship(ctx context.Context, relations
 		return nil, fmt.Errorf("failed to update relationship: %w", err)
 	}
 
+	entry := newAuditEntry(ctx, userID, companyID, models.AuditActionSuspend,
+		models.ResourceTypeRelationship, relationship.ID, "Suspended supplier relationship")
+	entry.Changes = map[string]interface{}{"reason": reason}
+	logAudit(s.auditService, entry)
+
 	return relationship, nil
 }
 
@@ -381,10 +554,17 @@ func (s *relationshipService) ReactivateRelationship(ctx context.Context, relati
 		return nil, fmt.Errorf("failed to update relationship: %w", err)
 	}
 
+	entry := newAuditEntry(ctx, userID, companyID, models.AuditActionActivate,
+		models.ResourceTypeRelationship, relationship.ID, "Reactivated supplier relationship")
+	entry.Changes = map[string]interface{}{"reason": reason}
+	logAudit(s.auditService, entry)
+
 	return relationship, nil
 }
 
 // TerminateRelationship terminates a relationship
+// #BUSINESS_RULE: The relationship's open requirements are closed as expired or cancelled, per the
+// company's settings, so they no longer count as outstanding
 func (s *relationshipService) TerminateRelationship(ctx context.Context, relationshipID, companyID, userID primitive.ObjectID, reason string) (*models.CompanySupplierRelationship, error) {
 	relationship, err := s.relationshipRepo.GetByID(ctx, relationshipID)
 	if err != nil {
@@ -403,13 +583,101 @@ func (s *relationshipService) TerminateRelationship(ctx context.Context, relatio
 		return nil, ErrInvalidStatusTransition
 	}
 
+	// Requirements are closed first so a failure leaves the relationship active and the call can be retried
+	closed, err := s.closeOpenRequirements(ctx, relationship, userID, reason)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := s.relationshipRepo.Update(ctx, relationship); err != nil {
 		return nil, fmt.Errorf("failed to update relationship: %w", err)
 	}
 
+	entry := newAuditEntry(ctx, userID, companyID, models.AuditActionTerminate,
+		models.ResourceTypeRelationship, relationship.ID, "Terminated supplier relationship")
+	entry.Changes = map[string]interface{}{"reason": reason, "closed_requirements": closed}
+	logAudit(s.auditService, entry)
+
 	return relationship, nil
 }
 
+// closeOpenRequirements moves every non-terminal requirement of a relationship to the company's
+// configured termination status and returns how many were closed
+func (s *relationshipService) closeOpenRequirements(ctx context.Context, relationship *models.CompanySupplierRelationship, userID primitive.ObjectID, reason string) (int, error) {
+	requirements, err := s.requirementRepo.ListByRelationship(ctx, relationship.ID, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list requirements: %w", err)
+	}
+
+	var open []*models.Requirement
+	for i := range requirements {
+		if !requirements[i].Status.IsTerminal() {
+			open = append(open, &requirements[i])
+		}
+	}
+	if len(open) == 0 {
+		return 0, nil
+	}
+
+	company, err := s.orgRepo.GetByID(ctx, relationship.CompanyID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get company: %w", err)
+	}
+	status := company.Settings.EffectiveTerminatedRequirementStatus()
+
+	historyReason := "Relationship terminated"
+	if reason != "" {
+		historyReason += ": " + reason
+	}
+	for _, requirement := range open {
+		if err := requirement.CloseForTermination(status, userID, historyReason); err != nil {
+			return 0, fmt.Errorf("failed to close requirement %s: %w", requirement.ID.Hex(), err)
+		}
+		if err := s.requirementRepo.Update(ctx, requirement); err != nil {
+			return 0, fmt.Errorf("failed to update requirement: %w", err)
+		}
+	}
+	return len(open), nil
+}
+
+// SendSupplierLoginLink sends the supplier contact a magic link on behalf of the company
+// #BUSINESS_RULE: Only for active relationships, and only to the invited contact when that user
+// belongs to the supplier organization
+// #SECURITY_CONCERN: Succeeds silently whether or not a link was sent, so the caller learns nothing
+// about the contact's account beyond what the relationship already shows
+func (s *relationshipService) SendSupplierLoginLink(ctx context.Context, relationshipID, companyID, userID primitive.ObjectID) error {
+	relationship, err := s.relationshipRepo.GetByID(ctx, relationshipID)
+	if err != nil {
+		if errors.Is(err, models.ErrRelationshipNotFound) {
+			return ErrRelationshipNotFound
+		}
+		return fmt.Errorf("failed to get relationship: %w", err)
+	}
+
+	// Verify company ownership
+	if relationship.CompanyID != companyID {
+		return ErrRelationshipNotFound
+	}
+
+	if !relationship.IsActive() || relationship.SupplierID == nil {
+		return ErrRelationshipNotActive
+	}
+
+	logAudit(s.auditService, newAuditEntry(ctx, userID, companyID, models.AuditActionNotify,
+		models.ResourceTypeRelationship, relationship.ID, "Sent supplier login link"))
+
+	contact, err := s.userRepo.GetByEmail(ctx, relationship.InvitedEmail)
+	if err != nil || contact.OrganizationID != *relationship.SupplierID {
+		return nil //nolint:nilerr // Security: intentional to prevent user enumeration
+	}
+
+	// The per-email magic link limit only trips for existing accounts, so it is not surfaced
+	if err := s.authService.RequestMagicLink(ctx, contact.Email); err != nil && !errors.Is(err, ErrRateLimitExceeded) {
+		return fmt.Errorf("failed to send login link: %w", err)
+	}
+	return nil
+}
+
 // GetSupplierStats returns supplier statistics for a company
 func (s *relationshipService) GetSupplierStats(ctx context.Context, companyID primitive.ObjectID) (*SupplierStats, error) {
 	total, err := s.relationshipRepo.CountByCompany(ctx, companyID, nil)
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// memoryRelationshipRepo keeps relationships by ID; other methods are not used
+type memoryRelationshipRepo struct {
+	repository.RelationshipRepository
+	relationships map[primitive.ObjectID]*models.CompanySupplierRelationship
+}
+
+func (r *memoryRelationshipRepo) GetByID(_ context.Context, id primitive.ObjectID) (*models.CompanySupplierRelationship, error) {
+	if relationship, ok := r.relationships[id]; ok {
+		clone := *relationship
+		return &clone, nil
+	}
+	return nil, models.ErrRelationshipNotFound
+}
+
+// recordingMagicLinkAuthService records magic link requests; other methods are not used
+type recordingMagicLinkAuthService struct {
+	AuthService
+	emails []string
+	err    error
+}
+
+func (a *recordingMagicLinkAuthService) RequestMagicLink(_ context.Context, email string) error {
+	a.emails = append(a.emails, email)
+	return a.err
+}
+
+func TestRelationshipService_SendSupplierLoginLink(t *testing.T) {
+	ctx := context.Background()
+	companyID, adminID, supplierID := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	contact := &models.User{ID: primitive.NewObjectID(), Email: "it@acme.example", OrganizationID: supplierID}
+	outsider := &models.User{ID: primitive.NewObjectID(), Email: "ops@other.example", OrganizationID: primitive.NewObjectID()}
+
+	newRelationship := func(email string, status models.RelationshipStatus) *models.CompanySupplierRelationship {
+		return &models.CompanySupplierRelationship{ID: primitive.NewObjectID(), CompanyID: companyID, SupplierID: &supplierID, InvitedEmail: email, Status: status}
+	}
+	active := newRelationship(contact.Email, models.RelationshipStatusActive)
+	movedOn := newRelationship(outsider.Email, models.RelationshipStatusActive)
+	pending := newRelationship(contact.Email, models.RelationshipStatusPending)
+
+	relationships := &memoryRelationshipRepo{relationships: map[primitive.ObjectID]*models.CompanySupplierRelationship{
+		active.ID: active, movedOn.ID: movedOn, pending.ID: pending,
+	}}
+	users := &memoryUserRepo{users: map[primitive.ObjectID]*models.User{contact.ID: contact, outsider.ID: outsider}}
+	authService := &recordingMagicLinkAuthService{}
+	service := NewRelationshipService(relationships, nil, nil, users, nil, nil, nil, authService, "")
+
+	if err := service.SendSupplierLoginLink(ctx, active.ID, companyID, adminID); err != nil {
+		t.Fatalf("SendSupplierLoginLink failed: %v", err)
+	}
+	if len(authService.emails) != 1 || authService.emails[0] != contact.Email {
+		t.Errorf("Expected a link for the supplier contact, got %v", authService.emails)
+	}
+
+	// A contact outside the supplier organization gets nothing, without an error
+	if err := service.SendSupplierLoginLink(ctx, movedOn.ID, companyID, adminID); err != nil || len(authService.emails) != 1 {
+		t.Errorf("Expected a silent no-op, got %v and %v", err, authService.emails)
+	}
+
+	if err := service.SendSupplierLoginLink(ctx, pending.ID, companyID, adminID); !errors.Is(err, ErrRelationshipNotActive) {
+		t.Errorf("Expected ErrRelationshipNotActive, got %v", err)
+	}
+	if err := service.SendSupplierLoginLink(ctx, active.ID, primitive.NewObjectID(), adminID); !errors.Is(err, ErrRelationshipNotFound) {
+		t.Errorf("Expected ErrRelationshipNotFound for another company, got %v", err)
+	}
+
+	// The per-email limit only trips for existing accounts, so it must not surface
+	authService.err = ErrRateLimitExceeded
+	if err := service.SendSupplierLoginLink(ctx, active.ID, companyID, adminID); err != nil {
+		t.Errorf("Expected rate limited request to succeed silently, got %v", err)
+	}
+}
+
+func (r *memoryRelationshipRepo) Update(_ context.Context, relationship *models.CompanySupplierRelationship) error {
+	clone := *relationship
+	r.relationships[relationship.ID] = &clone
+	return nil
+}
+
+func (r *memoryRequirementRepo) ListByRelationship(_ context.Context, relationshipID primitive.ObjectID, _ []models.RequirementStatus) ([]models.Requirement, error) {
+	var requirements []models.Requirement
+	for _, requirement := range r.requirements {
+		if requirement.RelationshipID == relationshipID {
+			requirements = append(requirements, *requirement)
+		}
+	}
+	return requirements, nil
+}
+
+func TestRelationshipService_TerminateRelationship_ClosesOpenRequirements(t *testing.T) {
+	ctx := context.Background()
+	companyID, adminID, supplierID := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	company := &models.Organization{ID: companyID, Settings: models.DefaultOrganizationSettings()}
+	company.Settings.TerminatedRequirementStatus = models.RequirementStatusExpired
+	relationship := &models.CompanySupplierRelationship{ID: primitive.NewObjectID(), CompanyID: companyID, SupplierID: &supplierID, Status: models.RelationshipStatusActive}
+
+	requirements := &memoryRequirementRepo{requirements: map[primitive.ObjectID]*models.Requirement{}}
+	statuses := []models.RequirementStatus{
+		models.RequirementStatusPending, models.RequirementStatusInProgress, models.RequirementStatusSubmitted,
+		models.RequirementStatusRejected, models.RequirementStatusApproved,
+	}
+	for _, status := range statuses {
+		id := primitive.NewObjectID()
+		requirements.requirements[id] = &models.Requirement{ID: id, RelationshipID: relationship.ID, CompanyID: companyID, Status: status}
+	}
+
+	relationships := &memoryRelationshipRepo{relationships: map[primitive.ObjectID]*models.CompanySupplierRelationship{relationship.ID: relationship}}
+	orgs := &memoryOrganizationRepo{orgs: map[primitive.ObjectID]*models.Organization{companyID: company}}
+	service := NewRelationshipService(relationships, requirements, orgs, nil, nil, nil, nil, nil, "")
+
+	if _, err := service.TerminateRelationship(ctx, relationship.ID, companyID, adminID, "Contract ended"); err != nil {
+		t.Fatalf("TerminateRelationship failed: %v", err)
+	}
+
+	for _, requirement := range requirements.requirements {
+		if requirement.Status == models.RequirementStatusApproved {
+			if len(requirement.StatusHistory) != 0 {
+				t.Errorf("Expected the approved requirement to be left alone, got %+v", requirement.StatusHistory)
+			}
+			continue
+		}
+		if requirement.Status != models.RequirementStatusExpired {
+			t.Errorf("Expected open requirement to be expired, got %s", requirement.Status)
+		}
+		last := requirement.LastStatusChange()
+		if last == nil || last.ChangedBy != adminID || last.Reason != "Relationship terminated: Contract ended" {
+			t.Errorf("Expected the termination in the status history, got %+v", last)
+		}
+	}
+}
@@ -2,15 +2,22 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
 	"time"
+	"unicode"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/checkfix-tools/nisfix_backend/internal/models"
 	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+	"github.com/checkfix-tools/nisfix_backend/internal/storage"
 )
 
 // Custom errors for requirement service
@@ -19,8 +26,50 @@ var (
 	ErrInvalidRequirementType    = errors.New("invalid requirement type")
 	ErrRelationshipNotActive     = errors.New("relationship is not active")
 	ErrQuestionnaireNotPublished = errors.New("questionnaire is not published")
+	ErrInvalidCategoryMinimum    = errors.New("invalid CheckFix category minimum")
+	ErrAttachmentNotFound        = errors.New("attachment not found")
+	ErrAttachmentTooLarge        = errors.New("attachment exceeds the maximum size")
+	ErrAttachmentEmpty           = errors.New("attachment is empty")
+	ErrAttachmentTypeNotAllowed  = errors.New("attachment type is not allowed")
+	ErrTooManyAttachments        = errors.New("requirement has too many attachments")
+	ErrRequirementNotCancellable = errors.New("only pending requirements can be cancelled")
+	ErrRequirementNotEditable    = errors.New("only pending requirements can be updated")
+	ErrRequirementHasResponse    = errors.New("requirement already has a response")
+	ErrAssigneeNotInOrganization = errors.New("assignee is not an active member of the supplier organization")
 )
 
+const (
+	// MaxAttachmentSize is the largest accepted requirement attachment in bytes
+	MaxAttachmentSize = 10 << 20
+
+	// maxAttachmentsPerRequirement bounds the attachments stored on one requirement
+	maxAttachmentsPerRequirement = 10
+
+	// maxAttachmentFilenameLength bounds stored attachment filenames in characters
+	maxAttachmentFilenameLength = 255
+)
+
+// attachmentType describes an allowed attachment file extension
+type attachmentType struct {
+	contentType string
+	// sniffed is the prefix http.DetectContentType must report for the file contents
+	sniffed string
+}
+
+// allowedAttachmentTypes maps lower-case file extensions to their attachment type
+// #SECURITY_ASSUMPTION: The stored content type comes from this list, never from the client, and
+// the contents must match the extension so renamed executables or HTML are rejected
+var allowedAttachmentTypes = map[string]attachmentType{
+	".pdf":  {contentType: "application/pdf", sniffed: "application/pdf"},
+	".docx": {contentType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document", sniffed: "application/zip"},
+	".xlsx": {contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", sniffed: "application/zip"},
+	".txt":  {contentType: "text/plain; charset=utf-8", sniffed: "text/plain"},
+	".csv":  {contentType: "text/csv; charset=utf-8", sniffed: "text/plain"},
+	".png":  {contentType: "image/png", sniffed: "image/png"},
+	".jpg":  {contentType: "image/jpeg", sniffed: "image/jpeg"},
+	".jpeg": {contentType: "image/jpeg", sniffed: "image/jpeg"},
+}
+
 // RequirementService handles requirement business logic
 // #INTEGRATION_POINT: Used by requirement handler for requirement management
 type RequirementService interface {
@@ -33,17 +82,38 @@ type RequirementService interface {
 	// ListRequirementsByCompany lists requirements created by a company
 	ListRequirementsByCompany(ctx context.Context, companyID primitive.ObjectID, filters RequirementFilters, opts repository.PaginationOptions) (*repository.PaginatedResult[models.Requirement], error)
 
+	// ListRequirementsByCompanyCursor lists requirements created by a company using keyset pagination
+	ListRequirementsByCompanyCursor(ctx context.Context, companyID primitive.ObjectID, filters RequirementFilters, opts repository.CursorPaginationOptions) (*repository.CursorResult[models.Requirement], error)
+
 	// ListRequirementsBySupplier lists requirements for a supplier
 	ListRequirementsBySupplier(ctx context.Context, supplierID primitive.ObjectID, filters RequirementFilters, opts repository.PaginationOptions) (*repository.PaginatedResult[models.Requirement], error)
 
 	// ListRequirementsByRelationship lists requirements for a specific relationship
-	ListRequirementsByRelationship(ctx context.Context, relationshipID primitive.ObjectID, status *models.RequirementStatus) ([]models.Requirement, error)
+	ListRequirementsByRelationship(ctx context.Context, relationshipID primitive.ObjectID, statuses []models.RequirementStatus) ([]models.Requirement, error)
+
+	// GetTimeline returns a company's requirement history as one chronological list of events
+	GetTimeline(ctx context.Context, id, companyID primitive.ObjectID) ([]TimelineEvent, error)
 
 	// UpdateRequirement updates requirement details (before supplier starts)
 	UpdateRequirement(ctx context.Context, id, companyID primitive.ObjectID, req UpdateRequirementRequest) (*models.Requirement, error)
 
+	// CancelRequirement cancels a pending requirement the supplier has not started
+	CancelRequirement(ctx context.Context, id, companyID, userID primitive.ObjectID, reason string) (*models.Requirement, error)
+
+	// ListOverdueRequirements lists a company's overdue requirements, most overdue first
+	ListOverdueRequirements(ctx context.Context, companyID primitive.ObjectID) ([]models.Requirement, error)
+
 	// GetRequirementStats returns requirement statistics for a company
 	GetRequirementStats(ctx context.Context, companyID primitive.ObjectID) (*RequirementStats, error)
+
+	// AddAttachment stores a reference document on a company's requirement
+	AddAttachment(ctx context.Context, requirementID, companyID, uploaderID primitive.ObjectID, filename string, content io.Reader) (*models.RequirementAttachment, error)
+
+	// OpenAttachment returns an attachment and its contents to the owning company or the assigned supplier
+	OpenAttachment(ctx context.Context, requirementID, attachmentID, orgID primitive.ObjectID) (*models.RequirementAttachment, io.ReadCloser, error)
+
+	// AssignSupplierUser sets or, with a nil assigneeID, clears the supplier team member owning a requirement
+	AssignSupplierUser(ctx context.Context, requirementID, supplierID, userID primitive.ObjectID, assigneeID *primitive.ObjectID) (*models.Requirement, error)
 }
 
 // CreateRequirementRequest represents the request to create a requirement
@@ -58,10 +128,13 @@ type CreateRequirementRequest struct {
 	// For Questionnaire requirements
 	QuestionnaireID *string `json:"questionnaire_id,omitempty"`
 	PassingScore    *int    `json:"passing_score,omitempty"`
+	// AutoApprove defaults to the company's auto_approve setting when nil
+	AutoApprove *bool `json:"auto_approve,omitempty"`
 
 	// For CheckFix requirements
-	MinimumGrade     *string `json:"minimum_grade,omitempty"`
-	MaxReportAgeDays *int    `json:"max_report_age_days,omitempty"`
+	MinimumGrade     *string           `json:"minimum_grade,omitempty"`
+	MaxReportAgeDays *int              `json:"max_report_age_days,omitempty"`
+	CategoryMinimums map[string]string `json:"category_minimums,omitempty"`
 }
 
 // UpdateRequirementRequest represents the request to update a requirement
@@ -71,15 +144,23 @@ type UpdateRequirementRequest struct {
 	Priority         *models.Priority `json:"priority,omitempty"`
 	DueDate          *time.Time       `json:"due_date,omitempty"`
 	PassingScore     *int             `json:"passing_score,omitempty"`
+	AutoApprove      *bool            `json:"auto_approve,omitempty"`
 	MinimumGrade     *string          `json:"minimum_grade,omitempty"`
 	MaxReportAgeDays *int             `json:"max_report_age_days,omitempty"`
+
+	// CategoryMinimums replaces all category minimums when non-nil (empty map clears them)
+	CategoryMinimums map[string]string `json:"category_minimums,omitempty"`
 }
 
 // RequirementFilters contains filters for listing requirements
 type RequirementFilters struct {
-	Status   *models.RequirementStatus
+	// Statuses matches requirements in any of the statuses; empty matches all
+	Statuses []models.RequirementStatus
 	Type     *models.RequirementType
 	Priority *models.Priority
+
+	// AssigneeID limits supplier listings to requirements assigned to one team member
+	AssigneeID *primitive.ObjectID
 }
 
 // RequirementStats contains requirement statistics
@@ -91,6 +172,7 @@ type RequirementStats struct {
 	Approved   int64 `json:"approved"`
 	Rejected   int64 `json:"rejected"`
 	Expired    int64 `json:"expired"`
+	Cancelled  int64 `json:"cancelled"`
 	Overdue    int64 `json:"overdue"`
 }
 
@@ -99,6 +181,11 @@ type requirementService struct {
 	requirementRepo   repository.RequirementRepository
 	relationshipRepo  repository.RelationshipRepository
 	questionnaireRepo repository.QuestionnaireRepository
+	responseRepo      repository.ResponseRepository
+	orgRepo           repository.OrganizationRepository
+	userRepo          repository.UserRepository
+	auditService      AuditService
+	fileStorage       storage.FileStorage
 }
 
 // NewRequirementService creates a new requirement service
@@ -106,11 +193,21 @@ func NewRequirementService(
 	requirementRepo repository.RequirementRepository,
 	relationshipRepo repository.RelationshipRepository,
 	questionnaireRepo repository.QuestionnaireRepository,
+	responseRepo repository.ResponseRepository,
+	orgRepo repository.OrganizationRepository,
+	userRepo repository.UserRepository,
+	auditService AuditService,
+	fileStorage storage.FileStorage,
 ) RequirementService {
 	return &requirementService{
 		requirementRepo:   requirementRepo,
 		relationshipRepo:  relationshipRepo,
 		questionnaireRepo: questionnaireRepo,
+		responseRepo:      responseRepo,
+		orgRepo:           orgRepo,
+		userRepo:          userRepo,
+		auditService:      auditService,
+		fileStorage:       fileStorage,
 	}
 }
 
@@ -201,17 +298,38 @@ func (s *requirementService) CreateRequirement(ctx context.Context, companyID, u
 			ps := questionnaire.PassingScore
 			requirement.PassingScore = &ps
 		}
+
+		if req.AutoApprove != nil {
+			requirement.AutoApprove = *req.AutoApprove
+		} else {
+			company, err := s.orgRepo.GetByID(ctx, companyID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get organization: %w", err)
+			}
+			requirement.AutoApprove = company.Settings.AutoApprove
+		}
 	} else if req.Type == models.RequirementTypeCheckFix {
+		categoryMinimums, err := normalizeCategoryMinimums(req.CategoryMinimums)
+		if err != nil {
+			return nil, err
+		}
+
 		requirement.MinimumGrade = req.MinimumGrade
 		requirement.MaxReportAgeDays = req.MaxReportAgeDays
+		requirement.CategoryMinimums = categoryMinimums
 
 		// Set defaults for CheckFix
 		if requirement.MinimumGrade == nil {
 			defaultGrade := "C"
 			requirement.MinimumGrade = &defaultGrade
 		}
+		// #BUSINESS_RULE: Without a value the company's DefaultMaxReportAgeDays setting applies, then 90 days
 		if requirement.MaxReportAgeDays == nil {
-			defaultDays := 90
+			company, err := s.orgRepo.GetByID(ctx, companyID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get organization: %w", err)
+			}
+			defaultDays := company.Settings.EffectiveDefaultMaxReportAgeDays()
 			requirement.MaxReportAgeDays = &defaultDays
 		}
 	}
@@ -222,6 +340,9 @@ func (s *requirementService) CreateRequirement(ctx context.Context, companyID, u
 		return nil, fmt.Errorf("failed to create requirement: %w", err)
 	}
 
+	logAudit(s.auditService, newAuditEntry(ctx, userID, companyID, models.AuditActionCreate,
+		models.ResourceTypeRequirement, requirement.ID, fmt.Sprintf("Created requirement: %s", requirement.Title)))
+
 	return requirement, nil
 }
 
@@ -245,17 +366,28 @@ func (s *requirementService) GetRequirement(ctx context.Context, id primitive.Ob
 
 // ListRequirementsByCompany lists requirements created by a company
 func (s *requirementService) ListRequirementsByCompany(ctx context.Context, companyID primitive.ObjectID, filters RequirementFilters, opts repository.PaginationOptions) (*repository.PaginatedResult[models.Requirement], error) {
-	return s.requirementRepo.ListByCompany(ctx, companyID, filters.Status, opts)
+	return s.requirementRepo.ListByCompany(ctx, companyID, filters.Statuses, opts)
+}
+
+// ListRequirementsByCompanyCursor lists requirements created by a company using keyset pagination
+func (s *requirementService) ListRequirementsByCompanyCursor(ctx context.Context, companyID primitive.ObjectID, filters RequirementFilters, opts repository.CursorPaginationOptions) (*repository.CursorResult[models.Requirement], error) {
+	return s.requirementRepo.ListByCompanyCursor(ctx, companyID, filters.Statuses, opts)
 }
 
 // ListRequirementsBySupplier lists requirements for a supplier
 func (s *requirementService) ListRequirementsBySupplier(ctx context.Context, supplierID primitive.ObjectID, filters RequirementFilters, opts repository.PaginationOptions) (*repository.PaginatedResult[models.Requirement], error) {
-	return s.requirementRepo.ListBySupplier(ctx, supplierID, filters.Status, opts)
+	return s.requirementRepo.ListBySupplier(ctx, supplierID, filters.Statuses, filters.AssigneeID, opts)
 }
 
 // ListRequirementsByRelationship lists requirements for a specific relationship
-func (s *requirementService) ListRequirementsByRelationship(ctx context.Context, relationshipID primitive.ObjectID, status *models.RequirementStatus) ([]models.Requirement, error) {
-	return s.requirementRepo.ListByRelationship(ctx, relationshipID, status)
+func (s *requirementService) ListRequirementsByRelationship(ctx context.Context, relationshipID primitive.ObjectID, statuses []models.RequirementStatus) ([]models.Requirement, error) {
+	return s.requirementRepo.ListByRelationship(ctx, relationshipID, statuses)
+}
+
+// ListOverdueRequirements lists a company's overdue requirements, most overdue first
+// #BUSINESS_RULE: Only pending and in-progress requirements count as overdue; submitted ones wait on the company
+func (s *requirementService) ListOverdueRequirements(ctx context.Context, companyID primitive.ObjectID) ([]models.Requirement, error) {
+	return s.requirementRepo.ListOverdue(ctx, &companyID)
 }
 
 // UpdateRequirement updates requirement details
@@ -268,7 +400,7 @@ func (s *requirementService) UpdateRequirement(ctx context.Context, id, companyI
 
 	// Only pending requirements can be updated
 	if !requirement.IsPending() {
-		return nil, errors.New("requirement can only be updated while pending")
+		return nil, ErrRequirementNotEditable
 	}
 
 	// Update fields if provided
@@ -287,12 +419,22 @@ func (s *requirementService) UpdateRequirement(ctx context.Context, id, companyI
 	if req.PassingScore != nil && requirement.IsQuestionnaireRequirement() {
 		requirement.PassingScore = req.PassingScore
 	}
+	if req.AutoApprove != nil && requirement.IsQuestionnaireRequirement() {
+		requirement.AutoApprove = *req.AutoApprove
+	}
 	if req.MinimumGrade != nil && requirement.IsCheckFixRequirement() {
 		requirement.MinimumGrade = req.MinimumGrade
 	}
 	if req.MaxReportAgeDays != nil && requirement.IsCheckFixRequirement() {
 		requirement.MaxReportAgeDays = req.MaxReportAgeDays
 	}
+	if req.CategoryMinimums != nil && requirement.IsCheckFixRequirement() {
+		categoryMinimums, err := normalizeCategoryMinimums(req.CategoryMinimums)
+		if err != nil {
+			return nil, err
+		}
+		requirement.CategoryMinimums = categoryMinimums
+	}
 
 	requirement.BeforeUpdate()
 
@@ -300,9 +442,118 @@ func (s *requirementService) UpdateRequirement(ctx context.Context, id, companyI
 		return nil, fmt.Errorf("failed to update requirement: %w", err)
 	}
 
+	logAudit(s.auditService, newAuditEntry(ctx, primitive.NilObjectID, companyID, models.AuditActionUpdate,
+		models.ResourceTypeRequirement, requirement.ID, fmt.Sprintf("Updated requirement: %s", requirement.Title)))
+
+	return requirement, nil
+}
+
+// CancelRequirement cancels a pending requirement the supplier has not started
+// #BUSINESS_RULE: Only pending requirements without a response can be cancelled; the reason is
+// kept in StatusHistory and cancelled requirements drop out of the supplier's active list
+func (s *requirementService) CancelRequirement(ctx context.Context, id, companyID, userID primitive.ObjectID, reason string) (*models.Requirement, error) {
+	requirement, err := s.GetRequirement(ctx, id, &companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !requirement.IsPending() {
+		return nil, ErrRequirementNotCancellable
+	}
+
+	// A response can exist while the requirement is still pending if starting it failed halfway
+	if s.responseRepo != nil {
+		_, err := s.responseRepo.GetByRequirement(ctx, requirement.ID)
+		if err == nil {
+			return nil, ErrRequirementHasResponse
+		}
+		if !errors.Is(err, models.ErrResponseNotFound) {
+			return nil, fmt.Errorf("failed to check response: %w", err)
+		}
+	}
+
+	if err := requirement.Cancel(userID, strings.TrimSpace(reason)); err != nil {
+		return nil, ErrRequirementNotCancellable
+	}
+
+	if err := s.requirementRepo.Update(ctx, requirement); err != nil {
+		return nil, fmt.Errorf("failed to cancel requirement: %w", err)
+	}
+
+	logAudit(s.auditService, newAuditEntry(ctx, userID, companyID, models.AuditActionUpdate,
+		models.ResourceTypeRequirement, requirement.ID, fmt.Sprintf("Cancelled requirement: %s", requirement.Title)))
+
+	return requirement, nil
+}
+
+// AssignSupplierUser sets or, with a nil assigneeID, clears the supplier team member owning a requirement
+// #BUSINESS_RULE: The assignee must be an active user of the supplier organization
+// #SECURITY_ASSUMPTION: Unknown users and users of other organizations get the same error, so
+// user IDs cannot be probed across organizations
+func (s *requirementService) AssignSupplierUser(ctx context.Context, requirementID, supplierID, userID primitive.ObjectID, assigneeID *primitive.ObjectID) (*models.Requirement, error) {
+	requirement, err := s.requirementRepo.GetByID(ctx, requirementID)
+	if err != nil {
+		if errors.Is(err, models.ErrRequirementNotFound) {
+			return nil, ErrRequirementNotFound
+		}
+		return nil, fmt.Errorf("failed to get requirement: %w", err)
+	}
+	if requirement.SupplierID != supplierID || requirement.IsCancelled() {
+		return nil, ErrRequirementNotFound
+	}
+
+	message := fmt.Sprintf("Unassigned requirement: %s", requirement.Title)
+	if assigneeID != nil {
+		assignee, err := s.userRepo.GetByID(ctx, *assigneeID)
+		if err != nil {
+			if errors.Is(err, models.ErrUserNotFound) {
+				return nil, ErrAssigneeNotInOrganization
+			}
+			return nil, fmt.Errorf("failed to get assignee: %w", err)
+		}
+		if assignee.OrganizationID != supplierID || !assignee.IsActive || assignee.IsDeleted() {
+			return nil, ErrAssigneeNotInOrganization
+		}
+		message = fmt.Sprintf("Assigned requirement %s to %s", requirement.Title, assignee.Email)
+	}
+
+	if err := s.requirementRepo.SetAssignee(ctx, requirement.ID, supplierID, assigneeID); err != nil {
+		if errors.Is(err, models.ErrRequirementNotFound) {
+			return nil, ErrRequirementNotFound
+		}
+		return nil, fmt.Errorf("failed to assign requirement: %w", err)
+	}
+	requirement.AssignedUserID = assigneeID
+
+	logAudit(s.auditService, newAuditEntry(ctx, userID, supplierID, models.AuditActionUpdate,
+		models.ResourceTypeRequirement, requirement.ID, message))
+
 	return requirement, nil
 }
 
+// normalizeCategoryMinimums validates category minimums and returns them keyed by canonical category name
+// #BUSINESS_RULE: Only known CheckFix categories with valid grades may be required
+func normalizeCategoryMinimums(minimums map[string]string) (map[string]string, error) {
+	if len(minimums) == 0 {
+		return nil, nil
+	}
+
+	normalized := make(map[string]string, len(minimums))
+	for category, grade := range minimums {
+		canonical, ok := models.NormalizeCheckFixCategory(category)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown category %q (known: %s)", ErrInvalidCategoryMinimum,
+				category, strings.Join(models.KnownCheckFixCategories, ", "))
+		}
+		g := models.CheckFixGrade(strings.ToUpper(strings.TrimSpace(grade)))
+		if !g.IsValid() {
+			return nil, fmt.Errorf("%w: invalid grade %q for %s", ErrInvalidCategoryMinimum, grade, canonical)
+		}
+		normalized[canonical] = string(g)
+	}
+	return normalized, nil
+}
+
 // GetRequirementStats returns requirement statistics for a company
 func (s *requirementService) GetRequirementStats(ctx context.Context, companyID primitive.ObjectID) (*RequirementStats, error) {
 	total, err := s.requirementRepo.CountByCompany(ctx, companyID, nil)
@@ -346,6 +597,12 @@ func (s *requirementService) GetRequirementStats(ctx context.Context, companyID
 		return nil, fmt.Errorf("failed to count expired: %w", err)
 	}
 
+	cancelledStatus := models.RequirementStatusCancelled
+	cancelled, err := s.requirementRepo.CountByCompany(ctx, companyID, &cancelledStatus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count cancelled: %w", err)
+	}
+
 	// Count overdue (would need a specific query)
 	// #TECHNICAL_DEBT: Add overdue count method to repository
 	overdue := int64(0)
@@ -358,6 +615,114 @@ func (s *requirementService) GetRequirementStats(ctx context.Context, companyID
 		Approved:   approved,
 		Rejected:   rejected,
 		Expired:    expired,
+		Cancelled:  cancelled,
 		Overdue:    overdue,
 	}, nil
 }
+
+// AddAttachment stores a reference document on a company's requirement
+// #BUSINESS_RULE: Only the owning company attaches documents; the assigned supplier can download them
+func (s *requirementService) AddAttachment(ctx context.Context, requirementID, companyID, uploaderID primitive.ObjectID, filename string, content io.Reader) (*models.RequirementAttachment, error) {
+	requirement, err := s.GetRequirement(ctx, requirementID, &companyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(requirement.Attachments) >= maxAttachmentsPerRequirement {
+		return nil, ErrTooManyAttachments
+	}
+
+	filename = sanitizeAttachmentFilename(filename)
+	fileType, ok := allowedAttachmentTypes[strings.ToLower(filepath.Ext(filename))]
+	if !ok {
+		return nil, ErrAttachmentTypeNotAllowed
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	if n == 0 {
+		return nil, ErrAttachmentEmpty
+	}
+	if !strings.HasPrefix(http.DetectContentType(head[:n]), fileType.sniffed) {
+		return nil, ErrAttachmentTypeNotAllowed
+	}
+
+	attachment := models.RequirementAttachment{
+		ID:          primitive.NewObjectID(),
+		Filename:    filename,
+		ContentType: fileType.contentType,
+		UploadedBy:  uploaderID,
+		UploadedAt:  time.Now().UTC(),
+	}
+	attachment.StorageKey = fmt.Sprintf("requirements/%s/%s", requirement.ID.Hex(), attachment.ID.Hex())
+
+	// Read one byte past the limit so oversized uploads are detected without buffering them
+	reader := io.LimitReader(io.MultiReader(bytes.NewReader(head[:n]), content), MaxAttachmentSize+1)
+	attachment.Size, err = s.fileStorage.Save(ctx, attachment.StorageKey, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	if attachment.Size > MaxAttachmentSize {
+		s.fileStorage.Delete(ctx, attachment.StorageKey) //nolint:errcheck // best-effort cleanup
+		return nil, ErrAttachmentTooLarge
+	}
+
+	if err := s.requirementRepo.AddAttachment(ctx, requirement.ID, companyID, attachment, maxAttachmentsPerRequirement); err != nil {
+		s.fileStorage.Delete(ctx, attachment.StorageKey) //nolint:errcheck // best-effort cleanup
+		switch {
+		case errors.Is(err, models.ErrRequirementNotFound):
+			return nil, ErrRequirementNotFound
+		case errors.Is(err, models.ErrTooManyAttachments):
+			return nil, ErrTooManyAttachments
+		}
+		return nil, fmt.Errorf("failed to add attachment: %w", err)
+	}
+
+	logAudit(s.auditService, newAuditEntry(ctx, uploaderID, companyID, models.AuditActionUpdate,
+		models.ResourceTypeRequirement, requirement.ID, fmt.Sprintf("Attached document: %s", attachment.Filename)))
+
+	return &attachment, nil
+}
+
+// OpenAttachment returns an attachment and its contents to the owning company or the assigned supplier
+// #SECURITY_ASSUMPTION: Any other organization gets ErrRequirementNotFound so requirement IDs are not confirmed
+func (s *requirementService) OpenAttachment(ctx context.Context, requirementID, attachmentID, orgID primitive.ObjectID) (*models.RequirementAttachment, io.ReadCloser, error) {
+	requirement, err := s.GetRequirement(ctx, requirementID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if requirement.CompanyID != orgID && requirement.SupplierID != orgID {
+		return nil, nil, ErrRequirementNotFound
+	}
+
+	attachment := requirement.FindAttachment(attachmentID)
+	if attachment == nil {
+		return nil, nil, ErrAttachmentNotFound
+	}
+
+	content, err := s.fileStorage.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrFileNotFound) {
+			return nil, nil, ErrAttachmentNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to open attachment: %w", err)
+	}
+	return attachment, content, nil
+}
+
+// sanitizeAttachmentFilename strips directories and control characters from a client-supplied filename
+func sanitizeAttachmentFilename(filename string) string {
+	filename = filepath.Base(strings.ReplaceAll(filename, "\\", "/"))
+	filename = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, filename))
+	if runes := []rune(filename); len(runes) > maxAttachmentFilenameLength {
+		filename = string(runes[len(runes)-maxAttachmentFilenameLength:])
+	}
+	return filename
+}
@@ -0,0 +1,309 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+	"github.com/checkfix-tools/nisfix_backend/internal/storage"
+)
+
+// memoryRequirementRepo keeps requirements in memory; unused methods are not implemented
+type memoryRequirementRepo struct {
+	repository.RequirementRepository
+	requirements map[primitive.ObjectID]*models.Requirement
+}
+
+func (r *memoryRequirementRepo) GetByID(_ context.Context, id primitive.ObjectID) (*models.Requirement, error) {
+	requirement, ok := r.requirements[id]
+	if !ok {
+		return nil, models.ErrRequirementNotFound
+	}
+	clone := *requirement
+	return &clone, nil
+}
+
+func (r *memoryRequirementRepo) GetByIDs(_ context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]*models.Requirement, error) {
+	requirements := make(map[primitive.ObjectID]*models.Requirement, len(ids))
+	for _, id := range ids {
+		if requirement, ok := r.requirements[id]; ok {
+			clone := *requirement
+			requirements[id] = &clone
+		}
+	}
+	return requirements, nil
+}
+
+func (r *memoryRequirementRepo) Update(_ context.Context, requirement *models.Requirement) error {
+	if _, ok := r.requirements[requirement.ID]; !ok {
+		return models.ErrRequirementNotFound
+	}
+	clone := *requirement
+	r.requirements[requirement.ID] = &clone
+	return nil
+}
+
+func (r *memoryRequirementRepo) AddAttachment(_ context.Context, id, companyID primitive.ObjectID, attachment models.RequirementAttachment, maxAttachments int) error {
+	requirement, ok := r.requirements[id]
+	if !ok || requirement.CompanyID != companyID {
+		return models.ErrRequirementNotFound
+	}
+	if len(requirement.Attachments) >= maxAttachments {
+		return models.ErrTooManyAttachments
+	}
+	requirement.Attachments = append(requirement.Attachments, attachment)
+	return nil
+}
+
+func (r *memoryRequirementRepo) SetAssignee(_ context.Context, id, supplierID primitive.ObjectID, userID *primitive.ObjectID) error {
+	requirement, ok := r.requirements[id]
+	if !ok || requirement.SupplierID != supplierID {
+		return models.ErrRequirementNotFound
+	}
+	requirement.AssignedUserID = userID
+	return nil
+}
+
+func TestRequirementService_Attachments(t *testing.T) {
+	ctx := context.Background()
+	companyID, supplierID := primitive.NewObjectID(), primitive.NewObjectID()
+	requirement := &models.Requirement{ID: primitive.NewObjectID(), CompanyID: companyID, SupplierID: supplierID}
+
+	fileStorage, err := storage.NewLocalFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalFileStorage failed: %v", err)
+	}
+	service := NewRequirementService(
+		&memoryRequirementRepo{requirements: map[primitive.ObjectID]*models.Requirement{requirement.ID: requirement}},
+		nil, nil, nil, nil, nil, nil, fileStorage,
+	)
+
+	t.Run("rejects mismatched content", func(t *testing.T) {
+		_, err := service.AddAttachment(ctx, requirement.ID, companyID, primitive.NewObjectID(), "guidance.pdf", strings.NewReader("<html>not a pdf</html>"))
+		if !errors.Is(err, ErrAttachmentTypeNotAllowed) {
+			t.Errorf("Expected ErrAttachmentTypeNotAllowed, got %v", err)
+		}
+	})
+
+	t.Run("rejects other companies", func(t *testing.T) {
+		_, err := service.AddAttachment(ctx, requirement.ID, supplierID, primitive.NewObjectID(), "guidance.txt", strings.NewReader("guidance"))
+		if !errors.Is(err, ErrRequirementNotFound) {
+			t.Errorf("Expected ErrRequirementNotFound, got %v", err)
+		}
+	})
+
+	attachment, err := service.AddAttachment(ctx, requirement.ID, companyID, primitive.NewObjectID(), `C:\policies\guidance.txt`, strings.NewReader("Use MFA everywhere."))
+	if err != nil {
+		t.Fatalf("AddAttachment failed: %v", err)
+	}
+	if attachment.Filename != "guidance.txt" || attachment.Size != 19 || attachment.ContentType != "text/plain; charset=utf-8" {
+		t.Errorf("Unexpected attachment metadata %+v", attachment)
+	}
+
+	for _, orgID := range []primitive.ObjectID{companyID, supplierID} {
+		_, content, err := service.OpenAttachment(ctx, requirement.ID, attachment.ID, orgID)
+		if err != nil {
+			t.Fatalf("OpenAttachment failed for %s: %v", orgID.Hex(), err)
+		}
+		data, _ := io.ReadAll(content)
+		content.Close()
+		if string(data) != "Use MFA everywhere." {
+			t.Errorf("Unexpected attachment content %q", data)
+		}
+	}
+
+	if _, _, err := service.OpenAttachment(ctx, requirement.ID, attachment.ID, primitive.NewObjectID()); !errors.Is(err, ErrRequirementNotFound) {
+		t.Errorf("Expected ErrRequirementNotFound for an unrelated organization, got %v", err)
+	}
+}
+
+// startedResponseRepo reports a response for the listed requirements only
+type startedResponseRepo struct {
+	repository.ResponseRepository
+	started map[primitive.ObjectID]bool
+}
+
+func (r startedResponseRepo) GetByRequirement(_ context.Context, requirementID primitive.ObjectID) (*models.SupplierResponse, error) {
+	if !r.started[requirementID] {
+		return nil, models.ErrResponseNotFound
+	}
+	return &models.SupplierResponse{ID: primitive.NewObjectID(), RequirementID: requirementID}, nil
+}
+
+func TestRequirementService_CancelRequirement(t *testing.T) {
+	ctx := context.Background()
+	companyID, userID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	newRequirement := func(status models.RequirementStatus) *models.Requirement {
+		return &models.Requirement{ID: primitive.NewObjectID(), CompanyID: companyID, Status: status}
+	}
+	pending := newRequirement(models.RequirementStatusPending)
+	started := newRequirement(models.RequirementStatusPending)
+	inProgress := newRequirement(models.RequirementStatusInProgress)
+
+	repo := &memoryRequirementRepo{requirements: map[primitive.ObjectID]*models.Requirement{}}
+	for _, r := range []*models.Requirement{pending, started, inProgress} {
+		repo.requirements[r.ID] = r
+	}
+	responses := startedResponseRepo{started: map[primitive.ObjectID]bool{started.ID: true}}
+	service := NewRequirementService(repo, nil, nil, responses, nil, nil, nil, nil)
+
+	cancelled, err := service.CancelRequirement(ctx, pending.ID, companyID, userID, "Supplier offboarded")
+	if err != nil {
+		t.Fatalf("CancelRequirement failed: %v", err)
+	}
+	if !cancelled.IsCancelled() || !repo.requirements[pending.ID].IsCancelled() {
+		t.Errorf("Expected requirement to be cancelled, got %s", repo.requirements[pending.ID].Status)
+	}
+	last := cancelled.StatusHistory[len(cancelled.StatusHistory)-1]
+	if last.Reason != "Supplier offboarded" || last.ChangedBy != userID {
+		t.Errorf("Unexpected status history entry %+v", last)
+	}
+
+	if _, err := service.CancelRequirement(ctx, started.ID, companyID, userID, ""); !errors.Is(err, ErrRequirementHasResponse) {
+		t.Errorf("Expected ErrRequirementHasResponse, got %v", err)
+	}
+	if _, err := service.CancelRequirement(ctx, inProgress.ID, companyID, userID, ""); !errors.Is(err, ErrRequirementNotCancellable) {
+		t.Errorf("Expected ErrRequirementNotCancellable, got %v", err)
+	}
+	if _, err := service.CancelRequirement(ctx, inProgress.ID, primitive.NewObjectID(), userID, ""); !errors.Is(err, ErrRequirementNotFound) {
+		t.Errorf("Expected ErrRequirementNotFound for another company, got %v", err)
+	}
+}
+
+func TestRequirementService_AssignSupplierUser(t *testing.T) {
+	ctx := context.Background()
+	supplierID := primitive.NewObjectID()
+	member := &models.User{ID: primitive.NewObjectID(), OrganizationID: supplierID, IsActive: true}
+	inactive := &models.User{ID: primitive.NewObjectID(), OrganizationID: supplierID}
+	outsider := &models.User{ID: primitive.NewObjectID(), OrganizationID: primitive.NewObjectID(), IsActive: true}
+
+	requirement := &models.Requirement{ID: primitive.NewObjectID(), SupplierID: supplierID, Status: models.RequirementStatusPending}
+	repo := &memoryRequirementRepo{requirements: map[primitive.ObjectID]*models.Requirement{requirement.ID: requirement}}
+	users := &memoryUserRepo{users: map[primitive.ObjectID]*models.User{member.ID: member, inactive.ID: inactive, outsider.ID: outsider}}
+	service := NewRequirementService(repo, nil, nil, nil, nil, users, nil, nil)
+
+	assigned, err := service.AssignSupplierUser(ctx, requirement.ID, supplierID, member.ID, &member.ID)
+	if err != nil {
+		t.Fatalf("AssignSupplierUser failed: %v", err)
+	}
+	if assigned.AssignedUserID == nil || *repo.requirements[requirement.ID].AssignedUserID != member.ID {
+		t.Errorf("Expected the requirement to be assigned to %s", member.ID.Hex())
+	}
+
+	for name, user := range map[string]*models.User{"inactive": inactive, "outsider": outsider} {
+		if _, err := service.AssignSupplierUser(ctx, requirement.ID, supplierID, member.ID, &user.ID); !errors.Is(err, ErrAssigneeNotInOrganization) {
+			t.Errorf("%s: expected ErrAssigneeNotInOrganization, got %v", name, err)
+		}
+	}
+	unknown := primitive.NewObjectID()
+	if _, err := service.AssignSupplierUser(ctx, requirement.ID, supplierID, member.ID, &unknown); !errors.Is(err, ErrAssigneeNotInOrganization) {
+		t.Errorf("Expected ErrAssigneeNotInOrganization for an unknown user, got %v", err)
+	}
+	if _, err := service.AssignSupplierUser(ctx, requirement.ID, primitive.NewObjectID(), member.ID, nil); !errors.Is(err, ErrRequirementNotFound) {
+		t.Errorf("Expected ErrRequirementNotFound for another supplier, got %v", err)
+	}
+
+	if _, err := service.AssignSupplierUser(ctx, requirement.ID, supplierID, member.ID, nil); err != nil || repo.requirements[requirement.ID].AssignedUserID != nil {
+		t.Errorf("Expected the assignment to be cleared, got %v", err)
+	}
+}
+
+// memoryAuditService serves fixed audit logs; unused methods are not implemented
+type memoryAuditService struct {
+	AuditService
+	logs []models.AuditLog
+}
+
+func (s *memoryAuditService) ListByResource(_ context.Context, resourceType string, resourceID primitive.ObjectID, _, _ *time.Time, _ repository.PaginationOptions) (*repository.PaginatedResult[models.AuditLog], error) {
+	var items []models.AuditLog
+	for _, entry := range s.logs {
+		if entry.ResourceType == resourceType && entry.ResourceID == resourceID {
+			items = append(items, entry)
+		}
+	}
+	return &repository.PaginatedResult[models.AuditLog]{Items: items, TotalCount: int64(len(items))}, nil
+}
+
+func (r *memoryUserRepo) GetByIDs(_ context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]*models.User, error) {
+	users := make(map[primitive.ObjectID]*models.User)
+	for _, id := range ids {
+		if user, ok := r.users[id]; ok {
+			users[id] = user
+		}
+	}
+	return users, nil
+}
+
+func TestRequirementService_GetTimeline(t *testing.T) {
+	ctx := context.Background()
+	companyID := primitive.NewObjectID()
+	owner := &models.User{ID: primitive.NewObjectID(), Email: "owner@acme.example"}
+	reviewer := &models.User{ID: primitive.NewObjectID(), Email: "reviewer@acme.example"}
+	at := func(minutes int) time.Time {
+		return time.Date(2026, 3, 2, 9, minutes, 0, 0, time.UTC)
+	}
+	reminded, submitted, reviewed := at(30), at(40), at(50)
+
+	requirement := &models.Requirement{
+		ID: primitive.NewObjectID(), CompanyID: companyID, Status: models.RequirementStatusApproved, ReminderSentAt: &reminded,
+		StatusHistory: []models.RequirementStatusChange{
+			{ToStatus: models.RequirementStatusPending, ChangedBy: owner.ID, ChangedAt: at(0)},
+			{FromStatus: models.RequirementStatusPending, ToStatus: models.RequirementStatusInProgress, ChangedAt: at(10)},
+		},
+	}
+	response := &models.SupplierResponse{
+		RequirementID: requirement.ID, StartedAt: at(10), SubmittedAt: &submitted,
+		DraftAnswers:     []models.DraftAnswer{{SavedAt: at(15)}, {SavedAt: at(20)}},
+		ReviewedAt:       &reviewed,
+		ReviewedByUserID: &reviewer.ID,
+	}
+	audit := &memoryAuditService{logs: []models.AuditLog{
+		// Recorded by the creation itself, so covered by the first status change
+		{ActorUserID: &owner.ID, ResourceType: models.ResourceTypeRequirement, ResourceID: requirement.ID, Description: "Created requirement", CreatedAt: at(0)},
+		{ActorUserID: &owner.ID, ResourceType: models.ResourceTypeRequirement, ResourceID: requirement.ID, Description: "Updated requirement", CreatedAt: at(5)},
+	}}
+
+	service := NewRequirementService(
+		&memoryRequirementRepo{requirements: map[primitive.ObjectID]*models.Requirement{requirement.ID: requirement}},
+		nil, nil,
+		&memoryResponseRepo{responses: map[primitive.ObjectID]*models.SupplierResponse{requirement.ID: response}},
+		nil,
+		&memoryUserRepo{users: map[primitive.ObjectID]*models.User{owner.ID: owner, reviewer.ID: reviewer}},
+		audit, nil,
+	)
+
+	events, err := service.GetTimeline(ctx, requirement.ID, companyID)
+	if err != nil {
+		t.Fatalf("GetTimeline failed: %v", err)
+	}
+	want := []TimelineEventType{
+		TimelineEventCreated, TimelineEventActivity, TimelineEventStatusChanged, TimelineEventStarted,
+		TimelineEventDraftSaved, TimelineEventReminderSent, TimelineEventSubmitted, TimelineEventReviewed,
+	}
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %+v", len(want), events)
+	}
+	for i, event := range events {
+		if event.Type != want[i] {
+			t.Errorf("Event %d: expected %s, got %s", i, want[i], event.Type)
+		}
+	}
+	if events[0].ActorEmail != owner.Email || events[len(events)-1].ActorEmail != reviewer.Email {
+		t.Errorf("Expected actors to be resolved, got %q and %q", events[0].ActorEmail, events[len(events)-1].ActorEmail)
+	}
+	if !events[4].OccurredAt.Equal(at(20)) {
+		t.Errorf("Expected the latest draft save, got %s", events[4].OccurredAt)
+	}
+
+	if _, err := service.GetTimeline(ctx, requirement.ID, primitive.NewObjectID()); !errors.Is(err, ErrRequirementNotFound) {
+		t.Errorf("Expected ErrRequirementNotFound for another company, got %v", err)
+	}
+}
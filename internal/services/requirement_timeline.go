@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// TimelineEventType identifies the kind of event on a requirement timeline
+type TimelineEventType string
+
+const (
+	TimelineEventCreated       TimelineEventType = "created"
+	TimelineEventStatusChanged TimelineEventType = "status_changed"
+	TimelineEventStarted       TimelineEventType = "started"
+	TimelineEventDraftSaved    TimelineEventType = "draft_saved"
+	TimelineEventSubmitted     TimelineEventType = "submitted"
+	TimelineEventReviewed      TimelineEventType = "reviewed"
+	TimelineEventReminderSent  TimelineEventType = "reminder_sent"
+	TimelineEventActivity      TimelineEventType = "activity"
+)
+
+// maxTimelineAuditEntries caps the audit log entries merged into one timeline, newest kept
+const maxTimelineAuditEntries = 200
+
+// timelineDuplicateWindow is how close an audit entry must be to a status change by the same
+// actor to count as the same operation
+const timelineDuplicateWindow = time.Second
+
+// TimelineEvent is one entry on a requirement timeline
+type TimelineEvent struct {
+	Type        TimelineEventType
+	OccurredAt  time.Time
+	Description string
+
+	// ActorUserID is who caused the event; nil for system and supplier-side response events
+	ActorUserID *primitive.ObjectID
+	// ActorEmail is resolved from ActorUserID; empty when the user no longer exists
+	ActorEmail string
+
+	// FromStatus and ToStatus are set for status changes
+	FromStatus models.RequirementStatus
+	ToStatus   models.RequirementStatus
+}
+
+// GetTimeline merges a company's requirement history into one chronological list
+// #BUSINESS_RULE: Status history, the response's lifecycle timestamps, the reminder and the
+// requirement's audit entries all contribute; oldest first
+// #IMPLEMENTATION_DECISION: Audit entries recorded by the same actor alongside a status change
+// (creation, cancellation) are dropped, since the status change already describes them
+func (s *requirementService) GetTimeline(ctx context.Context, id, companyID primitive.ObjectID) ([]TimelineEvent, error) {
+	requirement, err := s.GetRequirement(ctx, id, &companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []TimelineEvent
+	for _, change := range requirement.StatusHistory {
+		event := TimelineEvent{
+			Type:        TimelineEventStatusChanged,
+			OccurredAt:  change.ChangedAt,
+			Description: change.Reason,
+			ActorUserID: actorPtr(change.ChangedBy),
+			FromStatus:  change.FromStatus,
+			ToStatus:    change.ToStatus,
+		}
+		if change.FromStatus == "" {
+			event.Type = TimelineEventCreated
+		}
+		events = append(events, event)
+	}
+
+	if requirement.ReminderSentAt != nil {
+		events = append(events, TimelineEvent{
+			Type:        TimelineEventReminderSent,
+			OccurredAt:  *requirement.ReminderSentAt,
+			Description: "Due date reminder sent to the supplier",
+		})
+	}
+
+	response, err := s.responseRepo.GetByRequirement(ctx, requirement.ID)
+	if err != nil && !errors.Is(err, models.ErrResponseNotFound) {
+		return nil, fmt.Errorf("failed to get response: %w", err)
+	}
+	if response != nil {
+		events = append(events, responseTimelineEvents(response)...)
+	}
+
+	auditEvents, err := s.auditTimelineEvents(ctx, requirement)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, auditEvents...)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OccurredAt.Before(events[j].OccurredAt)
+	})
+	s.resolveTimelineActors(ctx, events)
+
+	return events, nil
+}
+
+// responseTimelineEvents lists the lifecycle events recorded on a supplier response
+func responseTimelineEvents(response *models.SupplierResponse) []TimelineEvent {
+	events := []TimelineEvent{{
+		Type:        TimelineEventStarted,
+		OccurredAt:  response.StartedAt,
+		Description: "Supplier started the response",
+	}}
+
+	// Only the latest draft save is shown; every answer save would flood the timeline
+	var lastSaved time.Time
+	for _, answer := range response.DraftAnswers {
+		if answer.SavedAt.After(lastSaved) {
+			lastSaved = answer.SavedAt
+		}
+	}
+	if !lastSaved.IsZero() {
+		events = append(events, TimelineEvent{
+			Type:        TimelineEventDraftSaved,
+			OccurredAt:  lastSaved,
+			Description: "Supplier saved draft answers",
+		})
+	}
+
+	if response.SubmittedAt != nil {
+		events = append(events, TimelineEvent{
+			Type:        TimelineEventSubmitted,
+			OccurredAt:  *response.SubmittedAt,
+			Description: "Supplier submitted the response",
+		})
+	}
+	if response.ReviewedAt != nil {
+		events = append(events, TimelineEvent{
+			Type:        TimelineEventReviewed,
+			OccurredAt:  *response.ReviewedAt,
+			Description: "Response reviewed",
+			ActorUserID: response.ReviewedByUserID,
+		})
+	}
+	return events
+}
+
+// auditTimelineEvents lists the requirement's audit entries not already covered by its status history
+func (s *requirementService) auditTimelineEvents(ctx context.Context, requirement *models.Requirement) ([]TimelineEvent, error) {
+	if s.auditService == nil {
+		return nil, nil
+	}
+
+	opts := repository.PaginationOptions{Page: 1, Limit: maxTimelineAuditEntries, SortBy: "created_at", SortDir: -1}
+	result, err := s.auditService.ListByResource(ctx, models.ResourceTypeRequirement, requirement.ID, nil, nil, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	var events []TimelineEvent
+	for _, entry := range result.Items {
+		if coveredByStatusChange(requirement.StatusHistory, entry) {
+			continue
+		}
+		events = append(events, TimelineEvent{
+			Type:        TimelineEventActivity,
+			OccurredAt:  entry.CreatedAt,
+			Description: entry.Description,
+			ActorUserID: entry.ActorUserID,
+			ActorEmail:  entry.ActorEmail,
+		})
+	}
+	return events, nil
+}
+
+// coveredByStatusChange reports whether an audit entry was recorded by the operation behind a status change
+func coveredByStatusChange(history []models.RequirementStatusChange, entry models.AuditLog) bool {
+	if entry.ActorUserID == nil {
+		return false
+	}
+	for _, change := range history {
+		gap := entry.CreatedAt.Sub(change.ChangedAt)
+		if change.ChangedBy == *entry.ActorUserID && gap > -timelineDuplicateWindow && gap < timelineDuplicateWindow {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveTimelineActors fills in actor emails with one user lookup
+// #IMPLEMENTATION_DECISION: Best effort - a failed lookup leaves emails empty instead of failing the timeline
+func (s *requirementService) resolveTimelineActors(ctx context.Context, events []TimelineEvent) {
+	if s.userRepo == nil {
+		return
+	}
+
+	seen := make(map[primitive.ObjectID]bool)
+	var ids []primitive.ObjectID
+	for _, event := range events {
+		if event.ActorUserID != nil && event.ActorEmail == "" && !seen[*event.ActorUserID] {
+			seen[*event.ActorUserID] = true
+			ids = append(ids, *event.ActorUserID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	users, err := s.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		log.Printf("Failed to resolve timeline actors: %v", err)
+		return
+	}
+	for i := range events {
+		if events[i].ActorUserID == nil || events[i].ActorEmail != "" {
+			continue
+		}
+		if user, ok := users[*events[i].ActorUserID]; ok {
+			events[i].ActorEmail = user.Email
+		}
+	}
+}
+
+// actorPtr returns a pointer to a user ID, or nil for the zero ID
+func actorPtr(id primitive.ObjectID) *primitive.ObjectID {
+	if id.IsZero() {
+		return nil
+	}
+	return &id
+}
@@ -5,10 +5,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"github.com/checkfix-tools/nisfix_backend/internal/metrics"
 	"github.com/checkfix-tools/nisfix_backend/internal/models"
 	"github.com/checkfix-tools/nisfix_backend/internal/repository"
 )
@@ -21,8 +23,39 @@ var (
 	ErrCannotStartResponse      = errors.New("cannot start response for this requirement")
 	ErrSubmissionNotFound       = errors.New("submission not found")
 	ErrInvalidAnswer            = errors.New("invalid answer")
+	ErrResponseConflict         = errors.New("response was modified by another save")
+	ErrRequirementApproved      = errors.New("requirement is approved and its response can no longer be changed")
+	ErrMissingRequiredNotes     = errors.New("answers are missing required notes")
+	ErrNotQuestionnaire         = errors.New("requirement is not a questionnaire requirement")
+	ErrUnknownQuestions         = errors.New("answers reference questions outside the questionnaire")
 )
 
+// MissingNotesError lists the questions answered without their required note; it matches ErrMissingRequiredNotes
+type MissingNotesError struct {
+	QuestionIDs []string
+}
+
+func (e *MissingNotesError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrMissingRequiredNotes, strings.Join(e.QuestionIDs, ", "))
+}
+
+func (e *MissingNotesError) Unwrap() error {
+	return ErrMissingRequiredNotes
+}
+
+// UnknownQuestionsError lists imported question IDs that are not part of the questionnaire; it matches ErrUnknownQuestions
+type UnknownQuestionsError struct {
+	QuestionIDs []string
+}
+
+func (e *UnknownQuestionsError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrUnknownQuestions, strings.Join(e.QuestionIDs, ", "))
+}
+
+func (e *UnknownQuestionsError) Unwrap() error {
+	return ErrUnknownQuestions
+}
+
 // ResponseService handles supplier response business logic
 // #INTEGRATION_POINT: Used by response handler for supplier response management
 type ResponseService interface {
@@ -38,8 +71,18 @@ type ResponseService interface {
 	// SaveDraftAnswer saves a draft answer for a question
 	SaveDraftAnswer(ctx context.Context, responseID, supplierID primitive.ObjectID, answer SaveDraftAnswerRequest) error
 
-	// SaveMultipleDraftAnswers saves multiple draft answers at once
-	SaveMultipleDraftAnswers(ctx context.Context, responseID, supplierID primitive.ObjectID, answers []SaveDraftAnswerRequest) error
+	// SaveMultipleDraftAnswers saves multiple draft answers at once and returns the new response version;
+	// a non-nil expectedVersion that no longer matches yields ErrResponseConflict
+	SaveMultipleDraftAnswers(ctx context.Context, responseID, supplierID primitive.ObjectID, expectedVersion *int, answers []SaveDraftAnswerRequest) (int, error)
+
+	// ExportQuestionnaire returns the questionnaire of a questionnaire requirement for answering offline
+	ExportQuestionnaire(ctx context.Context, requirementID, supplierID primitive.ObjectID) (*QuestionnaireExport, error)
+
+	// ImportDraftAnswers saves answers filled in offline as draft answers of a response
+	ImportDraftAnswers(ctx context.Context, responseID, supplierID primitive.ObjectID, answers []SaveDraftAnswerRequest) (*DraftImportResult, error)
+
+	// DeleteDraftAnswer clears the saved draft answer for a question and returns the updated response
+	DeleteDraftAnswer(ctx context.Context, responseID, supplierID primitive.ObjectID, questionID string) (*models.SupplierResponse, error)
 
 	// SubmitQuestionnaireResponse submits a questionnaire response
 	SubmitQuestionnaireResponse(ctx context.Context, responseID, supplierID primitive.ObjectID, answers []SubmitAnswerRequest) (*SubmissionResult, error)
@@ -56,6 +99,7 @@ type SaveDraftAnswerRequest struct {
 	QuestionID      string   `json:"question_id" binding:"required"`
 	SelectedOptions []string `json:"selected_options,omitempty"`
 	TextAnswer      string   `json:"text_answer,omitempty"`
+	Note            string   `json:"note,omitempty"`
 }
 
 // SubmitAnswerRequest represents an answer to submit
@@ -63,6 +107,22 @@ type SubmitAnswerRequest struct {
 	QuestionID      string   `json:"question_id" binding:"required"`
 	SelectedOptions []string `json:"selected_options,omitempty"`
 	TextAnswer      string   `json:"text_answer,omitempty"`
+	Note            string   `json:"note,omitempty"`
+}
+
+// QuestionnaireExport holds what a supplier needs to answer a questionnaire requirement offline
+type QuestionnaireExport struct {
+	Requirement   *models.Requirement
+	Questionnaire *models.Questionnaire
+	Questions     []models.Question
+}
+
+// DraftImportResult reports the outcome of importing offline answers
+type DraftImportResult struct {
+	// Version is the response version after the import
+	Version int
+	// Imported counts the answers saved; blank answer slots are skipped
+	Imported int
 }
 
 // SubmissionResult contains the result of a questionnaire submission
@@ -74,6 +134,8 @@ type SubmissionResult struct {
 	Score       int                             `json:"score"`
 	MaxScore    int                             `json:"max_score"`
 	Percentage  float64                         `json:"percentage"`
+	// ScoringScale is the company's scale the answers were scored on
+	ScoringScale models.ScoringScale `json:"scoring_scale"`
 }
 
 // responseService implements ResponseService
@@ -83,6 +145,8 @@ type responseService struct {
 	requirementRepo   repository.RequirementRepository
 	questionnaireRepo repository.QuestionnaireRepository
 	questionRepo      repository.QuestionRepository
+	orgRepo           repository.OrganizationRepository
+	webhooks          WebhookDispatcher
 }
 
 // NewResponseService creates a new response service
@@ -92,6 +156,8 @@ func NewResponseService(
 	requirementRepo repository.RequirementRepository,
 	questionnaireRepo repository.QuestionnaireRepository,
 	questionRepo repository.QuestionRepository,
+	orgRepo repository.OrganizationRepository,
+	webhooks WebhookDispatcher,
 ) ResponseService {
 	return &responseService{
 		responseRepo:      responseRepo,
@@ -99,6 +165,8 @@ func NewResponseService(
 		requirementRepo:   requirementRepo,
 		questionnaireRepo: questionnaireRepo,
 		questionRepo:      questionRepo,
+		orgRepo:           orgRepo,
+		webhooks:          webhooks,
 	}
 }
 
@@ -120,6 +188,10 @@ func (s *responseService) StartResponse(ctx context.Context, requirementID, supp
 		return nil, ErrRequirementNotFound
 	}
 
+	if requirement.IsApproved() {
+		return nil, ErrRequirementApproved
+	}
+
 	// Check if response can be started
 	if !requirement.CanStartResponse() {
 		return nil, ErrCannotStartResponse
@@ -202,6 +274,10 @@ func (s *responseService) SaveDraftAnswer(ctx context.Context, responseID, suppl
 		return err
 	}
 
+	if err := s.ensureResponseEditable(ctx, response); err != nil {
+		return err
+	}
+
 	// Cannot save draft after submission
 	if response.IsSubmitted() {
 		return ErrResponseAlreadySubmitted
@@ -218,6 +294,7 @@ func (s *responseService) SaveDraftAnswer(ctx context.Context, responseID, suppl
 		QuestionID:      questionID,
 		SelectedOptions: answer.SelectedOptions,
 		TextAnswer:      answer.TextAnswer,
+		Note:            strings.TrimSpace(answer.Note),
 		SavedAt:         time.Now().UTC(),
 	}
 
@@ -228,72 +305,242 @@ func (s *responseService) SaveDraftAnswer(ctx context.Context, responseID, suppl
 	return nil
 }
 
-// SaveMultipleDraftAnswers saves multiple draft answers at once
-func (s *responseService) SaveMultipleDraftAnswers(ctx context.Context, responseID, supplierID primitive.ObjectID, answers []SaveDraftAnswerRequest) error {
-	for _, answer := range answers {
-		if err := s.SaveDraftAnswer(ctx, responseID, supplierID, answer); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-// SubmitQuestionnaireResponse submits a questionnaire response
-// #BUSINESS_RULE: All answers are scored and saved to submission
-// #BUSINESS_RULE: Requirement status is updated to submitted
-func (s *responseService) SubmitQuestionnaireResponse(ctx context.Context, responseID, supplierID primitive.ObjectID, answers []SubmitAnswerRequest) (*SubmissionResult, error) {
+// SaveMultipleDraftAnswers saves multiple draft answers at once and returns the new response version
+// #BUSINESS_RULE: A save based on a stale version is rejected as a whole with ErrResponseConflict;
+// the client must reload the response, reapply its unsaved answers and save with the new version
+// #IMPLEMENTATION_DECISION: Answers are merged in memory and written in one compare-and-set, so
+// even saves without an expected version never interleave with a concurrent save
+func (s *responseService) SaveMultipleDraftAnswers(ctx context.Context, responseID, supplierID primitive.ObjectID, expectedVersion *int, answers []SaveDraftAnswerRequest) (int, error) {
 	// Verify response exists and belongs to supplier
 	response, err := s.GetResponse(ctx, responseID, &supplierID)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	// Cannot submit if already submitted
+	if err := s.ensureResponseEditable(ctx, response); err != nil {
+		return 0, err
+	}
+
+	// Cannot save draft after submission
 	if response.IsSubmitted() {
-		return nil, ErrResponseAlreadySubmitted
+		return 0, ErrResponseAlreadySubmitted
 	}
 
-	// Get requirement
-	requirement, err := s.requirementRepo.GetByID(ctx, response.RequirementID)
+	if expectedVersion != nil && *expectedVersion != response.Version {
+		return 0, ErrResponseConflict
+	}
+
+	// Merge new answers over the saved ones, keyed by question
+	merged := append([]models.DraftAnswer(nil), response.DraftAnswers...)
+	positions := make(map[primitive.ObjectID]int, len(merged))
+	for i, a := range merged {
+		positions[a.QuestionID] = i
+	}
+
+	now := time.Now().UTC()
+	for _, answer := range answers {
+		questionID, err := primitive.ObjectIDFromHex(answer.QuestionID)
+		if err != nil {
+			return 0, ErrInvalidAnswer
+		}
+
+		draftAnswer := models.DraftAnswer{
+			QuestionID:      questionID,
+			SelectedOptions: answer.SelectedOptions,
+			TextAnswer:      answer.TextAnswer,
+			Note:            strings.TrimSpace(answer.Note),
+			SavedAt:         now,
+		}
+		if i, ok := positions[questionID]; ok {
+			merged[i] = draftAnswer
+		} else {
+			positions[questionID] = len(merged)
+			merged = append(merged, draftAnswer)
+		}
+	}
+
+	version, err := s.responseRepo.ReplaceDraftAnswers(ctx, responseID, response.Version, merged)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get requirement: %w", err)
+		if errors.Is(err, models.ErrResponseConflict) {
+			return 0, ErrResponseConflict
+		}
+		// Submitted between the check above and the write
+		if errors.Is(err, models.ErrResponseAlreadySubmitted) {
+			return 0, ErrResponseAlreadySubmitted
+		}
+		if errors.Is(err, models.ErrResponseNotFound) {
+			return 0, ErrResponseNotFound
+		}
+		return 0, fmt.Errorf("failed to save draft answers: %w", err)
 	}
 
-	// Verify requirement is questionnaire type
+	return version, nil
+}
+
+// ExportQuestionnaire returns the questionnaire of a questionnaire requirement for answering offline
+// #BUSINESS_RULE: Only the assigned supplier can export; other suppliers get ErrRequirementNotFound
+func (s *responseService) ExportQuestionnaire(ctx context.Context, requirementID, supplierID primitive.ObjectID) (*QuestionnaireExport, error) {
+	requirement, err := s.requirementRepo.GetByID(ctx, requirementID)
+	if err != nil {
+		if errors.Is(err, models.ErrRequirementNotFound) {
+			return nil, ErrRequirementNotFound
+		}
+		return nil, fmt.Errorf("failed to get requirement: %w", err)
+	}
+	if requirement.SupplierID != supplierID {
+		return nil, ErrRequirementNotFound
+	}
 	if !requirement.IsQuestionnaireRequirement() || requirement.QuestionnaireID == nil {
-		return nil, errors.New("requirement is not a questionnaire requirement")
+		return nil, ErrNotQuestionnaire
 	}
 
-	// Get questionnaire
 	questionnaire, err := s.questionnaireRepo.GetByID(ctx, *requirement.QuestionnaireID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get questionnaire: %w", err)
 	}
+	questions, err := s.questionRepo.ListByQuestionnaire(ctx, questionnaire.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	return &QuestionnaireExport{
+		Requirement:   requirement,
+		Questionnaire: questionnaire,
+		Questions:     questions,
+	}, nil
+}
+
+// ImportDraftAnswers saves answers filled in offline as draft answers of a response
+// #BUSINESS_RULE: The whole file is rejected when any answer names a question outside the
+// questionnaire, so a file exported for another questionnaire never half-applies
+// #BUSINESS_RULE: Blank answer slots are skipped and leave existing draft answers untouched
+// #IMPLEMENTATION_DECISION: Saved through SaveMultipleDraftAnswers without an expected version;
+// the offline file is the supplier's latest intent
+func (s *responseService) ImportDraftAnswers(ctx context.Context, responseID, supplierID primitive.ObjectID, answers []SaveDraftAnswerRequest) (*DraftImportResult, error) {
+	response, err := s.GetResponse(ctx, responseID, &supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	requirement, err := s.requirementRepo.GetByID(ctx, response.RequirementID)
+	if err != nil {
+		if errors.Is(err, models.ErrRequirementNotFound) {
+			return nil, ErrRequirementNotFound
+		}
+		return nil, fmt.Errorf("failed to get requirement: %w", err)
+	}
+	if !requirement.IsQuestionnaireRequirement() || requirement.QuestionnaireID == nil {
+		return nil, ErrNotQuestionnaire
+	}
 
-	// Get questions
 	questions, err := s.questionRepo.ListByQuestionnaire(ctx, *requirement.QuestionnaireID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get questions: %w", err)
 	}
-
-	// Build question map for quick lookup
-	questionMap := make(map[string]*models.Question)
+	known := make(map[string]bool, len(questions))
 	for i := range questions {
-		questionMap[questions[i].ID.Hex()] = &questions[i]
+		known[questions[i].ID.Hex()] = true
 	}
 
-	// Create submission
-	submission := &models.QuestionnaireSubmission{
-		ResponseID:      responseID,
-		QuestionnaireID: *requirement.QuestionnaireID,
-		SupplierID:      supplierID,
-		StartedAt:       response.StartedAt,
+	var unknown []string
+	filled := make([]SaveDraftAnswerRequest, 0, len(answers))
+	for _, answer := range answers {
+		if !known[answer.QuestionID] {
+			unknown = append(unknown, answer.QuestionID)
+			continue
+		}
+		if len(answer.SelectedOptions) == 0 && strings.TrimSpace(answer.TextAnswer) == "" && strings.TrimSpace(answer.Note) == "" {
+			continue
+		}
+		filled = append(filled, answer)
+	}
+	if len(unknown) > 0 {
+		return nil, &UnknownQuestionsError{QuestionIDs: unknown}
+	}
+
+	version, err := s.SaveMultipleDraftAnswers(ctx, responseID, supplierID, nil, filled)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DraftImportResult{Version: version, Imported: len(filled)}, nil
+}
+
+// DeleteDraftAnswer clears the saved draft answer for a question and returns the updated response
+// #BUSINESS_RULE: Lets the UI drop answers to conditional questions that no longer apply
+func (s *responseService) DeleteDraftAnswer(ctx context.Context, responseID, supplierID primitive.ObjectID, questionID string) (*models.SupplierResponse, error) {
+	response, err := s.GetResponse(ctx, responseID, &supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureResponseEditable(ctx, response); err != nil {
+		return nil, err
+	}
+
+	if response.IsSubmitted() {
+		return nil, ErrResponseAlreadySubmitted
+	}
+
+	qID, err := primitive.ObjectIDFromHex(questionID)
+	if err != nil {
+		return nil, ErrInvalidAnswer
+	}
+
+	updated, err := s.responseRepo.DeleteDraftAnswer(ctx, responseID, qID)
+	if err != nil {
+		// The response was submitted between the check above and the update
+		if errors.Is(err, models.ErrResponseNotFound) {
+			return nil, ErrResponseAlreadySubmitted
+		}
+		return nil, fmt.Errorf("failed to delete draft answer: %w", err)
 	}
-	submission.BeforeCreate()
 
+	return updated, nil
+}
+
+// checkRequiredNotes reports every answered RequireNote question whose answer has no note
+// #BUSINESS_RULE: Only answered questions are checked; leaving a question unanswered is still allowed
+// and simply scores no points
+func checkRequiredNotes(questionMap map[string]*models.Question, answers []SubmitAnswerRequest) error {
+	var missing []string
+	for _, answer := range answers {
+		question, exists := questionMap[answer.QuestionID]
+		if exists && question.RequireNote && strings.TrimSpace(answer.Note) == "" {
+			missing = append(missing, answer.QuestionID)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingNotesError{QuestionIDs: missing}
+	}
+	return nil
+}
+
+// ensureResponseEditable rejects changes to a response whose requirement has been approved
+// #BUSINESS_RULE: Approval is final; the approved response stays immutable even when a stale
+// client still reaches the draft endpoints
+func (s *responseService) ensureResponseEditable(ctx context.Context, response *models.SupplierResponse) error {
+	requirement, err := s.requirementRepo.GetByID(ctx, response.RequirementID)
+	if err != nil {
+		if errors.Is(err, models.ErrRequirementNotFound) {
+			return ErrRequirementNotFound
+		}
+		return fmt.Errorf("failed to get requirement: %w", err)
+	}
+	if requirement.IsApproved() {
+		return ErrRequirementApproved
+	}
+	return nil
+}
+
+// scoreAnswers scores the answers, adds them with the per-topic totals to the submission and
+// calculates the final scores
+// #BUSINESS_RULE: Answers to unknown questions are skipped; topics without scorable questions are left out
+// #IMPLEMENTATION_DECISION: Shared by real submissions and company self-tests so both score identically
+func scoreAnswers(submission *models.QuestionnaireSubmission, topics []models.QuestionnaireTopic, questionMap map[string]*models.Question, answers []SubmitAnswerRequest, scale models.ScoringScale, passingScore int) {
 	// Build topic scores map
 	topicScores := make(map[string]*models.TopicScore)
-	for _, topic := range questionnaire.Topics {
+	for _, topic := range topics {
 		topicScores[topic.ID] = &models.TopicScore{
 			TopicID:   topic.ID,
 			TopicName: topic.Name,
@@ -310,20 +557,13 @@ func (s *responseService) SubmitQuestionnaireResponse(ctx context.Context, respo
 		}
 
 		// Calculate score for this answer
-		var pointsEarned int
-		if question.IsChoiceQuestion() {
-			pointsEarned = question.CalculateScore(answerReq.SelectedOptions)
-		} else if question.IsTextQuestion() {
-			// Text questions get full points if answered
-			if answerReq.TextAnswer != "" {
-				pointsEarned = question.MaxPoints
-			}
-		}
+		pointsEarned, maxPoints := question.ScoreAnswer(answerReq.SelectedOptions, answerReq.TextAnswer, scale)
 
 		// Check must-pass
 		var mustPassMet *bool
-		if question.IsMustPass {
-			passed := pointsEarned >= question.MaxPoints
+		// #BUSINESS_RULE: Must-pass only applies to scored questions
+		if question.IsMustPass && question.Scored {
+			passed := pointsEarned >= maxPoints
 			mustPassMet = &passed
 		}
 
@@ -332,8 +572,9 @@ func (s *responseService) SubmitQuestionnaireResponse(ctx context.Context, respo
 			QuestionID:      question.ID,
 			SelectedOptions: answerReq.SelectedOptions,
 			TextAnswer:      answerReq.TextAnswer,
+			Note:            strings.TrimSpace(answerReq.Note),
 			PointsEarned:    pointsEarned,
-			MaxPoints:       question.MaxPoints,
+			MaxPoints:       maxPoints,
 			IsMustPassMet:   mustPassMet,
 		}
 		submission.AddAnswer(submissionAnswer)
@@ -341,25 +582,98 @@ func (s *responseService) SubmitQuestionnaireResponse(ctx context.Context, respo
 		// Update topic score
 		if topic, exists := topicScores[question.TopicID]; exists {
 			topic.Score += pointsEarned
-			topic.MaxScore += question.MaxPoints
+			topic.MaxScore += maxPoints
 		}
 	}
 
-	// Add topic scores to submission
-	for _, topic := range topicScores {
-		if topic.MaxScore > 0 {
-			submission.AddTopicScore(*topic)
+	// Add topic scores to submission, in questionnaire order
+	for _, topic := range topics {
+		if score := topicScores[topic.ID]; score.MaxScore > 0 {
+			submission.AddTopicScore(*score)
 		}
 	}
 
+	// Calculate final scores
+	submission.CalculateScores(passingScore)
+}
+
+// SubmitQuestionnaireResponse submits a questionnaire response
+// #BUSINESS_RULE: All answers are scored and saved to submission
+// #BUSINESS_RULE: Requirement status is updated to submitted, or approved when it passed and auto-approval is on
+func (s *responseService) SubmitQuestionnaireResponse(ctx context.Context, responseID, supplierID primitive.ObjectID, answers []SubmitAnswerRequest) (*SubmissionResult, error) {
+	// Verify response exists and belongs to supplier
+	response, err := s.GetResponse(ctx, responseID, &supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get requirement
+	requirement, err := s.requirementRepo.GetByID(ctx, response.RequirementID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get requirement: %w", err)
+	}
+	if requirement.IsApproved() {
+		return nil, ErrRequirementApproved
+	}
+
+	// Cannot submit if already submitted
+	if response.IsSubmitted() {
+		return nil, ErrResponseAlreadySubmitted
+	}
+
+	// Verify requirement is questionnaire type
+	if !requirement.IsQuestionnaireRequirement() || requirement.QuestionnaireID == nil {
+		return nil, ErrNotQuestionnaire
+	}
+
+	// Get questionnaire
+	questionnaire, err := s.questionnaireRepo.GetByID(ctx, *requirement.QuestionnaireID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questionnaire: %w", err)
+	}
+
+	// Get questions
+	questions, err := s.questionRepo.ListByQuestionnaire(ctx, *requirement.QuestionnaireID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	// Build question map for quick lookup
+	questionMap := make(map[string]*models.Question)
+	for i := range questions {
+		questionMap[questions[i].ID.Hex()] = &questions[i]
+	}
+
+	if err := checkRequiredNotes(questionMap, answers); err != nil {
+		return nil, err
+	}
+
+	// Answers are scored on the company's scale
+	company, err := s.orgRepo.GetByID(ctx, questionnaire.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	scale := company.Settings.EffectiveScoringScale()
+
+	// Create submission
+	submission := &models.QuestionnaireSubmission{
+		ResponseID:      responseID,
+		QuestionnaireID: *requirement.QuestionnaireID,
+		SupplierID:      supplierID,
+		CompanyID:       requirement.CompanyID,
+		RequirementID:   requirement.ID,
+		StartedAt:       response.StartedAt,
+		ScoringScale:    &scale,
+	}
+	submission.BeforeCreate()
+
 	// Determine passing score
 	passingScore := questionnaire.PassingScore
 	if requirement.PassingScore != nil {
 		passingScore = *requirement.PassingScore
 	}
 
-	// Calculate final scores
-	submission.CalculateScores(passingScore)
+	scoreAnswers(submission, questionnaire.Topics, questionMap, answers, scale, passingScore)
 
 	// Calculate completion time
 	submission.CompletionTimeMinutes = int(time.Since(response.StartedAt).Minutes())
@@ -382,19 +696,28 @@ func (s *responseService) SubmitQuestionnaireResponse(ctx context.Context, respo
 	}
 
 	// Update requirement status
-	if submitErr := requirement.Submit(supplierID); submitErr == nil {
+	if submitErr := requirement.CompleteSubmission(supplierID, submission.Passed); submitErr == nil {
 		//nolint:errcheck // Best-effort update
 		s.requirementRepo.Update(ctx, requirement)
+
+		data := newRequirementWebhookData(requirement, &submission.Passed, "")
+		dispatchWebhook(ctx, s.webhooks, requirement.CompanyID, models.WebhookEventRequirementSubmitted, data)
+		if requirement.IsApproved() {
+			dispatchWebhook(ctx, s.webhooks, requirement.CompanyID, models.WebhookEventRequirementApproved, data)
+		}
 	}
 
+	metrics.RecordEvent(metrics.EventQuestionnaireSubmitted, metrics.Outcome(submission.Passed))
+
 	return &SubmissionResult{
-		Submission:  submission,
-		Response:    response,
-		Requirement: requirement,
-		Passed:      submission.Passed,
-		Score:       submission.TotalScore,
-		MaxScore:    submission.MaxPossibleScore,
-		Percentage:  submission.PercentageScore,
+		Submission:   submission,
+		Response:     response,
+		Requirement:  requirement,
+		Passed:       submission.Passed,
+		Score:        submission.TotalScore,
+		MaxScore:     submission.MaxPossibleScore,
+		Percentage:   submission.PercentageScore,
+		ScoringScale: scale,
 	}, nil
 }
 
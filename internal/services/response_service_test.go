@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+)
+
+func TestCheckRequiredNotes(t *testing.T) {
+	noted := &models.Question{ID: primitive.NewObjectID(), Type: models.QuestionTypeYesNo, RequireNote: true}
+	plain := &models.Question{ID: primitive.NewObjectID(), Type: models.QuestionTypeYesNo}
+	unanswered := &models.Question{ID: primitive.NewObjectID(), Type: models.QuestionTypeYesNo, RequireNote: true}
+	questionMap := map[string]*models.Question{
+		noted.ID.Hex():      noted,
+		plain.ID.Hex():      plain,
+		unanswered.ID.Hex(): unanswered,
+	}
+
+	err := checkRequiredNotes(questionMap, []SubmitAnswerRequest{
+		{QuestionID: noted.ID.Hex(), SelectedOptions: []string{"yes"}, Note: "   "},
+		{QuestionID: plain.ID.Hex(), SelectedOptions: []string{"no"}},
+	})
+	var missing *MissingNotesError
+	if !errors.Is(err, ErrMissingRequiredNotes) || !errors.As(err, &missing) {
+		t.Fatalf("Expected MissingNotesError, got %v", err)
+	}
+	if len(missing.QuestionIDs) != 1 || missing.QuestionIDs[0] != noted.ID.Hex() {
+		t.Errorf("Expected only %s to be reported, got %v", noted.ID.Hex(), missing.QuestionIDs)
+	}
+
+	err = checkRequiredNotes(questionMap, []SubmitAnswerRequest{
+		{QuestionID: noted.ID.Hex(), SelectedOptions: []string{"yes"}, Note: "Covered by ISO 27001 certificate"},
+	})
+	if err != nil {
+		t.Errorf("Expected answers with notes to pass, got %v", err)
+	}
+}
+
+func TestScoreAnswers_InformationalQuestions(t *testing.T) {
+	options := []models.QuestionOption{{ID: "yes", Text: "Yes", Points: 10}, {ID: "no", Text: "No", Points: 0}}
+	mfa := &models.Question{ID: primitive.NewObjectID(), TopicID: "sec", Type: models.QuestionTypeYesNo, MaxPoints: 10, Scored: true, Options: options}
+	backups := &models.Question{ID: primitive.NewObjectID(), TopicID: "sec", Type: models.QuestionTypeYesNo, MaxPoints: 10, Scored: true, Options: options}
+	headcount := &models.Question{ID: primitive.NewObjectID(), TopicID: "info", Type: models.QuestionTypeText, MaxPoints: 1, IsMustPass: true}
+	questionMap := map[string]*models.Question{mfa.ID.Hex(): mfa, backups.ID.Hex(): backups, headcount.ID.Hex(): headcount}
+	topics := []models.QuestionnaireTopic{{ID: "sec", Name: "Security"}, {ID: "info", Name: "Company facts"}}
+
+	submission := &models.QuestionnaireSubmission{}
+	scoreAnswers(submission, topics, questionMap, []SubmitAnswerRequest{
+		{QuestionID: mfa.ID.Hex(), SelectedOptions: []string{"yes"}},
+		{QuestionID: backups.ID.Hex(), SelectedOptions: []string{"no"}},
+		{QuestionID: headcount.ID.Hex()},
+	}, models.DefaultScoringScale(), 50)
+
+	if submission.TotalScore != 10 || submission.MaxPossibleScore != 20 || submission.PercentageScore != 50 {
+		t.Errorf("Expected 10/20 (50%%), got %d/%d (%.1f%%)", submission.TotalScore, submission.MaxPossibleScore, submission.PercentageScore)
+	}
+	// The informational answer is still recorded but neither scores nor fails the must-pass check
+	if len(submission.Answers) != 3 || !submission.Passed || submission.MustPassFailed {
+		t.Errorf("Expected 3 recorded answers and a pass, got %d answers, passed=%v", len(submission.Answers), submission.Passed)
+	}
+	if len(submission.TopicScores) != 1 || submission.TopicScores[0].TopicID != "sec" {
+		t.Errorf("Expected only the scored topic, got %+v", submission.TopicScores)
+	}
+}
+
+func (r *memoryResponseRepo) GetByID(_ context.Context, id primitive.ObjectID) (*models.SupplierResponse, error) {
+	for _, response := range r.responses {
+		if response.ID == id {
+			return response, nil
+		}
+	}
+	return nil, models.ErrResponseNotFound
+}
+
+func (r *memoryResponseRepo) ReplaceDraftAnswers(_ context.Context, responseID primitive.ObjectID, expectedVersion int, answers []models.DraftAnswer) (int, error) {
+	for _, response := range r.responses {
+		if response.ID == responseID {
+			if response.IsSubmitted() {
+				return 0, models.ErrResponseAlreadySubmitted
+			}
+			if response.Version != expectedVersion {
+				return 0, models.ErrResponseConflict
+			}
+			response.DraftAnswers = answers
+			response.Version++
+			return response.Version, nil
+		}
+	}
+	return 0, models.ErrResponseNotFound
+}
+
+func TestResponseService_ImportDraftAnswers(t *testing.T) {
+	ctx := context.Background()
+	supplierID, questionnaireID := primitive.NewObjectID(), primitive.NewObjectID()
+	answered := models.Question{ID: primitive.NewObjectID(), QuestionnaireID: questionnaireID, Type: models.QuestionTypeText}
+	blank := models.Question{ID: primitive.NewObjectID(), QuestionnaireID: questionnaireID, Type: models.QuestionTypeText}
+
+	requirement := &models.Requirement{ID: primitive.NewObjectID(), SupplierID: supplierID, Type: models.RequirementTypeQuestionnaire, Status: models.RequirementStatusInProgress, QuestionnaireID: &questionnaireID}
+	response := &models.SupplierResponse{ID: primitive.NewObjectID(), RequirementID: requirement.ID, SupplierID: supplierID}
+
+	responses := &memoryResponseRepo{responses: map[primitive.ObjectID]*models.SupplierResponse{requirement.ID: response}}
+	requirements := &memoryRequirementRepo{requirements: map[primitive.ObjectID]*models.Requirement{requirement.ID: requirement}}
+	questions := &memoryQuestionRepo{questions: map[primitive.ObjectID]models.Question{answered.ID: answered, blank.ID: blank}}
+	service := NewResponseService(responses, nil, requirements, nil, questions, nil, nil)
+
+	// A file naming a foreign question is rejected as a whole
+	foreignID := primitive.NewObjectID().Hex()
+	var unknown *UnknownQuestionsError
+	_, err := service.ImportDraftAnswers(ctx, response.ID, supplierID, []SaveDraftAnswerRequest{
+		{QuestionID: answered.ID.Hex(), TextAnswer: "MFA everywhere"},
+		{QuestionID: foreignID, TextAnswer: "?"},
+	})
+	if !errors.As(err, &unknown) || len(unknown.QuestionIDs) != 1 || unknown.QuestionIDs[0] != foreignID {
+		t.Fatalf("Expected UnknownQuestionsError for %s, got %v", foreignID, err)
+	}
+	if len(response.DraftAnswers) != 0 {
+		t.Fatalf("Expected nothing to be saved, got %+v", response.DraftAnswers)
+	}
+
+	result, err := service.ImportDraftAnswers(ctx, response.ID, supplierID, []SaveDraftAnswerRequest{
+		{QuestionID: answered.ID.Hex(), TextAnswer: "MFA everywhere"},
+		{QuestionID: blank.ID.Hex(), SelectedOptions: []string{}},
+	})
+	if err != nil {
+		t.Fatalf("ImportDraftAnswers failed: %v", err)
+	}
+	// The blank slot is skipped
+	if result.Imported != 1 || result.Version != 1 || len(response.DraftAnswers) != 1 || response.DraftAnswers[0].QuestionID != answered.ID {
+		t.Errorf("Expected only the filled answer to be saved, got %+v and %+v", result, response.DraftAnswers)
+	}
+
+	// Drafts of a submitted response cannot be replaced
+	response.Submit()
+	_, err = service.ImportDraftAnswers(ctx, response.ID, supplierID, []SaveDraftAnswerRequest{
+		{QuestionID: blank.ID.Hex(), TextAnswer: "Too late"},
+	})
+	if !errors.Is(err, ErrResponseAlreadySubmitted) {
+		t.Fatalf("Expected ErrResponseAlreadySubmitted, got %v", err)
+	}
+	if len(response.DraftAnswers) != 1 {
+		t.Errorf("Expected the submitted drafts to be kept, got %+v", response.DraftAnswers)
+	}
+}
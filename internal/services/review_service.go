@@ -5,9 +5,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"github.com/checkfix-tools/nisfix_backend/internal/metrics"
 	"github.com/checkfix-tools/nisfix_backend/internal/models"
 	"github.com/checkfix-tools/nisfix_backend/internal/repository"
 )
@@ -17,8 +20,38 @@ var (
 	ErrCannotReview    = errors.New("cannot review this requirement")
 	ErrAlreadyReviewed = errors.New("requirement has already been reviewed")
 	ErrNoSubmission    = errors.New("no submission to review")
+	ErrInvalidDecision = errors.New("invalid review decision")
+	ErrReasonRequired  = errors.New("reason is required for this decision")
+	ErrBulkReviewSize  = errors.New("bulk review batch is empty or too large")
 )
 
+// MaxBulkReviewItems caps how many requirements a single bulk review may decide
+const MaxBulkReviewItems = 100
+
+// ReviewDecision is the outcome applied to a submitted requirement
+type ReviewDecision string
+
+// Review decisions
+const (
+	ReviewDecisionApprove         ReviewDecision = "approve"
+	ReviewDecisionReject          ReviewDecision = "reject"
+	ReviewDecisionRequestRevision ReviewDecision = "request_revision"
+)
+
+// BulkReviewItem is a single decision within a bulk review
+type BulkReviewItem struct {
+	RequirementID primitive.ObjectID
+	Decision      ReviewDecision
+	Reason        string
+}
+
+// BulkReviewResult reports the outcome for one item of a bulk review
+type BulkReviewResult struct {
+	RequirementID primitive.ObjectID
+	Requirement   *models.Requirement
+	Err           error
+}
+
 // ReviewService handles requirement review business logic
 // #INTEGRATION_POINT: Used by review handler for company review workflow
 type ReviewService interface {
@@ -33,8 +66,38 @@ type ReviewService interface {
 
 	// GetSubmissionForReview gets the submission for a requirement
 	GetSubmissionForReview(ctx context.Context, requirementID, companyID primitive.ObjectID) (*ReviewSubmission, error)
+
+	// GetSubmissionDetail pairs every questionnaire question with the supplier's submitted answer
+	GetSubmissionDetail(ctx context.Context, requirementID, companyID primitive.ObjectID) (*SubmissionDetail, error)
+
+	// BulkReview applies a decision to each item independently and reports a result per item
+	BulkReview(ctx context.Context, companyID, userID primitive.ObjectID, items []BulkReviewItem) ([]BulkReviewResult, error)
+
+	// ExportCompanySubmissions streams the outcome of every submission to the company's questionnaires to fn in batches
+	ExportCompanySubmissions(ctx context.Context, companyID primitive.ObjectID, filters SubmissionExportFilters, fn func([]SubmissionExportRow) error) error
+}
+
+// SubmissionExportFilters narrows a submission export to a submission date range; nil bounds are open
+type SubmissionExportFilters struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// SubmissionExportRow is one submission in a submission export
+type SubmissionExportRow struct {
+	SupplierName     string    `json:"supplier_name"`
+	Questionnaire    string    `json:"questionnaire"`
+	RequirementTitle string    `json:"requirement_title"`
+	Score            int       `json:"score"`
+	MaxScore         int       `json:"max_score"`
+	PercentageScore  float64   `json:"percentage_score"`
+	Passed           bool      `json:"passed"`
+	SubmittedAt      time.Time `json:"submitted_at"`
 }
 
+// submissionExportBatchSize is the number of submissions enriched and written per batch
+const submissionExportBatchSize = 200
+
 // ReviewSubmission combines submission with response for review
 type ReviewSubmission struct {
 	Requirement *models.Requirement             `json:"requirement"`
@@ -42,11 +105,30 @@ type ReviewSubmission struct {
 	Submission  *models.QuestionnaireSubmission `json:"submission,omitempty"`
 }
 
+// SubmissionDetail is a question-by-question view of a submitted questionnaire
+type SubmissionDetail struct {
+	Requirement *models.Requirement             `json:"requirement"`
+	Response    *models.SupplierResponse        `json:"response"`
+	Submission  *models.QuestionnaireSubmission `json:"submission"`
+	Items       []SubmissionDetailItem          `json:"items"`
+}
+
+// SubmissionDetailItem pairs a question with the answer given to it
+type SubmissionDetailItem struct {
+	Question        models.Question          `json:"question"`
+	Answer          *models.SubmissionAnswer `json:"answer,omitempty"`
+	SelectedOptions []models.QuestionOption  `json:"selected_options"`
+}
+
 // reviewService implements ReviewService
 type reviewService struct {
-	requirementRepo repository.RequirementRepository
-	responseRepo    repository.ResponseRepository
-	submissionRepo  repository.SubmissionRepository
+	requirementRepo   repository.RequirementRepository
+	responseRepo      repository.ResponseRepository
+	submissionRepo    repository.SubmissionRepository
+	questionRepo      repository.QuestionRepository
+	questionnaireRepo repository.QuestionnaireRepository
+	orgRepo           repository.OrganizationRepository
+	webhooks          WebhookDispatcher
 }
 
 // NewReviewService creates a new review service
@@ -54,18 +136,55 @@ func NewReviewService(
 	requirementRepo repository.RequirementRepository,
 	responseRepo repository.ResponseRepository,
 	submissionRepo repository.SubmissionRepository,
+	questionRepo repository.QuestionRepository,
+	questionnaireRepo repository.QuestionnaireRepository,
+	orgRepo repository.OrganizationRepository,
+	webhooks WebhookDispatcher,
 ) ReviewService {
 	return &reviewService{
-		requirementRepo: requirementRepo,
-		responseRepo:    responseRepo,
-		submissionRepo:  submissionRepo,
+		requirementRepo:   requirementRepo,
+		responseRepo:      responseRepo,
+		submissionRepo:    submissionRepo,
+		questionRepo:      questionRepo,
+		questionnaireRepo: questionnaireRepo,
+		orgRepo:           orgRepo,
+		webhooks:          webhooks,
 	}
 }
 
 // ApproveRequirement approves a submitted requirement
 // #BUSINESS_RULE: Only submitted requirements can be approved
 func (s *reviewService) ApproveRequirement(ctx context.Context, requirementID, companyID, userID primitive.ObjectID, notes string) (*models.Requirement, error) {
-	// Get requirement
+	requirement, err := s.getOwnedRequirement(ctx, requirementID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.approve(ctx, requirement, companyID, userID, notes)
+}
+
+// RejectRequirement rejects a submitted requirement
+// #BUSINESS_RULE: Only submitted requirements can be rejected
+// #BUSINESS_RULE: Rejection allows supplier to retry
+func (s *reviewService) RejectRequirement(ctx context.Context, requirementID, companyID, userID primitive.ObjectID, reason string) (*models.Requirement, error) {
+	requirement, err := s.getOwnedRequirement(ctx, requirementID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.reject(ctx, requirement, companyID, userID, reason)
+}
+
+// RequestRevision requests revision for a submitted requirement
+// #BUSINESS_RULE: Puts requirement in under_review status for supplier to resubmit
+func (s *reviewService) RequestRevision(ctx context.Context, requirementID, companyID, userID primitive.ObjectID, reason string) (*models.Requirement, error) {
+	requirement, err := s.getOwnedRequirement(ctx, requirementID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.requestRevision(ctx, requirement, companyID, userID, reason)
+}
+
+// getOwnedRequirement loads a requirement and verifies it belongs to the company
+func (s *reviewService) getOwnedRequirement(ctx context.Context, requirementID, companyID primitive.ObjectID) (*models.Requirement, error) {
 	requirement, err := s.requirementRepo.GetByID(ctx, requirementID)
 	if err != nil {
 		if errors.Is(err, models.ErrRequirementNotFound) {
@@ -79,13 +198,18 @@ func (s *reviewService) ApproveRequirement(ctx context.Context, requirementID, c
 		return nil, ErrRequirementNotFound
 	}
 
+	return requirement, nil
+}
+
+// approve approves an already loaded requirement owned by the company
+func (s *reviewService) approve(ctx context.Context, requirement *models.Requirement, companyID, userID primitive.ObjectID, notes string) (*models.Requirement, error) {
 	// Check if can be reviewed
 	if !requirement.CanBeReviewed() {
 		return nil, ErrCannotReview
 	}
 
 	// Get response and mark as reviewed
-	response, getErr := s.responseRepo.GetByRequirement(ctx, requirementID)
+	response, getErr := s.responseRepo.GetByRequirement(ctx, requirement.ID)
 	if getErr == nil && response != nil {
 		response.MarkReviewed(userID, notes)
 		//nolint:errcheck // Best-effort update
@@ -101,34 +225,22 @@ func (s *reviewService) ApproveRequirement(ctx context.Context, requirementID, c
 		return nil, fmt.Errorf("failed to update requirement: %w", err)
 	}
 
+	metrics.RecordEvent(metrics.EventRequirementApproved, strings.ToLower(string(requirement.Type)))
+	dispatchWebhook(ctx, s.webhooks, companyID, models.WebhookEventRequirementApproved,
+		newRequirementWebhookData(requirement, nil, notes))
+
 	return requirement, nil
 }
 
-// RejectRequirement rejects a submitted requirement
-// #BUSINESS_RULE: Only submitted requirements can be rejected
-// #BUSINESS_RULE: Rejection allows supplier to retry
-func (s *reviewService) RejectRequirement(ctx context.Context, requirementID, companyID, userID primitive.ObjectID, reason string) (*models.Requirement, error) {
-	// Get requirement
-	requirement, err := s.requirementRepo.GetByID(ctx, requirementID)
-	if err != nil {
-		if errors.Is(err, models.ErrRequirementNotFound) {
-			return nil, ErrRequirementNotFound
-		}
-		return nil, fmt.Errorf("failed to get requirement: %w", err)
-	}
-
-	// Verify company ownership
-	if requirement.CompanyID != companyID {
-		return nil, ErrRequirementNotFound
-	}
-
+// reject rejects an already loaded requirement owned by the company
+func (s *reviewService) reject(ctx context.Context, requirement *models.Requirement, companyID, userID primitive.ObjectID, reason string) (*models.Requirement, error) {
 	// Check if can be reviewed
 	if !requirement.CanBeReviewed() {
 		return nil, ErrCannotReview
 	}
 
 	// Get response and mark as reviewed
-	response, getErr := s.responseRepo.GetByRequirement(ctx, requirementID)
+	response, getErr := s.responseRepo.GetByRequirement(ctx, requirement.ID)
 	if getErr == nil && response != nil {
 		response.MarkReviewed(userID, reason)
 		//nolint:errcheck // Best-effort update
@@ -144,26 +256,15 @@ func (s *reviewService) RejectRequirement(ctx context.Context, requirementID, co
 		return nil, fmt.Errorf("failed to update requirement: %w", err)
 	}
 
+	metrics.RecordEvent(metrics.EventRequirementRejected, strings.ToLower(string(requirement.Type)))
+	dispatchWebhook(ctx, s.webhooks, companyID, models.WebhookEventRequirementRejected,
+		newRequirementWebhookData(requirement, nil, reason))
+
 	return requirement, nil
 }
 
-// RequestRevision requests revision for a submitted requirement
-// #BUSINESS_RULE: Puts requirement in under_review status for supplier to resubmit
-func (s *reviewService) RequestRevision(ctx context.Context, requirementID, companyID, userID primitive.ObjectID, reason string) (*models.Requirement, error) {
-	// Get requirement
-	requirement, err := s.requirementRepo.GetByID(ctx, requirementID)
-	if err != nil {
-		if errors.Is(err, models.ErrRequirementNotFound) {
-			return nil, ErrRequirementNotFound
-		}
-		return nil, fmt.Errorf("failed to get requirement: %w", err)
-	}
-
-	// Verify company ownership
-	if requirement.CompanyID != companyID {
-		return nil, ErrRequirementNotFound
-	}
-
+// requestRevision sends an already loaded requirement owned by the company back for revision
+func (s *reviewService) requestRevision(ctx context.Context, requirement *models.Requirement, companyID, userID primitive.ObjectID, reason string) (*models.Requirement, error) {
 	// Check if can be reviewed
 	if !requirement.CanBeReviewed() {
 		return nil, ErrCannotReview
@@ -178,6 +279,9 @@ func (s *reviewService) RequestRevision(ctx context.Context, requirementID, comp
 		return nil, fmt.Errorf("failed to update requirement: %w", err)
 	}
 
+	dispatchWebhook(ctx, s.webhooks, companyID, models.WebhookEventRequirementRevisionRequested,
+		newRequirementWebhookData(requirement, nil, reason))
+
 	return requirement, nil
 }
 
@@ -221,3 +325,182 @@ func (s *reviewService) GetSubmissionForReview(ctx context.Context, requirementI
 
 	return result, nil
 }
+
+// GetSubmissionDetail pairs every questionnaire question with the supplier's submitted answer
+// #BUSINESS_RULE: Only the owning company can see the detail, and only once the response is submitted
+// #IMPLEMENTATION_DECISION: Questions are loaded from the questionnaire rather than derived from the
+// answers so unanswered questions still appear, with a nil answer
+func (s *reviewService) GetSubmissionDetail(ctx context.Context, requirementID, companyID primitive.ObjectID) (*SubmissionDetail, error) {
+	review, err := s.GetSubmissionForReview(ctx, requirementID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	submission := review.Submission
+	if submission == nil {
+		if review.Response.SubmissionID == nil {
+			return nil, ErrNoSubmission
+		}
+		submission, err = s.submissionRepo.GetByID(ctx, *review.Response.SubmissionID)
+		if err != nil {
+			if errors.Is(err, models.ErrSubmissionNotFound) {
+				return nil, ErrNoSubmission
+			}
+			return nil, fmt.Errorf("failed to get submission: %w", err)
+		}
+	}
+
+	questions, err := s.questionRepo.ListByQuestionnaire(ctx, submission.QuestionnaireID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list questions: %w", err)
+	}
+
+	return &SubmissionDetail{
+		Requirement: review.Requirement,
+		Response:    review.Response,
+		Submission:  submission,
+		Items:       pairQuestionsWithAnswers(questions, submission.Answers),
+	}, nil
+}
+
+// pairQuestionsWithAnswers matches answers to questions by question ID, keeping question order
+func pairQuestionsWithAnswers(questions []models.Question, answers []models.SubmissionAnswer) []SubmissionDetailItem {
+	byQuestion := make(map[primitive.ObjectID]*models.SubmissionAnswer, len(answers))
+	for i := range answers {
+		byQuestion[answers[i].QuestionID] = &answers[i]
+	}
+
+	items := make([]SubmissionDetailItem, len(questions))
+	for i, q := range questions {
+		item := SubmissionDetailItem{Question: q, SelectedOptions: []models.QuestionOption{}}
+		if answer, ok := byQuestion[q.ID]; ok {
+			item.Answer = answer
+			for _, optionID := range answer.SelectedOptions {
+				for _, opt := range q.Options {
+					if opt.ID == optionID {
+						item.SelectedOptions = append(item.SelectedOptions, opt)
+						break
+					}
+				}
+			}
+		}
+		items[i] = item
+	}
+	return items
+}
+
+// BulkReview applies a decision to each item independently and reports a result per item
+// #BUSINESS_RULE: Each item goes through the same ownership and status checks as a single review,
+// so only requirements awaiting manual review can be decided; failures never abort the batch
+// #IMPLEMENTATION_DECISION: Items are applied sequentially rather than in a transaction - a partial
+// batch is the expected outcome and each item reports why it was skipped
+func (s *reviewService) BulkReview(ctx context.Context, companyID, userID primitive.ObjectID, items []BulkReviewItem) ([]BulkReviewResult, error) {
+	if len(items) == 0 || len(items) > MaxBulkReviewItems {
+		return nil, ErrBulkReviewSize
+	}
+
+	// Load the whole batch in one query. A repeated ID maps to the same requirement, so the
+	// second decision sees the first one's status change and fails with ErrCannotReview
+	ids := make([]primitive.ObjectID, len(items))
+	for i, item := range items {
+		ids[i] = item.RequirementID
+	}
+	requirements, err := s.requirementRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get requirements: %w", err)
+	}
+
+	results := make([]BulkReviewResult, len(items))
+	for i, item := range items {
+		requirement, err := s.applyDecision(ctx, companyID, userID, requirements[item.RequirementID], item)
+		results[i] = BulkReviewResult{
+			RequirementID: item.RequirementID,
+			Requirement:   requirement,
+			Err:           err,
+		}
+	}
+	return results, nil
+}
+
+// applyDecision dispatches a single bulk review item to the matching review action
+func (s *reviewService) applyDecision(ctx context.Context, companyID, userID primitive.ObjectID, requirement *models.Requirement, item BulkReviewItem) (*models.Requirement, error) {
+	reason := strings.TrimSpace(item.Reason)
+
+	switch item.Decision {
+	case ReviewDecisionApprove:
+	case ReviewDecisionReject, ReviewDecisionRequestRevision:
+		if reason == "" {
+			return nil, ErrReasonRequired
+		}
+	default:
+		return nil, ErrInvalidDecision
+	}
+
+	// Missing and foreign requirements are indistinguishable to the caller
+	if requirement == nil || requirement.CompanyID != companyID {
+		return nil, ErrRequirementNotFound
+	}
+
+	switch item.Decision {
+	case ReviewDecisionReject:
+		return s.reject(ctx, requirement, companyID, userID, reason)
+	case ReviewDecisionRequestRevision:
+		return s.requestRevision(ctx, requirement, companyID, userID, reason)
+	default:
+		return s.approve(ctx, requirement, companyID, userID, reason)
+	}
+}
+
+// ExportCompanySubmissions streams the outcome of every submission to the company's questionnaires to fn in batches
+// #QUERY_PATTERN: Per batch, one lookup each for supplier organizations, questionnaires and requirements
+// #IMPLEMENTATION_DECISION: Batches keep memory flat however many submissions a company has collected
+func (s *reviewService) ExportCompanySubmissions(ctx context.Context, companyID primitive.ObjectID, filters SubmissionExportFilters, fn func([]SubmissionExportRow) error) error {
+	return s.submissionRepo.ForEachByCompany(ctx, companyID, filters.From, filters.To, submissionExportBatchSize,
+		func(submissions []models.QuestionnaireSubmission) error {
+			supplierIDs := make([]primitive.ObjectID, len(submissions))
+			questionnaireIDs := make([]primitive.ObjectID, len(submissions))
+			requirementIDs := make([]primitive.ObjectID, len(submissions))
+			for i := range submissions {
+				supplierIDs[i] = submissions[i].SupplierID
+				questionnaireIDs[i] = submissions[i].QuestionnaireID
+				requirementIDs[i] = submissions[i].RequirementID
+			}
+
+			suppliers, err := s.orgRepo.GetByIDs(ctx, supplierIDs)
+			if err != nil {
+				return fmt.Errorf("failed to get supplier organizations: %w", err)
+			}
+			questionnaires, err := s.questionnaireRepo.GetByIDs(ctx, questionnaireIDs)
+			if err != nil {
+				return fmt.Errorf("failed to get questionnaires: %w", err)
+			}
+			requirements, err := s.requirementRepo.GetByIDs(ctx, requirementIDs)
+			if err != nil {
+				return fmt.Errorf("failed to get requirements: %w", err)
+			}
+
+			rows := make([]SubmissionExportRow, len(submissions))
+			for i := range submissions {
+				submission := &submissions[i]
+				rows[i] = SubmissionExportRow{
+					Score:           submission.TotalScore,
+					MaxScore:        submission.MaxPossibleScore,
+					PercentageScore: submission.PercentageScore,
+					Passed:          submission.Passed,
+				}
+				if submission.SubmittedAt != nil {
+					rows[i].SubmittedAt = *submission.SubmittedAt
+				}
+				if org, ok := suppliers[submission.SupplierID]; ok {
+					rows[i].SupplierName = org.Name
+				}
+				if questionnaire, ok := questionnaires[submission.QuestionnaireID]; ok {
+					rows[i].Questionnaire = questionnaire.Name
+				}
+				if requirement, ok := requirements[submission.RequirementID]; ok {
+					rows[i].RequirementTitle = requirement.Title
+				}
+			}
+			return fn(rows)
+		})
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// emptyResponseRepo has no responses; unused methods are not implemented
+type emptyResponseRepo struct {
+	repository.ResponseRepository
+}
+
+func (emptyResponseRepo) GetByRequirement(context.Context, primitive.ObjectID) (*models.SupplierResponse, error) {
+	return nil, models.ErrResponseNotFound
+}
+
+func TestReviewService_BulkReview(t *testing.T) {
+	ctx := context.Background()
+	companyID, userID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	newRequirement := func(owner primitive.ObjectID, status models.RequirementStatus) *models.Requirement {
+		return &models.Requirement{ID: primitive.NewObjectID(), CompanyID: owner, Status: status}
+	}
+	approve := newRequirement(companyID, models.RequirementStatusSubmitted)
+	reject := newRequirement(companyID, models.RequirementStatusSubmitted)
+	pending := newRequirement(companyID, models.RequirementStatusPending)
+	foreign := newRequirement(primitive.NewObjectID(), models.RequirementStatusSubmitted)
+	noReason := newRequirement(companyID, models.RequirementStatusSubmitted)
+
+	repo := &memoryRequirementRepo{requirements: map[primitive.ObjectID]*models.Requirement{}}
+	for _, r := range []*models.Requirement{approve, reject, pending, foreign, noReason} {
+		repo.requirements[r.ID] = r
+	}
+	service := NewReviewService(repo, emptyResponseRepo{}, nil, nil, nil, nil, nil)
+
+	results, err := service.BulkReview(ctx, companyID, userID, []BulkReviewItem{
+		{RequirementID: approve.ID, Decision: ReviewDecisionApprove},
+		{RequirementID: reject.ID, Decision: ReviewDecisionReject, Reason: "Missing evidence"},
+		{RequirementID: pending.ID, Decision: ReviewDecisionApprove},
+		{RequirementID: foreign.ID, Decision: ReviewDecisionApprove},
+		{RequirementID: noReason.ID, Decision: ReviewDecisionRequestRevision},
+		{RequirementID: approve.ID, Decision: "escalate"},
+		{RequirementID: reject.ID, Decision: ReviewDecisionApprove},
+	})
+	if err != nil {
+		t.Fatalf("BulkReview failed: %v", err)
+	}
+
+	wantErrs := []error{nil, nil, ErrCannotReview, ErrRequirementNotFound, ErrReasonRequired, ErrInvalidDecision, ErrCannotReview}
+	for i, want := range wantErrs {
+		if want == nil && results[i].Err != nil || want != nil && !errors.Is(results[i].Err, want) {
+			t.Errorf("Item %d: expected error %v, got %v", i, want, results[i].Err)
+		}
+	}
+	if got := repo.requirements[approve.ID].Status; got != models.RequirementStatusApproved {
+		t.Errorf("Expected approved status, got %s", got)
+	}
+	if got := repo.requirements[reject.ID].Status; got != models.RequirementStatusRejected {
+		t.Errorf("Expected rejected status, got %s", got)
+	}
+	if got := repo.requirements[noReason.ID].Status; got != models.RequirementStatusSubmitted {
+		t.Errorf("Expected item without reason to stay submitted, got %s", got)
+	}
+
+	if _, err := service.BulkReview(ctx, companyID, userID, nil); !errors.Is(err, ErrBulkReviewSize) {
+		t.Errorf("Expected ErrBulkReviewSize for an empty batch, got %v", err)
+	}
+}
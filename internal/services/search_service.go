@@ -0,0 +1,137 @@
+// Package services provides business logic implementations.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// Search limits
+const (
+	MinSearchQueryLength = 2
+	MaxSearchQueryLength = 100
+
+	// SearchResultsPerCategory caps the hits returned for each result category
+	SearchResultsPerCategory = 10
+)
+
+// searchTimeout is the deadline shared by all category queries of one search
+const searchTimeout = 5 * time.Second
+
+// ErrInvalidSearchQuery is returned when the search query is too short or too long
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+
+// SearchService searches across a company's suppliers, requirements and questionnaires
+// #INTEGRATION_POINT: Backs the company portal's global search box
+type SearchService interface {
+	// Search runs the query against every category of a company's data
+	Search(ctx context.Context, companyID primitive.ObjectID, query string) (*SearchResults, error)
+}
+
+// SearchResults holds the capped, best-first hits of each category
+type SearchResults struct {
+	Suppliers      []models.CompanySupplierRelationship
+	Requirements   []models.Requirement
+	Questionnaires []models.Questionnaire
+}
+
+// searchService implements SearchService
+type searchService struct {
+	relationshipRepo  repository.RelationshipRepository
+	orgRepo           repository.OrganizationRepository
+	requirementRepo   repository.RequirementRepository
+	questionnaireRepo repository.QuestionnaireRepository
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(
+	relationshipRepo repository.RelationshipRepository,
+	orgRepo repository.OrganizationRepository,
+	requirementRepo repository.RequirementRepository,
+	questionnaireRepo repository.QuestionnaireRepository,
+) SearchService {
+	return &searchService{
+		relationshipRepo:  relationshipRepo,
+		orgRepo:           orgRepo,
+		requirementRepo:   requirementRepo,
+		questionnaireRepo: questionnaireRepo,
+	}
+}
+
+// Search runs the query against every category of a company's data
+// #IMPLEMENTATION_DECISION: Categories are queried concurrently under one deadline; the first
+// failure cancels the others and fails the search rather than returning partial results
+// #SECURITY_ASSUMPTION: Every query is filtered by companyID, supplier names only among the company's suppliers
+func (s *searchService) Search(ctx context.Context, companyID primitive.ObjectID, query string) (*SearchResults, error) {
+	query = strings.TrimSpace(query)
+	if n := utf8.RuneCountInString(query); n < MinSearchQueryLength || n > MaxSearchQueryLength {
+		return nil, ErrInvalidSearchQuery
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, searchTimeout)
+	defer cancel()
+
+	results := &SearchResults{}
+	g, gctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		suppliers, err := s.searchSuppliers(gctx, companyID, query)
+		if err != nil {
+			return fmt.Errorf("failed to search suppliers: %w", err)
+		}
+		results.Suppliers = suppliers
+		return nil
+	})
+	g.Go(func() error {
+		requirements, err := s.requirementRepo.SearchByCompany(gctx, companyID, query, SearchResultsPerCategory)
+		if err != nil {
+			return fmt.Errorf("failed to search requirements: %w", err)
+		}
+		results.Requirements = requirements
+		return nil
+	})
+	g.Go(func() error {
+		questionnaires, err := s.questionnaireRepo.SearchByCompany(gctx, companyID, query, SearchResultsPerCategory)
+		if err != nil {
+			return fmt.Errorf("failed to search questionnaires: %w", err)
+		}
+		results.Questionnaires = questionnaires
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// searchSuppliers matches suppliers by organization name or invited email
+// #QUERY_PATTERN: Names live on the supplier organization, so they are searched among the
+// company's supplier IDs first and the matches folded into the relationship query
+func (s *searchService) searchSuppliers(ctx context.Context, companyID primitive.ObjectID, query string) ([]models.CompanySupplierRelationship, error) {
+	supplierIDs, err := s.relationshipRepo.ListSupplierIDsByCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs, err := s.orgRepo.SearchByName(ctx, supplierIDs, query, SearchResultsPerCategory)
+	if err != nil {
+		return nil, err
+	}
+	matchedIDs := make([]primitive.ObjectID, len(orgs))
+	for i := range orgs {
+		matchedIDs[i] = orgs[i].ID
+	}
+
+	return s.relationshipRepo.SearchByCompany(ctx, companyID, query, matchedIDs, SearchResultsPerCategory)
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// searchRelationshipRepo returns fixed supplier IDs and records the supplier IDs searched with
+type searchRelationshipRepo struct {
+	repository.RelationshipRepository
+	supplierIDs []primitive.ObjectID
+	searchedIDs []primitive.ObjectID
+}
+
+func (r *searchRelationshipRepo) ListSupplierIDsByCompany(_ context.Context, _ primitive.ObjectID) ([]primitive.ObjectID, error) {
+	return r.supplierIDs, nil
+}
+
+func (r *searchRelationshipRepo) SearchByCompany(_ context.Context, companyID primitive.ObjectID, _ string, supplierIDs []primitive.ObjectID, _ int) ([]models.CompanySupplierRelationship, error) {
+	r.searchedIDs = supplierIDs
+	return []models.CompanySupplierRelationship{{ID: primitive.NewObjectID(), CompanyID: companyID}}, nil
+}
+
+// searchOrgRepo matches organizations by name among the IDs it is given
+type searchOrgRepo struct {
+	repository.OrganizationRepository
+	orgs []models.Organization
+}
+
+func (r *searchOrgRepo) SearchByName(_ context.Context, ids []primitive.ObjectID, query string, _ int) ([]models.Organization, error) {
+	var matches []models.Organization
+	for _, org := range r.orgs {
+		for _, id := range ids {
+			if org.ID == id && strings.Contains(org.Name, query) {
+				matches = append(matches, org)
+			}
+		}
+	}
+	return matches, nil
+}
+
+// searchRequirementRepo returns one requirement of the searched company, or err
+type searchRequirementRepo struct {
+	repository.RequirementRepository
+	err error
+}
+
+func (r *searchRequirementRepo) SearchByCompany(ctx context.Context, companyID primitive.ObjectID, _ string, _ int) ([]models.Requirement, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return []models.Requirement{{ID: primitive.NewObjectID(), CompanyID: companyID}}, nil
+}
+
+// searchQuestionnaireRepo returns one questionnaire of the searched company
+type searchQuestionnaireRepo struct {
+	repository.QuestionnaireRepository
+}
+
+func (r *searchQuestionnaireRepo) SearchByCompany(_ context.Context, companyID primitive.ObjectID, _ string, _ int) ([]models.Questionnaire, error) {
+	return []models.Questionnaire{{ID: primitive.NewObjectID(), CompanyID: companyID}}, nil
+}
+
+func TestSearchService_Search(t *testing.T) {
+	ctx := context.Background()
+	companyID := primitive.NewObjectID()
+	own := models.Organization{ID: primitive.NewObjectID(), Name: "Acme Hosting"}
+	foreign := models.Organization{ID: primitive.NewObjectID(), Name: "Acme Logistics"}
+
+	relationships := &searchRelationshipRepo{supplierIDs: []primitive.ObjectID{own.ID}}
+	orgs := &searchOrgRepo{orgs: []models.Organization{own, foreign}}
+	requirements := &searchRequirementRepo{}
+	service := NewSearchService(relationships, orgs, requirements, &searchQuestionnaireRepo{})
+
+	if _, err := service.Search(ctx, companyID, " a "); !errors.Is(err, ErrInvalidSearchQuery) {
+		t.Errorf("Expected ErrInvalidSearchQuery for a short query, got %v", err)
+	}
+
+	results, err := service.Search(ctx, companyID, "Acme")
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results.Suppliers) != 1 || len(results.Requirements) != 1 || len(results.Questionnaires) != 1 {
+		t.Errorf("Expected one hit per category, got %+v", results)
+	}
+	// Only the company's own supplier may be matched by name
+	if len(relationships.searchedIDs) != 1 || relationships.searchedIDs[0] != own.ID {
+		t.Errorf("Expected name matches limited to the company's suppliers, got %v", relationships.searchedIDs)
+	}
+
+	requirements.err = errors.New("boom")
+	if _, err := service.Search(ctx, companyID, "Acme"); err == nil {
+		t.Error("Expected a failing category to fail the search")
+	}
+}
@@ -4,7 +4,10 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
@@ -45,6 +48,41 @@ type UpdateTemplateRequest struct {
 	Tags                []string             `json:"tags,omitempty"`
 }
 
+// TemplateDiff describes the changes from a base template to a target template
+type TemplateDiff struct {
+	FromID  string              `json:"from_id"`
+	ToID    string              `json:"to_id"`
+	Added   []TemplateDiffEntry `json:"added"`
+	Removed []TemplateDiffEntry `json:"removed"`
+	Changed []TemplateDiffEntry `json:"changed"`
+}
+
+// TemplateDiffEntry is a single added, removed, or changed item
+type TemplateDiffEntry struct {
+	Type   string                `json:"type"` // "topic"
+	ID     string                `json:"id"`
+	Name   string                `json:"name"`
+	Fields []TemplateFieldChange `json:"fields,omitempty"`
+}
+
+// TemplateFieldChange is a field whose value differs between the two versions
+type TemplateFieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// TemplatePreview summarizes the questionnaire a template would produce
+type TemplatePreview struct {
+	TemplateID       string                 `json:"template_id"`
+	Topics           []models.TemplateTopic `json:"topics"`
+	QuestionCount    int                    `json:"question_count"`
+	EstimatedMinutes int                    `json:"estimated_minutes"`
+	MaxPossibleScore int                    `json:"max_possible_score"`
+	// TopicsOnly is set when the template defines no questions, so the counts above are zero
+	TopicsOnly bool `json:"topics_only"`
+}
+
 // TemplateService handles questionnaire template business logic
 // #INTEGRATION_POINT: Used by template handler for CRUD operations
 type TemplateService interface {
@@ -57,6 +95,24 @@ type TemplateService interface {
 	// GetTemplate retrieves a template by ID (checks visibility permissions)
 	GetTemplate(ctx context.Context, id primitive.ObjectID, orgID *primitive.ObjectID) (*models.QuestionnaireTemplate, error)
 
+	// CanAccess reports whether a template is visible to the organization
+	CanAccess(ctx context.Context, template *models.QuestionnaireTemplate, orgID *primitive.ObjectID) bool
+
+	// PreviewTemplate summarizes the questionnaire a visible template would produce
+	PreviewTemplate(ctx context.Context, id primitive.ObjectID, orgID *primitive.ObjectID) (*TemplatePreview, error)
+
+	// CloneTemplate creates a draft copy of a visible template owned by the caller
+	CloneTemplate(ctx context.Context, id, orgID, userID primitive.ObjectID, name string) (*models.QuestionnaireTemplate, error)
+
+	// CreateVersion creates a draft of the next version of a published template (user must be owner)
+	CreateVersion(ctx context.Context, id, userID primitive.ObjectID) (*models.QuestionnaireTemplate, error)
+
+	// Diff compares a template against a base template; both must be visible to the organization
+	Diff(ctx context.Context, id, againstID primitive.ObjectID, orgID *primitive.ObjectID) (*TemplateDiff, error)
+
+	// ListVersions lists the visible versions in a template's lineage, oldest first
+	ListVersions(ctx context.Context, id primitive.ObjectID, orgID *primitive.ObjectID) ([]models.QuestionnaireTemplate, error)
+
 	// UpdateTemplate updates a draft template (user must be owner)
 	UpdateTemplate(ctx context.Context, id, userID primitive.ObjectID, req UpdateTemplateRequest) (*models.QuestionnaireTemplate, error)
 
@@ -70,7 +126,7 @@ type TemplateService interface {
 	UnpublishTemplate(ctx context.Context, id, userID primitive.ObjectID) (*models.QuestionnaireTemplate, error)
 
 	// ListAvailableTemplates lists templates available to an organization
-	ListAvailableTemplates(ctx context.Context, orgID primitive.ObjectID, category *models.TemplateCategory, opts repository.PaginationOptions) (*repository.PaginatedResult[models.QuestionnaireTemplate], error)
+	ListAvailableTemplates(ctx context.Context, orgID primitive.ObjectID, category *models.TemplateCategory, tags repository.TagFilter, includeSuperseded bool, opts repository.PaginationOptions) (*repository.PaginatedResult[models.QuestionnaireTemplate], error)
 
 	// ListMyTemplates lists templates created by a user
 	ListMyTemplates(ctx context.Context, userID primitive.ObjectID, opts repository.PaginationOptions) (*repository.PaginatedResult[models.QuestionnaireTemplate], error)
@@ -79,87 +135,269 @@ type TemplateService interface {
 // templateService implements TemplateService
 type templateService struct {
 	templateRepo repository.QuestionnaireTemplateRepository
+	auditService AuditService
 }
 
 // NewTemplateService creates a new template service
-func NewTemplateService(templateRepo repository.QuestionnaireTemplateRepository) TemplateService {
+func NewTemplateService(templateRepo repository.QuestionnaireTemplateRepository, auditService AuditService) TemplateService {
 	return &templateService{
 		templateRepo: templateRepo,
+		auditService: auditService,
 	}
 }
 
 // CreateTemplate creates a new draft template
 // #BUSINESS_RULE: Templates are created as DRAFT, owned by user
 func (s *templateService) CreateTemplate(ctx context.Context, orgID, userID primitive.ObjectID, req CreateTemplateRequest) (*models.QuestionnaireTemplate, error) {
+	template, err := newTemplateFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	template.IsSystem = false
+	template.CreatedByOrgID = &orgID
+	template.CreatedByUser = &userID
+	template.Visibility = models.TemplateVisibilityDraft
+
+	// Create in repository
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to create template: %w", err)
+	}
+
+	s.logAudit(ctx, userID, template, models.AuditActionCreate, fmt.Sprintf("Created template: %s", template.Name))
+
+	return template, nil
+}
+
+// ImportTemplate parses and creates a template from JSON content
+func (s *templateService) ImportTemplate(ctx context.Context, orgID, userID primitive.ObjectID, content []byte) (*models.QuestionnaireTemplate, error) {
+	req, err := parseTemplateImport(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateTemplate(ctx, orgID, userID, req)
+}
+
+// ParseTemplateImport parses template JSON and validates it against the import schema
+// The template has no owner or visibility yet; callers set them before storing it
+// #INTEGRATION_POINT: Shared by the import endpoint and the import-templates CLI
+func ParseTemplateImport(content []byte) (*models.QuestionnaireTemplate, error) {
+	req, err := parseTemplateImport(content)
+	if err != nil {
+		return nil, err
+	}
+	return newTemplateFromRequest(req)
+}
+
+// parseTemplateImport decodes template JSON and checks the fields an import must carry
+func parseTemplateImport(content []byte) (CreateTemplateRequest, error) {
+	var req CreateTemplateRequest
+	if err := json.Unmarshal(content, &req); err != nil {
+		return req, fmt.Errorf("%w: %v", models.ErrTemplateInvalidFormat, err)
+	}
+
+	// Validate required fields
+	if req.Name == "" {
+		return req, fmt.Errorf("%w: name is required", models.ErrTemplateMissingFields)
+	}
+	if req.Category == "" {
+		return req, fmt.Errorf("%w: category is required", models.ErrTemplateMissingFields)
+	}
+	return req, nil
+}
+
+// newTemplateFromRequest builds and validates an unowned template from creation data
+func newTemplateFromRequest(req CreateTemplateRequest) (*models.QuestionnaireTemplate, error) {
 	// Validate category
 	category := models.TemplateCategory(strings.ToUpper(req.Category))
 	if !category.IsValid() {
 		return nil, fmt.Errorf("%w: %s", models.ErrTemplateInvalidFormat, "invalid category")
 	}
 
-	// Build template
 	template := &models.QuestionnaireTemplate{
 		Name:                req.Name,
 		Description:         req.Description,
 		Category:            category,
 		Version:             req.Version,
-		IsSystem:            false,
-		CreatedByOrgID:      &orgID,
-		CreatedByUser:       &userID,
-		Visibility:          models.TemplateVisibilityDraft,
 		DefaultPassingScore: req.DefaultPassingScore,
 		EstimatedMinutes:    req.EstimatedMinutes,
 		Tags:                req.Tags,
+		Topics:              convertTopics(req.Topics),
 	}
 
-	// Convert topics
-	template.Topics = s.convertTopics(req.Topics)
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
 
-	// Validate
-	if err := s.validateTemplate(template); err != nil {
+// GetTemplate retrieves a template by ID
+// #BUSINESS_RULE: Check visibility - system/global visible to all, org templates visible to owning org
+func (s *templateService) GetTemplate(ctx context.Context, id primitive.ObjectID, orgID *primitive.ObjectID) (*models.QuestionnaireTemplate, error) {
+	template, err := s.templateRepo.GetByID(ctx, id)
+	if err != nil {
 		return nil, err
 	}
 
-	// Create in repository
-	if err := s.templateRepo.Create(ctx, template); err != nil {
-		return nil, fmt.Errorf("failed to create template: %w", err)
+	// Hidden templates are reported as not found so their existence is not revealed
+	if !s.CanAccess(ctx, template, orgID) {
+		return nil, models.ErrTemplateNotFound
 	}
 
 	return template, nil
 }
 
-// ImportTemplate parses and creates a template from JSON content
-func (s *templateService) ImportTemplate(ctx context.Context, orgID, userID primitive.ObjectID, content []byte) (*models.QuestionnaireTemplate, error) {
-	var req CreateTemplateRequest
-	if err := json.Unmarshal(content, &req); err != nil {
-		return nil, fmt.Errorf("%w: %v", models.ErrTemplateInvalidFormat, err)
+// PreviewTemplate summarizes the questionnaire a visible template would produce
+// #TECHNICAL_DEBT: Templates only define topics; questions are added after a questionnaire is
+// created, so QuestionCount and MaxPossibleScore stay zero and TopicsOnly is always set for now
+func (s *templateService) PreviewTemplate(ctx context.Context, id primitive.ObjectID, orgID *primitive.ObjectID) (*TemplatePreview, error) {
+	template, err := s.GetTemplate(ctx, id, orgID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Validate required fields
-	if req.Name == "" {
-		return nil, fmt.Errorf("%w: name is required", models.ErrTemplateMissingFields)
+	topics := make([]models.TemplateTopic, len(template.Topics))
+	copy(topics, template.Topics)
+	sort.SliceStable(topics, func(i, j int) bool {
+		return topics[i].Order < topics[j].Order
+	})
+
+	return &TemplatePreview{
+		TemplateID:       template.ID.Hex(),
+		Topics:           topics,
+		EstimatedMinutes: template.EstimatedMinutes,
+		TopicsOnly:       true,
+	}, nil
+}
+
+// CloneTemplate creates a draft copy of a template the organization can view
+// #BUSINESS_RULE: Clones are always custom drafts owned by the caller, so they can be edited
+// before publishing; an empty name defaults to "Copy of <name>"
+func (s *templateService) CloneTemplate(ctx context.Context, id, orgID, userID primitive.ObjectID, name string) (*models.QuestionnaireTemplate, error) {
+	source, err := s.GetTemplate(ctx, id, &orgID)
+	if err != nil {
+		return nil, err
 	}
-	if req.Category == "" {
-		return nil, fmt.Errorf("%w: category is required", models.ErrTemplateMissingFields)
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "Copy of " + source.Name
 	}
 
-	return s.CreateTemplate(ctx, orgID, userID, req)
+	// #IMPLEMENTATION_DECISION: Topic IDs are kept so content keyed by topic can be copied alongside
+	topics := make([]models.TemplateTopic, len(source.Topics))
+	copy(topics, source.Topics)
+
+	clone := &models.QuestionnaireTemplate{
+		Name:                name,
+		Description:         source.Description,
+		Category:            source.Category,
+		Version:             source.Version,
+		IsSystem:            false,
+		CreatedByOrgID:      &orgID,
+		CreatedByUser:       &userID,
+		Visibility:          models.TemplateVisibilityDraft,
+		DefaultPassingScore: source.DefaultPassingScore,
+		EstimatedMinutes:    source.EstimatedMinutes,
+		Topics:              topics,
+		Tags:                append([]string(nil), source.Tags...),
+	}
+
+	if err := validateTemplate(clone); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Create(ctx, clone); err != nil {
+		return nil, fmt.Errorf("failed to clone template: %w", err)
+	}
+
+	s.logAudit(ctx, userID, clone, models.AuditActionCreate, fmt.Sprintf("Cloned template %s from %s", clone.Name, source.ID.Hex()))
+
+	return clone, nil
 }
 
-// GetTemplate retrieves a template by ID
-// #BUSINESS_RULE: Check visibility - system/global visible to all, org templates visible to owning org
-func (s *templateService) GetTemplate(ctx context.Context, id primitive.ObjectID, orgID *primitive.ObjectID) (*models.QuestionnaireTemplate, error) {
-	template, err := s.templateRepo.GetByID(ctx, id)
+// CreateVersion creates a draft of the next version of a published template
+// #BUSINESS_RULE: Drafts are edited in place, so only the latest published version can be versioned;
+// the source keeps serving existing questionnaires until the new version is published
+func (s *templateService) CreateVersion(ctx context.Context, id, userID primitive.ObjectID) (*models.QuestionnaireTemplate, error) {
+	source, err := s.templateRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check visibility
-	if !s.canViewTemplate(template, orgID) {
-		return nil, models.ErrTemplateNotFound
+	if !source.IsOwnedByUser(userID) {
+		return nil, models.ErrTemplateNotOwnedByUser
+	}
+	if !source.IsPublished() {
+		return nil, models.ErrTemplateNotPublished
+	}
+	if source.IsSuperseded() {
+		return nil, models.ErrTemplateSuperseded
 	}
 
-	return template, nil
+	rootID := source.LineageRootID()
+	topics := make([]models.TemplateTopic, len(source.Topics))
+	copy(topics, source.Topics)
+
+	version := &models.QuestionnaireTemplate{
+		Name:                source.Name,
+		Description:         source.Description,
+		Category:            source.Category,
+		Version:             nextTemplateVersion(source.Version),
+		IsSystem:            false,
+		CreatedByOrgID:      source.CreatedByOrgID,
+		CreatedByUser:       &userID,
+		Visibility:          models.TemplateVisibilityDraft,
+		PreviousVersionID:   &source.ID,
+		RootTemplateID:      &rootID,
+		DefaultPassingScore: source.DefaultPassingScore,
+		EstimatedMinutes:    source.EstimatedMinutes,
+		Topics:              topics,
+		Tags:                append([]string(nil), source.Tags...),
+	}
+
+	if err := s.templateRepo.Create(ctx, version); err != nil {
+		return nil, fmt.Errorf("failed to create template version: %w", err)
+	}
+
+	s.logAudit(ctx, userID, version, models.AuditActionCreate,
+		fmt.Sprintf("Created version %s of template %s", version.Version, version.Name))
+
+	return version, nil
+}
+
+// Diff compares a template against a base template
+func (s *templateService) Diff(ctx context.Context, id, againstID primitive.ObjectID, orgID *primitive.ObjectID) (*TemplateDiff, error) {
+	target, err := s.GetTemplate(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+	base, err := s.GetTemplate(ctx, againstID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return DiffTemplates(base, target), nil
+}
+
+// ListVersions lists the visible versions in a template's lineage
+func (s *templateService) ListVersions(ctx context.Context, id primitive.ObjectID, orgID *primitive.ObjectID) ([]models.QuestionnaireTemplate, error) {
+	template, err := s.GetTemplate(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.templateRepo.ListVersions(ctx, template.LineageRootID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list template versions: %w", err)
+	}
+
+	visible := make([]models.QuestionnaireTemplate, 0, len(versions))
+	for i := range versions {
+		if s.CanAccess(ctx, &versions[i], orgID) {
+			visible = append(visible, versions[i])
+		}
+	}
+	return visible, nil
 }
 
 // UpdateTemplate updates a draft template
@@ -197,14 +435,14 @@ func (s *templateService) UpdateTemplate(ctx context.Context, id, userID primiti
 		template.EstimatedMinutes = *req.EstimatedMinutes
 	}
 	if req.Topics != nil {
-		template.Topics = s.convertTopics(req.Topics)
+		template.Topics = convertTopics(req.Topics)
 	}
 	if req.Tags != nil {
 		template.Tags = req.Tags
 	}
 
 	// Validate
-	if err := s.validateTemplate(template); err != nil {
+	if err := validateTemplate(template); err != nil {
 		return nil, err
 	}
 
@@ -213,6 +451,8 @@ func (s *templateService) UpdateTemplate(ctx context.Context, id, userID primiti
 		return nil, fmt.Errorf("failed to update template: %w", err)
 	}
 
+	s.logAudit(ctx, userID, template, models.AuditActionUpdate, fmt.Sprintf("Updated template: %s", template.Name))
+
 	return template, nil
 }
 
@@ -237,7 +477,13 @@ func (s *templateService) DeleteTemplate(ctx context.Context, id, userID primiti
 		return models.ErrTemplateNotDeletable
 	}
 
-	return s.templateRepo.Delete(ctx, id)
+	if err := s.templateRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.logAudit(ctx, userID, template, models.AuditActionDelete, fmt.Sprintf("Deleted template: %s", template.Name))
+
+	return nil
 }
 
 // PublishTemplate publishes a template with specified visibility
@@ -268,14 +514,27 @@ func (s *templateService) PublishTemplate(ctx context.Context, id, userID primit
 		return nil, err
 	}
 
+	// A new version supersedes its predecessor so listings only offer the latest
+	if template.PreviousVersionID != nil {
+		if err := s.supersedePrevious(ctx, template); err != nil {
+			return nil, err
+		}
+	}
+
 	// Publish
 	template.Publish(visibility, userID)
 
 	// Update in repository
 	if err := s.templateRepo.Update(ctx, template); err != nil {
+		if template.PreviousVersionID != nil {
+			_ = s.templateRepo.ClearSuperseded(ctx, *template.PreviousVersionID, template.ID)
+		}
 		return nil, fmt.Errorf("failed to publish template: %w", err)
 	}
 
+	s.logAudit(ctx, userID, template, models.AuditActionPublish,
+		fmt.Sprintf("Published template %s as %s", template.Name, strings.ToLower(string(visibility))))
+
 	return template, nil
 }
 
@@ -297,6 +556,11 @@ func (s *templateService) UnpublishTemplate(ctx context.Context, id, userID prim
 		return nil, models.ErrTemplateNotPublished
 	}
 
+	// Superseded versions stay frozen so the lineage keeps a single latest version
+	if template.IsSuperseded() {
+		return nil, models.ErrTemplateSuperseded
+	}
+
 	// Check if can be unpublished
 	if !template.CanBeUnpublished() {
 		return nil, models.ErrTemplateInUse
@@ -310,12 +574,30 @@ func (s *templateService) UnpublishTemplate(ctx context.Context, id, userID prim
 		return nil, fmt.Errorf("failed to unpublish template: %w", err)
 	}
 
+	// Withdrawing a version makes its predecessor the latest again
+	if template.PreviousVersionID != nil {
+		if err := s.templateRepo.ClearSuperseded(ctx, *template.PreviousVersionID, template.ID); err != nil {
+			return nil, fmt.Errorf("failed to restore previous template version: %w", err)
+		}
+	}
+
+	s.logAudit(ctx, userID, template, models.AuditActionUpdate, fmt.Sprintf("Unpublished template: %s", template.Name))
+
 	return template, nil
 }
 
+// logAudit records a template action attributed to the owning organization
+func (s *templateService) logAudit(ctx context.Context, userID primitive.ObjectID, template *models.QuestionnaireTemplate, action models.AuditAction, description string) {
+	orgID := primitive.NilObjectID
+	if template.CreatedByOrgID != nil {
+		orgID = *template.CreatedByOrgID
+	}
+	logAudit(s.auditService, newAuditEntry(ctx, userID, orgID, action, models.ResourceTypeTemplate, template.ID, description))
+}
+
 // ListAvailableTemplates lists templates available to an organization
-func (s *templateService) ListAvailableTemplates(ctx context.Context, orgID primitive.ObjectID, category *models.TemplateCategory, opts repository.PaginationOptions) (*repository.PaginatedResult[models.QuestionnaireTemplate], error) {
-	return s.templateRepo.ListAvailableTemplates(ctx, orgID, category, opts)
+func (s *templateService) ListAvailableTemplates(ctx context.Context, orgID primitive.ObjectID, category *models.TemplateCategory, tags repository.TagFilter, includeSuperseded bool, opts repository.PaginationOptions) (*repository.PaginatedResult[models.QuestionnaireTemplate], error) {
+	return s.templateRepo.ListAvailableTemplates(ctx, orgID, category, tags, includeSuperseded, opts)
 }
 
 // ListMyTemplates lists templates created by a user
@@ -325,8 +607,86 @@ func (s *templateService) ListMyTemplates(ctx context.Context, userID primitive.
 
 // Helper methods
 
+// supersedePrevious marks the predecessor of a version being published as superseded
+// #BUSINESS_RULE: A deleted predecessor is skipped; an already superseded one means another
+// version was published first and this one is rejected
+func (s *templateService) supersedePrevious(ctx context.Context, template *models.QuestionnaireTemplate) error {
+	err := s.templateRepo.MarkSuperseded(ctx, *template.PreviousVersionID, template.ID)
+	if errors.Is(err, models.ErrTemplateSuperseded) {
+		if _, getErr := s.templateRepo.GetByID(ctx, *template.PreviousVersionID); errors.Is(getErr, models.ErrTemplateNotFound) {
+			return nil
+		}
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to supersede previous template version: %w", err)
+	}
+	return nil
+}
+
+// DiffTemplates compares two templates by stable topic ID
+// #IMPLEMENTATION_DECISION: Matching on IDs rather than names keeps renames and reorders
+// reported as changes instead of a remove/add pair; entries follow the target's topic order
+func DiffTemplates(from, to *models.QuestionnaireTemplate) *TemplateDiff {
+	diff := &TemplateDiff{
+		FromID:  from.ID.Hex(),
+		ToID:    to.ID.Hex(),
+		Added:   []TemplateDiffEntry{},
+		Removed: []TemplateDiffEntry{},
+		Changed: []TemplateDiffEntry{},
+	}
+
+	fromTopics := make(map[string]models.TemplateTopic, len(from.Topics))
+	for _, topic := range from.Topics {
+		fromTopics[topic.ID] = topic
+	}
+	toTopics := make(map[string]bool, len(to.Topics))
+
+	for _, topic := range to.Topics {
+		toTopics[topic.ID] = true
+		old, ok := fromTopics[topic.ID]
+		if !ok {
+			diff.Added = append(diff.Added, TemplateDiffEntry{Type: "topic", ID: topic.ID, Name: topic.Name})
+			continue
+		}
+
+		var fields []TemplateFieldChange
+		if old.Name != topic.Name {
+			fields = append(fields, TemplateFieldChange{Field: "name", From: old.Name, To: topic.Name})
+		}
+		if old.Description != topic.Description {
+			fields = append(fields, TemplateFieldChange{Field: "description", From: old.Description, To: topic.Description})
+		}
+		if old.Order != topic.Order {
+			fields = append(fields, TemplateFieldChange{Field: "order", From: old.Order, To: topic.Order})
+		}
+		if len(fields) > 0 {
+			diff.Changed = append(diff.Changed, TemplateDiffEntry{Type: "topic", ID: topic.ID, Name: topic.Name, Fields: fields})
+		}
+	}
+
+	for _, topic := range from.Topics {
+		if !toTopics[topic.ID] {
+			diff.Removed = append(diff.Removed, TemplateDiffEntry{Type: "topic", ID: topic.ID, Name: topic.Name})
+		}
+	}
+
+	return diff
+}
+
+// nextTemplateVersion increments the last numeric component of a version string ("1.0" -> "1.1")
+func nextTemplateVersion(version string) string {
+	parts := strings.Split(version, ".")
+	last, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return version + ".1"
+	}
+	parts[len(parts)-1] = strconv.Itoa(last + 1)
+	return strings.Join(parts, ".")
+}
+
 // convertTopics converts topic inputs to model topics
-func (s *templateService) convertTopics(inputs []TemplateTopicInput) []models.TemplateTopic {
+func convertTopics(inputs []TemplateTopicInput) []models.TemplateTopic {
 	topics := make([]models.TemplateTopic, len(inputs))
 	for i, input := range inputs {
 		id := input.ID
@@ -348,7 +708,7 @@ func (s *templateService) convertTopics(inputs []TemplateTopicInput) []models.Te
 }
 
 // validateTemplate validates a template's basic requirements
-func (s *templateService) validateTemplate(template *models.QuestionnaireTemplate) error {
+func validateTemplate(template *models.QuestionnaireTemplate) error {
 	var errs []string
 
 	if template.Name == "" {
@@ -392,8 +752,9 @@ func (s *templateService) validateForPublish(template *models.QuestionnaireTempl
 	return nil
 }
 
-// canViewTemplate checks if a template is visible to the given organization
-func (s *templateService) canViewTemplate(template *models.QuestionnaireTemplate, orgID *primitive.ObjectID) bool {
+// CanAccess reports whether a template is visible to the given organization
+// #SECURITY_ASSUMPTION: A nil orgID only sees system and globally published templates
+func (s *templateService) CanAccess(_ context.Context, template *models.QuestionnaireTemplate, orgID *primitive.ObjectID) bool {
 	// System templates are always visible
 	if template.IsSystem {
 		return true
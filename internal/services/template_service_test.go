@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+func TestNextTemplateVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"1.0", "1.1"},
+		{"1.9", "1.10"},
+		{"2", "3"},
+		{"2024.03.1", "2024.03.2"},
+		{"1.0-beta", "1.0-beta.1"},
+	}
+
+	for _, tt := range tests {
+		if got := nextTemplateVersion(tt.version); got != tt.want {
+			t.Errorf("nextTemplateVersion(%q) = %q, want %q", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestDiffTemplates(t *testing.T) {
+	from := &models.QuestionnaireTemplate{
+		ID: primitive.NewObjectID(),
+		Topics: []models.TemplateTopic{
+			{ID: "access", Name: "Access Control", Order: 1},
+			{ID: "crypto", Name: "Cryptography", Order: 2},
+			{ID: "legacy", Name: "Legacy Systems", Order: 3},
+		},
+	}
+	to := &models.QuestionnaireTemplate{
+		ID: primitive.NewObjectID(),
+		Topics: []models.TemplateTopic{
+			{ID: "crypto", Name: "Cryptography", Order: 1},
+			{ID: "access", Name: "Identity & Access", Order: 2},
+			{ID: "incident", Name: "Incident Response", Order: 3},
+		},
+	}
+
+	diff := DiffTemplates(from, to)
+
+	if len(diff.Added) != 1 || diff.Added[0].ID != "incident" {
+		t.Errorf("Expected incident to be added, got %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].ID != "legacy" {
+		t.Errorf("Expected legacy to be removed, got %+v", diff.Removed)
+	}
+	if len(diff.Changed) != 2 {
+		t.Fatalf("Expected 2 changed topics, got %+v", diff.Changed)
+	}
+
+	// Reorder only
+	if c := diff.Changed[0]; c.ID != "crypto" || len(c.Fields) != 1 || c.Fields[0].Field != "order" {
+		t.Errorf("Expected crypto to change order only, got %+v", c)
+	}
+	// Rename and reorder
+	if c := diff.Changed[1]; c.ID != "access" || len(c.Fields) != 2 || c.Fields[0].Field != "name" {
+		t.Errorf("Expected access to change name and order, got %+v", c)
+	}
+}
+
+// memoryTemplateRepo stores templates in memory; unused methods are not implemented
+type memoryTemplateRepo struct {
+	repository.QuestionnaireTemplateRepository
+	templates map[primitive.ObjectID]*models.QuestionnaireTemplate
+}
+
+func (r *memoryTemplateRepo) GetByID(_ context.Context, id primitive.ObjectID) (*models.QuestionnaireTemplate, error) {
+	template, ok := r.templates[id]
+	if !ok {
+		return nil, models.ErrTemplateNotFound
+	}
+	return template, nil
+}
+
+func TestTemplateService_GetTemplate_Visibility(t *testing.T) {
+	ownerOrg := primitive.NewObjectID()
+	otherOrg := primitive.NewObjectID()
+
+	newTemplate := func(visibility models.TemplateVisibility, isSystem bool) *models.QuestionnaireTemplate {
+		template := &models.QuestionnaireTemplate{ID: primitive.NewObjectID(), IsSystem: isSystem, Visibility: visibility}
+		if !isSystem {
+			template.CreatedByOrgID = &ownerOrg
+		}
+		return template
+	}
+	system := newTemplate(models.TemplateVisibilityGlobal, true)
+	global := newTemplate(models.TemplateVisibilityGlobal, false)
+	local := newTemplate(models.TemplateVisibilityLocal, false)
+	draft := newTemplate(models.TemplateVisibilityDraft, false)
+
+	repo := &memoryTemplateRepo{templates: map[primitive.ObjectID]*models.QuestionnaireTemplate{}}
+	for _, template := range []*models.QuestionnaireTemplate{system, global, local, draft} {
+		repo.templates[template.ID] = template
+	}
+	service := NewTemplateService(repo, nil)
+
+	tests := []struct {
+		name     string
+		template *models.QuestionnaireTemplate
+		orgID    *primitive.ObjectID
+		visible  bool
+	}{
+		{"system template to any org", system, &otherOrg, true},
+		{"global template to any org", global, &otherOrg, true},
+		{"own local template", local, &ownerOrg, true},
+		{"own draft template", draft, &ownerOrg, true},
+		{"local template of another org", local, &otherOrg, false},
+		{"draft template of another org", draft, &otherOrg, false},
+		{"draft template without org", draft, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := service.CanAccess(context.Background(), tt.template, tt.orgID); got != tt.visible {
+				t.Errorf("CanAccess = %v, want %v", got, tt.visible)
+			}
+
+			_, err := service.GetTemplate(context.Background(), tt.template.ID, tt.orgID)
+			if tt.visible && err != nil {
+				t.Errorf("Expected template to be returned, got %v", err)
+			}
+			if !tt.visible && !errors.Is(err, models.ErrTemplateNotFound) {
+				t.Errorf("Expected ErrTemplateNotFound, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTemplateService_PreviewTemplate(t *testing.T) {
+	template := &models.QuestionnaireTemplate{
+		ID:               primitive.NewObjectID(),
+		IsSystem:         true,
+		Visibility:       models.TemplateVisibilityGlobal,
+		EstimatedMinutes: 45,
+		Topics: []models.TemplateTopic{
+			{ID: "b", Name: "Second", Order: 2},
+			{ID: "a", Name: "First", Order: 1},
+		},
+	}
+	repo := &memoryTemplateRepo{templates: map[primitive.ObjectID]*models.QuestionnaireTemplate{template.ID: template}}
+
+	preview, err := NewTemplateService(repo, nil).PreviewTemplate(context.Background(), template.ID, nil)
+	if err != nil {
+		t.Fatalf("PreviewTemplate failed: %v", err)
+	}
+
+	if !preview.TopicsOnly || preview.QuestionCount != 0 || preview.MaxPossibleScore != 0 {
+		t.Errorf("Expected zero counts for a topic-only template, got %+v", preview)
+	}
+	if preview.EstimatedMinutes != 45 {
+		t.Errorf("Expected 45 estimated minutes, got %d", preview.EstimatedMinutes)
+	}
+	if len(preview.Topics) != 2 || preview.Topics[0].ID != "a" {
+		t.Errorf("Expected topics ordered by order, got %+v", preview.Topics)
+	}
+	if template.Topics[0].ID != "b" {
+		t.Error("Expected the template's topics to be left unchanged")
+	}
+}
+
+func TestParseTemplateImport(t *testing.T) {
+	template, err := ParseTemplateImport([]byte(`{"name":"Vendor Baseline","category":"nis2","topics":[{"name":"Governance"},{"name":"Incidents"}]}`))
+	if err != nil {
+		t.Fatalf("ParseTemplateImport() error = %v", err)
+	}
+	if template.Category != models.TemplateCategoryNIS2 || len(template.Topics) != 2 || template.Topics[1].Order != 2 {
+		t.Errorf("Unexpected template %+v", template)
+	}
+	if template.CreatedByOrgID != nil || template.IsSystem {
+		t.Errorf("Expected an unowned template, got %+v", template)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{"malformed json", `{"name":`, models.ErrTemplateInvalidFormat},
+		{"missing name", `{"category":"nis2"}`, models.ErrTemplateMissingFields},
+		{"unknown category", `{"name":"X","category":"soc2"}`, models.ErrTemplateInvalidFormat},
+		{"unnamed topic", `{"name":"X","category":"nis2","topics":[{"description":"no name"}]}`, models.ErrTemplateMissingFields},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseTemplateImport([]byte(tt.content)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseTemplateImport() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,455 @@
+// Package services provides business logic implementations.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// Custom errors for user service
+var (
+	ErrUserAlreadyExists = errors.New("a user with this email already exists")
+	ErrInvalidUserRole   = errors.New("invalid user role")
+	ErrLastAdmin         = errors.New("cannot remove the last admin of an organization")
+	ErrInvalidProfile    = errors.New("invalid profile")
+	ErrEmailUnchanged    = errors.New("new email matches the current email")
+
+	ErrInvalidNotificationPreference = errors.New("invalid notification preference")
+)
+
+// maxUserNameLength bounds display names in characters
+const maxUserNameLength = 100
+
+// Email change requests per new address and window, mirroring the magic link limit
+const (
+	emailChangeRateLimitCount = 3
+	emailChangeRateLimitMins  = 60
+)
+
+// UserService handles team-member management within an organization
+// #INTEGRATION_POINT: Used by user handler for organization user management
+type UserService interface {
+	// InviteUser creates a user in the organization and sends a sign-in link
+	InviteUser(ctx context.Context, orgID primitive.ObjectID, req InviteUserRequest) (*models.User, error)
+
+	// ListUsers lists users in the organization
+	ListUsers(ctx context.Context, orgID primitive.ObjectID, includeInactive bool, opts repository.PaginationOptions) (*repository.PaginatedResult[models.User], error)
+
+	// RemoveUser soft deletes a user from the organization
+	RemoveUser(ctx context.Context, orgID, userID primitive.ObjectID) error
+
+	// GetProfile returns the user and their organization
+	GetProfile(ctx context.Context, userID primitive.ObjectID) (*models.User, *models.Organization, error)
+
+	// UpdateProfile updates the user's own display name and language
+	UpdateProfile(ctx context.Context, userID primitive.ObjectID, req UpdateProfileRequest) (*models.User, error)
+
+	// RequestEmailChange sends a verification link to the new address
+	RequestEmailChange(ctx context.Context, userID primitive.ObjectID, newEmail string) error
+
+	// ConfirmEmailChange redeems an email change link and updates the user's email
+	ConfirmEmailChange(ctx context.Context, identifier string) (*models.User, error)
+
+	// GetNotificationPreferences returns the user whose notification preferences are requested
+	GetNotificationPreferences(ctx context.Context, userID primitive.ObjectID) (*models.User, error)
+
+	// UpdateNotificationPreferences enables or disables notification kinds for the user
+	UpdateNotificationPreferences(ctx context.Context, userID primitive.ObjectID, changes map[models.NotificationKind]bool) (*models.User, error)
+}
+
+// UpdateProfileRequest contains the self-service profile fields; nil fields are left unchanged
+// #SECURITY_ASSUMPTION: Email is deliberately absent - it is the sign-in identity and changing it
+// needs a verified flow, not a profile edit
+type UpdateProfileRequest struct {
+	Name     *string
+	Language *string
+}
+
+// InviteUserRequest represents the request to invite a team member
+type InviteUserRequest struct {
+	Email    string          `json:"email" binding:"required,email"`
+	Name     string          `json:"name,omitempty"`
+	Role     models.UserRole `json:"role"`
+	Language string          `json:"language,omitempty"`
+}
+
+// userService implements UserService
+type userService struct {
+	userRepo       repository.UserRepository
+	orgRepo        repository.OrganizationRepository
+	secureLinkRepo repository.SecureLinkRepository
+	authService    AuthService
+	mailService    MailService
+	mailOutbox     EmailOutbox
+	magicLinkBase  string
+}
+
+// NewUserService creates a new user service
+func NewUserService(
+	userRepo repository.UserRepository,
+	orgRepo repository.OrganizationRepository,
+	secureLinkRepo repository.SecureLinkRepository,
+	authService AuthService,
+	mailService MailService,
+	mailOutbox EmailOutbox,
+	magicLinkBaseURL string,
+) UserService {
+	return &userService{
+		userRepo:       userRepo,
+		orgRepo:        orgRepo,
+		secureLinkRepo: secureLinkRepo,
+		authService:    authService,
+		mailService:    mailService,
+		mailOutbox:     mailOutbox,
+		magicLinkBase:  magicLinkBaseURL,
+	}
+}
+
+// InviteUser creates a user in the organization and sends a sign-in link
+// #BUSINESS_RULE: Email is unique across the system, so existing users cannot be invited again
+// #BUSINESS_RULE: Role defaults to VIEWER when not specified
+// #IMPLEMENTATION_DECISION: Invitation is an AUTH secure link with invitation expiry so the
+// invitee signs in through the regular magic link verification flow
+func (s *userService) InviteUser(ctx context.Context, orgID primitive.ObjectID, req InviteUserRequest) (*models.User, error) {
+	// Normalize email
+	email := models.NormalizeEmail(req.Email)
+
+	role := req.Role
+	if role == "" {
+		role = models.UserRoleViewer
+	}
+	if !role.IsValid() {
+		return nil, ErrInvalidUserRole
+	}
+
+	// Check for existing user with this email
+	existing, err := s.userRepo.GetByEmail(ctx, email)
+	if err == nil && existing != nil {
+		return nil, ErrUserAlreadyExists
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, models.ErrOrganizationNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	user := &models.User{
+		Email:          email,
+		Name:           req.Name,
+		OrganizationID: orgID,
+		Role:           role,
+		Language:       req.Language,
+	}
+	if user.Language == "" {
+		user.Language = org.Settings.DefaultLanguage
+	}
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		if errors.Is(err, models.ErrEmailAlreadyExists) {
+			return nil, ErrUserAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	// Generate secure identifier
+	identifier, err := generateSecureIdentifier()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secure identifier: %w", err)
+	}
+
+	link := &models.SecureLink{
+		SecureIdentifier: identifier,
+		Type:             models.SecureLinkTypeAuth,
+		Email:            email,
+		UserID:           &user.ID,
+		ExpiresAt:        time.Now().UTC().Add(models.InvitationLinkExpiryDuration),
+	}
+	link.BeforeCreate()
+
+	if err := s.secureLinkRepo.Create(ctx, link); err != nil {
+		return nil, fmt.Errorf("failed to create secure link: %w", err)
+	}
+
+	// Queue invitation email
+	// #IMPLEMENTATION_DECISION: Queued through the outbox so mail API outages are retried; a failure
+	// to queue does not fail the invite, since the invitee can still request a magic link
+	magicLinkURL := fmt.Sprintf("%s/auth/verify/%s", s.magicLinkBase, identifier)
+	if err := s.mailOutbox.EnqueueTeamInvitation(ctx, org.ID, email, user.Name, org.Name, magicLinkURL, user.Language); err != nil {
+		log.Printf("Failed to queue team invitation to %s: %v", email, err)
+	}
+
+	return user, nil
+}
+
+// ListUsers lists users in the organization
+func (s *userService) ListUsers(ctx context.Context, orgID primitive.ObjectID, includeInactive bool, opts repository.PaginationOptions) (*repository.PaginatedResult[models.User], error) {
+	return s.userRepo.ListByOrganization(ctx, orgID, includeInactive, opts)
+}
+
+// GetProfile returns the user and their organization
+func (s *userService) GetProfile(ctx context.Context, userID primitive.ObjectID) (*models.User, *models.Organization, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			return nil, nil, ErrUserNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, user.OrganizationID)
+	if err != nil {
+		if errors.Is(err, models.ErrOrganizationNotFound) {
+			return nil, nil, ErrOrganizationNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return user, org, nil
+}
+
+// UpdateProfile updates the user's own display name and language
+func (s *userService) UpdateProfile(ctx context.Context, userID primitive.ObjectID, req UpdateProfileRequest) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || utf8.RuneCountInString(name) > maxUserNameLength {
+			return nil, fmt.Errorf("%w: name must be between 1 and %d characters", ErrInvalidProfile, maxUserNameLength)
+		}
+		user.Name = name
+	}
+	if req.Language != nil {
+		language := strings.ToLower(strings.TrimSpace(*req.Language))
+		if !models.IsSupportedLanguage(language) {
+			return nil, fmt.Errorf("%w: language must be one of %s", ErrInvalidProfile, strings.Join(models.SupportedLanguages, ", "))
+		}
+		user.Language = language
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return user, nil
+}
+
+// RequestEmailChange sends a verification link to the new address
+// #BUSINESS_RULE: The email is only changed once the link sent to the new address is opened;
+// a new request supersedes any pending one
+// #SECURITY_ASSUMPTION: Uniqueness is checked here for a clear error and again on confirmation,
+// where the unique email index is the final guard
+func (s *userService) RequestEmailChange(ctx context.Context, userID primitive.ObjectID, newEmail string) error {
+	email := models.NormalizeEmail(newEmail)
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if email == user.Email {
+		return ErrEmailUnchanged
+	}
+
+	existing, err := s.userRepo.GetByEmail(ctx, email)
+	if err == nil && existing != nil {
+		return ErrUserAlreadyExists
+	}
+
+	count, err := s.secureLinkRepo.CountRecentByEmail(ctx, email, emailChangeRateLimitMins)
+	if err != nil {
+		return fmt.Errorf("failed to check rate limit: %w", err)
+	}
+	if count >= emailChangeRateLimitCount {
+		return ErrRateLimitExceeded
+	}
+
+	if err := s.secureLinkRepo.InvalidateAllForUser(ctx, user.ID, models.SecureLinkTypeEmailChange); err != nil {
+		return fmt.Errorf("failed to invalidate pending email changes: %w", err)
+	}
+
+	identifier, err := generateSecureIdentifier()
+	if err != nil {
+		return fmt.Errorf("failed to generate secure identifier: %w", err)
+	}
+
+	link := &models.SecureLink{
+		SecureIdentifier: identifier,
+		Type:             models.SecureLinkTypeEmailChange,
+		Email:            email,
+		UserID:           &user.ID,
+	}
+	link.BeforeCreate()
+
+	if err := s.secureLinkRepo.Create(ctx, link); err != nil {
+		return fmt.Errorf("failed to create secure link: %w", err)
+	}
+
+	verifyURL := fmt.Sprintf("%s/auth/verify-email/%s", s.magicLinkBase, identifier)
+	mailCtx := WithMailOrigin(ctx, MailOrigin{OrganizationID: user.OrganizationID})
+	if _, err := s.mailService.SendEmailChangeVerification(mailCtx, email, user.Name, verifyURL, user.Language); err != nil {
+		return fmt.Errorf("failed to send email change verification: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange redeems an email change link and updates the user's email
+// #SECURITY_ASSUMPTION: Sign-in links sent to the old address are revoked and the old address
+// is notified so an unexpected change is noticed
+func (s *userService) ConfirmEmailChange(ctx context.Context, identifier string) (*models.User, error) {
+	link, err := s.secureLinkRepo.GetByIdentifier(ctx, identifier)
+	if err != nil || link == nil || !link.CanBeUsed() || !link.IsEmailChangeLink() || link.UserID == nil {
+		return nil, ErrInvalidSecureLink
+	}
+
+	// Mark as used immediately to prevent race conditions
+	if err := s.secureLinkRepo.MarkAsUsed(ctx, link.ID); err != nil {
+		return nil, fmt.Errorf("failed to mark link as used: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, *link.UserID)
+	if err != nil || user == nil {
+		return nil, ErrUserNotFound
+	}
+	if !user.IsActive || user.IsDeleted() {
+		return nil, ErrUserInactive
+	}
+
+	oldEmail := user.Email
+	user.Email = link.Email
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		if errors.Is(err, models.ErrEmailAlreadyExists) {
+			return nil, ErrUserAlreadyExists
+		}
+		if errors.Is(err, models.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	//nolint:errcheck // Best-effort cleanup
+	s.secureLinkRepo.InvalidateAllForEmail(ctx, oldEmail)
+
+	// The change is already applied, so a notice that cannot be queued is only logged
+	if err := s.mailOutbox.EnqueueEmailChangeNotice(ctx, user.OrganizationID, oldEmail, user.Name, user.Email, user.Language); err != nil {
+		log.Printf("Failed to queue email change notice to %s: %v", oldEmail, err)
+	}
+
+	return user, nil
+}
+
+// GetNotificationPreferences returns the user whose notification preferences are requested
+func (s *userService) GetNotificationPreferences(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+// UpdateNotificationPreferences enables or disables notification kinds for the user
+// #BUSINESS_RULE: Non-optional kinds may be sent as enabled but never disabled; the whole
+// update is rejected before anything is changed
+func (s *userService) UpdateNotificationPreferences(ctx context.Context, userID primitive.ObjectID, changes map[models.NotificationKind]bool) (*models.User, error) {
+	for kind, enabled := range changes {
+		if !kind.IsValid() {
+			return nil, fmt.Errorf("%w: unknown notification %q", ErrInvalidNotificationPreference, kind)
+		}
+		if !enabled && !kind.IsOptional() {
+			return nil, fmt.Errorf("%w: %s notifications cannot be disabled", ErrInvalidNotificationPreference, kind)
+		}
+	}
+
+	user, err := s.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, kind := range models.NotificationKinds {
+		if enabled, ok := changes[kind]; ok && kind.IsOptional() {
+			user.SetNotification(kind, enabled)
+		}
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return user, nil
+}
+
+// RemoveUser soft deletes a user from the organization
+// #BUSINESS_RULE: An organization must always keep at least one active admin
+// #SECURITY_ASSUMPTION: All of the user's access and refresh tokens are revoked on removal
+func (s *userService) RemoveUser(ctx context.Context, orgID, userID primitive.ObjectID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Verify organization ownership
+	if user.OrganizationID != orgID {
+		return ErrUserNotFound
+	}
+
+	if user.IsAdmin() && user.IsActive {
+		admins, countErr := s.userRepo.CountByOrganizationAndRole(ctx, orgID, models.UserRoleAdmin)
+		if countErr != nil {
+			return fmt.Errorf("failed to count admins: %w", countErr)
+		}
+		if admins <= 1 {
+			return ErrLastAdmin
+		}
+	}
+
+	if err := s.userRepo.SoftDelete(ctx, userID); err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	// Revoke issued tokens so the removed user is signed out immediately
+	if err := s.authService.RevokeUserTokens(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
+
+	// Revoke outstanding sign-in links for the removed user
+	//nolint:errcheck // Best-effort cleanup
+	s.secureLinkRepo.InvalidateAllForEmail(ctx, user.Email)
+
+	return nil
+}
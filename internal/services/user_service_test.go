@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// memoryUserRepo keeps users by ID; other methods are not used
+type memoryUserRepo struct {
+	repository.UserRepository
+	users map[primitive.ObjectID]*models.User
+}
+
+func (r *memoryUserRepo) GetByID(_ context.Context, id primitive.ObjectID) (*models.User, error) {
+	if user, ok := r.users[id]; ok {
+		clone := *user
+		return &clone, nil
+	}
+	return nil, models.ErrUserNotFound
+}
+
+func (r *memoryUserRepo) GetByEmail(_ context.Context, email string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, models.ErrUserNotFound
+}
+
+func (r *memoryUserRepo) Update(_ context.Context, user *models.User) error {
+	clone := *user
+	r.users[user.ID] = &clone
+	return nil
+}
+
+func (r *memoryUserRepo) Create(_ context.Context, user *models.User) error {
+	user.BeforeCreate()
+	clone := *user
+	r.users[user.ID] = &clone
+	return nil
+}
+
+func (r *memoryUserRepo) CountByOrganizationAndRole(_ context.Context, orgID primitive.ObjectID, role models.UserRole) (int64, error) {
+	var count int64
+	for _, user := range r.users {
+		if user.OrganizationID == orgID && user.Role == role && user.IsActive {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryUserRepo) UpdateLastLogin(_ context.Context, _ primitive.ObjectID) error {
+	return nil
+}
+
+func (r *memoryUserRepo) SoftDelete(_ context.Context, id primitive.ObjectID) error {
+	user, ok := r.users[id]
+	if !ok {
+		return models.ErrUserNotFound
+	}
+	user.IsActive = false
+	return nil
+}
+
+func (r *recordingSecureLinkRepo) GetByIdentifier(_ context.Context, identifier string) (*models.SecureLink, error) {
+	for _, link := range r.links {
+		if link.SecureIdentifier == identifier {
+			return link, nil
+		}
+	}
+	return nil, models.ErrSecureLinkNotFound
+}
+
+func (r *recordingSecureLinkRepo) MarkAsUsed(_ context.Context, id primitive.ObjectID) error {
+	for _, link := range r.links {
+		if link.ID == id {
+			link.MarkAsUsed()
+		}
+	}
+	return nil
+}
+
+func (r *recordingSecureLinkRepo) InvalidateAllForUser(_ context.Context, userID primitive.ObjectID, linkType models.SecureLinkType) error {
+	for _, link := range r.links {
+		if link.UserID != nil && *link.UserID == userID && link.Type == linkType {
+			link.Invalidate()
+		}
+	}
+	return nil
+}
+
+// recordingEmailChangeMailer records email change mails; other methods are not used
+type recordingEmailChangeMailer struct {
+	MailService
+	verifications []string
+	notices       []string
+}
+
+func (m *recordingEmailChangeMailer) SendEmailChangeVerification(_ context.Context, email, _, _, _ string) (string, error) {
+	m.verifications = append(m.verifications, email)
+	return "", nil
+}
+
+func (m *recordingEmailChangeMailer) SendEmailChangeNotice(_ context.Context, email, _, _, _ string) (string, error) {
+	m.notices = append(m.notices, email)
+	return "", nil
+}
+
+// recordingTeamInvitationMailer records team invitations; other methods are not used
+type recordingTeamInvitationMailer struct {
+	MailService
+	invitations []string
+}
+
+func (m *recordingTeamInvitationMailer) SendTeamInvitation(_ context.Context, email, _, organizationName, _, _ string) (string, error) {
+	m.invitations = append(m.invitations, email+" "+organizationName)
+	return "", nil
+}
+
+// recordingRevokeAuthService records token revocations; other methods are not used
+type recordingRevokeAuthService struct {
+	AuthService
+	revoked []primitive.ObjectID
+}
+
+func (a *recordingRevokeAuthService) RevokeUserTokens(_ context.Context, userID primitive.ObjectID) error {
+	a.revoked = append(a.revoked, userID)
+	return nil
+}
+
+func TestUserService_InviteUser(t *testing.T) {
+	ctx := context.Background()
+	org := &models.Organization{ID: primitive.NewObjectID(), Name: "Acme", Settings: models.OrganizationSettings{DefaultLanguage: "de"}}
+	existing := &models.User{ID: primitive.NewObjectID(), Email: "taken@example.com", OrganizationID: org.ID, IsActive: true}
+	users := &memoryUserRepo{users: map[primitive.ObjectID]*models.User{existing.ID: existing}}
+	orgs := &memoryOrgRepoByID{orgs: map[primitive.ObjectID]*models.Organization{org.ID: org}}
+	links := &recordingSecureLinkRepo{}
+	mailer := &recordingTeamInvitationMailer{}
+	outbox := NewEmailOutboxService(&memoryOutboxRepo{}, nil, mailer)
+	service := NewUserService(users, orgs, links, nil, nil, outbox, "https://app.example.com")
+
+	if _, err := service.InviteUser(ctx, org.ID, InviteUserRequest{Email: " Taken@Example.com"}); !errors.Is(err, ErrUserAlreadyExists) {
+		t.Errorf("Expected ErrUserAlreadyExists, got %v", err)
+	}
+	if _, err := service.InviteUser(ctx, org.ID, InviteUserRequest{Email: "new@example.com", Role: "OWNER"}); !errors.Is(err, ErrInvalidUserRole) {
+		t.Errorf("Expected ErrInvalidUserRole, got %v", err)
+	}
+
+	user, err := service.InviteUser(ctx, org.ID, InviteUserRequest{Email: " New@Example.com", Name: "Nora"})
+	if err != nil {
+		t.Fatalf("InviteUser failed: %v", err)
+	}
+	if user.Email != "new@example.com" || user.Role != models.UserRoleViewer || user.Language != "de" {
+		t.Errorf("Expected a normalized viewer in the organization language, got %+v", user)
+	}
+	if len(links.links) != 1 || links.links[0].Type != models.SecureLinkTypeAuth || *links.links[0].UserID != user.ID {
+		t.Fatalf("Expected one sign-in link for the invitee, got %+v", links.links)
+	}
+	if len(mailer.invitations) != 0 {
+		t.Errorf("Expected the invitation to be queued, got %v sent directly", mailer.invitations)
+	}
+	if _, err := outbox.ProcessDue(ctx); err != nil {
+		t.Fatalf("ProcessDue failed: %v", err)
+	}
+	if len(mailer.invitations) != 1 || mailer.invitations[0] != "new@example.com Acme" {
+		t.Errorf("Expected a team invitation to new@example.com, got %v", mailer.invitations)
+	}
+}
+
+func TestUserService_RemoveUser_LastAdmin(t *testing.T) {
+	ctx := context.Background()
+	orgID := primitive.NewObjectID()
+	admin := &models.User{ID: primitive.NewObjectID(), Email: "admin@example.com", OrganizationID: orgID, Role: models.UserRoleAdmin, IsActive: true}
+	users := &memoryUserRepo{users: map[primitive.ObjectID]*models.User{admin.ID: admin}}
+	auth := &recordingRevokeAuthService{}
+	service := NewUserService(users, nil, &recordingSecureLinkRepo{}, auth, nil, nil, "")
+
+	if err := service.RemoveUser(ctx, orgID, admin.ID); !errors.Is(err, ErrLastAdmin) {
+		t.Fatalf("Expected ErrLastAdmin, got %v", err)
+	}
+	if !users.users[admin.ID].IsActive || len(auth.revoked) != 0 {
+		t.Fatalf("Expected the last admin to be kept")
+	}
+
+	second := &models.User{ID: primitive.NewObjectID(), Email: "second@example.com", OrganizationID: orgID, Role: models.UserRoleAdmin, IsActive: true}
+	users.users[second.ID] = second
+	if err := service.RemoveUser(ctx, orgID, admin.ID); err != nil {
+		t.Fatalf("RemoveUser failed: %v", err)
+	}
+	if users.users[admin.ID].IsActive || len(auth.revoked) != 1 || auth.revoked[0] != admin.ID {
+		t.Errorf("Expected the admin to be removed and signed out")
+	}
+
+	// The remaining admin is the last one again
+	if err := service.RemoveUser(ctx, orgID, second.ID); !errors.Is(err, ErrLastAdmin) {
+		t.Errorf("Expected ErrLastAdmin, got %v", err)
+	}
+	if err := service.RemoveUser(ctx, primitive.NewObjectID(), second.ID); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound for another organization, got %v", err)
+	}
+}
+
+func TestUserService_EmailChange(t *testing.T) {
+	ctx := context.Background()
+	user := &models.User{ID: primitive.NewObjectID(), Email: "old@example.com", IsActive: true}
+	other := &models.User{ID: primitive.NewObjectID(), Email: "taken@example.com", IsActive: true}
+	users := &memoryUserRepo{users: map[primitive.ObjectID]*models.User{user.ID: user, other.ID: other}}
+	links := &recordingSecureLinkRepo{}
+	mailer := &recordingEmailChangeMailer{}
+	outbox := NewEmailOutboxService(&memoryOutboxRepo{}, nil, mailer)
+	service := NewUserService(users, nil, links, nil, mailer, outbox, "https://app.example.com")
+
+	if err := service.RequestEmailChange(ctx, user.ID, " Taken@Example.com "); !errors.Is(err, ErrUserAlreadyExists) {
+		t.Errorf("Expected ErrUserAlreadyExists, got %v", err)
+	}
+	if err := service.RequestEmailChange(ctx, user.ID, "OLD@example.com"); !errors.Is(err, ErrEmailUnchanged) {
+		t.Errorf("Expected ErrEmailUnchanged, got %v", err)
+	}
+
+	if err := service.RequestEmailChange(ctx, user.ID, "first@example.com"); err != nil {
+		t.Fatalf("RequestEmailChange failed: %v", err)
+	}
+	if err := service.RequestEmailChange(ctx, user.ID, " New@Example.com"); err != nil {
+		t.Fatalf("RequestEmailChange failed: %v", err)
+	}
+	if len(links.links) != 2 || links.links[1].Email != "new@example.com" || !links.links[1].IsEmailChangeLink() {
+		t.Fatalf("Expected a normalized email change link, got %+v", links.links)
+	}
+	if users.users[user.ID].Email != "old@example.com" {
+		t.Errorf("Email changed before verification")
+	}
+
+	// The newer request supersedes the first one
+	if _, err := service.ConfirmEmailChange(ctx, links.links[0].SecureIdentifier); !errors.Is(err, ErrInvalidSecureLink) {
+		t.Errorf("Expected superseded link to be rejected, got %v", err)
+	}
+
+	updated, err := service.ConfirmEmailChange(ctx, links.links[1].SecureIdentifier)
+	if err != nil {
+		t.Fatalf("ConfirmEmailChange failed: %v", err)
+	}
+	if updated.Email != "new@example.com" || users.users[user.ID].Email != "new@example.com" {
+		t.Errorf("Expected email to be changed, got %q", users.users[user.ID].Email)
+	}
+	if _, err := outbox.ProcessDue(ctx); err != nil {
+		t.Fatalf("ProcessDue failed: %v", err)
+	}
+	if len(mailer.notices) != 1 || mailer.notices[0] != "old@example.com" {
+		t.Errorf("Expected the old address to be notified, got %v", mailer.notices)
+	}
+
+	if _, err := service.ConfirmEmailChange(ctx, links.links[1].SecureIdentifier); !errors.Is(err, ErrInvalidSecureLink) {
+		t.Errorf("Expected a used link to be rejected, got %v", err)
+	}
+
+	// Email change links cannot be used to sign in
+	auth := NewAuthService(users, nil, links, nil, nil, nil, mailer, AuthServiceConfig{})
+	pending := &models.SecureLink{SecureIdentifier: "pending", Type: models.SecureLinkTypeEmailChange, Email: "x@example.com", UserID: &user.ID}
+	pending.BeforeCreate()
+	links.links = append(links.links, pending)
+	if _, _, _, err := auth.VerifyMagicLink(ctx, "pending"); !errors.Is(err, ErrInvalidSecureLink) {
+		t.Errorf("Expected ErrInvalidSecureLink signing in with an email change link, got %v", err)
+	}
+}
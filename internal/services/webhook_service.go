@@ -0,0 +1,413 @@
+// Package services provides business logic implementations.
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// Custom errors for webhook service
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrInvalidWebhookURL       = errors.New("webhook URL must be an absolute https URL")
+	ErrWebhookURLNotPublic     = errors.New("webhook URL must resolve to a public address")
+	ErrInvalidWebhookEventType = errors.New("invalid webhook event type")
+	ErrTooManyWebhooks         = errors.New("organization has reached the webhook limit")
+)
+
+// Outbound webhook request headers
+const (
+	// WebhookSignatureHeader carries the hex HMAC-SHA256 of the request body, prefixed with "sha256="
+	WebhookSignatureHeader = "X-NisFix-Signature"
+	WebhookEventHeader     = "X-NisFix-Event"
+	WebhookDeliveryHeader  = "X-NisFix-Delivery"
+)
+
+// webhookSecretPrefix marks webhook signing secrets so they are recognizable in secret scanners
+const webhookSecretPrefix = "whsec_"
+
+// defaultWebhookBackoff is the wait before each retry of a failed delivery
+var defaultWebhookBackoff = []time.Duration{5 * time.Second, 30 * time.Second, 2 * time.Minute}
+
+// WebhookDispatcher publishes lifecycle events to an organization's webhooks
+// #INTEGRATION_POINT: Implemented by WebhookService; services emitting events depend only on this
+type WebhookDispatcher interface {
+	// Dispatch delivers an event asynchronously; it never blocks on or fails because of the endpoints
+	Dispatch(ctx context.Context, orgID primitive.ObjectID, event models.WebhookEventType, data any)
+}
+
+// dispatchWebhook publishes an event if a dispatcher is configured
+func dispatchWebhook(ctx context.Context, dispatcher WebhookDispatcher, orgID primitive.ObjectID, event models.WebhookEventType, data any) {
+	if dispatcher == nil {
+		return
+	}
+	dispatcher.Dispatch(ctx, orgID, event, data)
+}
+
+// WebhookService manages outbound webhooks and delivers events to them
+// #INTEGRATION_POINT: Used by webhook handler and, as a WebhookDispatcher, by the lifecycle services
+type WebhookService interface {
+	WebhookDispatcher
+
+	// CreateWebhook registers a webhook and returns it together with the one-time signing secret
+	CreateWebhook(ctx context.Context, orgID, userID primitive.ObjectID, req CreateWebhookRequest) (*models.Webhook, string, error)
+
+	// ListWebhooks lists webhooks of the organization
+	ListWebhooks(ctx context.Context, orgID primitive.ObjectID) ([]models.Webhook, error)
+
+	// DeleteWebhook deletes a webhook of the organization
+	DeleteWebhook(ctx context.Context, orgID, webhookID primitive.ObjectID) error
+
+	// Close cancels pending retries and waits for in-flight deliveries to return
+	Close()
+}
+
+// CreateWebhookRequest represents the request to register a webhook
+type CreateWebhookRequest struct {
+	URL    string                    `json:"url"`
+	Events []models.WebhookEventType `json:"events"`
+}
+
+// WebhookPayload is the JSON body POSTed to webhook endpoints
+type WebhookPayload struct {
+	ID             string                  `json:"id"`
+	Event          models.WebhookEventType `json:"event"`
+	OrganizationID string                  `json:"organization_id"`
+	CreatedAt      time.Time               `json:"created_at"`
+	Data           any                     `json:"data"`
+}
+
+// RequirementWebhookData is the payload data of requirement events
+type RequirementWebhookData struct {
+	RequirementID  string `json:"requirement_id"`
+	RelationshipID string `json:"relationship_id"`
+	SupplierID     string `json:"supplier_id"`
+	Type           string `json:"type"`
+	Title          string `json:"title"`
+	Status         string `json:"status"`
+	Passed         *bool  `json:"passed,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// newRequirementWebhookData builds requirement event data from a requirement
+func newRequirementWebhookData(r *models.Requirement, passed *bool, reason string) RequirementWebhookData {
+	return RequirementWebhookData{
+		RequirementID:  r.ID.Hex(),
+		RelationshipID: r.RelationshipID.Hex(),
+		SupplierID:     r.SupplierID.Hex(),
+		Type:           string(r.Type),
+		Title:          r.Title,
+		Status:         string(r.Status),
+		Passed:         passed,
+		Reason:         reason,
+	}
+}
+
+// RelationshipWebhookData is the payload data of relationship events
+type RelationshipWebhookData struct {
+	RelationshipID string `json:"relationship_id"`
+	SupplierID     string `json:"supplier_id,omitempty"`
+	Status         string `json:"status"`
+}
+
+// webhookService implements WebhookService
+type webhookService struct {
+	webhookRepo repository.WebhookRepository
+	httpClient  *http.Client
+	backoff     []time.Duration
+
+	// ctx outlives requests; it is cancelled by Close
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(webhookRepo repository.WebhookRepository) WebhookService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		httpClient:  newWebhookHTTPClient(),
+		backoff:     defaultWebhookBackoff,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// newWebhookHTTPClient creates the client webhooks are delivered with
+// #SECURITY_ASSUMPTION: Webhook URLs are chosen by customers, so every connection is checked against
+// the resolved address at dial time - this also covers DNS answers that change after registration -
+// and redirects are not followed, since they could point anywhere
+func newWebhookHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicWebhookIP(ip) {
+				return fmt.Errorf("%w: %s", ErrWebhookURLNotPublic, host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConns:        20,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// publicWebhookIP reports whether an address may receive webhooks
+func publicWebhookIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast()
+}
+
+// checkWebhookHost resolves a webhook host and rejects it unless every address is public
+func checkWebhookHost(ctx context.Context, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if !publicWebhookIP(ip) {
+			return ErrWebhookURLNotPublic
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return ErrInvalidWebhookURL
+	}
+	for _, addr := range addrs {
+		if !publicWebhookIP(addr.IP) {
+			return ErrWebhookURLNotPublic
+		}
+	}
+	return nil
+}
+
+// CreateWebhook registers a webhook and returns it together with the one-time signing secret
+// #SECURITY_ASSUMPTION: Only https endpoints are accepted so payloads and signatures are not sent in clear text,
+// and only hosts resolving to public addresses, so webhooks cannot reach internal services
+func (s *webhookService) CreateWebhook(ctx context.Context, orgID, userID primitive.ObjectID, req CreateWebhookRequest) (*models.Webhook, string, error) {
+	endpoint, err := url.Parse(strings.TrimSpace(req.URL))
+	if err != nil || endpoint.Scheme != "https" || endpoint.Hostname() == "" {
+		return nil, "", ErrInvalidWebhookURL
+	}
+	if err := checkWebhookHost(ctx, endpoint.Hostname()); err != nil {
+		return nil, "", err
+	}
+
+	if len(req.Events) == 0 {
+		return nil, "", ErrInvalidWebhookEventType
+	}
+	events := make([]models.WebhookEventType, 0, len(req.Events))
+	seen := make(map[models.WebhookEventType]bool, len(req.Events))
+	for _, event := range req.Events {
+		if !event.IsValid() {
+			return nil, "", ErrInvalidWebhookEventType
+		}
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+
+	count, err := s.webhookRepo.CountByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to count webhooks: %w", err)
+	}
+	if count >= models.MaxWebhooksPerOrganization {
+		return nil, "", ErrTooManyWebhooks
+	}
+
+	secret, err := generateSecureIdentifier()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	secret = webhookSecretPrefix + secret
+
+	webhook := &models.Webhook{
+		OrganizationID: orgID,
+		CreatedBy:      userID,
+		URL:            endpoint.String(),
+		Secret:         secret,
+		Events:         events,
+	}
+	if err := s.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, "", fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return webhook, secret, nil
+}
+
+// ListWebhooks lists webhooks of the organization
+func (s *webhookService) ListWebhooks(ctx context.Context, orgID primitive.ObjectID) ([]models.Webhook, error) {
+	return s.webhookRepo.ListByOrganization(ctx, orgID)
+}
+
+// DeleteWebhook deletes a webhook of the organization
+func (s *webhookService) DeleteWebhook(ctx context.Context, orgID, webhookID primitive.ObjectID) error {
+	if err := s.webhookRepo.Delete(ctx, orgID, webhookID); err != nil {
+		if errors.Is(err, models.ErrWebhookNotFound) {
+			return ErrWebhookNotFound
+		}
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// Dispatch delivers an event asynchronously to every subscribed webhook of the organization
+// #IMPLEMENTATION_DECISION: Deliveries run in background goroutines on the service context rather
+// than the request context, so a slow endpoint never delays the API response that triggered the event
+// #TECHNICAL_DEBT: Pending retries live in memory and are lost when the process restarts
+func (s *webhookService) Dispatch(_ context.Context, orgID primitive.ObjectID, event models.WebhookEventType, data any) {
+	ctx := s.ctx
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		webhooks, err := s.webhookRepo.ListSubscribed(ctx, orgID, event)
+		if err != nil {
+			log.Printf("[WEBHOOK] Failed to list webhooks for %s: %v", event, err)
+			return
+		}
+		if len(webhooks) == 0 {
+			return
+		}
+
+		payload := WebhookPayload{
+			ID:             primitive.NewObjectID().Hex(),
+			Event:          event,
+			OrganizationID: orgID.Hex(),
+			CreatedAt:      time.Now().UTC(),
+			Data:           data,
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			log.Printf("[WEBHOOK] Failed to encode %s payload: %v", event, err)
+			return
+		}
+
+		for i := range webhooks {
+			s.wg.Add(1)
+			go func(webhook models.Webhook) {
+				defer s.wg.Done()
+				s.deliver(ctx, &webhook, payload, body)
+			}(webhooks[i])
+		}
+	}()
+}
+
+// Close cancels pending retries and waits for in-flight deliveries to return
+func (s *webhookService) Close() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// deliver POSTs a payload to one webhook, retrying with backoff, and records the final outcome
+// #BUSINESS_RULE: Network errors, 408, 429 and 5xx responses are retried; other 4xx responses
+// mean the endpoint rejected the payload and are not retried
+func (s *webhookService) deliver(ctx context.Context, webhook *models.Webhook, payload WebhookPayload, body []byte) {
+	signature := signWebhookPayload(webhook.Secret, body)
+
+	var result models.WebhookDeliveryResult
+	for attempt := 0; ; attempt++ {
+		result = s.attemptDelivery(ctx, webhook.URL, payload, body, signature)
+		if result.Succeeded() || !retryableWebhookResult(result) || attempt >= len(s.backoff) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.backoff[attempt]):
+		}
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
+	if !result.Succeeded() {
+		log.Printf("[WEBHOOK] Delivery of %s to webhook %s failed: status=%d error=%s",
+			payload.Event, webhook.ID.Hex(), result.StatusCode, result.Error)
+	}
+	if err := s.webhookRepo.RecordDelivery(ctx, webhook.ID, result); err != nil {
+		log.Printf("[WEBHOOK] Failed to record delivery for webhook %s: %v", webhook.ID.Hex(), err)
+	}
+}
+
+// attemptDelivery performs a single signed POST
+func (s *webhookService) attemptDelivery(ctx context.Context, endpoint string, payload WebhookPayload, body []byte, signature string) models.WebhookDeliveryResult {
+	result := models.WebhookDeliveryResult{DeliveredAt: time.Now().UTC()}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSignatureHeader, signature)
+	req.Header.Set(WebhookEventHeader, string(payload.Event))
+	req.Header.Set(WebhookDeliveryHeader, payload.ID)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer resp.Body.Close() //nolint:errcheck // defer close
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		result.Error = fmt.Sprintf("endpoint returned status %d", resp.StatusCode)
+	}
+	return result
+}
+
+// retryableWebhookResult returns true if a failed delivery may succeed when retried
+func retryableWebhookResult(result models.WebhookDeliveryResult) bool {
+	switch {
+	case result.StatusCode == 0:
+		return true
+	case result.StatusCode == http.StatusRequestTimeout, result.StatusCode == http.StatusTooManyRequests:
+		return true
+	default:
+		return result.StatusCode >= 500
+	}
+}
+
+// signWebhookPayload returns the signature header value for a payload
+func signWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
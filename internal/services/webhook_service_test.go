@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/checkfix-tools/nisfix_backend/internal/models"
+	"github.com/checkfix-tools/nisfix_backend/internal/repository"
+)
+
+// memoryWebhookRepo serves a fixed set of webhooks and records deliveries
+type memoryWebhookRepo struct {
+	repository.WebhookRepository
+	webhooks   []models.Webhook
+	mu         sync.Mutex
+	deliveries []models.WebhookDeliveryResult
+}
+
+func (r *memoryWebhookRepo) ListSubscribed(_ context.Context, orgID primitive.ObjectID, event models.WebhookEventType) ([]models.Webhook, error) {
+	var matched []models.Webhook
+	for _, w := range r.webhooks {
+		if w.OrganizationID == orgID && w.Subscribes(event) {
+			matched = append(matched, w)
+		}
+	}
+	return matched, nil
+}
+
+func (r *memoryWebhookRepo) RecordDelivery(_ context.Context, _ primitive.ObjectID, result models.WebhookDeliveryResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries = append(r.deliveries, result)
+	return nil
+}
+
+func TestWebhookService_DispatchSignsAndRetries(t *testing.T) {
+	const secret = "whsec_test"
+	var (
+		mu       sync.Mutex
+		attempts int
+		bodies   [][]byte
+		sigs     []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		bodies = append(bodies, body)
+		sigs = append(sigs, r.Header.Get(WebhookSignatureHeader))
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	orgID := primitive.NewObjectID()
+	repo := &memoryWebhookRepo{webhooks: []models.Webhook{
+		{ID: primitive.NewObjectID(), OrganizationID: orgID, URL: server.URL, Secret: secret,
+			Events: []models.WebhookEventType{models.WebhookEventRequirementApproved}},
+		{ID: primitive.NewObjectID(), OrganizationID: orgID, URL: server.URL, Secret: secret,
+			Events: []models.WebhookEventType{models.WebhookEventRelationshipAccepted}},
+	}}
+	service := NewWebhookService(repo).(*webhookService)
+	service.backoff = []time.Duration{time.Millisecond}
+	// The test server listens on loopback, which the delivery client refuses
+	service.httpClient = server.Client()
+
+	service.Dispatch(context.Background(), orgID, models.WebhookEventRequirementApproved, map[string]string{"requirement_id": "r1"})
+	service.wg.Wait()
+
+	if attempts != 2 {
+		t.Fatalf("Expected 2 attempts (one retry), got %d", attempts)
+	}
+	for i, body := range bodies {
+		if want := signWebhookPayload(secret, body); sigs[i] != want {
+			t.Errorf("Attempt %d: signature %q does not match body, want %q", i, sigs[i], want)
+		}
+	}
+	if len(repo.deliveries) != 1 || !repo.deliveries[0].Succeeded() || repo.deliveries[0].StatusCode != http.StatusNoContent {
+		t.Errorf("Expected one successful recorded delivery, got %+v", repo.deliveries)
+	}
+}
+
+func TestRetryableWebhookResult(t *testing.T) {
+	tests := []struct {
+		status int
+		want   bool
+	}{
+		{0, true},
+		{http.StatusTooManyRequests, true},
+		{http.StatusBadGateway, true},
+		{http.StatusBadRequest, false},
+		{http.StatusGone, false},
+	}
+	for _, tt := range tests {
+		if got := retryableWebhookResult(models.WebhookDeliveryResult{StatusCode: tt.status}); got != tt.want {
+			t.Errorf("retryableWebhookResult(%d) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookService_CreateWebhookRejectsInternalHosts(t *testing.T) {
+	service := NewWebhookService(&memoryWebhookRepo{}).(*webhookService)
+	defer service.Close()
+
+	for _, endpoint := range []string{
+		"https://localhost/hook",
+		"https://127.0.0.1/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://10.0.0.5/hook",
+		"https://192.168.1.10:8443/hook",
+		"https://[::1]/hook",
+		"https://0.0.0.0/hook",
+	} {
+		_, _, err := service.CreateWebhook(context.Background(), primitive.NewObjectID(), primitive.NewObjectID(), CreateWebhookRequest{
+			URL:    endpoint,
+			Events: []models.WebhookEventType{models.WebhookEventRequirementApproved},
+		})
+		if !errors.Is(err, ErrWebhookURLNotPublic) {
+			t.Errorf("%s: expected ErrWebhookURLNotPublic, got %v", endpoint, err)
+		}
+	}
+}
+
+func TestPublicWebhookIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"172.16.0.1", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"::", false},
+	}
+	for _, tt := range tests {
+		if got := publicWebhookIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("publicWebhookIP(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookHTTPClient_RefusesInternalAddresses(t *testing.T) {
+	// A host that passed registration but now resolves to loopback is refused when connecting
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	resp, err := newWebhookHTTPClient().Post(server.URL, "application/json", http.NoBody)
+	if err == nil {
+		resp.Body.Close() //nolint:errcheck // test cleanup
+		t.Fatal("Expected the loopback connection to be refused")
+	}
+	if !errors.Is(err, ErrWebhookURLNotPublic) {
+		t.Errorf("Expected ErrWebhookURLNotPublic, got %v", err)
+	}
+}
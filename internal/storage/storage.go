@@ -0,0 +1,123 @@
+// Package storage persists uploaded files outside the database.
+// #IMPLEMENTATION_DECISION: Files are addressed by opaque keys chosen by the caller; metadata
+// (filename, content type, uploader) lives on the owning document in MongoDB
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Storage errors
+var (
+	ErrFileNotFound = errors.New("file not found")
+	ErrInvalidKey   = errors.New("invalid file key")
+)
+
+// FileStorage stores and retrieves file contents by key
+// #INTEGRATION_POINT: Local disk today; an object store (S3, GCS) can implement the same interface
+type FileStorage interface {
+	// Save writes the reader's contents under key, replacing any existing file, and returns the byte count
+	Save(ctx context.Context, key string, r io.Reader) (int64, error)
+
+	// Open returns the contents stored under key; the caller must close it
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the file stored under key; deleting a missing file is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// LocalFileStorage stores files in a directory on the local filesystem
+// #TECHNICAL_DEBT: Not shared between replicas - multi-replica deployments need a shared volume
+type LocalFileStorage struct {
+	baseDir string
+}
+
+// NewLocalFileStorage creates a local file storage rooted at baseDir, creating it if needed
+func NewLocalFileStorage(baseDir string) (*LocalFileStorage, error) {
+	if err := os.MkdirAll(baseDir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &LocalFileStorage{baseDir: baseDir}, nil
+}
+
+// Save writes the file to a temporary name first so readers never see a partial file
+func (s *LocalFileStorage) Save(_ context.Context, key string, r io.Reader) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // no-op after a successful rename
+
+	n, err := io.Copy(tmp, r)
+	if err != nil {
+		tmp.Close() //nolint:errcheck // already failing
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// Open opens the file stored under key
+func (s *LocalFileStorage) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrFileNotFound
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+// Delete removes the file stored under key
+func (s *LocalFileStorage) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path maps a key to a file path inside baseDir
+// #SECURITY_ASSUMPTION: Keys are relative slash-separated paths; anything that could escape
+// baseDir (absolute paths, "..", empty segments) is rejected
+func (s *LocalFileStorage) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", ErrInvalidKey
+		}
+	}
+	return filepath.Join(s.baseDir, filepath.FromSlash(key)), nil
+}
+
+// Ensure LocalFileStorage implements FileStorage
+var _ FileStorage = (*LocalFileStorage)(nil)
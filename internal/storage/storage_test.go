@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocalFileStorage_SaveOpenDelete(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLocalFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalFileStorage failed: %v", err)
+	}
+
+	n, err := s.Save(ctx, "requirements/abc/file1", strings.NewReader("policy"))
+	if err != nil || n != 6 {
+		t.Fatalf("Save returned %d, %v", n, err)
+	}
+
+	f, err := s.Open(ctx, "requirements/abc/file1")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	content, _ := io.ReadAll(f)
+	f.Close()
+	if string(content) != "policy" {
+		t.Errorf("Expected stored content, got %q", content)
+	}
+
+	if err := s.Delete(ctx, "requirements/abc/file1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := s.Open(ctx, "requirements/abc/file1"); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("Expected ErrFileNotFound after delete, got %v", err)
+	}
+}
+
+func TestLocalFileStorage_RejectsEscapingKeys(t *testing.T) {
+	s, err := NewLocalFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalFileStorage failed: %v", err)
+	}
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "a/../../b", "a//b", `a\b`} {
+		if _, err := s.Save(context.Background(), key, strings.NewReader("x")); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Expected ErrInvalidKey for %q, got %v", key, err)
+		}
+	}
+}